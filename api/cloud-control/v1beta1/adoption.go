@@ -0,0 +1,31 @@
+package v1beta1
+
+const (
+	ConditionTypeAdopted = "Adopted"
+
+	ReasonAdopted        = "Adopted"
+	ReasonAdoptionFailed = "AdoptionFailed"
+)
+
+// +kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
+const (
+	DeletionPolicyRetain = DeletionPolicy("Retain")
+	DeletionPolicyDelete = DeletionPolicy("Delete")
+)
+
+// AdoptOptions configures the adoption of an already existing cloud resource instead of creating
+// a new one. The resource is adopted only on the first reconciliation, before anything is created.
+type AdoptOptions struct {
+	// MatchTags the existing cloud resource must have, all of them with the same values
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	MatchTags map[string]string `json:"matchTags"`
+
+	// DeletionPolicy of the adopted cloud resource. With Retain the adopted resource is left in place
+	// when the object is deleted, with Delete it is deleted as if it was created by cloud-manager.
+	// +optional
+	// +kubebuilder:default=Retain
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
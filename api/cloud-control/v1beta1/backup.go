@@ -0,0 +1,74 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeBackupCopyFailed is set when the copies of the backups to the copyToRegion fail repeatedly
+	ConditionTypeBackupCopyFailed = "BackupCopyFailed"
+
+	// ConditionTypeBackupConfigurationFailed is set while the backups can not be configured, without
+	// affecting the readiness of the backed up resource
+	ConditionTypeBackupConfigurationFailed = "BackupConfigurationFailed"
+
+	ReasonInvalidSchedule         = "InvalidSchedule"
+	ReasonScheduledBackupFailed   = "ScheduledBackupFailed"
+	ReasonBackupCopyFailed        = "BackupCopyFailed"
+	ReasonInvalidBackupCopyRegion = "InvalidBackupCopyRegion"
+	ReasonBackupPlanFailed        = "BackupPlanFailed"
+
+	ReasonBackupConfigurationFailed = "BackupConfigurationFailed"
+)
+
+// BackupOptions configures the scheduled backups of the cloud resource. The type is shared by the
+// providers, and it's currently implemented for the GCP Filestore. The AWS EFS implements only the
+// copyToRegion.
+type BackupOptions struct {
+	// Enabled turns the scheduled backups on. When disabled the schedule is removed, and the
+	// already created backups are kept.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Schedule of the backups, either a cron expression like "0 2 * * *", or an interval of
+	// at least one hour like "12h"
+	// +optional
+	// +kubebuilder:default="0 2 * * *"
+	Schedule string `json:"schedule,omitempty"`
+
+	// RetentionDays is the number of days the scheduled backups are kept, except the latest one
+	// that is always kept
+	// +optional
+	// +kubebuilder:default=7
+	// +kubebuilder:validation:Minimum=1
+	RetentionDays int `json:"retentionDays,omitempty"`
+
+	// Location the backups are created in. If empty, the region of the scope is used.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// CopyToRegion is the region the backups are copied to for the disaster recovery, with the same
+	// retention. Currently supported only by AWS, where the backups are created on the schedule only
+	// to be copied.
+	// +optional
+	CopyToRegion string `json:"copyToRegion,omitempty"`
+}
+
+// BackupStatus is the observed state of the scheduled backups
+type BackupStatus struct {
+	// Name of the latest completed backup
+	// +optional
+	LatestBackupName string `json:"latestBackupName,omitempty"`
+
+	// Time the latest completed backup was created
+	// +optional
+	LatestBackupTime *metav1.Time `json:"latestBackupTime,omitempty"`
+
+	// Time the next backup is due
+	// +optional
+	NextBackupTime *metav1.Time `json:"nextBackupTime,omitempty"`
+
+	// ARN of the backup vault in the copyToRegion the backups are copied to
+	// +optional
+	CopyDestinationVaultArn string `json:"copyDestinationVaultArn,omitempty"`
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CloudResourceInventorySpec defines the desired state of CloudResourceInventory
+type CloudResourceInventorySpec struct {
+	// MinUpdateInterval is the minimum time between the status updates, the resource transitions
+	// happening in between are aggregated into the next update. If not specified, it is 30s.
+	// +optional
+	MinUpdateInterval *metav1.Duration `json:"minUpdateInterval,omitempty"`
+}
+
+// CloudResourceInventoryStatus defines the observed state of CloudResourceInventory
+type CloudResourceInventoryStatus struct {
+	// Total is the number of all managed resources
+	// +optional
+	Total int `json:"total"`
+
+	// Scopes are the resource counts of each Scope
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=scope
+	Scopes []CloudResourceInventoryScope `json:"scopes,omitempty"`
+
+	// LastUpdateTime is the time the status was last updated
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// List of status conditions
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CloudResourceInventoryScope is the count of the managed resources of a Scope
+type CloudResourceInventoryScope struct {
+	// Namespace of the Scope the resources belong to
+	Namespace string `json:"namespace"`
+
+	// Scope is the name of the Scope the resources belong to
+	Scope string `json:"scope"`
+
+	// Provider of the Scope, empty if the Scope does not exist
+	// +optional
+	Provider ProviderType `json:"provider,omitempty"`
+
+	// Kinds are the resource counts of each kind
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	Kinds []CloudResourceInventoryKind `json:"kinds,omitempty"`
+}
+
+// CloudResourceInventoryKind is the count of the managed resources of a kind in a Scope
+type CloudResourceInventoryKind struct {
+	Kind string `json:"kind"`
+
+	// Count is the number of the resources
+	Count int `json:"count"`
+
+	// States are the number of the resources in each status state, Unknown for those without a state
+	// +optional
+	States map[string]int `json:"states,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+
+// CloudResourceInventory is the Schema for the cloudresourceinventories API
+type CloudResourceInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudResourceInventorySpec   `json:"spec,omitempty"`
+	Status CloudResourceInventoryStatus `json:"status,omitempty"`
+}
+
+func (in *CloudResourceInventory) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *CloudResourceInventory) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+func (in *CloudResourceInventory) CloneForPatchStatus() client.Object {
+	return &CloudResourceInventory{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CloudResourceInventory",
+			APIVersion: GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: in.Name,
+		},
+		Status: in.Status,
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// CloudResourceInventoryList contains a list of CloudResourceInventory
+type CloudResourceInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudResourceInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudResourceInventory{}, &CloudResourceInventoryList{})
+}
@@ -0,0 +1,25 @@
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ConditionHistoryEntry is a compact record of a condition status transition
+type ConditionHistoryEntry struct {
+	Type string `json:"type"`
+
+	Status metav1.ConditionStatus `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Time of the transition
+	Time metav1.Time `json:"time"`
+}
+
+// appendConditionHistory appends the entry and drops the oldest entries above the limit
+func appendConditionHistory(history []ConditionHistoryEntry, entry ConditionHistoryEntry, limit int) []ConditionHistoryEntry {
+	history = append(history, entry)
+	if limit > 0 && len(history) > limit {
+		history = append([]ConditionHistoryEntry(nil), history[len(history)-limit:]...)
+	}
+	return history
+}
@@ -6,7 +6,24 @@ const (
 
 	ConditionTypeUpdating = "Updating"
 
+	ConditionTypeForeignFinalizerBlocking = "ForeignFinalizerBlocking"
+
+	ConditionTypeScopeMissing      = "ScopeMissing"
+	ConditionTypeScopeRefForbidden = "ScopeRefForbidden"
+
+	ConditionTypeInsufficientZones = "InsufficientZones"
+
+	ConditionTypeEndpointReachable = "EndpointReachable"
+
 	ReasonScopeNotFound = "ScopeNoFound"
+	ReasonScopeDeleting = "ScopeDeleting"
+
+	ReasonScopeRefForbidden = "ScopeRefForbidden"
+
+	ReasonInsufficientZones = "InsufficientZones"
+
+	ReasonEndpointReachable   = "EndpointReachable"
+	ReasonEndpointUnreachable = "EndpointUnreachable"
 
 	ReasonUnknown           = "Unknown"
 	ReasonReady             = "Ready"
@@ -14,4 +31,11 @@ const (
 	ReasonValidationFailed  = "ValidationFailed"
 	ReasonMissingDependency = "MissingDependency"
 	ReasonWaitingDependency = "WaitingDependency"
+	ReasonInvalidTag        = "InvalidTag"
+
+	ReasonForeignFinalizerBlocking = "ForeignFinalizerBlocking"
+
+	// ReasonNameCollision is the reason of the Error condition when the name the cloud resource must be
+	// created with is already used by a resource not owned by cloud-manager
+	ReasonNameCollision = "NameCollision"
 )
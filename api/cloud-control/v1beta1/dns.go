@@ -0,0 +1,43 @@
+package v1beta1
+
+const (
+	ConditionTypeDnsRecordFailed = "DnsRecordFailed"
+
+	ReasonDnsRecordFailed = "DnsRecordFailed"
+)
+
+// DnsOptions define the DNS record pointing at the resource endpoint
+type DnsOptions struct {
+	// ZoneName is the name of the private DNS zone the record is created in, the Cloud DNS managed zone
+	// name on GCP and the Route53 hosted zone DNS name on AWS
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ZoneName string `json:"zoneName"`
+
+	// HostedZoneId is the id of the Route53 private hosted zone named ZoneName. Required on AWS.
+	// +optional
+	HostedZoneId string `json:"hostedZoneId,omitempty"`
+
+	// RecordName is the record name relative to the zone DNS name. If empty, the object name is used.
+	// +optional
+	RecordName string `json:"recordName,omitempty"`
+}
+
+// DnsStatus is the observed DNS record of the resource endpoint
+type DnsStatus struct {
+	// Fully qualified domain name of the record
+	// +optional
+	Fqdn string `json:"fqdn,omitempty"`
+
+	// Zone the record is created in
+	// +optional
+	ZoneName string `json:"zoneName,omitempty"`
+
+	// Route53 hosted zone id of the zone the record is created in, on AWS
+	// +optional
+	HostedZoneId string `json:"hostedZoneId,omitempty"`
+
+	// Type of the record, A for the IP endpoints and CNAME for the host endpoints
+	// +optional
+	RecordType string `json:"recordType,omitempty"`
+}
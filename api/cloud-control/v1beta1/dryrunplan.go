@@ -0,0 +1,42 @@
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	DryRunOperationCreate = "Create"
+	DryRunOperationUpdate = "Update"
+	DryRunOperationDelete = "Delete"
+)
+
+// DryRunPlan lists the operations the reconciliation would perform on the cloud resources,
+// computed instead of performing them while the resource is in the dry-run mode
+type DryRunPlan struct {
+	// +optional
+	Operations []DryRunOperation `json:"operations,omitempty"`
+
+	// Number of the planned operations left out of the list over its size limit
+	// +optional
+	Truncated int `json:"truncated,omitempty"`
+
+	// Time the plan was computed
+	Time metav1.Time `json:"time"`
+}
+
+// DryRunOperation is a planned operation on a cloud resource
+type DryRunOperation struct {
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	Operation string `json:"operation"`
+
+	// Type of the cloud resource, for example Subnet
+	ResourceType string `json:"resourceType"`
+
+	// Name or id of the cloud resource, if known before it is created
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	Before string `json:"before,omitempty"`
+
+	// +optional
+	After string `json:"after,omitempty"`
+}
@@ -31,12 +31,79 @@ const (
 	ReasonCidrAssociationFailed          = "CidrAssociationFailed"
 	ReasonCidrAllocationFailed           = "CidrAllocationFailed"
 	ReasonVpcNotFound                    = "VpcNotFound"
+	ReasonVpcNotFoundByTag               = "VpcNotFoundByTag"
+	ReasonMultipleVpcsMatched            = "MultipleVpcsMatched"
 	ReasonShootAndVpcMismatch            = "ShootAndVpcMismatch"
 	ReasonFailedExtendingVpcAddressSpace = "FailedExtendingVpcAddressSpace"
 	ReasonInvalidIpRangeReference        = "InvalidIpRangeReference"
+	ReasonResourceShareNotAllowed        = "ResourceShareNotAllowed"
+	ReasonResourceShareFailed            = "ResourceShareFailed"
+	ReasonIncompleteZoneSubnets          = "IncompleteZoneSubnets"
+	ReasonInvalidZoneSubnets             = "InvalidZoneSubnets"
+	ReasonTgwNotAvailable                = "TgwNotAvailable"
+	ReasonTgwAttachmentFailed            = "TgwAttachmentFailed"
+	ReasonTgwAttachmentPending           = "TgwAttachmentPending"
+	ReasonIpv6EgressConfigured           = "Ipv6EgressConfigured"
+	ReasonIpv6EgressFailed               = "Ipv6EgressFailed"
+	ReasonMultipleCidrsNotSupported      = "MultipleCidrsNotSupported"
+	ReasonDryRunNotSupported             = "DryRunNotSupported"
+	ReasonSubnetHasDependencies          = "SubnetHasDependencies"
+	ReasonSubnetDependenciesTimeout      = "SubnetDependenciesTimeout"
+	ReasonUnknownAllocationStrategy      = "UnknownAllocationStrategy"
+	ReasonIpamPoolExhausted              = "IpamPoolExhausted"
+	ReasonIpamAllocationFailed           = "IpamAllocationFailed"
+	ReasonZoneCapacityUnavailable        = "ZoneCapacityUnavailable"
+	ReasonInvalidPreferredSecondaryCidr  = "InvalidPreferredSecondaryCidr"
+	ReasonInvalidSecondaryRange          = "InvalidSecondaryRange"
+	ReasonSubnetNotFound                 = "SubnetNotFound"
+	ReasonNoFreeCidr                     = "NoFreeCidr"
+	ReasonInvalidAvailabilityZoneIds     = "InvalidAvailabilityZoneIds"
+	ReasonIpamUnavailable                = "IpamUnavailable"
+)
+
+const (
+	// IpRangeAllocationStrategyEven splits each CIDR block evenly by its zones
+	IpRangeAllocationStrategyEven = "Even"
+	// IpRangeAllocationStrategyFixedSizePerZone allocates a subnet of spec.zoneSubnetPrefixLength for each
+	// zone from the start of the CIDR blocks, leaving the rest of the blocks free
+	IpRangeAllocationStrategyFixedSizePerZone = "FixedSizePerZone"
+	// IpRangeAllocationStrategyCustom uses the zone subnets specified in spec.zoneSubnets
+	IpRangeAllocationStrategyCustom = "Custom"
+	// IpRangeAllocationStrategyReservedGrowth reserves spec.reservedCidrRatio of each CIDR block and splits
+	// the rest of it evenly by its zones
+	IpRangeAllocationStrategyReservedGrowth = "ReservedGrowth"
+)
+
+const (
+	ConditionTypeTgwAttachmentPending = "TgwAttachmentPending"
+	// ConditionTypeIpamUnavailable is set while the external IPAM can not be reached to lease or release the cidr
+	ConditionTypeIpamUnavailable      = "IpamUnavailable"
+	ConditionTypeIpv6EgressConfigured = "Ipv6EgressConfigured"
+	// ConditionTypeSubnetHasDependencies is set while the subnet deletion waits for the network interfaces in it to be deleted
+	ConditionTypeSubnetHasDependencies = "SubnetHasDependencies"
+	// ConditionTypeSubnetTagsRestored is set once the lost ownership tags of the subnets recorded in the status are re-applied,
+	// or the untagged subnets of the ranges left by a failed subnet creation are tagged
+	ConditionTypeSubnetTagsRestored = "SubnetTagsRestored"
+	// ConditionTypeForceDeleting is set once the dependencies blocking the subnet deletion are force deleted
+	ConditionTypeForceDeleting = "ForceDeleting"
+	// ConditionTypeZoneCapacityUnavailable is set while the subnet can not be created in its zone due to the zone capacity
+	ConditionTypeZoneCapacityUnavailable = "ZoneCapacityUnavailable"
+
+	ReasonSubnetTagsRestored = "SubnetTagsRestored"
+	ReasonForceDeleting      = "ForceDeleting"
+	ReasonZoneFallback       = "ZoneFallback"
 )
 
 // IpRangeSpec defines the desired state of IpRange
+// +kubebuilder:validation:XValidation:rule=(!has(self.cidr) || self.cidr == "" || !has(self.cidrs) || size(self.cidrs) == 0), message="Only one of cidr and cidrs can be specified."
+// +kubebuilder:validation:XValidation:rule=(!has(self.reservedCidrRatio) || !has(self.zoneSubnets) || size(self.zoneSubnets) == 0), message="ReservedCidrRatio can not be specified with zoneSubnets."
+// +kubebuilder:validation:XValidation:rule=(!has(self.allocationStrategy) || self.allocationStrategy == "Custom" || !has(self.zoneSubnets) || size(self.zoneSubnets) == 0), message="ZoneSubnets can be specified only with the Custom allocationStrategy."
+// +kubebuilder:validation:XValidation:rule=(!has(self.allocationStrategy) || self.allocationStrategy == "ReservedGrowth" || !has(self.reservedCidrRatio)), message="ReservedCidrRatio can be specified only with the ReservedGrowth allocationStrategy."
+// +kubebuilder:validation:XValidation:rule=(!has(self.allocationStrategy) || self.allocationStrategy != "FixedSizePerZone" || has(self.zoneSubnetPrefixLength)), message="ZoneSubnetPrefixLength is required with the FixedSizePerZone allocationStrategy."
+// +kubebuilder:validation:XValidation:rule=(!has(self.ipamPoolId) || ((!has(self.cidr) || self.cidr == "") && (!has(self.cidrs) || size(self.cidrs) == 0))), message="Cidr can not be specified with ipamPoolId."
+// +kubebuilder:validation:XValidation:rule=(!has(self.preferredSecondaryCidr) || self.preferredSecondaryCidr == "" || ((!has(self.cidr) || self.cidr == "") && (!has(self.cidrs) || size(self.cidrs) == 0) && !has(self.ipamPoolId))), message="PreferredSecondaryCidr can not be specified with cidr or ipamPoolId."
+// +kubebuilder:validation:XValidation:rule=(!has(self.cidrSize) || ((!has(self.cidr) || self.cidr == "") && (!has(self.cidrs) || size(self.cidrs) == 0))), message="CidrSize can not be specified with cidr or cidrs."
+// +kubebuilder:validation:XValidation:rule=(!has(self.externalIpam) || ((!has(self.cidr) || self.cidr == "") && (!has(self.cidrs) || size(self.cidrs) == 0) && !has(self.ipamPoolId))), message="ExternalIpam can not be specified with cidr, cidrs or ipamPoolId."
 type IpRangeSpec struct {
 	// +kubebuilder:validation:Required
 	RemoteRef RemoteRef `json:"remoteRef"`
@@ -47,6 +114,21 @@ type IpRangeSpec struct {
 	// +optional
 	Cidr string `json:"cidr"`
 
+	// CidrSize is the prefix length of the cidr allocated when none is specified, for example 24 for
+	// a /24 block. The free block of that size is found in the SKR free address space, the preferred
+	// secondary CIDR or the IPAM pool. If empty, a /22 block is allocated.
+	// +optional
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="CidrSize is immutable."
+	CidrSize int `json:"cidrSize,omitempty"`
+
+	// Cidrs are the non-contiguous CIDR blocks the IpRange is composed of, with the zone subnets
+	// allocated across all of them. Use instead of cidr when more than one block is needed.
+	// +optional
+	// +listType=set
+	Cidrs []string `json:"cidrs,omitempty"`
+
 	// +optional
 	Options IpRangeOptions `json:"options,omitempty"`
 
@@ -54,6 +136,150 @@ type IpRangeSpec struct {
 	// If empty then it's implied that it belongs to the Network of the type "kyma" in its Scope.
 	// +optional
 	Network *klog.ObjectRef `json:"network,omitempty"`
+
+	// ZoneSubnets specifies the CIDR of the subnet in each zone. If specified, it must cover all
+	// zones and each CIDR must be within the IpRange cidr and not overlap with others.
+	// If empty, the cidr is split evenly by zones.
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="ZoneSubnets is immutable."
+	ZoneSubnets []IpRangeZoneSubnet `json:"zoneSubnets,omitempty"`
+
+	// ReservedCidrRatio is the part of each CIDR block reserved for the future zones and subnets.
+	// The reserved block is the end of the CIDR block, it is not split to the zone subnets, and since
+	// it stays within the IpRange CIDR it can not be used by other IpRanges.
+	// +optional
+	// +kubebuilder:validation:Enum="1/2";"1/4";"1/8"
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="ReservedCidrRatio is immutable."
+	ReservedCidrRatio string `json:"reservedCidrRatio,omitempty"`
+
+	// AllocationStrategy selects how the zone subnets are allocated from the CIDR blocks. If empty,
+	// it is Custom when zoneSubnets are specified, ReservedGrowth when reservedCidrRatio is specified,
+	// and Even otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=Even;FixedSizePerZone;Custom;ReservedGrowth
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="AllocationStrategy is immutable."
+	AllocationStrategy string `json:"allocationStrategy,omitempty"`
+
+	// ZoneSubnetPrefixLength is the prefix length of each zone subnet with the FixedSizePerZone allocationStrategy
+	// +optional
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=28
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="ZoneSubnetPrefixLength is immutable."
+	ZoneSubnetPrefixLength int `json:"zoneSubnetPrefixLength,omitempty"`
+
+	// IpamPoolId is the AWS IPAM pool the cidr is allocated from, instead of specifying it
+	// or allocating it from the SKR free address space. The allocation is released on delete.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="IpamPoolId is immutable."
+	IpamPoolId string `json:"ipamPoolId,omitempty"`
+
+	// ExternalIpam is the external IPAM service the cidr is leased from, instead of specifying it or
+	// allocating it from the SKR free address space. The lease is recorded in the status and released on delete.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="ExternalIpam is immutable."
+	ExternalIpam *IpRangeExternalIpam `json:"externalIpam,omitempty"`
+
+	// ReadinessGates are the condition types that must all be True for the resource to be Ready,
+	// until then Ready is False with the WaitingForGates reason
+	// +optional
+	// +listType=set
+	ReadinessGates []string `json:"readinessGates,omitempty"`
+
+	// ForceDelete lets the deletion of the AWS subnets blocked by the orphaned dependencies delete the
+	// network interfaces in the available state, and the mount targets of the EFS file systems not owned
+	// by any NfsInstance. The network interfaces in use are never detached. It is the last resort for the
+	// operators, each forced deletion is reported as an event.
+	// +optional
+	ForceDelete bool `json:"forceDelete,omitempty"`
+
+	// Provider overrides the provider derived from the Scope, for testing and the multi-cloud Scopes.
+	// A provider different from the Scope provider is rejected with the ProviderMismatch reason, unless
+	// allowed by the cloud-manager configuration and the Scope has that provider configured.
+	// +optional
+	// +kubebuilder:validation:Enum=aws;azure;gcp
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="Provider is immutable."
+	Provider ProviderType `json:"provider,omitempty"`
+
+	// AllowZoneFallback lets the AWS subnet that can not be created in its zone due to the zone capacity
+	// be created in an alternate available zone of the region, not used by the Scope. The substitutions
+	// are recorded in the status zoneFallbacks.
+	// +optional
+	AllowZoneFallback bool `json:"allowZoneFallback,omitempty"`
+
+	// AvailabilityZoneIds are the AWS availability zone IDs, for example euw1-az1, the subnets are created in
+	// instead of the Scope zones. The zone names map to different physical zones in each account, so the IDs
+	// keep the subnets of the peered accounts in the same physical zones. There must be one ID for each Scope zone,
+	// and they must exist in the Scope region. The resolved zone names are recorded in the status subnets.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="AvailabilityZoneIds is immutable."
+	AvailabilityZoneIds []string `json:"availabilityZoneIds,omitempty"`
+
+	// DriftDetection selects how the changes of the AWS subnets made outside of cloud-manager, like a deleted
+	// subnet or its lost ownership tags, are handled. Enforce recreates the subnets and restores their tags,
+	// Detect only reports the drift in the DriftDetected condition, and Off ignores it, so the subnets are
+	// reconciled only on the spec change. If empty, it is Enforce.
+	// +optional
+	// +kubebuilder:validation:Enum=Enforce;Detect;Off
+	DriftDetection string `json:"driftDetection,omitempty"`
+
+	// PreferredSecondaryCidr is the AWS VPC secondary CIDR block, already associated with the VPC, the cidr
+	// is allocated from, so the subnets are placed in it instead of in a block associated for the cidr.
+	// If the block has no room left, the cidr is allocated from the SKR free address space as usual.
+	// The block the subnets are placed in is recorded in the status secondaryCidr.
+	// +optional
+	// +kubebuilder:validation:Format=cidr
+	PreferredSecondaryCidr string `json:"preferredSecondaryCidr,omitempty"`
+
+	// TtlSecondsAfterCreation deletes the IpRange automatically once the given number of seconds elapses
+	// since its creation, for the ephemeral environments. Removing it cancels the automatic deletion.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TtlSecondsAfterCreation *int64 `json:"ttlSecondsAfterCreation,omitempty"`
+
+	// SecondaryRanges are the GCP secondary IP ranges, for example of the GKE pods and services, added to
+	// the subnet of the Scope nodes network. They must be within the private address space and not overlap
+	// the other ranges of the VPC. They are removed from the subnet once removed from the spec, and on delete.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SecondaryRanges []IpRangeSecondaryRange `json:"secondaryRanges,omitempty"`
+
+	// ZoneCapacityThreshold is the count of the available IP addresses of the zone below which a supplementary
+	// AWS subnet is added to the zone. AWS subnets can not be resized, so the supplementary subnet takes the
+	// range of the same size immediately after the last subnet of the zone, if it is within the cidr and free.
+	// The subnet ranges of each grown zone are recorded in the status zonePools. If zero, the zones do not grow.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ZoneCapacityThreshold int32 `json:"zoneCapacityThreshold,omitempty"`
+}
+
+type IpRangeExternalIpam struct {
+	// Url of the external IPAM endpoint. The cidr is leased with POST <url>/leases and the lease
+	// released with DELETE <url>/leases/<leaseId>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Url string `json:"url"`
+}
+
+type IpRangeSecondaryRange struct {
+	// Name of the secondary range, unique within the subnet
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Format=cidr
+	Cidr string `json:"cidr"`
+}
+
+type IpRangeZoneSubnet struct {
+	// +kubebuilder:validation:Required
+	Zone string `json:"zone"`
+
+	// +kubebuilder:validation:Required
+	Cidr string `json:"cidr"`
 }
 
 // +kubebuilder:validation:MinProperties=0
@@ -94,6 +320,36 @@ type IpRangeAzure struct {
 }
 
 type IpRangeAws struct {
+	// AWS account ids the created subnets are shared with through AWS Resource Access Manager.
+	// Only accounts within the organization, and if configured in the allow-list, are accepted.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^[0-9]{12}$`
+	ShareWithAccounts []string `json:"shareWithAccounts,omitempty"`
+
+	// TransitGateway the created subnets are attached to. The transit gateway must be owned by,
+	// or shared through AWS Resource Access Manager with the account.
+	// +optional
+	TransitGateway *IpRangeAwsTransitGateway `json:"transitGateway,omitempty"`
+
+	// Ipv6Egress enables the outbound only internet access of the IPv6 subnets through an egress-only
+	// internet gateway. The subnets must have an IPv6 CIDR block and be explicitly associated with the route
+	// tables tagged with cloud-manager.kyma-project.io/iprange set to the IpRange name, other route tables
+	// are never modified. If not met, the Ipv6EgressConfigured condition is False.
+	// +optional
+	Ipv6Egress bool `json:"ipv6Egress,omitempty"`
+}
+
+type IpRangeAwsTransitGateway struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^tgw-[0-9a-f]+$`
+	Id string `json:"id"`
+
+	// RouteTableId is the transit gateway route table the attachment is associated with and propagated to.
+	// If empty, the transit gateway default route table settings apply.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^tgw-rtb-[0-9a-f]+$`
+	RouteTableId string `json:"routeTableId,omitempty"`
 }
 
 // IpRangeStatus defines the observed state of IpRange
@@ -103,18 +359,67 @@ type IpRangeStatus struct {
 	// +optional
 	Cidr string `json:"cidr,omitempty"`
 
+	// Cidrs are all the CIDR blocks of the IpRange, set only if more than one was specified.
+	// The first one is also set in cidr.
+	// +optional
+	Cidrs []string `json:"cidrs,omitempty"`
+
 	// +optional
 	Ranges []string `json:"ranges,omitempty"`
 
+	// ZoneSubnets is the final mapping of the zones to the range of their subnet,
+	// either specified in spec.zoneSubnets or split from the cidr
+	// +optional
+	ZoneSubnets []IpRangeZoneSubnet `json:"zoneSubnets,omitempty"`
+
+	// ReservedCidrs are the blocks reserved by spec.reservedCidrRatio, one for each CIDR block
+	// +optional
+	ReservedCidrs []string `json:"reservedCidrs,omitempty"`
+
+	// IpamPoolAllocationId is the id of the cidr allocation in the spec.ipamPoolId IPAM pool
+	// +optional
+	IpamPoolAllocationId string `json:"ipamPoolAllocationId,omitempty"`
+
+	// ExternalIpamLeaseId is the id of the cidr lease in the spec.externalIpam IPAM
+	// +optional
+	ExternalIpamLeaseId string `json:"externalIpamLeaseId,omitempty"`
+
 	// +optional
 	VpcId string `json:"vpcId,omitempty"`
 
 	// +optional
 	AddressSpaceId string `json:"addressSpaceId,omitempty"`
 
+	// SecondaryCidr is the AWS VPC CIDR block the subnets are placed in, either spec.preferredSecondaryCidr
+	// or the cidr associated as its own block
+	// +optional
+	SecondaryCidr string `json:"secondaryCidr,omitempty"`
+
 	// +optional
 	Subnets IpRangeSubnets `json:"subnets,omitempty"`
 
+	// SecondaryRanges are the GCP secondary IP ranges added to the subnet of the Scope nodes network
+	// +optional
+	SecondaryRanges []IpRangeSecondaryRange `json:"secondaryRanges,omitempty"`
+
+	// ZoneFallbacks are the zones whose subnet was created in an alternate zone with spec.allowZoneFallback
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	ZoneFallbacks []IpRangeZoneFallback `json:"zoneFallbacks,omitempty"`
+
+	// ZonePools are the contiguous subnet ranges of the zones grown with spec.zoneCapacityThreshold.
+	// The supplementary subnets are not listed in the subnets, which keep one subnet per zone.
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	ZonePools []IpRangeZonePool `json:"zonePools,omitempty"`
+
+	// PropagatedTags are the keys of the tags propagated from the Scope labels and default tags to the
+	// cloud resources, removed from them once the Scope label or default tag is deleted
+	// +optional
+	PropagatedTags []string `json:"propagatedTags,omitempty"`
+
 	// List of status conditions to indicate the status of a Peering.
 	// +optional
 	// +listType=map
@@ -128,6 +433,53 @@ type IpRangeStatus struct {
 	// Id to track the Hyperscaler IpRange identifier
 	// +optional
 	Id string `json:"id,omitempty"`
+
+	// +optional
+	ResourceShare *IpRangeResourceShare `json:"resourceShare,omitempty"`
+
+	// Id of the AWS transit gateway VPC attachment of the subnets
+	// +optional
+	TransitGatewayAttachmentId string `json:"transitGatewayAttachmentId,omitempty"`
+
+	// Id of the AWS egress-only internet gateway routing the IPv6 egress traffic of the subnets
+	// +optional
+	EgressOnlyInternetGatewayId string `json:"egressOnlyInternetGatewayId,omitempty"`
+
+	// Time the object was last reconciled, updated on a throttled cadence
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// DryRunPlan lists the operations on the cloud resources planned while the IpRange is in the
+	// dry-run mode, and is cleared once the dry-run mode is disabled
+	// +optional
+	DryRunPlan *DryRunPlan `json:"dryRunPlan,omitempty"`
+
+	// TtlExpiresAt is the time the IpRange is deleted automatically due to spec.ttlSecondsAfterCreation
+	// +optional
+	TtlExpiresAt *metav1.Time `json:"ttlExpiresAt,omitempty"`
+}
+
+// IpRangeResourceShare is the observed state of the AWS RAM resource share of the IpRange subnets
+type IpRangeResourceShare struct {
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// Accounts that the subnets are successfully shared with
+	// +optional
+	AcceptedAccounts []string `json:"acceptedAccounts,omitempty"`
+}
+
+// IpRangeZoneFallback records the subnet of the zone created in the alternate zone
+type IpRangeZoneFallback struct {
+	Zone         string `json:"zone"`
+	FallbackZone string `json:"fallbackZone"`
+}
+
+// IpRangeZonePool is the address space of the zone, the range of its subnet followed by the ranges of
+// the supplementary subnets, each one immediately after the previous one
+type IpRangeZonePool struct {
+	Zone   string   `json:"zone"`
+	Ranges []string `json:"ranges"`
 }
 
 type IpRangeSubnets []IpRangeSubnet
@@ -136,8 +488,19 @@ type IpRangeSubnet struct {
 	Id    string `json:"id"`
 	Zone  string `json:"zone"`
 	Range string `json:"range"`
+
+	// ZoneId is the AWS availability zone ID of the zone
+	// +optional
+	ZoneId string `json:"zoneId,omitempty"`
+
+	// AvailableIpAddressCount is the count of the subnet IP addresses not used yet, as of the last change
+	// of the subnets
+	// +optional
+	AvailableIpAddressCount int32 `json:"availableIpAddressCount,omitempty"`
 }
 
+// Equals returns true if both have the same subnets. The AvailableIpAddressCount changes with every IP
+// address used, so it is not compared, and it is refreshed only when the subnets change.
 func (in IpRangeSubnets) Equals(other IpRangeSubnets) bool {
 	if len(in) != len(other) {
 		return false
@@ -145,7 +508,7 @@ func (in IpRangeSubnets) Equals(other IpRangeSubnets) bool {
 	for _, mine := range in {
 		found := false
 		for _, his := range other {
-			if mine == his {
+			if mine.Id == his.Id && mine.Zone == his.Zone && mine.Range == his.Range && mine.ZoneId == his.ZoneId {
 				found = true
 				break
 			}
@@ -191,18 +554,104 @@ type IpRange struct {
 	Status IpRangeStatus `json:"status,omitempty"`
 }
 
+// SpecCidrs returns the CIDR blocks specified either by cidrs or by the legacy single cidr
+func (in *IpRange) SpecCidrs() []string {
+	if len(in.Spec.Cidrs) > 0 {
+		return in.Spec.Cidrs
+	}
+	if len(in.Spec.Cidr) > 0 {
+		return []string{in.Spec.Cidr}
+	}
+	return nil
+}
+
+// CidrSizeOrDefault returns the spec.cidrSize prefix length of the allocated cidr, or the given default if not specified
+func (in *IpRange) CidrSizeOrDefault(defaultSize int) int {
+	if in.Spec.CidrSize > 0 {
+		return in.Spec.CidrSize
+	}
+	return defaultSize
+}
+
+// StatusCidrs returns the CIDR blocks of the IpRange status, falling back to the legacy single cidr
+func (in *IpRange) StatusCidrs() []string {
+	if len(in.Status.Cidrs) > 0 {
+		return in.Status.Cidrs
+	}
+	if len(in.Status.Cidr) > 0 {
+		return []string{in.Status.Cidr}
+	}
+	return nil
+}
+
+func (in *IpRange) ProviderOverride() ProviderType {
+	return in.Spec.Provider
+}
+
 func (in *IpRange) ScopeRef() ScopeRef {
 	return in.Spec.Scope
 }
 
+func (in *IpRange) PropagatedTags() []string {
+	return in.Status.PropagatedTags
+}
+
+func (in *IpRange) SetPropagatedTags(keys []string) {
+	in.Status.PropagatedTags = keys
+}
+
 func (in *IpRange) SetScopeRef(scopeRef ScopeRef) {
 	in.Spec.Scope = scopeRef
 }
 
+func (in *IpRange) GetLastReconcileTime() *metav1.Time {
+	return in.Status.LastReconcileTime
+}
+
+func (in *IpRange) SetLastReconcileTime(t *metav1.Time) {
+	in.Status.LastReconcileTime = t
+}
+
+func (in *IpRange) State() string {
+	return string(in.Status.State)
+}
+
+func (in *IpRange) SetState(v string) {
+	in.Status.State = StatusState(v)
+}
+
 func (in *IpRange) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+func (in *IpRange) DriftDetection() string {
+	return in.Spec.DriftDetection
+}
+
+func (in *IpRange) ReadinessGates() []string {
+	return in.Spec.ReadinessGates
+}
+
+func (in *IpRange) GetDryRunPlan() *DryRunPlan {
+	return in.Status.DryRunPlan
+}
+
+func (in *IpRange) SetDryRunPlan(plan *DryRunPlan) {
+	in.Status.DryRunPlan = plan
+}
+
+func (in *IpRange) TtlSecondsAfterCreation() *int64 {
+	return in.Spec.TtlSecondsAfterCreation
+}
+
+func (in *IpRange) GetTtlExpiresAt() *metav1.Time {
+	return in.Status.TtlExpiresAt
+}
+
+func (in *IpRange) SetTtlExpiresAt(t *metav1.Time) {
+	in.Status.TtlExpiresAt = t
+}
+
 func (in *IpRange) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
 }
@@ -136,4 +136,24 @@ func TestIpRangeSubnets(t *testing.T) {
 		}
 		assert.False(t, a.Equals(b))
 	})
+
+	t.Run("equal when only available ip address count differs", func(t *testing.T) {
+		a := IpRangeSubnets{
+			{
+				Id:                      "a",
+				Zone:                    "aa",
+				Range:                   "aaa",
+				AvailableIpAddressCount: 250,
+			},
+		}
+		b := IpRangeSubnets{
+			{
+				Id:                      "a",
+				Zone:                    "aa",
+				Range:                   "aaa",
+				AvailableIpAddressCount: 240,
+			},
+		}
+		assert.True(t, a.Equals(b))
+	})
 }
@@ -0,0 +1,140 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionTypePoolExhausted = "PoolExhausted"
+	ReasonPoolExhausted        = "PoolExhausted"
+)
+
+const (
+	// LabelIpRangePool is set on the IpRanges that are members of the IpRangePool with the label value name
+	LabelIpRangePool = "cloud-control.kyma-project.io/iprange-pool"
+
+	// LabelIpRangePoolClaim is set by the consumer on the pool member IpRange it allocates,
+	// with the value identifying the claimant. Removing the label returns the IpRange to the pool.
+	LabelIpRangePoolClaim = "cloud-control.kyma-project.io/iprange-pool-claim"
+
+	// AnnotationIpRangePoolClaimedBy is set by the pool on the claimed member IpRange and removed
+	// once the returned IpRange is scrubbed and available again
+	AnnotationIpRangePoolClaimedBy = "cloud-control.kyma-project.io/iprange-pool-claimed-by"
+)
+
+// IpRangePoolSpec defines the desired state of IpRangePool
+type IpRangePoolSpec struct {
+	// +kubebuilder:validation:Required
+	Scope ScopeRef `json:"scope"`
+
+	// +kubebuilder:validation:Required
+	Pool IpRangePoolOptions `json:"pool"`
+}
+
+type IpRangePoolOptions struct {
+	// MinAvailable is the number of warm, unclaimed and ready IpRanges the pool maintains
+	// +kubebuilder:validation:Minimum=0
+	MinAvailable int `json:"minAvailable"`
+
+	// MaxSize limits the total number of IpRanges in the pool, claimed ones included.
+	// If zero, the pool size is not limited.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSize int `json:"maxSize,omitempty"`
+}
+
+// IpRangePoolStatus defines the observed state of IpRangePool
+type IpRangePoolStatus struct {
+	State StatusState `json:"state,omitempty"`
+
+	// Available is the number of ready IpRanges in the pool that are not claimed
+	// +optional
+	Available int `json:"available"`
+
+	// Allocated is the number of claimed IpRanges in the pool, including those being recycled
+	// +optional
+	Allocated int `json:"allocated"`
+
+	// List of status conditions
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Scope",type="string",JSONPath=".spec.scope.name"
+// +kubebuilder:printcolumn:name="MinAvailable",type="integer",JSONPath=".spec.pool.minAvailable"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.available"
+// +kubebuilder:printcolumn:name="Allocated",type="integer",JSONPath=".status.allocated"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+
+// IpRangePool is the Schema for the iprangepools API
+type IpRangePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IpRangePoolSpec   `json:"spec,omitempty"`
+	Status IpRangePoolStatus `json:"status,omitempty"`
+}
+
+func (in *IpRangePool) ScopeRef() ScopeRef {
+	return in.Spec.Scope
+}
+
+func (in *IpRangePool) SetScopeRef(scopeRef ScopeRef) {
+	in.Spec.Scope = scopeRef
+}
+
+func (in *IpRangePool) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *IpRangePool) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+func (in *IpRangePool) CloneForPatchStatus() client.Object {
+	return &IpRangePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "IpRangePool",
+			APIVersion: GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: in.Namespace,
+			Name:      in.Name,
+		},
+		Status: in.Status,
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// IpRangePoolList contains a list of IpRangePool
+type IpRangePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IpRangePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IpRangePool{}, &IpRangePoolList{})
+}
@@ -0,0 +1,15 @@
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// LastError is the most recent error the reconciliation of the resource failed with
+type LastError struct {
+	// Error message with the sensitive details redacted
+	Message string `json:"message"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Time the error occurred
+	Time metav1.Time `json:"time"`
+}
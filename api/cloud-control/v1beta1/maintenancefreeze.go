@@ -0,0 +1,40 @@
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type DayOfWeek string
+
+// MaintenanceFreezeWindow is the recurring window in which the disruptive operations on the cloud resource,
+// like the upgrades and the recreation, are deferred while the rest of the reconciliation continues
+// +kubebuilder:validation:XValidation:rule=(self.start != self.end),message="MaintenanceFreeze window start and end must differ."
+type MaintenanceFreezeWindow struct {
+	// Start of the window as HH:MM in UTC
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End of the window as HH:MM in UTC. If it is before the start, the window ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// Days of the week the window starts on, every day if empty
+	// +optional
+	// +listType=set
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+}
+
+// String returns the window in the cron-like `<start>-<end> [<day>,...]` form, for example
+// `08:00-18:00 Mon,Tue,Wed,Thu,Fri`, parsed by the composed.ParseFreezeWindow
+func (in MaintenanceFreezeWindow) String() string {
+	if len(in.DaysOfWeek) == 0 {
+		return fmt.Sprintf("%s-%s", in.Start, in.End)
+	}
+	days := make([]string, 0, len(in.DaysOfWeek))
+	for _, d := range in.DaysOfWeek {
+		days = append(days, string(d))
+	}
+	return fmt.Sprintf("%s-%s %s", in.Start, in.End, strings.Join(days, ","))
+}
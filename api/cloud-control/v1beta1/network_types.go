@@ -32,7 +32,8 @@ const (
 	NetworkTypeCloudResources NetworkType = "cloud-resources"
 )
 
-const NetworkFieldScope = ".spec.scope.name"
+// NetworkFieldScope indexes the Networks by the namespace/name key of the Scope they reference
+const NetworkFieldScope = ".spec.scope"
 
 // NetworkSpec defines the desired state of Network
 type NetworkSpec struct {
@@ -125,7 +126,12 @@ type AwsNetworkReference struct {
 
 	Region string `json:"region"`
 
-	VpcId string `json:"vpcId"`
+	// +optional
+	VpcId string `json:"vpcId,omitempty"`
+
+	// VpcNameTag is the Name tag value the VPC is looked up by when the VpcId is not specified
+	// +optional
+	VpcNameTag string `json:"vpcNameTag,omitempty"`
 
 	NetworkName string `json:"networkName"`
 }
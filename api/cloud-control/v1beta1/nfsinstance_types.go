@@ -24,6 +24,44 @@ import (
 const (
 	ReasonFailedCreatingFileSystem        = "FailedCreatingFileSystem"
 	ReasonInvalidMountTargetsAlreadyExist = "InvalidMountTargetsAlreadyExist"
+	ReasonLifecyclePolicyInvalid          = "LifecyclePolicyInvalid"
+	ReasonLifecyclePolicyFailed           = "LifecyclePolicyFailed"
+	ReasonBackupPolicyFailed              = "BackupPolicyFailed"
+	ReasonFileSystemPolicyFailed          = "FileSystemPolicyFailed"
+	ReasonInvalidMountTargetIp            = "InvalidMountTargetIp"
+	ReasonIpv6MountTargetNotSupported     = "Ipv6MountTargetNotSupported"
+)
+
+const (
+	ConditionTypeMountTargetIpInUse = "MountTargetIpInUse"
+	ReasonMountTargetIpInUse        = "MountTargetIpInUse"
+)
+
+const (
+	ConditionTypeOneZoneConstraint = "OneZoneConstraint"
+	ReasonOneZoneConstraint        = "OneZoneConstraint"
+)
+
+const (
+	ReasonRegionNotReady        = "RegionNotReady"
+	ReasonRegionNetworkNotFound = "RegionNetworkNotFound"
+	ReasonRegionFailed          = "RegionFailed"
+)
+
+const (
+	// LabelNfsInstanceBinding is set on the workload binding Secrets in the NfsInstance namespace with the
+	// NfsInstance name as value. The NfsInstance is not deleted while any such binding exists.
+	LabelNfsInstanceBinding = "cloud-control.kyma-project.io/nfs-instance-binding"
+)
+
+const (
+	AwsStorageClassRegional = "Regional"
+	AwsStorageClassOneZone  = "OneZone"
+)
+
+const (
+	ConditionTypeInTransitEncryptionEnforced = "InTransitEncryptionEnforced"
+	ReasonInTransitEncryptionEnforced        = "InTransitEncryptionEnforced"
 )
 
 // +kubebuilder:validation:Enum=generalPurpose;maxIO
@@ -34,14 +72,47 @@ const (
 	AwsPerformanceModeBursting       = AwsPerformanceMode("maxIO")
 )
 
-// +kubebuilder:validation:Enum=bursting;elastic
+// +kubebuilder:validation:Enum=bursting;elastic;provisioned
 type AwsThroughputMode string
 
 const (
-	AwsThroughputModeBursting = AwsThroughputMode("bursting")
-	AwsThroughputModeElastic  = AwsThroughputMode("elastic")
+	AwsThroughputModeBursting    = AwsThroughputMode("bursting")
+	AwsThroughputModeElastic     = AwsThroughputMode("elastic")
+	AwsThroughputModeProvisioned = AwsThroughputMode("provisioned")
+)
+
+const (
+	ConditionTypeThroughputScaleThrottled = "ThroughputScaleThrottled"
+	ReasonThroughputDecreaseLimit         = "ThroughputDecreaseLimit"
 )
 
+// +kubebuilder:validation:XValidation:rule=(self.min <= self.max), message="Min can not be greater than max."
+type AwsProvisionedThroughput struct {
+	// Min is the lowest provisioned throughput in MiB/s the file system is scaled down to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Min int `json:"min"`
+
+	// Max is the highest provisioned throughput in MiB/s the file system is scaled up to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Max int `json:"max"`
+}
+
+// +kubebuilder:validation:Enum=NONE;AFTER_1_DAY;AFTER_7_DAYS;AFTER_14_DAYS;AFTER_30_DAYS;AFTER_60_DAYS;AFTER_90_DAYS;AFTER_180_DAYS;AFTER_270_DAYS;AFTER_365_DAYS
+type AwsTransitionToIA string
+
+const (
+	AwsTransitionToIANone = AwsTransitionToIA("NONE")
+)
+
+type AwsLifecyclePolicy struct {
+	// TransitionToIA is the period after which files not accessed are moved to the Infrequent Access
+	// storage class. NONE removes the transition.
+	// +kubebuilder:validation:Required
+	TransitionToIA AwsTransitionToIA `json:"transitionToIA"`
+}
+
 // NfsInstanceSpec defines the desired state of NfsInstance
 // +kubebuilder:validation:XValidation:rule=(has(self.instance.openStack) || false) && self.ipRange.name == "" || (has(self.instance.aws) || has(self.instance.gcp) || false) && self.ipRange.name != "", message="IpRange can not be specified for openstack, and is mandatory for gcp and aws."
 type NfsInstanceSpec struct {
@@ -57,6 +128,37 @@ type NfsInstanceSpec struct {
 
 	// +kubebuilder:validation:Required
 	Instance NfsInstanceInfo `json:"instance"`
+
+	// Adopt an already existing file system instead of creating a new one. Only the file system having the
+	// cloud-manager scope and shoot tags of the Scope, and not bound to other NfsInstance, can be adopted.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="Adopt is immutable."
+	Adopt *AdoptOptions `json:"adopt,omitempty"`
+
+	// Dns configures the DNS record pointing at the instance endpoint. Supported by GCP and AWS.
+	// +optional
+	Dns *DnsOptions `json:"dns,omitempty"`
+
+	// Tags put on the cloud resources created for the instance. They take precedence over the tags propagated
+	// from the Scope labels and default tags, and the reserved cloud-manager tags take precedence over them.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Backup configures the scheduled backups of the file system
+	// +optional
+	Backup *BackupOptions `json:"backup,omitempty"`
+
+	// DeletionGracePeriodSeconds delays the deletion of the cloud resources after the object is marked
+	// for deletion, so an accidental delete can be recovered by removing the finalizer before it elapses
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+
+	// ReadinessGates are the condition types that must all be True for the resource to be Ready,
+	// until then Ready is False with the WaitingForGates reason
+	// +optional
+	// +listType=set
+	ReadinessGates []string `json:"readinessGates,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties=1
@@ -85,12 +187,59 @@ type NfsInstanceOpenStack struct {
 	SizeGb int `json:"sizeGb"`
 }
 
+// +kubebuilder:validation:XValidation:rule=(!has(self.throughput) || self.throughput != "provisioned" || has(self.provisionedThroughput)), message="ProvisionedThroughput is required for provisioned throughput mode."
 type NfsInstanceAws struct {
 	// +kubebuilder:default=generalPurpose
 	PerformanceMode AwsPerformanceMode `json:"performanceMode,omitempty"`
 
 	// +kubebuilder:default=bursting
 	Throughput AwsThroughputMode `json:"throughput,omitempty"`
+
+	// ProvisionedThroughput are the bounds the provisioned throughput is kept in, and autoscaled
+	// within by the utilization if the autoscaling is enabled. Required for the provisioned throughput mode.
+	// +optional
+	ProvisionedThroughput *AwsProvisionedThroughput `json:"provisionedThroughput,omitempty"`
+
+	// +optional
+	Replication *ReplicationOptions `json:"replication,omitempty"`
+
+	// +optional
+	WorkloadAccess *WorkloadAccessOptions `json:"workloadAccess,omitempty"`
+
+	// +optional
+	LifecyclePolicy *AwsLifecyclePolicy `json:"lifecyclePolicy,omitempty"`
+
+	// AutomaticBackups enables or disables the EFS automatic daily backups.
+	// If not specified, the region default of the account is used and the backup policy is not managed.
+	// +optional
+	AutomaticBackups *bool `json:"automaticBackups,omitempty"`
+
+	// EnforceInTransitEncryption applies the EFS file system policy that denies the NFS clients
+	// not using TLS. When disabled, the file system policy applied by the cloud-manager is removed.
+	// +optional
+	EnforceInTransitEncryption bool `json:"enforceInTransitEncryption,omitempty"`
+
+	// MountTargetIps are the IP addresses of the mount targets by zone. Each IP must be within
+	// the IpRange subnet of its zone and not used by other network interfaces. The mount targets
+	// in the zones not listed get an IP assigned by AWS. The IP of a zone can be changed only
+	// until its mount target is created.
+	// +optional
+	MountTargetIps map[string]string `json:"mountTargetIps,omitempty"`
+
+	// OneZone is the availability zone of the One Zone storage class file system, with a single
+	// mount target in that zone. It must be one of the IpRange subnet zones. If not specified,
+	// the file system has the Regional storage class with a mount target in each IpRange subnet.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="OneZone is immutable."
+	OneZone string `json:"oneZone,omitempty"`
+
+	// Regions are the additional regions the same logical file system is provisioned in, active-active,
+	// each with its own file system and mount targets in the VPC of the Scope network name. The file
+	// system of a region removed from the list is deleted. The region of the Scope is always provisioned.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=4
+	Regions []string `json:"regions,omitempty"`
 }
 
 // NfsInstanceStatus defines the observed state of NfsInstance
@@ -128,6 +277,125 @@ type NfsInstanceStatus struct {
 
 	// +optional
 	StateData map[string]string `json:"stateData,omitempty"`
+
+	// +optional
+	Replica *ReplicaStatus `json:"replica,omitempty"`
+
+	// +optional
+	Dns *DnsStatus `json:"dns,omitempty"`
+
+	// PropagatedTags are the keys of the spec tags and the tags propagated from the Scope labels and default
+	// tags to the cloud resources, removed from them once no longer propagated
+	// +optional
+	PropagatedTags []string `json:"propagatedTags,omitempty"`
+
+	// Arn of the IAM role workloads can assume to access the file system
+	// +optional
+	WorkloadAccessRoleArn string `json:"workloadAccessRoleArn,omitempty"`
+
+	// Lifecycle policy active on the file system
+	// +optional
+	LifecyclePolicy *AwsLifecyclePolicy `json:"lifecyclePolicy,omitempty"`
+
+	// State of the file system automatic backups policy, ENABLED or DISABLED
+	// +optional
+	BackupPolicy string `json:"backupPolicy,omitempty"`
+
+	// Scheduled backups of the file system
+	// +optional
+	Backup *BackupStatus `json:"backup,omitempty"`
+
+	// Current provisioned throughput of the file system in MiB/s
+	// +optional
+	ProvisionedThroughputMibps int `json:"provisionedThroughputMibps,omitempty"`
+
+	// Time the provisioned throughput was last changed
+	// +optional
+	LastThroughputScaleTime *metav1.Time `json:"lastThroughputScaleTime,omitempty"`
+
+	// Time the provisioned throughput was last decreased, which AWS allows once per day
+	// +optional
+	LastThroughputDecreaseTime *metav1.Time `json:"lastThroughputDecreaseTime,omitempty"`
+
+	// Time the object was last reconciled, updated on a throttled cadence
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// Id of the already existing cloud resource that was adopted
+	// +optional
+	AdoptedResourceId string `json:"adoptedResourceId,omitempty"`
+
+	// Approximate monthly cost of the provisioned cloud resources, computed from a static
+	// pricing table. It is an estimate only and does not reflect the actual bill.
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+
+	// Mount targets of the file system by zone, with the IP addresses clients in the zone can mount
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	MountTargets []NfsMountTarget `json:"mountTargets,omitempty"`
+
+	// Storage class of the file system, Regional or OneZone
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// Availability zone of the One Zone storage class file system
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// Id of the security group attached to the mount targets, allowing NFS from the IpRange CIDRs
+	// +optional
+	SecurityGroupId string `json:"securityGroupId,omitempty"`
+
+	// Connection the workloads mount the share with
+	// +optional
+	Connection *NfsConnection `json:"connection,omitempty"`
+
+	// Most recent reconciliation error, cleared on the next successful reconciliation
+	// +optional
+	LastError *LastError `json:"lastError,omitempty"`
+
+	// Last condition status transitions, oldest first
+	// +optional
+	ConditionHistory []ConditionHistoryEntry `json:"conditionHistory,omitempty"`
+
+	// File systems provisioned in the additional regions, the NfsInstance is Ready only when all are ready
+	// +optional
+	// +listType=map
+	// +listMapKey=region
+	Regions []NfsRegionStatus `json:"regions,omitempty"`
+}
+
+type NfsRegionStatus struct {
+	Region string `json:"region"`
+
+	// Id of the file system in the region
+	// +optional
+	Id string `json:"id,omitempty"`
+
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Mount targets of the file system in the region by zone
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	MountTargets []NfsMountTarget `json:"mountTargets,omitempty"`
+
+	// Conditions of the file system in the region
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type NfsMountTarget struct {
+	Zone string `json:"zone"`
+
+	// IPv4 address of the mount target
+	// +optional
+	IpAddress string `json:"ipAddress,omitempty"`
 }
 
 var _ client.Object = &NfsInstance{}
@@ -155,10 +423,80 @@ func (in *NfsInstance) SetScopeRef(scopeRef ScopeRef) {
 	in.Spec.Scope = scopeRef
 }
 
+func (in *NfsInstance) GetLastReconcileTime() *metav1.Time {
+	return in.Status.LastReconcileTime
+}
+
+func (in *NfsInstance) SetLastReconcileTime(t *metav1.Time) {
+	in.Status.LastReconcileTime = t
+}
+
+func (in *NfsInstance) DeletionGracePeriodSeconds() int64 {
+	if in.Spec.DeletionGracePeriodSeconds == nil {
+		return 0
+	}
+	return *in.Spec.DeletionGracePeriodSeconds
+}
+
+func (in *NfsInstance) ReadinessGates() []string {
+	return in.Spec.ReadinessGates
+}
+
+func (in *NfsInstance) GetLastErrorMessage() string {
+	if in.Status.LastError == nil {
+		return ""
+	}
+	return in.Status.LastError.Message
+}
+
+func (in *NfsInstance) GetLastErrorReason() string {
+	if in.Status.LastError == nil {
+		return ""
+	}
+	return in.Status.LastError.Reason
+}
+
+func (in *NfsInstance) SetLastError(message, reason string, t metav1.Time) {
+	in.Status.LastError = &LastError{Message: message, Reason: reason, Time: t}
+}
+
+func (in *NfsInstance) ClearLastError() {
+	in.Status.LastError = nil
+}
+
+func (in *NfsInstance) AppendConditionHistory(conditionType string, status metav1.ConditionStatus, reason string, t metav1.Time, limit int) {
+	in.Status.ConditionHistory = appendConditionHistory(in.Status.ConditionHistory, ConditionHistoryEntry{
+		Type:   conditionType,
+		Status: status,
+		Reason: reason,
+		Time:   t,
+	}, limit)
+}
+
 func (in *NfsInstance) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+func (in *NfsInstance) DnsOptions() *DnsOptions {
+	return in.Spec.Dns
+}
+
+func (in *NfsInstance) DnsStatus() *DnsStatus {
+	return in.Status.Dns
+}
+
+func (in *NfsInstance) SetDnsStatus(dns *DnsStatus) {
+	in.Status.Dns = dns
+}
+
+func (in *NfsInstance) PropagatedTags() []string {
+	return in.Status.PropagatedTags
+}
+
+func (in *NfsInstance) SetPropagatedTags(keys []string) {
+	in.Status.PropagatedTags = keys
+}
+
 func (in *NfsInstance) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
 }
@@ -179,6 +517,26 @@ func (in *NfsInstance) GetStateData(key string) (string, bool) {
 	return v, found
 }
 
+func (in *NfsInstance) AdoptOptions() *AdoptOptions {
+	return in.Spec.Adopt
+}
+
+func (in *NfsInstance) GetAdoptedResourceId() string {
+	return in.Status.AdoptedResourceId
+}
+
+func (in *NfsInstance) SetAdoptedResourceId(id string) {
+	in.Status.AdoptedResourceId = id
+}
+
+func (in *NfsInstance) GetEstimatedMonthlyCost() string {
+	return in.Status.EstimatedMonthlyCost
+}
+
+func (in *NfsInstance) SetEstimatedMonthlyCost(v string) {
+	in.Status.EstimatedMonthlyCost = v
+}
+
 func (in *NfsInstance) CloneForPatchStatus() client.Object {
 	return &NfsInstance{
 		TypeMeta: metav1.TypeMeta{
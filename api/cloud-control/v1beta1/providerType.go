@@ -8,3 +8,7 @@ const (
 	ProviderAws       = ProviderType("aws")
 	ProviderOpenStack = ProviderType("openstack")
 )
+
+// ReasonProviderMismatch is the reason of the Error condition when the provider specified by the
+// resource is not compatible with its Scope
+const ReasonProviderMismatch = "ProviderMismatch"
@@ -28,6 +28,49 @@ const (
 	ReasonCanNotCreateResourceGroup = "ResourceGroupCanNotCreate"
 )
 
+const (
+	// LabelRedisInstanceBinding is set on the workload binding Secrets in the RedisInstance namespace with the
+	// RedisInstance name as value. The RedisInstance is not deleted while any such binding exists.
+	LabelRedisInstanceBinding = "cloud-control.kyma-project.io/redis-instance-binding"
+)
+
+const (
+	// ConditionTypeUpgrading is set while the engine version upgrade is scheduled or in progress
+	ConditionTypeUpgrading = "Upgrading"
+
+	ReasonUpgradeScheduled        = "UpgradeScheduled"
+	ReasonUpgradeInProgress       = "UpgradeInProgress"
+	ReasonInvalidVersionDowngrade = "InvalidVersionDowngrade"
+	ReasonUnsupportedUpgradePath  = "UnsupportedUpgradePath"
+)
+
+const (
+	// ConditionTypeScaling is set while the Azure Redis SKU or capacity, or the AWS cache node type change is in progress
+	ConditionTypeScaling = "Scaling"
+
+	ReasonScaling                      = "Scaling"
+	ReasonVnetInjectionRequiresPremium = "VnetInjectionRequiresPremium"
+	ReasonIncompatibleNodeType         = "IncompatibleNodeType"
+)
+
+const (
+	// ConditionTypeResharding is set while the AWS cluster mode shards or replicas per shard change is in progress
+	ConditionTypeResharding = "Resharding"
+
+	ReasonResharding = "Resharding"
+)
+
+const (
+	ReasonLogDestinationNotFound     = "LogDestinationNotFound"
+	ReasonLogDestinationInvalid      = "LogDestinationInvalid"
+	ReasonLogDestinationAccessDenied = "LogDestinationAccessDenied"
+)
+
+const (
+	AzureRedisTierStandard = "Standard"
+	AzureRedisTierPremium  = "Premium"
+)
+
 // RedisInstanceSpec defines the desired state of RedisInstance
 type RedisInstanceSpec struct {
 	// +kubebuilder:validation:Required
@@ -42,6 +85,40 @@ type RedisInstanceSpec struct {
 
 	// +kubebuilder:validation:Required
 	Instance RedisInstanceInfo `json:"instance"`
+
+	// Dns configures the DNS record pointing at the instance endpoint. Supported by GCP and AWS.
+	// +optional
+	Dns *DnsOptions `json:"dns,omitempty"`
+
+	// Tags put on the cloud resources created for the instance. They take precedence over the tags propagated
+	// from the Scope labels and default tags, and the reserved cloud-manager tags take precedence over them.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Names of the sub-reconcilers to pause while the rest of the instance keeps reconciling,
+	// for example scaling or parameters on AWS
+	// +optional
+	// +listType=set
+	Paused []string `json:"paused,omitempty"`
+
+	// DeletionGracePeriodSeconds delays the deletion of the cloud resources after the object is marked
+	// for deletion, so an accidental delete can be recovered by removing the finalizer before it elapses
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+
+	// UpdateStrategy of the spec changes that can not be applied to the existing instance. With InPlace
+	// such changes are rejected, with Recreate the instance is deleted and created again, losing its data,
+	// once confirmed with the confirm-recreate annotation set to the metadata.generation
+	// +optional
+	// +kubebuilder:default=InPlace
+	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// MaintenanceFreeze windows in which the disruptive operations, like the engine upgrade and the
+	// recreation, are deferred until the window ends. Supported only for AWS.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	MaintenanceFreeze []MaintenanceFreezeWindow `json:"maintenanceFreeze,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties=1
@@ -109,6 +186,13 @@ func (redisConfigs *RedisInstanceAzureConfigs) GetRedisConfig() *armRedis.Common
 }
 
 type AzureRedisSKU struct {
+	// Tier of the Azure Cache for Redis. The instance is injected into the cloud-manager subnet,
+	// which Azure supports only for the Premium tier.
+	// +optional
+	// +kubebuilder:default=Premium
+	// +kubebuilder:validation:Enum=Standard;Premium
+	Tier string `json:"tier,omitempty"`
+
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=1;2;3;4
 	Capacity int `json:"capacity"`
@@ -205,15 +289,28 @@ type RedisInstanceAzure struct {
 }
 
 // +kubebuilder:validation:XValidation:rule=(self.authEnabled == false || self.transitEncryptionEnabled == true), message="authEnabled can only be true if TransitEncryptionEnabled is also true"
+// +kubebuilder:validation:XValidation:rule=(!has(self.dataTieringEnabled) || !self.dataTieringEnabled || self.cacheNodeType.startsWith('cache.r6gd.')), message="dataTieringEnabled requires the r6gd cache node type"
+// +kubebuilder:validation:XValidation:rule=(!has(self.replicasPerShard) || has(self.shards)), message="replicasPerShard can be set only with shards"
 type RedisInstanceAws struct {
 	// +kubebuilder:validation:Required
 	CacheNodeType string `json:"cacheNodeType"`
 
+	// DataTieringEnabled keeps the less frequently accessed data on the local SSD of the node, and
+	// requires the r6gd cache node type. It can not be changed once the instance is created.
+	// +optional
+	DataTieringEnabled bool `json:"dataTieringEnabled,omitempty"`
+
+	// EngineVersion can be increased to upgrade the engine within the same major version, the upgrade
+	// is done in the next maintenance window unless ApplyImmediately is set. Downgrades are not supported.
 	// +optional
 	// +kubebuilder:default="7.0"
-	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="EngineVersion is immutable."
 	EngineVersion string `json:"engineVersion"`
 
+	// ApplyImmediately when set makes the engine version upgrade start immediately,
+	// instead of in the next maintenance window
+	// +optional
+	ApplyImmediately bool `json:"applyImmediately,omitempty"`
+
 	// +optional
 	// +kubebuilder:default=false
 	AutoMinorVersionUpgrade bool `json:"autoMinorVersionUpgrade"`
@@ -238,6 +335,67 @@ type RedisInstanceAws struct {
 
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// +optional
+	Replication *ReplicationOptions `json:"replication,omitempty"`
+
+	// LogDelivery streams the slow log and the engine log to a CloudWatch log group or a
+	// Kinesis Data Firehose delivery stream. The destination must exist and be accessible.
+	// +optional
+	LogDelivery *RedisInstanceAwsLogDelivery `json:"logDelivery,omitempty"`
+
+	// Shards enables the cluster mode with the data partitioned across the given number of shards.
+	// The number of shards can be changed with the online resharding, but the cluster mode can not
+	// be enabled or disabled once the instance is created.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=500
+	Shards *int32 `json:"shards,omitempty"`
+
+	// ReplicasPerShard is the number of the read replicas in each shard of the cluster mode
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	ReplicasPerShard *int32 `json:"replicasPerShard,omitempty"`
+}
+
+type RedisInstanceAwsLogDelivery struct {
+	// +optional
+	SlowLog *RedisInstanceAwsLogDestination `json:"slowLog,omitempty"`
+
+	// +optional
+	EngineLog *RedisInstanceAwsLogDestination `json:"engineLog,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule=(has(self.cloudWatchLogGroup) != has(self.kinesisFirehoseDeliveryStream)), message="exactly one of cloudWatchLogGroup or kinesisFirehoseDeliveryStream must be set"
+type RedisInstanceAwsLogDestination struct {
+	// Name of the CloudWatch log group the log is delivered to
+	// +optional
+	CloudWatchLogGroup string `json:"cloudWatchLogGroup,omitempty"`
+
+	// Name of the Kinesis Data Firehose delivery stream the log is delivered to
+	// +optional
+	KinesisFirehoseDeliveryStream string `json:"kinesisFirehoseDeliveryStream,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=json
+	// +kubebuilder:validation:Enum=json;text
+	Format string `json:"format,omitempty"`
+}
+
+// RedisInstanceLogDeliveryStatus is the observed log delivery of one log type
+type RedisInstanceLogDeliveryStatus struct {
+	// LogType is slow-log or engine-log
+	LogType string `json:"logType"`
+
+	// DestinationType is cloudwatch-logs or kinesis-firehose
+	DestinationType string `json:"destinationType"`
+
+	// Destination is the name of the log group or the delivery stream
+	Destination string `json:"destination"`
+
+	// +optional
+	Status string `json:"status,omitempty"`
 }
 
 // RedisInstanceStatus defines the observed state of RedisInstance
@@ -253,17 +411,75 @@ type RedisInstanceStatus struct {
 	// +optional
 	ReadEndpoint string `json:"readEndpoint,omitempty"`
 
+	// ConfigurationEndpoint is the endpoint of the cluster mode instance the clients discover the shards from
+	// +optional
+	ConfigurationEndpoint string `json:"configurationEndpoint,omitempty"`
+
 	// +optional
 	AuthString string `json:"authString,omitempty"`
 
 	// +optional
 	CaCert string `json:"caCert,omitempty"`
 
+	// +optional
+	Replica *ReplicaStatus `json:"replica,omitempty"`
+
 	// List of status conditions to indicate the status of a RedisInstance.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Time the object was last reconciled, updated on a throttled cadence
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// Approximate monthly cost of the provisioned cloud resources, computed from a static
+	// pricing table. It is an estimate only and does not reflect the actual bill.
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+
+	// Engine version the instance is currently running
+	// +optional
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// Cache node type the instance is currently running on
+	// +optional
+	CacheNodeType string `json:"cacheNodeType,omitempty"`
+
+	// DataTieringEnabled is true if the instance keeps the less frequently accessed data on the node SSD
+	// +optional
+	DataTieringEnabled bool `json:"dataTieringEnabled,omitempty"`
+
+	// +optional
+	Dns *DnsStatus `json:"dns,omitempty"`
+
+	// PropagatedTags are the keys of the spec tags and the tags propagated from the Scope labels and default
+	// tags to the cloud resources, removed from them once no longer propagated
+	// +optional
+	PropagatedTags []string `json:"propagatedTags,omitempty"`
+
+	// Log destinations the instance delivers its logs to
+	// +optional
+	// +listType=map
+	// +listMapKey=logType
+	LogDeliveries []RedisInstanceLogDeliveryStatus `json:"logDeliveries,omitempty"`
+
+	// Name of the cache subnet group the instance is placed in
+	// +optional
+	SubnetGroupName string `json:"subnetGroupName,omitempty"`
+
+	// Connection the workloads connect to the instance with, the credentials are in the auth Secret
+	// +optional
+	Connection *RedisConnection `json:"connection,omitempty"`
+
+	// Most recent reconciliation error, cleared on the next successful reconciliation
+	// +optional
+	LastError *LastError `json:"lastError,omitempty"`
+
+	// Last condition status transitions, oldest first
+	// +optional
+	ConditionHistory []ConditionHistoryEntry `json:"conditionHistory,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -286,14 +502,104 @@ func (in *RedisInstance) SetScopeRef(scopeRef ScopeRef) {
 	in.Spec.Scope = scopeRef
 }
 
+func (in *RedisInstance) GetLastReconcileTime() *metav1.Time {
+	return in.Status.LastReconcileTime
+}
+
+func (in *RedisInstance) SetLastReconcileTime(t *metav1.Time) {
+	in.Status.LastReconcileTime = t
+}
+
+func (in *RedisInstance) GetLastErrorMessage() string {
+	if in.Status.LastError == nil {
+		return ""
+	}
+	return in.Status.LastError.Message
+}
+
+func (in *RedisInstance) GetLastErrorReason() string {
+	if in.Status.LastError == nil {
+		return ""
+	}
+	return in.Status.LastError.Reason
+}
+
+func (in *RedisInstance) SetLastError(message, reason string, t metav1.Time) {
+	in.Status.LastError = &LastError{Message: message, Reason: reason, Time: t}
+}
+
+func (in *RedisInstance) ClearLastError() {
+	in.Status.LastError = nil
+}
+
+func (in *RedisInstance) AppendConditionHistory(conditionType string, status metav1.ConditionStatus, reason string, t metav1.Time, limit int) {
+	in.Status.ConditionHistory = appendConditionHistory(in.Status.ConditionHistory, ConditionHistoryEntry{
+		Type:   conditionType,
+		Status: status,
+		Reason: reason,
+		Time:   t,
+	}, limit)
+}
+
 func (in *RedisInstance) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+func (in *RedisInstance) PausedSubReconcilers() []string {
+	return in.Spec.Paused
+}
+
+func (in *RedisInstance) DeletionGracePeriodSeconds() int64 {
+	if in.Spec.DeletionGracePeriodSeconds == nil {
+		return 0
+	}
+	return *in.Spec.DeletionGracePeriodSeconds
+}
+
+func (in *RedisInstance) UpdateStrategy() UpdateStrategy {
+	return in.Spec.UpdateStrategy
+}
+
+func (in *RedisInstance) MaintenanceFreezeWindows() []string {
+	result := make([]string, 0, len(in.Spec.MaintenanceFreeze))
+	for _, w := range in.Spec.MaintenanceFreeze {
+		result = append(result, w.String())
+	}
+	return result
+}
+
+func (in *RedisInstance) DnsOptions() *DnsOptions {
+	return in.Spec.Dns
+}
+
+func (in *RedisInstance) DnsStatus() *DnsStatus {
+	return in.Status.Dns
+}
+
+func (in *RedisInstance) SetDnsStatus(dns *DnsStatus) {
+	in.Status.Dns = dns
+}
+
+func (in *RedisInstance) PropagatedTags() []string {
+	return in.Status.PropagatedTags
+}
+
+func (in *RedisInstance) SetPropagatedTags(keys []string) {
+	in.Status.PropagatedTags = keys
+}
+
 func (in *RedisInstance) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
 }
 
+func (in *RedisInstance) GetEstimatedMonthlyCost() string {
+	return in.Status.EstimatedMonthlyCost
+}
+
+func (in *RedisInstance) SetEstimatedMonthlyCost(v string) {
+	in.Status.EstimatedMonthlyCost = v
+}
+
 func (in *RedisInstance) SetStatusStateToReady() {
 	in.Status.State = ReadyState
 }
@@ -0,0 +1,61 @@
+package v1beta1
+
+const (
+	ConditionTypeReplicationSyncing = "ReplicationSyncing"
+	ConditionTypeReplicationReady   = "ReplicationReady"
+
+	ConditionTypeReplicationDestinationReadOnly = "ReplicationDestinationReadOnly"
+	ConditionTypeReplicaPromoted                = "ReplicaPromoted"
+
+	ReasonReplicationSyncing = "ReplicationSyncing"
+	ReasonReplicationReady   = "ReplicationReady"
+	ReasonReplicationFailed  = "ReplicationFailed"
+
+	ReasonReplicationDestinationReadOnly = "ReplicationDestinationReadOnly"
+	ReasonReplicationDestinationWritable = "ReplicationDestinationWritable"
+	ReasonReplicaPromoting               = "ReplicaPromoting"
+	ReasonReplicaPromoted                = "ReplicaPromoted"
+)
+
+// ReplicationOptions define the cross-region disaster recovery replica of the resource
+// +kubebuilder:validation:XValidation:rule=(!has(oldSelf.failover) || !oldSelf.failover || (has(self.failover) && self.failover)), message="Failover can not be reverted."
+type ReplicationOptions struct {
+	// Region where the read replica is provisioned
+	// +kubebuilder:validation:Required
+	Region string `json:"region"`
+
+	// Failover promotes the replica to a standalone writable resource by deleting the replication.
+	// The promotion can not be reverted. Currently supported only by the AWS NfsInstance.
+	// +optional
+	Failover bool `json:"failover,omitempty"`
+
+	// VpcId of the VPC in the replica region the replica is provisioned in.
+	// Required by the AWS RedisInstance.
+	// +optional
+	VpcId string `json:"vpcId,omitempty"`
+
+	// SubnetIds of the VPC subnets in the replica region the replica is provisioned in.
+	// Required by the AWS RedisInstance.
+	// +optional
+	SubnetIds []string `json:"subnetIds,omitempty"`
+}
+
+// ReplicaStatus is the observed state of the cross-region disaster recovery replica
+type ReplicaStatus struct {
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Id of the replica cloud resource, the EFS file system id or the ElastiCache replication group id
+	// +optional
+	Id string `json:"id,omitempty"`
+
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Promoted is true once the replica is promoted on failover, and is no longer replicated to
+	// +optional
+	Promoted bool `json:"promoted,omitempty"`
+}
@@ -1,8 +1,29 @@
 package v1beta1
 
+import "k8s.io/apimachinery/pkg/types"
+
 type ScopeRef struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="Scope is immutable."
 	// +kubebuilder:validation:XValidation:rule=(self != ""), message="Scope is required."
 	Name string `json:"name"`
+
+	// Namespace of the Scope when it is managed centrally in a namespace other than the one of the resource.
+	// Defaults to the namespace of the resource.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="Scope namespace is immutable."
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ObjectKey returns the name and the namespace of the referenced Scope, where namespace is the one of the resource
+func (in ScopeRef) ObjectKey(namespace string) types.NamespacedName {
+	if len(in.Namespace) > 0 {
+		namespace = in.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: in.Name}
+}
+
+// IsCrossNamespace returns true if the Scope is referenced in a namespace other than the one of the resource
+func (in ScopeRef) IsCrossNamespace(namespace string) bool {
+	return len(in.Namespace) > 0 && in.Namespace != namespace
 }
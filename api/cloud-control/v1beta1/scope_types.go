@@ -37,6 +37,12 @@ type ScopeSpec struct {
 
 	// +kubebuilder:validation:Required
 	Scope ScopeInfo `json:"scope"`
+
+	// DefaultTags are put on all the cloud resources created for the Scope. The tags propagated from the
+	// Scope labels, the spec tags of the resource, and the cloud-manager tags, take precedence over them.
+	// Changes are reconciled on the existing resources.
+	// +optional
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties=1
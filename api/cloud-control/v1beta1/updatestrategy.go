@@ -0,0 +1,24 @@
+package v1beta1
+
+const (
+	// ConditionTypeRecreateRequiresConfirmation is set while the recreation of the stateful cloud resource
+	// waits to be confirmed with the AnnotationConfirmRecreate
+	ConditionTypeRecreateRequiresConfirmation = "RecreateRequiresConfirmation"
+
+	ReasonRequiresRecreate             = "RequiresRecreate"
+	ReasonRecreateRequiresConfirmation = "RecreateRequiresConfirmation"
+	ReasonRecreating                   = "Recreating"
+
+	// AnnotationConfirmRecreate confirms the recreation of the stateful cloud resource, losing its data.
+	// Its value must be the metadata.generation of the spec requiring the recreation, so the confirmation
+	// given once does not apply to the later changes.
+	AnnotationConfirmRecreate = "cloud-control.kyma-project.io/confirm-recreate"
+)
+
+// +kubebuilder:validation:Enum=InPlace;Recreate
+type UpdateStrategy string
+
+const (
+	UpdateStrategyInPlace  = UpdateStrategy("InPlace")
+	UpdateStrategyRecreate = UpdateStrategy("Recreate")
+)
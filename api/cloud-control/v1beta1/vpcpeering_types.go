@@ -30,6 +30,14 @@ const (
 	ReasonFailedCreatingRoutes                    = "FailedCreatingRoutes"
 )
 
+const (
+	// ConditionTypeRouteConflict is set when an existing route to the peer CIDR with another target
+	// prevents adding the route through the peering connection
+	ConditionTypeRouteConflict = "RouteConflict"
+
+	ReasonRouteConflict = "RouteConflict"
+)
+
 const (
 	VirtualNetworkPeeringStateConnected    = "Connected"
 	VirtualNetworkPeeringStateDisconnected = "Disconnected"
@@ -118,6 +126,10 @@ type VpcPeeringStatus struct {
 	// +optional
 	RemoteId string `json:"remoteId,omitempty"`
 
+	// Routes through the peering connection added to the route tables of the local network
+	// +optional
+	Routes []VpcPeeringRoute `json:"routes,omitempty"`
+
 	// List of status conditions to indicate the status of a Peering.
 	// +optional
 	// +listType=map
@@ -125,6 +137,12 @@ type VpcPeeringStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type VpcPeeringRoute struct {
+	RouteTableId string `json:"routeTableId"`
+
+	DestinationCidrBlock string `json:"destinationCidrBlock"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -0,0 +1,25 @@
+package v1beta1
+
+const (
+	ConditionTypeWorkloadAccessReady = "WorkloadAccessReady"
+
+	ReasonWorkloadAccessReady   = "WorkloadAccessReady"
+	ReasonWorkloadAccessInvalid = "WorkloadAccessInvalid"
+	ReasonWorkloadAccessFailed  = "WorkloadAccessFailed"
+)
+
+// WorkloadAccessOptions define the IAM role created for cluster workloads to access the resource,
+// assumed by the Kubernetes service account through the cluster OIDC identity provider (AWS IRSA)
+type WorkloadAccessOptions struct {
+	// Arn of the cluster IAM OIDC identity provider, in the form arn:aws:iam::<account>:oidc-provider/<issuer>
+	// +kubebuilder:validation:Required
+	OidcProviderArn string `json:"oidcProviderArn"`
+
+	// Namespace of the service account allowed to assume the role
+	// +kubebuilder:validation:Required
+	ServiceAccountNamespace string `json:"serviceAccountNamespace"`
+
+	// Name of the service account allowed to assume the role
+	// +kubebuilder:validation:Required
+	ServiceAccountName string `json:"serviceAccountName"`
+}
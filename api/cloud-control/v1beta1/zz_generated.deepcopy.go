@@ -26,6 +26,43 @@ import (
 	v2 "k8s.io/klog/v2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptOptions) DeepCopyInto(out *AdoptOptions) {
+	*out = *in
+	if in.MatchTags != nil {
+		in, out := &in.MatchTags, &out.MatchTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptOptions.
+func (in *AdoptOptions) DeepCopy() *AdoptOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsLifecyclePolicy) DeepCopyInto(out *AwsLifecyclePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsLifecyclePolicy.
+func (in *AwsLifecyclePolicy) DeepCopy() *AwsLifecyclePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsLifecyclePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsNetwork) DeepCopyInto(out *AwsNetwork) {
 	*out = *in
@@ -62,6 +99,21 @@ func (in *AwsNetworkReference) DeepCopy() *AwsNetworkReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsProvisionedThroughput) DeepCopyInto(out *AwsProvisionedThroughput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsProvisionedThroughput.
+func (in *AwsProvisionedThroughput) DeepCopy() *AwsProvisionedThroughput {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsProvisionedThroughput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsScope) DeepCopyInto(out *AwsScope) {
 	*out = *in
@@ -219,6 +271,216 @@ func (in *AzureVpcPeering) DeepCopy() *AzureVpcPeering {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupOptions) DeepCopyInto(out *BackupOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupOptions.
+func (in *BackupOptions) DeepCopy() *BackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	if in.LatestBackupTime != nil {
+		in, out := &in.LatestBackupTime, &out.LatestBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextBackupTime != nil {
+		in, out := &in.NextBackupTime, &out.NextBackupTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventory) DeepCopyInto(out *CloudResourceInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventory.
+func (in *CloudResourceInventory) DeepCopy() *CloudResourceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudResourceInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventoryKind) DeepCopyInto(out *CloudResourceInventoryKind) {
+	*out = *in
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventoryKind.
+func (in *CloudResourceInventoryKind) DeepCopy() *CloudResourceInventoryKind {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventoryKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventoryList) DeepCopyInto(out *CloudResourceInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudResourceInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventoryList.
+func (in *CloudResourceInventoryList) DeepCopy() *CloudResourceInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudResourceInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventoryScope) DeepCopyInto(out *CloudResourceInventoryScope) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]CloudResourceInventoryKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventoryScope.
+func (in *CloudResourceInventoryScope) DeepCopy() *CloudResourceInventoryScope {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventoryScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventorySpec) DeepCopyInto(out *CloudResourceInventorySpec) {
+	*out = *in
+	if in.MinUpdateInterval != nil {
+		in, out := &in.MinUpdateInterval, &out.MinUpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventorySpec.
+func (in *CloudResourceInventorySpec) DeepCopy() *CloudResourceInventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceInventoryStatus) DeepCopyInto(out *CloudResourceInventoryStatus) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]CloudResourceInventoryScope, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceInventoryStatus.
+func (in *CloudResourceInventoryStatus) DeepCopy() *CloudResourceInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionHistoryEntry) DeepCopyInto(out *ConditionHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionHistoryEntry.
+func (in *ConditionHistoryEntry) DeepCopy() *ConditionHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DayOfWeekPolicyGcp) DeepCopyInto(out *DayOfWeekPolicyGcp) {
 	*out = *in
@@ -235,6 +497,72 @@ func (in *DayOfWeekPolicyGcp) DeepCopy() *DayOfWeekPolicyGcp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsOptions) DeepCopyInto(out *DnsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsOptions.
+func (in *DnsOptions) DeepCopy() *DnsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsStatus) DeepCopyInto(out *DnsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsStatus.
+func (in *DnsStatus) DeepCopy() *DnsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunOperation) DeepCopyInto(out *DryRunOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunOperation.
+func (in *DryRunOperation) DeepCopy() *DryRunOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunPlan) DeepCopyInto(out *DryRunPlan) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]DryRunOperation, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunPlan.
+func (in *DryRunPlan) DeepCopy() *DryRunPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GcpNetwork) DeepCopyInto(out *GcpNetwork) {
 	*out = *in
@@ -353,6 +681,16 @@ func (in *IpRange) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeAws) DeepCopyInto(out *IpRangeAws) {
 	*out = *in
+	if in.ShareWithAccounts != nil {
+		in, out := &in.ShareWithAccounts, &out.ShareWithAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(IpRangeAwsTransitGateway)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeAws.
@@ -365,6 +703,21 @@ func (in *IpRangeAws) DeepCopy() *IpRangeAws {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeAwsTransitGateway) DeepCopyInto(out *IpRangeAwsTransitGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeAwsTransitGateway.
+func (in *IpRangeAwsTransitGateway) DeepCopy() *IpRangeAwsTransitGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeAwsTransitGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeAzure) DeepCopyInto(out *IpRangeAzure) {
 	*out = *in
@@ -395,6 +748,21 @@ func (in *IpRangeGcp) DeepCopy() *IpRangeGcp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeExternalIpam) DeepCopyInto(out *IpRangeExternalIpam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeExternalIpam.
+func (in *IpRangeExternalIpam) DeepCopy() *IpRangeExternalIpam {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeExternalIpam)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeList) DeepCopyInto(out *IpRangeList) {
 	*out = *in
@@ -443,7 +811,7 @@ func (in *IpRangeOptions) DeepCopyInto(out *IpRangeOptions) {
 	if in.Aws != nil {
 		in, out := &in.Aws, &out.Aws
 		*out = new(IpRangeAws)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -457,6 +825,119 @@ func (in *IpRangeOptions) DeepCopy() *IpRangeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangePool) DeepCopyInto(out *IpRangePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangePool.
+func (in *IpRangePool) DeepCopy() *IpRangePool {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IpRangePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangePoolList) DeepCopyInto(out *IpRangePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IpRangePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangePoolList.
+func (in *IpRangePoolList) DeepCopy() *IpRangePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IpRangePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangePoolOptions) DeepCopyInto(out *IpRangePoolOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangePoolOptions.
+func (in *IpRangePoolOptions) DeepCopy() *IpRangePoolOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangePoolOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangePoolSpec) DeepCopyInto(out *IpRangePoolSpec) {
+	*out = *in
+	out.Scope = in.Scope
+	out.Pool = in.Pool
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangePoolSpec.
+func (in *IpRangePoolSpec) DeepCopy() *IpRangePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangePoolStatus) DeepCopyInto(out *IpRangePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangePoolStatus.
+func (in *IpRangePoolStatus) DeepCopy() *IpRangePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeRef) DeepCopyInto(out *IpRangeRef) {
 	*out = *in
@@ -472,17 +953,87 @@ func (in *IpRangeRef) DeepCopy() *IpRangeRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeResourceShare) DeepCopyInto(out *IpRangeResourceShare) {
+	*out = *in
+	if in.AcceptedAccounts != nil {
+		in, out := &in.AcceptedAccounts, &out.AcceptedAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeResourceShare.
+func (in *IpRangeResourceShare) DeepCopy() *IpRangeResourceShare {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeResourceShare)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeSecondaryRange) DeepCopyInto(out *IpRangeSecondaryRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeSecondaryRange.
+func (in *IpRangeSecondaryRange) DeepCopy() *IpRangeSecondaryRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeSecondaryRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeSpec) DeepCopyInto(out *IpRangeSpec) {
 	*out = *in
 	out.RemoteRef = in.RemoteRef
 	out.Scope = in.Scope
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Options.DeepCopyInto(&out.Options)
 	if in.Network != nil {
 		in, out := &in.Network, &out.Network
 		*out = new(v2.ObjectRef)
 		**out = **in
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make([]IpRangeZoneSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalIpam != nil {
+		in, out := &in.ExternalIpam, &out.ExternalIpam
+		*out = new(IpRangeExternalIpam)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityZoneIds != nil {
+		in, out := &in.AvailabilityZoneIds, &out.AvailabilityZoneIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TtlSecondsAfterCreation != nil {
+		in, out := &in.TtlSecondsAfterCreation, &out.TtlSecondsAfterCreation
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SecondaryRanges != nil {
+		in, out := &in.SecondaryRanges, &out.SecondaryRanges
+		*out = make([]IpRangeSecondaryRange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeSpec.
@@ -498,16 +1049,53 @@ func (in *IpRangeSpec) DeepCopy() *IpRangeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeStatus) DeepCopyInto(out *IpRangeStatus) {
 	*out = *in
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Ranges != nil {
 		in, out := &in.Ranges, &out.Ranges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make([]IpRangeZoneSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReservedCidrs != nil {
+		in, out := &in.ReservedCidrs, &out.ReservedCidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make(IpRangeSubnets, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecondaryRanges != nil {
+		in, out := &in.SecondaryRanges, &out.SecondaryRanges
+		*out = make([]IpRangeSecondaryRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZoneFallbacks != nil {
+		in, out := &in.ZoneFallbacks, &out.ZoneFallbacks
+		*out = make([]IpRangeZoneFallback, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZonePools != nil {
+		in, out := &in.ZonePools, &out.ZonePools
+		*out = make([]IpRangeZonePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PropagatedTags != nil {
+		in, out := &in.PropagatedTags, &out.PropagatedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -515,6 +1103,24 @@ func (in *IpRangeStatus) DeepCopyInto(out *IpRangeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceShare != nil {
+		in, out := &in.ResourceShare, &out.ResourceShare
+		*out = new(IpRangeResourceShare)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DryRunPlan != nil {
+		in, out := &in.DryRunPlan, &out.DryRunPlan
+		*out = new(DryRunPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TtlExpiresAt != nil {
+		in, out := &in.TtlExpiresAt, &out.TtlExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeStatus.
@@ -561,6 +1167,92 @@ func (in IpRangeSubnets) DeepCopy() IpRangeSubnets {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeZoneFallback) DeepCopyInto(out *IpRangeZoneFallback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeZoneFallback.
+func (in *IpRangeZoneFallback) DeepCopy() *IpRangeZoneFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeZoneFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeZonePool) DeepCopyInto(out *IpRangeZonePool) {
+	*out = *in
+	if in.Ranges != nil {
+		in, out := &in.Ranges, &out.Ranges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeZonePool.
+func (in *IpRangeZonePool) DeepCopy() *IpRangeZonePool {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeZonePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpRangeZoneSubnet) DeepCopyInto(out *IpRangeZoneSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeZoneSubnet.
+func (in *IpRangeZoneSubnet) DeepCopy() *IpRangeZoneSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(IpRangeZoneSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastError) DeepCopyInto(out *LastError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastError.
+func (in *LastError) DeepCopy() *LastError {
+	if in == nil {
+		return nil
+	}
+	out := new(LastError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceFreezeWindow) DeepCopyInto(out *MaintenanceFreezeWindow) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]DayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceFreezeWindow.
+func (in *MaintenanceFreezeWindow) DeepCopy() *MaintenanceFreezeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceFreezeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaintenancePolicyGcp) DeepCopyInto(out *MaintenancePolicyGcp) {
 	*out = *in
@@ -775,6 +1467,31 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsConnection) DeepCopyInto(out *NfsConnection) {
+	*out = *in
+	if in.MountTargets != nil {
+		in, out := &in.MountTargets, &out.MountTargets
+		*out = make([]NfsMountTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsConnection.
+func (in *NfsConnection) DeepCopy() *NfsConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsInstance) DeepCopyInto(out *NfsInstance) {
 	*out = *in
@@ -805,6 +1522,43 @@ func (in *NfsInstance) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsInstanceAws) DeepCopyInto(out *NfsInstanceAws) {
 	*out = *in
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(AwsProvisionedThroughput)
+		**out = **in
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadAccess != nil {
+		in, out := &in.WorkloadAccess, &out.WorkloadAccess
+		*out = new(WorkloadAccessOptions)
+		**out = **in
+	}
+	if in.LifecyclePolicy != nil {
+		in, out := &in.LifecyclePolicy, &out.LifecyclePolicy
+		*out = new(AwsLifecyclePolicy)
+		**out = **in
+	}
+	if in.AutomaticBackups != nil {
+		in, out := &in.AutomaticBackups, &out.AutomaticBackups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MountTargetIps != nil {
+		in, out := &in.MountTargetIps, &out.MountTargetIps
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsInstanceAws.
@@ -863,7 +1617,7 @@ func (in *NfsInstanceInfo) DeepCopyInto(out *NfsInstanceInfo) {
 	if in.Aws != nil {
 		in, out := &in.Aws, &out.Aws
 		*out = new(NfsInstanceAws)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OpenStack != nil {
 		in, out := &in.OpenStack, &out.OpenStack
@@ -936,6 +1690,38 @@ func (in *NfsInstanceSpec) DeepCopyInto(out *NfsInstanceSpec) {
 	out.IpRange = in.IpRange
 	out.Scope = in.Scope
 	in.Instance.DeepCopyInto(&out.Instance)
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(AdoptOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(DnsOptions)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupOptions)
+		**out = **in
+	}
+	if in.DeletionGracePeriodSeconds != nil {
+		in, out := &in.DeletionGracePeriodSeconds, &out.DeletionGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsInstanceSpec.
@@ -970,6 +1756,72 @@ func (in *NfsInstanceStatus) DeepCopyInto(out *NfsInstanceStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Replica != nil {
+		in, out := &in.Replica, &out.Replica
+		*out = new(ReplicaStatus)
+		**out = **in
+	}
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(DnsStatus)
+		**out = **in
+	}
+	if in.PropagatedTags != nil {
+		in, out := &in.PropagatedTags, &out.PropagatedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LifecyclePolicy != nil {
+		in, out := &in.LifecyclePolicy, &out.LifecyclePolicy
+		*out = new(AwsLifecyclePolicy)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastThroughputScaleTime != nil {
+		in, out := &in.LastThroughputScaleTime, &out.LastThroughputScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastThroughputDecreaseTime != nil {
+		in, out := &in.LastThroughputDecreaseTime, &out.LastThroughputDecreaseTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MountTargets != nil {
+		in, out := &in.MountTargets, &out.MountTargets
+		*out = make([]NfsMountTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(NfsConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(LastError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]ConditionHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]NfsRegionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsInstanceStatus.
@@ -982,6 +1834,48 @@ func (in *NfsInstanceStatus) DeepCopy() *NfsInstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsMountTarget) DeepCopyInto(out *NfsMountTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsMountTarget.
+func (in *NfsMountTarget) DeepCopy() *NfsMountTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsMountTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsRegionStatus) DeepCopyInto(out *NfsRegionStatus) {
+	*out = *in
+	if in.MountTargets != nil {
+		in, out := &in.MountTargets, &out.MountTargets
+		*out = make([]NfsMountTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsRegionStatus.
+func (in *NfsRegionStatus) DeepCopy() *NfsRegionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsRegionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NfsOptionsGcp) DeepCopyInto(out *NfsOptionsGcp) {
 	*out = *in
@@ -1048,6 +1942,21 @@ func (in *OpenStackScope) DeepCopy() *OpenStackScope {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisConnection) DeepCopyInto(out *RedisConnection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisConnection.
+func (in *RedisConnection) DeepCopy() *RedisConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisInstance) DeepCopyInto(out *RedisInstance) {
 	*out = *in
@@ -1090,6 +1999,26 @@ func (in *RedisInstanceAws) DeepCopyInto(out *RedisInstanceAws) {
 			(*out)[key] = val
 		}
 	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogDelivery != nil {
+		in, out := &in.LogDelivery, &out.LogDelivery
+		*out = new(RedisInstanceAwsLogDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicasPerShard != nil {
+		in, out := &in.ReplicasPerShard, &out.ReplicasPerShard
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceAws.
@@ -1102,6 +2031,46 @@ func (in *RedisInstanceAws) DeepCopy() *RedisInstanceAws {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisInstanceAwsLogDelivery) DeepCopyInto(out *RedisInstanceAwsLogDelivery) {
+	*out = *in
+	if in.SlowLog != nil {
+		in, out := &in.SlowLog, &out.SlowLog
+		*out = new(RedisInstanceAwsLogDestination)
+		**out = **in
+	}
+	if in.EngineLog != nil {
+		in, out := &in.EngineLog, &out.EngineLog
+		*out = new(RedisInstanceAwsLogDestination)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceAwsLogDelivery.
+func (in *RedisInstanceAwsLogDelivery) DeepCopy() *RedisInstanceAwsLogDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisInstanceAwsLogDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisInstanceAwsLogDestination) DeepCopyInto(out *RedisInstanceAwsLogDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceAwsLogDestination.
+func (in *RedisInstanceAwsLogDestination) DeepCopy() *RedisInstanceAwsLogDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisInstanceAwsLogDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisInstanceAzure) DeepCopyInto(out *RedisInstanceAzure) {
 	*out = *in
@@ -1223,6 +2192,21 @@ func (in *RedisInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisInstanceLogDeliveryStatus) DeepCopyInto(out *RedisInstanceLogDeliveryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceLogDeliveryStatus.
+func (in *RedisInstanceLogDeliveryStatus) DeepCopy() *RedisInstanceLogDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisInstanceLogDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisInstanceSpec) DeepCopyInto(out *RedisInstanceSpec) {
 	*out = *in
@@ -1230,6 +2214,35 @@ func (in *RedisInstanceSpec) DeepCopyInto(out *RedisInstanceSpec) {
 	out.IpRange = in.IpRange
 	out.Scope = in.Scope
 	in.Instance.DeepCopyInto(&out.Instance)
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(DnsOptions)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletionGracePeriodSeconds != nil {
+		in, out := &in.DeletionGracePeriodSeconds, &out.DeletionGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaintenanceFreeze != nil {
+		in, out := &in.MaintenanceFreeze, &out.MaintenanceFreeze
+		*out = make([]MaintenanceFreezeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceSpec.
@@ -1245,6 +2258,11 @@ func (in *RedisInstanceSpec) DeepCopy() *RedisInstanceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisInstanceStatus) DeepCopyInto(out *RedisInstanceStatus) {
 	*out = *in
+	if in.Replica != nil {
+		in, out := &in.Replica, &out.Replica
+		*out = new(ReplicaStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1252,6 +2270,42 @@ func (in *RedisInstanceStatus) DeepCopyInto(out *RedisInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(DnsStatus)
+		**out = **in
+	}
+	if in.PropagatedTags != nil {
+		in, out := &in.PropagatedTags, &out.PropagatedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogDeliveries != nil {
+		in, out := &in.LogDeliveries, &out.LogDeliveries
+		*out = make([]RedisInstanceLogDeliveryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(RedisConnection)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(LastError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]ConditionHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisInstanceStatus.
@@ -1279,6 +2333,41 @@ func (in *RemoteRef) DeepCopy() *RemoteRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationOptions) DeepCopyInto(out *ReplicationOptions) {
+	*out = *in
+	if in.SubnetIds != nil {
+		in, out := &in.SubnetIds, &out.SubnetIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationOptions.
+func (in *ReplicationOptions) DeepCopy() *ReplicationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scope) DeepCopyInto(out *Scope) {
 	*out = *in
@@ -1392,6 +2481,13 @@ func (in *ScopeRef) DeepCopy() *ScopeRef {
 func (in *ScopeSpec) DeepCopyInto(out *ScopeSpec) {
 	*out = *in
 	in.Scope.DeepCopyInto(&out.Scope)
+	if in.DefaultTags != nil {
+		in, out := &in.DefaultTags, &out.DefaultTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeSpec.
@@ -1568,6 +2664,21 @@ func (in *VpcPeeringList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringRoute) DeepCopyInto(out *VpcPeeringRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringRoute.
+func (in *VpcPeeringRoute) DeepCopy() *VpcPeeringRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcPeeringSpec) DeepCopyInto(out *VpcPeeringSpec) {
 	*out = *in
@@ -1598,6 +2709,11 @@ func (in *VpcPeeringSpec) DeepCopy() *VpcPeeringSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcPeeringStatus) DeepCopyInto(out *VpcPeeringStatus) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VpcPeeringRoute, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1616,3 +2732,18 @@ func (in *VpcPeeringStatus) DeepCopy() *VpcPeeringStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadAccessOptions) DeepCopyInto(out *WorkloadAccessOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadAccessOptions.
+func (in *WorkloadAccessOptions) DeepCopy() *WorkloadAccessOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadAccessOptions)
+	in.DeepCopyInto(out)
+	return out
+}
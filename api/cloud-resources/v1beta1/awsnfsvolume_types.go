@@ -46,6 +46,12 @@ type AwsNfsVolumeSpec struct {
 	// +optional
 	IpRange IpRangeRef `json:"ipRange"`
 
+	// Create the specified IpRange with the default cidr if it does not exist, instead of failing
+	// with the IpRangeNotFound error. Only one IpRange is auto-provisioned per Scope, and it is
+	// deleted with the last object referencing it.
+	// +optional
+	AutoProvisionDependencies bool `json:"autoProvisionDependencies,omitempty"`
+
 	// +kubebuilder:validation:Required
 	Capacity resource.Quantity `json:"capacity"`
 
@@ -55,6 +61,14 @@ type AwsNfsVolumeSpec struct {
 	// +kubebuilder:default=bursting
 	Throughput AwsThroughputMode `json:"throughput,omitempty"`
 
+	// Regions are the additional regions the same volume is provisioned in, active-active, each with
+	// its own file system in the VPC of the cluster network name. The file system of a region removed
+	// from the list is deleted.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=4
+	Regions []string `json:"regions,omitempty"`
+
 	PersistentVolume *AwsNfsVolumePvSpec `json:"volume,omitempty"`
 
 	PersistentVolumeClaim *AwsNfsVolumePvcSpec `json:"volumeClaim,omitempty"`
@@ -81,6 +95,10 @@ type AwsNfsVolumeStatus struct {
 	// +optional
 	Server string `json:"server,omitempty"`
 
+	// Connection the workloads mount the volume with
+	// +optional
+	Connection *NfsConnection `json:"connection,omitempty"`
+
 	// List of status conditions
 	// +optional
 	// +listType=map
@@ -125,6 +143,10 @@ func (in *AwsNfsVolume) GetIpRangeRef() IpRangeRef {
 	return in.Spec.IpRange
 }
 
+func (in *AwsNfsVolume) GetAutoProvisionDependencies() bool {
+	return in.Spec.AutoProvisionDependencies
+}
+
 func (in *AwsNfsVolume) State() string {
 	return in.Status.State
 }
@@ -79,6 +79,10 @@ type AwsRedisInstanceStatus struct {
 
 	// +optional
 	State string `json:"state,omitempty"`
+
+	// Connection the workloads connect to the instance with, the credentials are in the auth Secret
+	// +optional
+	Connection *RedisConnection `json:"connection,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -22,11 +22,18 @@ import (
 )
 
 // AwsVpcPeeringSpec defines the desired state of AwsVpcPeering
+// +kubebuilder:validation:XValidation:rule=(has(self.remoteVpcId) && self.remoteVpcId != "" || has(self.vpcNameTag) && self.vpcNameTag != ""), message="One of remoteVpcId or vpcNameTag is required."
 type AwsVpcPeeringSpec struct {
 
-	// +kubebuilder:validation:Required
+	// +optional
 	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="RemoteVpcId is immutable."
-	RemoteVpcId string `json:"remoteVpcId"`
+	RemoteVpcId string `json:"remoteVpcId,omitempty"`
+
+	// VpcNameTag is the Name tag value of the remote VPC, used to look up the remote VPC when its ID
+	// is not known in advance. If the RemoteVpcId is specified it takes precedence.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="VpcNameTag is immutable."
+	VpcNameTag string `json:"vpcNameTag,omitempty"`
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="RemoteRegion is immutable."
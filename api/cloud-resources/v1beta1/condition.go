@@ -59,6 +59,20 @@ const (
 	ConditionTypeQuotaExceeded = "QuotaExceeded"
 )
 
+const (
+	// ConditionTypeDependencyAutoProvisioned is set when the object dependency it specified, like the IpRange,
+	// did not exist and was created since the object has the autoProvisionDependencies spec enabled
+	ConditionTypeDependencyAutoProvisioned = "DependencyAutoProvisioned"
+
+	ConditionReasonIpRangeAutoProvisioned = "IpRangeAutoProvisioned"
+)
+
+const (
+	// ConditionTypeBackupConfigurationFailed is mirrored from the KCP resource while the backups of the volume
+	// can not be configured, without affecting its readiness
+	ConditionTypeBackupConfigurationFailed = "BackupConfigurationFailed"
+)
+
 const (
 	ReasonInvalidCronExpression = "InvalidCronExpression"
 	ReasonTimeParseError        = "TimeParseError"
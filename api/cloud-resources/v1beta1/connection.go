@@ -0,0 +1,54 @@
+package v1beta1
+
+// NfsConnection is the information the workloads mount the volume with, of the same shape for all providers
+type NfsConnection struct {
+	// Host the share is mounted from, a DNS name or an IP address
+	Host string `json:"host"`
+
+	// Absolute path of the share on the host, mounted as <host>:<path>
+	Path string `json:"path"`
+
+	// Mount targets of the share by zone, the workloads should mount the one in their own zone if any
+	// +optional
+	// +listType=map
+	// +listMapKey=zone
+	MountTargets []NfsMountTarget `json:"mountTargets,omitempty"`
+
+	// Mount options recommended by the provider for the share
+	// +optional
+	MountOptions []string `json:"mountOptions,omitempty"`
+}
+
+type NfsMountTarget struct {
+	Zone string `json:"zone"`
+
+	// IPv4 address of the mount target
+	// +optional
+	IpAddress string `json:"ipAddress,omitempty"`
+}
+
+// RedisConnection is the information the workloads connect to the instance with, of the same shape
+// for all providers. The credentials are never part of it, they are delivered in the auth Secret.
+type RedisConnection struct {
+	// Host of the primary endpoint, or of the configuration endpoint in the cluster mode
+	Host string `json:"host"`
+
+	// Port of the primary endpoint, or of the configuration endpoint in the cluster mode
+	Port int32 `json:"port"`
+
+	// Host of the read endpoint, if the instance has read replicas
+	// +optional
+	ReadHost string `json:"readHost,omitempty"`
+
+	// Port of the read endpoint, if the instance has read replicas
+	// +optional
+	ReadPort int32 `json:"readPort,omitempty"`
+
+	// Tls is true if the connections must use TLS
+	// +optional
+	Tls bool `json:"tls,omitempty"`
+
+	// ClusterMode is true if the clients must discover the shards from the configuration endpoint
+	// +optional
+	ClusterMode bool `json:"clusterMode,omitempty"`
+}
@@ -66,6 +66,12 @@ type GcpNfsVolumeSpec struct {
 	// +optional
 	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="IpRange is immutable."
 	IpRange IpRangeRef `json:"ipRange"`
+
+	// Create the specified IpRange with the default cidr if it does not exist, instead of failing
+	// with the IpRangeNotFound error. Only one IpRange is auto-provisioned per Scope, and it is
+	// deleted with the last object referencing it.
+	// +optional
+	AutoProvisionDependencies bool `json:"autoProvisionDependencies,omitempty"`
 	// +optional
 	// +kubebuilder:validation:XValidation:rule=(self == oldSelf), message="Location is immutable."
 	Location string `json:"location"`
@@ -114,6 +120,10 @@ type GcpNfsVolumeStatus struct {
 	// +optional
 	Hosts []string `json:"hosts,omitempty"`
 
+	// Connection the workloads mount the volume with
+	// +optional
+	Connection *NfsConnection `json:"connection,omitempty"`
+
 	// Capacity of the volume with Ready Condition
 	// +optional
 	CapacityGb int `json:"capacityGb"`
@@ -158,6 +168,10 @@ func (in *GcpNfsVolume) GetIpRangeRef() IpRangeRef {
 	return in.Spec.IpRange
 }
 
+func (in *GcpNfsVolume) GetAutoProvisionDependencies() bool {
+	return in.Spec.AutoProvisionDependencies
+}
+
 func (in *GcpNfsVolume) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
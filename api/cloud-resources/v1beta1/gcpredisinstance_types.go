@@ -122,6 +122,10 @@ type GcpRedisInstanceStatus struct {
 
 	// +optional
 	State string `json:"state,omitempty"`
+
+	// Connection the workloads connect to the instance with, the credentials are in the auth Secret
+	// +optional
+	Connection *RedisConnection `json:"connection,omitempty"`
 }
 
 // +kubebuilder:object:root=true
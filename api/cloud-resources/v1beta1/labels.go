@@ -6,6 +6,7 @@ const (
 	LabelNfsVolName      = "cloud-resources.kyma-project.io/nfsVolumeName"
 	LabelNfsVolNS        = "cloud-resources.kyma-project.io/nfsVolumeNamespace"
 	LabelStorageCapacity = "cloud-resources.kyma-project.io/nfsVolumeStorageCapacity"
+	LabelAutoProvisioned = "cloud-resources.kyma-project.io/autoProvisioned"
 
 	LabelRedisInstanceStatusId  = "cloud-resources.kyma-project.io/redisInstanceStatusId"
 	LabelRedisInstanceNamespace = "cloud-resources.kyma-project.io/redisInstanceNamespace"
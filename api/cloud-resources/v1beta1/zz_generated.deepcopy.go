@@ -316,6 +316,11 @@ func (in *AwsNfsVolumeSpec) DeepCopyInto(out *AwsNfsVolumeSpec) {
 	*out = *in
 	out.IpRange = in.IpRange
 	out.Capacity = in.Capacity.DeepCopy()
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PersistentVolume != nil {
 		in, out := &in.PersistentVolume, &out.PersistentVolume
 		*out = new(AwsNfsVolumePvSpec)
@@ -341,6 +346,11 @@ func (in *AwsNfsVolumeSpec) DeepCopy() *AwsNfsVolumeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsNfsVolumeStatus) DeepCopyInto(out *AwsNfsVolumeStatus) {
 	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(NfsConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -462,6 +472,11 @@ func (in *AwsRedisInstanceStatus) DeepCopyInto(out *AwsRedisInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(RedisConnection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsRedisInstanceStatus.
@@ -662,6 +677,11 @@ func (in *AzureRedisInstanceStatus) DeepCopyInto(out *AzureRedisInstanceStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(RedisConnection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRedisInstanceStatus.
@@ -1560,6 +1580,11 @@ func (in *GcpNfsVolumeStatus) DeepCopyInto(out *GcpNfsVolumeStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(NfsConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1681,6 +1706,11 @@ func (in *GcpRedisInstanceStatus) DeepCopyInto(out *GcpRedisInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(RedisConnection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpRedisInstanceStatus.
@@ -1949,6 +1979,46 @@ func (in *NameLabelsAnnotationsSpec) DeepCopy() *NameLabelsAnnotationsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsConnection) DeepCopyInto(out *NfsConnection) {
+	*out = *in
+	if in.MountTargets != nil {
+		in, out := &in.MountTargets, &out.MountTargets
+		*out = make([]NfsMountTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsConnection.
+func (in *NfsConnection) DeepCopy() *NfsConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NfsMountTarget) DeepCopyInto(out *NfsMountTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NfsMountTarget.
+func (in *NfsMountTarget) DeepCopy() *NfsMountTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NfsMountTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisAuthSecretSpec) DeepCopyInto(out *RedisAuthSecretSpec) {
 	*out = *in
@@ -1978,6 +2048,21 @@ func (in *RedisAuthSecretSpec) DeepCopy() *RedisAuthSecretSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisConnection) DeepCopyInto(out *RedisConnection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisConnection.
+func (in *RedisConnection) DeepCopy() *RedisConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisInstanceAzureConfigs) DeepCopyInto(out *RedisInstanceAzureConfigs) {
 	*out = *in
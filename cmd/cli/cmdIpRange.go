@@ -0,0 +1,12 @@
+package main
+
+import "github.com/spf13/cobra"
+
+func init() {
+	cmdRoot.AddCommand(cmdIpRange)
+}
+
+var cmdIpRange = &cobra.Command{
+	Use:     "iprange",
+	Aliases: []string{"ipr"},
+}
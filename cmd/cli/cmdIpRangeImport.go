@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/cmd/cli/helper"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
+	awsiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/v2"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	importScopeName string
+	importMatchTags map[string]string
+	importDryRun    bool
+)
+
+func init() {
+	cmdIpRangeImport.Flags().StringVarP(&importScopeName, "scope", "s", "", "Scope name")
+	cmdIpRangeImport.Flags().StringToStringVarP(&importMatchTags, "match-tag", "t", nil, "Tag the subnets not tagged by cloud-manager must have to be imported, as key=value")
+	cmdIpRangeImport.Flags().BoolVar(&importDryRun, "dry-run", false, "Only print the IpRanges that would be created")
+	cmdIpRange.AddCommand(cmdIpRangeImport)
+}
+
+var cmdIpRangeImport = &cobra.Command{
+	Use:   "import",
+	Short: "Creates the KCP IpRanges for the existing subnets of the AWS Scope VPC",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mustAll(
+			requiredString(importScopeName, "scope"),
+			defaultKcpNamespace(),
+		)
+
+		ctx := context.Background()
+		c := helper.NewKcpClient()
+
+		scope := &cloudcontrolv1beta1.Scope{}
+		name := types.NamespacedName{Namespace: namespace, Name: importScopeName}
+		if err := c.Get(ctx, name, scope); err != nil {
+			return fmt.Errorf("error loading Scope %s: %w", name, err)
+		}
+		if scope.Spec.Scope.Aws == nil {
+			return fmt.Errorf("scope %s is not an AWS scope", name)
+		}
+
+		awsClient, err := iprangeclient.NewClientProvider()(
+			ctx,
+			scope.Spec.Region,
+			os.Getenv("AWS_ACCESS_KEY_ID"),
+			os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			fmt.Sprintf("arn:aws:iam::%s:role/%s", scope.Spec.Scope.Aws.AccountId, os.Getenv("AWS_ROLE_NAME")),
+		)
+		if err != nil {
+			return fmt.Errorf("error creating AWS client: %w", err)
+		}
+
+		cluster := composed.NewStateCluster(c, c, nil, helper.KcpScheme)
+		state := composed.NewStateFactory(cluster).NewState(name, scope)
+
+		summary := &awsiprange.ImportSummary{}
+		err, _ = awsiprange.NewImportExistingIpRanges(awsClient, awsiprange.ImportOptions{
+			Namespace: namespace,
+			MatchTags: importMatchTags,
+			DryRun:    importDryRun,
+		}, summary)(ctx, state)
+		fmt.Print(summary.String())
+		return err
+	},
+}
@@ -18,8 +18,10 @@ package main
 
 import (
 	"flag"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
 	azureiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/iprange/client"
 	"os"
+	"time"
 
 	cceeconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/ccee/config"
 	cceenfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/ccee/nfsinstance/client"
@@ -29,15 +31,24 @@ import (
 	"github.com/elliotchance/pie/v2"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/config"
 	"github.com/kyma-project/cloud-manager/pkg/feature"
 	featuretypes "github.com/kyma-project/cloud-manager/pkg/feature/types"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/ha"
+	awscloudevents "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/cloudevents"
 	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
 	azureconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/config"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/scope"
+	"github.com/kyma-project/cloud-manager/pkg/metrics"
+	"github.com/kyma-project/cloud-manager/pkg/notification"
 	"github.com/kyma-project/cloud-manager/pkg/quota"
 
+	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	awsdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/dns/client"
 	awsiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
 	awsnfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
 	awsredisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
@@ -46,6 +57,7 @@ import (
 	azureredisclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/redisinstance/client"
 	azurevpcpeeringclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/vpcpeering/client"
 	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	gcpiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange/client"
 	gcpnfsbackupclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsbackup/client"
 	gcpFilestoreClient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
@@ -70,6 +82,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -78,6 +91,7 @@ import (
 	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
 	cloudcontrolcontroller "github.com/kyma-project/cloud-manager/internal/controller/cloud-control"
 	cloudresourcescontroller "github.com/kyma-project/cloud-manager/internal/controller/cloud-resources"
+	cloudcontrolwebhook "github.com/kyma-project/cloud-manager/internal/webhook/cloud-control"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -103,18 +117,165 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var gcpStructuredLogging bool
+	var finalizerName string
+	var notificationWebhookUrl string
+	var conditionSeverities string
+	var conditionTypeMapping string
+	var priorityQueue bool
+	var resourceLockHolder string
+	var resourceLockTtl time.Duration
+	var pollIntervals string
+	var statusRedactions string
+	var allowProviderMismatch bool
+	var endpointProbe bool
+	var endpointProbeTimeout time.Duration
+	var propagateLabelPrefix string
+	var enableWebhooks bool
+	var awsCloudEventsQueueUrl string
+	var ipRangeConcurrency int
+	var nfsConcurrency int
+	var redisConcurrency int
+	var vpcPeeringConcurrency int
+	var networkConcurrency int
+	var shutdownDrainTimeout time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&gcpStructuredLogging, "gcp-structured-logging", false, "Enable GCP structured logging")
+	flag.StringVar(&finalizerName, "finalizer-name", cloudcontrolv1beta1.FinalizerName,
+		"The finalizer added to the reconciled objects. "+
+			"Set a different value to run two cloud-manager instances against the same cluster.")
+	flag.StringVar(&notificationWebhookUrl, "notification-webhook-url", "",
+		"The url JSON notifications about objects getting Ready, Error or Deleted are POSTed to. "+
+			"If empty, no notifications are sent.")
+	flag.StringVar(&conditionSeverities, "condition-severities", "",
+		"Comma separated reason=severity pairs, for example ZoneCapacityUnavailable=Error, overriding the default "+
+			"Info, Warning or Error severity of the condition reasons recorded as the condition message prefix. "+
+			"If empty, the severity is not recorded and the condition messages are not prefixed.")
+	flag.StringVar(&conditionTypeMapping, "condition-type-mapping", "",
+		"Comma separated internal=public pairs renaming the condition types written in the status, "+
+			"for example CidrOverlap=NetworkConflict. If empty, the condition types are not renamed.")
+	flag.StringVar(&resourceLockHolder, "resource-lock-holder", "",
+		"The holder id the KCP NfsInstance, RedisInstance, IpRange and VpcPeering controllers take the Lease lock "+
+			"of the reconciled object with, so they do not edit it concurrently with other tools taking the same lock. "+
+			"If empty, the objects are not locked.")
+	flag.DurationVar(&resourceLockTtl, "resource-lock-ttl", composed.DefaultResourceLockTTL,
+		"The duration the resource lock is held for, after which other holders can reclaim it.")
+	flag.BoolVar(&priorityQueue, "priority-queue", false,
+		"Use the priority work queue in the KCP NfsInstance, RedisInstance, IpRange and VpcPeering controllers, "+
+			"dequeuing the objects marked for deletion before the others of the same kind.")
+	flag.StringVar(&pollIntervals, "poll-intervals", "",
+		"Comma separated resourceType=initial:max pairs, for example AwsRedisInstance=10s:5m, of the adaptive "+
+			"requeue delays of the actions waiting for the cloud resources. If empty, the action defaults are used.")
+	flag.StringVar(&statusRedactions, "status-redactions", "",
+		"Comma separated Kind.field=mode items, for example RedisInstance.logDeliveries.destination=omit, "+
+			"of the status fields masked or omitted in the written status. Only the fields not read back by the "+
+			"reconciliation can be redacted. If the mode is not set the field is masked. If empty, the status is written as is.")
+	flag.BoolVar(&allowProviderMismatch, "allow-provider-mismatch", false,
+		"Allow the resources to override the provider with one different from their Scope provider, "+
+			"if the Scope has it configured. If false, such resources are rejected with the ProviderMismatch reason.")
+	flag.BoolVar(&endpointProbe, "endpoint-probe", false,
+		"Enable the active health check of the RedisInstance and NfsInstance endpoints reflected in the EndpointReachable "+
+			"condition. Enable only if the cloud-manager network can reach the managed networks.")
+	flag.DurationVar(&endpointProbeTimeout, "endpoint-probe-timeout", actions.DefaultEndpointProbeTimeout,
+		"The timeout of the endpoint probe connection.")
+	flag.StringVar(&propagateLabelPrefix, "propagate-label-prefix", "",
+		"The prefix of the Scope labels propagated as tags, without the prefix, to the cloud resources. "+
+			"If empty, the Scope labels are not propagated.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating admission webhooks rejecting the NfsInstance and RedisInstance specs exceeding the "+
+			"provider limits. Requires the webhook serving certificate and the ValidatingWebhookConfiguration.")
+	flag.StringVar(&awsCloudEventsQueueUrl, "aws-cloud-events-queue-url", "",
+		"The url of the SQS queue the EventBridge rule delivers the CloudTrail recorded EC2 API calls to, read with the "+
+			"default AWS credentials. The IpRanges whose VPC or subnets the calls changed are reconciled immediately. "+
+			"If empty, the external changes are detected on the resync.")
+	flag.DurationVar(&shutdownDrainTimeout, "shutdown-drain-timeout", composed.DefaultShutdownDrainTimeout,
+		"The time the in-flight reconciliations are waited for on shutdown to finish their current cloud operation and "+
+			"status write, stopping before their next action. Keep it below the pod termination grace period.")
+	flag.IntVar(&composed.DefaultStatusConflictRetries, "status-conflict-retries", composed.DefaultStatusConflictRetries,
+		"The number of times the status update failed with the conflict is retried on the freshly loaded object, "+
+			"before the reconciliation is requeued.")
+	flag.IntVar(&ipRangeConcurrency, "iprange-concurrency", composed.DefaultReconcileConcurrency,
+		"The max number of the IpRanges reconciled concurrently. The concurrency is not limited per cloud account, "+
+			"so the higher values increase the provider API pressure of the accounts with many objects.")
+	flag.IntVar(&nfsConcurrency, "nfs-concurrency", composed.DefaultReconcileConcurrency,
+		"The max number of the NfsInstances reconciled concurrently, not limited per cloud account.")
+	flag.IntVar(&redisConcurrency, "redis-concurrency", composed.DefaultReconcileConcurrency,
+		"The max number of the RedisInstances reconciled concurrently, not limited per cloud account.")
+	flag.IntVar(&vpcPeeringConcurrency, "vpcpeering-concurrency", composed.DefaultReconcileConcurrency,
+		"The max number of the VpcPeerings reconciled concurrently, not limited per cloud account.")
+	flag.IntVar(&networkConcurrency, "network-concurrency", composed.DefaultReconcileConcurrency,
+		"The max number of the Networks reconciled concurrently, not limited per cloud account.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	actions.SetFinalizerName(finalizerName)
+
+	if len(conditionSeverities) > 0 {
+		severities, err := composed.ParseConditionSeverities(conditionSeverities)
+		if err != nil {
+			setupLog.Error(err, "invalid condition-severities flag")
+			os.Exit(1)
+		}
+		for reason, severity := range actions.DefaultConditionSeverities {
+			if _, overridden := severities[reason]; !overridden {
+				severities[reason] = severity
+			}
+		}
+		composed.SetConditionSeverities(severities)
+	}
+
+	if len(conditionTypeMapping) > 0 {
+		conditionTypes, err := composed.ParseConditionTypeMapping(conditionTypeMapping)
+		if err != nil {
+			setupLog.Error(err, "invalid condition-type-mapping flag")
+			os.Exit(1)
+		}
+		composed.SetConditionTypeMapping(conditionTypes)
+	}
+
+	composed.SetPriorityQueueEnabled(priorityQueue)
+	composed.SetResourceLock(resourceLockHolder, resourceLockTtl)
+
+	pollIntervalsByType, err := composed.ParsePollIntervals(pollIntervals)
+	if err != nil {
+		setupLog.Error(err, "invalid poll-intervals flag")
+		os.Exit(1)
+	}
+	composed.SetPollIntervals(pollIntervalsByType)
+
+	redactions, err := composed.ParseStatusRedactions(statusRedactions)
+	if err != nil {
+		setupLog.Error(err, "invalid status-redactions flag")
+		os.Exit(1)
+	}
+	composed.SetStatusRedactions(redactions)
+
+	for kind, concurrency := range map[string]int{
+		"IpRange":       ipRangeConcurrency,
+		"NfsInstance":   nfsConcurrency,
+		"RedisInstance": redisConcurrency,
+		"VpcPeering":    vpcPeeringConcurrency,
+		"Network":       networkConcurrency,
+	} {
+		if err := composed.SetReconcileConcurrency(kind, concurrency); err != nil {
+			setupLog.Error(err, "invalid reconcile concurrency flag")
+			os.Exit(1)
+		}
+	}
+
+	focal.SetAllowProviderMismatch(allowProviderMismatch)
+
+	actions.SetEndpointProbe(endpointProbe, endpointProbeTimeout)
+
+	common.SetPropagateLabelPrefix(propagateLabelPrefix)
+
 	cfg := loadConfig()
 	cfg.Read()
 
-	opts := zap.Options{}
 	if gcpStructuredLogging {
 		opts.EncoderConfigOptions = []zap.EncoderConfigOption{
 			util.GcpZapEncoderConfigOption(),
@@ -253,7 +414,9 @@ func main() {
 	if err = cloudcontrolcontroller.SetupNfsInstanceReconciler(
 		mgr,
 		awsnfsinstanceclient.NewClientProvider(),
+		awsdnsclient.NewClientProvider(),
 		gcpFilestoreClient.NewFilestoreClientProvider(),
+		gcpdnsclient.NewDnsClientProvider(),
 		cceenfsinstanceclient.NewClientProvider(),
 		env,
 	); err != nil {
@@ -270,13 +433,29 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VpcPeering")
 		os.Exit(1)
 	}
+	awsNetworkCache := awsiprangeclient.NewInventoryCache(time.Minute)
+	if err = mgr.Add(awsNetworkCache); err != nil {
+		setupLog.Error(err, "unable to add AWS network cache poller")
+		os.Exit(1)
+	}
+	var ipRangeCloudEvents <-chan event.GenericEvent
+	if awsCloudEventsQueueUrl != "" {
+		ingester := awscloudevents.NewIngester(awsCloudEventsQueueUrl, awscloudevents.NewQueueClientProvider(), mgr.GetClient(), awsNetworkCache)
+		if err = mgr.Add(ingester); err != nil {
+			setupLog.Error(err, "unable to add AWS cloud events ingester")
+			os.Exit(1)
+		}
+		ipRangeCloudEvents = ingester.IpRangeEvents()
+	}
 	if err = cloudcontrolcontroller.SetupIpRangeReconciler(
 		mgr,
-		awsiprangeclient.NewClientProvider(),
+		awsiprangeclient.NewCachedClientProvider(awsiprangeclient.NewClientProvider(), awsNetworkCache),
 		azureiprangeclient.NewClientProvider(),
 		gcpiprangeclient.NewServiceNetworkingClient(),
 		gcpiprangeclient.NewComputeClient(),
+		externalipam.NewClient(),
 		env,
+		ipRangeCloudEvents,
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IpRange")
 		os.Exit(1)
@@ -284,8 +463,10 @@ func main() {
 	if err = cloudcontrolcontroller.SetupRedisInstanceReconciler(
 		mgr,
 		gcpmemorystoreclient.NewMemorystoreClientProvider(),
+		gcpdnsclient.NewDnsClientProvider(),
 		azureredisclient.NewClientProvider(),
 		awsredisinstanceclient.NewClientProvider(),
+		awsdnsclient.NewClientProvider(),
 		env,
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RedisInstance")
@@ -298,8 +479,27 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Network")
 		os.Exit(1)
 	}
+	if err = cloudcontrolcontroller.SetupIpRangePoolReconciler(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IpRangePool")
+		os.Exit(1)
+	}
+	if err = cloudcontrolcontroller.SetupCloudResourceInventoryReconciler(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CloudResourceInventory")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
+	if enableWebhooks {
+		if err = cloudcontrolwebhook.SetupNfsInstanceWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NfsInstance")
+			os.Exit(1)
+		}
+		if err = cloudcontrolwebhook.SetupRedisInstanceWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RedisInstance")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -315,8 +515,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	composed.OnLeaderAcquired(metrics.ResetGauges)
+	if err := mgr.Add(composed.NewLeaderAcquiredRunnable()); err != nil {
+		setupLog.Error(err, "error adding leader acquired runnable to KCP manager")
+		os.Exit(1)
+	}
+
+	if notificationWebhookUrl != "" {
+		notifier := notification.NewWebhookNotifier(notificationWebhookUrl, rootLogger)
+		composed.SetNotifier(notifier)
+		if err := mgr.Add(notifier); err != nil {
+			setupLog.Error(err, "error adding webhook notifier to KCP manager")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
-	ctx := ctrl.SetupSignalHandler()
+	ctx := composed.DrainOnShutdown(ctrl.SetupSignalHandler(), shutdownDrainTimeout)
 
 	if err := feature.Initialize(ctx, rootLogger.WithName("ff")); err != nil {
 		setupLog.Error(err, "problem initializing feature flags")
@@ -361,6 +576,7 @@ func loadConfig() config.Config {
 	quota.InitConfig(cfg)
 	skrruntimeconfig.InitConfig(cfg)
 	scope.InitConfig(cfg)
+	ha.InitConfig(cfg)
 	gcpclient.InitConfig(cfg)
 
 	cfg.Read()
@@ -0,0 +1,180 @@
+package api_tests
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ownsFinalizer returns true if the field manager owns the given finalizer of the object
+func ownsFinalizer(obj metav1.Object, manager, finalizer string) bool {
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager != manager || mf.Operation != metav1.ManagedFieldsOperationApply || mf.FieldsV1 == nil {
+			continue
+		}
+		if strings.Contains(string(mf.FieldsV1.Raw), `"v:\"`+finalizer+`\""`) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsLabel returns true if the field manager owns the given label of the object
+func ownsLabel(obj metav1.Object, manager, label string) bool {
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager != manager || mf.Operation != metav1.ManagedFieldsOperationApply || mf.FieldsV1 == nil {
+			continue
+		}
+		if strings.Contains(string(mf.FieldsV1.Raw), `"f:`+label+`"`) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ = Describe("Feature: Server-side applied finalizer", func() {
+
+	It("Scenario: Finalizer is applied and removed without clobbering finalizer of other manager", func() {
+		otherFinalizer := "example.com/other"
+		obj := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       uuid.NewString(),
+				Namespace:  infra.KCP().Namespace(),
+				Finalizers: []string{otherFinalizer},
+			},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "skr", Name: uuid.NewString()},
+				Scope:     cloudcontrolv1beta1.ScopeRef{Name: "s"},
+			},
+		}
+
+		By("Given IpRange with finalizer of other manager exists", func() {
+			Expect(infra.KCP().Client().Create(infra.Ctx(), obj)).To(Succeed())
+		})
+
+		By("When cloud-manager finalizer is applied", func() {
+			added, err := composed.ApplyObjAddFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(BeTrue())
+		})
+
+		By("Then IpRange has both finalizers", func() {
+			Expect(obj.Finalizers).To(ConsistOf(otherFinalizer, actions.FinalizerName()))
+		})
+
+		By("And Then cloud-manager field manager owns only its finalizer", func() {
+			Expect(ownsFinalizer(obj, common.ObjectFieldOwner, actions.FinalizerName())).To(BeTrue())
+			Expect(ownsFinalizer(obj, common.ObjectFieldOwner, otherFinalizer)).To(BeFalse())
+		})
+
+		By("When cloud-manager finalizer is removed", func() {
+			removed, err := composed.ApplyObjRemoveFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(BeTrue())
+		})
+
+		By("Then IpRange has only the finalizer of other manager", func() {
+			Expect(obj.Finalizers).To(ConsistOf(otherFinalizer))
+			Expect(ownsFinalizer(obj, common.ObjectFieldOwner, actions.FinalizerName())).To(BeFalse())
+		})
+
+		By("And When finalizer of other manager is removed and IpRange deleted", func() {
+			_, err := composed.PatchObjRemoveFinalizer(infra.Ctx(), otherFinalizer, obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+			_ = infra.KCP().Client().Delete(infra.Ctx(), obj)
+		})
+	})
+
+	It("Scenario: Finalizer added by legacy patch is removed", func() {
+		obj := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: infra.KCP().Namespace(),
+			},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "skr", Name: uuid.NewString()},
+				Scope:     cloudcontrolv1beta1.ScopeRef{Name: "s"},
+			},
+		}
+
+		By("Given IpRange with finalizer added by legacy patch exists", func() {
+			Expect(infra.KCP().Client().Create(infra.Ctx(), obj)).To(Succeed())
+			_, err := composed.PatchObjAddFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("When cloud-manager finalizer is removed", func() {
+			_, err := composed.ApplyObjRemoveFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("Then IpRange has no finalizers", func() {
+			Expect(obj.Finalizers).To(BeEmpty())
+		})
+
+		By("And When IpRange is deleted", func() {
+			_ = infra.KCP().Client().Delete(infra.Ctx(), obj)
+		})
+	})
+
+	It("Scenario: Labels are applied without releasing the applied finalizer", func() {
+		otherLabel := "example.com/other"
+		obj := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: infra.KCP().Namespace(),
+				Labels:    map[string]string{otherLabel: "x"},
+			},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "skr", Name: uuid.NewString()},
+				Scope:     cloudcontrolv1beta1.ScopeRef{Name: "s"},
+			},
+		}
+
+		By("Given IpRange with label of other manager and applied cloud-manager finalizer exists", func() {
+			Expect(infra.KCP().Client().Create(infra.Ctx(), obj)).To(Succeed())
+			_, err := composed.ApplyObjAddFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("When cloud-manager label is applied", func() {
+			applied, err := composed.ApplyObjLabels(infra.Ctx(), map[string]string{"foo": "bar"}, obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(BeTrue())
+		})
+
+		By("Then IpRange has both labels and keeps the finalizer", func() {
+			Expect(obj.Labels).To(HaveKeyWithValue(otherLabel, "x"))
+			Expect(obj.Labels).To(HaveKeyWithValue("foo", "bar"))
+			Expect(obj.Finalizers).To(ConsistOf(actions.FinalizerName()))
+		})
+
+		By("And Then cloud-manager field manager owns only its label and finalizer", func() {
+			Expect(ownsLabel(obj, common.ObjectFieldOwner, "foo")).To(BeTrue())
+			Expect(ownsLabel(obj, common.ObjectFieldOwner, otherLabel)).To(BeFalse())
+			Expect(ownsFinalizer(obj, common.ObjectFieldOwner, actions.FinalizerName())).To(BeTrue())
+		})
+
+		By("When cloud-manager finalizer is removed", func() {
+			_, err := composed.ApplyObjRemoveFinalizer(infra.Ctx(), actions.FinalizerName(), obj, infra.KCP().Client())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("Then IpRange keeps the cloud-manager label", func() {
+			Expect(obj.Finalizers).To(BeEmpty())
+			Expect(obj.Labels).To(HaveKeyWithValue("foo", "bar"))
+			Expect(ownsLabel(obj, common.ObjectFieldOwner, "foo")).To(BeTrue())
+		})
+
+		By("And When IpRange is deleted", func() {
+			_ = infra.KCP().Client().Delete(infra.Ctx(), obj)
+		})
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudcontrol
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/inventory"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func SetupCloudResourceInventoryReconciler(kcpManager manager.Manager) error {
+	return NewCloudResourceInventoryReconciler(
+		inventory.NewCloudResourceInventoryReconciler(
+			composed.NewStateFactory(composed.NewStateClusterFromCluster(kcpManager)),
+		),
+		kcpManager.GetClient(),
+	).SetupWithManager(kcpManager)
+}
+
+func NewCloudResourceInventoryReconciler(reconciler reconcile.Reconciler, kcpClient client.Client) *CloudResourceInventoryReconciler {
+	return &CloudResourceInventoryReconciler{
+		reconciler: reconciler,
+		kcpClient:  kcpClient,
+	}
+}
+
+// CloudResourceInventoryReconciler reconciles a CloudResourceInventory object
+type CloudResourceInventoryReconciler struct {
+	reconciler reconcile.Reconciler
+	kcpClient  client.Client
+}
+
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=cloudresourceinventories,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=cloudresourceinventories/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=cloudresourceinventories/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *CloudResourceInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.reconciler.Reconcile(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CloudResourceInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&cloudcontrolv1beta1.CloudResourceInventory{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	for _, obj := range inventory.WatchedObjects() {
+		b = b.Watches(
+			obj,
+			handler.EnqueueRequestsFromMapFunc(r.mapRequestsToInventories),
+			builder.WithPredicates(inventory.TransitionPredicate()),
+		)
+	}
+	return b.Complete(r)
+}
+
+// mapRequestsToInventories enqueues all inventories on the managed resource transition,
+// the requests of the transitions happening close together are collapsed by the workqueue
+func (r *CloudResourceInventoryReconciler) mapRequestsToInventories(ctx context.Context, _ client.Object) []reconcile.Request {
+	list := &cloudcontrolv1beta1.CloudResourceInventoryList{}
+	if err := r.kcpClient.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Error listing CloudResourceInventories")
+		return nil
+	}
+	result := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		result = append(result, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+	}
+	return result
+}
@@ -435,4 +435,115 @@ var _ = Describe("Feature: KCP IpRange for AWS", func() {
 		})
 	})
 
+	It("Scenario: KCP AWS IpRange with two CIDR blocks is created and deleted", func() {
+		const (
+			kymaName    = "0e0d7f4a-3b1e-4f0c-9a5e-5d6c1b7a2f31"
+			vpcId       = "6a1c2f4e-8d3b-4a7e-b5c9-0f2e1d3c4b5a"
+			iprangeName = "c3d2e1f0-4b5a-4c6d-8e7f-9a0b1c2d3e4f"
+			firstCidr   = "10.182.0.0/16"
+			secondCidr  = "10.183.0.0/17"
+		)
+
+		scope := &cloudcontrolv1beta1.Scope{}
+
+		By("Given Scope exists", func() {
+			// Tell Scope reconciler to ignore this kymaName
+			scopePkg.Ignore.AddName(kymaName)
+
+			Eventually(CreateScopeAws).
+				WithArguments(infra.Ctx(), infra, scope, WithName(kymaName)).
+				Should(Succeed())
+		})
+
+		var theVpc *ec2Types.Vpc
+		By("And Given AWS VPC exists", func() {
+			theVpc = infra.AwsMock().AddVpc(
+				vpcId,
+				"10.250.0.0/22",
+				awsutil.Ec2Tags("Name", scope.Spec.Scope.Aws.VpcNetwork),
+				awsmock.VpcSubnetsFromScope(scope),
+			)
+		})
+
+		By("And Given KCP Kyma Network exists in Ready state", func() {
+			kcpNetworkKyma := cloudcontrolv1beta1.NewNetworkBuilder().
+				WithScope(kymaName).
+				WithName(common.KcpNetworkKymaCommonName(kymaName)).
+				WithAwsRef(scope.Spec.Scope.Aws.AccountId, scope.Spec.Region, vpcId, scope.Spec.Scope.Aws.VpcNetwork).
+				WithType(cloudcontrolv1beta1.NetworkTypeKyma).
+				Build()
+
+			Eventually(CreateObj).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), kcpNetworkKyma).
+				Should(Succeed())
+
+			Eventually(LoadAndCheck).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), kcpNetworkKyma, NewObjActions(),
+					HavingConditionTrue(cloudcontrolv1beta1.ConditionTypeReady)).
+				Should(Succeed())
+		})
+
+		iprange := &cloudcontrolv1beta1.IpRange{}
+
+		By("When KCP IpRange with two CIDR blocks is created", func() {
+			Eventually(CreateKcpIpRange).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), iprange,
+					WithName(iprangeName),
+					WithKcpIpRangeRemoteRef("skr-aws-ip-range-two-blocks"),
+					WithScope(kymaName),
+					WithKcpIpRangeSpecCidrs(firstCidr, secondCidr),
+				).
+				Should(Succeed())
+		})
+
+		By("Then KCP IpRange has Ready condition", func() {
+			Eventually(LoadAndCheck).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), iprange,
+					NewObjActions(),
+					HavingConditionTrue(cloudcontrolv1beta1.ConditionTypeReady),
+				).
+				Should(Succeed())
+		})
+
+		By("And Then KCP IpRange has both CIDR blocks in status", func() {
+			Expect(iprange.Status.Cidr).To(Equal(firstCidr))
+			Expect(iprange.Status.Cidrs).To(Equal([]string{firstCidr, secondCidr}))
+		})
+
+		By("And Then KCP IpRange has zone ranges split across both CIDR blocks", func() {
+			Expect(iprange.Status.Ranges).To(Equal([]string{"10.182.0.0/17", "10.182.128.0/17", "10.183.0.0/17"}))
+			Expect(iprange.Status.Subnets).To(HaveLen(3))
+			for i, subnet := range iprange.Status.Subnets {
+				Expect(iprange.Status.Ranges).To(ContainElement(subnet.Range), fmt.Sprintf("expected IpRange.status.subnets[%d].range %s to be listed in IpRange.status.ranges", i, subnet.Range))
+			}
+		})
+
+		By("And Then KCP IpRange AWS VPC Cidr blocks are associated", func() {
+			var associated []string
+			for _, cidrBlock := range theVpc.CidrBlockAssociationSet {
+				associated = append(associated, ptr.Deref(cidrBlock.CidrBlock, ""))
+			}
+			Expect(associated).To(ContainElements(firstCidr, secondCidr))
+		})
+
+		By("When KCP IpRange is deleted", func() {
+			Eventually(Delete).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), iprange).
+				Should(Succeed(), "failed deleting KCP IpRange")
+		})
+
+		By("Then KCP IpRange does not exist", func() {
+			Eventually(IsDeleted).
+				WithArguments(infra.Ctx(), infra.KCP().Client(), iprange).
+				Should(Succeed(), "expected KCP IpRange to be deleted, but it exists")
+		})
+
+		By("And Then KCP IpRange AWS VPC Cidr blocks do not exist", func() {
+			for _, cidrBlock := range theVpc.CidrBlockAssociationSet {
+				Expect(ptr.Deref(cidrBlock.CidrBlock, "")).NotTo(BeElementOf(firstCidr, secondCidr),
+					"expected VPC Cidr block not to exist, but it still exists")
+			}
+		})
+	})
+
 })
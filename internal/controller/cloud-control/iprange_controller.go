@@ -18,11 +18,13 @@ package cloudcontrol
 
 import (
 	"context"
+
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange"
 	awsiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
@@ -33,7 +35,12 @@ import (
 	gcpiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange"
 	gcpiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange/client"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 func SetupIpRangeReconciler(
@@ -42,7 +49,9 @@ func SetupIpRangeReconciler(
 	azureProvider azureclient.ClientProvider[azureiprangeclient.Client],
 	gcpSvcNetProvider gcpclient.ClientProvider[gcpiprangeclient.ServiceNetworkingClient],
 	gcpComputeProvider gcpclient.ClientProvider[gcpiprangeclient.ComputeClient],
+	externalIpamClient externalipam.Client,
 	env abstractions.Environment,
+	cloudEvents <-chan event.GenericEvent,
 ) error {
 	if env == nil {
 		env = abstractions.NewOSEnvironment()
@@ -54,8 +63,9 @@ func SetupIpRangeReconciler(
 			awsiprange.NewStateFactory(awsProvider),
 			azureiprange.NewStateFactory(azureProvider),
 			gcpiprange.NewStateFactory(gcpSvcNetProvider, gcpComputeProvider, env),
+			externalIpamClient,
 		),
-	).SetupWithManager(kcpManager)
+	).WithCloudEvents(cloudEvents).SetupWithManager(kcpManager)
 }
 
 func NewIpRangeReconciler(
@@ -68,6 +78,15 @@ func NewIpRangeReconciler(
 
 type IpRangeReconciler struct {
 	Reconciler iprange.IPRangeReconciler
+
+	// cloudEvents if not nil emits the IpRanges whose cloud resources were changed outside of cloud-manager
+	cloudEvents <-chan event.GenericEvent
+}
+
+// WithCloudEvents sets the channel of the IpRanges to reconcile on the cloud change events
+func (r *IpRangeReconciler) WithCloudEvents(cloudEvents <-chan event.GenericEvent) *IpRangeReconciler {
+	r.cloudEvents = cloudEvents
+	return r
 }
 
 //+kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=ipranges,verbs=get;list;watch;create;update;patch;delete
@@ -85,7 +104,20 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *IpRangeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	opts, err := composed.NewPriorityControllerOptions(mgr.GetClient(), mgr.GetScheme(), &cloudcontrolv1beta1.IpRange{})
+	if err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&cloudcontrolv1beta1.IpRange{}).
-		Complete(r)
+		WithOptions(opts).
+		Watches(
+			&cloudcontrolv1beta1.Scope{},
+			scopePropagatedTagsHandler(mgr.GetClient(), func() client.ObjectList { return &cloudcontrolv1beta1.IpRangeList{} }),
+			builder.WithPredicates(scopePropagatedTagsChangedPredicate()),
+		)
+	if r.cloudEvents != nil {
+		b = b.WatchesRawSource(source.Channel(r.cloudEvents, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(r)
 }
@@ -0,0 +1,88 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudcontrol
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprangepool"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func SetupIpRangePoolReconciler(kcpManager manager.Manager) error {
+	return NewIpRangePoolReconciler(
+		iprangepool.NewIpRangePoolReconciler(
+			composed.NewStateFactory(composed.NewStateClusterFromCluster(kcpManager)),
+			focal.NewStateFactory(),
+		),
+	).SetupWithManager(kcpManager)
+}
+
+func NewIpRangePoolReconciler(reconciler reconcile.Reconciler) *IpRangePoolReconciler {
+	return &IpRangePoolReconciler{
+		reconciler: reconciler,
+	}
+}
+
+// IpRangePoolReconciler reconciles a IpRangePool object
+type IpRangePoolReconciler struct {
+	reconciler reconcile.Reconciler
+}
+
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=iprangepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=iprangepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=iprangepools/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *IpRangePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.reconciler.Reconcile(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IpRangePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cloudcontrolv1beta1.IpRangePool{}).
+		Watches(
+			&cloudcontrolv1beta1.IpRange{},
+			handler.EnqueueRequestsFromMapFunc(r.mapRequestsFromMemberIpRange),
+		).
+		Complete(r)
+}
+
+func (r *IpRangePoolReconciler) mapRequestsFromMemberIpRange(ctx context.Context, obj client.Object) []reconcile.Request {
+	poolName, ok := obj.GetLabels()[cloudcontrolv1beta1.LabelIpRangePool]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: obj.GetNamespace(),
+				Name:      poolName,
+			},
+		},
+	}
+}
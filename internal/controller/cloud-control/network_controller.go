@@ -26,6 +26,7 @@ import (
 	networkclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/network/client"
 	gcpnetwork "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/network"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"time"
@@ -73,17 +74,20 @@ func (r *NetworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// index networks by scope name
+	// index networks by scope namespace and name, since the scope can be referenced in another namespace
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &cloudcontrolv1beta1.Network{}, cloudcontrolv1beta1.NetworkFieldScope, func(obj client.Object) []string {
 		net := obj.(*cloudcontrolv1beta1.Network)
-		return []string{net.Spec.Scope.Name}
+		return []string{net.Spec.Scope.ObjectKey(net.Namespace).String()}
 	}); err != nil {
 		return err
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cloudcontrolv1beta1.Network{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: composed.ReconcileConcurrency("Network"),
+		}).
 		Complete(r)
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsnfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance"
@@ -31,10 +32,12 @@ import (
 	cceenfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/ccee/nfsinstance"
 	cceenfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/ccee/nfsinstance/client"
 	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	gcpnfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance"
 	gcpnfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -42,7 +45,9 @@ import (
 func SetupNfsInstanceReconciler(
 	kcpManager manager.Manager,
 	awsSkrProvider awsclient.SkrClientProvider[awsnfsinstanceclient.Client],
+	awsDnsProvider awsclient.SkrClientProvider[kcpdns.Client],
 	filestoreClientProvider gcpclient.ClientProvider[gcpnfsinstanceclient.FilestoreClient],
+	gcpDnsClientProvider gcpclient.ClientProvider[gcpdnsclient.DnsClient],
 	cceeProvider cceeclient.CceeClientProvider[cceenfsinstanceclient.Client],
 	env abstractions.Environment,
 ) error {
@@ -53,9 +58,9 @@ func SetupNfsInstanceReconciler(
 		nfsinstance.NewNfsInstanceReconciler(
 			composed.NewStateFactory(composed.NewStateClusterFromCluster(kcpManager)),
 			focal.NewStateFactory(),
-			awsnfsinstance.NewStateFactory(awsSkrProvider),
+			awsnfsinstance.NewStateFactory(awsSkrProvider, awsDnsProvider),
 			azurenfsinstance.NewStateFactory(),
-			gcpnfsinstance.NewStateFactory(filestoreClientProvider, env),
+			gcpnfsinstance.NewStateFactory(filestoreClientProvider, gcpDnsClientProvider, env),
 			cceenfsinstance.NewStateFactory(cceeProvider),
 		),
 	).SetupWithManager(kcpManager)
@@ -76,6 +81,7 @@ type NfsInstanceReconciler struct {
 //+kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=nfsinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=nfsinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cloud-control.kyma-project.io,resources=nfsinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -85,7 +91,17 @@ func (r *NfsInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NfsInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	opts, err := composed.NewPriorityControllerOptions(mgr.GetClient(), mgr.GetScheme(), &cloudcontrolv1beta1.NfsInstance{})
+	if err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cloudcontrolv1beta1.NfsInstance{}, builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WithOptions(opts).
+		Watches(
+			&cloudcontrolv1beta1.Scope{},
+			scopePropagatedTagsHandler(mgr.GetClient(), func() client.ObjectList { return &cloudcontrolv1beta1.NfsInstanceList{} }),
+			builder.WithPredicates(scopePropagatedTagsChangedPredicate()),
+		).
 		Complete(r)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsredisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance"
 	awsredisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
@@ -30,11 +31,13 @@ import (
 	azureredisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/redisinstance"
 	azureredisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/redisinstance/client"
 	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	gcpredisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/redisinstance"
 	gcpredisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/redisinstance/client"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/redisinstance"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -42,8 +45,10 @@ import (
 func SetupRedisInstanceReconciler(
 	kcpManager manager.Manager,
 	gcpFilestoreClientProvider gcpclient.ClientProvider[gcpredisinstanceclient.MemorystoreClient],
+	gcpDnsClientProvider gcpclient.ClientProvider[gcpdnsclient.DnsClient],
 	azureFilestoreClientProvider azureclient.ClientProvider[azureredisinstanceclient.Client],
 	awsFilestoreClientProvider awsclient.SkrClientProvider[awsredisinstanceclient.ElastiCacheClient],
+	awsDnsProvider awsclient.SkrClientProvider[kcpdns.Client],
 	env abstractions.Environment,
 ) error {
 	if env == nil {
@@ -53,9 +58,9 @@ func SetupRedisInstanceReconciler(
 		redisinstance.NewRedisInstanceReconciler(
 			composed.NewStateFactory(composed.NewStateClusterFromCluster(kcpManager)),
 			focal.NewStateFactory(),
-			gcpredisinstance.NewStateFactory(gcpFilestoreClientProvider, env),
+			gcpredisinstance.NewStateFactory(gcpFilestoreClientProvider, gcpDnsClientProvider, env),
 			azureredisinstance.NewStateFactory(azureFilestoreClientProvider),
-			awsredisinstance.NewStateFactory(awsFilestoreClientProvider),
+			awsredisinstance.NewStateFactory(awsFilestoreClientProvider, awsDnsProvider),
 		),
 	).SetupWithManager(kcpManager)
 }
@@ -91,7 +96,17 @@ func (r *RedisInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RedisInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	opts, err := composed.NewPriorityControllerOptions(mgr.GetClient(), mgr.GetScheme(), &cloudcontrolv1beta1.RedisInstance{})
+	if err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cloudcontrolv1beta1.RedisInstance{}, builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		WithOptions(opts).
+		Watches(
+			&cloudcontrolv1beta1.Scope{},
+			scopePropagatedTagsHandler(mgr.GetClient(), func() client.ObjectList { return &cloudcontrolv1beta1.RedisInstanceList{} }),
+			builder.WithPredicates(scopePropagatedTagsChangedPredicate()),
+		).
 		Complete(r)
 }
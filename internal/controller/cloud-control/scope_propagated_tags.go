@@ -0,0 +1,77 @@
+package cloudcontrol
+
+import (
+	"context"
+	"reflect"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// scopePropagatedTagsHandler returns the handler of the Scope events enqueueing the objects of the Scope from the
+// list created by newList, to reconcile the tags propagated from the Scope labels and default tags
+func scopePropagatedTagsHandler(clnt client.Client, newList func() client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return mapRequestsFromScope(ctx, clnt, newList(), obj)
+	})
+}
+
+// mapRequestsFromScope returns the objects of the list referencing the Scope
+func mapRequestsFromScope(ctx context.Context, clnt client.Client, list client.ObjectList, obj client.Object) []reconcile.Request {
+	// the Scope might be referenced from other namespaces
+	if err := clnt.List(ctx, list); err != nil {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+	var result []reconcile.Request
+	for _, item := range items {
+		o, ok := item.(interface {
+			client.Object
+			ScopeRef() cloudcontrolv1beta1.ScopeRef
+		})
+		if !ok {
+			continue
+		}
+		if o.ScopeRef().ObjectKey(o.GetNamespace()) == client.ObjectKeyFromObject(obj) {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+		}
+	}
+	return result
+}
+
+// scopePropagatedTagsChangedPredicate passes the Scope creates, so the tags are propagated on the start, and
+// only the Scope updates changing the default tags or the labels propagated as tags
+func scopePropagatedTagsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return true },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !reflect.DeepEqual(propagatedLabels(e.ObjectOld.GetLabels()), propagatedLabels(e.ObjectNew.GetLabels())) {
+				return true
+			}
+			oldScope, okOld := e.ObjectOld.(*cloudcontrolv1beta1.Scope)
+			newScope, okNew := e.ObjectNew.(*cloudcontrolv1beta1.Scope)
+			return okOld && okNew && !reflect.DeepEqual(oldScope.Spec.DefaultTags, newScope.Spec.DefaultTags)
+		},
+	}
+}
+
+func propagatedLabels(labels map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range labels {
+		if common.IsPropagatedLabel(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
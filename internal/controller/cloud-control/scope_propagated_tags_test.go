@@ -0,0 +1,75 @@
+package cloudcontrol
+
+import (
+	"context"
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestScopePropagatedTagsWatch(t *testing.T) {
+	common.SetPropagateLabelPrefix("tags.example.com/")
+	defer common.SetPropagateLabelPrefix("")
+
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "kyma"},
+	}
+
+	t.Run("predicate", func(t *testing.T) {
+		p := scopePropagatedTagsChangedPredicate()
+		assert.True(t, p.Create(event.CreateEvent{Object: scope}))
+		assert.False(t, p.Delete(event.DeleteEvent{Object: scope}))
+
+		labeled := scope.DeepCopy()
+		labeled.Labels = map[string]string{"tags.example.com/team": "networking"}
+		assert.True(t, p.Update(event.UpdateEvent{ObjectOld: scope, ObjectNew: labeled}))
+
+		otherLabel := scope.DeepCopy()
+		otherLabel.Labels = map[string]string{"other.example.com/team": "networking"}
+		assert.False(t, p.Update(event.UpdateEvent{ObjectOld: scope, ObjectNew: otherLabel}))
+
+		defaultTags := scope.DeepCopy()
+		defaultTags.Spec.DefaultTags = map[string]string{"environment": "prod"}
+		assert.True(t, p.Update(event.UpdateEvent{ObjectOld: scope, ObjectNew: defaultTags}))
+	})
+
+	t.Run("map requests", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+		clnt := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				&cloudcontrolv1beta1.NfsInstance{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs-1"},
+					Spec:       cloudcontrolv1beta1.NfsInstanceSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "kyma"}},
+				},
+				&cloudcontrolv1beta1.NfsInstance{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs-2"},
+					Spec:       cloudcontrolv1beta1.NfsInstanceSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "other"}},
+				},
+				&cloudcontrolv1beta1.RedisInstance{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis-1"},
+					Spec:       cloudcontrolv1beta1.RedisInstanceSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "kyma"}},
+				},
+			).
+			Build()
+
+		assert.Equal(t,
+			[]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "kcp-system", Name: "nfs-1"}}},
+			mapRequestsFromScope(context.Background(), clnt, &cloudcontrolv1beta1.NfsInstanceList{}, scope),
+		)
+		assert.Equal(t,
+			[]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "kcp-system", Name: "redis-1"}}},
+			mapRequestsFromScope(context.Background(), clnt, &cloudcontrolv1beta1.RedisInstanceList{}, scope),
+		)
+	})
+}
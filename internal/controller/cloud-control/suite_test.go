@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
 	"github.com/kyma-project/cloud-manager/pkg/testinfra"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -85,13 +86,17 @@ var _ = BeforeSuite(func() {
 		infra.AzureMock().IpRangeProvider(),
 		infra.GcpMock().ServiceNetworkingClientProvider(),
 		infra.GcpMock().ComputeClientProvider(),
+		externalipam.NewClient(),
 		env,
+		nil,
 	)).NotTo(HaveOccurred())
 	// NfsInstance
 	Expect(SetupNfsInstanceReconciler(
 		infra.KcpManager(),
 		infra.AwsMock().NfsInstanceSkrProvider(),
+		infra.AwsMock().Route53SkrProvider(),
 		infra.GcpMock().FilestoreClientProvider(),
+		infra.GcpMock().DnsClientProvider(),
 		infra.CceeMock().NfsInstanceProvider(),
 		env,
 	)).NotTo(HaveOccurred())
@@ -107,8 +112,10 @@ var _ = BeforeSuite(func() {
 	Expect(SetupRedisInstanceReconciler(
 		infra.KcpManager(),
 		infra.GcpMock().MemoryStoreProviderFake(),
+		infra.GcpMock().DnsClientProvider(),
 		infra.AzureMock().RedisClientProvider(),
 		infra.AwsMock().ElastiCacheProviderFake(),
+		infra.AwsMock().Route53SkrProvider(),
 		env,
 	)).NotTo(HaveOccurred())
 	// Network
@@ -92,7 +92,12 @@ func (r *VpcPeeringReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VpcPeeringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	opts, err := composed.NewPriorityControllerOptions(mgr.GetClient(), mgr.GetScheme(), &cloudcontrolv1beta1.VpcPeering{})
+	if err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cloudcontrolv1beta1.VpcPeering{}).
+		WithOptions(opts).
 		Complete(r)
 }
@@ -0,0 +1,23 @@
+package cloudcontrol
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// +kubebuilder:webhook:path=/validate-cloud-control-kyma-project-io-v1beta1-nfsinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud-control.kyma-project.io,resources=nfsinstances,verbs=create;update,versions=v1beta1,name=vnfsinstance.cloud-control.kyma-project.io,admissionReviewVersions=v1
+
+func SetupNfsInstanceWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&cloudcontrolv1beta1.NfsInstance{}).
+		WithValidator(newNfsInstanceValidator()).
+		Complete()
+}
+
+func newNfsInstanceValidator() *specValidator[*cloudcontrolv1beta1.NfsInstance] {
+	return &specValidator[*cloudcontrolv1beta1.NfsInstance]{
+		groupKind: cloudcontrolv1beta1.GroupVersion.WithKind("NfsInstance").GroupKind(),
+		validate:  validation.ValidateNfsInstance,
+	}
+}
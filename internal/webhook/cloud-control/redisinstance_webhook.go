@@ -0,0 +1,23 @@
+package cloudcontrol
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// +kubebuilder:webhook:path=/validate-cloud-control-kyma-project-io-v1beta1-redisinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud-control.kyma-project.io,resources=redisinstances,verbs=create;update,versions=v1beta1,name=vredisinstance.cloud-control.kyma-project.io,admissionReviewVersions=v1
+
+func SetupRedisInstanceWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&cloudcontrolv1beta1.RedisInstance{}).
+		WithValidator(newRedisInstanceValidator()).
+		Complete()
+}
+
+func newRedisInstanceValidator() *specValidator[*cloudcontrolv1beta1.RedisInstance] {
+	return &specValidator[*cloudcontrolv1beta1.RedisInstance]{
+		groupKind: cloudcontrolv1beta1.GroupVersion.WithKind("RedisInstance").GroupKind(),
+		validate:  validation.ValidateRedisInstance,
+	}
+}
@@ -0,0 +1,50 @@
+package cloudcontrol
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ admission.CustomValidator = &specValidator[client.Object]{}
+
+// specValidator rejects the objects with the spec exceeding the provider hard limits at the admission,
+// with the same validate function the reconciler runs, so both report the same field errors
+type specValidator[T client.Object] struct {
+	groupKind schema.GroupKind
+	validate  func(obj T) field.ErrorList
+}
+
+func (v *specValidator[T]) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validateObj(obj)
+}
+
+func (v *specValidator[T]) ValidateUpdate(_ context.Context, _ runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validateObj(newObj)
+}
+
+func (v *specValidator[T]) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *specValidator[T]) validateObj(obj runtime.Object) error {
+	typed, ok := obj.(T)
+	if !ok {
+		return fmt.Errorf("expected %s object but got %T", v.groupKind.Kind, obj)
+	}
+	if !typed.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	errs := v.validate(typed)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(v.groupKind, typed.GetName(), errs)
+}
@@ -0,0 +1,162 @@
+package cloudcontrol
+
+import (
+	"context"
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestNfsInstanceWebhook(t *testing.T) {
+	newNfsInstance := func(instance cloudcontrolv1beta1.NfsInstanceInfo) *cloudcontrolv1beta1.NfsInstance {
+		return &cloudcontrolv1beta1.NfsInstance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+			Spec:       cloudcontrolv1beta1.NfsInstanceSpec{Instance: instance},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance cloudcontrolv1beta1.NfsInstanceInfo
+		field    string
+	}{
+		{
+			name: "aws provisioned throughput above limit",
+			instance: cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+				Throughput:            cloudcontrolv1beta1.AwsThroughputModeProvisioned,
+				ProvisionedThroughput: &cloudcontrolv1beta1.AwsProvisionedThroughput{Min: 100, Max: 5000},
+			}},
+			field: "spec.instance.aws.provisionedThroughput.max",
+		},
+		{
+			name: "aws provisioned throughput in bursting mode",
+			instance: cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+				ProvisionedThroughput: &cloudcontrolv1beta1.AwsProvisionedThroughput{Min: 10, Max: 100},
+			}},
+			field: "spec.instance.aws.provisionedThroughput",
+		},
+		{
+			name: "aws elastic throughput with maxIO",
+			instance: cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+				PerformanceMode: cloudcontrolv1beta1.AwsPerformanceModeBursting,
+				Throughput:      cloudcontrolv1beta1.AwsThroughputModeElastic,
+			}},
+			field: "spec.instance.aws.throughput",
+		},
+		{
+			name: "aws one zone with maxIO",
+			instance: cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+				PerformanceMode: cloudcontrolv1beta1.AwsPerformanceModeBursting,
+				OneZone:         "eu-west-1a",
+			}},
+			field: "spec.instance.aws.performanceMode",
+		},
+		{
+			name: "gcp capacity below tier minimum",
+			instance: cloudcontrolv1beta1.NfsInstanceInfo{Gcp: &cloudcontrolv1beta1.NfsInstanceGcp{
+				Tier:       cloudcontrolv1beta1.BASIC_SSD,
+				CapacityGb: 1024,
+			}},
+			field: "spec.instance.gcp.capacityGb",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newNfsInstanceValidator().ValidateCreate(context.Background(), newNfsInstance(tc.instance))
+
+			assert.True(t, apierrors.IsInvalid(err), "expected Invalid error, got %v", err)
+			assert.Contains(t, err.Error(), tc.field)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		oldObj := newNfsInstance(cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{}})
+		newObj := newNfsInstance(cloudcontrolv1beta1.NfsInstanceInfo{Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+			Throughput:            cloudcontrolv1beta1.AwsThroughputModeProvisioned,
+			ProvisionedThroughput: &cloudcontrolv1beta1.AwsProvisionedThroughput{Min: 100, Max: 1024},
+		}})
+
+		_, err := newNfsInstanceValidator().ValidateUpdate(context.Background(), oldObj, newObj)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestRedisInstanceWebhook(t *testing.T) {
+	newRedisInstance := func(instance cloudcontrolv1beta1.RedisInstanceInfo) *cloudcontrolv1beta1.RedisInstance {
+		return &cloudcontrolv1beta1.RedisInstance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+			Spec:       cloudcontrolv1beta1.RedisInstanceSpec{Instance: instance},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		instance cloudcontrolv1beta1.RedisInstanceInfo
+		field    string
+	}{
+		{
+			name: "aws cluster above node limit",
+			instance: cloudcontrolv1beta1.RedisInstanceInfo{Aws: &cloudcontrolv1beta1.RedisInstanceAws{
+				CacheNodeType:    "cache.t3.micro",
+				Shards:           ptr.To(int32(200)),
+				ReplicasPerShard: ptr.To(int32(2)),
+			}},
+			field: "spec.instance.aws.shards",
+		},
+		{
+			name: "gcp memory below standard ha minimum",
+			instance: cloudcontrolv1beta1.RedisInstanceInfo{Gcp: &cloudcontrolv1beta1.RedisInstanceGcp{
+				Tier:         "STANDARD_HA",
+				MemorySizeGb: 2,
+			}},
+			field: "spec.instance.gcp.memorySizeGb",
+		},
+		{
+			name: "azure shards in standard tier",
+			instance: cloudcontrolv1beta1.RedisInstanceInfo{Azure: &cloudcontrolv1beta1.RedisInstanceAzure{
+				SKU:        cloudcontrolv1beta1.AzureRedisSKU{Tier: cloudcontrolv1beta1.AzureRedisTierStandard, Capacity: 1},
+				ShardCount: 2,
+			}},
+			field: "spec.instance.azure.shardCount",
+		},
+		{
+			name: "azure replicas per primary above limit",
+			instance: cloudcontrolv1beta1.RedisInstanceInfo{Azure: &cloudcontrolv1beta1.RedisInstanceAzure{
+				SKU:                cloudcontrolv1beta1.AzureRedisSKU{Tier: cloudcontrolv1beta1.AzureRedisTierPremium, Capacity: 1},
+				ReplicasPerPrimary: 4,
+			}},
+			field: "spec.instance.azure.replicasPerPrimary",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newRedisInstanceValidator().ValidateCreate(context.Background(), newRedisInstance(tc.instance))
+
+			assert.True(t, apierrors.IsInvalid(err), "expected Invalid error, got %v", err)
+			assert.Contains(t, err.Error(), tc.field)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		_, err := newRedisInstanceValidator().ValidateCreate(context.Background(), newRedisInstance(cloudcontrolv1beta1.RedisInstanceInfo{
+			Aws: &cloudcontrolv1beta1.RedisInstanceAws{
+				CacheNodeType:    "cache.t3.micro",
+				Shards:           ptr.To(int32(100)),
+				ReplicasPerShard: ptr.To(int32(4)),
+			},
+		}))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete is not validated", func(t *testing.T) {
+		_, err := newRedisInstanceValidator().ValidateDelete(context.Background(), newRedisInstance(cloudcontrolv1beta1.RedisInstanceInfo{
+			Gcp: &cloudcontrolv1beta1.RedisInstanceGcp{Tier: "BASIC", MemorySizeGb: 1000},
+		}))
+
+		assert.NoError(t, err)
+	})
+}
@@ -3,8 +3,8 @@ package actions
 import (
 	"context"
 
-	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -13,7 +13,12 @@ func PatchAddFinalizer(ctx context.Context, state composed.State) (error, contex
 		return nil, nil
 	}
 
-	_, err := state.PatchObjAddFinalizer(ctx, cloudcontrolv1beta1.FinalizerName)
+	if feature.ServerSideApply.Value(ctx) {
+		_, err := composed.ApplyObjAddFinalizer(ctx, FinalizerName(), state.Obj(), state.Cluster().K8sClient())
+		return err, nil
+	}
+
+	_, err := state.PatchObjAddFinalizer(ctx, FinalizerName())
 
 	return err, nil
 }
@@ -26,12 +31,19 @@ func AddFinalizer(ctx context.Context, state composed.State) (error, context.Con
 	}
 
 	//If finalizer already present, don't add it again.
-	if controllerutil.ContainsFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName) {
+	if controllerutil.ContainsFinalizer(state.Obj(), FinalizerName()) {
 		return nil, nil
 	}
 
 	//Add finalizer
-	controllerutil.AddFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName)
+	if feature.ServerSideApply.Value(ctx) {
+		if _, err := composed.ApplyObjAddFinalizer(ctx, FinalizerName(), state.Obj(), state.Cluster().K8sClient()); err != nil {
+			return composed.LogErrorAndReturn(err, "Error applying Finalizer", composed.StopWithRequeue, ctx)
+		}
+		return nil, nil
+	}
+
+	controllerutil.AddFinalizer(state.Obj(), FinalizerName())
 	if err := state.UpdateObj(ctx); err != nil {
 		return composed.LogErrorAndReturn(err, "Error adding Finalizer", composed.StopWithRequeue, ctx)
 	}
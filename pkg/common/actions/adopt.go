@@ -0,0 +1,130 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjWithAdoption is implemented by the objects that can adopt an already existing cloud resource
+type ObjWithAdoption interface {
+	composed.ObjWithConditions
+	AdoptOptions() *cloudcontrolv1beta1.AdoptOptions
+	GetAdoptedResourceId() string
+	SetAdoptedResourceId(id string)
+}
+
+// AdoptionCandidate is an existing cloud resource that could be adopted
+type AdoptionCandidate struct {
+	Id   string
+	Tags map[string]string
+}
+
+// Adopter is the provider specific part of the adoption
+type Adopter interface {
+	// IsBound returns true if the object is already bound to a cloud resource it created
+	IsBound(ctx context.Context, state composed.State) bool
+	// ListCandidates returns the existing cloud resources of the adopted kind, with their tags
+	ListCandidates(ctx context.Context, state composed.State) ([]AdoptionCandidate, error)
+	// Bind adds the cloud-manager ownership tags to the adopted resource
+	Bind(ctx context.Context, state composed.State, id string) error
+}
+
+// AdoptByTags returns an Action that, on the first reconciliation of the object with adopt options, looks
+// for the existing cloud resource having all the adopt match tags, and if found binds the object to it
+// instead of creating a new one. The adopted resource id is recorded in the status with the Adopted
+// condition. If more than one resource matches the object is put in the error state. If none matches
+// it does nothing and the resource gets created as usual.
+func AdoptByTags(adopter Adopter) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithAdoption)
+		if !ok || obj.AdoptOptions() == nil || obj.GetAdoptedResourceId() != "" {
+			return nil, nil
+		}
+		if adopter.IsBound(ctx, state) {
+			return nil, nil
+		}
+
+		candidates, err := adopter.ListCandidates(ctx, state)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error listing adoption candidates", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+
+		var matching []string
+		for _, c := range candidates {
+			if tagsMatch(c.Tags, obj.AdoptOptions().MatchTags) {
+				matching = append(matching, c.Id)
+			}
+		}
+		if len(matching) == 0 {
+			return nil, nil
+		}
+
+		if len(matching) > 1 {
+			if objWithState, ok := obj.(composed.ObjWithConditionsAndState); ok {
+				objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+			}
+			return composed.PatchStatus(obj).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonAdoptionFailed,
+					Message: fmt.Sprintf("Adopt match tags match more than one resource: %s", strings.Join(matching, ", ")),
+				}).
+				ErrorLogMessage("Error patching status with ambiguous adoption").
+				SuccessLogMsg("Forgetting object with ambiguous adoption").
+				Run(ctx, state)
+		}
+
+		id := matching[0]
+		composed.LoggerFromCtx(ctx).
+			WithValues("adoptedResourceId", id).
+			Info("Adopting existing cloud resource")
+
+		if err := adopter.Bind(ctx, state, id); err != nil {
+			return composed.LogErrorAndReturn(err, "Error binding adopted cloud resource", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+
+		obj.SetAdoptedResourceId(id)
+
+		return composed.PatchStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeAdopted,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonAdopted,
+				Message: fmt.Sprintf("Adopted existing resource %s", id),
+			}).
+			ErrorLogMessage("Error patching status with adopted resource").
+			SuccessError(composed.StopWithRequeue).
+			Run(ctx, state)
+	}
+}
+
+// IsAdoptedAndRetained returns true if the object adopted an existing cloud resource that must be
+// left in place when the object is deleted
+func IsAdoptedAndRetained(obj ObjWithAdoption) bool {
+	if obj.GetAdoptedResourceId() == "" {
+		return false
+	}
+	return obj.AdoptOptions() == nil || obj.AdoptOptions().DeletionPolicy != cloudcontrolv1beta1.DeletionPolicyDelete
+}
+
+func tagsMatch(tags, matchTags map[string]string) bool {
+	if len(matchTags) == 0 {
+		return false
+	}
+	for k, v := range matchTags {
+		if actual, ok := tags[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsMatch(t *testing.T) {
+	tags := map[string]string{"team": "a", "env": "prod"}
+
+	assert.True(t, tagsMatch(tags, map[string]string{"team": "a"}))
+	assert.True(t, tagsMatch(tags, map[string]string{"team": "a", "env": "prod"}))
+	assert.False(t, tagsMatch(tags, map[string]string{"team": "b"}))
+	assert.False(t, tagsMatch(tags, map[string]string{"team": "a", "owner": "x"}))
+	assert.False(t, tagsMatch(tags, nil))
+}
+
+func TestIsAdoptedAndRetained(t *testing.T) {
+	nfs := &cloudcontrolv1beta1.NfsInstance{}
+	assert.False(t, IsAdoptedAndRetained(nfs), "not adopted")
+
+	nfs.Spec.Adopt = &cloudcontrolv1beta1.AdoptOptions{MatchTags: map[string]string{"team": "a"}}
+	nfs.Status.AdoptedResourceId = "fs-123"
+	assert.True(t, IsAdoptedAndRetained(nfs), "default policy")
+
+	nfs.Spec.Adopt.DeletionPolicy = cloudcontrolv1beta1.DeletionPolicyRetain
+	assert.True(t, IsAdoptedAndRetained(nfs), "retain policy")
+
+	nfs.Spec.Adopt.DeletionPolicy = cloudcontrolv1beta1.DeletionPolicyDelete
+	assert.False(t, IsAdoptedAndRetained(nfs), "delete policy")
+}
@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NameAvailability is the availability of the name a cloud resource is created with
+type NameAvailability string
+
+const (
+	// NameAvailable means there is no cloud resource with the name
+	NameAvailable NameAvailability = "Available"
+	// NameOwned means the cloud resource with the name exists and is owned by the object
+	NameOwned NameAvailability = "Owned"
+	// NameTaken means the cloud resource with the name exists and is owned by someone else
+	NameTaken NameAvailability = "Taken"
+)
+
+// NameChecker is the provider specific part of the name availability check. It returns the name the
+// cloud resource of the object is created with, and whether it is available, already owned by the
+// object, or taken by someone else.
+type NameChecker func(ctx context.Context, state composed.State) (name string, availability NameAvailability, err error)
+
+// CheckNameAvailability returns an Action checking the name of the cloud resource that must be globally
+// or account unique is not taken by someone else before it is created, so the collision is reported
+// upfront rather than as a failure in the middle of the create. If the name is taken, the object is put in
+// the error state with the NameCollision reason and the check is retried with a long delay, in case
+// the name gets freed. If the name is available, or the resource is already owned by the object, the
+// flow continues and the previously reported collision is cleared.
+func CheckNameAvailability(checker NameChecker) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(composed.ObjWithConditions)
+		if !ok {
+			return nil, nil
+		}
+
+		name, availability, err := checker(ctx, state)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error checking the cloud resource name availability", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+
+		logger := composed.LoggerFromCtx(ctx).WithValues(
+			"cloudResourceName", name,
+			"nameAvailability", availability,
+		)
+		ctx = composed.LoggerIntoCtx(ctx, logger)
+
+		if availability == NameTaken {
+			logger.Info("Cloud resource name is taken by a resource not owned by cloud-manager")
+			if objWithState, ok := obj.(composed.ObjWithConditionsAndState); ok {
+				objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+			}
+			return composed.PatchStatus(obj).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonNameCollision,
+					Message: fmt.Sprintf("Name %s is already used by a resource not owned by cloud-manager", name),
+				}).
+				ErrorLogMessage("Error patching status with name collision").
+				SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+				Run(ctx, state)
+		}
+
+		cond := meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeError)
+		if cond == nil || cond.Reason != cloudcontrolv1beta1.ReasonNameCollision {
+			return nil, ctx
+		}
+
+		logger.Info("Cloud resource name collision resolved")
+
+		return composed.PatchStatus(obj).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeError).
+			ErrorLogMessage("Error patching status after name collision resolved").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
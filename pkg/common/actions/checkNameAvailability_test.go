@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCheckNameAvailability(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newState := func(redis *cloudcontrolv1beta1.RedisInstance) composed.State {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+		clnt := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(redis).
+			WithStatusSubresource(redis).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// the fake client does not support the server side apply used to patch the status
+				SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					return nil
+				},
+			}).
+			Build()
+		cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+		return composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(redis), redis)
+	}
+
+	checkName := func(availability NameAvailability) composed.Action {
+		return CheckNameAvailability(func(ctx context.Context, state composed.State) (string, NameAvailability, error) {
+			return "cm-redis", availability, nil
+		})
+	}
+
+	t.Run("available", func(t *testing.T) {
+		redis := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+
+		err, _ := checkName(NameAvailable)(ctx, newState(redis))
+
+		assert.NoError(t, err)
+		assert.Empty(t, redis.Status.Conditions)
+	})
+
+	t.Run("owned", func(t *testing.T) {
+		redis := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+
+		err, _ := checkName(NameOwned)(ctx, newState(redis))
+
+		assert.NoError(t, err)
+		assert.Empty(t, redis.Status.Conditions)
+	})
+
+	t.Run("foreign", func(t *testing.T) {
+		redis := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		state := newState(redis)
+
+		err, _ := checkName(NameTaken)(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(5*time.Minute), err)
+		cond := meta.FindStatusCondition(redis.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, cloudcontrolv1beta1.ReasonNameCollision, cond.Reason)
+			assert.Contains(t, cond.Message, "cm-redis")
+		}
+
+		// the collision is cleared once the name is freed
+		err, _ = checkName(NameAvailable)(ctx, state)
+
+		assert.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(redis.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+	})
+}
@@ -0,0 +1,38 @@
+package actions
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// DefaultConditionSeverities are the severities of the condition reasons set by the providers,
+// that can be overridden with the condition-severities flag. The unlisted reasons of the Error
+// condition are of the Error severity, and the others of the Info severity.
+var DefaultConditionSeverities = map[string]composed.ConditionSeverity{
+	cloudcontrolv1beta1.ReasonVpcNotFound:                  composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonVpcNotFoundByTag:             composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonScopeRefForbidden:            composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonIpamPoolExhausted:            composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonPoolExhausted:                composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonRequiresRecreate:             composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonIncompatibleNodeType:         composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonInvalidBackupCopyRegion:      composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonNameCollision:                composed.ConditionSeverityError,
+	cloudcontrolv1beta1.ReasonZoneCapacityUnavailable:      composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonInsufficientZones:            composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonEndpointUnreachable:          composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonIpamUnavailable:              composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonScheduledBackupFailed:        composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonBackupCopyFailed:             composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonBackupConfigurationFailed:    composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonDnsRecordFailed:              composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonForeignFinalizerBlocking:     composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonRecreateRequiresConfirmation: composed.ConditionSeverityWarning,
+	composed.ReasonDriftDetected:                           composed.ConditionSeverityWarning,
+	cloudcontrolv1beta1.ReasonTgwAttachmentPending:         composed.ConditionSeverityInfo,
+	cloudcontrolv1beta1.ReasonUpgradeScheduled:             composed.ConditionSeverityInfo,
+	cloudcontrolv1beta1.ReasonScaling:                      composed.ConditionSeverityInfo,
+	cloudcontrolv1beta1.ReasonResharding:                   composed.ConditionSeverityInfo,
+	cloudcontrolv1beta1.ReasonRecreating:                   composed.ConditionSeverityInfo,
+	composed.ReasonMaintenanceFrozen:                       composed.ConditionSeverityInfo,
+}
@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+)
+
+// ObjWithCostEstimate is implemented by the objects reporting the estimated monthly cost in the status
+type ObjWithCostEstimate interface {
+	composed.ObjWithConditions
+	GetEstimatedMonthlyCost() string
+	SetEstimatedMonthlyCost(v string)
+}
+
+// CostEstimator computes the formatted estimated monthly cost of the provisioned resources. It
+// returns false if the cost can not be estimated, for example if the resources are not yet provisioned.
+type CostEstimator func(ctx context.Context, state composed.State) (string, bool)
+
+// ReconcileCostEstimate returns an Action that, when the CostEstimate feature is enabled, writes the
+// estimated monthly cost into the object status. The estimate is recomputed on each reconciliation
+// so the spec changes affecting the cost are picked up, and the status is patched only when it differs.
+// When the feature is disabled a previously written estimate is removed.
+func ReconcileCostEstimate(estimator CostEstimator) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithCostEstimate)
+		if !ok {
+			return nil, nil
+		}
+
+		desired := ""
+		if feature.CostEstimate.Value(ctx) {
+			cost, ok := estimator(ctx, state)
+			if !ok {
+				return nil, nil
+			}
+			desired = cost
+		}
+		if obj.GetEstimatedMonthlyCost() == desired {
+			return nil, nil
+		}
+
+		obj.SetEstimatedMonthlyCost(desired)
+		return composed.PatchStatus(obj).
+			ErrorLogMessage("Error patching status with estimated monthly cost").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
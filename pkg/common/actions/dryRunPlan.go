@@ -0,0 +1,136 @@
+package actions
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationDryRun set to "true" puts the object in the dry-run mode, where the operations on the cloud
+	// resources are only planned and written to the status instead of being performed. The objects of the
+	// providers not computing the plan reject it with an error
+	AnnotationDryRun = "cloud-manager.kyma-project.io/dry-run"
+
+	// DryRunPlanMaxOperations is the max number of the planned operations written to the status
+	DryRunPlanMaxOperations = 50
+)
+
+// ObjWithDryRunPlan is implemented by objects that publish in their status the operations planned in the dry-run mode
+type ObjWithDryRunPlan interface {
+	composed.ObjWithConditions
+	GetDryRunPlan() *cloudcontrolv1beta1.DryRunPlan
+	SetDryRunPlan(plan *cloudcontrolv1beta1.DryRunPlan)
+}
+
+// PlanBuilder collects the operations the provider reconciliation would perform on the cloud resources
+type PlanBuilder interface {
+	Create(resourceType, name, after string)
+	Update(resourceType, name, before, after string)
+	Delete(resourceType, name, before string)
+	Operations() []cloudcontrolv1beta1.DryRunOperation
+}
+
+// Planner adds to the plan builder the operations the provider reconciliation of the state would perform,
+// without performing them
+type Planner func(ctx context.Context, state composed.State, plan PlanBuilder) error
+
+func NewPlanBuilder() PlanBuilder {
+	return &planBuilder{}
+}
+
+type planBuilder struct {
+	operations []cloudcontrolv1beta1.DryRunOperation
+}
+
+func (b *planBuilder) Create(resourceType, name, after string) {
+	b.add(cloudcontrolv1beta1.DryRunOperationCreate, resourceType, name, "", after)
+}
+
+func (b *planBuilder) Update(resourceType, name, before, after string) {
+	b.add(cloudcontrolv1beta1.DryRunOperationUpdate, resourceType, name, before, after)
+}
+
+func (b *planBuilder) Delete(resourceType, name, before string) {
+	b.add(cloudcontrolv1beta1.DryRunOperationDelete, resourceType, name, before, "")
+}
+
+func (b *planBuilder) add(operation, resourceType, name, before, after string) {
+	b.operations = append(b.operations, cloudcontrolv1beta1.DryRunOperation{
+		Operation:    operation,
+		ResourceType: resourceType,
+		Name:         name,
+		Before:       before,
+		After:        after,
+	})
+}
+
+func (b *planBuilder) Operations() []cloudcontrolv1beta1.DryRunOperation {
+	return b.operations
+}
+
+// IsDryRun returns true if the object is annotated to be in the dry-run mode
+func IsDryRun(state composed.State) bool {
+	return state.Obj() != nil && state.Obj().GetAnnotations()[AnnotationDryRun] == "true"
+}
+
+// DryRunPlan returns an Action that, for the object in the dry-run mode, writes the operations the planner
+// contributes to the status dryRunPlan, capped to DryRunPlanMaxOperations, and stops the flow before any
+// cloud resource is changed, requeuing the object to refresh the plan. Once the dry-run mode is disabled
+// the plan is cleared from the status and the flow continues. Must run after the cloud resources are loaded.
+func DryRunPlan(planner Planner) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithDryRunPlan)
+		if !ok {
+			return nil, nil
+		}
+
+		if !IsDryRun(state) {
+			if obj.GetDryRunPlan() == nil {
+				return nil, nil
+			}
+			obj.SetDryRunPlan(nil)
+			return composed.UpdateStatus(obj).
+				ErrorLogMessage("Error updating status after clearing the dry-run plan").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		builder := NewPlanBuilder()
+		if err := planner(ctx, state, builder); err != nil {
+			return composed.LogErrorAndReturn(err, "Error computing the dry-run plan", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+
+		plan := &cloudcontrolv1beta1.DryRunPlan{
+			Operations: builder.Operations(),
+			Time:       metav1.NewTime(time.Now()),
+		}
+		if len(plan.Operations) > DryRunPlanMaxOperations {
+			plan.Truncated = len(plan.Operations) - DryRunPlanMaxOperations
+			plan.Operations = plan.Operations[:DryRunPlanMaxOperations]
+		}
+
+		composed.LoggerFromCtx(ctx).
+			WithValues(
+				"operations", len(builder.Operations()),
+				"truncated", plan.Truncated,
+			).
+			Info("Dry-run plan computed")
+
+		current := obj.GetDryRunPlan()
+		if current != nil && current.Truncated == plan.Truncated && reflect.DeepEqual(current.Operations, plan.Operations) {
+			return composed.StopWithRequeueDelay(util.Timing.T300000ms()), nil
+		}
+
+		obj.SetDryRunPlan(plan)
+		return composed.UpdateStatus(obj).
+			ErrorLogMessage("Error updating status with the dry-run plan").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+}
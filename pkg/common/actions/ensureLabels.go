@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+)
+
+// EnsureLabels sets the labels on the state object. When the serverSideApply feature is enabled the
+// labels are server-side applied with the dedicated cloud-manager-object field manager, so only those
+// labels are owned by cloud-manager, otherwise the object is updated.
+func EnsureLabels(ctx context.Context, state composed.State, labels map[string]string) error {
+	if feature.ServerSideApply.Value(ctx) {
+		_, err := composed.ApplyObjLabels(ctx, labels, state.Obj(), state.Cluster().K8sClient())
+		return err
+	}
+
+	objLabels := state.Obj().GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	for k, v := range labels {
+		objLabels[k] = v
+	}
+	state.Obj().SetLabels(objLabels)
+
+	return state.UpdateObj(ctx)
+}
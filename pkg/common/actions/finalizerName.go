@@ -0,0 +1,25 @@
+package actions
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// finalizerName is the finalizer this cloud-manager instance adds to and removes from the objects
+// it reconciles. It defaults to cloudcontrolv1beta1.FinalizerName and is changed with the
+// finalizer-name manager flag, so two instances can run against the same cluster without
+// releasing each other's objects.
+var finalizerName = cloudcontrolv1beta1.FinalizerName
+
+// SetFinalizerName sets the finalizer name used by the add and remove finalizer actions.
+// An empty name resets it to the default cloudcontrolv1beta1.FinalizerName.
+func SetFinalizerName(name string) {
+	if len(name) == 0 {
+		name = cloudcontrolv1beta1.FinalizerName
+	}
+	finalizerName = name
+}
+
+// FinalizerName returns the configured finalizer name
+func FinalizerName() string {
+	return finalizerName
+}
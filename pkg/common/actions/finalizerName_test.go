@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestFinalizerName(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+
+	t.Cleanup(func() {
+		SetFinalizerName("")
+	})
+
+	newState := func(obj *cloudcontrolv1beta1.RedisInstance) (composed.State, client.Client) {
+		clnt := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(obj).
+			Build()
+		cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+		return composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(obj), obj), clnt
+	}
+
+	loadFinalizers := func(t *testing.T, clnt client.Client) []string {
+		loaded := &cloudcontrolv1beta1.RedisInstance{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKey{Namespace: "kcp-system", Name: "redis"}, loaded))
+		return loaded.Finalizers
+	}
+
+	t.Run("defaults to the cloud-manager finalizer", func(t *testing.T) {
+		assert.Equal(t, cloudcontrolv1beta1.FinalizerName, FinalizerName())
+
+		SetFinalizerName("other.io/finalizer")
+		SetFinalizerName("")
+		assert.Equal(t, cloudcontrolv1beta1.FinalizerName, FinalizerName())
+	})
+
+	t.Run("configured finalizer is added", func(t *testing.T) {
+		SetFinalizerName("green.cloud-manager.io/finalizer")
+		state, clnt := newState(&cloudcontrolv1beta1.RedisInstance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis", Finalizers: []string{cloudcontrolv1beta1.FinalizerName}},
+		})
+
+		err, _ := AddFinalizer(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName, "green.cloud-manager.io/finalizer"}, loadFinalizers(t, clnt))
+
+		err, _ = PatchAddFinalizer(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName, "green.cloud-manager.io/finalizer"}, loadFinalizers(t, clnt))
+	})
+
+	t.Run("only configured finalizer is removed", func(t *testing.T) {
+		SetFinalizerName("green.cloud-manager.io/finalizer")
+		state, clnt := newState(&cloudcontrolv1beta1.RedisInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "kcp-system",
+				Name:              "redis",
+				Finalizers:        []string{cloudcontrolv1beta1.FinalizerName, "green.cloud-manager.io/finalizer"},
+				DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+			},
+		})
+
+		err, _ := RemoveFinalizer(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		// the finalizer of the other instance is kept
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName}, loadFinalizers(t, clnt))
+	})
+
+	t.Run("only configured finalizer is removed with patch", func(t *testing.T) {
+		SetFinalizerName("green.cloud-manager.io/finalizer")
+		state, clnt := newState(&cloudcontrolv1beta1.RedisInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "kcp-system",
+				Name:              "redis",
+				Finalizers:        []string{"green.cloud-manager.io/finalizer", cloudcontrolv1beta1.FinalizerName},
+				DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+			},
+		})
+
+		err, _ := PatchRemoveFinalizer(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName}, loadFinalizers(t, clnt))
+	})
+}
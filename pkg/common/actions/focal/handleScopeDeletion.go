@@ -0,0 +1,112 @@
+package focal
+
+import (
+	"context"
+	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// handleScopeDeletion stops the object whose Scope is missing or being deleted from creating
+// any cloud resources. With the ScopeOrphanCleanup feature enabled such object is deleted, and
+// if its Scope is already gone its finalizer is removed since its cloud resources can not be
+// reached anymore. Otherwise, the object is marked with the ScopeMissing condition.
+// An object being deleted with its Scope still present continues with the delete flow.
+func handleScopeDeletion(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(State)
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsCommonObj()
+
+	scopeMissing := state.Scope() == nil
+	scopeDeleting := !scopeMissing && !state.Scope().DeletionTimestamp.IsZero()
+
+	if !scopeMissing && !scopeDeleting {
+		return removeScopeMissingCondition(ctx, state)
+	}
+
+	if scopeDeleting && composed.MarkedForDeletionPredicate(ctx, state) {
+		return nil, ctx
+	}
+
+	if feature.ScopeOrphanCleanup.Value(ctx) {
+		if !composed.MarkedForDeletionPredicate(ctx, state) {
+			logger.Info("Deleting object since its Scope is missing or being deleted")
+			err := state.Cluster().K8sClient().Delete(ctx, obj)
+			if client.IgnoreNotFound(err) != nil {
+				return composed.LogErrorAndReturn(err, "Error deleting object of missing Scope", composed.StopWithRequeue, ctx)
+			}
+			return composed.StopWithRequeue, ctx
+		}
+
+		logger.Info("Removing finalizer from object of missing Scope")
+		// the cleanup stages can not run without the Scope either, so their finalizers are removed as well
+		original := obj.DeepCopyObject().(client.Object)
+		controllerutil.RemoveFinalizer(obj, actions.FinalizerName())
+		for _, f := range actions.StagedFinalizers(obj) {
+			controllerutil.RemoveFinalizer(obj, f)
+		}
+		err := state.Cluster().K8sClient().Patch(ctx, obj, client.MergeFrom(original))
+		if client.IgnoreNotFound(err) != nil {
+			return composed.LogErrorAndReturn(err, "Error removing finalizer from object of missing Scope", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+		return composed.StopAndForget, ctx
+	}
+
+	reason := cloudcontrolv1beta1.ReasonScopeNotFound
+	scopeKey := obj.ScopeRef().ObjectKey(obj.GetNamespace())
+	message := fmt.Sprintf("Scope %s does not exist", scopeKey)
+	if scopeDeleting {
+		reason = cloudcontrolv1beta1.ReasonScopeDeleting
+		message = fmt.Sprintf("Scope %s is being deleted", scopeKey)
+	}
+
+	logger.Info(message)
+
+	return composed.UpdateStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeScopeMissing,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		}).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		}).
+		ErrorLogMessage("Error updating status with ScopeMissing condition").
+		SuccessError(composed.StopAndForget).
+		Run(ctx, state)
+}
+
+// removeScopeMissingCondition removes the ScopeMissing condition and the Error condition it set
+// once the Scope is present again
+func removeScopeMissingCondition(ctx context.Context, state State) (error, context.Context) {
+	obj := state.ObjAsCommonObj()
+	if meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeScopeMissing) == nil {
+		return nil, ctx
+	}
+
+	conditionTypes := []string{cloudcontrolv1beta1.ConditionTypeScopeMissing}
+	errorCondition := meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeError)
+	if errorCondition != nil &&
+		(errorCondition.Reason == cloudcontrolv1beta1.ReasonScopeNotFound || errorCondition.Reason == cloudcontrolv1beta1.ReasonScopeDeleting) {
+		conditionTypes = append(conditionTypes, cloudcontrolv1beta1.ConditionTypeError)
+	}
+
+	err, _ := composed.UpdateStatus(obj).
+		RemoveConditions(conditionTypes...).
+		ErrorLogMessage("Error updating status removing ScopeMissing condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+
+	return err, ctx
+}
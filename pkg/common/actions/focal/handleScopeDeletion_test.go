@@ -0,0 +1,191 @@
+package focal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type handleScopeDeletionSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *handleScopeDeletionSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+	feature.InitializeFromStaticConfig(nil)
+}
+
+func (suite *handleScopeDeletionSuite) TearDownTest() {
+	feature.InitializeFromStaticConfig(nil)
+}
+
+func (suite *handleScopeDeletionSuite) enableOrphanCleanup() {
+	feature.InitializeFromStaticConfig(abstractions.NewMockedEnvironment(map[string]string{
+		"FF_SCOPE_ORPHAN_CLEANUP": "true",
+	}))
+}
+
+func (suite *handleScopeDeletionSuite) run(objects ...client.Object) (error, client.Client) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&cloudcontrolv1beta1.IpRange{}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: "kcp-system", Name: "ip-range"}, &cloudcontrolv1beta1.IpRange{}),
+	)
+	err, _ := New()(suite.ctx, state)
+	return err, clnt
+}
+
+func newIpRange(deleting bool) *cloudcontrolv1beta1.IpRange {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "kcp-system",
+			Name:       "ip-range",
+			Finalizers: []string{actions.FinalizerName()},
+		},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+		},
+	}
+	if deleting {
+		ipRange.DeletionTimestamp = ptr.To(metav1.Now())
+	}
+	return ipRange
+}
+
+func newScope(deleting bool) *cloudcontrolv1beta1.Scope {
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "kcp-system",
+			Name:       "scope",
+			Finalizers: []string{actions.FinalizerName()},
+		},
+	}
+	if deleting {
+		scope.DeletionTimestamp = ptr.To(metav1.Now())
+	}
+	return scope
+}
+
+func (suite *handleScopeDeletionSuite) loadIpRange(clnt client.Client) (*cloudcontrolv1beta1.IpRange, error) {
+	ipRange := &cloudcontrolv1beta1.IpRange{}
+	err := clnt.Get(suite.ctx, types.NamespacedName{Namespace: "kcp-system", Name: "ip-range"}, ipRange)
+	return ipRange, err
+}
+
+func (suite *handleScopeDeletionSuite) TestScopePresentContinues() {
+	ipRange := newIpRange(false)
+	ipRange.Status.Conditions = []metav1.Condition{
+		{Type: cloudcontrolv1beta1.ConditionTypeScopeMissing, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonScopeNotFound},
+		{Type: cloudcontrolv1beta1.ConditionTypeError, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonScopeNotFound},
+	}
+
+	err, clnt := suite.run(ipRange, newScope(false))
+	assert.Nil(suite.T(), err)
+
+	ipRange, err = suite.loadIpRange(clnt)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), ipRange.Status.Conditions, "stale ScopeMissing and its Error conditions are removed")
+}
+
+func (suite *handleScopeDeletionSuite) TestCreateWithMissingScopeSetsCondition() {
+	err, clnt := suite.run(newIpRange(false))
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	ipRange, err := suite.loadIpRange(clnt)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ipRange.DeletionTimestamp.IsZero())
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScopeMissing)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScopeNotFound, cond.Reason)
+}
+
+func (suite *handleScopeDeletionSuite) TestCreateWithDeletingScopeSetsCondition() {
+	err, clnt := suite.run(newIpRange(false), newScope(true))
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	ipRange, err := suite.loadIpRange(clnt)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ipRange.DeletionTimestamp.IsZero())
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScopeMissing)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScopeDeleting, cond.Reason)
+}
+
+func (suite *handleScopeDeletionSuite) TestCreateWithDeletingScopeAndOrphanCleanupDeletesObject() {
+	suite.enableOrphanCleanup()
+
+	err, clnt := suite.run(newIpRange(false), newScope(true))
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+
+	ipRange, err := suite.loadIpRange(clnt)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ipRange.DeletionTimestamp.IsZero(), "object is marked for deletion")
+}
+
+func (suite *handleScopeDeletionSuite) TestDeleteWithDeletingScopeContinues() {
+	err, _ := suite.run(newIpRange(true), newScope(true))
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *handleScopeDeletionSuite) TestDeleteWithMissingScopeSetsCondition() {
+	err, clnt := suite.run(newIpRange(true))
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	ipRange, err := suite.loadIpRange(clnt)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), ipRange.Finalizers, actions.FinalizerName())
+	assert.NotNil(suite.T(), meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScopeMissing))
+}
+
+func (suite *handleScopeDeletionSuite) TestDeleteWithMissingScopeAndOrphanCleanupRemovesFinalizer() {
+	suite.enableOrphanCleanup()
+
+	err, clnt := suite.run(newIpRange(true))
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	_, err = suite.loadIpRange(clnt)
+	assert.True(suite.T(), apierrors.IsNotFound(err), "object without finalizer is gone")
+}
+
+func (suite *handleScopeDeletionSuite) TestDeleteWithMissingScopeAndOrphanCleanupRemovesStagedFinalizers() {
+	suite.enableOrphanCleanup()
+
+	ipRange := newIpRange(true)
+	ipRange.Finalizers = append(ipRange.Finalizers, actions.StagedFinalizerName("stage1"), actions.StagedFinalizerName("stage2"))
+	err, clnt := suite.run(ipRange)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	_, err = suite.loadIpRange(clnt)
+	assert.True(suite.T(), apierrors.IsNotFound(err), "object without finalizers is gone")
+}
+
+func TestHandleScopeDeletion(t *testing.T) {
+	suite.Run(t, new(handleScopeDeletionSuite))
+}
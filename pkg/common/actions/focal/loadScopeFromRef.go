@@ -3,47 +3,70 @@ package focal
 import (
 	"context"
 	"fmt"
+
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// loadScopeFromRef loads the Scope referenced by the object, from the namespace of the object or from
+// the namespace given in the reference when Scopes are managed centrally. The Scope has no reference
+// of its own, so the reference is resolved in a single step and can not form a cycle. When the access review
+// denies the reconciler to read the referenced Scope the object is marked with the ScopeRefForbidden condition.
 func loadScopeFromRef(ctx context.Context, st composed.State) (error, context.Context) {
 	logger := composed.LoggerFromCtx(ctx)
 	state := st.(State)
 
+	scopeRef := state.ObjAsCommonObj().ScopeRef()
+	scopeKey := scopeRef.ObjectKey(state.ObjAsCommonObj().GetNamespace())
+
 	logger = logger.WithValues(
-		"scope", state.ObjAsCommonObj().ScopeRef().Name,
-		"scopeNamespace", state.ObjAsCommonObj().GetNamespace(),
+		"scope", scopeKey.Name,
+		"scopeNamespace", scopeKey.Namespace,
 	)
 	ctx = composed.LoggerIntoCtx(ctx, logger)
 	logger.
 		WithValues(
-			"scopeName", state.ObjAsCommonObj().ScopeRef().Name,
-			"scopeNamespace", state.ObjAsCommonObj().GetNamespace(),
+			"scopeName", scopeKey.Name,
+			"scopeNamespace", scopeKey.Namespace,
+			"crossNamespace", scopeRef.IsCrossNamespace(state.ObjAsCommonObj().GetNamespace()),
 		).
 		Info("Loading Scope from reference")
 
+	if scopeRef.IsCrossNamespace(state.ObjAsCommonObj().GetNamespace()) {
+		// the cached client reads the Scopes of all namespaces the cache watches regardless of the RBAC,
+		// so the access of the reconciler to the referenced namespace is reviewed explicitly
+		allowed, reason, err := reviewScopeAccess(ctx, state.Cluster().K8sClient(), scopeKey)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error reviewing access to referenced Scope", composed.StopWithRequeue, ctx)
+		}
+		if !allowed {
+			message := fmt.Sprintf("Access to Scope %s in namespace %s is forbidden", scopeKey.Name, scopeKey.Namespace)
+			if len(reason) > 0 {
+				message = fmt.Sprintf("%s: %s", message, reason)
+			}
+			return scopeRefForbidden(ctx, state, message)
+		}
+	}
+
 	scope := &cloudcontrolv1beta1.Scope{}
-	err := state.Cluster().K8sClient().Get(ctx, types.NamespacedName{
-		Name:      state.ObjAsCommonObj().ScopeRef().Name,
-		Namespace: state.ObjAsCommonObj().GetNamespace(),
-	}, scope)
+	err := state.Cluster().K8sClient().Get(ctx, scopeKey, scope)
 
 	if apierrors.IsNotFound(err) {
+		// handled by handleScopeDeletion once the feature context is loaded
 		logger.Info("Scope not found")
+		state.SetScope(nil)
+		return nil, ctx
+	}
 
-		return composed.UpdateStatus(state.ObjAsCommonObj()).
-			SetCondition(metav1.Condition{
-				Type:    cloudcontrolv1beta1.ConditionTypeError,
-				Status:  metav1.ConditionTrue,
-				Reason:  cloudcontrolv1beta1.ReasonScopeNotFound,
-				Message: fmt.Sprintf("Scope %s does not exist", state.ObjAsCommonObj().ScopeRef().Name),
-			}).
-			SuccessError(composed.StopAndForget).
-			Run(ctx, state)
+	if apierrors.IsForbidden(err) {
+		return scopeRefForbidden(ctx, state, fmt.Sprintf("Access to Scope %s in namespace %s is forbidden", scopeKey.Name, scopeKey.Namespace))
 	}
 
 	if err != nil {
@@ -65,5 +88,82 @@ func loadScopeFromRef(ctx context.Context, st composed.State) (error, context.Co
 
 	state.SetScope(scope)
 
-	return nil, ctx
+	return removeScopeRefForbiddenCondition(ctx, state)
+}
+
+// reviewScopeAccess checks with the SelfSubjectAccessReview if the reconciler is allowed to get the Scope,
+// and returns the reason of the decision given by the authorizer
+func reviewScopeAccess(ctx context.Context, clnt client.Client, scopeKey types.NamespacedName) (bool, string, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: scopeKey.Namespace,
+				Verb:      "get",
+				Group:     cloudcontrolv1beta1.GroupVersion.Group,
+				Resource:  "scopes",
+				Name:      scopeKey.Name,
+			},
+		},
+	}
+	if err := clnt.Create(ctx, review); err != nil {
+		return false, "", err
+	}
+	return review.Status.Allowed && !review.Status.Denied, review.Status.Reason, nil
+}
+
+// scopeRefForbidden marks the object with the ScopeRefForbidden condition and retries later, since
+// the access to the referenced Scope namespace might be granted in the meantime
+func scopeRefForbidden(ctx context.Context, state State, message string) (error, context.Context) {
+	obj := state.ObjAsCommonObj()
+
+	composed.LoggerFromCtx(ctx).Info(message)
+
+	if !composed.AnyConditionChanged(obj, metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeScopeRefForbidden,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonScopeRefForbidden,
+		Message: message,
+	}) {
+		return composed.StopWithRequeueDelay(util.Timing.T300000ms()), ctx
+	}
+
+	return composed.UpdateStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeScopeRefForbidden,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonScopeRefForbidden,
+			Message: message,
+		}).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonScopeRefForbidden,
+			Message: message,
+		}).
+		ErrorLogMessage("Error updating status with ScopeRefForbidden condition").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+		Run(ctx, state)
+}
+
+// removeScopeRefForbiddenCondition removes the ScopeRefForbidden condition and the Error condition it set
+// once the referenced Scope can be read
+func removeScopeRefForbiddenCondition(ctx context.Context, state State) (error, context.Context) {
+	obj := state.ObjAsCommonObj()
+	if meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeScopeRefForbidden) == nil {
+		return nil, ctx
+	}
+
+	conditionTypes := []string{cloudcontrolv1beta1.ConditionTypeScopeRefForbidden}
+	errorCondition := meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeError)
+	if errorCondition != nil && errorCondition.Reason == cloudcontrolv1beta1.ReasonScopeRefForbidden {
+		conditionTypes = append(conditionTypes, cloudcontrolv1beta1.ConditionTypeError)
+	}
+
+	err, _ := composed.UpdateStatus(obj).
+		RemoveConditions(conditionTypes...).
+		ErrorLogMessage("Error updating status removing ScopeRefForbidden condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+
+	return err, ctx
 }
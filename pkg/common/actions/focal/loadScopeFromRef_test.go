@@ -0,0 +1,155 @@
+package focal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type loadScopeFromRefSuite struct {
+	suite.Suite
+	ctx     context.Context
+	reviews []authorizationv1.ResourceAttributes
+}
+
+func (suite *loadScopeFromRefSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+	suite.reviews = nil
+}
+
+func (suite *loadScopeFromRefSuite) run(ipRange *cloudcontrolv1beta1.IpRange, forbiddenNamespace, deniedNamespace string, objects ...client.Object) (error, State) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objects, ipRange)...).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not evaluate RBAC, so the access review and the forbidden read are simulated
+			Create: func(ctx context.Context, clnt client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, isReview := obj.(*authorizationv1.SelfSubjectAccessReview)
+				if !isReview {
+					return clnt.Create(ctx, obj, opts...)
+				}
+				suite.reviews = append(suite.reviews, *review.Spec.ResourceAttributes)
+				review.Status.Allowed = review.Spec.ResourceAttributes.Namespace != deniedNamespace
+				if !review.Status.Allowed {
+					review.Status.Reason = "no RBAC policy matched"
+				}
+				return nil
+			},
+			Get: func(ctx context.Context, clnt client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, isScope := obj.(*cloudcontrolv1beta1.Scope); isScope && key.Namespace == forbiddenNamespace {
+					return apierrors.NewForbidden(schema.GroupResource{Group: cloudcontrolv1beta1.GroupVersion.Group, Resource: "scopes"}, key.Name, nil)
+				}
+				return clnt.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	err, _ := loadScopeFromRef(suite.ctx, state)
+	return err, state
+}
+
+func newScopeInNamespace(namespace string) *cloudcontrolv1beta1.Scope {
+	return &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "scope"},
+	}
+}
+
+func newIpRangeWithScopeRef(scopeRef cloudcontrolv1beta1.ScopeRef) *cloudcontrolv1beta1.IpRange {
+	return &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "ip-range"},
+		Spec:       cloudcontrolv1beta1.IpRangeSpec{Scope: scopeRef},
+	}
+}
+
+func (suite *loadScopeFromRefSuite) TestSameNamespaceByDefault() {
+	ipRange := newIpRangeWithScopeRef(cloudcontrolv1beta1.ScopeRef{Name: "scope"})
+
+	err, state := suite.run(ipRange, "", "", newScopeInNamespace("kcp-system"), newScopeInNamespace("scopes"))
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), state.Scope())
+	assert.Equal(suite.T(), "kcp-system", state.Scope().Namespace)
+	assert.Empty(suite.T(), suite.reviews, "access to the own namespace is not reviewed")
+}
+
+func (suite *loadScopeFromRefSuite) TestCrossNamespaceAllowed() {
+	ipRange := newIpRangeWithScopeRef(cloudcontrolv1beta1.ScopeRef{Name: "scope", Namespace: "scopes"})
+	ipRange.Status.Conditions = []metav1.Condition{
+		{Type: cloudcontrolv1beta1.ConditionTypeScopeRefForbidden, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonScopeRefForbidden},
+		{Type: cloudcontrolv1beta1.ConditionTypeError, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonScopeRefForbidden},
+	}
+
+	err, state := suite.run(ipRange, "", "", newScopeInNamespace("kcp-system"), newScopeInNamespace("scopes"))
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), state.Scope())
+	assert.Equal(suite.T(), "scopes", state.Scope().Namespace)
+	assert.Equal(suite.T(), []authorizationv1.ResourceAttributes{{
+		Namespace: "scopes",
+		Verb:      "get",
+		Group:     cloudcontrolv1beta1.GroupVersion.Group,
+		Resource:  "scopes",
+		Name:      "scope",
+	}}, suite.reviews)
+	assert.Empty(suite.T(), ipRange.Status.Conditions, "stale ScopeRefForbidden and its Error conditions are removed")
+}
+
+func (suite *loadScopeFromRefSuite) TestCrossNamespaceForbidden() {
+	ipRange := newIpRangeWithScopeRef(cloudcontrolv1beta1.ScopeRef{Name: "scope", Namespace: "scopes"})
+
+	err, state := suite.run(ipRange, "scopes", "", newScopeInNamespace("scopes"))
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+	assert.Nil(suite.T(), state.Scope())
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScopeRefForbidden)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScopeRefForbidden, cond.Reason)
+	cond = meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScopeRefForbidden, cond.Reason)
+}
+
+func (suite *loadScopeFromRefSuite) TestCrossNamespaceDeniedByAccessReview() {
+	ipRange := newIpRangeWithScopeRef(cloudcontrolv1beta1.ScopeRef{Name: "scope", Namespace: "scopes"})
+
+	// the Scope is readable by the client, but the access review denies it
+	err, state := suite.run(ipRange, "", "scopes", newScopeInNamespace("scopes"))
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+	assert.Nil(suite.T(), state.Scope())
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScopeRefForbidden)
+	if assert.NotNil(suite.T(), cond) {
+		assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScopeRefForbidden, cond.Reason)
+		assert.Equal(suite.T(), "Access to Scope scope in namespace scopes is forbidden: no RBAC policy matched", cond.Message)
+	}
+}
+
+func TestLoadScopeFromRefSuite(t *testing.T) {
+	suite.Run(t, new(loadScopeFromRefSuite))
+}
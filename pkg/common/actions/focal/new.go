@@ -9,5 +9,6 @@ func New() composed.Action {
 		loadScopeFromRef,
 		loadKyma,
 		loadFeatureContext,
+		handleScopeDeletion,
 	)
 }
@@ -0,0 +1,88 @@
+package focal
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjWithProviderOverride is implemented by the objects that can specify their provider,
+// overriding the one derived from the Scope
+type ObjWithProviderOverride interface {
+	ProviderOverride() cloudcontrolv1beta1.ProviderType
+}
+
+// allowProviderMismatch allows the override of the provider different from the Scope provider
+var allowProviderMismatch = false
+
+// SetAllowProviderMismatch sets whether the objects can override the provider with one different
+// from the Scope provider. Must be called before the reconcilers are started.
+func SetAllowProviderMismatch(v bool) {
+	allowProviderMismatch = v
+}
+
+// Provider returns the provider the state object is reconciled with, the object override if specified,
+// or the Scope provider otherwise
+func Provider(state State) cloudcontrolv1beta1.ProviderType {
+	if obj, ok := state.Obj().(ObjWithProviderOverride); ok && len(obj.ProviderOverride()) > 0 {
+		return obj.ProviderOverride()
+	}
+	return state.Scope().Spec.Provider
+}
+
+func scopeHasProvider(scope *cloudcontrolv1beta1.Scope, provider cloudcontrolv1beta1.ProviderType) bool {
+	switch provider {
+	case cloudcontrolv1beta1.ProviderAws:
+		return scope.Spec.Scope.Aws != nil
+	case cloudcontrolv1beta1.ProviderAzure:
+		return scope.Spec.Scope.Azure != nil
+	case cloudcontrolv1beta1.ProviderGCP:
+		return scope.Spec.Scope.Gcp != nil
+	case cloudcontrolv1beta1.ProviderOpenStack:
+		return scope.Spec.Scope.OpenStack != nil
+	}
+	return false
+}
+
+// ValidateProviderOverride stops the object overriding the provider with one not compatible with its Scope.
+// The override different from the Scope provider is valid only if allowed with SetAllowProviderMismatch,
+// and if the Scope has the overriding provider configured, otherwise the Error condition is set with the
+// ProviderMismatch reason.
+func ValidateProviderOverride(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(State)
+	obj, ok := state.Obj().(ObjWithProviderOverride)
+	if !ok {
+		return nil, nil
+	}
+	override := obj.ProviderOverride()
+	scopeProvider := state.Scope().Spec.Provider
+	if len(override) == 0 || override == scopeProvider {
+		return nil, nil
+	}
+	if allowProviderMismatch && scopeHasProvider(state.Scope(), override) {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("Provider %s does not match the Scope provider %s", override, scopeProvider)
+	if allowProviderMismatch {
+		message = fmt.Sprintf("Provider %s is not configured in the Scope with provider %s", override, scopeProvider)
+	}
+
+	objWithConditions := state.Obj().(composed.ObjWithConditions)
+	if objWithState, ok := objWithConditions.(composed.ObjWithConditionsAndState); ok {
+		objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+	}
+	return composed.PatchStatus(objWithConditions).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonProviderMismatch,
+			Message: message,
+		}).
+		ErrorLogMessage("Error patching status with provider mismatch").
+		SuccessLogMsg("Forgetting object with provider mismatch").
+		Run(ctx, state)
+}
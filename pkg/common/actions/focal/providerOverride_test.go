@@ -0,0 +1,137 @@
+package focal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type providerOverrideSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *providerOverrideSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *providerOverrideSuite) TearDownTest() {
+	SetAllowProviderMismatch(false)
+}
+
+func (suite *providerOverrideSuite) newState(provider cloudcontrolv1beta1.ProviderType, scopeInfo cloudcontrolv1beta1.ScopeInfo) (State, *cloudcontrolv1beta1.IpRange) {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "ip-range"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			Scope:    cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+			Provider: provider,
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	state.SetScope(&cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Provider: cloudcontrolv1beta1.ProviderAws,
+			Scope:    scopeInfo,
+		},
+	})
+	return state, ipRange
+}
+
+func (suite *providerOverrideSuite) TestNoOverrideUsesScopeProvider() {
+	state, ipRange := suite.newState("", cloudcontrolv1beta1.ScopeInfo{Aws: &cloudcontrolv1beta1.AwsScope{}})
+
+	err, _ := ValidateProviderOverride(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ProviderAws, Provider(state))
+	assert.True(suite.T(), AwsProviderPredicate(suite.ctx, state))
+	assert.Empty(suite.T(), ipRange.Status.Conditions)
+}
+
+func (suite *providerOverrideSuite) TestValidOverride() {
+	SetAllowProviderMismatch(true)
+	state, ipRange := suite.newState(cloudcontrolv1beta1.ProviderGCP, cloudcontrolv1beta1.ScopeInfo{
+		Aws: &cloudcontrolv1beta1.AwsScope{},
+		Gcp: &cloudcontrolv1beta1.GcpScope{},
+	})
+
+	err, _ := ValidateProviderOverride(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), ipRange.Status.Conditions)
+
+	// the flow is dispatched to the overriding provider
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ProviderGCP, Provider(state))
+	assert.True(suite.T(), GcpProviderPredicate(suite.ctx, state))
+	assert.False(suite.T(), AwsProviderPredicate(suite.ctx, state))
+}
+
+func (suite *providerOverrideSuite) TestOverrideMatchingScopeIsValid() {
+	state, _ := suite.newState(cloudcontrolv1beta1.ProviderAws, cloudcontrolv1beta1.ScopeInfo{Aws: &cloudcontrolv1beta1.AwsScope{}})
+
+	err, _ := ValidateProviderOverride(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), AwsProviderPredicate(suite.ctx, state))
+}
+
+func (suite *providerOverrideSuite) TestMismatchRejected() {
+	state, ipRange := suite.newState(cloudcontrolv1beta1.ProviderGCP, cloudcontrolv1beta1.ScopeInfo{
+		Aws: &cloudcontrolv1beta1.AwsScope{},
+		Gcp: &cloudcontrolv1beta1.GcpScope{},
+	})
+
+	err, _ := ValidateProviderOverride(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ErrorState, ipRange.Status.State)
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonProviderMismatch, cond.Reason)
+	assert.Equal(suite.T(), "Provider gcp does not match the Scope provider aws", cond.Message)
+}
+
+func (suite *providerOverrideSuite) TestAllowedMismatchRejectedWithoutScopeProvider() {
+	SetAllowProviderMismatch(true)
+	state, ipRange := suite.newState(cloudcontrolv1beta1.ProviderAzure, cloudcontrolv1beta1.ScopeInfo{Aws: &cloudcontrolv1beta1.AwsScope{}})
+
+	err, _ := ValidateProviderOverride(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonProviderMismatch, cond.Reason)
+	assert.Equal(suite.T(), "Provider azure is not configured in the Scope with provider aws", cond.Message)
+}
+
+func TestProviderOverride(t *testing.T) {
+	suite.Run(t, new(providerOverrideSuite))
+}
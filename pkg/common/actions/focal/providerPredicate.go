@@ -8,20 +8,20 @@ import (
 
 func AwsProviderPredicate(_ context.Context, st composed.State) bool {
 	state := st.(State)
-	return state.Scope().Spec.Provider == cloudcontrolv1beta1.ProviderAws
+	return Provider(state) == cloudcontrolv1beta1.ProviderAws
 }
 
 func AzureProviderPredicate(_ context.Context, st composed.State) bool {
 	state := st.(State)
-	return state.Scope().Spec.Provider == cloudcontrolv1beta1.ProviderAzure
+	return Provider(state) == cloudcontrolv1beta1.ProviderAzure
 }
 
 func GcpProviderPredicate(_ context.Context, st composed.State) bool {
 	state := st.(State)
-	return state.Scope().Spec.Provider == cloudcontrolv1beta1.ProviderGCP
+	return Provider(state) == cloudcontrolv1beta1.ProviderGCP
 }
 
 func OpenStackProviderPredicate(_ context.Context, st composed.State) bool {
 	state := st.(State)
-	return state.Scope().Spec.Provider == cloudcontrolv1beta1.ProviderOpenStack
+	return Provider(state) == cloudcontrolv1beta1.ProviderOpenStack
 }
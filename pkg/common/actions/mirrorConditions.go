@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KcpConditionsFunc returns the conditions of the KCP object of the SKR state, or false if it is not loaded
+type KcpConditionsFunc func(state composed.State) ([]metav1.Condition, bool)
+
+// MirrorConditions returns an Action setting the conditions of the given types of the KCP object on the
+// SKR object of the state, with the same status, reason and message, and removing them once the KCP
+// object has them no more. It surfaces the conditions that are not mirrored by the Ready and Error
+// handling, like the warnings of the optional features set by composed.Optional.
+func MirrorConditions(kcpConditionsFn KcpConditionsFunc, conditionTypes ...string) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(composed.ObjWithConditions)
+		if !ok || composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		kcpConditions, loaded := kcpConditionsFn(state)
+		if !loaded {
+			return nil, nil
+		}
+
+		builder := composed.UpdateStatus(obj)
+		var changed bool
+		var removed []string
+		for _, conditionType := range conditionTypes {
+			kcpCond := meta.FindStatusCondition(kcpConditions, conditionType)
+			skrCond := meta.FindStatusCondition(*obj.Conditions(), conditionType)
+			if kcpCond == nil {
+				if skrCond != nil {
+					removed = append(removed, conditionType)
+				}
+				continue
+			}
+			_, message := composed.ConditionSeverityFromMessage(kcpCond.Message)
+			if skrCond != nil &&
+				skrCond.Status == kcpCond.Status &&
+				skrCond.Reason == kcpCond.Reason &&
+				composed.ConditionMessageEquals(*skrCond, message) {
+				continue
+			}
+			builder.SetCondition(metav1.Condition{
+				Type:    conditionType,
+				Status:  kcpCond.Status,
+				Reason:  kcpCond.Reason,
+				Message: message,
+			})
+			changed = true
+		}
+		if len(removed) > 0 {
+			builder.RemoveConditions(removed...)
+			changed = true
+		}
+		if !changed {
+			return nil, nil
+		}
+
+		return builder.
+			ErrorLogMessage("Error updating status with the conditions mirrored from KCP").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
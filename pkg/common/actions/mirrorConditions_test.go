@@ -0,0 +1,109 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestMirrorConditions(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudresourcesv1beta1.AddToScheme(scheme))
+
+	skrObj := &cloudresourcesv1beta1.AwsNfsVolume{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nfs"},
+		Status: cloudresourcesv1beta1.AwsNfsVolumeStatus{
+			Conditions: []metav1.Condition{{
+				Type:    cloudresourcesv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudresourcesv1beta1.ConditionReasonReady,
+				Message: "Ready",
+			}},
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(skrObj).
+		WithStatusSubresource(skrObj).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(skrObj), skrObj)
+
+	var kcpConditions []metav1.Condition
+	kcpLoaded := true
+	action := MirrorConditions(
+		func(state composed.State) ([]metav1.Condition, bool) {
+			return kcpConditions, kcpLoaded
+		},
+		cloudresourcesv1beta1.ConditionTypeBackupConfigurationFailed,
+	)
+
+	loadSkrConditions := func(t *testing.T) []metav1.Condition {
+		loaded := &cloudresourcesv1beta1.AwsNfsVolume{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(skrObj), loaded))
+		return loaded.Status.Conditions
+	}
+
+	t.Run("warning of the KCP object is set", func(t *testing.T) {
+		kcpConditions = []metav1.Condition{
+			{
+				Type:    cloudcontrolv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReady,
+				Message: "Ready",
+			},
+			{
+				Type:    cloudcontrolv1beta1.ConditionTypeBackupConfigurationFailed,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonBackupConfigurationFailed,
+				Message: "[Warning] Access denied",
+			},
+		}
+
+		err, _ := action(ctx, state)
+
+		assert.NoError(t, err)
+		conditions := loadSkrConditions(t)
+		assert.NotNil(t, meta.FindStatusCondition(conditions, cloudresourcesv1beta1.ConditionTypeReady))
+		cond := meta.FindStatusCondition(conditions, cloudresourcesv1beta1.ConditionTypeBackupConfigurationFailed)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonBackupConfigurationFailed, cond.Reason)
+		assert.Equal(t, "Access denied", cond.Message)
+	})
+
+	t.Run("unloaded KCP object changes nothing", func(t *testing.T) {
+		kcpLoaded = false
+		defer func() { kcpLoaded = true }()
+
+		err, _ := action(ctx, state)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, meta.FindStatusCondition(loadSkrConditions(t), cloudresourcesv1beta1.ConditionTypeBackupConfigurationFailed))
+	})
+
+	t.Run("warning is removed once removed from the KCP object", func(t *testing.T) {
+		kcpConditions = kcpConditions[:1]
+
+		err, _ := action(ctx, state)
+
+		assert.NoError(t, err)
+		conditions := loadSkrConditions(t)
+		assert.NotNil(t, meta.FindStatusCondition(conditions, cloudresourcesv1beta1.ConditionTypeReady))
+		assert.Nil(t, meta.FindStatusCondition(conditions, cloudresourcesv1beta1.ConditionTypeBackupConfigurationFailed))
+	})
+}
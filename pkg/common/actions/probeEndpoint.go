@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const DefaultEndpointProbeTimeout = 5 * time.Second
+
+// Dialer opens the probe connections, implemented by the net.Dialer
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+var (
+	endpointProbeEnabled bool
+	endpointProbeTimeout        = DefaultEndpointProbeTimeout
+	endpointProbeDialer  Dialer = &net.Dialer{}
+)
+
+// SetEndpointProbe configures the ProbeEndpoint actions. The probe connects from the cloud-manager
+// pod, so it should be enabled only if the cloud-manager network can reach the managed networks,
+// otherwise every endpoint would be reported as unreachable. Zero timeout sets the default one.
+func SetEndpointProbe(enabled bool, timeout time.Duration) {
+	endpointProbeEnabled = enabled
+	endpointProbeTimeout = DefaultEndpointProbeTimeout
+	if timeout > 0 {
+		endpointProbeTimeout = timeout
+	}
+}
+
+// EndpointResolver returns the host:port endpoint of the object to probe, empty if not known yet,
+// and whether the endpoint is a plaintext Redis server that should also reply to the PING
+type EndpointResolver func(ctx context.Context, state composed.State) (endpoint string, redisPing bool)
+
+// ProbeEndpoint returns an Action that actively checks the endpoint of the Ready object is reachable,
+// by opening a TCP connection to it and optionally sending the Redis PING, and reflects the result in
+// the EndpointReachable condition. It catches the resources reported as Ready by the cloud provider but
+// unreachable due to a security group or network ACL misconfiguration. The probe runs on every reconcile
+// and never stops the flow. It is skipped if disabled by SetEndpointProbe, since it is meaningful only
+// when run from within the managed network context.
+func ProbeEndpoint(resolver EndpointResolver) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if !endpointProbeEnabled || composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(composed.ObjWithConditions)
+		if !ok || !meta.IsStatusConditionTrue(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeReady) {
+			return nil, nil
+		}
+		endpoint, redisPing := resolver(ctx, state)
+		if len(endpoint) == 0 {
+			return nil, nil
+		}
+
+		condition := metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeEndpointReachable,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonEndpointReachable,
+			Message: fmt.Sprintf("Endpoint %s is reachable", endpoint),
+		}
+		if err := probeEndpoint(ctx, endpoint, redisPing); err != nil {
+			composed.LoggerFromCtx(ctx).
+				WithValues("endpoint", endpoint).
+				Info(fmt.Sprintf("Endpoint unreachable: %s", err))
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = cloudcontrolv1beta1.ReasonEndpointUnreachable
+			condition.Message = fmt.Sprintf("Endpoint %s is unreachable: %s", endpoint, err)
+		}
+
+		if !composed.AnyConditionChanged(obj, condition) {
+			return nil, nil
+		}
+
+		return composed.UpdateStatus(obj).
+			SetCondition(condition).
+			ErrorLogMessage("Error updating status with EndpointReachable condition").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
+
+func probeEndpoint(ctx context.Context, endpoint string, redisPing bool) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+
+	conn, err := endpointProbeDialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if !redisPing {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if _, err := conn.Write([]byte("PING\r\n")); err != nil {
+		return fmt.Errorf("error sending PING: %w", err)
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("error reading PING reply: %w", err)
+	}
+	// the error reply, like NOAUTH of the instance with AUTH enabled, still means the server is reachable
+	if !strings.HasPrefix(reply, "+") && !strings.HasPrefix(reply, "-") {
+		return fmt.Errorf("unexpected PING reply %q", strings.TrimSpace(reply))
+	}
+	return nil
+}
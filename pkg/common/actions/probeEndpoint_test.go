@@ -0,0 +1,186 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeDialer connects to an in-memory server that replies to the PING with the reply, or closes
+// the connection if the reply is empty
+type fakeDialer struct {
+	err       error
+	reply     string
+	addresses []string
+}
+
+func (d *fakeDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	d.addresses = append(d.addresses, address)
+	if d.err != nil {
+		return nil, d.err
+	}
+	clientConn, serverConn := net.Pipe()
+	go func() {
+		defer func() {
+			_ = serverConn.Close()
+		}()
+		if len(d.reply) == 0 {
+			return
+		}
+		buf := make([]byte, 64)
+		n, _ := serverConn.Read(buf)
+		if string(buf[:n]) == "PING\r\n" {
+			_, _ = serverConn.Write([]byte(d.reply))
+		}
+	}()
+	return clientConn, nil
+}
+
+func runProbeEndpoint(t *testing.T, dialer *fakeDialer, ready bool, endpoint string, redisPing bool) (error, *cloudcontrolv1beta1.NfsInstance) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	nfs := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+	}
+	if ready {
+		nfs.Status.Conditions = []metav1.Condition{{
+			Type:               cloudcontrolv1beta1.ConditionTypeReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             cloudcontrolv1beta1.ReasonReady,
+			LastTransitionTime: metav1.Now(),
+		}}
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfs).
+		WithStatusSubresource(&cloudcontrolv1beta1.NfsInstance{}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(nfs), &cloudcontrolv1beta1.NfsInstance{})
+
+	endpointProbeDialer = dialer
+	err, _ := composed.ComposeActions(
+		"test",
+		composed.LoadObj,
+		ProbeEndpoint(func(ctx context.Context, state composed.State) (string, bool) {
+			return endpoint, redisPing
+		}),
+	)(ctx, state)
+
+	loaded := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(t, clnt.Get(ctx, types.NamespacedName{Namespace: nfs.Namespace, Name: nfs.Name}, loaded))
+	return err, loaded
+}
+
+func TestProbeEndpoint(t *testing.T) {
+	defer func() {
+		SetEndpointProbe(false, 0)
+		endpointProbeDialer = &net.Dialer{}
+	}()
+
+	assertCondition := func(t *testing.T, nfs *cloudcontrolv1beta1.NfsInstance, status metav1.ConditionStatus, reason, message string) {
+		cond := meta.FindStatusCondition(nfs.Status.Conditions, cloudcontrolv1beta1.ConditionTypeEndpointReachable)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, status, cond.Status)
+			assert.Equal(t, reason, cond.Reason)
+			assert.Equal(t, message, cond.Message)
+		}
+	}
+
+	t.Run("disabled probe is skipped", func(t *testing.T) {
+		SetEndpointProbe(false, 0)
+		dialer := &fakeDialer{}
+		err, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.2:2049", false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, dialer.addresses)
+		assert.Nil(t, meta.FindStatusCondition(loaded.Status.Conditions, cloudcontrolv1beta1.ConditionTypeEndpointReachable))
+	})
+
+	SetEndpointProbe(true, 0)
+
+	t.Run("not ready object is skipped", func(t *testing.T) {
+		dialer := &fakeDialer{}
+		err, loaded := runProbeEndpoint(t, dialer, false, "10.0.0.2:2049", false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, dialer.addresses)
+		assert.Nil(t, meta.FindStatusCondition(loaded.Status.Conditions, cloudcontrolv1beta1.ConditionTypeEndpointReachable))
+	})
+
+	t.Run("unknown endpoint is skipped", func(t *testing.T) {
+		dialer := &fakeDialer{}
+		err, _ := runProbeEndpoint(t, dialer, true, "", false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, dialer.addresses)
+	})
+
+	t.Run("reachable endpoint", func(t *testing.T) {
+		dialer := &fakeDialer{}
+		err, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.2:2049", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.2:2049"}, dialer.addresses)
+		assertCondition(t, loaded, metav1.ConditionTrue, cloudcontrolv1beta1.ReasonEndpointReachable, "Endpoint 10.0.0.2:2049 is reachable")
+	})
+
+	t.Run("unreachable endpoint does not stop the flow", func(t *testing.T) {
+		dialer := &fakeDialer{err: errors.New("i/o timeout")}
+		err, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.2:2049", false)
+
+		assert.NoError(t, err)
+		assertCondition(t, loaded, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonEndpointUnreachable, "Endpoint 10.0.0.2:2049 is unreachable: i/o timeout")
+	})
+
+	t.Run("redis replies to ping", func(t *testing.T) {
+		dialer := &fakeDialer{reply: "+PONG\r\n"}
+		err, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.3:6379", true)
+
+		assert.NoError(t, err)
+		assertCondition(t, loaded, metav1.ConditionTrue, cloudcontrolv1beta1.ReasonEndpointReachable, "Endpoint 10.0.0.3:6379 is reachable")
+	})
+
+	t.Run("redis with auth enabled is reachable", func(t *testing.T) {
+		dialer := &fakeDialer{reply: "-NOAUTH Authentication required.\r\n"}
+		_, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.3:6379", true)
+
+		assertCondition(t, loaded, metav1.ConditionTrue, cloudcontrolv1beta1.ReasonEndpointReachable, "Endpoint 10.0.0.3:6379 is reachable")
+	})
+
+	t.Run("redis unexpected ping reply", func(t *testing.T) {
+		dialer := &fakeDialer{reply: "HTTP/1.1 400 Bad Request\r\n"}
+		_, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.3:6379", true)
+
+		assertCondition(t, loaded, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonEndpointUnreachable,
+			`Endpoint 10.0.0.3:6379 is unreachable: unexpected PING reply "HTTP/1.1 400 Bad Request"`)
+	})
+
+	t.Run("redis closing connection without ping reply", func(t *testing.T) {
+		dialer := &fakeDialer{}
+		_, loaded := runProbeEndpoint(t, dialer, true, "10.0.0.3:6379", true)
+
+		cond := meta.FindStatusCondition(loaded.Status.Conditions, cloudcontrolv1beta1.ConditionTypeEndpointReachable)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Contains(t, cond.Message, "error")
+		}
+	})
+}
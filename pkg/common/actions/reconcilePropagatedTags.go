@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+)
+
+// ObjWithPropagatedTags is implemented by the objects recording the keys of the tags propagated from
+// the Scope to their cloud resources, so the tags are removed once they are not propagated anymore
+type ObjWithPropagatedTags interface {
+	composed.ObjWithConditions
+	PropagatedTags() []string
+	SetPropagatedTags(keys []string)
+}
+
+// TaggedResource is the existing cloud resource the Scope tags are propagated to, with its current tags
+type TaggedResource struct {
+	Id   string
+	Tags map[string]string
+}
+
+// TagResourceFunc adds the tags to the cloud resource, replacing the values of the existing ones
+type TagResourceFunc func(ctx context.Context, state composed.State, id string, tags map[string]string) error
+
+// UntagResourceFunc removes the tags with the given keys from the cloud resource
+type UntagResourceFunc func(ctx context.Context, state composed.State, id string, keys []string) error
+
+// ReconcilePropagatedTags returns an Action that keeps the spec tags of the object returned by specTagsFn, if
+// given, and the tags propagated from the Scope labels and default tags on the existing cloud resources of the
+// state. The spec tags take precedence over the propagated ones, and the reserved tags of the object returned by
+// ownTagsFn, as the cloud-manager tags, take precedence over both, so the tags with the same key are skipped.
+// The changed tags are updated, and the tags previously propagated, as recorded in the status, are removed once
+// no longer propagated. The propagated keys are recorded in the status.
+func ReconcilePropagatedTags(
+	provider cloudcontrolv1beta1.ProviderType,
+	resourcesFn func(state composed.State) []TaggedResource,
+	specTagsFn func(state composed.State) map[string]string,
+	ownTagsFn func(state composed.State) map[string]string,
+	tagFn TagResourceFunc,
+	untagFn UntagResourceFunc,
+) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithPropagatedTags)
+		if !ok || composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		scopeState, ok := state.(interface {
+			Scope() *cloudcontrolv1beta1.Scope
+		})
+		if !ok {
+			return nil, nil
+		}
+		logger := composed.LoggerFromCtx(ctx)
+
+		own := ownTagsFn(state)
+		var specTags map[string]string
+		if specTagsFn != nil {
+			specTags = specTagsFn(state)
+		}
+		desired := map[string]string{}
+		for k, v := range common.TagsWithPropagated(provider, scopeState.Scope(), specTags, nil) {
+			if _, isOwn := own[k]; !isOwn {
+				desired[k] = v
+			}
+		}
+		removed := pie.Filter(obj.PropagatedTags(), func(key string) bool {
+			_, isDesired := desired[key]
+			_, isOwn := own[key]
+			return !isDesired && !isOwn
+		})
+
+		for _, resource := range resourcesFn(state) {
+			changed := map[string]string{}
+			for k, v := range desired {
+				if current, exists := resource.Tags[k]; !exists || current != v {
+					changed[k] = v
+				}
+			}
+			if len(changed) > 0 {
+				logger.
+					WithValues(
+						"resourceId", resource.Id,
+						"tags", pie.Sort(pie.Keys(changed)),
+					).
+					Info("Propagating Scope tags to the cloud resource")
+				if err := tagFn(ctx, state, resource.Id, changed); err != nil {
+					return composed.LogErrorAndReturn(err, "Error propagating Scope tags", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+				}
+			}
+
+			stale := pie.Filter(removed, func(key string) bool {
+				_, exists := resource.Tags[key]
+				return exists
+			})
+			if len(stale) > 0 {
+				logger.
+					WithValues(
+						"resourceId", resource.Id,
+						"tags", stale,
+					).
+					Info("Removing tags no longer propagated from the Scope from the cloud resource")
+				if err := untagFn(ctx, state, resource.Id, stale); err != nil {
+					return composed.LogErrorAndReturn(err, "Error removing propagated Scope tags", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+				}
+			}
+		}
+
+		keys := pie.Sort(pie.Keys(desired))
+		if pie.Equals(keys, obj.PropagatedTags()) {
+			return nil, nil
+		}
+		obj.SetPropagatedTags(keys)
+
+		return composed.UpdateStatus(obj).
+			ErrorLogMessage("Error updating status with propagated tags").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 
-	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -14,7 +14,12 @@ func PatchRemoveFinalizer(ctx context.Context, state composed.State) (error, con
 		return nil, nil
 	}
 
-	_, err := state.PatchObjRemoveFinalizer(ctx, cloudcontrolv1beta1.FinalizerName)
+	var err error
+	if feature.ServerSideApply.Value(ctx) {
+		_, err = composed.ApplyObjRemoveFinalizer(ctx, FinalizerName(), state.Obj(), state.Cluster().K8sClient())
+	} else {
+		_, err = state.PatchObjRemoveFinalizer(ctx, FinalizerName())
+	}
 
 	if err != nil {
 		return composed.LogErrorAndReturn(err, "Error patching obj to remove finalizer", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
@@ -31,12 +36,19 @@ func RemoveFinalizer(ctx context.Context, state composed.State) (error, context.
 	}
 
 	//If finalizer not already present, don't remove it .
-	if !controllerutil.ContainsFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName) {
+	if !controllerutil.ContainsFinalizer(state.Obj(), FinalizerName()) {
 		return nil, nil
 	}
 
 	//Remove finalizer
-	controllerutil.RemoveFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName)
+	if feature.ServerSideApply.Value(ctx) {
+		if _, err := composed.ApplyObjRemoveFinalizer(ctx, FinalizerName(), state.Obj(), state.Cluster().K8sClient()); err != nil {
+			return composed.LogErrorAndReturn(err, "Error applying object without Finalizer", composed.StopWithRequeue, ctx)
+		}
+		return composed.StopAndForget, nil
+	}
+
+	controllerutil.RemoveFinalizer(state.Obj(), FinalizerName())
 	if err := state.UpdateObj(ctx); err != nil {
 		return composed.LogErrorAndReturn(err, "Error removing Finalizer", composed.StopWithRequeue, ctx)
 	}
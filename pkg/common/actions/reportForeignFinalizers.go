@@ -0,0 +1,60 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReportForeignFinalizers is a delete-path diagnostic meant to run after PatchRemoveFinalizer or
+// RemoveFinalizer. If the cloud-manager finalizer is removed, but the object is still not deleted
+// since finalizers of other operators remain on it, it logs them and sets the ForeignFinalizerBlocking
+// condition listing them. Foreign finalizers are never removed, only reported. If there are no
+// foreign finalizers, or the cloud-manager finalizer is still present, it does nothing.
+func ReportForeignFinalizers(ctx context.Context, state composed.State) (error, context.Context) {
+	if !composed.MarkedForDeletionPredicate(ctx, state) {
+		return nil, nil
+	}
+
+	var foreign []string
+	for _, f := range state.Obj().GetFinalizers() {
+		if f == FinalizerName() {
+			// our finalizer is still here, deletion is still in progress
+			return nil, nil
+		}
+		foreign = append(foreign, f)
+	}
+	if len(foreign) == 0 {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+	logger.
+		WithValues("foreignFinalizers", foreign).
+		Info("Object deletion is blocked by foreign finalizers")
+
+	obj, ok := state.Obj().(composed.ObjWithConditions)
+	if !ok {
+		return composed.StopAndForget, nil
+	}
+
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeForeignFinalizerBlocking,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonForeignFinalizerBlocking,
+		Message: fmt.Sprintf("Deletion is blocked by foreign finalizers: %s", strings.Join(foreign, ", ")),
+	}
+	if !composed.AnyConditionChanged(obj, condition) {
+		return composed.StopAndForget, nil
+	}
+
+	return composed.PatchStatus(obj).
+		SetExclusiveConditions(condition).
+		ErrorLogMessage("Error patching status with foreign finalizer blocking condition").
+		SuccessLogMsg("Forgetting object with deletion blocked by foreign finalizers").
+		Run(ctx, state)
+}
@@ -0,0 +1,102 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// newForeignFinalizersState returns the state of the IpRange with the given finalizers, marked for deletion
+// if deleted is true, and the pointer to the count of the status patches
+func newForeignFinalizersState(deleted bool, finalizers ...string) (composed.State, *cloudcontrolv1beta1.IpRange, *int) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange", Finalizers: finalizers},
+	}
+	if deleted {
+		obj.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+	}
+	patches := 0
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				patches++
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(obj), obj)
+	return state, obj, &patches
+}
+
+func TestReportForeignFinalizers(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	t.Run("object not marked for deletion is not reported", func(t *testing.T) {
+		state, obj, patches := newForeignFinalizersState(false, "other.io/finalizer")
+
+		err, _ := ReportForeignFinalizers(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, *patches)
+		assert.Empty(t, obj.Status.Conditions)
+	})
+
+	t.Run("object with cloud-manager finalizer is not reported", func(t *testing.T) {
+		state, obj, patches := newForeignFinalizersState(true, "other.io/finalizer", FinalizerName())
+
+		err, _ := ReportForeignFinalizers(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, *patches)
+		assert.Empty(t, obj.Status.Conditions)
+	})
+
+	t.Run("foreign finalizers remaining after cloud-manager finalizer removal are reported", func(t *testing.T) {
+		state, obj, patches := newForeignFinalizersState(true, "other.io/first", FinalizerName(), "other.io/second")
+
+		err, _ := composed.ComposeActions(
+			"test",
+			PatchRemoveFinalizer,
+			ReportForeignFinalizers,
+		)(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Equal(t, 1, *patches)
+		// foreign finalizers are not removed
+		assert.Equal(t, []string{"other.io/first", "other.io/second"}, obj.Finalizers)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeForeignFinalizerBlocking)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, cloudcontrolv1beta1.ReasonForeignFinalizerBlocking, cond.Reason)
+			assert.Equal(t, "Deletion is blocked by foreign finalizers: other.io/first, other.io/second", cond.Message)
+		}
+		assert.Len(t, obj.Status.Conditions, 1)
+
+		// the unchanged condition is not patched again
+		err, _ = ReportForeignFinalizers(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Equal(t, 1, *patches)
+	})
+}
@@ -0,0 +1,52 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// StagedFinalizerName returns the finalizer of the cleanup stage. It is derived from the configured
+// finalizer name, so the finalizers of all stages are released together with it when the object
+// is abandoned, as on the Scope deletion.
+func StagedFinalizerName(stage string) string {
+	return FinalizerName() + "." + stage
+}
+
+// StagedFinalizers returns the cleanup stage finalizers the object has
+func StagedFinalizers(obj client.Object) []string {
+	var result []string
+	for _, f := range obj.GetFinalizers() {
+		if strings.HasPrefix(f, FinalizerName()+".") {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// AddStagedFinalizers returns an Action that adds the finalizers of the cleanup stages, in the order the
+// stages run on delete. It must precede the AddFinalizer, since the stages are added only to the object
+// that does not have the finalizer yet. The existing objects are not changed and are deleted without
+// the stages, what the RemoveStagedFinalizer of each stage handles.
+func AddStagedFinalizers(stages ...string) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if controllerutil.ContainsFinalizer(state.Obj(), FinalizerName()) {
+			return nil, nil
+		}
+		names := make([]string, 0, len(stages))
+		for _, stage := range stages {
+			names = append(names, StagedFinalizerName(stage))
+		}
+		return composed.AddFinalizers(names...)(ctx, state)
+	}
+}
+
+// RemoveStagedFinalizer returns an Action that removes the finalizer of the completed cleanup stage
+func RemoveStagedFinalizer(stage string) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		return composed.RemoveFinalizer(StagedFinalizerName(stage))(ctx, state)
+	}
+}
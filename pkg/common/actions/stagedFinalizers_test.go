@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func runAddStagedFinalizers(t *testing.T, finalizers ...string) []string {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis", Finalizers: finalizers},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(obj), &cloudcontrolv1beta1.RedisInstance{})
+
+	err, _ := composed.ComposeActions(
+		"test",
+		composed.LoadObj,
+		AddStagedFinalizers("stage1", "stage2"),
+		AddFinalizer,
+	)(ctx, state)
+	assert.NoError(t, err)
+
+	loaded := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), loaded))
+	return loaded.Finalizers
+}
+
+func TestAddStagedFinalizers(t *testing.T) {
+
+	t.Run("new object gets the stages", func(t *testing.T) {
+		finalizers := runAddStagedFinalizers(t)
+		assert.Equal(t, []string{
+			StagedFinalizerName("stage1"),
+			StagedFinalizerName("stage2"),
+			FinalizerName(),
+		}, finalizers)
+		assert.Equal(t, []string{StagedFinalizerName("stage1"), StagedFinalizerName("stage2")},
+			StagedFinalizers(&cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Finalizers: finalizers}}))
+	})
+
+	t.Run("existing object is not changed", func(t *testing.T) {
+		finalizers := runAddStagedFinalizers(t, FinalizerName())
+		assert.Equal(t, []string{FinalizerName()}, finalizers)
+	})
+}
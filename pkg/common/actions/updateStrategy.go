@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjWithUpdateStrategy is implemented by the objects which cloud resource can be recreated to apply
+// the spec changes that can not be applied in-place
+type ObjWithUpdateStrategy interface {
+	composed.ObjWithConditions
+	UpdateStrategy() cloudcontrolv1beta1.UpdateStrategy
+}
+
+// Recreator is the provider specific part of the update strategy
+type Recreator interface {
+	// RequiresRecreate returns the description of the spec change that can not be applied to the
+	// existing cloud resource, or an empty string if there is none
+	RequiresRecreate(ctx context.Context, state composed.State) string
+	// Stateful returns true if the data of the cloud resource is lost when it is recreated
+	Stateful() bool
+	// Delete deletes the existing cloud resource, returning nil error once it is gone
+	Delete(ctx context.Context, state composed.State) (error, context.Context)
+}
+
+// ReconcileUpdateStrategy returns an Action that handles the spec change requiring the cloud resource
+// to be recreated. With the InPlace update strategy the object is put in the error state with the
+// RequiresRecreate reason. With the Recreate strategy the cloud resource is deleted with the Updating
+// condition and the Recreating reason, so the rest of the flow creates it again. The recreation of the
+// stateful cloud resource must additionally be confirmed with the AnnotationConfirmRecreate set to the
+// object generation, until then the RecreateRequiresConfirmation condition is set. Inside the maintenance
+// freeze window of the object the recreation is deferred until the window ends.
+// Must run after the cloud resource is loaded and before it is modified.
+func ReconcileUpdateStrategy(recreator Recreator) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithUpdateStrategy)
+		if !ok {
+			return nil, nil
+		}
+
+		change := recreator.RequiresRecreate(ctx, state)
+		if change == "" {
+			return nil, nil
+		}
+
+		if obj.UpdateStrategy() != cloudcontrolv1beta1.UpdateStrategyRecreate {
+			return composed.PatchStatus(obj).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonRequiresRecreate,
+					Message: fmt.Sprintf("%s, the instance must be recreated", change),
+				}).
+				ErrorLogMessage("Error patching status with the change requiring recreate").
+				SuccessLogMsg("Change requiring recreate requested with the InPlace update strategy").
+				SuccessError(composed.StopAndForget).
+				Run(ctx, state)
+		}
+
+		generation := strconv.FormatInt(obj.GetGeneration(), 10)
+		if recreator.Stateful() && obj.GetAnnotations()[cloudcontrolv1beta1.AnnotationConfirmRecreate] != generation {
+			return composed.PatchStatus(obj).
+				SetExclusiveConditions(metav1.Condition{
+					Type:   cloudcontrolv1beta1.ConditionTypeRecreateRequiresConfirmation,
+					Status: metav1.ConditionTrue,
+					Reason: cloudcontrolv1beta1.ReasonRecreateRequiresConfirmation,
+					Message: fmt.Sprintf("%s, the instance must be recreated losing its data, confirm with the annotation %s: \"%s\"",
+						change, cloudcontrolv1beta1.AnnotationConfirmRecreate, generation),
+				}).
+				ErrorLogMessage("Error patching status with the recreate requiring confirmation").
+				SuccessLogMsg("Recreate requires confirmation").
+				SuccessError(composed.StopAndForget).
+				Run(ctx, state)
+		}
+
+		if until, frozen := composed.MaintenanceFrozenUntil(ctx, state); frozen {
+			composed.LoggerFromCtx(ctx).
+				WithValues("change", change).
+				Info("Recreation deferred in maintenance freeze window")
+			err, resCtx := composed.DeferFrozen(ctx, state, until)
+			if err != nil {
+				return err, resCtx
+			}
+			// the rest of the flow can not apply the change to the existing cloud resource
+			return composed.StopAndForget, resCtx
+		}
+
+		condition := metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeUpdating,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonRecreating,
+			Message: fmt.Sprintf("%s, the instance is being recreated", change),
+		}
+		if composed.AnyConditionChanged(obj, condition) {
+			composed.LoggerFromCtx(ctx).
+				WithValues("change", change).
+				Info("Recreating the cloud resource")
+			err, _ := composed.PatchStatus(obj).
+				SetExclusiveConditions(condition).
+				ErrorLogMessage("Error patching status with the Recreating condition").
+				SuccessErrorNil().
+				Run(ctx, state)
+			if err != nil {
+				return err, ctx
+			}
+		}
+
+		err, resCtx := recreator.Delete(ctx, state)
+		if err != nil {
+			return err, resCtx
+		}
+
+		// deleted, created again on the next run
+		return composed.StopWithRequeue, nil
+	}
+}
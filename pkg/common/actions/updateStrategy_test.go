@@ -0,0 +1,155 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type testRecreator struct {
+	change  string
+	deleted bool
+}
+
+func (r *testRecreator) RequiresRecreate(_ context.Context, _ composed.State) string {
+	return r.change
+}
+
+func (r *testRecreator) Stateful() bool {
+	return true
+}
+
+func (r *testRecreator) Delete(_ context.Context, _ composed.State) (error, context.Context) {
+	r.deleted = true
+	return nil, nil
+}
+
+func newUpdateStrategyState(obj *cloudcontrolv1beta1.RedisInstance) composed.State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	return composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+}
+
+func TestReconcileUpdateStrategy(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	t.Run("in-place change", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		recreator := &testRecreator{}
+
+		err, _ := ReconcileUpdateStrategy(recreator)(ctx, newUpdateStrategyState(obj))
+
+		assert.Nil(t, err)
+		assert.False(t, recreator.deleted)
+		assert.Empty(t, obj.Status.Conditions)
+	})
+
+	t.Run("recreate rejected with InPlace strategy", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		obj.Spec.UpdateStrategy = cloudcontrolv1beta1.UpdateStrategyInPlace
+		recreator := &testRecreator{change: "Cluster mode can not be changed"}
+
+		err, _ := ReconcileUpdateStrategy(recreator)(ctx, newUpdateStrategyState(obj))
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.False(t, recreator.deleted)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonRequiresRecreate, cond.Reason)
+	})
+
+	t.Run("recreate requires confirmation", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kcp-system",
+			Name:        "redis",
+			Generation:  3,
+			Annotations: map[string]string{cloudcontrolv1beta1.AnnotationConfirmRecreate: "2"},
+		}}
+		obj.Spec.UpdateStrategy = cloudcontrolv1beta1.UpdateStrategyRecreate
+		recreator := &testRecreator{change: "Cluster mode can not be changed"}
+
+		err, _ := ReconcileUpdateStrategy(recreator)(ctx, newUpdateStrategyState(obj))
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.False(t, recreator.deleted, "confirmation of the earlier generation does not apply")
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeRecreateRequiresConfirmation)
+		assert.NotNil(t, cond)
+		assert.Contains(t, cond.Message, `cloud-control.kyma-project.io/confirm-recreate: "3"`)
+	})
+
+	t.Run("confirmed recreate", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kcp-system",
+			Name:        "redis",
+			Generation:  3,
+			Annotations: map[string]string{cloudcontrolv1beta1.AnnotationConfirmRecreate: "3"},
+		}}
+		obj.Spec.UpdateStrategy = cloudcontrolv1beta1.UpdateStrategyRecreate
+		obj.Status.Conditions = []metav1.Condition{{
+			Type:   cloudcontrolv1beta1.ConditionTypeRecreateRequiresConfirmation,
+			Status: metav1.ConditionTrue,
+			Reason: cloudcontrolv1beta1.ReasonRecreateRequiresConfirmation,
+		}}
+		recreator := &testRecreator{change: "Cluster mode can not be changed"}
+
+		err, _ := ReconcileUpdateStrategy(recreator)(ctx, newUpdateStrategyState(obj))
+
+		assert.Equal(t, composed.StopWithRequeue, err)
+		assert.True(t, recreator.deleted)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeRecreateRequiresConfirmation))
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpdating)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonRecreating, cond.Reason)
+	})
+
+	t.Run("confirmed recreate deferred in maintenance freeze window", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kcp-system",
+			Name:        "redis",
+			Generation:  3,
+			Annotations: map[string]string{cloudcontrolv1beta1.AnnotationConfirmRecreate: "3"},
+		}}
+		obj.Spec.UpdateStrategy = cloudcontrolv1beta1.UpdateStrategyRecreate
+		now := time.Now().UTC()
+		obj.Spec.MaintenanceFreeze = []cloudcontrolv1beta1.MaintenanceFreezeWindow{{
+			Start: now.Add(-time.Hour).Format("15:04"),
+			End:   now.Add(time.Hour).Format("15:04"),
+		}}
+		recreator := &testRecreator{change: "Cluster mode can not be changed"}
+
+		err, _ := ReconcileUpdateStrategy(recreator)(ctx, newUpdateStrategyState(obj))
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.False(t, recreator.deleted)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpdating))
+		assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, composed.ConditionTypeMaintenanceFrozen))
+	})
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationValidatedSpecHash holds the hash of the spec ValidateSpec last accepted
+const AnnotationValidatedSpecHash = "cloud-manager.kyma-project.io/validated-spec-hash"
+
+// ValidateSpec returns an Action that checks the spec with the validate function shared with the
+// admission webhook, so the objects persisted while the webhook was not enabled are rejected the same way.
+// The spec is checked only on create and on change, what the hash of the accepted spec recorded in the
+// AnnotationValidatedSpecHash tells, so the existing objects are not rejected when the limits get stricter.
+// The objects that already have the Ready condition without the annotation are accepted as they are.
+// Invalid objects get the ValidationFailed reason listing the field errors, and are forgotten.
+func ValidateSpec[T composed.ObjWithConditions](validate func(obj T) field.ErrorList) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+
+		obj, ok := state.Obj().(T)
+		if !ok {
+			return nil, nil
+		}
+
+		hash, err := composed.SpecHash(obj)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error calculating spec hash", composed.StopWithRequeue, ctx)
+		}
+		validatedHash, validated := obj.GetAnnotations()[AnnotationValidatedSpecHash]
+		if validatedHash == hash {
+			return nil, nil
+		}
+		if !validated && meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeReady) != nil {
+			return recordValidatedSpecHash(ctx, state, hash)
+		}
+
+		errs := validate(obj)
+		if len(errs) == 0 {
+			return recordValidatedSpecHash(ctx, state, hash)
+		}
+
+		logger := composed.LoggerFromCtx(ctx)
+		logger.
+			WithValues("validationErrors", errs.ToAggregate().Error()).
+			Info("Spec exceeds the provider limits")
+
+		if objWithState, ok := composed.ObjWithConditions(obj).(composed.ObjWithConditionsAndState); ok {
+			objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+		}
+
+		return composed.PatchStatus(obj).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonValidationFailed,
+				Message: errs.ToAggregate().Error(),
+			}).
+			ErrorLogMessage("Error patching status with the spec validation errors").
+			SuccessLogMsg("Forgetting object with invalid spec").
+			Run(ctx, state)
+	}
+}
+
+func recordValidatedSpecHash(ctx context.Context, state composed.State, hash string) (error, context.Context) {
+	original := state.Obj().DeepCopyObject().(client.Object)
+	annotations := state.Obj().GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationValidatedSpecHash] = hash
+	state.Obj().SetAnnotations(annotations)
+	if err := state.Cluster().K8sClient().Patch(ctx, state.Obj(), client.MergeFrom(original)); err != nil {
+		return composed.LogErrorAndReturn(err, "Error patching validated spec hash annotation", composed.StopWithRequeue, ctx)
+	}
+	return nil, nil
+}
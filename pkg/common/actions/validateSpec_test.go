@@ -0,0 +1,84 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestValidateSpec(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	validate := func(obj *cloudcontrolv1beta1.RedisInstance) field.ErrorList {
+		if obj.Spec.Instance.Gcp == nil || obj.Spec.Instance.Gcp.MemorySizeGb <= 300 {
+			return nil
+		}
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "instance", "gcp", "memorySizeGb"), obj.Spec.Instance.Gcp.MemorySizeGb, "must be at most 300")}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+
+		err, _ := ValidateSpec(validate)(ctx, newUpdateStrategyState(obj))
+
+		assert.Nil(t, err)
+		assert.Empty(t, obj.Status.Conditions)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		obj.Spec.Instance.Gcp = &cloudcontrolv1beta1.RedisInstanceGcp{Tier: "BASIC", MemorySizeGb: 500}
+
+		err, _ := ValidateSpec(validate)(ctx, newUpdateStrategyState(obj))
+
+		assert.Equal(t, composed.StopAndForget, err)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonValidationFailed, cond.Reason)
+		assert.Contains(t, cond.Message, "spec.instance.gcp.memorySizeGb")
+	})
+
+	t.Run("validated spec is not checked again", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		obj.Spec.Instance.Gcp = &cloudcontrolv1beta1.RedisInstanceGcp{Tier: "BASIC", MemorySizeGb: 200}
+		state := newUpdateStrategyState(obj)
+
+		err, _ := ValidateSpec(validate)(ctx, state)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, obj.Annotations[AnnotationValidatedSpecHash])
+
+		// the limit gets stricter
+		stricter := func(obj *cloudcontrolv1beta1.RedisInstance) field.ErrorList {
+			return field.ErrorList{field.Invalid(field.NewPath("spec"), "", "always invalid")}
+		}
+		err, _ = ValidateSpec(stricter)(ctx, state)
+		assert.Nil(t, err, "unchanged spec is not rejected")
+
+		obj.Spec.Instance.Gcp.MemorySizeGb = 500
+		err, _ = ValidateSpec(validate)(ctx, state)
+		assert.Equal(t, composed.StopAndForget, err, "changed spec is checked")
+	})
+
+	t.Run("existing ready object is accepted", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.RedisInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"}}
+		obj.Spec.Instance.Gcp = &cloudcontrolv1beta1.RedisInstanceGcp{Tier: "BASIC", MemorySizeGb: 500}
+		meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:   cloudcontrolv1beta1.ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+			Reason: cloudcontrolv1beta1.ReasonReady,
+		})
+
+		err, _ := ValidateSpec(validate)(ctx, newUpdateStrategyState(obj))
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, obj.Annotations[AnnotationValidatedSpecHash])
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+	})
+}
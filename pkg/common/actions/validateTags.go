@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"context"
+	"errors"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidateTags returns an Action that checks the tags returned by the getter against the provider
+// limits before they are used in any cloud API create call. Valid tags, with the too long values of
+// non-critical tags truncated if the TagValueTruncation feature is enabled, are handed to the setter.
+// Invalid tags are rejected with the InvalidTag reason naming the offending tag, and the object is forgotten.
+func ValidateTags(
+	provider cloudcontrolv1beta1.ProviderType,
+	getter func(state composed.State) map[string]string,
+	setter func(state composed.State, tags map[string]string),
+) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+
+		tags, err := common.NormalizeTags(provider, getter(state), feature.TagValueTruncation.Value(ctx))
+		if err == nil {
+			setter(state, tags)
+			return nil, nil
+		}
+
+		var invalidTagErr *common.InvalidTagError
+		if !errors.As(err, &invalidTagErr) {
+			return composed.LogErrorAndReturn(err, "Error validating tags", composed.StopAndForget, ctx)
+		}
+
+		logger := composed.LoggerFromCtx(ctx)
+		logger.
+			WithValues("tag", invalidTagErr.Key).
+			Info(invalidTagErr.Error())
+
+		obj, ok := state.Obj().(composed.ObjWithConditions)
+		if !ok {
+			return composed.StopAndForget, nil
+		}
+		if objWithState, ok := obj.(composed.ObjWithConditionsAndState); ok {
+			objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+		}
+
+		return composed.PatchStatus(obj).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInvalidTag,
+				Message: invalidTagErr.Error(),
+			}).
+			ErrorLogMessage("Error patching status with invalid tag").
+			SuccessLogMsg("Forgetting object with invalid tag").
+			Run(ctx, state)
+	}
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/ha"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneResolver returns the zones the object cloud resources are spread over, and whether the object
+// requires a highly available setup. The zones are empty if they are not resolved yet.
+type ZoneResolver func(ctx context.Context, state composed.State) (zones []string, highlyAvailable bool)
+
+// ValidateZoneCount returns an Action that checks the highly available object is spread over at least
+// the minimum number of zones configured for its kind. If not, the object is put in the error state with
+// the InsufficientZones condition, and is checked again later since the zones can be added to the cluster.
+// The condition is removed once the requirement is met.
+func ValidateZoneCount(kind string, resolver ZoneResolver) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(composed.ObjWithConditions)
+		if !ok {
+			return nil, nil
+		}
+
+		minZones := ha.HaConfig.MinZonesFor(kind)
+		zones, highlyAvailable := resolver(ctx, state)
+		if !highlyAvailable || len(zones) == 0 || len(zones) >= minZones {
+			return removeInsufficientZonesCondition(ctx, state, obj)
+		}
+
+		message := fmt.Sprintf(
+			"Highly available %s requires at least %d zones, but only %d are available: %v. "+
+				"Add more zones to the cluster or use the configuration without high availability.",
+			kind, minZones, len(zones), zones,
+		)
+		composed.LoggerFromCtx(ctx).Info(message)
+
+		if objWithState, ok := obj.(composed.ObjWithConditionsAndState); ok {
+			objWithState.SetState(string(cloudcontrolv1beta1.ErrorState))
+		}
+
+		return composed.UpdateStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeInsufficientZones,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInsufficientZones,
+				Message: message,
+			}).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInsufficientZones,
+				Message: message,
+			}).
+			ErrorLogMessage("Error updating status with InsufficientZones condition").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+}
+
+func removeInsufficientZonesCondition(ctx context.Context, state composed.State, obj composed.ObjWithConditions) (error, context.Context) {
+	if meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeInsufficientZones) == nil {
+		return nil, nil
+	}
+
+	conditionTypes := []string{cloudcontrolv1beta1.ConditionTypeInsufficientZones}
+	errorCondition := meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeError)
+	if errorCondition != nil && errorCondition.Reason == cloudcontrolv1beta1.ReasonInsufficientZones {
+		conditionTypes = append(conditionTypes, cloudcontrolv1beta1.ConditionTypeError)
+	}
+
+	return composed.UpdateStatus(obj).
+		RemoveConditions(conditionTypes...).
+		ErrorLogMessage("Error updating status removing InsufficientZones condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func runValidateZoneCount(t *testing.T, nfs *cloudcontrolv1beta1.NfsInstance, zones []string, highlyAvailable bool) (error, *cloudcontrolv1beta1.NfsInstance) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfs).
+		WithStatusSubresource(&cloudcontrolv1beta1.NfsInstance{}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(nfs), &cloudcontrolv1beta1.NfsInstance{})
+
+	err, _ := composed.ComposeActions(
+		"test",
+		composed.LoadObj,
+		ValidateZoneCount("NfsInstance", func(ctx context.Context, state composed.State) ([]string, bool) {
+			return zones, highlyAvailable
+		}),
+	)(ctx, state)
+
+	loaded := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(t, clnt.Get(ctx, types.NamespacedName{Namespace: nfs.Namespace, Name: nfs.Name}, loaded))
+	return err, loaded
+}
+
+func newZoneTestNfsInstance() *cloudcontrolv1beta1.NfsInstance {
+	return &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+	}
+}
+
+func TestValidateZoneCount(t *testing.T) {
+
+	t.Run("single zone is rejected", func(t *testing.T) {
+		err, loaded := runValidateZoneCount(t, newZoneTestNfsInstance(), []string{"eu-west-1a"}, true)
+
+		assert.Error(t, err)
+		cond := meta.FindStatusCondition(loaded.Status.Conditions, cloudcontrolv1beta1.ConditionTypeInsufficientZones)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonInsufficientZones, cond.Reason)
+		assert.Contains(t, cond.Message, "at least 2 zones")
+		assert.NotNil(t, meta.FindStatusCondition(loaded.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+	})
+
+	t.Run("multiple zones pass", func(t *testing.T) {
+		err, loaded := runValidateZoneCount(t, newZoneTestNfsInstance(), []string{"eu-west-1a", "eu-west-1b"}, true)
+
+		assert.NoError(t, err)
+		assert.Empty(t, loaded.Status.Conditions)
+	})
+
+	t.Run("single zone passes when not highly available", func(t *testing.T) {
+		err, loaded := runValidateZoneCount(t, newZoneTestNfsInstance(), []string{"eu-west-1a"}, false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, loaded.Status.Conditions)
+	})
+
+	t.Run("condition is removed once zones are added", func(t *testing.T) {
+		nfs := newZoneTestNfsInstance()
+		nfs.Status.Conditions = []metav1.Condition{
+			{Type: cloudcontrolv1beta1.ConditionTypeInsufficientZones, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonInsufficientZones, Message: "x"},
+			{Type: cloudcontrolv1beta1.ConditionTypeError, Status: metav1.ConditionTrue, Reason: cloudcontrolv1beta1.ReasonInsufficientZones, Message: "x"},
+		}
+		err, loaded := runValidateZoneCount(t, nfs, []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"}, true)
+
+		assert.NoError(t, err)
+		assert.Empty(t, loaded.Status.Conditions)
+	})
+}
@@ -9,6 +9,9 @@ const (
 
 const FieldOwner = "cloud-manager"
 
+// ObjectFieldOwner is the field manager of the object metadata and spec fields cloud-manager server-side applies
+const ObjectFieldOwner = "cloud-manager-object"
+
 const (
 	DefaultCloudManagerCidr = "10.250.4.0/22"
 )
@@ -0,0 +1,70 @@
+package pricing
+
+import (
+	_ "embed"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed pricing.yaml
+var pricingYaml []byte
+
+type table struct {
+	Currency      string  `yaml:"currency"`
+	HoursPerMonth float64 `yaml:"hoursPerMonth"`
+	Aws           struct {
+		Efs struct {
+			Default float64            `yaml:"default"`
+			Regions map[string]float64 `yaml:"regions"`
+		} `yaml:"efs"`
+		ElastiCache struct {
+			NodeTypes map[string]float64 `yaml:"nodeTypes"`
+		} `yaml:"elastiCache"`
+	} `yaml:"aws"`
+}
+
+var (
+	loadOnce sync.Once
+	prices   *table
+)
+
+func getTable() *table {
+	loadOnce.Do(func() {
+		t := &table{}
+		if err := yaml.Unmarshal(pricingYaml, t); err != nil {
+			panic(fmt.Errorf("invalid embedded pricing table: %w", err))
+		}
+		prices = t
+	})
+	return prices
+}
+
+// AwsEfsMonthly returns the approximate monthly cost of the EFS file system with the given
+// metered size in bytes, using the region price or the default one if the region is not listed
+func AwsEfsMonthly(region string, sizeInBytes int64) float64 {
+	t := getTable()
+	price, ok := t.Aws.Efs.Regions[region]
+	if !ok {
+		price = t.Aws.Efs.Default
+	}
+	gb := float64(sizeInBytes) / (1024 * 1024 * 1024)
+	return gb * price
+}
+
+// AwsElastiCacheMonthly returns the approximate monthly cost of the nodeCount ElastiCache nodes
+// of the given node type. The second return value is false if the node type is not priced.
+func AwsElastiCacheMonthly(nodeType string, nodeCount int) (float64, bool) {
+	t := getTable()
+	price, ok := t.Aws.ElastiCache.NodeTypes[nodeType]
+	if !ok {
+		return 0, false
+	}
+	return price * t.HoursPerMonth * float64(nodeCount), true
+}
+
+// Format renders the cost as the status value, marked as an estimate
+func Format(cost float64) string {
+	return fmt.Sprintf("~%.2f %s (estimate)", cost, getTable().Currency)
+}
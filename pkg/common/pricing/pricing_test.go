@@ -0,0 +1,28 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsEfsMonthly(t *testing.T) {
+	gib := int64(1024 * 1024 * 1024)
+
+	assert.InDelta(t, 36.0, AwsEfsMonthly("eu-central-1", 100*gib), 0.001)
+	assert.InDelta(t, 30.0, AwsEfsMonthly("unknown-region-1", 100*gib), 0.001, "default price is used for unlisted region")
+	assert.InDelta(t, 0.0, AwsEfsMonthly("eu-central-1", 0), 0.001)
+}
+
+func TestAwsElastiCacheMonthly(t *testing.T) {
+	cost, ok := AwsElastiCacheMonthly("cache.t4g.small", 2)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.032*730*2, cost, 0.001)
+
+	_, ok = AwsElastiCacheMonthly("cache.unknown.large", 1)
+	assert.False(t, ok)
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "~46.72 USD (estimate)", Format(46.72))
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+var propagateLabelPrefix string
+
+// SetPropagateLabelPrefix sets the prefix of the Scope labels propagated as tags to the cloud resources.
+// Empty prefix disables the propagation.
+func SetPropagateLabelPrefix(prefix string) {
+	propagateLabelPrefix = prefix
+}
+
+// IsPropagatedLabel returns true if the label key matches the prefix of the propagated Scope labels
+func IsPropagatedLabel(key string) bool {
+	return len(propagateLabelPrefix) > 0 && strings.HasPrefix(key, propagateLabelPrefix) && len(key) > len(propagateLabelPrefix)
+}
+
+// PropagatedTags returns the tags of the cloud resources created for the Scope, the Scope default tags
+// overridden by the tags converted from the Scope labels matching the propagate label prefix. The label tag
+// key is the label key without the prefix. Keys and values are sanitized to the provider limits by replacing
+// the not allowed characters with the underscore, lower-casing if needed and truncating to the max length.
+// The tags that can not be sanitized into a valid tag, and the ones colliding with the critical cloud-manager
+// tags, are skipped.
+func PropagatedTags(provider cloudcontrolv1beta1.ProviderType, scope *cloudcontrolv1beta1.Scope) map[string]string {
+	if scope == nil {
+		return nil
+	}
+	result := map[string]string{}
+	add := func(key, value string) {
+		key, value, ok := sanitizeTag(provider, key, value)
+		if !ok || IsCriticalTag(key) {
+			return
+		}
+		result[key] = value
+	}
+	for key, value := range scope.Spec.DefaultTags {
+		add(key, value)
+	}
+	for labelKey, labelValue := range scope.Labels {
+		if IsPropagatedLabel(labelKey) {
+			add(strings.TrimPrefix(labelKey, propagateLabelPrefix), labelValue)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// TagsWithPropagated returns the tags of the cloud resources created for the object of the Scope in the order of
+// precedence: the given reserved cloud-manager tags of the object, the spec tags of the object, and the tags
+// propagated from the Scope labels and default tags. The spec tags with the critical cloud-manager key are skipped.
+func TagsWithPropagated(provider cloudcontrolv1beta1.ProviderType, scope *cloudcontrolv1beta1.Scope, specTags, reservedTags map[string]string) map[string]string {
+	result := PropagatedTags(provider, scope)
+	if result == nil {
+		result = map[string]string{}
+	}
+	for k, v := range specTags {
+		if !IsCriticalTag(k) {
+			result[k] = v
+		}
+	}
+	for k, v := range reservedTags {
+		result[k] = v
+	}
+	return result
+}
+
+func sanitizeTag(provider cloudcontrolv1beta1.ProviderType, key, value string) (string, string, bool) {
+	limits, ok := TagLimitsPerProvider[provider]
+	if !ok {
+		return key, value, true
+	}
+
+	// the key characters are checked after a letter, so the first character constraint does not apply to them
+	key = sanitizeTagString(key, limits.MaxKeyLength, func(s string) bool {
+		return limits.KeyPattern.MatchString("a" + s)
+	})
+	if !limits.KeyPattern.MatchString(key) {
+		return "", "", false
+	}
+	for _, prefix := range limits.ReservedKeyPrefixes {
+		if strings.HasPrefix(strings.ToLower(key), prefix) {
+			return "", "", false
+		}
+	}
+
+	value = sanitizeTagString(value, limits.MaxValueLength, limits.ValuePattern.MatchString)
+	if !limits.ValuePattern.MatchString(value) {
+		return "", "", false
+	}
+
+	return key, value, true
+}
+
+func sanitizeTagString(s string, maxLength int, valid func(string) bool) string {
+	var b strings.Builder
+	for i, r := range []rune(s) {
+		if i >= maxLength {
+			break
+		}
+		switch {
+		case valid(string(r)):
+			b.WriteRune(r)
+		case valid(string(unicode.ToLower(r))):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
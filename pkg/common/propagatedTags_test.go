@@ -0,0 +1,75 @@
+package common
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPropagatedTags(t *testing.T) {
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"tags.example.com/team":   "networking",
+				"other.example.com/owner": "someone",
+			},
+		},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			DefaultTags: map[string]string{
+				"team":              "platform",
+				"cost-center":       "cc 1234",
+				TagCloudManagerName: "overridden",
+			},
+		},
+	}
+
+	t.Run("default tags without propagated labels", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"team":        "platform",
+			"cost-center": "cc 1234",
+		}, PropagatedTags(cloudcontrolv1beta1.ProviderAws, scope))
+	})
+
+	t.Run("label tags take precedence over default tags", func(t *testing.T) {
+		SetPropagateLabelPrefix("tags.example.com/")
+		defer SetPropagateLabelPrefix("")
+
+		assert.Equal(t, map[string]string{
+			"team":        "networking",
+			"cost-center": "cc 1234",
+		}, PropagatedTags(cloudcontrolv1beta1.ProviderAws, scope))
+	})
+
+	t.Run("default tags are sanitized", func(t *testing.T) {
+		actual := PropagatedTags(cloudcontrolv1beta1.ProviderGCP, scope)
+		assert.Equal(t, "cc_1234", actual["cost-center"])
+		assert.NotContains(t, actual, TagCloudManagerName)
+	})
+
+	t.Run("reserved tags take precedence over spec tags over propagated tags", func(t *testing.T) {
+		SetPropagateLabelPrefix("tags.example.com/")
+		defer SetPropagateLabelPrefix("")
+
+		assert.Equal(t, map[string]string{
+			"team":              "storage",
+			"cost-center":       "cc 1234",
+			"owner":             "someone",
+			TagCloudManagerName: "nfs",
+		}, TagsWithPropagated(cloudcontrolv1beta1.ProviderAws, scope,
+			map[string]string{
+				"team":                    "storage",
+				"owner":                   "someone",
+				TagCloudManagerName:       "spec",
+				TagCloudManagerRemoteName: "spec",
+			},
+			map[string]string{TagCloudManagerName: "nfs"},
+		))
+	})
+
+	t.Run("no tags", func(t *testing.T) {
+		assert.Nil(t, PropagatedTags(cloudcontrolv1beta1.ProviderAws, &cloudcontrolv1beta1.Scope{}))
+		assert.Nil(t, PropagatedTags(cloudcontrolv1beta1.ProviderAws, nil))
+	})
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// TagLimits describes the constraints the cloud provider API puts on resource tag (label) keys and values
+type TagLimits struct {
+	MaxKeyLength   int
+	MaxValueLength int
+	KeyPattern     *regexp.Regexp
+	ValuePattern   *regexp.Regexp
+	// ReservedKeyPrefixes are key prefixes reserved by the provider that the user can not set
+	ReservedKeyPrefixes []string
+}
+
+// TagLimitsPerProvider https://docs.aws.amazon.com/tag-editor/latest/userguide/tagging.html
+// https://cloud.google.com/compute/docs/labeling-resources#requirements
+// https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources#limitations
+var TagLimitsPerProvider = map[cloudcontrolv1beta1.ProviderType]TagLimits{
+	cloudcontrolv1beta1.ProviderAws: {
+		MaxKeyLength:        128,
+		MaxValueLength:      256,
+		KeyPattern:          regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]+$`),
+		ValuePattern:        regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`),
+		ReservedKeyPrefixes: []string{"aws:"},
+	},
+	cloudcontrolv1beta1.ProviderGCP: {
+		MaxKeyLength:   63,
+		MaxValueLength: 63,
+		KeyPattern:     regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]*$`),
+		ValuePattern:   regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]*$`),
+	},
+	cloudcontrolv1beta1.ProviderAzure: {
+		MaxKeyLength:        512,
+		MaxValueLength:      256,
+		KeyPattern:          regexp.MustCompile(`^[^<>%&\\?/]+$`),
+		ValuePattern:        regexp.MustCompile(`^.*$`),
+		ReservedKeyPrefixes: []string{"microsoft", "azure", "windows"},
+	},
+}
+
+// IsCriticalTag returns true for tags cloud-manager relies on when looking up its cloud resources. Critical tags
+// are never truncated since that would break the lookup.
+func IsCriticalTag(key string) bool {
+	return strings.HasPrefix(key, "cloud-manager.kyma-project.io/")
+}
+
+type InvalidTagError struct {
+	Key    string
+	Reason string
+}
+
+func (e *InvalidTagError) Error() string {
+	return fmt.Sprintf("invalid tag %q: %s", e.Key, e.Reason)
+}
+
+// NormalizeTags validates the tags against the provider limits and returns them unchanged, or with the too long values
+// of the non-critical tags truncated if truncate is true. Returned error is of the *InvalidTagError type and names
+// the first offending tag in the keys order. For the provider without known limits tags are returned as they are.
+func NormalizeTags(provider cloudcontrolv1beta1.ProviderType, tags map[string]string, truncate bool) (map[string]string, error) {
+	limits, ok := TagLimitsPerProvider[provider]
+	if !ok {
+		return tags, nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(tags))
+	for _, key := range keys {
+		value := tags[key]
+		if l := utf8.RuneCountInString(key); l == 0 || l > limits.MaxKeyLength {
+			return nil, &InvalidTagError{Key: key, Reason: fmt.Sprintf("key length %d is not between 1 and %d", l, limits.MaxKeyLength)}
+		}
+		if !limits.KeyPattern.MatchString(key) {
+			return nil, &InvalidTagError{Key: key, Reason: "key contains not allowed characters"}
+		}
+		for _, prefix := range limits.ReservedKeyPrefixes {
+			if strings.HasPrefix(strings.ToLower(key), prefix) {
+				return nil, &InvalidTagError{Key: key, Reason: fmt.Sprintf("key prefix %q is reserved", prefix)}
+			}
+		}
+		if l := utf8.RuneCountInString(value); l > limits.MaxValueLength {
+			if !truncate || IsCriticalTag(key) {
+				return nil, &InvalidTagError{Key: key, Reason: fmt.Sprintf("value length %d exceeds %d", l, limits.MaxValueLength)}
+			}
+			value = string([]rune(value)[:limits.MaxValueLength])
+		}
+		if !limits.ValuePattern.MatchString(value) {
+			return nil, &InvalidTagError{Key: key, Reason: "value contains not allowed characters"}
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	t.Run("valid aws tags are returned unchanged", func(t *testing.T) {
+		tags := map[string]string{
+			TagCloudManagerName: "kcp-system/some-name",
+			"Name":              "some-name-0",
+		}
+		actual, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, tags, false)
+		assert.NoError(t, err)
+		assert.Equal(t, tags, actual)
+	})
+
+	t.Run("too long aws value is rejected", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, map[string]string{"owner": strings.Repeat("a", 257)}, false)
+		var invalidTagErr *InvalidTagError
+		assert.ErrorAs(t, err, &invalidTagErr)
+		assert.Equal(t, "owner", invalidTagErr.Key)
+	})
+
+	t.Run("too long non-critical value is truncated", func(t *testing.T) {
+		actual, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, map[string]string{"owner": strings.Repeat("a", 257)}, true)
+		assert.NoError(t, err)
+		assert.Len(t, actual["owner"], 256)
+	})
+
+	t.Run("too long critical value is rejected even with truncation", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, map[string]string{TagCloudManagerName: strings.Repeat("a", 257)}, true)
+		var invalidTagErr *InvalidTagError
+		assert.ErrorAs(t, err, &invalidTagErr)
+		assert.Equal(t, TagCloudManagerName, invalidTagErr.Key)
+	})
+
+	t.Run("too long aws key is rejected", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, map[string]string{strings.Repeat("k", 129): "v"}, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("reserved aws key prefix is rejected", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderAws, map[string]string{"aws:owner": "v"}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("gcp label with upper case and slash is rejected", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderGCP, map[string]string{"Owner": "v"}, false)
+		assert.Error(t, err)
+		_, err = NormalizeTags(cloudcontrolv1beta1.ProviderGCP, map[string]string{"owner": "a/b"}, false)
+		assert.Error(t, err)
+		_, err = NormalizeTags(cloudcontrolv1beta1.ProviderGCP, map[string]string{"kyma-project__cloud-manager__kcp-name": "some-name"}, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("azure key with not allowed character is rejected", func(t *testing.T) {
+		_, err := NormalizeTags(cloudcontrolv1beta1.ProviderAzure, map[string]string{"a/b": "v"}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("provider without limits returns tags as they are", func(t *testing.T) {
+		tags := map[string]string{"a/b": strings.Repeat("a", 1000)}
+		actual, err := NormalizeTags(cloudcontrolv1beta1.ProviderOpenStack, tags, false)
+		assert.NoError(t, err)
+		assert.Equal(t, tags, actual)
+	})
+}
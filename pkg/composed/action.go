@@ -20,6 +20,8 @@ type Action func(ctx context.Context, state State) (error, context.Context)
 
 func ComposeActions(name string, actions ...Action) Action {
 	return func(ctx context.Context, state State) (error, context.Context) {
+		ctx, done := trackInFlight(ctx)
+		defer done()
 		logger := log.FromContext(ctx).WithValues("action", name)
 		var actionName string
 		var lastError error
@@ -32,9 +34,19 @@ func ComposeActions(name string, actions ...Action) Action {
 				lastError = currentCtx.Err()
 				break loop
 			default:
+				if Draining() && atCheckpoint(currentCtx) {
+					// the previous action persisted the status of its cloud operation, stop before the next one
+					// without requeue, the reconciliation is resumed by the new instance on its initial sync
+					logger.
+						WithValues("targetAction", actionName).
+						Info("Draining on shutdown, stopping at checkpoint before action")
+					lastError = StopAndForget
+					break loop
+				}
 				//logger.
 				//	WithValues("targetAction", actionName).
 				//	Info("Running action")
+				resetCheckpoint(currentCtx)
 				err, nextCtx := a(currentCtx, state)
 				lastError = err
 				if nextCtx != nil {
@@ -0,0 +1,98 @@
+package composed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// AnnotationApiCallLogging set to "true" enables the logging of the cloud API calls made while the object is reconciled
+	AnnotationApiCallLogging = "cloud-manager.kyma-project.io/api-call-logging"
+
+	// ApiCallLogVerbosity is the verbosity the cloud API calls are logged with
+	ApiCallLogVerbosity = 1
+)
+
+const apiCallRedacted = "REDACTED"
+
+// sensitiveApiCallFields are the lowercase parts of the API call parameter and response field names whose values are never logged
+var sensitiveApiCallFields = []string{
+	"password",
+	"secret",
+	"token",
+	"credential",
+	"authorization",
+	"accesskey",
+	"privatekey",
+}
+
+type apiCallLoggingKey struct{}
+
+// WithApiCallLogging returns the context the cloud API calls are logged with
+func WithApiCallLogging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiCallLoggingKey{}, true)
+}
+
+// ApiCallLoggingEnabled returns true if the cloud API calls made with the context are logged
+func ApiCallLoggingEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(apiCallLoggingKey{}).(bool)
+	return enabled
+}
+
+// ApiCallLogging enables the logging of the cloud API calls made by the rest of the flow, if the state
+// object is annotated with AnnotationApiCallLogging. The provider clients log the calls with the
+// ApiCallLogVerbosity, so the logs of one object can be debugged without raising the verbosity of all.
+func ApiCallLogging(ctx context.Context, state State) (error, context.Context) {
+	if state.Obj() == nil || state.Obj().GetAnnotations()[AnnotationApiCallLogging] != "true" {
+		return nil, nil
+	}
+	return nil, WithApiCallLogging(ctx)
+}
+
+// SanitizedApiCallPayload returns the JSON of the cloud API call parameters or response, with the values
+// of the fields that might hold credentials, tokens, passwords and other secrets redacted
+func SanitizedApiCallPayload(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("<%T can not be logged>", v)
+	}
+	var payload any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Sprintf("<%T can not be logged>", v)
+	}
+	data, err = json.Marshal(sanitizeApiCallPayload(payload))
+	if err != nil {
+		return fmt.Sprintf("<%T can not be logged>", v)
+	}
+	return string(data)
+}
+
+func sanitizeApiCallPayload(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, val := range x {
+			if val != nil && isSensitiveApiCallField(k) {
+				x[k] = apiCallRedacted
+				continue
+			}
+			x[k] = sanitizeApiCallPayload(val)
+		}
+	case []any:
+		for i, val := range x {
+			x[i] = sanitizeApiCallPayload(val)
+		}
+	}
+	return v
+}
+
+func isSensitiveApiCallField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveApiCallFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package composed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ApplyObjAddFinalizer server-side applies the finalizer with the ObjectFieldOwner field manager, so
+// only that finalizer is owned by cloud-manager, and the fields of other managers are left intact.
+// The obj is updated with the applied object. It returns false if the obj already had the finalizer.
+func ApplyObjAddFinalizer(ctx context.Context, f string, obj client.Object, clnt client.Client) (bool, error) {
+	if controllerutil.ContainsFinalizer(obj, f) {
+		return false, nil
+	}
+	finalizers, labels := ownedObjFields(obj)
+	return true, applyObj(ctx, obj, clnt, append(finalizers, f), labels)
+}
+
+// ApplyObjRemoveFinalizer server-side applies the object without the finalizer, so the finalizer
+// owned by the ObjectFieldOwner field manager is removed. If the obj still has the finalizer after
+// the apply, since it was added by the legacy update not owned by the field manager, it is removed
+// with a patch. It returns false if the obj did not have the finalizer.
+func ApplyObjRemoveFinalizer(ctx context.Context, f string, obj client.Object, clnt client.Client) (bool, error) {
+	if !controllerutil.ContainsFinalizer(obj, f) {
+		return false, nil
+	}
+	finalizers, labels := ownedObjFields(obj)
+	finalizers = pie.Filter(finalizers, func(x string) bool { return x != f })
+	if err := applyObj(ctx, obj, clnt, finalizers, labels); err != nil {
+		return true, err
+	}
+	if controllerutil.ContainsFinalizer(obj, f) {
+		return PatchObjRemoveFinalizer(ctx, f, obj, clnt)
+	}
+	return true, nil
+}
+
+// ApplyObjLabels server-side applies the labels with the ObjectFieldOwner field manager, so only
+// those labels are owned by cloud-manager, and the labels of other managers are left intact.
+// The obj is updated with the applied object. It returns false if the obj already had the labels.
+func ApplyObjLabels(ctx context.Context, labels map[string]string, obj client.Object, clnt client.Client) (bool, error) {
+	changed := false
+	for k, v := range labels {
+		if current, ok := obj.GetLabels()[k]; !ok || current != v {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	finalizers, owned := ownedObjFields(obj)
+	for k, v := range labels {
+		owned[k] = v
+	}
+	return true, applyObj(ctx, obj, clnt, finalizers, owned)
+}
+
+// applyObj server-side applies the object with only the given finalizers and labels, forcing
+// the ownership of them in case of conflict. Since the fields owned by the field manager but not
+// in the applied configuration are removed, the configuration must hold all the fields cloud-manager
+// owns. The fields not in the applied configuration and owned by other managers are not changed.
+func applyObj(ctx context.Context, obj client.Object, clnt client.Client, finalizers []string, labels map[string]string) error {
+	u, err := newApplyConfiguration(obj, clnt.Scheme())
+	if err != nil {
+		return err
+	}
+	if finalizers == nil {
+		finalizers = []string{}
+	}
+	u.SetFinalizers(finalizers)
+	if len(labels) > 0 {
+		u.SetLabels(labels)
+	}
+	if err := clnt.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(common.ObjectFieldOwner)); err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), obj); err != nil {
+		return fmt.Errorf("error converting applied object: %w", err)
+	}
+	return nil
+}
+
+// newApplyConfiguration returns the unstructured apply configuration of the obj with only its
+// identity set, meant for the caller to add the fields cloud-manager owns
+func newApplyConfiguration(obj client.Object, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GVK of object: %w", err)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(obj.GetNamespace())
+	u.SetName(obj.GetName())
+	return u, nil
+}
+
+// ownedObjFields returns the finalizers and the labels with their current values the ObjectFieldOwner
+// field manager applied on the obj, as recorded in its managed fields
+func ownedObjFields(obj client.Object) ([]string, map[string]string) {
+	var finalizers []string
+	labels := map[string]string{}
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager != common.ObjectFieldOwner || mf.Operation != metav1.ManagedFieldsOperationApply || mf.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		metadata, _ := fields["f:metadata"].(map[string]interface{})
+		ownedFinalizers, _ := metadata["f:finalizers"].(map[string]interface{})
+		ownedLabels, _ := metadata["f:labels"].(map[string]interface{})
+		for key := range ownedFinalizers {
+			var f string
+			if !strings.HasPrefix(key, "v:") || json.Unmarshal([]byte(strings.TrimPrefix(key, "v:")), &f) != nil {
+				continue
+			}
+			if controllerutil.ContainsFinalizer(obj, f) {
+				finalizers = append(finalizers, f)
+			}
+		}
+		for key := range ownedLabels {
+			k := strings.TrimPrefix(key, "f:")
+			if v, ok := obj.GetLabels()[k]; ok && key != k {
+				labels[k] = v
+			}
+		}
+	}
+	return finalizers, labels
+}
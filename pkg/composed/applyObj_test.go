@@ -0,0 +1,75 @@
+package composed
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+func TestNewApplyConfiguration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "kcp-system",
+			Name:            "ip-range",
+			Finalizers:      []string{"example.com/other"},
+			Labels:          map[string]string{"foo": "bar"},
+			ResourceVersion: "123",
+		},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			Cidr: "10.0.0.0/24",
+		},
+	}
+
+	u, err := newApplyConfiguration(obj, scheme)
+	assert.NoError(t, err)
+
+	assert.Equal(t, cloudcontrolv1beta1.GroupVersion.String(), u.GetAPIVersion())
+	assert.Equal(t, "IpRange", u.GetKind())
+	assert.Equal(t, "kcp-system", u.GetNamespace())
+	assert.Equal(t, "ip-range", u.GetName())
+	metadata, ok := u.Object["metadata"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Len(t, metadata, 2, "only the identity is set, so no other field is owned")
+	_, hasSpec := u.Object["spec"]
+	assert.False(t, hasSpec)
+}
+
+func TestOwnedObjFields(t *testing.T) {
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "kcp-system",
+			Name:       "ip-range",
+			Finalizers: []string{"cloud-control.kyma-project.io/deletion-hook", "example.com/other"},
+			Labels:     map[string]string{"foo": "bar", "other": "x"},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   common.ObjectFieldOwner,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:metadata":{"f:finalizers":{"v:\"cloud-control.kyma-project.io/deletion-hook\"":{},"v:\"example.com/removed\"":{}},"f:labels":{"f:foo":{},"f:removed":{}}}}`),
+					},
+				},
+				{
+					Manager:   "other",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:metadata":{"f:finalizers":{"v:\"example.com/other\"":{}},"f:labels":{"f:other":{}}}}`),
+					},
+				},
+			},
+		},
+	}
+
+	finalizers, labels := ownedObjFields(obj)
+
+	assert.Equal(t, []string{"cloud-control.kyma-project.io/deletion-hook"}, finalizers, "only the present finalizers of the field manager are owned")
+	assert.Equal(t, map[string]string{"foo": "bar"}, labels, "only the present labels of the field manager are owned")
+}
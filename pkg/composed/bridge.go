@@ -6,7 +6,18 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func Handle(err error, _ context.Context) (ctrl.Result, error) {
+func Handle(err error, ctx context.Context) (ctrl.Result, error) {
+	result, err := handle(err)
+	// the optional actions that failed, and the actions deferred by the maintenance freeze, are retried
+	// even if the flow stopped and forgot the object
+	if retryDelay, ok := optionalRetryDelay(ctx); ok && err == nil && !result.Requeue &&
+		(result.RequeueAfter == 0 || retryDelay < result.RequeueAfter) {
+		result.RequeueAfter = retryDelay
+	}
+	return result, err
+}
+
+func handle(err error) (ctrl.Result, error) {
 	if errors.Is(err, context.DeadlineExceeded) {
 		return ctrl.Result{}, nil
 	}
@@ -0,0 +1,52 @@
+package composed
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+const conditionTypeReady = "Ready"
+
+// CleanupStatusOnDelete returns a delete path action that should run after the cloud resources are
+// cleaned up and before the finalizer is removed. It removes the Ready condition, sets the state
+// to deletingState if the object implements ObjWithConditionsAndState and deletingState is not empty,
+// and calls clearFields, if given, to reset the transient status fields of the object.
+// The status is updated only if any of these changed anything, so the update is done once.
+// Failed status update is only logged so it never blocks the finalizer removal.
+func CleanupStatusOnDelete(deletingState string, clearFields func(obj ObjWithConditions)) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if !IsMarkedForDeletion(state.Obj()) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return nil, nil
+		}
+
+		original := obj.DeepCopyObject()
+
+		if objWithState, ok := obj.(ObjWithConditionsAndState); ok && len(deletingState) > 0 {
+			objWithState.SetState(deletingState)
+		}
+		if clearFields != nil {
+			clearFields(obj)
+		}
+		readyRemoved := meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(conditionTypeReady)) != nil
+
+		if !readyRemoved && reflect.DeepEqual(original, obj) {
+			return nil, nil
+		}
+
+		return UpdateStatus(obj).
+			RemoveConditions(conditionTypeReady).
+			SuccessLogMsg("Status cleaned up on delete").
+			OnUpdateError(func(ctx context.Context, err error) (error, context.Context) {
+				LoggerFromCtx(ctx).Error(err, "Error updating status on delete cleanup, continuing")
+				return nil, nil
+			}).
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
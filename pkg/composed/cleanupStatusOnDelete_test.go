@@ -0,0 +1,75 @@
+package composed
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+type cleanupStatusOnDeleteSuite struct {
+	testStateSuite
+}
+
+func (suite *cleanupStatusOnDeleteSuite) newState(deleting bool) State {
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "redis",
+			Finalizers: []string{cloudcontrolv1beta1.FinalizerName},
+		},
+		Status: cloudcontrolv1beta1.RedisInstanceStatus{
+			PrimaryEndpoint: "primary:6379",
+			Conditions: []metav1.Condition{
+				{
+					Type:               cloudcontrolv1beta1.ConditionTypeReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             cloudcontrolv1beta1.ReasonReady,
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+	if deleting {
+		obj.DeletionTimestamp = ptr.To(metav1.Now())
+	}
+	return newTestState(obj)
+}
+
+func clearPrimaryEndpoint(obj ObjWithConditions) {
+	obj.(*cloudcontrolv1beta1.RedisInstance).Status.PrimaryEndpoint = ""
+}
+
+func (suite *cleanupStatusOnDeleteSuite) TestStatusIsCleanedUpOnce() {
+	state := suite.newState(true)
+	obj := state.Obj().(*cloudcontrolv1beta1.RedisInstance)
+
+	err, _ := CleanupStatusOnDelete("", clearPrimaryEndpoint)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Nil(suite.T(), state.LoadObj(suite.ctx))
+	obj = state.Obj().(*cloudcontrolv1beta1.RedisInstance)
+	assert.Empty(suite.T(), obj.Status.PrimaryEndpoint)
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	resourceVersion := obj.ResourceVersion
+
+	err, _ = CleanupStatusOnDelete("", clearPrimaryEndpoint)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), resourceVersion, state.Obj().GetResourceVersion())
+}
+
+func (suite *cleanupStatusOnDeleteSuite) TestNotDeletedIsIgnored() {
+	state := suite.newState(false)
+
+	err, _ := CleanupStatusOnDelete("", clearPrimaryEndpoint)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "primary:6379", state.Obj().(*cloudcontrolv1beta1.RedisInstance).Status.PrimaryEndpoint)
+}
+
+func TestCleanupStatusOnDelete(t *testing.T) {
+	suite.Run(t, new(cleanupStatusOnDeleteSuite))
+}
@@ -0,0 +1,36 @@
+package composed
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultConditionHistoryLimit is the number of condition transitions kept when
+// UpdateStatusBuilder.ConditionHistoryLimit is not specified
+const DefaultConditionHistoryLimit = 10
+
+// ObjWithConditionHistory is implemented by objects that keep in their status a bounded history of
+// the condition status transitions, so the flapping resources can be diagnosed
+type ObjWithConditionHistory interface {
+	// AppendConditionHistory appends the transition and drops the oldest ones above the limit
+	AppendConditionHistory(conditionType string, status metav1.ConditionStatus, reason string, t metav1.Time, limit int)
+}
+
+// recordConditionTransitions appends to the history of the objects implementing ObjWithConditionHistory
+// the conditions that were added or whose status differs from the previous conditions
+func recordConditionTransitions(obj ObjWithConditions, previous []metav1.Condition, limit int) {
+	withHistory, ok := obj.(ObjWithConditionHistory)
+	if !ok {
+		return
+	}
+	previousStatus := make(map[string]metav1.ConditionStatus, len(previous))
+	for _, c := range previous {
+		previousStatus[c.Type] = c.Status
+	}
+	now := metav1.Now()
+	for _, c := range *obj.Conditions() {
+		if status, found := previousStatus[c.Type]; found && status == c.Status {
+			continue
+		}
+		withHistory.AppendConditionHistory(c.Type, c.Status, c.Reason, now, limit)
+	}
+}
@@ -0,0 +1,115 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type conditionHistorySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *conditionHistorySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *conditionHistorySuite) newState() (State, *cloudcontrolv1beta1.RedisInstance) {
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis",
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), obj
+}
+
+func (suite *conditionHistorySuite) ready() metav1.Condition {
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+}
+
+func (suite *conditionHistorySuite) error() metav1.Condition {
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeError,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ConditionTypeError,
+		Message: "Error",
+	}
+}
+
+func (suite *conditionHistorySuite) TestTransitionsAreRecorded() {
+	state, obj := suite.newState()
+
+	_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.ready()).Run(suite.ctx, state)
+	_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.error()).Run(suite.ctx, state)
+	_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.ready()).Run(suite.ctx, state)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	assert.Len(suite.T(), saved.Status.ConditionHistory, 3)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeReady, saved.Status.ConditionHistory[0].Type)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeError, saved.Status.ConditionHistory[1].Type)
+	assert.Equal(suite.T(), metav1.ConditionTrue, saved.Status.ConditionHistory[1].Status)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeError, saved.Status.ConditionHistory[1].Reason)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeReady, saved.Status.ConditionHistory[2].Type)
+}
+
+func (suite *conditionHistorySuite) TestUnchangedStatusIsNotRecorded() {
+	state, obj := suite.newState()
+
+	_, _ = UpdateStatus(obj).SetCondition(suite.ready()).Run(suite.ctx, state)
+
+	changedMessage := suite.ready()
+	changedMessage.Message = "Still ready"
+	_, _ = UpdateStatus(obj).SetCondition(changedMessage).Run(suite.ctx, state)
+
+	assert.Len(suite.T(), obj.Status.ConditionHistory, 1)
+}
+
+func (suite *conditionHistorySuite) TestHistoryIsBounded() {
+	state, obj := suite.newState()
+
+	for i := 0; i < 3; i++ {
+		_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.ready()).ConditionHistoryLimit(2).Run(suite.ctx, state)
+		_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.error()).ConditionHistoryLimit(2).Run(suite.ctx, state)
+	}
+	assert.Len(suite.T(), obj.Status.ConditionHistory, 2)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeReady, obj.Status.ConditionHistory[0].Type)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeError, obj.Status.ConditionHistory[1].Type)
+
+	for i := 0; i < DefaultConditionHistoryLimit; i++ {
+		_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.ready()).Run(suite.ctx, state)
+		_, _ = UpdateStatus(obj).SetExclusiveConditions(suite.error()).Run(suite.ctx, state)
+	}
+	assert.Len(suite.T(), obj.Status.ConditionHistory, DefaultConditionHistoryLimit)
+}
+
+func TestConditionHistory(t *testing.T) {
+	suite.Run(t, new(conditionHistorySuite))
+}
@@ -0,0 +1,121 @@
+package composed
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionSeverity classifies the conditions for the alerting, so the warnings can be routed
+// differently than the errors
+type ConditionSeverity string
+
+const (
+	ConditionSeverityInfo    ConditionSeverity = "Info"
+	ConditionSeverityWarning ConditionSeverity = "Warning"
+	ConditionSeverityError   ConditionSeverity = "Error"
+)
+
+const conditionTypeError = "Error"
+
+// conditionSeverities maps the condition reasons to their severity, if nil the severity is not recorded
+var conditionSeverities map[string]ConditionSeverity
+
+// ParseConditionSeverity parses the case-insensitive severity name
+func ParseConditionSeverity(s string) (ConditionSeverity, error) {
+	for _, severity := range []ConditionSeverity{ConditionSeverityInfo, ConditionSeverityWarning, ConditionSeverityError} {
+		if strings.EqualFold(s, string(severity)) {
+			return severity, nil
+		}
+	}
+	return "", fmt.Errorf("invalid condition severity %q, expected one of Info, Warning, Error", s)
+}
+
+// ParseConditionSeverities parses the comma separated list of reason=severity pairs
+func ParseConditionSeverities(s string) (map[string]ConditionSeverity, error) {
+	result := map[string]ConditionSeverity{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		reason, severityName, ok := strings.Cut(pair, "=")
+		reason = strings.TrimSpace(reason)
+		if !ok || len(reason) == 0 {
+			return nil, fmt.Errorf("invalid condition severity %q, expected reason=severity", pair)
+		}
+		severity, err := ParseConditionSeverity(strings.TrimSpace(severityName))
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := result[reason]; exists {
+			return nil, fmt.Errorf("condition reason %s has the severity set more than once", reason)
+		}
+		result[reason] = severity
+	}
+	return result, nil
+}
+
+// SetConditionSeverities enables recording the severity of the conditions set by UpdateStatusBuilder
+// with the given severities of the condition reasons. A nil mapping disables it.
+// Must be called before the reconcilers are started.
+func SetConditionSeverities(m map[string]ConditionSeverity) {
+	if m == nil {
+		conditionSeverities = nil
+		return
+	}
+	result := make(map[string]ConditionSeverity, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	conditionSeverities = result
+}
+
+// ConditionSeverityOf returns the severity registered for the condition reason. The conditions with
+// unregistered reasons are of the Error severity if their type is Error and true, otherwise of the Info.
+func ConditionSeverityOf(cond metav1.Condition) ConditionSeverity {
+	if severity, ok := conditionSeverities[cond.Reason]; ok {
+		return severity
+	}
+	if cond.Type == conditionTypeError && cond.Status == metav1.ConditionTrue {
+		return ConditionSeverityError
+	}
+	return ConditionSeverityInfo
+}
+
+// ConditionWithSeverity returns the condition with its severity recorded as the message prefix, for
+// example "[Warning] Subnet is nearly full", so the alerting rules can key off it. The Info severity
+// is not prefixed, and the prefix already present is replaced. If recording the severity is not
+// enabled with SetConditionSeverities the condition is returned unchanged.
+func ConditionWithSeverity(cond metav1.Condition) metav1.Condition {
+	if conditionSeverities == nil {
+		return cond
+	}
+	_, message := ConditionSeverityFromMessage(cond.Message)
+	severity := ConditionSeverityOf(cond)
+	if severity == ConditionSeverityInfo {
+		cond.Message = message
+	} else {
+		cond.Message = fmt.Sprintf("[%s] %s", severity, message)
+	}
+	return cond
+}
+
+// ConditionSeverityFromMessage returns the severity recorded in the message prefix and the message
+// without it. The message without the prefix is of the Info severity.
+func ConditionSeverityFromMessage(message string) (ConditionSeverity, string) {
+	for _, severity := range []ConditionSeverity{ConditionSeverityWarning, ConditionSeverityError} {
+		prefix := fmt.Sprintf("[%s] ", severity)
+		if strings.HasPrefix(message, prefix) {
+			return severity, strings.TrimPrefix(message, prefix)
+		}
+	}
+	return ConditionSeverityInfo, message
+}
+
+// ConditionMessageEquals returns true if the condition message without the severity prefix equals the given message
+func ConditionMessageEquals(cond metav1.Condition, message string) bool {
+	_, condMessage := ConditionSeverityFromMessage(cond.Message)
+	return condMessage == message
+}
@@ -0,0 +1,117 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestParseConditionSeverities(t *testing.T) {
+	m, err := ParseConditionSeverities(" SubnetNearlyFull=warning, VpcNotFound=Error ,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ConditionSeverity{
+		"SubnetNearlyFull": ConditionSeverityWarning,
+		"VpcNotFound":      ConditionSeverityError,
+	}, m)
+
+	m, err = ParseConditionSeverities("")
+	assert.NoError(t, err)
+	assert.Empty(t, m)
+
+	_, err = ParseConditionSeverities("VpcNotFound")
+	assert.Error(t, err)
+
+	_, err = ParseConditionSeverities("VpcNotFound=Fatal")
+	assert.Error(t, err)
+
+	_, err = ParseConditionSeverities("VpcNotFound=Error,VpcNotFound=Warning")
+	assert.Error(t, err)
+}
+
+func TestConditionWithSeverity(t *testing.T) {
+	warning := metav1.Condition{
+		Type:    "SubnetCapacity",
+		Status:  metav1.ConditionTrue,
+		Reason:  "SubnetNearlyFull",
+		Message: "Subnet is nearly full",
+	}
+
+	assert.Equal(t, warning, ConditionWithSeverity(warning), "not recorded unless enabled")
+
+	SetConditionSeverities(map[string]ConditionSeverity{"SubnetNearlyFull": ConditionSeverityWarning})
+	defer SetConditionSeverities(nil)
+
+	c := ConditionWithSeverity(warning)
+	assert.Equal(t, "[Warning] Subnet is nearly full", c.Message)
+	assert.Equal(t, c, ConditionWithSeverity(c), "the prefix is not repeated")
+	severity, message := ConditionSeverityFromMessage(c.Message)
+	assert.Equal(t, ConditionSeverityWarning, severity)
+	assert.Equal(t, "Subnet is nearly full", message)
+	assert.True(t, ConditionMessageEquals(c, "Subnet is nearly full"))
+	assert.False(t, ConditionMessageEquals(c, "Subnet is full"))
+
+	// defaults of the unregistered reasons
+	unregisteredError := metav1.Condition{Type: cloudcontrolv1beta1.ConditionTypeError, Status: metav1.ConditionTrue, Reason: "Unlisted"}
+	assert.Equal(t, ConditionSeverityError, ConditionSeverityOf(unregisteredError))
+	unregisteredOther := metav1.Condition{Type: "Updating", Status: metav1.ConditionTrue, Reason: "Unlisted", Message: "[Error] Stale"}
+	assert.Equal(t, ConditionSeverityInfo, ConditionSeverityOf(unregisteredOther))
+	assert.Equal(t, "Stale", ConditionWithSeverity(unregisteredOther).Message, "the stale prefix is removed from the info")
+}
+
+func TestUpdateStatusRecordsConditionSeverity(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	SetConditionSeverities(map[string]ConditionSeverity{
+		cloudcontrolv1beta1.ReasonVpcNotFound: ConditionSeverityError,
+		"SubnetNearlyFull":                    ConditionSeverityWarning,
+	})
+	defer SetConditionSeverities(nil)
+
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).WithStatusSubresource(obj).Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+
+	vpcNotFound := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeError,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonVpcNotFound,
+		Message: "VPC not found",
+	}
+	nearlyFull := metav1.Condition{
+		Type:    "SubnetCapacity",
+		Status:  metav1.ConditionTrue,
+		Reason:  "SubnetNearlyFull",
+		Message: "Subnet is nearly full",
+	}
+	ready := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+
+	_, _ = UpdateStatus(obj).SetCondition(vpcNotFound).SetCondition(nearlyFull).SetCondition(ready).Run(ctx, state)
+
+	saved := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Equal(t, "[Error] VPC not found", meta.FindStatusCondition(saved.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError).Message)
+	assert.Equal(t, "[Warning] Subnet is nearly full", meta.FindStatusCondition(saved.Status.Conditions, "SubnetCapacity").Message)
+	assert.Equal(t, "Ready", meta.FindStatusCondition(saved.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady).Message)
+	assert.False(t, AnyConditionChanged(obj, vpcNotFound, nearlyFull, ready))
+}
@@ -0,0 +1,58 @@
+package composed
+
+import (
+	"fmt"
+	"strings"
+)
+
+// conditionTypeMapping maps the condition types set by the reconcilers to the public names
+// written in the object status
+var conditionTypeMapping = map[string]string{}
+
+// ParseConditionTypeMapping parses the comma separated list of internal=public condition type pairs.
+// The public names can not be mapped again, so the mapping is applied only once.
+func ParseConditionTypeMapping(s string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		internal, public, ok := strings.Cut(pair, "=")
+		internal = strings.TrimSpace(internal)
+		public = strings.TrimSpace(public)
+		if !ok || len(internal) == 0 || len(public) == 0 {
+			return nil, fmt.Errorf("invalid condition type mapping %q, expected internal=public", pair)
+		}
+		if _, exists := result[internal]; exists {
+			return nil, fmt.Errorf("condition type %s is mapped more than once", internal)
+		}
+		result[internal] = public
+	}
+	for internal, public := range result {
+		if _, chained := result[public]; chained {
+			return nil, fmt.Errorf("condition type %s is mapped to %s, which is mapped again", internal, public)
+		}
+	}
+	return result, nil
+}
+
+// SetConditionTypeMapping sets the mapping of the condition types applied by UpdateStatusBuilder, including
+// its keep lists, the readiness gates and the optional feature conditions, and by the composed actions reading
+// the conditions back. A nil or empty mapping keeps the condition types unchanged.
+// Must be called before the reconcilers are started.
+func SetConditionTypeMapping(m map[string]string) {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	conditionTypeMapping = result
+}
+
+// PublicConditionType returns the name the given condition type is written with in the object status
+func PublicConditionType(conditionType string) string {
+	if public, ok := conditionTypeMapping[conditionType]; ok {
+		return public
+	}
+	return conditionType
+}
@@ -0,0 +1,122 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestParseConditionTypeMapping(t *testing.T) {
+	m, err := ParseConditionTypeMapping(" CidrOverlap=NetworkConflict, Error=Failed ,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"CidrOverlap": "NetworkConflict", "Error": "Failed"}, m)
+
+	m, err = ParseConditionTypeMapping("")
+	assert.NoError(t, err)
+	assert.Empty(t, m)
+
+	_, err = ParseConditionTypeMapping("CidrOverlap")
+	assert.Error(t, err)
+
+	_, err = ParseConditionTypeMapping("CidrOverlap=A,CidrOverlap=B")
+	assert.Error(t, err)
+
+	_, err = ParseConditionTypeMapping("CidrOverlap=Error,Error=Failed")
+	assert.Error(t, err)
+}
+
+func TestUpdateStatusWithConditionTypeMapping(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	SetConditionTypeMapping(map[string]string{"CidrOverlap": "NetworkConflict"})
+	defer SetConditionTypeMapping(nil)
+
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+	}
+	state := newTestState(obj)
+
+	overlap := metav1.Condition{
+		Type:    "CidrOverlap",
+		Status:  metav1.ConditionTrue,
+		Reason:  "CidrOverlap",
+		Message: "Overlaps",
+	}
+	ready := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+
+	_, _ = UpdateStatus(obj).SetCondition(overlap).SetCondition(ready).Run(ctx, state)
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "NetworkConflict"))
+	assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, "CidrOverlap"))
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	assert.False(t, AnyConditionChanged(obj, overlap))
+
+	// the keep list is given with the internal names
+	_, _ = UpdateStatus(obj).KeepConditions("CidrOverlap").Run(ctx, state)
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "NetworkConflict"))
+	assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+
+	_, _ = UpdateStatus(obj).RemoveConditions("CidrOverlap").Run(ctx, state)
+	assert.Empty(t, obj.Status.Conditions)
+
+	saved := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, state.Cluster().K8sClient().Get(ctx, state.Name(), saved))
+	assert.Empty(t, saved.Status.Conditions)
+}
+
+func TestConditionTypeMappingKeepsReadinessGatesAndOptionalConditions(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	SetConditionTypeMapping(map[string]string{
+		"BackupConfigured":       "BackupReady",
+		"MappedOptionalDegraded": "OptionalWarning",
+	})
+	defer SetConditionTypeMapping(nil)
+
+	Optional("MappedOptionalDegraded", "MappedOptionalDegraded", func(ctx context.Context, state State) (error, context.Context) {
+		return nil, nil
+	})
+
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			ReadinessGates: []string{"BackupConfigured"},
+		},
+	}
+	state := newTestState(obj)
+
+	ready := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+	statusConditions := func() []metav1.Condition {
+		return []metav1.Condition{
+			{Type: "BackupReady", Status: metav1.ConditionTrue, Reason: "BackupReady", Message: "Configured"},
+			{Type: "OptionalWarning", Status: metav1.ConditionTrue, Reason: "OptionalWarning", Message: "Degraded"},
+			{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other", Message: "Other"},
+		}
+	}
+
+	obj.Status.Conditions = statusConditions()
+	_, _ = UpdateStatus(obj).SetExclusiveConditions(ready).SuccessErrorNil().Run(ctx, state)
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "BackupReady"), "renamed readiness gate must be kept")
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "OptionalWarning"), "renamed optional condition must be kept")
+	assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, "Other"))
+	assert.True(t, meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady), "renamed readiness gate must pass")
+
+	obj.Status.Conditions = statusConditions()
+	_, _ = UpdateStatus(obj).SetCondition(ready).KeepConditions(cloudcontrolv1beta1.ConditionTypeReady).SuccessErrorNil().Run(ctx, state)
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "BackupReady"))
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, "OptionalWarning"))
+	assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, "Other"))
+}
@@ -0,0 +1,32 @@
+package composed
+
+import (
+	"context"
+)
+
+// WithDeferredStatusUpdate runs the action in the deferred status mode, so all the status writes of
+// the action accumulate in memory and are flushed with a single API call once the action returns,
+// regardless if it finished, was short-circuited by a flow control error, or failed.
+// If the flush fails, the error of the action is returned if it had one, and the flush error otherwise.
+func WithDeferredStatusUpdate(action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		state.DeferStatusUpdate()
+		err, nextCtx := action(ctx, state)
+
+		flushCtx := ctx
+		if nextCtx != nil {
+			flushCtx = nextCtx
+		}
+		flushErr := state.FlushStatus(flushCtx)
+		if flushErr == nil {
+			return err, nextCtx
+		}
+		if err != nil {
+			if _, isFlowControl := err.(FlowControlError); !isFlowControl {
+				LoggerFromCtx(flushCtx).Error(flushErr, "Error flushing deferred status")
+				return err, nextCtx
+			}
+		}
+		return LogErrorAndReturn(flushErr, "Error flushing deferred status", StopWithRequeue, flushCtx)
+	}
+}
@@ -0,0 +1,145 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type deferredStatusSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *deferredStatusSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *deferredStatusSuite) newState() (State, client.Client, *int) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nfs"},
+	}
+	writes := 0
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				writes++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), clnt, &writes
+}
+
+func deferredConditionAction(conditionType string, result error) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		b := UpdateStatus(state.Obj().(ObjWithConditions)).
+			SetCondition(metav1.Condition{Type: conditionType, Status: metav1.ConditionTrue, Reason: conditionType})
+		if result == nil {
+			b = b.SuccessErrorNil()
+		} else {
+			b = b.SuccessError(result)
+		}
+		return b.Run(ctx, state)
+	}
+}
+
+func (suite *deferredStatusSuite) pipeline(last Action) Action {
+	return ComposeActions(
+		"deferredStatusTest",
+		deferredConditionAction("First", nil),
+		deferredConditionAction("Second", nil),
+		deferredConditionAction("Third", nil),
+		last,
+	)
+}
+
+func (suite *deferredStatusSuite) loadConditions(clnt client.Client) []metav1.Condition {
+	loaded := &cloudcontrolv1beta1.NfsInstance{}
+	err := clnt.Get(suite.ctx, types.NamespacedName{Namespace: "default", Name: "nfs"}, loaded)
+	assert.NoError(suite.T(), err)
+	return loaded.Status.Conditions
+}
+
+func (suite *deferredStatusSuite) TestWritesEachUpdateWithoutDeferring() {
+	state, _, writes := suite.newState()
+
+	err, _ := suite.pipeline(deferredConditionAction("Fourth", nil))(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, *writes)
+}
+
+func (suite *deferredStatusSuite) TestWritesOnceWhenDeferred() {
+	state, clnt, writes := suite.newState()
+
+	err, _ := WithDeferredStatusUpdate(suite.pipeline(deferredConditionAction("Fourth", nil)))(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, *writes)
+
+	conditions := suite.loadConditions(clnt)
+	for _, c := range []string{"First", "Second", "Third", "Fourth"} {
+		assert.True(suite.T(), meta.IsStatusConditionTrue(conditions, c), c)
+	}
+}
+
+func (suite *deferredStatusSuite) TestFlushesOnShortCircuit() {
+	state, clnt, writes := suite.newState()
+
+	err, _ := WithDeferredStatusUpdate(suite.pipeline(deferredConditionAction("Fourth", StopWithRequeue)))(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeue, err)
+	assert.Equal(suite.T(), 1, *writes)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(suite.loadConditions(clnt), "Fourth"))
+}
+
+func (suite *deferredStatusSuite) TestFlushesOnError() {
+	state, clnt, writes := suite.newState()
+	terminalErr := errors.New("terminal")
+
+	err, _ := WithDeferredStatusUpdate(suite.pipeline(func(ctx context.Context, state State) (error, context.Context) {
+		return terminalErr, nil
+	}))(suite.ctx, state)
+	assert.Equal(suite.T(), terminalErr, err)
+	assert.Equal(suite.T(), 1, *writes)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(suite.loadConditions(clnt), "Third"))
+}
+
+func (suite *deferredStatusSuite) TestNoWriteWithoutStatusChange() {
+	state, _, writes := suite.newState()
+
+	err, _ := WithDeferredStatusUpdate(func(ctx context.Context, state State) (error, context.Context) {
+		return nil, nil
+	})(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, *writes)
+
+	// deferred mode ends with the flush
+	err, _ = deferredConditionAction("First", nil)(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, *writes)
+}
+
+func TestDeferredStatus(t *testing.T) {
+	suite.Run(t, new(deferredStatusSuite))
+}
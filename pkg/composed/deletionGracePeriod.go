@@ -0,0 +1,86 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ConditionTypeDeletionPending is set while the deletion of the cloud resources waits for the grace period to elapse
+	ConditionTypeDeletionPending = "DeletionPending"
+	ReasonDeletionPending        = "DeletionPending"
+)
+
+// ObjWithDeletionGracePeriod is implemented by objects that can delay the deletion of their cloud
+// resources after they are marked for deletion
+type ObjWithDeletionGracePeriod interface {
+	DeletionGracePeriodSeconds() int64
+}
+
+// DeletionGracePeriod returns an action that stops the delete flow of the object marked for deletion until
+// its deletion grace period elapses, counted from the deletion timestamp. While waiting, the DeletionPending
+// condition is set and the object is requeued for when the grace period elapses, and then the condition
+// is removed and the flow proceeds to the destructive actions. If the given finalizer was removed from the
+// object during the grace period the deletion is canceled, and the flow stops leaving the cloud resources
+// as they are, so the object can be recreated to take them over again. Must run before the delete actions.
+func DeletionGracePeriod(finalizer string) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if !IsMarkedForDeletion(state.Obj()) {
+			return nil, nil
+		}
+		objWithGracePeriod, ok := state.Obj().(ObjWithDeletionGracePeriod)
+		if !ok || objWithGracePeriod.DeletionGracePeriodSeconds() <= 0 {
+			return nil, nil
+		}
+
+		logger := LoggerFromCtx(ctx)
+
+		if !controllerutil.ContainsFinalizer(state.Obj(), finalizer) {
+			logger.Info("Finalizer removed during the deletion grace period, canceling the deletion")
+			return StopAndForget, nil
+		}
+
+		gracePeriod := time.Duration(objWithGracePeriod.DeletionGracePeriodSeconds()) * time.Second
+		deleteAt := state.Obj().GetDeletionTimestamp().Add(gracePeriod)
+		remaining := time.Until(deleteAt)
+
+		obj, hasConditions := state.Obj().(ObjWithConditions)
+
+		if remaining <= 0 {
+			if !hasConditions || meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeDeletionPending)) == nil {
+				return nil, nil
+			}
+			return UpdateStatus(obj).
+				RemoveConditions(ConditionTypeDeletionPending).
+				ErrorLogMessage("Error updating status after removing DeletionPending condition").
+				SuccessLogMsg("Deletion grace period elapsed").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		logger.
+			WithValues("deleteAt", deleteAt.Format(time.RFC3339)).
+			Info("Waiting for the deletion grace period")
+
+		condition := metav1.Condition{
+			Type:    ConditionTypeDeletionPending,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonDeletionPending,
+			Message: fmt.Sprintf("Cloud resources will be deleted at %s, remove the finalizer %s to cancel the deletion", deleteAt.UTC().Format(time.RFC3339), finalizer),
+		}
+		if !hasConditions || !AnyConditionChanged(obj, condition) {
+			return StopWithRequeueDelay(remaining), nil
+		}
+
+		return UpdateStatus(obj).
+			SetCondition(condition).
+			ErrorLogMessage("Error updating status with DeletionPending condition").
+			SuccessError(StopWithRequeueDelay(remaining)).
+			Run(ctx, state)
+	}
+}
@@ -0,0 +1,111 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type deletionGracePeriodSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *deletionGracePeriodSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *deletionGracePeriodSuite) newState(deletedAgo time.Duration, gracePeriodSeconds int64, finalizers ...string) State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "nfs",
+			Finalizers:        finalizers,
+			DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-deletedAgo).Truncate(time.Second)},
+		},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			DeletionGracePeriodSeconds: ptr.To(gracePeriodSeconds),
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, &cloudcontrolv1beta1.NfsInstance{})
+	assert.NoError(suite.T(), state.LoadObj(suite.ctx))
+	return state
+}
+
+func (suite *deletionGracePeriodSuite) TestGraceThenDelete() {
+	state := suite.newState(10*time.Second, 600, cloudcontrolv1beta1.FinalizerName)
+
+	err, _ := DeletionGracePeriod(cloudcontrolv1beta1.FinalizerName)(suite.ctx, state)
+	var delayErr *stopWithRequeueDelay
+	assert.True(suite.T(), errors.As(err, &delayErr))
+	assert.InDelta(suite.T(), float64(590*time.Second), float64(delayErr.Delay()), float64(5*time.Second))
+
+	nfs := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), nfs))
+	cond := meta.FindStatusCondition(nfs.Status.Conditions, ConditionTypeDeletionPending)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), ReasonDeletionPending, cond.Reason)
+
+	// the grace period elapsed
+	nfs.Spec.DeletionGracePeriodSeconds = ptr.To(int64(5))
+	state.SetObj(nfs)
+	err, _ = DeletionGracePeriod(cloudcontrolv1beta1.FinalizerName)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	nfs = &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), nfs))
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfs.Status.Conditions, ConditionTypeDeletionPending))
+}
+
+func (suite *deletionGracePeriodSuite) TestFinalizerRemovedCancelsDeletion() {
+	state := suite.newState(10*time.Second, 600, "other")
+
+	called := false
+	action := ComposeActions(
+		"test",
+		DeletionGracePeriod(cloudcontrolv1beta1.FinalizerName),
+		func(ctx context.Context, state State) (error, context.Context) {
+			called = true
+			return nil, nil
+		},
+	)
+
+	err, _ := action(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.False(suite.T(), called, "delete actions must not run when the deletion is canceled")
+}
+
+func (suite *deletionGracePeriodSuite) TestNoGracePeriod() {
+	state := suite.newState(10*time.Second, 0, cloudcontrolv1beta1.FinalizerName)
+
+	err, _ := DeletionGracePeriod(cloudcontrolv1beta1.FinalizerName)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestDeletionGracePeriod(t *testing.T) {
+	suite.Run(t, new(deletionGracePeriodSuite))
+}
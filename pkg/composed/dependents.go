@@ -0,0 +1,78 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionTypeWaitingForDependents = "WaitingForDependents"
+	ReasonWaitingForDependents        = "WaitingForDependents"
+)
+
+// DependentsFunc returns the namespace/name of the objects that depend on the state object,
+// and must be deleted before it
+type DependentsFunc func(ctx context.Context, state State) ([]string, error)
+
+// ListDependents returns a DependentsFunc that lists the objects of the list created by newList in the
+// state object namespace, and returns those for which dependsOn returns true, for example because their
+// spec refers to the state object. The dependents marked for deletion are returned as well, since they
+// are not deleted until their finalizer is removed. If the listed kind is not installed in the cluster,
+// no dependents are returned.
+func ListDependents(newList func() client.ObjectList, dependsOn func(obj client.Object, state State) bool) DependentsFunc {
+	return func(ctx context.Context, state State) ([]string, error) {
+		list := newList()
+		err := state.Cluster().K8sClient().List(ctx, list, client.InNamespace(state.Obj().GetNamespace()))
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		var result []string
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if dependsOn(obj, state) {
+				result = append(result, fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName()))
+			}
+		}
+		return result, nil
+	}
+}
+
+// WaitForDependentsDeleted returns a delete path action that orders the teardown by blocking the
+// deletion of the object while any of the given funcs returns a dependent. In that case it sets the
+// WaitingForDependents condition listing the dependents and requeues after the given delay, so the
+// cloud resources of the object are deleted only after all the dependents are gone. Once there are
+// no dependents the WaitingForDependents condition is removed, if present, and the flow continues.
+// The objects not marked for deletion or not implementing ObjWithConditions are not checked.
+func WaitForDependentsDeleted(requeueDelay time.Duration, funcs ...DependentsFunc) Action {
+	return blockDeleteWhileReferenced(
+		ConditionTypeWaitingForDependents,
+		ReasonWaitingForDependents,
+		"Waiting for dependents to be deleted: %s",
+		requeueDelay,
+		func(ctx context.Context, state State) ([]string, error) {
+			var dependents []string
+			for _, f := range funcs {
+				deps, err := f(ctx, state)
+				if err != nil {
+					return nil, err
+				}
+				dependents = append(dependents, deps...)
+			}
+			return dependents, nil
+		},
+	)
+}
@@ -0,0 +1,106 @@
+package composed
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShutdownDrainTimeout is the default time the in-flight reconciliations are waited for on shutdown
+const DefaultShutdownDrainTimeout = 20 * time.Second
+
+var (
+	draining atomic.Bool
+	inFlight atomic.Int64
+)
+
+type inFlightKey struct{}
+
+// inFlightReconciliation tracks if the status was persisted by the last action of the reconciliation
+type inFlightReconciliation struct {
+	statusPersisted atomic.Bool
+}
+
+// StartDrain makes the composed actions stop at the next checkpoint, after the action that persisted the
+// status, so the in-flight reconciliations finish their current cloud operation and its status write
+func StartDrain() {
+	draining.Store(true)
+}
+
+// Draining returns true once StartDrain is called
+func Draining() bool {
+	return draining.Load()
+}
+
+// InFlight returns the number of the reconciliations the top level composed actions are running
+func InFlight() int64 {
+	return inFlight.Load()
+}
+
+// trackInFlight counts the reconciliation if the ctx is not already of one counted by the
+// outer composed action, and returns the func to call once the reconciliation finishes
+func trackInFlight(ctx context.Context) (context.Context, func()) {
+	if ctx.Value(inFlightKey{}) != nil {
+		return ctx, func() {}
+	}
+	inFlight.Add(1)
+	return context.WithValue(ctx, inFlightKey{}, &inFlightReconciliation{}), func() {
+		inFlight.Add(-1)
+	}
+}
+
+// markStatusPersisted records the status of the reconciliation of the ctx was written to the API
+func markStatusPersisted(ctx context.Context) {
+	if r, ok := ctx.Value(inFlightKey{}).(*inFlightReconciliation); ok {
+		r.statusPersisted.Store(true)
+	}
+}
+
+// resetCheckpoint clears the persisted status before the next action of the reconciliation of the ctx is run
+func resetCheckpoint(ctx context.Context) {
+	if r, ok := ctx.Value(inFlightKey{}).(*inFlightReconciliation); ok {
+		r.statusPersisted.Store(false)
+	}
+}
+
+// atCheckpoint returns true if the last action of the reconciliation of the ctx persisted the status,
+// so the reconciliation can stop there without leaving the cloud operation unrecorded
+func atCheckpoint(ctx context.Context) bool {
+	r, ok := ctx.Value(inFlightKey{}).(*inFlightReconciliation)
+	return ok && r.statusPersisted.Load()
+}
+
+// DrainOnShutdown returns the context the manager is started with, cancelled once the given shutdown
+// context is done and the in-flight reconciliations drained, or the timeout passed. Until then the
+// reconciliations are not cancelled in the middle of a cloud operation, but stop at their next checkpoint.
+func DrainOnShutdown(ctx context.Context, timeout time.Duration) context.Context {
+	result, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		<-ctx.Done()
+		logger := LoggerFromCtx(ctx)
+		logger.
+			WithValues("inFlight", InFlight()).
+			Info("Draining in-flight reconciliations on shutdown")
+		StartDrain()
+		if waitDrained(timeout) {
+			logger.Info("In-flight reconciliations drained")
+		} else {
+			logger.
+				WithValues("inFlight", InFlight()).
+				Info("Timeout draining in-flight reconciliations")
+		}
+		cancel()
+	}()
+	return result
+}
+
+func waitDrained(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for InFlight() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
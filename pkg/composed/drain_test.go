@@ -0,0 +1,145 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDrainBetweenActions(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	defer draining.Store(false)
+
+	state, obj := newMaintenanceFreezeTestState()
+
+	var called []string
+	drain := func(ctx context.Context, state State) (error, context.Context) {
+		called = append(called, "drain")
+		// the drain signal arrives while the action runs its cloud operation, not followed by the status write
+		StartDrain()
+		return nil, nil
+	}
+	persist := func(ctx context.Context, state State) (error, context.Context) {
+		called = append(called, "persist")
+		return UpdateStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReady,
+				Message: "Ready",
+			}).
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+	next := func(ctx context.Context, state State) (error, context.Context) {
+		called = append(called, "next")
+		return nil, nil
+	}
+
+	err, _ := ComposeActions(
+		"main",
+		drain,
+		ComposeActions("nested", next, persist, next),
+		next,
+	)(ctx, state)
+
+	// the reconciliation continues until the status is persisted, and stops there without requeue
+	assert.Equal(t, StopAndForget, err)
+	assert.Equal(t, []string{"drain", "next", "persist"}, called)
+	assert.Equal(t, int64(0), InFlight())
+
+	persisted := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(t, state.Cluster().K8sClient().Get(ctx, client.ObjectKeyFromObject(obj), persisted))
+	assert.Len(t, persisted.Status.Conditions, 1)
+}
+
+func TestDrainWithDeferredStatus(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	defer draining.Store(false)
+	StartDrain()
+
+	state, obj := newMaintenanceFreezeTestState()
+
+	var called []string
+	deferred := func(ctx context.Context, state State) (error, context.Context) {
+		called = append(called, "deferred")
+		return UpdateStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReady,
+				Message: "Ready",
+			}).
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+	next := func(ctx context.Context, state State) (error, context.Context) {
+		called = append(called, "next")
+		return nil, nil
+	}
+
+	err, _ := ComposeActions(
+		"main",
+		WithDeferredStatusUpdate(ComposeActions("deferred", deferred, next)),
+		next,
+	)(ctx, state)
+
+	// the deferred status is persisted only once flushed, so the checkpoint is after the deferred action
+	assert.Equal(t, StopAndForget, err)
+	assert.Equal(t, []string{"deferred", "next"}, called)
+}
+
+func TestDrainOnShutdown(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	defer draining.Store(false)
+
+	shutdownCtx, shutdown := context.WithCancel(ctx)
+	managerCtx := DrainOnShutdown(shutdownCtx, time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var actionCtxErr error
+	called := 0
+	inFlightAction := func(ctx context.Context, state State) (error, context.Context) {
+		called++
+		close(started)
+		<-release
+		actionCtxErr = ctx.Err()
+		return nil, nil
+	}
+	next := func(ctx context.Context, state State) (error, context.Context) {
+		called++
+		return nil, nil
+	}
+
+	result := make(chan error)
+	go func() {
+		err, _ := ComposeActions("main", inFlightAction, next)(managerCtx, nil)
+		result <- err
+	}()
+
+	<-started
+	shutdown()
+
+	assert.Eventually(t, Draining, time.Second, 10*time.Millisecond)
+	// the in-flight action is not cancelled
+	assert.NoError(t, managerCtx.Err())
+	assert.Equal(t, int64(1), InFlight())
+
+	close(release)
+
+	// the action without the status write is no checkpoint, so the reconciliation runs to its end
+	assert.NoError(t, <-result)
+	assert.NoError(t, actionCtxErr)
+	assert.Equal(t, 2, called)
+	assert.Eventually(t, func() bool {
+		return managerCtx.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+}
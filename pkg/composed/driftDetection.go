@@ -0,0 +1,103 @@
+package composed
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionTypeDriftDetected = "DriftDetected"
+	ReasonDriftDetected        = "DriftDetected"
+
+	// DriftDetectionEnforce reconciles the drift of the cloud resources back to the desired state
+	DriftDetectionEnforce = "Enforce"
+	// DriftDetectionDetect reports the drift of the cloud resources in the DriftDetected condition
+	DriftDetectionDetect = "Detect"
+	// DriftDetectionOff ignores the drift of the cloud resources
+	DriftDetectionOff = "Off"
+)
+
+// ObjWithDriftDetection is implemented by objects that select how the changes of their cloud resources
+// made outside of cloud-manager are handled
+type ObjWithDriftDetection interface {
+	// DriftDetection returns one of the DriftDetectionEnforce, DriftDetectionDetect and DriftDetectionOff modes,
+	// or empty for DriftDetectionEnforce
+	DriftDetection() string
+}
+
+// DriftDetection returns the drift detection mode of the object, DriftDetectionEnforce if it does not
+// implement ObjWithDriftDetection or does not specify it
+func DriftDetection(obj client.Object) string {
+	withDriftDetection, ok := obj.(ObjWithDriftDetection)
+	if !ok || withDriftDetection.DriftDetection() == "" {
+		return DriftDetectionEnforce
+	}
+	return withDriftDetection.DriftDetection()
+}
+
+// EnforcesDrift returns true if the drift of the object cloud resources should be reconciled, either since
+// its mode is DriftDetectionEnforce, or since it is marked for deletion and its cloud resources must be cleaned up
+func EnforcesDrift(obj client.Object) bool {
+	return IsMarkedForDeletion(obj) || DriftDetection(obj) == DriftDetectionEnforce
+}
+
+// DriftGuard returns an Action guarding the remediation of the drift of the cloud resources, described by
+// the detect function, or empty if there is none. The remediate action runs only if the object EnforcesDrift.
+// With the DriftDetectionDetect mode the drift is reported in the DriftDetected condition instead, and with
+// DriftDetectionOff it is not even detected. The DriftDetected condition is removed once there is no drift
+// to report. The actions reconciling the drift implicitly, like creating the missing resources, should
+// consult EnforcesDrift on their own.
+func DriftGuard(detect func(ctx context.Context, state State) string, remediate Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return remediate(ctx, state)
+		}
+
+		var drift string
+		if DriftDetection(obj) != DriftDetectionOff {
+			drift = detect(ctx, state)
+		}
+
+		if drift == "" || EnforcesDrift(obj) {
+			if meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeDriftDetected)) != nil {
+				err, _ := UpdateStatus(obj).
+					RemoveConditions(ConditionTypeDriftDetected).
+					ErrorLogMessage("Error updating status after removing DriftDetected condition").
+					SuccessErrorNil().
+					Run(ctx, state)
+				if err != nil {
+					return err, ctx
+				}
+			}
+			if drift == "" {
+				return nil, nil
+			}
+			LoggerFromCtx(ctx).
+				WithValues("drift", drift).
+				Info("Reconciling drift")
+			return remediate(ctx, state)
+		}
+
+		condition := metav1.Condition{
+			Type:    ConditionTypeDriftDetected,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonDriftDetected,
+			Message: drift,
+		}
+		if !AnyConditionChanged(obj, condition) {
+			return nil, nil
+		}
+		LoggerFromCtx(ctx).
+			WithValues("drift", drift).
+			Info("Drift detected")
+		return UpdateStatus(obj).
+			SetCondition(condition).
+			ErrorLogMessage("Error updating status with DriftDetected condition").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
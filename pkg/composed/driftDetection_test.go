@@ -0,0 +1,35 @@
+package composed
+
+import (
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDriftDetection(t *testing.T) {
+	t.Run("Enforce is the default", func(t *testing.T) {
+		assert.Equal(t, DriftDetectionEnforce, DriftDetection(&cloudcontrolv1beta1.IpRange{}))
+		assert.Equal(t, DriftDetectionEnforce, DriftDetection(&corev1.ConfigMap{}))
+		assert.True(t, EnforcesDrift(&cloudcontrolv1beta1.IpRange{}))
+	})
+
+	t.Run("Detect and Off do not enforce", func(t *testing.T) {
+		for _, mode := range []string{DriftDetectionDetect, DriftDetectionOff} {
+			obj := &cloudcontrolv1beta1.IpRange{Spec: cloudcontrolv1beta1.IpRangeSpec{DriftDetection: mode}}
+			assert.Equal(t, mode, DriftDetection(obj))
+			assert.False(t, EnforcesDrift(obj))
+		}
+	})
+
+	t.Run("drift is enforced on delete", func(t *testing.T) {
+		obj := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+			Spec:       cloudcontrolv1beta1.IpRangeSpec{DriftDetection: DriftDetectionOff},
+		}
+		assert.True(t, EnforcesDrift(obj))
+	})
+}
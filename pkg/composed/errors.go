@@ -99,7 +99,8 @@ func StopWithRequeueDelay(d time.Duration) error {
 
 func AnyConditionChanged(obj ObjWithConditions, conditionsToSet ...metav1.Condition) bool {
 	return pie.All(conditionsToSet, func(x metav1.Condition) bool {
-		c := meta.FindStatusCondition(*obj.Conditions(), x.Type)
+		x = ConditionWithSeverity(x)
+		c := meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(x.Type))
 		return c == nil || c.Reason != x.Reason || c.Message != x.Message || c.Status != x.Status
 	})
 }
@@ -0,0 +1,48 @@
+package composed
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LastReconcileSetter is implemented by objects that publish in their status the time
+// they were last reconciled, so a watchdog can alert on objects that are stuck
+type LastReconcileSetter interface {
+	GetLastReconcileTime() *metav1.Time
+	SetLastReconcileTime(t *metav1.Time)
+}
+
+// ReconcileHeartbeat returns an action that sets the last reconcile time of the objects
+// implementing LastReconcileSetter. The time is always set on the loaded object, so it is
+// saved together with any other status change done later in the pipeline, but it is saved on its own
+// only once the previously saved time is older than the given interval. That keeps the
+// status update caused by the heartbeat from triggering a reconcile loop.
+// Must run after the object is loaded, and does nothing for objects that are not found.
+func ReconcileHeartbeat(interval time.Duration) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if state.Obj() == nil || state.Obj().GetName() == "" {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(LastReconcileSetter)
+		if !ok {
+			return nil, nil
+		}
+
+		now := metav1.Now()
+		last := obj.GetLastReconcileTime()
+		obj.SetLastReconcileTime(&now)
+
+		if last != nil && now.Sub(last.Time) < interval {
+			return nil, nil
+		}
+
+		if err := state.UpdateObjStatus(ctx); err != nil {
+			// the heartbeat is best effort and must not block the reconciliation
+			LoggerFromCtx(ctx).Error(err, "Error updating status with last reconcile time")
+		}
+
+		return nil, nil
+	}
+}
@@ -0,0 +1,91 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type reconcileHeartbeatSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileHeartbeatSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileHeartbeatSuite) newState(lastReconcileTime *metav1.Time) State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis",
+		},
+		Status: cloudcontrolv1beta1.RedisInstanceStatus{
+			LastReconcileTime: lastReconcileTime,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+}
+
+func (suite *reconcileHeartbeatSuite) loadSaved(state State) *cloudcontrolv1beta1.RedisInstance {
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.Nil(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	return saved
+}
+
+func (suite *reconcileHeartbeatSuite) TestFirstHeartbeatIsSaved() {
+	state := suite.newState(nil)
+
+	err, _ := ReconcileHeartbeat(time.Minute)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.NotNil(suite.T(), suite.loadSaved(state).Status.LastReconcileTime)
+}
+
+func (suite *reconcileHeartbeatSuite) TestRecentHeartbeatIsNotSaved() {
+	recent := metav1.NewTime(time.Now().Add(-10 * time.Second).Truncate(time.Second))
+	state := suite.newState(&recent)
+
+	err, _ := ReconcileHeartbeat(time.Minute)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.True(suite.T(), recent.Equal(suite.loadSaved(state).Status.LastReconcileTime))
+	// set on the loaded object to be saved with other status changes
+	assert.True(suite.T(), state.Obj().(LastReconcileSetter).GetLastReconcileTime().After(recent.Time))
+}
+
+func (suite *reconcileHeartbeatSuite) TestStaleHeartbeatIsSaved() {
+	stale := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	state := suite.newState(&stale)
+
+	err, _ := ReconcileHeartbeat(time.Minute)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.True(suite.T(), suite.loadSaved(state).Status.LastReconcileTime.After(stale.Time))
+}
+
+func TestReconcileHeartbeat(t *testing.T) {
+	suite.Run(t, new(reconcileHeartbeatSuite))
+}
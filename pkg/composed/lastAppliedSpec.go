@@ -0,0 +1,90 @@
+package composed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationLastAppliedSpecHash holds the hash of the spec the object was last reconciled to Ready with
+	AnnotationLastAppliedSpecHash = "cloud-manager.kyma-project.io/last-applied-spec-hash"
+	// AnnotationLastAppliedSpecTime holds the time the object was last fully reconciled to Ready, in RFC3339 format
+	AnnotationLastAppliedSpecTime = "cloud-manager.kyma-project.io/last-applied-spec-time"
+)
+
+// SpecHash returns the hex encoded sha256 hash of the object spec. Since the hash is computed over
+// the JSON encoded spec only, the metadata and status changes do not change it.
+func SpecHash(obj client.Object) (string, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(u["spec"])
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// LastAppliedSpec returns an action that skips the given action when the object is Ready, and its spec
+// hash equals the one recorded in the AnnotationLastAppliedSpecHash by the last full reconciliation that
+// happened within the resync interval. In that case it requeues for when the interval passes, so the
+// cloud resources are still periodically resynced to detect the drift. Otherwise, it runs the given action,
+// and if the object ends up Ready records the spec hash and the time in the object annotations.
+// Objects marked for deletion always run the given action. Must run after the object is loaded.
+func LastAppliedSpec(resyncInterval time.Duration, action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if state.Obj() == nil || state.Obj().GetName() == "" || IsMarkedForDeletion(state.Obj()) {
+			return action(ctx, state)
+		}
+
+		logger := LoggerFromCtx(ctx)
+
+		hash, err := SpecHash(state.Obj())
+		if err != nil {
+			logger.Error(err, "Error calculating spec hash")
+			return action(ctx, state)
+		}
+
+		now := time.Now()
+		annotations := state.Obj().GetAnnotations()
+		lastTime, timeErr := time.Parse(time.RFC3339, annotations[AnnotationLastAppliedSpecTime])
+		ready, _ := terminalCondition(state)
+		if annotations[AnnotationLastAppliedSpecHash] == hash && timeErr == nil &&
+			ready == NotificationTransitionReady && now.Sub(lastTime) < resyncInterval {
+			logger.Info("Spec unchanged since last applied, skipping reconciliation until resync")
+			return StopWithRequeueDelay(resyncInterval - now.Sub(lastTime)), nil
+		}
+
+		err, resCtx := action(ctx, state)
+
+		if IsMarkedForDeletion(state.Obj()) {
+			return err, resCtx
+		}
+		if ready, _ = terminalCondition(state); ready != NotificationTransitionReady {
+			return err, resCtx
+		}
+
+		original := state.Obj().DeepCopyObject().(client.Object)
+		annotations = state.Obj().GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationLastAppliedSpecHash] = hash
+		annotations[AnnotationLastAppliedSpecTime] = now.UTC().Format(time.RFC3339)
+		state.Obj().SetAnnotations(annotations)
+		if patchErr := state.Cluster().K8sClient().Patch(ctx, state.Obj(), client.MergeFrom(original)); patchErr != nil {
+			// the fast-path is an optimization and must not fail the reconciliation
+			logger.Error(patchErr, "Error patching last applied spec annotations")
+		}
+
+		return err, resCtx
+	}
+}
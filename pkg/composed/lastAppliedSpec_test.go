@@ -0,0 +1,175 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type lastAppliedSpecSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *lastAppliedSpecSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *lastAppliedSpecSuite) newObj() *cloudcontrolv1beta1.RedisInstance {
+	return &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis",
+		},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "skr", Name: "redis"},
+		},
+	}
+}
+
+func (suite *lastAppliedSpecSuite) newState(obj *cloudcontrolv1beta1.RedisInstance) State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+}
+
+func (suite *lastAppliedSpecSuite) readyObj(lastApplied time.Time) *cloudcontrolv1beta1.RedisInstance {
+	obj := suite.newObj()
+	hash, err := SpecHash(obj)
+	assert.NoError(suite.T(), err)
+	obj.Annotations = map[string]string{
+		AnnotationLastAppliedSpecHash: hash,
+		AnnotationLastAppliedSpecTime: lastApplied.UTC().Format(time.RFC3339),
+	}
+	obj.Status.Conditions = []metav1.Condition{{
+		Type:   cloudcontrolv1beta1.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: cloudcontrolv1beta1.ReasonReady,
+	}}
+	return obj
+}
+
+// setReadyAction records it was called and sets the Ready condition
+func setReadyAction(called *bool) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		*called = true
+		meta.SetStatusCondition(state.Obj().(ObjWithConditions).Conditions(), metav1.Condition{
+			Type:   cloudcontrolv1beta1.ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+			Reason: cloudcontrolv1beta1.ReasonReady,
+		})
+		return StopAndForget, nil
+	}
+}
+
+func (suite *lastAppliedSpecSuite) TestSpecHashIgnoresMetadataAndStatus() {
+	obj := suite.newObj()
+	hash, err := SpecHash(obj)
+	assert.NoError(suite.T(), err)
+
+	obj.Labels = map[string]string{"foo": "bar"}
+	obj.Status.State = cloudcontrolv1beta1.ErrorState
+	changed, err := SpecHash(obj)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), hash, changed)
+
+	obj.Spec.RemoteRef.Name = "other"
+	changed, err = SpecHash(obj)
+	assert.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), hash, changed)
+}
+
+func (suite *lastAppliedSpecSuite) TestReadyObjectIsAnnotated() {
+	state := suite.newState(suite.newObj())
+	called := false
+
+	err, _ := LastAppliedSpec(time.Hour, setReadyAction(&called))(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.True(suite.T(), called)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	hash, _ := SpecHash(saved)
+	assert.Equal(suite.T(), hash, saved.Annotations[AnnotationLastAppliedSpecHash])
+	assert.NotEmpty(suite.T(), saved.Annotations[AnnotationLastAppliedSpecTime])
+}
+
+func (suite *lastAppliedSpecSuite) TestNotReadyObjectIsNotAnnotated() {
+	state := suite.newState(suite.newObj())
+
+	err, _ := LastAppliedSpec(time.Hour, func(ctx context.Context, state State) (error, context.Context) {
+		return StopWithRequeue, nil
+	})(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeue, err)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	assert.Empty(suite.T(), saved.Annotations[AnnotationLastAppliedSpecHash])
+}
+
+func (suite *lastAppliedSpecSuite) TestUnchangedSpecSkipsAction() {
+	state := suite.newState(suite.readyObj(time.Now().Add(-10 * time.Minute)))
+	called := false
+
+	err, _ := LastAppliedSpec(time.Hour, setReadyAction(&called))(suite.ctx, state)
+	assert.False(suite.T(), called)
+	assert.True(suite.T(), IsStopWithRequeueDelay(err))
+}
+
+func (suite *lastAppliedSpecSuite) TestChangedSpecRunsAction() {
+	obj := suite.readyObj(time.Now().Add(-10 * time.Minute))
+	obj.Spec.RemoteRef.Name = "changed"
+	state := suite.newState(obj)
+	called := false
+
+	err, _ := LastAppliedSpec(time.Hour, setReadyAction(&called))(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.True(suite.T(), called)
+
+	hash, _ := SpecHash(obj)
+	assert.Equal(suite.T(), hash, state.Obj().GetAnnotations()[AnnotationLastAppliedSpecHash])
+}
+
+func (suite *lastAppliedSpecSuite) TestResyncRunsAction() {
+	state := suite.newState(suite.readyObj(time.Now().Add(-2 * time.Hour)))
+	called := false
+
+	_, _ = LastAppliedSpec(time.Hour, setReadyAction(&called))(suite.ctx, state)
+	assert.True(suite.T(), called)
+}
+
+func (suite *lastAppliedSpecSuite) TestDeletionBypassesFastPath() {
+	obj := suite.readyObj(time.Now().Add(-10 * time.Minute))
+	obj.Finalizers = []string{"test"}
+	state := suite.newState(obj)
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Delete(suite.ctx, obj))
+	assert.NoError(suite.T(), state.LoadObj(suite.ctx))
+	called := false
+
+	_, _ = LastAppliedSpec(time.Hour, setReadyAction(&called))(suite.ctx, state)
+	assert.True(suite.T(), called)
+}
+
+func TestLastAppliedSpec(t *testing.T) {
+	suite.Run(t, new(lastAppliedSpecSuite))
+}
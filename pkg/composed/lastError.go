@@ -0,0 +1,133 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReasonReconcileError     = "ReconcileError"
+	ReasonStatusUpdateFailed = "StatusUpdateFailed"
+
+	lastErrorMaxLength = 1024
+)
+
+// LastErrorSetter is implemented by objects that publish in their status the most recent
+// reconciliation error, so clients do not have to parse it from the condition messages
+type LastErrorSetter interface {
+	GetLastErrorMessage() string
+	GetLastErrorReason() string
+	SetLastError(message, reason string, t metav1.Time)
+	ClearLastError()
+}
+
+type lastErrorRecorderKey struct{}
+
+type lastErrorRecorder struct {
+	recorded bool
+	message  string
+	reason   string
+}
+
+// recordLastError records the error in the recorder from the context, if any
+func recordLastError(ctx context.Context, reason, msg string, err error) {
+	if ctx == nil {
+		return
+	}
+	if recorder, ok := ctx.Value(lastErrorRecorderKey{}).(*lastErrorRecorder); ok {
+		recorder.record(reason, msg, err)
+	}
+}
+
+// record overwrites the previously recorded error, so the most recent one is published
+func (r *lastErrorRecorder) record(reason, msg string, err error) {
+	message := msg
+	if err != nil {
+		if len(msg) > 0 {
+			message = fmt.Sprintf("%s: %s", msg, err.Error())
+		} else {
+			message = err.Error()
+		}
+	}
+	r.recorded = true
+	r.message = SanitizeErrorMessage(message)
+	r.reason = reason
+}
+
+var sensitiveErrorPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// bearer tokens
+	{regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`), "Bearer [REDACTED]"},
+	// key=value and key: value pairs with a sensitive key
+	{regexp.MustCompile(`(?i)\b(password|passwd|secret|token|api[_-]?key|access[_-]?key|client[_-]?secret|authorization|credentials?)(\s*[=:]\s*)("[^"]*"|'[^']*'|\S+)`), "${1}${2}[REDACTED]"},
+	// AWS access key ids
+	{regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`), "[REDACTED]"},
+	// user info in URLs
+	{regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`), "://[REDACTED]@"},
+}
+
+// SanitizeErrorMessage redacts the credentials, tokens and passwords from the error message, and
+// truncates it, so it can be published in the object status
+func SanitizeErrorMessage(message string) string {
+	for _, p := range sensitiveErrorPatterns {
+		message = p.re.ReplaceAllString(message, p.repl)
+	}
+	if runes := []rune(message); len(runes) > lastErrorMaxLength {
+		message = string(runes[:lastErrorMaxLength]) + "..."
+	}
+	return message
+}
+
+// RecordLastError returns an action that runs the given action and publishes the most recent error
+// logged with LogErrorAndReturn, the failed status update, or the non flow control error it returned
+// in the last error status of the objects implementing LastErrorSetter. If the given action completed
+// without any error, the last error is cleared. The status is saved only if the last error changed,
+// and a failed save is only logged. Must run after the object is loaded and wrap all the actions
+// that might fail. The result of the given action is returned unchanged.
+func RecordLastError(action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		recorder := &lastErrorRecorder{}
+
+		err, resCtx := action(context.WithValue(ctx, lastErrorRecorderKey{}, recorder), state)
+
+		if state.Obj() == nil || state.Obj().GetName() == "" {
+			return err, resCtx
+		}
+		if IsMarkedForDeletion(state.Obj()) && len(state.Obj().GetFinalizers()) == 0 {
+			// the deletion completed, and there is nothing to publish the error on
+			return err, resCtx
+		}
+		obj, ok := state.Obj().(LastErrorSetter)
+		if !ok {
+			return err, resCtx
+		}
+
+		if !recorder.recorded && err != nil && !IsFlowControl(err) {
+			recorder.record(ReasonReconcileError, "", err)
+		}
+
+		if recorder.recorded {
+			if obj.GetLastErrorMessage() == recorder.message && obj.GetLastErrorReason() == recorder.reason {
+				return err, resCtx
+			}
+			obj.SetLastError(recorder.message, recorder.reason, metav1.Now())
+		} else {
+			if len(obj.GetLastErrorMessage()) == 0 {
+				return err, resCtx
+			}
+			obj.ClearLastError()
+		}
+
+		if updateErr := state.UpdateObjStatus(ctx); updateErr != nil {
+			// publishing the last error is best effort and must not change the reconciliation result
+			LoggerFromCtx(ctx).Error(updateErr, "Error updating status with last error")
+		}
+
+		return err, resCtx
+	}
+}
@@ -0,0 +1,53 @@
+package composed
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var leaderAcquired struct {
+	sync.Mutex
+	hooks []func()
+}
+
+// OnLeaderAcquired registers the func called each time this instance acquires the leadership, so
+// the metrics reported by the previous leadership term can be reset. The funcs must only reset the
+// metric values and never register the collectors again. Must be called on startup before the runnable
+// returned by NewLeaderAcquiredRunnable is added to the manager.
+func OnLeaderAcquired(f func()) {
+	leaderAcquired.Lock()
+	defer leaderAcquired.Unlock()
+	leaderAcquired.hooks = append(leaderAcquired.hooks, f)
+}
+
+// notifyLeaderAcquired calls the funcs registered with OnLeaderAcquired in the registration order
+func notifyLeaderAcquired() {
+	leaderAcquired.Lock()
+	hooks := append([]func(){}, leaderAcquired.hooks...)
+	leaderAcquired.Unlock()
+	for _, f := range hooks {
+		f()
+	}
+}
+
+// NewLeaderAcquiredRunnable returns a manager runnable that requires the leader election, so the manager
+// starts it each time the leadership is acquired, and it then calls the funcs registered with OnLeaderAcquired
+func NewLeaderAcquiredRunnable() manager.Runnable {
+	return &leaderAcquiredRunnable{}
+}
+
+var _ manager.LeaderElectionRunnable = &leaderAcquiredRunnable{}
+
+type leaderAcquiredRunnable struct{}
+
+func (r *leaderAcquiredRunnable) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *leaderAcquiredRunnable) Start(ctx context.Context) error {
+	notifyLeaderAcquired()
+	<-ctx.Done()
+	return nil
+}
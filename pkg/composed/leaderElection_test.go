@@ -0,0 +1,39 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func TestLeaderAcquiredHooksCalledOnEachAcquisition(t *testing.T) {
+	var calls []string
+	OnLeaderAcquired(func() { calls = append(calls, "first") })
+	OnLeaderAcquired(func() { calls = append(calls, "second") })
+	defer func() {
+		leaderAcquired.hooks = nil
+	}()
+
+	runnable := NewLeaderAcquiredRunnable()
+	assert.True(t, runnable.(manager.LeaderElectionRunnable).NeedLeaderElection())
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- runnable.Start(ctx)
+		}()
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "runnable did not stop")
+		}
+	}
+
+	assert.Equal(t, []string{"first", "second", "first", "second"}, calls)
+}
@@ -23,5 +23,6 @@ func LogErrorAndReturn(err error, msg string, result error, ctx context.Context)
 		logger.Error(errors.New("the ctx is not supplied to LogErrorAndReturn"), "Logical error")
 	}
 	logger.Error(err, msg)
+	recordLastError(ctx, ReasonReconcileError, msg, err)
 	return result, ctx
 }
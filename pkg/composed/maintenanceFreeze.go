@@ -0,0 +1,193 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ConditionTypeMaintenanceFrozen = "MaintenanceFrozen"
+	ReasonMaintenanceFrozen        = "MaintenanceFrozen"
+)
+
+// freezeClock is the current time the maintenance freeze windows are checked against
+var freezeClock = time.Now
+
+var freezeDaysOfWeek = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// ObjWithMaintenanceFreeze is implemented by objects that defer the disruptive operations on their cloud
+// resources, like the upgrades and the recreation, while in one of their maintenance freeze windows
+type ObjWithMaintenanceFreeze interface {
+	// MaintenanceFreezeWindows returns the windows in the form parsed by ParseFreezeWindow
+	MaintenanceFreezeWindows() []string
+}
+
+// FreezeWindow is the recurring maintenance freeze window in UTC
+type FreezeWindow struct {
+	// Start of the window since the midnight
+	Start time.Duration
+	// End of the window since the midnight, if not after the Start the window ends on the next day
+	End time.Duration
+	// DaysOfWeek the window starts on, every day if empty
+	DaysOfWeek []time.Weekday
+}
+
+// ParseFreezeWindow parses the cron-like `<start>-<end> [<day>,...]` window, with the start and the end
+// as HH:MM in UTC and the optional comma separated list of the Mon..Sun days the window starts on, for
+// example `22:00-06:00 Fri,Sat`. The start and the end must differ.
+func ParseFreezeWindow(s string) (FreezeWindow, error) {
+	result := FreezeWindow{}
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return result, fmt.Errorf("invalid maintenance freeze window %q, expected <start>-<end> [<day>,...]", s)
+	}
+	start, end, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return result, fmt.Errorf("invalid maintenance freeze window %q, expected <start>-<end> [<day>,...]", s)
+	}
+	var err error
+	if result.Start, err = parseFreezeTime(start); err != nil {
+		return result, fmt.Errorf("invalid maintenance freeze window %q start: %w", s, err)
+	}
+	if result.End, err = parseFreezeTime(end); err != nil {
+		return result, fmt.Errorf("invalid maintenance freeze window %q end: %w", s, err)
+	}
+	if result.Start == result.End {
+		return result, fmt.Errorf("invalid maintenance freeze window %q, start and end must differ", s)
+	}
+	if len(fields) == 2 {
+		for _, day := range strings.Split(fields[1], ",") {
+			weekday, ok := freezeDaysOfWeek[day]
+			if !ok {
+				return result, fmt.Errorf("invalid maintenance freeze window %q day of week %q, expected one of Mon, Tue, Wed, Thu, Fri, Sat, Sun", s, day)
+			}
+			result.DaysOfWeek = append(result.DaysOfWeek, weekday)
+		}
+	}
+	return result, nil
+}
+
+func parseFreezeTime(s string) (time.Duration, error) {
+	if len(s) != len("15:04") {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FrozenUntil returns the end of the window occurrence the given time falls in, and false if it is
+// outside of the window
+func (w FreezeWindow) FrozenUntil(t time.Time) (time.Time, bool) {
+	t = t.UTC()
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// the occurrence spanning the midnight started yesterday
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		if !w.startsOn(day.Weekday()) {
+			continue
+		}
+		start := day.Add(w.Start)
+		end := day.Add(w.End)
+		if w.End <= w.Start {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !t.Before(start) && t.Before(end) {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (w FreezeWindow) startsOn(weekday time.Weekday) bool {
+	if len(w.DaysOfWeek) == 0 {
+		return true
+	}
+	for _, d := range w.DaysOfWeek {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceFrozenUntil returns the latest end of the maintenance freeze windows of the state object
+// the current time falls in, and false if it falls in none. The windows that can not be parsed are
+// logged and ignored. Objects marked for deletion are never frozen.
+func MaintenanceFrozenUntil(ctx context.Context, state State) (time.Time, bool) {
+	obj, ok := state.Obj().(ObjWithMaintenanceFreeze)
+	if !ok || IsMarkedForDeletion(state.Obj()) {
+		return time.Time{}, false
+	}
+
+	now := freezeClock()
+	var until time.Time
+	frozen := false
+	for _, s := range obj.MaintenanceFreezeWindows() {
+		window, err := ParseFreezeWindow(s)
+		if err != nil {
+			LoggerFromCtx(ctx).Error(err, "Ignoring invalid maintenance freeze window")
+			continue
+		}
+		if end, in := window.FrozenUntil(now); in && end.After(until) {
+			until = end
+			frozen = true
+		}
+	}
+	return until, frozen
+}
+
+// DeferFrozen defers the disruptive operation of the state object until the end of its maintenance freeze
+// window, by setting the MaintenanceFrozen condition and recording in the returned context the retry of
+// the reconciliation at the window end, even if the rest of the flow stops and forgets the object
+func DeferFrozen(ctx context.Context, state State, until time.Time) (error, context.Context) {
+	ctx = withRetryDelay(ctx, until.Sub(freezeClock()))
+
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok {
+		return nil, ctx
+	}
+	condition := metav1.Condition{
+		Type:    ConditionTypeMaintenanceFrozen,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonMaintenanceFrozen,
+		Message: fmt.Sprintf("Disruptive operations are deferred until the maintenance freeze window ends at %s", until.Format(time.RFC3339)),
+	}
+	if !AnyConditionChanged(obj, condition) {
+		return nil, ctx
+	}
+	err, _ := UpdateStatus(obj).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating status with MaintenanceFrozen condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+	return err, ctx
+}
+
+// RemoveFrozen removes the MaintenanceFrozen condition of the state object once the deferred disruptive
+// operation is not pending any more or can run, and continues the flow
+func RemoveFrozen(ctx context.Context, state State) (error, context.Context) {
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok || meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeMaintenanceFrozen)) == nil {
+		return nil, ctx
+	}
+	return UpdateStatus(obj).
+		RemoveConditions(ConditionTypeMaintenanceFrozen).
+		ErrorLogMessage("Error updating status after removing MaintenanceFrozen condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
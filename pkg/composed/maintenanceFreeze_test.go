@@ -0,0 +1,165 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestParseFreezeWindow(t *testing.T) {
+	window, err := ParseFreezeWindow("22:30-06:00 Fri,Sat")
+	assert.NoError(t, err)
+	assert.Equal(t, FreezeWindow{
+		Start:      22*time.Hour + 30*time.Minute,
+		End:        6 * time.Hour,
+		DaysOfWeek: []time.Weekday{time.Friday, time.Saturday},
+	}, window)
+
+	window, err = ParseFreezeWindow("08:00-18:00")
+	assert.NoError(t, err)
+	assert.Empty(t, window.DaysOfWeek)
+
+	for _, s := range []string{
+		"",
+		"08:00",
+		"8:00-18:00",
+		"08:00-24:00",
+		"08:60-18:00",
+		"08:00-08:00",
+		"08:00-18:00 Monday",
+		"08:00-18:00 Mon,",
+		"08:00-18:00 Mon Tue",
+	} {
+		_, err := ParseFreezeWindow(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestFreezeWindowFrozenUntil(t *testing.T) {
+	// 2024-06-07 is Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 6, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	businessHours, _ := ParseFreezeWindow("08:00-18:00 Mon,Tue,Wed,Thu,Fri")
+	weekendNights, _ := ParseFreezeWindow("22:00-06:00 Fri,Sat")
+
+	testData := []struct {
+		title  string
+		window FreezeWindow
+		now    time.Time
+		frozen bool
+		until  time.Time
+	}{
+		{"inside business hours", businessHours, at(7, 12, 0), true, at(7, 18, 0)},
+		{"at the start of business hours", businessHours, at(7, 8, 0), true, at(7, 18, 0)},
+		{"at the end of business hours", businessHours, at(7, 18, 0), false, time.Time{}},
+		{"before business hours", businessHours, at(7, 7, 59), false, time.Time{}},
+		{"business hours on saturday", businessHours, at(8, 12, 0), false, time.Time{}},
+		{"friday night before midnight", weekendNights, at(7, 23, 0), true, at(8, 6, 0)},
+		{"friday night after midnight", weekendNights, at(8, 1, 0), true, at(8, 6, 0)},
+		{"thursday night after midnight", weekendNights, at(7, 1, 0), false, time.Time{}},
+		{"saturday night after midnight", weekendNights, at(9, 5, 59), true, at(9, 6, 0)},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.title, func(t *testing.T) {
+			until, frozen := tt.window.FrozenUntil(tt.now)
+			assert.Equal(t, tt.frozen, frozen)
+			assert.Equal(t, tt.until, until)
+		})
+	}
+}
+
+func newMaintenanceFreezeTestState(windows ...cloudcontrolv1beta1.MaintenanceFreezeWindow) (State, *cloudcontrolv1beta1.RedisInstance) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			MaintenanceFreeze: windows,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), obj
+}
+
+func TestDeferFrozen(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	defer func() {
+		freezeClock = time.Now
+	}()
+	// 2024-06-07 is Friday
+	now := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	freezeClock = func() time.Time {
+		return now
+	}
+
+	state, obj := newMaintenanceFreezeTestState(cloudcontrolv1beta1.MaintenanceFreezeWindow{
+		Start:      "08:00",
+		End:        "18:00",
+		DaysOfWeek: []cloudcontrolv1beta1.DayOfWeek{"Mon", "Tue", "Wed", "Thu", "Fri"},
+	})
+
+	t.Run("deferred inside the window", func(t *testing.T) {
+		until, frozen := MaintenanceFrozenUntil(ctx, state)
+		assert.True(t, frozen)
+
+		err, resCtx := DeferFrozen(ctx, state, until)
+
+		assert.Nil(t, err)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeMaintenanceFrozen)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, ReasonMaintenanceFrozen, cond.Reason)
+			assert.Contains(t, cond.Message, "2024-06-07T18:00:00Z")
+		}
+
+		// requeued at the window end even if the flow stops and forgets the object
+		res, err := Handle(StopAndForget, resCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 6 * time.Hour}, res)
+	})
+
+	t.Run("removed outside the window", func(t *testing.T) {
+		now = time.Date(2024, 6, 7, 18, 0, 0, 0, time.UTC)
+
+		_, frozen := MaintenanceFrozenUntil(ctx, state)
+		assert.False(t, frozen)
+
+		err, _ := RemoveFrozen(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeMaintenanceFrozen))
+	})
+
+	t.Run("not frozen on delete", func(t *testing.T) {
+		now = time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+		obj.DeletionTimestamp = &metav1.Time{Time: now}
+		defer func() {
+			obj.DeletionTimestamp = nil
+		}()
+
+		_, frozen := MaintenanceFrozenUntil(ctx, state)
+		assert.False(t, frozen)
+	})
+}
@@ -0,0 +1,105 @@
+package composed
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	NotificationTransitionReady   = "Ready"
+	NotificationTransitionError   = "Error"
+	NotificationTransitionDeleted = "Deleted"
+)
+
+// Notification describes the transition of the reconciled object to a terminal state
+type Notification struct {
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Transition  string            `json:"transition"`
+	Condition   *metav1.Condition `json:"condition,omitempty"`
+	ReconcileId string            `json:"reconcileId,omitempty"`
+	Time        metav1.Time       `json:"time"`
+}
+
+// Notifier delivers the notifications. Implementations must not block the caller, and
+// must handle the delivery failures on their own, since they can not fail the reconciliation.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification)
+}
+
+var notifier Notifier
+
+// SetNotifier sets the notifier used by the NotifyTransitions action. If never set, or set to nil,
+// no notifications are sent. Must be called on startup before any reconciler is started.
+func SetNotifier(n Notifier) {
+	notifier = n
+}
+
+// NotifyTransitions returns an action that runs the given action and notifies the configured Notifier
+// if the object transitioned to the Ready or Error condition during its run, or if its deletion was
+// completed by removing the finalizer. Must run after the object is loaded and wrap all the actions
+// that might change the object status. The result of the given action is returned unchanged.
+func NotifyTransitions(action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if notifier == nil || state.Obj() == nil || state.Obj().GetName() == "" {
+			return action(ctx, state)
+		}
+
+		before, _ := terminalCondition(state)
+		finalizersBefore := len(state.Obj().GetFinalizers())
+
+		err, resCtx := action(ctx, state)
+
+		var transition string
+		after, condition := terminalCondition(state)
+		if IsMarkedForDeletion(state.Obj()) && len(state.Obj().GetFinalizers()) < finalizersBefore {
+			transition = NotificationTransitionDeleted
+			condition = nil
+		} else if after != "" && after != before {
+			transition = after
+		}
+		if transition == "" {
+			return err, resCtx
+		}
+
+		kind := state.Obj().GetObjectKind().GroupVersionKind().Kind
+		if state.Cluster() != nil && state.Cluster().Scheme() != nil {
+			if gvk, gvkErr := apiutil.GVKForObject(state.Obj(), state.Cluster().Scheme()); gvkErr == nil {
+				kind = gvk.Kind
+			}
+		}
+
+		notifier.Notify(ctx, Notification{
+			Kind:        kind,
+			Name:        state.Obj().GetName(),
+			Namespace:   state.Obj().GetNamespace(),
+			Transition:  transition,
+			Condition:   condition,
+			ReconcileId: string(controller.ReconcileIDFromContext(ctx)),
+			Time:        metav1.NewTime(time.Now()),
+		})
+
+		return err, resCtx
+	}
+}
+
+// terminalCondition returns the type and a copy of the Ready or Error condition if it is true
+func terminalCondition(state State) (string, *metav1.Condition) {
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok {
+		return "", nil
+	}
+	for _, t := range []string{NotificationTransitionError, NotificationTransitionReady} {
+		if c := meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(t)); c != nil && c.Status == metav1.ConditionTrue {
+			cpy := *c
+			return t, &cpy
+		}
+	}
+	return "", nil
+}
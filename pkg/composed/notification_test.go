@@ -0,0 +1,146 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeNotifier struct {
+	notifications []Notification
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, notification Notification) {
+	n.notifications = append(n.notifications, notification)
+}
+
+type notifyTransitionsSuite struct {
+	suite.Suite
+	ctx      context.Context
+	notifier *fakeNotifier
+}
+
+func (suite *notifyTransitionsSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+	suite.notifier = &fakeNotifier{}
+	SetNotifier(suite.notifier)
+}
+
+func (suite *notifyTransitionsSuite) TearDownTest() {
+	SetNotifier(nil)
+}
+
+func (suite *notifyTransitionsSuite) newState(conditions ...metav1.Condition) State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis",
+		},
+		Status: cloudcontrolv1beta1.RedisInstanceStatus{
+			Conditions: conditions,
+		},
+	}
+	clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+}
+
+func setConditionAction(conditionType string) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		obj := state.Obj().(ObjWithConditions)
+		meta.RemoveStatusCondition(obj.Conditions(), cloudcontrolv1beta1.ConditionTypeReady)
+		meta.RemoveStatusCondition(obj.Conditions(), cloudcontrolv1beta1.ConditionTypeError)
+		meta.SetStatusCondition(obj.Conditions(), metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  conditionType,
+			Message: conditionType,
+		})
+		return StopAndForget, nil
+	}
+}
+
+func (suite *notifyTransitionsSuite) TestTransitionToReadyIsNotified() {
+	state := suite.newState()
+
+	err, _ := NotifyTransitions(setConditionAction(cloudcontrolv1beta1.ConditionTypeReady))(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err, "result of the wrapped action is returned")
+
+	assert.Len(suite.T(), suite.notifier.notifications, 1)
+	n := suite.notifier.notifications[0]
+	assert.Equal(suite.T(), "RedisInstance", n.Kind)
+	assert.Equal(suite.T(), "redis", n.Name)
+	assert.Equal(suite.T(), "default", n.Namespace)
+	assert.Equal(suite.T(), NotificationTransitionReady, n.Transition)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeReady, n.Condition.Type)
+}
+
+func (suite *notifyTransitionsSuite) TestUnchangedConditionIsNotNotified() {
+	state := suite.newState(metav1.Condition{
+		Type:   cloudcontrolv1beta1.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: cloudcontrolv1beta1.ConditionTypeReady,
+	})
+
+	_, _ = NotifyTransitions(setConditionAction(cloudcontrolv1beta1.ConditionTypeReady))(suite.ctx, state)
+
+	assert.Empty(suite.T(), suite.notifier.notifications)
+}
+
+func (suite *notifyTransitionsSuite) TestTransitionFromReadyToErrorIsNotified() {
+	state := suite.newState(metav1.Condition{
+		Type:   cloudcontrolv1beta1.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: cloudcontrolv1beta1.ConditionTypeReady,
+	})
+
+	_, _ = NotifyTransitions(setConditionAction(cloudcontrolv1beta1.ConditionTypeError))(suite.ctx, state)
+
+	assert.Len(suite.T(), suite.notifier.notifications, 1)
+	assert.Equal(suite.T(), NotificationTransitionError, suite.notifier.notifications[0].Transition)
+}
+
+func (suite *notifyTransitionsSuite) TestFinalizerRemovalOnDeleteIsNotified() {
+	state := suite.newState()
+	now := metav1.Now()
+	state.Obj().SetDeletionTimestamp(&now)
+	state.Obj().SetFinalizers([]string{cloudcontrolv1beta1.FinalizerName})
+
+	_, _ = NotifyTransitions(func(ctx context.Context, state State) (error, context.Context) {
+		state.Obj().SetFinalizers(nil)
+		return StopAndForget, nil
+	})(suite.ctx, state)
+
+	assert.Len(suite.T(), suite.notifier.notifications, 1)
+	assert.Equal(suite.T(), NotificationTransitionDeleted, suite.notifier.notifications[0].Transition)
+	assert.Nil(suite.T(), suite.notifier.notifications[0].Condition)
+}
+
+func (suite *notifyTransitionsSuite) TestNothingIsNotifiedWithoutNotifier() {
+	SetNotifier(nil)
+	state := suite.newState()
+
+	err, _ := NotifyTransitions(setConditionAction(cloudcontrolv1beta1.ConditionTypeReady))(suite.ctx, state)
+
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.Empty(suite.T(), suite.notifier.notifications)
+}
+
+func TestNotifyTransitions(t *testing.T) {
+	suite.Run(t, new(notifyTransitionsSuite))
+}
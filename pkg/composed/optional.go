@@ -0,0 +1,147 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OptionalRetryDelay is the requeue delay of the reconciliation retrying the failed optional actions
+var OptionalRetryDelay = 5 * time.Minute
+
+type optionalRetryKey struct{}
+
+// optionalConditionTypes are the condition types set by the Optional actions. They are kept by the status
+// updates removing the other conditions, like the exclusive Ready, until the Optional action removes them.
+var optionalConditionTypes sync.Map
+
+// isOptionalConditionType returns true if the condition type written in the status is set by an Optional action
+func isOptionalConditionType(conditionType string) bool {
+	found := false
+	optionalConditionTypes.Range(func(key, _ any) bool {
+		found = PublicConditionType(key.(string)) == conditionType
+		return !found
+	})
+	return found
+}
+
+// Optional returns an Action running the action of an optional feature, like the flow logs, DNS record
+// or backup, so its failure does not keep the resource from getting Ready. The failure, an error returned
+// by the action or the Error condition it set, is downgraded to the condition of the given type and reason,
+// the object state and other conditions are restored to the ones before the action, and the flow continues.
+// The condition is removed once the action succeeds. The reason should be registered with the Warning severity.
+// The reconciliation of the failed, or still waiting, optional action is retried after OptionalRetryDelay,
+// or the delay the action requested, even if the rest of the flow stops and forgets the object.
+// The condition is not removed by the status updates setting the exclusive or keeping only the listed conditions.
+func Optional(conditionType, reason string, action Action) Action {
+	optionalConditionTypes.Store(conditionType, struct{}{})
+	return func(ctx context.Context, state State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return action(ctx, state)
+		}
+		objWithState, hasState := obj.(ObjWithConditionsAndState)
+		var stateBefore string
+		if hasState {
+			stateBefore = objWithState.State()
+		}
+		conditionsBefore := append([]metav1.Condition(nil), *obj.Conditions()...)
+
+		err, nextCtx := action(ctx, state)
+		if nextCtx == nil {
+			nextCtx = ctx
+		}
+
+		message, failed := optionalFailure(err, conditionsBefore, *obj.Conditions())
+		if !failed {
+			if err != nil && !IsStopAndForget(err) {
+				// still in progress, like waiting for the cloud resource
+				return nil, withOptionalRetry(nextCtx, err)
+			}
+			if meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(conditionType)) == nil {
+				return nil, nextCtx
+			}
+			LoggerFromCtx(ctx).
+				WithValues("conditionType", conditionType).
+				Info("Optional feature recovered")
+			res, _ := UpdateStatus(obj).
+				RemoveConditions(conditionType).
+				ErrorLogMessage("Error updating status after optional feature recovered").
+				SuccessErrorNil().
+				Run(nextCtx, state)
+			return res, nextCtx
+		}
+
+		LoggerFromCtx(ctx).
+			WithValues(
+				"conditionType", conditionType,
+				"reason", reason,
+			).
+			Info("Optional feature failed: " + message)
+
+		*obj.Conditions() = conditionsBefore
+		if hasState {
+			objWithState.SetState(stateBefore)
+		}
+		res, _ := UpdateStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    conditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}).
+			ErrorLogMessage("Error updating status with failed optional feature").
+			SuccessErrorNil().
+			Run(nextCtx, state)
+		return res, withOptionalRetry(nextCtx, err)
+	}
+}
+
+// optionalFailure returns the message of the optional action failure, if it returned a non flow control
+// error, or set or changed the Error condition
+func optionalFailure(err error, conditionsBefore, conditionsAfter []metav1.Condition) (string, bool) {
+	after := meta.FindStatusCondition(conditionsAfter, PublicConditionType(conditionTypeError))
+	if after != nil && after.Status == metav1.ConditionTrue {
+		before := meta.FindStatusCondition(conditionsBefore, PublicConditionType(conditionTypeError))
+		if before == nil || before.Status != after.Status || before.Reason != after.Reason || before.Message != after.Message {
+			_, message := ConditionSeverityFromMessage(after.Message)
+			return message, true
+		}
+	}
+	if err != nil && !IsFlowControl(err) {
+		return err.Error(), true
+	}
+	return "", false
+}
+
+// withOptionalRetry records in the context the retry of the optional action, keeping the earliest
+// of the already recorded and the delay requested by the action result
+func withOptionalRetry(ctx context.Context, err error) context.Context {
+	delay := OptionalRetryDelay
+	var requeueDelay *stopWithRequeueDelay
+	if errors.As(err, &requeueDelay) && requeueDelay.Delay() < delay {
+		delay = requeueDelay.Delay()
+	}
+	return withRetryDelay(ctx, delay)
+}
+
+// withRetryDelay records in the context the delay the reconciliation is retried with even if the flow
+// stops and forgets the object, keeping the earliest of the already recorded and the given delay
+func withRetryDelay(ctx context.Context, delay time.Duration) context.Context {
+	if recorded, ok := optionalRetryDelay(ctx); ok && recorded < delay {
+		return ctx
+	}
+	return context.WithValue(ctx, optionalRetryKey{}, delay)
+}
+
+func optionalRetryDelay(ctx context.Context) (time.Duration, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	delay, ok := ctx.Value(optionalRetryKey{}).(time.Duration)
+	return delay, ok
+}
@@ -0,0 +1,200 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newOptionalTestState() (State, *cloudcontrolv1beta1.IpRange) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "iprange"},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), obj
+}
+
+func TestOptional(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	const (
+		conditionType = "FlowLogsConfigurationFailed"
+		reason        = "FlowLogsConfigurationFailed"
+	)
+
+	setReady := func(ctx context.Context, state State) (error, context.Context) {
+		obj := state.Obj().(*cloudcontrolv1beta1.IpRange)
+		obj.Status.State = cloudcontrolv1beta1.ReadyState
+		return UpdateStatus(obj).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReady,
+				Message: "Ready",
+			}).
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	failWithErrorCondition := func(ctx context.Context, state State) (error, context.Context) {
+		obj := state.Obj().(*cloudcontrolv1beta1.IpRange)
+		obj.Status.State = cloudcontrolv1beta1.ErrorState
+		return UpdateStatus(obj).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  "FlowLogsFailed",
+				Message: "Access denied",
+			}).
+			SuccessError(StopWithRequeueDelay(time.Minute)).
+			Run(ctx, state)
+	}
+
+	run := func(state State, optionalAction Action) (ctrl.Result, error) {
+		return Handle(ComposeActions(
+			"test",
+			setReady,
+			Optional(conditionType, reason, optionalAction),
+			StopAndForgetAction,
+		)(ctx, state))
+	}
+
+	assertReady := func(t *testing.T, obj *cloudcontrolv1beta1.IpRange) {
+		cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+		assert.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+		assert.Equal(t, cloudcontrolv1beta1.ReadyState, obj.Status.State)
+	}
+
+	t.Run("failure setting Error condition is downgraded and Ready stays True", func(t *testing.T) {
+		state, obj := newOptionalTestState()
+
+		res, err := run(state, failWithErrorCondition)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, res, "retried with the delay requested by the action")
+		assertReady(t, obj)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, conditionType)
+		assert.NotNil(t, cond)
+		assert.Equal(t, reason, cond.Reason)
+		assert.Equal(t, "Access denied", cond.Message)
+	})
+
+	t.Run("returned error is downgraded and retried", func(t *testing.T) {
+		state, obj := newOptionalTestState()
+
+		res, err := run(state, func(ctx context.Context, state State) (error, context.Context) {
+			return errors.New("dns zone not found"), nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: OptionalRetryDelay}, res)
+		assertReady(t, obj)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, conditionType)
+		assert.NotNil(t, cond)
+		assert.Equal(t, "dns zone not found", cond.Message)
+	})
+
+	t.Run("recovery removes the condition", func(t *testing.T) {
+		state, obj := newOptionalTestState()
+
+		_, _ = run(state, failWithErrorCondition)
+		assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, conditionType))
+
+		res, err := run(state, func(ctx context.Context, state State) (error, context.Context) {
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assertReady(t, obj)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, conditionType))
+	})
+
+	t.Run("condition is kept by the exclusive Ready of the next reconciliation", func(t *testing.T) {
+		state, obj := newOptionalTestState()
+
+		_, _ = run(state, failWithErrorCondition)
+		assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, conditionType))
+
+		// the optional action is still retrying when the Ready condition is set exclusively again
+		obj.Status.Conditions = []metav1.Condition{*meta.FindStatusCondition(obj.Status.Conditions, conditionType)}
+		res, err := run(state, func(ctx context.Context, state State) (error, context.Context) {
+			return StopWithRequeue, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: OptionalRetryDelay}, res)
+		assertReady(t, obj)
+		cond := meta.FindStatusCondition(obj.Status.Conditions, conditionType)
+		assert.NotNil(t, cond)
+		assert.Equal(t, "Access denied", cond.Message)
+	})
+
+	t.Run("waiting action does not block the flow", func(t *testing.T) {
+		state, obj := newOptionalTestState()
+
+		res, err := run(state, func(ctx context.Context, state State) (error, context.Context) {
+			return StopWithRequeue, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: OptionalRetryDelay}, res)
+		assertReady(t, obj)
+		assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, conditionType))
+	})
+}
+
+func TestOptionalConditionIsKeptByKeepConditions(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	const conditionType = "BackupPlanConfigurationFailed"
+
+	Optional(conditionType, conditionType, func(ctx context.Context, state State) (error, context.Context) {
+		return nil, nil
+	})
+
+	state, obj := newOptionalTestState()
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: conditionType, Status: metav1.ConditionTrue, Reason: conditionType, Message: "Access denied"},
+		{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other", Message: "Other"},
+	}
+
+	err, _ := UpdateStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReady,
+			Message: "Ready",
+		}).
+		KeepConditions(cloudcontrolv1beta1.ConditionTypeReady).
+		SuccessErrorNil().
+		Run(ctx, state)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, conditionType))
+	assert.Nil(t, meta.FindStatusCondition(obj.Status.Conditions, "Other"))
+	assert.NotNil(t, meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+}
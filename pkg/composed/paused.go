@@ -0,0 +1,159 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ConditionTypePartiallyPaused = "PartiallyPaused"
+	ReasonPartiallyPaused        = "PartiallyPaused"
+
+	ConditionTypeInvalidPauseAnnotation = "InvalidPauseAnnotation"
+	ReasonInvalidPauseAnnotation        = "InvalidPauseAnnotation"
+
+	// AnnotationPausedUntil pauses the reconciliation of the object until the RFC3339 time it holds
+	AnnotationPausedUntil = "cloud-manager.kyma-project.io/paused-until"
+)
+
+// ObjWithPausedSubReconcilers is implemented by objects that can pause some of their sub-reconcilers,
+// for example scaling or parameters, while the others keep reconciling
+type ObjWithPausedSubReconcilers interface {
+	PausedSubReconcilers() []string
+}
+
+// IsSubReconcilerPaused returns true if the state object pauses the sub-reconciler with the given name
+func IsSubReconcilerPaused(state State, name string) bool {
+	obj, ok := state.Obj().(ObjWithPausedSubReconcilers)
+	if !ok {
+		return false
+	}
+	return pie.Contains(obj.PausedSubReconcilers(), name)
+}
+
+// IfNotPaused returns an action that runs the given action only if the sub-reconciler
+// with the given name is not listed as paused by the state object
+func IfNotPaused(name string, action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if IsSubReconcilerPaused(state, name) {
+			LoggerFromCtx(ctx).
+				WithValues(
+					"subReconciler", name,
+					"targetAction", findActionName(action),
+				).
+				Info("Skipping paused sub-reconciler")
+			return nil, nil
+		}
+		return action(ctx, state)
+	}
+}
+
+// UpdatePartiallyPausedCondition sets the PartiallyPaused condition listing the paused sub-reconcilers
+// of the state object, or removes the condition if none is paused
+func UpdatePartiallyPausedCondition(ctx context.Context, state State) (error, context.Context) {
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok {
+		return nil, nil
+	}
+	objPaused, ok := state.Obj().(ObjWithPausedSubReconcilers)
+	if !ok {
+		return nil, nil
+	}
+
+	paused := pie.Sort(pie.Unique(objPaused.PausedSubReconcilers()))
+	if len(paused) == 0 {
+		if meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypePartiallyPaused)) == nil {
+			return nil, nil
+		}
+		return UpdateStatus(obj).
+			RemoveConditions(ConditionTypePartiallyPaused).
+			ErrorLogMessage("Error updating status after removing PartiallyPaused condition").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionTypePartiallyPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonPartiallyPaused,
+		Message: fmt.Sprintf("Paused sub-reconcilers: %s", strings.Join(paused, ", ")),
+	}
+	if !AnyConditionChanged(obj, condition) {
+		return nil, nil
+	}
+
+	return UpdateStatus(obj).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating status with PartiallyPaused condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// PausedUntil stops the reconciliation of the object annotated with AnnotationPausedUntil while the
+// annotation time is in the future, requeuing it for when the time passes so the reconciliation
+// auto-resumes without the annotation being removed. If the annotation is not a valid RFC3339 time
+// the object is not paused, and the InvalidPauseAnnotation condition is set until the annotation
+// is fixed or removed. Objects marked for deletion are never paused. Must run after the object is loaded.
+func PausedUntil(ctx context.Context, state State) (error, context.Context) {
+	if state.Obj() == nil || state.Obj().GetName() == "" || IsMarkedForDeletion(state.Obj()) {
+		return nil, nil
+	}
+
+	value, annotated := state.Obj().GetAnnotations()[AnnotationPausedUntil]
+	var until time.Time
+	var parseErr error
+	if annotated {
+		until, parseErr = time.Parse(time.RFC3339, value)
+	}
+
+	if annotated && parseErr == nil {
+		if remaining := time.Until(until); remaining > 0 {
+			LoggerFromCtx(ctx).
+				WithValues("pausedUntil", value).
+				Info("Reconciliation paused")
+			return StopWithRequeueDelay(remaining), nil
+		}
+	}
+
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok {
+		return nil, nil
+	}
+
+	if parseErr == nil {
+		if meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeInvalidPauseAnnotation)) == nil {
+			return nil, nil
+		}
+		return UpdateStatus(obj).
+			RemoveConditions(ConditionTypeInvalidPauseAnnotation).
+			ErrorLogMessage("Error updating status after removing InvalidPauseAnnotation condition").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionTypeInvalidPauseAnnotation,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonInvalidPauseAnnotation,
+		Message: fmt.Sprintf("Annotation %s value %q is not a valid RFC3339 time, reconciliation is not paused", AnnotationPausedUntil, value),
+	}
+	if !AnyConditionChanged(obj, condition) {
+		return nil, nil
+	}
+
+	LoggerFromCtx(ctx).
+		WithValues("pausedUntil", value).
+		Info("Invalid pause annotation, not pausing")
+
+	return UpdateStatus(obj).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating status with InvalidPauseAnnotation condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
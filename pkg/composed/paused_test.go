@@ -0,0 +1,115 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type pausedSuite struct {
+	testStateSuite
+}
+
+func (suite *pausedSuite) newState(paused ...string) State {
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Paused: paused,
+		},
+	}
+	return newTestState(obj)
+}
+
+func (suite *pausedSuite) TestPausedActionIsSkipped() {
+	state := suite.newState("scaling")
+	called := false
+	action := func(ctx context.Context, state State) (error, context.Context) {
+		called = true
+		return nil, nil
+	}
+
+	err, _ := IfNotPaused("scaling", action)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), called)
+
+	err, _ = IfNotPaused("parameters", action)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), called)
+}
+
+func (suite *pausedSuite) TestPartiallyPausedConditionIsSetAndRemoved() {
+	state := suite.newState("scaling", "parameters")
+	obj := state.Obj().(*cloudcontrolv1beta1.RedisInstance)
+
+	err, _ := UpdatePartiallyPausedCondition(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	cond := meta.FindStatusCondition(obj.Status.Conditions, ConditionTypePartiallyPaused)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), "Paused sub-reconcilers: parameters, scaling", cond.Message)
+
+	obj.Spec.Paused = nil
+	err, _ = UpdatePartiallyPausedCondition(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, ConditionTypePartiallyPaused))
+}
+
+func (suite *pausedSuite) newPausedUntilState(pausedUntil string) (State, *cloudcontrolv1beta1.RedisInstance) {
+	state := suite.newState()
+	obj := state.Obj().(*cloudcontrolv1beta1.RedisInstance)
+	obj.Annotations = map[string]string{AnnotationPausedUntil: pausedUntil}
+	return state, obj
+}
+
+func (suite *pausedSuite) TestPausedUntilFutureRequeuesAtDeadline() {
+	state, _ := suite.newPausedUntilState(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	err, _ := PausedUntil(suite.ctx, state)
+	assert.True(suite.T(), IsStopWithRequeueDelay(err))
+	var delayErr *stopWithRequeueDelay
+	assert.True(suite.T(), errors.As(err, &delayErr))
+	assert.Greater(suite.T(), delayErr.Delay(), 59*time.Minute)
+	assert.LessOrEqual(suite.T(), delayErr.Delay(), time.Hour)
+}
+
+func (suite *pausedSuite) TestPausedUntilPastResumes() {
+	state, obj := suite.newPausedUntilState(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))
+
+	err, _ := PausedUntil(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeInvalidPauseAnnotation))
+}
+
+func (suite *pausedSuite) TestPausedUntilInvalidDoesNotPauseAndSetsCondition() {
+	state, obj := suite.newPausedUntilState("tomorrow")
+
+	err, _ := PausedUntil(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	cond := meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeInvalidPauseAnnotation)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+	assert.Equal(suite.T(), ReasonInvalidPauseAnnotation, cond.Reason)
+
+	delete(obj.Annotations, AnnotationPausedUntil)
+	err, _ = PausedUntil(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeInvalidPauseAnnotation))
+}
+
+func (suite *pausedSuite) TestPausedUntilDoesNotPauseDeletion() {
+	state, obj := suite.newPausedUntilState(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	obj.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	err, _ := PausedUntil(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestPaused(t *testing.T) {
+	suite.Run(t, new(pausedSuite))
+}
@@ -0,0 +1,115 @@
+package composed
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PollInterval is the adaptive requeue delay of the action waiting for a cloud resource to stabilize.
+// The first poll is delayed by Initial, and each next one by the double of the previous delay, up to Max.
+type PollInterval struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// pollIntervals are the configured poll intervals by resource type, overriding the defaults of the wait actions
+var pollIntervals = map[string]PollInterval{}
+
+// pollAttempts is the number of the consecutive polls of the object per resource type
+var pollAttempts = struct {
+	sync.Mutex
+	m map[string]int
+}{m: map[string]int{}}
+
+// ParsePollIntervals parses the comma separated list of resourceType=initial:max pairs, for example
+// AwsRedisInstance=10s:5m. If max is omitted the interval is fixed to the initial value.
+func ParsePollIntervals(s string) (map[string]PollInterval, error) {
+	result := map[string]PollInterval{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		resourceType, value, ok := strings.Cut(pair, "=")
+		resourceType = strings.TrimSpace(resourceType)
+		if !ok || len(resourceType) == 0 {
+			return nil, fmt.Errorf("invalid poll interval %q, expected resourceType=initial:max", pair)
+		}
+		initialValue, maxValue, hasMax := strings.Cut(value, ":")
+		initial, err := time.ParseDuration(strings.TrimSpace(initialValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid poll interval %q: %w", pair, err)
+		}
+		maxInterval := initial
+		if hasMax {
+			maxInterval, err = time.ParseDuration(strings.TrimSpace(maxValue))
+			if err != nil {
+				return nil, fmt.Errorf("invalid poll interval %q: %w", pair, err)
+			}
+		}
+		if initial <= 0 || maxInterval < initial {
+			return nil, fmt.Errorf("invalid poll interval %q, initial must be positive and not greater than max", pair)
+		}
+		if _, exists := result[resourceType]; exists {
+			return nil, fmt.Errorf("poll interval of %s is specified more than once", resourceType)
+		}
+		result[resourceType] = PollInterval{Initial: initial, Max: maxInterval}
+	}
+	return result, nil
+}
+
+// SetPollIntervals sets the poll intervals by resource type. Must be called before the reconcilers are started.
+func SetPollIntervals(m map[string]PollInterval) {
+	result := make(map[string]PollInterval, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	pollIntervals = result
+}
+
+// GetPollInterval returns the configured poll interval of the resource type, or the given default one
+func GetPollInterval(resourceType string, defaultInterval PollInterval) PollInterval {
+	if interval, ok := pollIntervals[resourceType]; ok {
+		return interval
+	}
+	return defaultInterval
+}
+
+func pollAttemptsKey(state State, resourceType string) string {
+	return fmt.Sprintf("%s/%s", resourceType, state.Name())
+}
+
+// PollDelay returns the delay of the next poll of the state object resource, and lengthens the delay
+// of the poll after it, so the slow resources are polled less often the longer they take
+func PollDelay(state State, resourceType string, defaultInterval PollInterval) time.Duration {
+	interval := GetPollInterval(resourceType, defaultInterval)
+	key := pollAttemptsKey(state, resourceType)
+
+	pollAttempts.Lock()
+	attempt := pollAttempts.m[key]
+	pollAttempts.m[key] = attempt + 1
+	pollAttempts.Unlock()
+
+	delay := interval.Initial
+	for i := 0; i < attempt && delay < interval.Max; i++ {
+		delay *= 2
+	}
+	if delay > interval.Max {
+		delay = interval.Max
+	}
+	return delay
+}
+
+// StopWithPollRequeue is the flow control error requeueing the waiting object with its PollDelay
+func StopWithPollRequeue(state State, resourceType string, defaultInterval PollInterval) error {
+	return StopWithRequeueDelay(PollDelay(state, resourceType, defaultInterval))
+}
+
+// ResetPoll resets the adaptive delay once the resource stabilized, so the next wait starts short again
+func ResetPoll(state State, resourceType string) {
+	pollAttempts.Lock()
+	defer pollAttempts.Unlock()
+	delete(pollAttempts.m, pollAttemptsKey(state, resourceType))
+}
@@ -0,0 +1,84 @@
+package composed
+
+import (
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type pollIntervalSuite struct {
+	suite.Suite
+}
+
+func (suite *pollIntervalSuite) TearDownTest() {
+	SetPollIntervals(nil)
+}
+
+func (suite *pollIntervalSuite) newState(name string) State {
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: name},
+	}
+	return NewStateFactory(NewStateCluster(nil, nil, nil, nil)).
+		NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+}
+
+func (suite *pollIntervalSuite) TestParse() {
+	m, err := ParsePollIntervals(" AwsRedisInstance=10s:5m, AwsEfs=2s ,")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]PollInterval{
+		"AwsRedisInstance": {Initial: 10 * time.Second, Max: 5 * time.Minute},
+		"AwsEfs":           {Initial: 2 * time.Second, Max: 2 * time.Second},
+	}, m)
+
+	m, err = ParsePollIntervals("")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), m)
+
+	for _, invalid := range []string{"AwsEfs", "=1s", "AwsEfs=abc", "AwsEfs=1s:xyz", "AwsEfs=0s", "AwsEfs=1m:10s", "AwsEfs=1s,AwsEfs=2s"} {
+		_, err = ParsePollIntervals(invalid)
+		assert.Error(suite.T(), err, invalid)
+	}
+}
+
+func (suite *pollIntervalSuite) TestIntervalIsReadFromConfig() {
+	defaultInterval := PollInterval{Initial: time.Second, Max: time.Minute}
+	assert.Equal(suite.T(), defaultInterval, GetPollInterval("AwsEfs", defaultInterval))
+
+	SetPollIntervals(map[string]PollInterval{"AwsEfs": {Initial: 5 * time.Second, Max: 20 * time.Second}})
+	assert.Equal(suite.T(), PollInterval{Initial: 5 * time.Second, Max: 20 * time.Second}, GetPollInterval("AwsEfs", defaultInterval))
+	assert.Equal(suite.T(), defaultInterval, GetPollInterval("AwsRedisInstance", defaultInterval))
+
+	state := suite.newState("configured")
+	defer ResetPoll(state, "AwsEfs")
+	assert.Equal(suite.T(), StopWithRequeueDelay(5*time.Second), StopWithPollRequeue(state, "AwsEfs", defaultInterval))
+}
+
+func (suite *pollIntervalSuite) TestBackoffAndReset() {
+	SetPollIntervals(map[string]PollInterval{"AwsRedisInstance": {Initial: 10 * time.Second, Max: 35 * time.Second}})
+	defaultInterval := PollInterval{Initial: time.Second, Max: time.Minute}
+	state := suite.newState("backoff")
+	other := suite.newState("other")
+	defer ResetPoll(state, "AwsRedisInstance")
+	defer ResetPoll(other, "AwsRedisInstance")
+
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		delays = append(delays, PollDelay(state, "AwsRedisInstance", defaultInterval))
+	}
+	assert.Equal(suite.T(), []time.Duration{10 * time.Second, 20 * time.Second, 35 * time.Second, 35 * time.Second}, delays)
+
+	// attempts are counted per object
+	assert.Equal(suite.T(), 10*time.Second, PollDelay(other, "AwsRedisInstance", defaultInterval))
+
+	ResetPoll(state, "AwsRedisInstance")
+	assert.Equal(suite.T(), 10*time.Second, PollDelay(state, "AwsRedisInstance", defaultInterval))
+}
+
+func TestPollInterval(t *testing.T) {
+	suite.Run(t, new(pollIntervalSuite))
+}
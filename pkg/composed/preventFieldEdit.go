@@ -0,0 +1,137 @@
+package composed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionTypeImmutableFieldEdited = "ImmutableFieldEdited"
+	ReasonImmutableFieldEdited        = "ImmutableFieldEdited"
+
+	// immutableFieldAnnotationPrefix is the prefix of the annotation holding the snapshot
+	// of the immutable field value taken by PreventFieldEdit
+	immutableFieldAnnotationPrefix = "cloud-manager.kyma-project.io/immutable-"
+)
+
+// PreventFieldEdit returns an action that rejects changes of the field identified by fieldPath,
+// for example "spec.cidr". On the first run it stores the value returned by the getter in an
+// annotation on the object, and on every subsequent run it compares the current value with
+// that snapshot. A zero value returned by the getter is considered as not yet set.
+func PreventFieldEdit(fieldPath string, getter func(State) any) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		current := getter(state)
+		if isZeroValue(current) {
+			return nil, nil
+		}
+
+		annotationKey := immutableFieldAnnotationPrefix + fieldPath
+		snapshot, ok := state.Obj().GetAnnotations()[annotationKey]
+		if !ok {
+			b, err := json.Marshal(current)
+			if err != nil {
+				return LogErrorAndReturn(err, fmt.Sprintf("Error serializing immutable field %s", fieldPath), StopAndForget, ctx)
+			}
+			err = patchObjAddJsonAnnotation(ctx, annotationKey, string(b), state.Obj(), state.Cluster().K8sClient())
+			if err != nil {
+				return LogErrorAndReturn(err, fmt.Sprintf("Error patching immutable field %s snapshot annotation", fieldPath), StopWithRequeue, ctx)
+			}
+			return nil, nil
+		}
+
+		return PreventFieldEditWithOriginal(fieldPath, getter, func(_ State) any {
+			original := reflect.New(reflect.TypeOf(current))
+			if err := json.Unmarshal([]byte(snapshot), original.Interface()); err != nil {
+				return nil
+			}
+			return original.Elem().Interface()
+		})(ctx, state)
+	}
+}
+
+// PreventFieldEditWithOriginal returns an action that rejects changes of the field identified by fieldPath
+// when the value returned by the getter differs from the original value, for example one already
+// copied to the status. A zero value returned by either function is considered as not yet set.
+// On edit, the exclusive ImmutableFieldEdited condition naming the field is set and the object
+// is forgotten until the original value is restored.
+func PreventFieldEditWithOriginal(fieldPath string, getter func(State) any, original func(State) any) Action {
+	return PreventFieldEditWithCondition(fieldPath, getter, original, ConditionTypeImmutableFieldEdited, ReasonImmutableFieldEdited)
+}
+
+// PreventFieldEditWithCondition is the PreventFieldEditWithOriginal setting the condition of the given type
+// and reason on edit, for the objects already reporting the edit of the field with their own condition.
+func PreventFieldEditWithCondition(fieldPath string, getter func(State) any, original func(State) any, conditionType, reason string) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		current := getter(state)
+		if isZeroValue(current) {
+			return nil, nil
+		}
+		originalValue := original(state)
+		if isZeroValue(originalValue) {
+			return nil, nil
+		}
+
+		if reflect.DeepEqual(current, originalValue) {
+			return nil, nil
+		}
+
+		logger := LoggerFromCtx(ctx)
+		logger.
+			WithValues(
+				"field", fieldPath,
+				"originalValue", originalValue,
+				"currentValue", current,
+			).
+			Info("Immutable field edited")
+
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return StopAndForget, nil
+		}
+
+		condition := metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: fmt.Sprintf("Field %s can not change from %v to %v", fieldPath, originalValue, current),
+		}
+		if !AnyConditionChanged(obj, condition) {
+			return StopAndForget, nil
+		}
+
+		return PatchStatus(obj).
+			SetExclusiveConditions(condition).
+			ErrorLogMessage(fmt.Sprintf("Error patching status after immutable field %s edited", fieldPath)).
+			SuccessLogMsg(fmt.Sprintf("Forgetting object with edited immutable field %s", fieldPath)).
+			Run(ctx, state)
+	}
+}
+
+func isZeroValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+func patchObjAddJsonAnnotation(ctx context.Context, k, v string, obj client.Object, clnt client.Client) error {
+	if obj.GetAnnotations() == nil {
+		obj.SetAnnotations(map[string]string{})
+	}
+	obj.GetAnnotations()[k] = v
+	p, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{k: v},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return clnt.Patch(ctx, obj, client.RawPatch(types.MergePatchType, p))
+}
@@ -0,0 +1,82 @@
+package composed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type preventFieldEditSuite struct {
+	testStateSuite
+}
+
+func (suite *preventFieldEditSuite) newState(cm *corev1.ConfigMap) State {
+	return newTestState(cm)
+}
+
+func configMapValueGetter(st State) any {
+	return st.Obj().(*corev1.ConfigMap).Data["value"]
+}
+
+func (suite *preventFieldEditSuite) TestSnapshotIsTakenOnFirstRun() {
+	state := suite.newState(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"},
+		Data:       map[string]string{"value": "one"},
+	})
+
+	err, _ := PreventFieldEdit("data.value", configMapValueGetter)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Nil(suite.T(), state.LoadObj(suite.ctx))
+	assert.Equal(suite.T(), `"one"`, state.Obj().GetAnnotations()[immutableFieldAnnotationPrefix+"data.value"])
+
+	err, _ = PreventFieldEdit("data.value", configMapValueGetter)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *preventFieldEditSuite) TestZeroValueIsNotSnapshotted() {
+	state := suite.newState(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"},
+	})
+
+	err, _ := PreventFieldEdit("data.value", configMapValueGetter)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Nil(suite.T(), state.LoadObj(suite.ctx))
+	assert.NotContains(suite.T(), state.Obj().GetAnnotations(), immutableFieldAnnotationPrefix+"data.value")
+}
+
+func (suite *preventFieldEditSuite) TestEditIsRejected() {
+	state := suite.newState(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "cm",
+			Annotations: map[string]string{
+				immutableFieldAnnotationPrefix + "data.value": `"one"`,
+			},
+		},
+		Data: map[string]string{"value": "two"},
+	})
+
+	err, _ := PreventFieldEdit("data.value", configMapValueGetter)(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+}
+
+func (suite *preventFieldEditSuite) TestOriginalNotSetIsIgnored() {
+	state := suite.newState(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"},
+		Data:       map[string]string{"value": "two"},
+	})
+
+	err, _ := PreventFieldEditWithOriginal("data.value", configMapValueGetter, func(_ State) any {
+		return ""
+	})(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestPreventFieldEdit(t *testing.T) {
+	suite.Run(t, new(preventFieldEditSuite))
+}
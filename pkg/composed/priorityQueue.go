@@ -0,0 +1,469 @@
+package composed
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DeletionWorkPriority is the priority of the objects marked for deletion, so they are dequeued
+// before the objects that are created or updated
+const DeletionWorkPriority = 1000
+
+// priorityQueueEnabled tells if the controllers use the priority queue instead of the default work queue
+var priorityQueueEnabled = false
+
+// SetPriorityQueueEnabled sets if the controllers use the priority queue. Must be called before the
+// reconcilers are set up.
+func SetPriorityQueueEnabled(enabled bool) {
+	priorityQueueEnabled = enabled
+}
+
+// WorkClassifier returns the priority of the queued item, higher priority is dequeued first
+type WorkClassifier func(item interface{}) int
+
+// NewWorkClassifier returns the WorkClassifier of the reconcile requests of the given object kind.
+// The priority is the DeletionWorkPriority if the object read from the reader, what should be the
+// cached manager client, is marked for deletion, and zero otherwise. Each controller has its own
+// queue, so only the requests of the same kind are ordered.
+func NewWorkClassifier(reader client.Reader, obj client.Object) WorkClassifier {
+	return func(item interface{}) int {
+		req, ok := item.(reconcile.Request)
+		if !ok {
+			return 0
+		}
+		o := obj.DeepCopyObject().(client.Object)
+		if err := reader.Get(context.Background(), req.NamespacedName, o); err != nil {
+			return 0
+		}
+		if IsMarkedForDeletion(o) {
+			return DeletionWorkPriority
+		}
+		return 0
+	}
+}
+
+// NewPriorityControllerOptions returns the controller options with the ReconcileConcurrency of the given
+// object kind, and if enabled with SetPriorityQueueEnabled, the priority queue classifying its reconcile requests
+func NewPriorityControllerOptions(reader client.Reader, scheme *runtime.Scheme, obj client.Object) (controller.Options, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return controller.Options{}, err
+	}
+	opts := controller.Options{
+		MaxConcurrentReconciles: ReconcileConcurrency(gvk.Kind),
+	}
+	if !priorityQueueEnabled {
+		return opts, nil
+	}
+	classifier := NewWorkClassifier(reader, obj)
+	opts.NewQueue = func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+		return NewPriorityQueue(controllerName, classifier, rateLimiter)
+	}
+	return opts, nil
+}
+
+// priorityQueue is the rate limiting work queue dequeuing the items with the higher priority first,
+// and the items with the same priority in the order they were added. Like the client-go work queue,
+// an item is queued only once, an item added while being processed is queued again once it is done,
+// and an item added with a delay is waiting only once, with the earliest of the delays.
+type priorityQueue struct {
+	cond        *sync.Cond
+	classifier  WorkClassifier
+	rateLimiter ratelimiter.RateLimiter
+	metrics     *priorityQueueMetrics
+
+	items  priorityItems
+	queued map[interface{}]*priorityItem
+	// processing are the items being processed, with the time they were dequeued
+	processing map[interface{}]time.Time
+	// requeued are the items added while processing, with their priority
+	requeued map[interface{}]int
+	// waiting are the items added with a delay, with the time they are added
+	waiting map[interface{}]time.Time
+
+	seq          uint64
+	shuttingDown bool
+	stopCh       chan struct{}
+}
+
+var _ workqueue.RateLimitingInterface = &priorityQueue{}
+
+// NewPriorityQueue returns the priority queue, with the client-go work queue metrics labeled with the
+// given name, or without metrics if the name is empty
+func NewPriorityQueue(name string, classifier WorkClassifier, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+	q := &priorityQueue{
+		cond:        sync.NewCond(&sync.Mutex{}),
+		classifier:  classifier,
+		rateLimiter: rateLimiter,
+		metrics:     newPriorityQueueMetrics(name),
+		queued:      map[interface{}]*priorityItem{},
+		processing:  map[interface{}]time.Time{},
+		requeued:    map[interface{}]int{},
+		waiting:     map[interface{}]time.Time{},
+		stopCh:      make(chan struct{}),
+	}
+	if q.metrics != nil {
+		go q.updateUnfinishedWorkLoop()
+	}
+	return q
+}
+
+func (q *priorityQueue) Add(item interface{}) {
+	// classified before locking, since the classifier may read the object
+	priority := q.classifier(item)
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if existing, ok := q.queued[item]; ok {
+		if priority > existing.priority {
+			existing.priority = priority
+			heap.Fix(&q.items, existing.index)
+		}
+		return
+	}
+	q.metrics.add()
+	if _, ok := q.processing[item]; ok {
+		if current, ok := q.requeued[item]; !ok || priority > current {
+			q.requeued[item] = priority
+		}
+		return
+	}
+	q.push(item, priority)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) push(item interface{}, priority int) {
+	q.seq++
+	pi := &priorityItem{item: item, priority: priority, seq: q.seq, added: time.Now()}
+	heap.Push(&q.items, pi)
+	q.queued[item] = pi
+	q.metrics.setDepth(len(q.items))
+}
+
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.items)
+}
+
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.items) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return nil, true
+	}
+	pi := heap.Pop(&q.items).(*priorityItem)
+	delete(q.queued, pi.item)
+	now := time.Now()
+	q.processing[pi.item] = now
+	q.metrics.setDepth(len(q.items))
+	q.metrics.observeLatency(now.Sub(pi.added))
+	return pi.item, false
+}
+
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if started, ok := q.processing[item]; ok {
+		q.metrics.observeWorkDuration(time.Since(started))
+	}
+	delete(q.processing, item)
+	if priority, ok := q.requeued[item]; ok {
+		delete(q.requeued, item)
+		q.push(item, priority)
+		q.cond.Signal()
+	} else if len(q.processing) == 0 {
+		// wakes up the ShutDownWithDrain waiting for the processed items
+		q.cond.Broadcast()
+	}
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shutDown()
+}
+
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shutDown()
+	for len(q.processing) > 0 {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) shutDown() {
+	if !q.shuttingDown {
+		close(q.stopCh)
+	}
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+func (q *priorityQueue) AddAfter(item interface{}, duration time.Duration) {
+	if q.ShuttingDown() {
+		return
+	}
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+
+	readyAt := time.Now().Add(duration)
+	q.cond.L.Lock()
+	if existing, ok := q.waiting[item]; ok && !existing.After(readyAt) {
+		// already waiting to be added sooner
+		q.cond.L.Unlock()
+		return
+	}
+	q.waiting[item] = readyAt
+	q.cond.L.Unlock()
+
+	time.AfterFunc(duration, func() {
+		q.cond.L.Lock()
+		if current, ok := q.waiting[item]; !ok || !current.Equal(readyAt) {
+			// superseded by the item added with the shorter delay
+			q.cond.L.Unlock()
+			return
+		}
+		delete(q.waiting, item)
+		q.cond.L.Unlock()
+		q.Add(item)
+	})
+}
+
+func (q *priorityQueue) AddRateLimited(item interface{}) {
+	q.metrics.retry()
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *priorityQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q *priorityQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+// updateUnfinishedWorkLoop updates the metrics of the items being processed until the queue is shut down
+func (q *priorityQueue) updateUnfinishedWorkLoop() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.stopCh:
+			return
+		case <-ticker.C:
+			q.cond.L.Lock()
+			now := time.Now()
+			var total, longest time.Duration
+			for _, started := range q.processing {
+				d := now.Sub(started)
+				total += d
+				if d > longest {
+					longest = d
+				}
+			}
+			q.cond.L.Unlock()
+			q.metrics.setUnfinishedWork(total, longest)
+		}
+	}
+}
+
+// priorityQueueMetrics ====================================================
+
+// priorityQueueMetrics are the client-go work queue metrics, the same the controller-runtime reports for
+// the default work queue of the controller
+type priorityQueueMetrics struct {
+	depth                   prometheus.Gauge
+	adds                    prometheus.Counter
+	latency                 prometheus.Observer
+	workDuration            prometheus.Observer
+	unfinishedWork          prometheus.Gauge
+	longestRunningProcessor prometheus.Gauge
+	retries                 prometheus.Counter
+}
+
+var (
+	workQueueDepth = registeredCollector(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.DepthKey,
+		Help:      "Current depth of workqueue",
+	}, []string{"name"}))
+
+	workQueueAdds = registeredCollector(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.AddsKey,
+		Help:      "Total number of adds handled by workqueue",
+	}, []string{"name"}))
+
+	workQueueLatency = registeredCollector(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.QueueLatencyKey,
+		Help:      "How long in seconds an item stays in workqueue before being requested",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name"}))
+
+	workQueueWorkDuration = registeredCollector(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.WorkDurationKey,
+		Help:      "How long in seconds processing an item from workqueue takes.",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name"}))
+
+	workQueueUnfinishedWork = registeredCollector(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.UnfinishedWorkKey,
+		Help: "How many seconds of work has been done that " +
+			"is in progress and hasn't been observed by work_duration. Large " +
+			"values indicate stuck threads. One can deduce the number of stuck " +
+			"threads by observing the rate at which this increases.",
+	}, []string{"name"}))
+
+	workQueueLongestRunningProcessor = registeredCollector(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.LongestRunningProcessorKey,
+		Help: "How many seconds has the longest running " +
+			"processor for workqueue been running.",
+	}, []string{"name"}))
+
+	workQueueRetries = registeredCollector(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: crmetrics.WorkQueueSubsystem,
+		Name:      crmetrics.RetriesKey,
+		Help:      "Total number of retries handled by workqueue",
+	}, []string{"name"}))
+)
+
+// registeredCollector registers the collector with the controller-runtime registry, or returns the already
+// registered one, what the work queue metrics are once the controller-runtime metrics are initialized
+func registeredCollector[T prometheus.Collector](c T) T {
+	err := crmetrics.Registry.Register(c)
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	return c
+}
+
+func newPriorityQueueMetrics(name string) *priorityQueueMetrics {
+	if len(name) == 0 {
+		return nil
+	}
+	return &priorityQueueMetrics{
+		depth:                   workQueueDepth.WithLabelValues(name),
+		adds:                    workQueueAdds.WithLabelValues(name),
+		latency:                 workQueueLatency.WithLabelValues(name),
+		workDuration:            workQueueWorkDuration.WithLabelValues(name),
+		unfinishedWork:          workQueueUnfinishedWork.WithLabelValues(name),
+		longestRunningProcessor: workQueueLongestRunningProcessor.WithLabelValues(name),
+		retries:                 workQueueRetries.WithLabelValues(name),
+	}
+}
+
+func (m *priorityQueueMetrics) add() {
+	if m != nil {
+		m.adds.Inc()
+	}
+}
+
+func (m *priorityQueueMetrics) setDepth(depth int) {
+	if m != nil {
+		m.depth.Set(float64(depth))
+	}
+}
+
+func (m *priorityQueueMetrics) observeLatency(d time.Duration) {
+	if m != nil {
+		m.latency.Observe(d.Seconds())
+	}
+}
+
+func (m *priorityQueueMetrics) observeWorkDuration(d time.Duration) {
+	if m != nil {
+		m.workDuration.Observe(d.Seconds())
+	}
+}
+
+func (m *priorityQueueMetrics) setUnfinishedWork(total, longest time.Duration) {
+	if m != nil {
+		m.unfinishedWork.Set(total.Seconds())
+		m.longestRunningProcessor.Set(longest.Seconds())
+	}
+}
+
+func (m *priorityQueueMetrics) retry() {
+	if m != nil {
+		m.retries.Inc()
+	}
+}
+
+// priorityItems ===========================================================
+
+type priorityItem struct {
+	item     interface{}
+	priority int
+	seq      uint64
+	added    time.Time
+	index    int
+}
+
+// priorityItems implements heap.Interface ordered by the priority descending, and the add sequence ascending
+type priorityItems []*priorityItem
+
+func (p priorityItems) Len() int { return len(p) }
+
+func (p priorityItems) Less(i, j int) bool {
+	if p[i].priority != p[j].priority {
+		return p[i].priority > p[j].priority
+	}
+	return p[i].seq < p[j].seq
+}
+
+func (p priorityItems) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+	p[i].index = i
+	p[j].index = j
+}
+
+func (p *priorityItems) Push(x any) {
+	pi := x.(*priorityItem)
+	pi.index = len(*p)
+	*p = append(*p, pi)
+}
+
+func (p *priorityItems) Pop() any {
+	old := *p
+	n := len(old)
+	pi := old[n-1]
+	old[n-1] = nil
+	*p = old[:n-1]
+	return pi
+}
@@ -0,0 +1,153 @@
+package composed
+
+import (
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type priorityQueueSuite struct {
+	suite.Suite
+}
+
+func (suite *priorityQueueSuite) newQueue(objects ...*cloudcontrolv1beta1.NfsInstance) workqueue.RateLimitingInterface {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, obj := range objects {
+		builder = builder.WithObjects(obj)
+	}
+	classifier := NewWorkClassifier(builder.Build(), &cloudcontrolv1beta1.NfsInstance{})
+	return NewPriorityQueue("", classifier, workqueue.DefaultItemBasedRateLimiter())
+}
+
+func nfsRequest(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+}
+
+func nfsInstance(name string, deleted bool) *cloudcontrolv1beta1.NfsInstance {
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+	}
+	if deleted {
+		obj.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		obj.Finalizers = []string{cloudcontrolv1beta1.FinalizerName}
+	}
+	return obj
+}
+
+func (suite *priorityQueueSuite) getAll(q workqueue.RateLimitingInterface) []string {
+	var result []string
+	for q.Len() > 0 {
+		item, shutdown := q.Get()
+		assert.False(suite.T(), shutdown)
+		result = append(result, item.(reconcile.Request).Name)
+		q.Done(item)
+	}
+	return result
+}
+
+func (suite *priorityQueueSuite) TestDeleteDequeuedBeforeCreate() {
+	q := suite.newQueue(
+		nfsInstance("create-1", false),
+		nfsInstance("delete-1", true),
+		nfsInstance("create-2", false),
+		nfsInstance("delete-2", true),
+	)
+	defer q.ShutDown()
+
+	q.Add(nfsRequest("create-1"))
+	q.Add(nfsRequest("delete-1"))
+	q.Add(nfsRequest("create-2"))
+	q.Add(nfsRequest("delete-2"))
+
+	assert.Equal(suite.T(), []string{"delete-1", "delete-2", "create-1", "create-2"}, suite.getAll(q))
+}
+
+func (suite *priorityQueueSuite) TestNotFoundDequeuedAfterDelete() {
+	q := suite.newQueue(nfsInstance("create", false), nfsInstance("delete", true))
+	defer q.ShutDown()
+
+	q.Add(nfsRequest("create"))
+	q.Add(nfsRequest("not-found"))
+	q.Add(nfsRequest("delete"))
+
+	assert.Equal(suite.T(), []string{"delete", "create", "not-found"}, suite.getAll(q))
+}
+
+func (suite *priorityQueueSuite) TestAddedOnceAndRequeuedAfterDone() {
+	q := suite.newQueue(nfsInstance("a", false))
+	defer q.ShutDown()
+
+	q.Add(nfsRequest("a"))
+	q.Add(nfsRequest("a"))
+	assert.Equal(suite.T(), 1, q.Len())
+
+	item, _ := q.Get()
+	q.Add(nfsRequest("a"))
+	assert.Equal(suite.T(), 0, q.Len(), "item being processed is not queued again until done")
+
+	q.Done(item)
+	assert.Equal(suite.T(), 1, q.Len())
+}
+
+func (suite *priorityQueueSuite) TestShutDown() {
+	q := suite.newQueue()
+	done := make(chan bool)
+	go func() {
+		_, shutdown := q.Get()
+		done <- shutdown
+	}()
+	q.ShutDown()
+	select {
+	case shutdown := <-done:
+		assert.True(suite.T(), shutdown)
+	case <-time.After(5 * time.Second):
+		suite.T().Fatal("Get not released by ShutDown")
+	}
+}
+
+func (suite *priorityQueueSuite) TestAddAfterDeduplicated() {
+	q := suite.newQueue(nfsInstance("a", false))
+	defer q.ShutDown()
+
+	q.AddAfter(nfsRequest("a"), time.Hour)
+	q.AddAfter(nfsRequest("a"), 50*time.Millisecond)
+	q.AddAfter(nfsRequest("a"), time.Hour)
+	assert.Equal(suite.T(), 1, len(q.(*priorityQueue).waiting), "item waiting once")
+
+	assert.Eventually(suite.T(), func() bool {
+		return q.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(suite.T(), q.(*priorityQueue).waiting, "longer delays superseded by the shorter one")
+}
+
+func (suite *priorityQueueSuite) TestDisabledByDefault() {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	opts, err := NewPriorityControllerOptions(reader, scheme, &cloudcontrolv1beta1.NfsInstance{})
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), opts.NewQueue)
+
+	SetPriorityQueueEnabled(true)
+	defer SetPriorityQueueEnabled(false)
+	opts, err = NewPriorityControllerOptions(reader, scheme, &cloudcontrolv1beta1.NfsInstance{})
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), opts.NewQueue)
+}
+
+func TestPriorityQueue(t *testing.T) {
+	suite.Run(t, new(priorityQueueSuite))
+}
@@ -0,0 +1,66 @@
+package composed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ReasonWaitingForGates = "WaitingForGates"
+
+// ReadinessGatesRequeueDelay is the delay the object waiting for its readiness gates is requeued
+// with instead of being forgotten, so the gates are evaluated again
+var ReadinessGatesRequeueDelay = 30 * time.Second
+
+// ObjWithReadinessGates is implemented by objects that are Ready only when all the condition types
+// listed as their readiness gates are True
+type ObjWithReadinessGates interface {
+	ReadinessGates() []string
+}
+
+// PendingReadinessGates returns the readiness gates of the object whose conditions are not True
+func PendingReadinessGates(obj ObjWithConditions) []string {
+	objWithGates, ok := obj.(ObjWithReadinessGates)
+	if !ok {
+		return nil
+	}
+	var result []string
+	for _, gate := range objWithGates.ReadinessGates() {
+		if !meta.IsStatusConditionTrue(*obj.Conditions(), PublicConditionType(gate)) {
+			result = append(result, gate)
+		}
+	}
+	return result
+}
+
+// readinessGateTypes returns the set of the object readiness gates, with the names they are written in the status
+func readinessGateTypes(obj ObjWithConditions) map[string]struct{} {
+	result := map[string]struct{}{}
+	if objWithGates, ok := obj.(ObjWithReadinessGates); ok {
+		for _, gate := range objWithGates.ReadinessGates() {
+			result[PublicConditionType(gate)] = struct{}{}
+		}
+	}
+	return result
+}
+
+// waitingForGatesCondition returns the Ready condition replacing the given Ready True condition
+// if any of the object readiness gates is not True, or false if all gates pass
+func waitingForGatesCondition(obj ObjWithConditions, ready metav1.Condition) (metav1.Condition, bool) {
+	if ready.Type != conditionTypeReady || ready.Status != metav1.ConditionTrue {
+		return ready, false
+	}
+	pending := PendingReadinessGates(obj)
+	if len(pending) == 0 {
+		return ready, false
+	}
+	return metav1.Condition{
+		Type:    ready.Type,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonWaitingForGates,
+		Message: fmt.Sprintf("Waiting for readiness gates: %s", strings.Join(pending, ", ")),
+	}, true
+}
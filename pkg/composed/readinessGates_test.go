@@ -0,0 +1,147 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type readinessGatesSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *readinessGatesSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *readinessGatesSuite) newState(gates ...string) (State, *cloudcontrolv1beta1.NfsInstance) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			ReadinessGates: gates,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), obj
+}
+
+func readyCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+}
+
+func (suite *readinessGatesSuite) TestMissingGateKeepsReadyFalse() {
+	state, obj := suite.newState("BackupConfigured", "PeeringActive")
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "PeeringActive", Status: metav1.ConditionTrue, Reason: "Active"},
+	}
+
+	err, _ := UpdateStatus(obj).
+		SetCondition(readyCondition()).
+		Run(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(ReadinessGatesRequeueDelay), err)
+
+	cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionFalse, cond.Status)
+	assert.Equal(suite.T(), ReasonWaitingForGates, cond.Reason)
+	assert.Equal(suite.T(), "Waiting for readiness gates: BackupConfigured", cond.Message)
+}
+
+func (suite *readinessGatesSuite) TestGateSetInSameUpdatePasses() {
+	state, obj := suite.newState("BackupConfigured")
+
+	err, _ := UpdateStatus(obj).
+		SetCondition(readyCondition()).
+		SetCondition(metav1.Condition{Type: "BackupConfigured", Status: metav1.ConditionTrue, Reason: "Configured"}).
+		SuccessErrorNil().
+		Run(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+}
+
+func (suite *readinessGatesSuite) TestFalseGateKeepsReadyFalse() {
+	state, obj := suite.newState("BackupConfigured")
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "BackupConfigured", Status: metav1.ConditionFalse, Reason: "NotConfigured"},
+	}
+
+	err, _ := UpdateStatus(obj).
+		SetCondition(readyCondition()).
+		SuccessErrorNil().
+		Run(suite.ctx, state)
+	assert.Nil(suite.T(), err, "non-default success error is kept")
+	assert.False(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+}
+
+func (suite *readinessGatesSuite) TestNoGates() {
+	state, obj := suite.newState()
+
+	err, _ := UpdateStatus(obj).
+		SetCondition(readyCondition()).
+		Run(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+}
+
+func (suite *readinessGatesSuite) TestGateKeptByExclusiveConditions() {
+	state, obj := suite.newState("BackupConfigured")
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "BackupConfigured", Status: metav1.ConditionTrue, Reason: "Configured"},
+		{Type: "Error", Status: metav1.ConditionTrue, Reason: "Failed"},
+	}
+
+	err, _ := UpdateStatus(obj).
+		SetExclusiveConditions(readyCondition()).
+		Run(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, "BackupConfigured"))
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, "Error"))
+}
+
+func (suite *readinessGatesSuite) TestGateKeptByKeepConditions() {
+	state, obj := suite.newState("BackupConfigured")
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "BackupConfigured", Status: metav1.ConditionTrue, Reason: "Configured"},
+		{Type: "Error", Status: metav1.ConditionTrue, Reason: "Failed"},
+	}
+
+	err, _ := UpdateStatus(obj).
+		KeepConditions(cloudcontrolv1beta1.ConditionTypeReady).
+		SetCondition(readyCondition()).
+		Run(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(obj.Status.Conditions, "BackupConfigured"))
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, "Error"))
+}
+
+func TestReadinessGates(t *testing.T) {
+	suite.Run(t, new(readinessGatesSuite))
+}
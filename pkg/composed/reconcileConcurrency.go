@@ -0,0 +1,37 @@
+package composed
+
+import "fmt"
+
+// DefaultReconcileConcurrency is the max concurrent reconciles of the controllers without configured concurrency
+const DefaultReconcileConcurrency = 1
+
+// reconcileConcurrencies are the max concurrent reconciles of the controllers by the reconciled kind
+var reconcileConcurrencies = map[string]int{}
+
+// SetReconcileConcurrency sets the max concurrent reconciles of the controller of the given kind. Must be
+// called before the reconcilers are set up. The concurrency is per controller and not per cloud account,
+// there is no per-account limit of the concurrent reconciles, so with the concurrency N up to N objects of
+// the same account call the provider API at the same time and are subject to its throttling. Raise it only
+// if the throughput of the controller is limited by the waiting for the cloud resources rather than by the
+// API rate limits.
+func SetReconcileConcurrency(kind string, concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("reconcile concurrency of %s must be at least 1", kind)
+	}
+	reconcileConcurrencies[kind] = concurrency
+	return nil
+}
+
+// ReconcileConcurrency returns the max concurrent reconciles of the controller of the given kind, or the
+// DefaultReconcileConcurrency if not configured
+func ReconcileConcurrency(kind string) int {
+	if concurrency, ok := reconcileConcurrencies[kind]; ok {
+		return concurrency
+	}
+	return DefaultReconcileConcurrency
+}
+
+// resetReconcileConcurrencies resets all controllers to the default concurrency, used by the tests
+func resetReconcileConcurrencies() {
+	reconcileConcurrencies = map[string]int{}
+}
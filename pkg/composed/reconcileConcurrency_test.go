@@ -0,0 +1,42 @@
+package composed
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileConcurrency(t *testing.T) {
+	defer resetReconcileConcurrencies()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	t.Run("default is one", func(t *testing.T) {
+		opts, err := NewPriorityControllerOptions(reader, scheme, &cloudcontrolv1beta1.IpRange{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, opts.MaxConcurrentReconciles)
+	})
+
+	t.Run("configured concurrency is applied to the controller options of the kind", func(t *testing.T) {
+		assert.NoError(t, SetReconcileConcurrency("IpRange", 4))
+
+		opts, err := NewPriorityControllerOptions(reader, scheme, &cloudcontrolv1beta1.IpRange{})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, opts.MaxConcurrentReconciles)
+
+		opts, err = NewPriorityControllerOptions(reader, scheme, &cloudcontrolv1beta1.NfsInstance{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, opts.MaxConcurrentReconciles)
+	})
+
+	t.Run("concurrency below one is rejected", func(t *testing.T) {
+		assert.Error(t, SetReconcileConcurrency("NfsInstance", 0))
+		assert.Equal(t, 1, ReconcileConcurrency("NfsInstance"))
+	})
+}
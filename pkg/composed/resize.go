@@ -0,0 +1,164 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ConditionTypeResizing               = "Resizing"
+	ReasonResizing                      = "Resizing"
+	ConditionTypeCannotShrinkBelowUsage = "CannotShrinkBelowUsage"
+	ReasonCannotShrinkBelowUsage        = "CannotShrinkBelowUsage"
+)
+
+// ResizeFuncs are the provider specific funcs used by ReconcileResize
+type ResizeFuncs struct {
+	// DesiredCapacityGb returns the capacity requested in the spec
+	DesiredCapacityGb func(state State) int64
+	// CurrentCapacityGb returns the capacity of the cloud resource, and false if it is not loaded
+	CurrentCapacityGb func(state State) (int64, bool)
+	// UsedCapacityGb returns the capacity currently used on the cloud resource, or nil if the
+	// provider does not report it. Optional.
+	UsedCapacityGb func(ctx context.Context, state State) (*int64, error)
+	// ResizeInProgress returns true while the provider resize operation is not completed. Optional.
+	ResizeInProgress func(state State) bool
+	// Resize starts the provider resize operation to the given capacity. Optional, if not set the resize
+	// is left to the provider update flow running after, and only the conditions are reconciled.
+	Resize func(ctx context.Context, state State, capacityGb int64) error
+	// SetStatusCapacityGb sets the resized capacity in the object status
+	SetStatusCapacityGb func(state State, capacityGb int64)
+}
+
+// ReconcileResize returns an action that resizes the cloud resource when the capacity in the spec
+// differs from the current capacity. While the capacity differs it sets the Resizing condition and
+// requeues after the given delay, or continues to the provider update flow if the Resize func is not set.
+// Once the capacity matches it removes the condition, sets the new capacity in the status and continues. A decrease below the used capacity is rejected
+// with the CannotShrinkBelowUsage condition and the object is not requeued until the spec changes.
+// The objects marked for deletion, not implementing ObjWithConditions, or whose cloud resource is not
+// loaded yet are not checked.
+func ReconcileResize(requeueDelay time.Duration, funcs ResizeFuncs) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if IsMarkedForDeletion(state.Obj()) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return nil, nil
+		}
+		current, ok := funcs.CurrentCapacityGb(state)
+		if !ok {
+			return nil, nil
+		}
+		logger := LoggerFromCtx(ctx)
+
+		desired := funcs.DesiredCapacityGb(state)
+
+		if desired == current {
+			resizing := meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeResizing)) != nil
+			cannotShrink := meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeCannotShrinkBelowUsage)) != nil
+			if !resizing && !cannotShrink {
+				return nil, nil
+			}
+			if resizing {
+				funcs.SetStatusCapacityGb(state, current)
+			}
+			return UpdateStatus(obj).
+				RemoveConditions(ConditionTypeResizing, ConditionTypeCannotShrinkBelowUsage).
+				ErrorLogMessage("Error updating status after resize").
+				SuccessLogMsg("Resize completed").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		if funcs.ResizeInProgress != nil && funcs.ResizeInProgress(state) {
+			return setResizingCondition(ctx, state, obj, requeueDelay)
+		}
+
+		if desired < current && funcs.UsedCapacityGb != nil {
+			used, err := funcs.UsedCapacityGb(ctx, state)
+			if err != nil {
+				return LogErrorAndReturn(err, "Error loading used capacity", StopWithRequeue, ctx)
+			}
+			if used != nil && desired < *used {
+				logger.
+					WithValues(
+						"desiredCapacityGb", desired,
+						"usedCapacityGb", *used,
+					).
+					Info("Can not shrink below the used capacity")
+				condition := metav1.Condition{
+					Type:    ConditionTypeCannotShrinkBelowUsage,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonCannotShrinkBelowUsage,
+					Message: fmt.Sprintf("Capacity can not be reduced to %dGb below the used %dGb", desired, *used),
+				}
+				if !AnyConditionChanged(obj, condition) {
+					return StopAndForget, nil
+				}
+				return UpdateStatus(obj).
+					SetCondition(condition).
+					ErrorLogMessage("Error updating status with CannotShrinkBelowUsage condition").
+					SuccessError(StopAndForget).
+					Run(ctx, state)
+			}
+		}
+
+		logger.
+			WithValues(
+				"currentCapacityGb", current,
+				"desiredCapacityGb", desired,
+			).
+			Info("Resizing")
+
+		if funcs.Resize == nil {
+			condition := resizingCondition()
+			if !AnyConditionChanged(obj, condition) && meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeCannotShrinkBelowUsage)) == nil {
+				return nil, nil
+			}
+			return UpdateStatus(obj).
+				SetCondition(condition).
+				RemoveConditions(ConditionTypeCannotShrinkBelowUsage).
+				ErrorLogMessage("Error updating status with Resizing condition").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		if err := funcs.Resize(ctx, state, desired); err != nil {
+			return LogErrorAndReturn(err, "Error resizing", StopWithRequeueDelay(requeueDelay), ctx)
+		}
+
+		// always saved, since the provider might have recorded the resize operation in the status
+		return UpdateStatus(obj).
+			SetCondition(resizingCondition()).
+			RemoveConditions(ConditionTypeCannotShrinkBelowUsage).
+			ErrorLogMessage("Error updating status with Resizing condition").
+			SuccessError(StopWithRequeueDelay(requeueDelay)).
+			Run(ctx, state)
+	}
+}
+
+func resizingCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    ConditionTypeResizing,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonResizing,
+		Message: "Resize operation in progress",
+	}
+}
+
+func setResizingCondition(ctx context.Context, state State, obj ObjWithConditions, requeueDelay time.Duration) (error, context.Context) {
+	condition := resizingCondition()
+	if !AnyConditionChanged(obj, condition) {
+		return StopWithRequeueDelay(requeueDelay), nil
+	}
+	return UpdateStatus(obj).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating status with Resizing condition").
+		SuccessError(StopWithRequeueDelay(requeueDelay)).
+		Run(ctx, state)
+}
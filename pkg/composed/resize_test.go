@@ -0,0 +1,162 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+type resizeSuite struct {
+	testStateSuite
+}
+
+type fakeResizer struct {
+	desired    int64
+	current    int64
+	used       *int64
+	inProgress bool
+	resizedTo  []int64
+	resizeErr  error
+}
+
+func (r *fakeResizer) funcs() ResizeFuncs {
+	return ResizeFuncs{
+		DesiredCapacityGb: func(state State) int64 {
+			return r.desired
+		},
+		CurrentCapacityGb: func(state State) (int64, bool) {
+			return r.current, true
+		},
+		UsedCapacityGb: func(ctx context.Context, state State) (*int64, error) {
+			return r.used, nil
+		},
+		ResizeInProgress: func(state State) bool {
+			return r.inProgress
+		},
+		Resize: func(ctx context.Context, state State, capacityGb int64) error {
+			r.resizedTo = append(r.resizedTo, capacityGb)
+			return r.resizeErr
+		},
+		SetStatusCapacityGb: func(state State, capacityGb int64) {
+			state.Obj().(*cloudcontrolv1beta1.NfsInstance).Status.CapacityGb = int(capacityGb)
+		},
+	}
+}
+
+func (suite *resizeSuite) newState() State {
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Status:     cloudcontrolv1beta1.NfsInstanceStatus{CapacityGb: 1024},
+	}
+	return newTestState(obj)
+}
+
+func (suite *resizeSuite) loadObj(state State) *cloudcontrolv1beta1.NfsInstance {
+	obj := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), obj))
+	return obj
+}
+
+func (suite *resizeSuite) TestIncreaseIsResizedAndCompleted() {
+	state := suite.newState()
+	resizer := &fakeResizer{desired: 2048, current: 1024}
+	action := ReconcileResize(time.Second, resizer.funcs())
+
+	err, _ := action(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Second), err)
+	assert.Equal(suite.T(), []int64{2048}, resizer.resizedTo)
+	assert.NotNil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeResizing))
+
+	// waits while the provider operation runs
+	resizer.inProgress = true
+	err, _ = action(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Second), err)
+	assert.Len(suite.T(), resizer.resizedTo, 1)
+
+	// and completes once the capacity matches
+	resizer.inProgress = false
+	resizer.current = 2048
+	err, _ = action(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	saved := suite.loadObj(state)
+	assert.Nil(suite.T(), meta.FindStatusCondition(saved.Status.Conditions, ConditionTypeResizing))
+	assert.Equal(suite.T(), 2048, saved.Status.CapacityGb)
+}
+
+func (suite *resizeSuite) TestUnchangedCapacityContinues() {
+	state := suite.newState()
+	resizer := &fakeResizer{desired: 1024, current: 1024}
+
+	err, _ := ReconcileResize(time.Second, resizer.funcs())(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), resizer.resizedTo)
+
+	// the provider operation not changing the capacity is not a resize
+	resizer.inProgress = true
+	err, _ = ReconcileResize(time.Second, resizer.funcs())(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeResizing))
+}
+
+func (suite *resizeSuite) TestResizeLeftToProviderUpdateFlow() {
+	state := suite.newState()
+	resizer := &fakeResizer{desired: 2048, current: 1024}
+	funcs := resizer.funcs()
+	funcs.Resize = nil
+	funcs.ResizeInProgress = nil
+	action := ReconcileResize(time.Second, funcs)
+
+	err, _ := action(suite.ctx, state)
+	assert.Nil(suite.T(), err, "continues to the provider update flow")
+	assert.NotNil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeResizing))
+
+	resizer.current = 2048
+	err, _ = action(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	saved := suite.loadObj(state)
+	assert.Nil(suite.T(), meta.FindStatusCondition(saved.Status.Conditions, ConditionTypeResizing))
+	assert.Equal(suite.T(), 2048, saved.Status.CapacityGb)
+}
+
+func (suite *resizeSuite) TestShrinkBelowUsageIsRejected() {
+	state := suite.newState()
+	resizer := &fakeResizer{desired: 512, current: 1024, used: ptr.To(int64(700))}
+	action := ReconcileResize(time.Second, resizer.funcs())
+
+	err, _ := action(suite.ctx, state)
+	assert.Equal(suite.T(), StopAndForget, err)
+	assert.Empty(suite.T(), resizer.resizedTo)
+	cond := meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeCannotShrinkBelowUsage)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), "Capacity can not be reduced to 512Gb below the used 700Gb", cond.Message)
+
+	// a shrink above the usage is resized and clears the condition
+	resizer.desired = 800
+	err, _ = action(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Second), err)
+	assert.Equal(suite.T(), []int64{800}, resizer.resizedTo)
+	saved := suite.loadObj(state)
+	assert.Nil(suite.T(), meta.FindStatusCondition(saved.Status.Conditions, ConditionTypeCannotShrinkBelowUsage))
+	assert.NotNil(suite.T(), meta.FindStatusCondition(saved.Status.Conditions, ConditionTypeResizing))
+}
+
+func (suite *resizeSuite) TestResizeErrorIsRequeued() {
+	state := suite.newState()
+	resizer := &fakeResizer{desired: 2048, current: 1024, resizeErr: errors.New("quota exceeded")}
+
+	err, _ := ReconcileResize(time.Second, resizer.funcs())(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Second), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeResizing))
+}
+
+func TestResize(t *testing.T) {
+	suite.Run(t, new(resizeSuite))
+}
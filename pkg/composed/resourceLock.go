@@ -0,0 +1,202 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	ConditionTypeLockedByOther = "LockedByOther"
+	ReasonLockedByOther        = "LockedByOther"
+)
+
+// DefaultResourceLockTTL is the lock TTL used by WithResourceLock if none is given
+var DefaultResourceLockTTL = 2 * time.Minute
+
+var (
+	resourceLockHolder string
+	resourceLockTTL    time.Duration
+)
+
+// SetResourceLock enables the ResourceLock action with the given holder id and lock ttl. An empty
+// holder disables it. Must be called on startup before any reconciler is started.
+func SetResourceLock(holder string, ttl time.Duration) {
+	resourceLockHolder = holder
+	resourceLockTTL = ttl
+}
+
+// ResourceLock runs the action with WithResourceLock of the holder and ttl set by SetResourceLock, or
+// just runs the action if the resource lock is not enabled
+func ResourceLock(action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if len(resourceLockHolder) == 0 {
+			return action(ctx, state)
+		}
+		return WithResourceLock(resourceLockHolder, resourceLockTTL, action)(ctx, state)
+	}
+}
+
+// ResourceLockLeaseName returns the name of the Lease holding the lock of the object of the given kind,
+// created in the object namespace
+func ResourceLockLeaseName(kind, name string) string {
+	return fmt.Sprintf("cm-lock-%s-%s", strings.ToLower(kind), name)
+}
+
+// WithResourceLock runs the action holding the lock of the state object, so the tools sharing the lock
+// do not edit the object concurrently. The lock is a coordination.k8s.io Lease named by ResourceLockLeaseName
+// in the object namespace, so taking it does not change the object and does not trigger its reconciliation.
+// If the Lease is held by other holder and not expired the action is not run, the LockedByOther condition
+// is set, and the object is requeued for when the lock expires. Otherwise, the lock is acquired or renewed
+// for the ttl before the action runs, and released once it returns. Acquiring the lock uses the optimistic
+// concurrency of the Lease create and update, so only one of the competing holders succeeds. Must run after
+// the object is loaded. The cluster scoped objects are not locked.
+func WithResourceLock(holder string, ttl time.Duration, action Action) Action {
+	if ttl <= 0 {
+		ttl = DefaultResourceLockTTL
+	}
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if state.Obj() == nil || state.Obj().GetName() == "" || state.Obj().GetNamespace() == "" {
+			return action(ctx, state)
+		}
+		logger := LoggerFromCtx(ctx)
+
+		gvk, err := apiutil.GVKForObject(state.Obj(), state.Cluster().Scheme())
+		if err != nil {
+			return LogErrorAndReturn(err, "Error resolving object kind for the lock", StopAndForget, ctx)
+		}
+		leaseKey := client.ObjectKey{
+			Namespace: state.Obj().GetNamespace(),
+			Name:      ResourceLockLeaseName(gvk.Kind, state.Obj().GetName()),
+		}
+
+		lease := &coordinationv1.Lease{}
+		err = state.Cluster().K8sClient().Get(ctx, leaseKey, lease)
+		if client.IgnoreNotFound(err) != nil {
+			return LogErrorAndReturn(err, "Error loading object lock", StopWithRequeue, ctx)
+		}
+		exists := err == nil
+
+		if other, expires, locked := lockedByOther(lease, holder); exists && locked {
+			logger.
+				WithValues(
+					"lockHolder", other,
+					"lockExpires", expires.Format(time.RFC3339),
+				).
+				Info("Object is locked by other holder")
+			return setLockedByOtherCondition(ctx, state, other, expires)
+		}
+
+		lease.Namespace = leaseKey.Namespace
+		lease.Name = leaseKey.Name
+		now := metav1.NowMicro()
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != holder {
+			lease.Spec.AcquireTime = &now
+		}
+		lease.Spec.HolderIdentity = ptr.To(holder)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(ttl.Seconds()))
+		lease.Spec.RenewTime = &now
+		if exists {
+			err = state.Cluster().K8sClient().Update(ctx, lease)
+		} else {
+			err = state.Cluster().K8sClient().Create(ctx, lease)
+		}
+		if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+			logger.Info("Object lock was taken concurrently, retrying")
+			return StopWithRequeue, nil
+		}
+		if err != nil {
+			return LogErrorAndReturn(err, "Error acquiring object lock", StopWithRequeue, ctx)
+		}
+
+		if err, _ := removeLockedByOtherCondition(ctx, state); err != nil {
+			return err, ctx
+		}
+
+		err, nextCtx := action(ctx, state)
+
+		releaseCtx := ctx
+		if nextCtx != nil {
+			releaseCtx = nextCtx
+		}
+		if releaseErr := releaseLock(releaseCtx, state, lease); releaseErr != nil {
+			// the lock expires eventually, so the result of the action is kept
+			LoggerFromCtx(releaseCtx).Error(releaseErr, "Error releasing object lock")
+		}
+
+		return err, nextCtx
+	}
+}
+
+// lockedByOther returns the holder and the expiry of the lock, if it is held by other holder and
+// not expired. The lock without the renew time or the duration is considered expired.
+func lockedByOther(lease *coordinationv1.Lease, holder string) (string, time.Time, bool) {
+	other := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if len(other) == 0 || other == holder {
+		return "", time.Time{}, false
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return "", time.Time{}, false
+	}
+	expires := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	if !expires.After(time.Now()) {
+		return "", time.Time{}, false
+	}
+	return other, expires, true
+}
+
+// releaseLock deletes the Lease if it was not changed since acquired, what otherwise means it was
+// reclaimed by other holder after expiring
+func releaseLock(ctx context.Context, state State, lease *coordinationv1.Lease) error {
+	err := state.Cluster().K8sClient().Delete(ctx, lease, client.Preconditions{
+		UID:             ptr.To(lease.UID),
+		ResourceVersion: ptr.To(lease.ResourceVersion),
+	})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+func setLockedByOtherCondition(ctx context.Context, state State, other string, expires time.Time) (error, context.Context) {
+	requeue := StopWithRequeueDelay(time.Until(expires))
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok {
+		return requeue, nil
+	}
+	condition := metav1.Condition{
+		Type:    ConditionTypeLockedByOther,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonLockedByOther,
+		Message: fmt.Sprintf("Object is locked by %s until %s", other, expires.UTC().Format(time.RFC3339)),
+	}
+	if !AnyConditionChanged(obj, condition) {
+		return requeue, nil
+	}
+	return UpdateStatus(obj).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating status with LockedByOther condition").
+		SuccessError(requeue).
+		Run(ctx, state)
+}
+
+func removeLockedByOtherCondition(ctx context.Context, state State) (error, context.Context) {
+	obj, ok := state.Obj().(ObjWithConditions)
+	if !ok || meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(ConditionTypeLockedByOther)) == nil {
+		return nil, nil
+	}
+	return UpdateStatus(obj).
+		RemoveConditions(ConditionTypeLockedByOther).
+		ErrorLogMessage("Error updating status after removing LockedByOther condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,166 @@
+package composed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type resourceLockSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *resourceLockSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *resourceLockSuite) newState(leases ...*coordinationv1.Lease) (State, client.Client) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "iprange"},
+	}
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj)
+	for _, lease := range leases {
+		builder = builder.WithObjects(lease)
+	}
+	clnt := builder.Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), clnt
+}
+
+func (suite *resourceLockSuite) loadLease(clnt client.Client) *coordinationv1.Lease {
+	lease := &coordinationv1.Lease{}
+	err := clnt.Get(suite.ctx, types.NamespacedName{Namespace: "default", Name: ResourceLockLeaseName("IpRange", "iprange")}, lease)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	assert.NoError(suite.T(), err)
+	return lease
+}
+
+func newLockLease(holder string, renewed time.Time, duration time.Duration) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: ResourceLockLeaseName("IpRange", "iprange")},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(holder),
+			LeaseDurationSeconds: ptr.To(int32(duration.Seconds())),
+			RenewTime:            &metav1.MicroTime{Time: renewed},
+		},
+	}
+}
+
+func (suite *resourceLockSuite) TestAcquiredAndReleased() {
+	state, clnt := suite.newState()
+	var leaseDuringAction *coordinationv1.Lease
+	action := func(ctx context.Context, st State) (error, context.Context) {
+		leaseDuringAction = suite.loadLease(clnt)
+		return StopWithRequeueDelay(time.Minute), nil
+	}
+
+	err, _ := WithResourceLock("cloud-manager", time.Minute, action)(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Minute), err)
+
+	assert.NotNil(suite.T(), leaseDuringAction)
+	assert.Equal(suite.T(), "cloud-manager", ptr.Deref(leaseDuringAction.Spec.HolderIdentity, ""))
+	assert.Equal(suite.T(), int32(60), ptr.Deref(leaseDuringAction.Spec.LeaseDurationSeconds, 0))
+	assert.WithinDuration(suite.T(), time.Now(), leaseDuringAction.Spec.RenewTime.Time, 5*time.Second)
+
+	assert.Nil(suite.T(), suite.loadLease(clnt), "the lock is released")
+	assert.Empty(suite.T(), state.Obj().GetAnnotations(), "the object is not changed")
+}
+
+func (suite *resourceLockSuite) TestLockedByOther() {
+	state, clnt := suite.newState(newLockLease("other-tool", time.Now(), time.Minute))
+	called := false
+	action := func(ctx context.Context, st State) (error, context.Context) {
+		called = true
+		return nil, nil
+	}
+
+	err, _ := WithResourceLock("cloud-manager", time.Minute, action)(suite.ctx, state)
+	assert.False(suite.T(), called)
+	assert.IsType(suite.T(), StopWithRequeueDelay(time.Minute), err)
+
+	obj := state.Obj().(ObjWithConditions)
+	cond := meta.FindStatusCondition(*obj.Conditions(), ConditionTypeLockedByOther)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+	assert.Contains(suite.T(), cond.Message, "other-tool")
+	assert.Equal(suite.T(), "other-tool", ptr.Deref(suite.loadLease(clnt).Spec.HolderIdentity, ""))
+}
+
+func (suite *resourceLockSuite) TestExpiredLockIsReclaimed() {
+	state, clnt := suite.newState(newLockLease("other-tool", time.Now().Add(-time.Hour), time.Minute))
+	obj := state.Obj().(ObjWithConditions)
+	meta.SetStatusCondition(obj.Conditions(), metav1.Condition{
+		Type:   ConditionTypeLockedByOther,
+		Status: metav1.ConditionTrue,
+		Reason: ReasonLockedByOther,
+	})
+	assert.NoError(suite.T(), state.UpdateObjStatus(suite.ctx))
+
+	var holderDuringAction string
+	action := func(ctx context.Context, st State) (error, context.Context) {
+		holderDuringAction = ptr.Deref(suite.loadLease(clnt).Spec.HolderIdentity, "")
+		return nil, nil
+	}
+
+	err, _ := WithResourceLock("cloud-manager", 0, action)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "cloud-manager", holderDuringAction)
+	assert.Nil(suite.T(), meta.FindStatusCondition(*obj.Conditions(), ConditionTypeLockedByOther))
+	assert.Nil(suite.T(), suite.loadLease(clnt))
+}
+
+func (suite *resourceLockSuite) TestOwnLockIsRenewed() {
+	state, clnt := suite.newState(newLockLease("cloud-manager", time.Now().Add(-time.Minute), time.Hour))
+	var renewedDuringAction time.Time
+	action := func(ctx context.Context, st State) (error, context.Context) {
+		renewedDuringAction = suite.loadLease(clnt).Spec.RenewTime.Time
+		return nil, nil
+	}
+
+	err, _ := WithResourceLock("cloud-manager", time.Hour, action)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.WithinDuration(suite.T(), time.Now(), renewedDuringAction, 5*time.Second)
+}
+
+func (suite *resourceLockSuite) TestNotLockedUnlessEnabled() {
+	state, clnt := suite.newState()
+	var leaseDuringAction *coordinationv1.Lease
+	action := func(ctx context.Context, st State) (error, context.Context) {
+		leaseDuringAction = suite.loadLease(clnt)
+		return nil, nil
+	}
+
+	err, _ := ResourceLock(action)(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), leaseDuringAction)
+}
+
+func TestResourceLock(t *testing.T) {
+	suite.Run(t, new(resourceLockSuite))
+}
@@ -0,0 +1,69 @@
+package composed
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SpecMigrationFunc converts the deprecated spec fields of the object to their new form in place,
+// and returns true if anything was converted
+type SpecMigrationFunc func(obj client.Object) bool
+
+type specMigration struct {
+	persist bool
+	migrate SpecMigrationFunc
+}
+
+var specMigrations struct {
+	sync.RWMutex
+	byType map[reflect.Type][]specMigration
+}
+
+// RegisterSpecMigration registers the migration of the deprecated spec fields for the objects of the same
+// type as the given one. If persist is true, the object is updated once the migration converted anything,
+// otherwise the conversion is done only in memory on each reconciliation, which is needed when the spec is
+// owned by another controller that would write back the deprecated form. Must be called on startup.
+func RegisterSpecMigration(obj client.Object, persist bool, f SpecMigrationFunc) {
+	specMigrations.Lock()
+	defer specMigrations.Unlock()
+	if specMigrations.byType == nil {
+		specMigrations.byType = map[reflect.Type][]specMigration{}
+	}
+	t := reflect.TypeOf(obj)
+	specMigrations.byType[t] = append(specMigrations.byType[t], specMigration{persist: persist, migrate: f})
+}
+
+// MigrateDeprecatedSpec is an action that runs the spec migrations registered for the type of the state
+// object, so the actions after it see only the new form of the spec fields. The object is updated only if
+// a migration registered to persist converted anything. Must run right after the object is loaded.
+func MigrateDeprecatedSpec(ctx context.Context, state State) (error, context.Context) {
+	if state.Obj() == nil || state.Obj().GetName() == "" {
+		return nil, nil
+	}
+
+	specMigrations.RLock()
+	migrations := specMigrations.byType[reflect.TypeOf(state.Obj())]
+	specMigrations.RUnlock()
+
+	persist := false
+	for _, m := range migrations {
+		if m.migrate(state.Obj()) && m.persist {
+			persist = true
+		}
+	}
+
+	if !persist {
+		return nil, nil
+	}
+
+	LoggerFromCtx(ctx).Info("Persisting migrated deprecated spec fields")
+
+	if err := state.UpdateObj(ctx); err != nil {
+		return LogErrorAndReturn(err, "Error updating object with migrated spec", StopWithRequeue, ctx)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestMigrateDeprecatedSpecPersisted(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	RegisterSpecMigration(&cloudcontrolv1beta1.RedisInstance{}, true, func(obj client.Object) bool {
+		redisInstance := obj.(*cloudcontrolv1beta1.RedisInstance)
+		if redisInstance.Spec.IpRange.Name != "old-iprange" {
+			return false
+		}
+		redisInstance.Spec.IpRange.Name = "new-iprange"
+		return true
+	})
+	defer func() {
+		specMigrations.byType = nil
+	}()
+
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			IpRange: cloudcontrolv1beta1.IpRangeRef{Name: "old-iprange"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+
+	err, _ := MigrateDeprecatedSpec(ctx, state)
+	assert.Nil(t, err)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Equal(t, "new-iprange", saved.Spec.IpRange.Name)
+
+	// nothing left to migrate, so the object is not written again
+	resourceVersion := saved.ResourceVersion
+	err, _ = MigrateDeprecatedSpec(ctx, state)
+	assert.Nil(t, err)
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Equal(t, resourceVersion, saved.ResourceVersion)
+}
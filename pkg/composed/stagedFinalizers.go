@@ -0,0 +1,69 @@
+package composed
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AddFinalizers returns an Action that adds the finalizers of the cleanup stages, in the order the stages
+// run on delete, to the object not marked for deletion. Each stage removes its finalizer with the
+// RemoveFinalizer once its cleanup is complete, so the remaining finalizers show the stages still to run,
+// and the first of them is the stage a deletion interrupted by a failure resumes at.
+func AddFinalizers(names ...string) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+
+		obj := state.Obj()
+		original := obj.DeepCopyObject().(client.Object)
+		added := false
+		for _, name := range names {
+			if controllerutil.AddFinalizer(obj, name) {
+				added = true
+			}
+		}
+		if !added {
+			return nil, nil
+		}
+
+		if err := state.Cluster().K8sClient().Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+			return LogErrorAndReturn(err, "Error patching obj to add cleanup stage finalizers", StopWithRequeue, ctx)
+		}
+
+		return nil, nil
+	}
+}
+
+// RemoveFinalizer returns an Action that removes the finalizer of the completed cleanup stage from the
+// object marked for deletion. It must follow the actions of its stage in the delete flow, so the finalizer
+// is removed only after they all succeed. On the deletion resumed after a failure it does nothing for the
+// stages completed before, and the flow continues to the stage that failed.
+func RemoveFinalizer(name string) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if !MarkedForDeletionPredicate(ctx, state) {
+			return nil, nil
+		}
+
+		obj := state.Obj()
+		original := obj.DeepCopyObject().(client.Object)
+		if !controllerutil.RemoveFinalizer(obj, name) {
+			return nil, nil
+		}
+
+		if err := state.Cluster().K8sClient().Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+			return LogErrorAndReturn(err, "Error patching obj to remove cleanup stage finalizer", StopWithRequeue, ctx)
+		}
+
+		LoggerFromCtx(ctx).
+			WithValues(
+				"finalizer", name,
+				"remainingFinalizers", obj.GetFinalizers(),
+			).
+			Info("Cleanup stage completed")
+
+		return nil, nil
+	}
+}
@@ -0,0 +1,108 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestStagedFinalizers(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	const (
+		stage1 = "test.kyma-project.io/stage1"
+		stage2 = "test.kyma-project.io/stage2"
+		stage3 = "test.kyma-project.io/stage3"
+	)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "redis",
+			Finalizers: []string{cloudcontrolv1beta1.FinalizerName},
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	newState := func() State {
+		state := NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, &cloudcontrolv1beta1.RedisInstance{})
+		assert.NoError(t, state.LoadObj(ctx))
+		return state
+	}
+
+	// stage2 cleanup fails on its first run
+	runs := map[string]int{}
+	stage := func(name string, failures int) Action {
+		return func(ctx context.Context, state State) (error, context.Context) {
+			runs[name]++
+			if runs[name] <= failures {
+				return errors.New("simulated cleanup failure"), nil
+			}
+			return nil, nil
+		}
+	}
+	deleteFlow := ComposeActions(
+		"delete",
+		stage(stage1, 0),
+		RemoveFinalizer(stage1),
+		stage(stage2, 1),
+		RemoveFinalizer(stage2),
+		stage(stage3, 0),
+		RemoveFinalizer(stage3),
+	)
+
+	t.Run("finalizers added in stage order", func(t *testing.T) {
+		state := newState()
+		err, _ := AddFinalizers(stage1, stage2, stage3)(ctx, state)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName, stage1, stage2, stage3}, state.Obj().GetFinalizers())
+
+		err, _ = RemoveFinalizer(stage1)(ctx, state)
+		assert.Nil(t, err)
+		assert.Contains(t, state.Obj().GetFinalizers(), stage1, "not removed unless marked for deletion")
+	})
+
+	assert.NoError(t, clnt.Delete(ctx, obj))
+
+	t.Run("failed stage keeps its finalizer", func(t *testing.T) {
+		state := newState()
+		err, _ := deleteFlow(ctx, state)
+		assert.EqualError(t, err, "simulated cleanup failure")
+
+		assert.NoError(t, clnt.Get(ctx, state.Name(), obj))
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName, stage2, stage3}, obj.GetFinalizers())
+	})
+
+	t.Run("deletion resumes at the failed stage", func(t *testing.T) {
+		state := newState()
+		err, _ := deleteFlow(ctx, state)
+		assert.Nil(t, err)
+
+		assert.NoError(t, clnt.Get(ctx, state.Name(), obj))
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName}, obj.GetFinalizers())
+		assert.Equal(t, map[string]int{stage1: 2, stage2: 2, stage3: 1}, runs)
+	})
+
+	t.Run("finalizers not added when marked for deletion", func(t *testing.T) {
+		state := newState()
+		err, _ := AddFinalizers(stage1)(ctx, state)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{cloudcontrolv1beta1.FinalizerName}, state.Obj().GetFinalizers())
+	})
+}
@@ -88,6 +88,12 @@ type State interface {
 	UpdateObjStatus(ctx context.Context, opts ...client.SubResourceUpdateOption) error
 	PatchObjStatus(ctx context.Context) error
 
+	// DeferStatusUpdate switches the state to the deferred status mode, where UpdateObjStatus and
+	// PatchObjStatus only mark the status as pending, and it is written once by FlushStatus
+	DeferStatusUpdate()
+	// FlushStatus writes the status pending in the deferred status mode, if any, and leaves the deferred mode
+	FlushStatus(ctx context.Context) error
+
 	PatchObjAddFinalizer(ctx context.Context, f string) (bool, error)
 	PatchObjRemoveFinalizer(ctx context.Context, f string) (bool, error)
 }
@@ -122,6 +128,15 @@ type baseState struct {
 
 	name types.NamespacedName
 	obj  client.Object
+
+	deferStatus   bool
+	pendingStatus *pendingStatusWrite
+}
+
+// pendingStatusWrite is the status write recorded in the deferred status mode
+type pendingStatusWrite struct {
+	patch      bool
+	updateOpts []client.SubResourceUpdateOption
 }
 
 func (s *baseState) Cluster() StateCluster {
@@ -161,11 +176,48 @@ func (s *baseState) UpdateObj(ctx context.Context, opts ...client.UpdateOption)
 }
 
 func (s *baseState) UpdateObjStatus(ctx context.Context, opts ...client.SubResourceUpdateOption) error {
-	return s.Cluster().K8sClient().Status().Update(ctx, s.Obj(), opts...)
+	if s.deferStatus {
+		s.pendingStatus = &pendingStatusWrite{updateOpts: opts}
+		return nil
+	}
+	if err := s.Cluster().K8sClient().Status().Update(ctx, s.Obj(), opts...); err != nil {
+		return err
+	}
+	markStatusPersisted(ctx)
+	return nil
 }
 
 func (s *baseState) PatchObjStatus(ctx context.Context) error {
-	return PatchObjStatus(ctx, s.Obj(), s.Cluster().K8sClient())
+	if s.deferStatus {
+		s.pendingStatus = &pendingStatusWrite{patch: true}
+		return nil
+	}
+	if err := PatchObjStatus(ctx, s.Obj(), s.Cluster().K8sClient()); err != nil {
+		return err
+	}
+	markStatusPersisted(ctx)
+	return nil
+}
+
+func (s *baseState) DeferStatusUpdate() {
+	s.deferStatus = true
+}
+
+// FlushStatus writes the pending status with the method of the last deferred write, since the status
+// of the object in memory already holds all the changes made by the previous ones
+func (s *baseState) FlushStatus(ctx context.Context) error {
+	s.deferStatus = false
+	pending := s.pendingStatus
+	s.pendingStatus = nil
+	if pending == nil {
+		return nil
+	}
+	return writeRedactedStatus(s.obj, func() error {
+		if pending.patch {
+			return s.PatchObjStatus(ctx)
+		}
+		return s.UpdateObjStatus(ctx, pending.updateOpts...)
+	})
 }
 
 // PatchObjAddFinalizer uses controllerutil.AddFinalizer() to add finalizer, if it returns false
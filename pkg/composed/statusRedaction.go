@@ -0,0 +1,172 @@
+package composed
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatusRedactionMask is the value the masked string status fields are written with
+const StatusRedactionMask = "REDACTED"
+
+const (
+	// StatusRedactionMaskMode writes the string field with the StatusRedactionMask, and omits the other ones
+	StatusRedactionMaskMode = "mask"
+	// StatusRedactionOmitMode omits the field from the written status
+	StatusRedactionOmitMode = "omit"
+)
+
+// StatusRedaction is the status field redacted in the written status of the objects of the kind
+type StatusRedaction struct {
+	// Path of the field within the status, the json names separated by dots, for example connection.host.
+	// The path going through a list applies to the field of each list item.
+	Path []string
+	Mode string
+}
+
+// statusRedactions are the configured status field redactions by kind
+var statusRedactions = map[string][]StatusRedaction{}
+
+// redactableStatusFields are the status fields, by kind, that can be redacted. The persisted status is the
+// source of truth the reconcilers and the SKR mirrors load, so only the fields that are reported and never
+// read back by any of them are listed. Redacting the other ones, like the endpoints, the ids or the fields
+// compared before the status is written, would break their readers or rewrite the status on each reconcile.
+var redactableStatusFields = map[string][]string{
+	"RedisInstance": {
+		"logDeliveries.destination",
+	},
+}
+
+// ParseStatusRedactions parses the comma separated list of kind.path=mode items, for example
+// RedisInstance.logDeliveries.destination=omit. If the mode is omitted the field is masked. Only the
+// fields listed in redactableStatusFields are accepted.
+func ParseStatusRedactions(s string) (map[string][]StatusRedaction, error) {
+	result := map[string][]StatusRedaction{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		field, mode, hasMode := strings.Cut(item, "=")
+		mode = strings.TrimSpace(mode)
+		if !hasMode {
+			mode = StatusRedactionMaskMode
+		}
+		if mode != StatusRedactionMaskMode && mode != StatusRedactionOmitMode {
+			return nil, fmt.Errorf("invalid status redaction %q, mode must be %s or %s", item, StatusRedactionMaskMode, StatusRedactionOmitMode)
+		}
+		path := strings.Split(strings.TrimSpace(field), ".")
+		if len(path) < 2 || slices.Contains(path, "") {
+			return nil, fmt.Errorf("invalid status redaction %q, expected kind.path=mode", item)
+		}
+		kind := path[0]
+		if !slices.Contains(redactableStatusFields[kind], strings.Join(path[1:], ".")) {
+			return nil, fmt.Errorf("invalid status redaction %q, the field is read back by the reconciliation and can not be redacted", item)
+		}
+		result[kind] = append(result[kind], StatusRedaction{Path: path[1:], Mode: mode})
+	}
+	return result, nil
+}
+
+// SetStatusRedactions sets the status field redactions by kind, ignoring the fields not listed in
+// redactableStatusFields. Must be called before the reconcilers are started.
+func SetStatusRedactions(m map[string][]StatusRedaction) {
+	result := make(map[string][]StatusRedaction, len(m))
+	for k, v := range m {
+		for _, r := range v {
+			if slices.Contains(redactableStatusFields[k], strings.Join(r.Path, ".")) {
+				result[k] = append(result[k], r)
+			}
+		}
+	}
+	statusRedactions = result
+}
+
+// writeRedactedStatus runs the write of the object status with the configured fields of its kind redacted,
+// so only the persisted copy is redacted. Once the write returns the status of the object in memory is restored,
+// keeping the values the rest of the reconciliation relies on, while the metadata keeps the written resource version.
+// The kind is the name of the object Go type, so the redactions apply to the kind in all API groups.
+func writeRedactedStatus(obj client.Object, write func() error) error {
+	if obj == nil {
+		return write()
+	}
+	redactions := statusRedactions[reflect.TypeOf(obj).Elem().Name()]
+	if len(redactions) == 0 {
+		return write()
+	}
+	status := reflect.ValueOf(obj).Elem().FieldByName("Status")
+	if !status.IsValid() || !status.CanSet() {
+		return write()
+	}
+
+	original := reflect.ValueOf(obj.DeepCopyObject()).Elem().FieldByName("Status")
+	for _, r := range redactions {
+		redactField(status, r.Path, r.Mode)
+	}
+	err := write()
+	status.Set(original)
+	return err
+}
+
+// redactField masks or omits the field at the json path within the value, and within each item of the lists on the path
+func redactField(v reflect.Value, path []string, mode string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			redactField(v.Elem(), path, mode)
+		}
+		return
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactField(v.Index(i), path, mode)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	if len(path) == 0 {
+		return
+	}
+	field, ok := fieldByJsonName(v, path[0])
+	if !ok {
+		return
+	}
+	if len(path) > 1 {
+		redactField(field, path[1:], mode)
+		return
+	}
+	if field.IsZero() {
+		return
+	}
+	if mode == StatusRedactionMaskMode && field.Kind() == reflect.String {
+		field.SetString(StatusRedactionMask)
+		return
+	}
+	field.Set(reflect.Zero(field.Type()))
+}
+
+// fieldByJsonName returns the struct field with the given json name, looking into the inlined structs as well
+func fieldByJsonName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tagName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tagName == name {
+			return v.Field(i), true
+		}
+		if sf.Anonymous && tagName == "" && sf.Type.Kind() == reflect.Struct {
+			if f, ok := fieldByJsonName(v.Field(i), name); ok {
+				return f, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
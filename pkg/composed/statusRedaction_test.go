@@ -0,0 +1,158 @@
+package composed
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestParseStatusRedactions(t *testing.T) {
+	redactions, err := ParseStatusRedactions("RedisInstance.logDeliveries.destination, ")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]StatusRedaction{
+		"RedisInstance": {
+			{Path: []string{"logDeliveries", "destination"}, Mode: StatusRedactionMaskMode},
+		},
+	}, redactions)
+
+	redactions, err = ParseStatusRedactions("RedisInstance.logDeliveries.destination=omit")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRedactionOmitMode, redactions["RedisInstance"][0].Mode)
+
+	redactions, err = ParseStatusRedactions("")
+	assert.NoError(t, err)
+	assert.Empty(t, redactions)
+
+	for _, s := range []string{
+		"RedisInstance",
+		"RedisInstance.=mask",
+		"RedisInstance..host",
+		".primaryEndpoint",
+		"RedisInstance.logDeliveries.destination=hide",
+		// read back by the reconciliation and the SKR mirrors
+		"RedisInstance.primaryEndpoint",
+		"RedisInstance.connection.host=mask",
+		"IpRange.subnets.id=omit",
+		"NfsInstance.logDeliveries.destination",
+	} {
+		_, err := ParseStatusRedactions(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRedactField(t *testing.T) {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			VpcId: "vpc-1",
+			Subnets: []cloudcontrolv1beta1.IpRangeSubnet{
+				{Id: "subnet-1", Zone: "eu-west-1a", AvailableIpAddressCount: 250},
+				{Id: "subnet-2", Zone: "eu-west-1b", AvailableIpAddressCount: 251},
+			},
+		},
+	}
+	status := reflect.ValueOf(&ipRange.Status)
+
+	redactField(status, []string{"subnets", "id"}, StatusRedactionOmitMode)
+	redactField(status, []string{"subnets", "availableIpAddressCount"}, StatusRedactionMaskMode)
+	redactField(status, []string{"vpcId"}, StatusRedactionMaskMode)
+	redactField(status, []string{"unknown"}, StatusRedactionMaskMode)
+
+	assert.Equal(t, StatusRedactionMask, ipRange.Status.VpcId)
+	assert.Equal(t, cloudcontrolv1beta1.IpRangeSubnets{
+		{Zone: "eu-west-1a"},
+		{Zone: "eu-west-1b"},
+	}, ipRange.Status.Subnets)
+}
+
+func TestWriteRedactedStatus(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	defer SetStatusRedactions(nil)
+	SetStatusRedactions(map[string][]StatusRedaction{
+		"RedisInstance": {
+			{Path: []string{"logDeliveries", "destination"}, Mode: StatusRedactionMaskMode},
+			// not redactable, ignored
+			{Path: []string{"primaryEndpoint"}, Mode: StatusRedactionMaskMode},
+		},
+	})
+
+	loaded := func(state State) *cloudcontrolv1beta1.RedisInstance {
+		result := &cloudcontrolv1beta1.RedisInstance{}
+		err := state.Cluster().K8sClient().Get(ctx, client.ObjectKeyFromObject(state.Obj()), result)
+		assert.NoError(t, err)
+		return result
+	}
+
+	logDeliveries := func(destination string) []cloudcontrolv1beta1.RedisInstanceLogDeliveryStatus {
+		return []cloudcontrolv1beta1.RedisInstanceLogDeliveryStatus{
+			{LogType: "slow-log", DestinationType: "cloudwatch-logs", Destination: destination, Status: "active"},
+		}
+	}
+
+	setStatus := func(obj *cloudcontrolv1beta1.RedisInstance) {
+		obj.Status.PrimaryEndpoint = "primary.redis.local:6379"
+		obj.Status.LogDeliveries = logDeliveries("redis-slow-log")
+	}
+
+	t.Run("written status is redacted", func(t *testing.T) {
+		state, obj := newMaintenanceFreezeTestState()
+		setStatus(obj)
+
+		err, _ := UpdateStatus(obj).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReady,
+				Message: "Ready",
+			}).
+			SuccessErrorNil().
+			Run(ctx, state)
+		assert.NoError(t, err)
+
+		persisted := loaded(state)
+		assert.Equal(t, logDeliveries(StatusRedactionMask), persisted.Status.LogDeliveries)
+		// the fields read back are persisted as they are
+		assert.Equal(t, "primary.redis.local:6379", persisted.Status.PrimaryEndpoint)
+		assert.Len(t, persisted.Status.Conditions, 1)
+
+		// the object in memory keeps the values, and the resource version of the write
+		assert.Equal(t, logDeliveries("redis-slow-log"), obj.Status.LogDeliveries)
+		assert.Len(t, obj.Status.Conditions, 1)
+		assert.Equal(t, persisted.ResourceVersion, obj.ResourceVersion)
+	})
+
+	t.Run("deferred status is redacted on flush", func(t *testing.T) {
+		state, obj := newMaintenanceFreezeTestState()
+		setStatus(obj)
+
+		state.DeferStatusUpdate()
+		err, _ := UpdateStatus(obj).SuccessErrorNil().Run(ctx, state)
+		assert.NoError(t, err)
+		assert.Empty(t, loaded(state).Status.LogDeliveries)
+
+		assert.NoError(t, state.FlushStatus(ctx))
+
+		assert.Equal(t, logDeliveries(StatusRedactionMask), loaded(state).Status.LogDeliveries)
+		assert.Equal(t, logDeliveries("redis-slow-log"), obj.Status.LogDeliveries)
+	})
+
+	t.Run("other kinds are not redacted", func(t *testing.T) {
+		SetStatusRedactions(map[string][]StatusRedaction{
+			"NfsInstance": {{Path: []string{"logDeliveries", "destination"}, Mode: StatusRedactionMaskMode}},
+		})
+		state, obj := newMaintenanceFreezeTestState()
+		setStatus(obj)
+
+		err, _ := UpdateStatus(obj).SuccessErrorNil().Run(ctx, state)
+		assert.NoError(t, err)
+
+		assert.Equal(t, logDeliveries("redis-slow-log"), loaded(state).Status.LogDeliveries)
+	})
+}
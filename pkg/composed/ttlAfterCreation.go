@@ -0,0 +1,81 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjWithTtlAfterCreation is implemented by objects that can be deleted automatically once their
+// time to live since creation elapses, and publish in their status when it expires
+type ObjWithTtlAfterCreation interface {
+	TtlSecondsAfterCreation() *int64
+	GetTtlExpiresAt() *metav1.Time
+	SetTtlExpiresAt(t *metav1.Time)
+}
+
+// TtlAfterCreation returns an action that deletes the object once its time to live since the creation
+// timestamp elapses, so it proceeds through its normal delete flow. Until then the expiry time is set
+// in the status, and the given action is run with its success or its stop and forget result turned into
+// the requeue for when the time to live elapses. Removing the time to live from the object cancels its
+// automatic deletion and clears the expiry time. Objects marked for deletion and objects without time
+// to live just run the given action. Must run after the object is loaded.
+func TtlAfterCreation(action Action) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if state.Obj() == nil || state.Obj().GetName() == "" || IsMarkedForDeletion(state.Obj()) {
+			return action(ctx, state)
+		}
+		obj, ok := state.Obj().(ObjWithTtlAfterCreation)
+		if !ok {
+			return action(ctx, state)
+		}
+
+		logger := LoggerFromCtx(ctx)
+
+		if obj.TtlSecondsAfterCreation() == nil {
+			if obj.GetTtlExpiresAt() != nil {
+				logger.Info("Time to live removed, canceling the automatic deletion")
+				obj.SetTtlExpiresAt(nil)
+				if err := state.UpdateObjStatus(ctx); err != nil {
+					return LogErrorAndReturn(err, "Error updating status after clearing the time to live expiry", StopWithRequeue, ctx)
+				}
+			}
+			return action(ctx, state)
+		}
+
+		expiresAt := state.Obj().GetCreationTimestamp().Add(time.Duration(*obj.TtlSecondsAfterCreation()) * time.Second)
+		remaining := time.Until(expiresAt)
+
+		if remaining <= 0 {
+			logger.
+				WithValues("ttlExpiresAt", expiresAt.Format(time.RFC3339)).
+				Info("Time to live elapsed, deleting the object")
+			if err := state.Cluster().K8sClient().Delete(ctx, state.Obj()); client.IgnoreNotFound(err) != nil {
+				return LogErrorAndReturn(err, "Error deleting object with elapsed time to live", StopWithRequeue, ctx)
+			}
+			return StopWithRequeue, nil
+		}
+
+		if current := obj.GetTtlExpiresAt(); current == nil || !current.Time.Equal(expiresAt) {
+			obj.SetTtlExpiresAt(&metav1.Time{Time: expiresAt})
+			if err := state.UpdateObjStatus(ctx); err != nil {
+				return LogErrorAndReturn(err, "Error updating status with the time to live expiry", StopWithRequeue, ctx)
+			}
+		}
+
+		err, resCtx := action(ctx, state)
+
+		if err == nil || IsStopAndForget(err) {
+			return StopWithRequeueDelay(remaining), resCtx
+		}
+		var delayErr *stopWithRequeueDelay
+		if errors.As(err, &delayErr) && delayErr.Delay() > remaining {
+			return StopWithRequeueDelay(remaining), resCtx
+		}
+
+		return err, resCtx
+	}
+}
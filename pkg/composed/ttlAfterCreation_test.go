@@ -0,0 +1,125 @@
+package composed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ttlAfterCreationSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *ttlAfterCreationSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *ttlAfterCreationSuite) newState(createdAgo time.Duration, ttlSeconds *int64, ttlExpiresAt *metav1.Time) State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "iprange",
+			Finalizers:        []string{cloudcontrolv1beta1.FinalizerName},
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-createdAgo).Truncate(time.Second)},
+		},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			TtlSecondsAfterCreation: ttlSeconds,
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			TtlExpiresAt: ttlExpiresAt,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := NewStateCluster(clnt, clnt, nil, scheme)
+	state := NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, &cloudcontrolv1beta1.IpRange{})
+	assert.NoError(suite.T(), state.LoadObj(suite.ctx))
+	return state
+}
+
+func (suite *ttlAfterCreationSuite) loadIpRange(state State) *cloudcontrolv1beta1.IpRange {
+	ipRange := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), ipRange))
+	return ipRange
+}
+
+func (suite *ttlAfterCreationSuite) TestExpiryRequeuedThenFired() {
+	state := suite.newState(10*time.Second, ptr.To(int64(600)), nil)
+
+	called := false
+	err, _ := TtlAfterCreation(func(ctx context.Context, state State) (error, context.Context) {
+		called = true
+		return StopAndForget, nil
+	})(suite.ctx, state)
+
+	assert.True(suite.T(), called)
+	var delayErr *stopWithRequeueDelay
+	assert.True(suite.T(), errors.As(err, &delayErr), "requeued to fire at expiry")
+	assert.InDelta(suite.T(), float64(590*time.Second), float64(delayErr.Delay()), float64(5*time.Second))
+
+	ipRange := suite.loadIpRange(state)
+	assert.NotNil(suite.T(), ipRange.Status.TtlExpiresAt)
+	assert.True(suite.T(), ipRange.Status.TtlExpiresAt.Time.Equal(ipRange.CreationTimestamp.Add(600*time.Second)))
+
+	// the time to live elapsed
+	ipRange.Spec.TtlSecondsAfterCreation = ptr.To(int64(5))
+	state.SetObj(ipRange)
+	called = false
+	err, _ = TtlAfterCreation(func(ctx context.Context, state State) (error, context.Context) {
+		called = true
+		return nil, nil
+	})(suite.ctx, state)
+
+	assert.Equal(suite.T(), StopWithRequeue, err)
+	assert.False(suite.T(), called)
+	ipRange = suite.loadIpRange(state)
+	assert.False(suite.T(), ipRange.DeletionTimestamp.IsZero(), "marked for deletion to proceed through the delete flow")
+}
+
+func (suite *ttlAfterCreationSuite) TestRemovedTtlCancelsDeletion() {
+	state := suite.newState(time.Hour, nil, &metav1.Time{Time: time.Now().Add(-time.Minute).Truncate(time.Second)})
+
+	err, _ := TtlAfterCreation(func(ctx context.Context, state State) (error, context.Context) {
+		return StopAndForget, nil
+	})(suite.ctx, state)
+
+	assert.Equal(suite.T(), StopAndForget, err, "the result of the action is not changed")
+	ipRange := suite.loadIpRange(state)
+	assert.True(suite.T(), ipRange.DeletionTimestamp.IsZero())
+	assert.Nil(suite.T(), ipRange.Status.TtlExpiresAt)
+}
+
+func (suite *ttlAfterCreationSuite) TestShorterRequeueIsKept() {
+	state := suite.newState(10*time.Second, ptr.To(int64(600)), nil)
+
+	err, _ := TtlAfterCreation(func(ctx context.Context, state State) (error, context.Context) {
+		return StopWithRequeueDelay(time.Second), nil
+	})(suite.ctx, state)
+
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Second), err)
+}
+
+func TestTtlAfterCreation(t *testing.T) {
+	suite.Run(t, new(ttlAfterCreationSuite))
+}
@@ -3,8 +3,11 @@ package composed
 import (
 	"context"
 	"fmt"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,6 +18,10 @@ const (
 	applyServerSide applyType = 2
 )
 
+// DefaultStatusConflictRetries is the number of times the status write failed with the conflict is retried
+// on the freshly loaded object, before the reconciliation is requeued
+var DefaultStatusConflictRetries = 3
+
 type ObjWithConditionsAndState interface {
 	ObjWithConditions
 	State() string
@@ -52,6 +59,7 @@ type UpdateStatusBuilder struct {
 	conditionsToKeep   map[string]struct{}
 	conditionsToRemove map[string]struct{}
 	conditionsToSet    []metav1.Condition
+	exclusive          bool
 	updateErrorLogMsg  string
 	successLogMsg      string
 	failedError        error
@@ -61,6 +69,8 @@ type UpdateStatusBuilder struct {
 	onUpdateError      func(ctx context.Context, err error) (error, context.Context)
 	onUpdateSuccess    func(ctx context.Context) (error, context.Context)
 	conditionsToState  func(obj ObjWithConditions) (string, bool)
+	historyLimit       int
+	conflictRetries    *int
 }
 
 func (b *UpdateStatusBuilder) KeepConditions(conditionTypes ...string) *UpdateStatusBuilder {
@@ -92,7 +102,7 @@ func (b *UpdateStatusBuilder) RemoveConditionIfReasonMatched(conditionType, cond
 	b.conditionsToKeep = nil
 	for i := range *b.obj.Conditions() {
 		condition := (*b.obj.Conditions())[i]
-		if condition.Type == conditionType && condition.Reason == conditionReason {
+		if condition.Type == PublicConditionType(conditionType) && condition.Reason == conditionReason {
 			b.conditionsToRemove[conditionType] = struct{}{}
 		}
 	}
@@ -105,13 +115,17 @@ func (b *UpdateStatusBuilder) SetCondition(cond metav1.Condition) *UpdateStatusB
 }
 
 func (b *UpdateStatusBuilder) SetExclusiveConditions(conditions ...metav1.Condition) *UpdateStatusBuilder {
-	// Remove all conditions and set the new ones passed as argument
+	// Remove all conditions, except the readiness gates and the optional features, and set the new ones passed as argument
 	if b.conditionsToRemove == nil {
 		b.conditionsToRemove = map[string]struct{}{}
 	}
+	gates := readinessGateTypes(b.obj)
 	for _, c := range *b.obj.Conditions() {
-		b.conditionsToRemove[c.Type] = struct{}{}
+		if _, gate := gates[c.Type]; !gate && !isOptionalConditionType(c.Type) {
+			b.conditionsToRemove[c.Type] = struct{}{}
+		}
 	}
+	b.exclusive = true
 	b.conditionsToSet = conditions
 	return b
 }
@@ -161,31 +175,123 @@ func (b *UpdateStatusBuilder) DeriveStateFromConditions(f func(obj ObjWithCondit
 	return b
 }
 
+// ConditionHistoryLimit sets the number of condition transitions kept in the history of the objects
+// implementing ObjWithConditionHistory, DefaultConditionHistoryLimit if not set
+func (b *UpdateStatusBuilder) ConditionHistoryLimit(limit int) *UpdateStatusBuilder {
+	b.historyLimit = limit
+	return b
+}
+
+// ConflictRetries sets the number of times the status write failed with the conflict is retried
+// on the freshly loaded object, DefaultStatusConflictRetries if not set. Only the writes that change
+// nothing but the conditions are retried, the others requeue the reconciliation.
+func (b *UpdateStatusBuilder) ConflictRetries(retries int) *UpdateStatusBuilder {
+	b.conflictRetries = &retries
+	return b
+}
+
 func (b *UpdateStatusBuilder) Run(ctx context.Context, state State) (error, context.Context) {
 	b.setDefaults()
 
-	if b.conditionsToRemove == nil {
-		if b.conditionsToKeep != nil {
-			b.conditionsToRemove = map[string]struct{}{}
-			for _, c := range *b.obj.Conditions() {
-				_, keep := b.conditionsToKeep[c.Type]
-				if !keep {
-					b.conditionsToRemove[c.Type] = struct{}{}
-				}
-			}
+	b.applyConditions(true)
+	err := b.writeStatus(ctx, state)
+	for attempt := 1; apierrors.IsConflict(err) && attempt <= *b.conflictRetries; attempt++ {
+		LoggerFromCtx(ctx).
+			WithValues("attempt", attempt).
+			Info("Conflict updating status, retrying on the fresh object")
+		retry, refreshErr := b.refreshObj(ctx, state)
+		if refreshErr != nil {
+			err = refreshErr
+			break
 		}
+		if !retry {
+			LoggerFromCtx(ctx).Info("Conflict updating status fields other than the conditions, not retrying")
+			break
+		}
+		// the transitions were recorded against the conditions the reconciliation started with
+		b.applyConditions(false)
+		err = b.writeStatus(ctx, state)
+	}
+	if err != nil {
+		err = b.updateErrorWrapper(err)
+		res, resCtx := b.onUpdateError(ctx, err)
+		recordLastError(ctx, ReasonStatusUpdateFailed, b.updateErrorLogMsg, err)
+		return res, resCtx
 	}
 
-	if b.conditionsToRemove != nil {
-		for c := range b.conditionsToRemove {
-			_ = meta.RemoveStatusCondition(b.obj.Conditions(), c)
+	if len(b.successLogMsg) > 0 {
+		logger := LoggerFromCtx(ctx)
+		logger.Info(b.successLogMsg)
+	}
+
+	return b.onUpdateSuccess(ctx)
+}
+
+// applyConditions removes and sets the conditions, and derives the state, on the current conditions of the object
+func (b *UpdateStatusBuilder) applyConditions(recordHistory bool) {
+	previousConditions := append([]metav1.Condition(nil), *b.obj.Conditions()...)
+
+	// the readiness gates are evaluated on the conditions written by others, and the conditions of the
+	// optional features are removed only by their actions, so they are kept when the conditions to
+	// remove are derived from the keep list or the exclusive set
+	gates := readinessGateTypes(b.obj)
+
+	conditionsToRemove := b.conditionsToRemove
+	if conditionsToRemove == nil && b.conditionsToKeep != nil {
+		// the keep list is given with the internal names
+		conditionsToKeep := make(map[string]struct{}, len(b.conditionsToKeep))
+		for c := range b.conditionsToKeep {
+			conditionsToKeep[PublicConditionType(c)] = struct{}{}
 		}
+		conditionsToRemove = map[string]struct{}{}
+		for _, c := range *b.obj.Conditions() {
+			_, keep := conditionsToKeep[c.Type]
+			_, gate := gates[c.Type]
+			if !keep && !gate && !isOptionalConditionType(c.Type) {
+				conditionsToRemove[c.Type] = struct{}{}
+			}
+		}
+	}
+	if b.exclusive {
+		// the fresh object might have the conditions set in the meantime
+		if conditionsToRemove == nil {
+			conditionsToRemove = map[string]struct{}{}
+		}
+		for _, c := range *b.obj.Conditions() {
+			if _, gate := gates[c.Type]; !gate && !isOptionalConditionType(c.Type) {
+				conditionsToRemove[c.Type] = struct{}{}
+			}
+		}
+	}
+
+	for c := range conditionsToRemove {
+		_ = meta.RemoveStatusCondition(b.obj.Conditions(), PublicConditionType(c))
 	}
 
+	var readyCondition *metav1.Condition
 	for _, c := range b.conditionsToSet {
+		c = ConditionWithSeverity(c)
+		if c.Type == conditionTypeReady {
+			// the readiness gates are evaluated once the other conditions are set
+			readyCondition = &c
+			continue
+		}
+		c.Type = PublicConditionType(c.Type)
+		_ = meta.SetStatusCondition(b.obj.Conditions(), c)
+	}
+	if readyCondition != nil {
+		c, waiting := waitingForGatesCondition(b.obj, *readyCondition)
+		if waiting && b.successError == StopAndForget {
+			b.successError = StopWithRequeueDelay(ReadinessGatesRequeueDelay)
+		}
+		c.Type = PublicConditionType(c.Type)
 		_ = meta.SetStatusCondition(b.obj.Conditions(), c)
 	}
 
+	if recordHistory {
+		recordConditionTransitions(b.obj, previousConditions, b.historyLimit)
+	}
+
 	//Set state based on conditions
 	withState, ok := b.obj.(ObjWithConditionsAndState)
 	if b.conditionsToState != nil && ok {
@@ -194,23 +300,64 @@ func (b *UpdateStatusBuilder) Run(ctx context.Context, state State) (error, cont
 			withState.SetState(newState)
 		}
 	}
-	var err error
-	if b.applyType == applyUpdate {
-		err = state.UpdateObjStatus(ctx)
-	} else {
-		err = state.PatchObjStatus(ctx)
+}
+
+// writeStatus writes the status with the fields configured by SetStatusRedactions redacted, while the
+// object in memory keeps their values for the rest of the reconciliation
+func (b *UpdateStatusBuilder) writeStatus(ctx context.Context, state State) error {
+	return writeRedactedStatus(state.Obj(), func() error {
+		if b.applyType == applyUpdate {
+			return state.UpdateObjStatus(ctx)
+		}
+		return state.PatchObjStatus(ctx)
+	})
+}
+
+// refreshObj loads the object with the API reader, since the cache might still hold the stale resource
+// version, and takes its resource version and conditions, so the conditions can be re-applied on them.
+// Only the conditions are re-applied, so it returns false when the written status differs from the fresh
+// one in other fields, as writing them would overwrite the fields set by the others in the meantime.
+func (b *UpdateStatusBuilder) refreshObj(ctx context.Context, state State) (bool, error) {
+	fresh, ok := b.obj.DeepCopyObject().(ObjWithConditions)
+	if !ok {
+		return false, fmt.Errorf("unable to copy %T", b.obj)
 	}
-	if err != nil {
-		err = b.updateErrorWrapper(err)
-		return b.onUpdateError(ctx, err)
+	reader := state.Cluster().ApiReader()
+	if reader == nil {
+		reader = state.Cluster().K8sClient()
+	}
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(b.obj), fresh); err != nil {
+		return false, err
 	}
 
-	if len(b.successLogMsg) > 0 {
-		logger := LoggerFromCtx(ctx)
-		logger.Info(b.successLogMsg)
+	written, err := statusWithoutConditions(b.obj)
+	if err != nil {
+		return false, err
+	}
+	loaded, err := statusWithoutConditions(fresh)
+	if err != nil {
+		return false, err
+	}
+	if !equality.Semantic.DeepEqual(written, loaded) {
+		return false, nil
 	}
 
-	return b.onUpdateSuccess(ctx)
+	b.obj.SetResourceVersion(fresh.GetResourceVersion())
+	*b.obj.Conditions() = append([]metav1.Condition(nil), *fresh.Conditions()...)
+	return true, nil
+}
+
+// statusWithoutConditions returns the status of the object without the conditions and the fields derived from them
+func statusWithoutConditions(obj client.Object) (map[string]interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	status, _ := u["status"].(map[string]interface{})
+	delete(status, "conditions")
+	delete(status, "conditionHistory")
+	delete(status, "state")
+	return status, nil
 }
 
 func (b *UpdateStatusBuilder) setDefaults() {
@@ -228,6 +375,13 @@ func (b *UpdateStatusBuilder) setDefaults() {
 	if b.failedError == nil {
 		b.failedError = StopWithRequeue
 	}
+	if b.historyLimit <= 0 {
+		b.historyLimit = DefaultConditionHistoryLimit
+	}
+	if b.conflictRetries == nil {
+		retries := DefaultStatusConflictRetries
+		b.conflictRetries = &retries
+	}
 
 	if b.onUpdateError == nil {
 		b.onUpdateError = func(ctx context.Context, err error) (error, context.Context) {
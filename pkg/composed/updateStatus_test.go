@@ -0,0 +1,155 @@
+package composed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestUpdateStatusConflictRetry(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newState := func(funcs interceptor.Funcs) (State, *cloudcontrolv1beta1.IpRange, client.Client) {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+		obj := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "iprange"},
+		}
+		clnt := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(obj).
+			WithStatusSubresource(obj).
+			WithInterceptorFuncs(funcs).
+			Build()
+		loaded := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), loaded))
+		// the cache still holds the object as it was loaded, only the API reader returns the fresh one
+		stale := loaded.DeepCopy()
+		cached := interceptor.NewClient(clnt.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if ipRange, ok := obj.(*cloudcontrolv1beta1.IpRange); ok {
+					stale.DeepCopyInto(ipRange)
+					return nil
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+		cluster := NewStateCluster(cached, clnt, nil, scheme)
+		return NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, loaded), loaded, clnt
+	}
+
+	// concurrentWrite sets the condition on the stored object, so the object held by the state is stale
+	concurrentWrite := func(clnt client.Client, cond metav1.Condition) {
+		other := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, types.NamespacedName{Namespace: "default", Name: "iprange"}, other))
+		meta.SetStatusCondition(&other.Status.Conditions, cond)
+		assert.NoError(t, clnt.Status().Update(ctx, other))
+	}
+
+	readyCondition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "Ready",
+	}
+	otherCondition := metav1.Condition{
+		Type:    "Other",
+		Status:  metav1.ConditionTrue,
+		Reason:  "Other",
+		Message: "Set concurrently",
+	}
+
+	t.Run("conflict is retried on the fresh object", func(t *testing.T) {
+		state, obj, clnt := newState(interceptor.Funcs{})
+		concurrentWrite(clnt, otherCondition)
+
+		err, _ := UpdateStatus(obj).
+			SetCondition(readyCondition).
+			SuccessErrorNil().
+			Run(ctx, state)
+		assert.NoError(t, err)
+
+		stored := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), stored))
+		assert.NotNil(t, meta.FindStatusCondition(stored.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+		assert.NotNil(t, meta.FindStatusCondition(stored.Status.Conditions, "Other"))
+	})
+
+	t.Run("conflict of write changing other fields is not retried", func(t *testing.T) {
+		state, obj, clnt := newState(interceptor.Funcs{})
+		// the other writer sets a status field as well
+		other := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), other))
+		other.Status.VpcId = "vpc-1"
+		assert.NoError(t, clnt.Status().Update(ctx, other))
+
+		obj.Status.Cidr = "10.250.0.0/22"
+		err, _ := UpdateStatus(obj).
+			SetCondition(readyCondition).
+			SuccessErrorNil().
+			Run(ctx, state)
+		assert.Equal(t, StopWithRequeue, err)
+
+		stored := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), stored))
+		assert.Equal(t, "vpc-1", stored.Status.VpcId, "the field written by the other is kept")
+		assert.Empty(t, stored.Status.Cidr)
+		assert.Nil(t, meta.FindStatusCondition(stored.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	})
+
+	t.Run("exclusive conditions remove the concurrently set ones", func(t *testing.T) {
+		state, obj, clnt := newState(interceptor.Funcs{})
+		concurrentWrite(clnt, otherCondition)
+
+		err, _ := UpdateStatus(obj).
+			SetExclusiveConditions(readyCondition).
+			SuccessErrorNil().
+			Run(ctx, state)
+		assert.NoError(t, err)
+
+		stored := &cloudcontrolv1beta1.IpRange{}
+		assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(obj), stored))
+		assert.Len(t, stored.Status.Conditions, 1)
+		assert.Equal(t, cloudcontrolv1beta1.ConditionTypeReady, stored.Status.Conditions[0].Type)
+	})
+
+	t.Run("exhausted retries requeue", func(t *testing.T) {
+		updates := 0
+		state, obj, _ := newState(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				updates++
+				// keep the object held by the state stale on every attempt
+				other := &cloudcontrolv1beta1.IpRange{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), other); err != nil {
+					return err
+				}
+				if err := c.SubResource(subResourceName).Update(ctx, other); err != nil {
+					return err
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		})
+
+		err, _ := UpdateStatus(obj).
+			SetCondition(readyCondition).
+			ConflictRetries(2).
+			SuccessErrorNil().
+			Run(ctx, state)
+		assert.Equal(t, StopWithRequeue, err)
+		assert.Equal(t, 3, updates)
+	})
+}
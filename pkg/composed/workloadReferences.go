@@ -0,0 +1,145 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConditionTypeInUseByWorkloads = "InUseByWorkloads"
+	ReasonInUseByWorkloads        = "InUseByWorkloads"
+)
+
+// WorkloadReferencesFunc returns the active workload references of the state object
+type WorkloadReferencesFunc func(ctx context.Context, state State) ([]string, error)
+
+// LabelSelectorWorkloadReferences returns a WorkloadReferencesFunc that lists the objects of the list
+// created by newList in the state object namespace having the given label with the state object name
+// as value, and returns them as namespace/name references. If the listed kind is not installed in
+// the cluster, no references are returned.
+func LabelSelectorWorkloadReferences(newList func() client.ObjectList, labelKey string) WorkloadReferencesFunc {
+	return func(ctx context.Context, state State) ([]string, error) {
+		list := newList()
+		err := state.Cluster().K8sClient().List(
+			ctx,
+			list,
+			client.InNamespace(state.Obj().GetNamespace()),
+			client.MatchingLabels{labelKey: state.Obj().GetName()},
+		)
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		var result []string
+		for _, item := range items {
+			o, err := meta.Accessor(item)
+			if err != nil {
+				return nil, err
+			}
+			if o.GetDeletionTimestamp() != nil {
+				// the reference is being removed and should not block the deletion
+				continue
+			}
+			result = append(result, fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName()))
+		}
+		return result, nil
+	}
+}
+
+// PreventDeleteWhileInUseByWorkloads returns a delete path action that refuses the deletion of the
+// object while any of the given funcs returns an active workload reference. In that case it sets the
+// InUseByWorkloads condition listing the references and requeues after the given delay, so the cloud
+// resources are deleted only once the references drop to zero. When there are no references the
+// InUseByWorkloads condition is removed, if present, and the flow continues. The objects not marked
+// for deletion or not implementing ObjWithConditions are not checked.
+func PreventDeleteWhileInUseByWorkloads(requeueDelay time.Duration, funcs ...WorkloadReferencesFunc) Action {
+	return blockDeleteWhileReferenced(
+		ConditionTypeInUseByWorkloads,
+		ReasonInUseByWorkloads,
+		"Can not be deleted while used by: %s",
+		requeueDelay,
+		func(ctx context.Context, state State) ([]string, error) {
+			var references []string
+			for _, f := range funcs {
+				refs, err := f(ctx, state)
+				if err != nil {
+					return nil, err
+				}
+				references = append(references, refs...)
+			}
+			return references, nil
+		},
+	)
+}
+
+// blockDeleteWhileReferenced returns a delete path action that sets the condition of the given type
+// and requeues while the given func returns any reference, and removes the condition once there are none
+func blockDeleteWhileReferenced(
+	conditionType, reason, messageFormat string,
+	requeueDelay time.Duration,
+	loadReferences func(ctx context.Context, state State) ([]string, error),
+) Action {
+	return func(ctx context.Context, state State) (error, context.Context) {
+		if !IsMarkedForDeletion(state.Obj()) {
+			return nil, nil
+		}
+		obj, ok := state.Obj().(ObjWithConditions)
+		if !ok {
+			return nil, nil
+		}
+
+		references, err := loadReferences(ctx, state)
+		if err != nil {
+			return LogErrorAndReturn(err, fmt.Sprintf("Error loading references for %s", conditionType), StopWithRequeue, ctx)
+		}
+		references = pie.Sort(pie.Unique(references))
+
+		if len(references) == 0 {
+			if meta.FindStatusCondition(*obj.Conditions(), PublicConditionType(conditionType)) == nil {
+				return nil, nil
+			}
+			return UpdateStatus(obj).
+				RemoveConditions(conditionType).
+				ErrorLogMessage(fmt.Sprintf("Error updating status after removing %s condition", conditionType)).
+				SuccessLogMsg("References dropped to zero, continuing with delete").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		LoggerFromCtx(ctx).
+			WithValues(
+				"conditionType", conditionType,
+				"references", references,
+			).
+			Info("Object marked for deletion is still referenced")
+
+		condition := metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: fmt.Sprintf(messageFormat, strings.Join(references, ", ")),
+		}
+		if !AnyConditionChanged(obj, condition) {
+			return StopWithRequeueDelay(requeueDelay), nil
+		}
+
+		return UpdateStatus(obj).
+			SetCondition(condition).
+			ErrorLogMessage(fmt.Sprintf("Error updating status with %s condition", conditionType)).
+			SuccessError(StopWithRequeueDelay(requeueDelay)).
+			Run(ctx, state)
+	}
+}
@@ -0,0 +1,118 @@
+package composed
+
+import (
+	"testing"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testWorkloadReferenceLabel = "cloud-manager.kyma-project.io/test-nfs-instance"
+
+type workloadReferencesSuite struct {
+	testStateSuite
+}
+
+func (suite *workloadReferencesSuite) newObj(deleting bool) *cloudcontrolv1beta1.NfsInstance {
+	obj := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "nfs",
+			Finalizers: []string{"test"},
+		},
+	}
+	if deleting {
+		obj.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	}
+	return obj
+}
+
+func (suite *workloadReferencesSuite) newBinding(name, nfsName string, deleting bool) *corev1.ConfigMap {
+	binding := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{testWorkloadReferenceLabel: nfsName},
+		},
+	}
+	if deleting {
+		binding.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		binding.Finalizers = []string{"test"}
+	}
+	return binding
+}
+
+func (suite *workloadReferencesSuite) references() WorkloadReferencesFunc {
+	return LabelSelectorWorkloadReferences(func() client.ObjectList { return &corev1.ConfigMapList{} }, testWorkloadReferenceLabel)
+}
+
+func (suite *workloadReferencesSuite) newState(obj *cloudcontrolv1beta1.NfsInstance, objs ...client.Object) State {
+	return newTestState(obj, objs...)
+}
+
+func (suite *workloadReferencesSuite) loadObj(state State) *cloudcontrolv1beta1.NfsInstance {
+	obj := &cloudcontrolv1beta1.NfsInstance{}
+	err := state.Cluster().K8sClient().Get(suite.ctx, state.Name(), obj)
+	assert.NoError(suite.T(), err)
+	return obj
+}
+
+func (suite *workloadReferencesSuite) TestDeleteBlockedByLabeledWorkloads() {
+	state := suite.newState(
+		suite.newObj(true),
+		suite.newBinding("binding-b", "nfs", false),
+		suite.newBinding("binding-a", "nfs", false),
+		suite.newBinding("other", "other-nfs", false),
+	)
+
+	err, _ := PreventDeleteWhileInUseByWorkloads(time.Minute, suite.references())(suite.ctx, state)
+	assert.Equal(suite.T(), StopWithRequeueDelay(time.Minute), err)
+
+	cond := meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeInUseByWorkloads)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+	assert.Equal(suite.T(), ReasonInUseByWorkloads, cond.Reason)
+	assert.Equal(suite.T(), "Can not be deleted while used by: default/binding-a, default/binding-b", cond.Message)
+}
+
+func (suite *workloadReferencesSuite) TestDeleteAllowedWhenReferencesDropToZero() {
+	obj := suite.newObj(true)
+	obj.Status.Conditions = []metav1.Condition{{
+		Type:    ConditionTypeInUseByWorkloads,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonInUseByWorkloads,
+		Message: "Can not be deleted while used by: default/binding-a",
+	}}
+	state := suite.newState(obj, suite.newBinding("binding-a", "nfs", true))
+
+	err, _ := PreventDeleteWhileInUseByWorkloads(time.Minute, suite.references())(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+
+	assert.Nil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeInUseByWorkloads))
+}
+
+func (suite *workloadReferencesSuite) TestDeleteAllowedWithoutReferences() {
+	state := suite.newState(suite.newObj(true))
+
+	err, _ := PreventDeleteWhileInUseByWorkloads(time.Minute, suite.references())(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *workloadReferencesSuite) TestNotCheckedWhenNotDeleting() {
+	state := suite.newState(suite.newObj(false), suite.newBinding("binding-a", "nfs", false))
+
+	err, _ := PreventDeleteWhileInUseByWorkloads(time.Minute, suite.references())(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+
+	assert.Nil(suite.T(), meta.FindStatusCondition(suite.loadObj(state).Status.Conditions, ConditionTypeInUseByWorkloads))
+}
+
+func TestWorkloadReferences(t *testing.T) {
+	suite.Run(t, new(workloadReferencesSuite))
+}
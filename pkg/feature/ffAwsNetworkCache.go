@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const awsNetworkCacheFlagName = "awsNetworkCache"
+
+// AwsNetworkCache when enabled serves the AWS VPC and subnet reads of the IpRange reconciler
+// from the per account and region inventory cache instead of calling the API on each reconcile
+var AwsNetworkCache = &awsNetworkCacheInfo{}
+
+type awsNetworkCacheInfo struct{}
+
+func (k *awsNetworkCacheInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, awsNetworkCacheFlagName, false)
+}
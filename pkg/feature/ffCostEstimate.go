@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const costEstimateFlagName = "costEstimate"
+
+// CostEstimate when enabled writes the approximate monthly cost of the provisioned
+// cloud resources, computed from a static pricing table, into the object status
+var CostEstimate = &costEstimateInfo{}
+
+type costEstimateInfo struct{}
+
+func (k *costEstimateInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, costEstimateFlagName, false)
+}
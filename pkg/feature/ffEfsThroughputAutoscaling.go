@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const efsThroughputAutoscalingFlagName = "efsThroughputAutoscaling"
+
+// EfsThroughputAutoscaling when enabled scales the provisioned throughput of the AWS EFS file
+// systems within their spec bounds by the throughput utilization reported by CloudWatch
+var EfsThroughputAutoscaling = &efsThroughputAutoscalingInfo{}
+
+type efsThroughputAutoscalingInfo struct{}
+
+func (k *efsThroughputAutoscalingInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, efsThroughputAutoscalingFlagName, false)
+}
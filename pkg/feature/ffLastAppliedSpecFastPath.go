@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const lastAppliedSpecFastPathFlagName = "lastAppliedSpecFastPath"
+
+// LastAppliedSpecFastPath when enabled skips the cloud reconciliation of the Ready objects whose
+// spec did not change since it was last applied, until the periodic drift resync
+var LastAppliedSpecFastPath = &lastAppliedSpecFastPathInfo{}
+
+type lastAppliedSpecFastPathInfo struct{}
+
+func (k *lastAppliedSpecFastPathInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, lastAppliedSpecFastPathFlagName, false)
+}
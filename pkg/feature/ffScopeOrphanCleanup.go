@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const scopeOrphanCleanupFlagName = "scopeOrphanCleanup"
+
+// ScopeOrphanCleanup when enabled deletes the objects whose Scope is missing or being deleted,
+// instead of only marking them with the ScopeMissing condition
+var ScopeOrphanCleanup = &scopeOrphanCleanupInfo{}
+
+type scopeOrphanCleanupInfo struct{}
+
+func (k *scopeOrphanCleanupInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, scopeOrphanCleanupFlagName, false)
+}
@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const serverSideApplyFlagName = "serverSideApply"
+
+// ServerSideApply when enabled makes the generic actions server-side apply the object finalizers and labels
+// with a dedicated field manager, instead of the legacy update and patch
+var ServerSideApply = &serverSideApplyInfo{}
+
+type serverSideApplyInfo struct{}
+
+func (k *serverSideApplyInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, serverSideApplyFlagName, false)
+}
@@ -0,0 +1,15 @@
+package feature
+
+import "context"
+
+const tagValueTruncationFlagName = "tagValueTruncation"
+
+// TagValueTruncation when enabled truncates the too long values of non-critical cloud resource tags
+// instead of rejecting them
+var TagValueTruncation = &tagValueTruncationInfo{}
+
+type tagValueTruncationInfo struct{}
+
+func (k *tagValueTruncationInfo) Value(ctx context.Context) bool {
+	return provider.BoolVariation(ctx, tagValueTruncationFlagName, false)
+}
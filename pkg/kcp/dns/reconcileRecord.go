@@ -0,0 +1,187 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientFunc returns the DNS client of the state
+type ClientFunc func(state composed.State) Client
+
+// EndpointFunc returns the endpoint of the state resource, an IP or a host, or empty if not yet known
+type EndpointFunc func(state composed.State) string
+
+// ReconcileRecord returns the action keeping the record in the spec.dns.zoneName zone pointing at the
+// resource endpoint. The record is recreated if it drifted or is missing, and the record of the previous
+// zone or name is deleted if the spec.dns changed or was removed. The fully qualified record name is
+// published in the status. On the DNS API errors the DnsRecordFailed condition is set, and removed once
+// the record is reconciled.
+func ReconcileRecord(clientFn ClientFunc, endpointFn EndpointFunc) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithDns)
+		if !ok {
+			return nil, nil
+		}
+		logger := composed.LoggerFromCtx(ctx)
+		clnt := clientFn(state)
+
+		opts := obj.DnsOptions()
+		current := obj.DnsStatus()
+
+		if opts == nil {
+			if current == nil {
+				return nil, nil
+			}
+			logger.Info("Deleting DNS record of removed dns spec")
+			if err := clnt.DeleteRecord(ctx, zoneId(current.ZoneName, current.HostedZoneId), current.Fqdn+".", current.RecordType); err != nil {
+				return dnsRecordFailed(ctx, state, obj, err)
+			}
+			obj.SetDnsStatus(nil)
+			return composed.UpdateStatus(obj).
+				RemoveConditions(cloudcontrolv1beta1.ConditionTypeDnsRecordFailed).
+				ErrorLogMessage("Error updating status after deleting DNS record").
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+
+		endpoint := endpointFn(state)
+		if len(endpoint) == 0 {
+			return nil, nil
+		}
+
+		zoneDnsName, err := clnt.GetZoneDnsName(ctx, zoneId(opts.ZoneName, opts.HostedZoneId))
+		if err != nil {
+			return dnsRecordFailed(ctx, state, obj, err)
+		}
+		desired := desiredRecord(obj, opts, zoneDnsName, endpoint)
+		desiredStatus := &cloudcontrolv1beta1.DnsStatus{
+			Fqdn:         strings.TrimSuffix(desired.Name, "."),
+			ZoneName:     opts.ZoneName,
+			HostedZoneId: opts.HostedZoneId,
+			RecordType:   desired.Type,
+		}
+
+		if current != nil && *current != *desiredStatus {
+			logger.
+				WithValues(
+					"dnsZone", current.ZoneName,
+					"dnsRecord", current.Fqdn,
+				).
+				Info("Deleting previous DNS record")
+			if err := clnt.DeleteRecord(ctx, zoneId(current.ZoneName, current.HostedZoneId), current.Fqdn+".", current.RecordType); err != nil {
+				return dnsRecordFailed(ctx, state, obj, err)
+			}
+		}
+
+		actual, err := clnt.GetRecord(ctx, zoneId(opts.ZoneName, opts.HostedZoneId), desired.Name, desired.Type)
+		if err != nil {
+			return dnsRecordFailed(ctx, state, obj, err)
+		}
+		if actual == nil || !reflect.DeepEqual(*actual, desired) {
+			logger.
+				WithValues(
+					"dnsZone", opts.ZoneName,
+					"dnsRecord", desired.Name,
+					"dnsValues", desired.Values,
+				).
+				Info("Upserting DNS record")
+			if err := clnt.UpsertRecord(ctx, zoneId(opts.ZoneName, opts.HostedZoneId), desired); err != nil {
+				return dnsRecordFailed(ctx, state, obj, err)
+			}
+		}
+
+		if current != nil && *current == *desiredStatus &&
+			meta.FindStatusCondition(*obj.Conditions(), cloudcontrolv1beta1.ConditionTypeDnsRecordFailed) == nil {
+			return nil, nil
+		}
+
+		obj.SetDnsStatus(desiredStatus)
+		return composed.UpdateStatus(obj).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeDnsRecordFailed).
+			ErrorLogMessage("Error updating status with DNS record").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
+
+// DeleteRecord returns the action deleting the record published in the status, on the resource teardown
+func DeleteRecord(clientFn ClientFunc) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(ObjWithDns)
+		if !ok || obj.DnsStatus() == nil {
+			return nil, nil
+		}
+		current := obj.DnsStatus()
+
+		composed.LoggerFromCtx(ctx).
+			WithValues(
+				"dnsZone", current.ZoneName,
+				"dnsRecord", current.Fqdn,
+			).
+			Info("Deleting DNS record")
+
+		if err := clientFn(state).DeleteRecord(ctx, zoneId(current.ZoneName, current.HostedZoneId), current.Fqdn+".", current.RecordType); err != nil {
+			return dnsRecordFailed(ctx, state, obj, err)
+		}
+
+		obj.SetDnsStatus(nil)
+		return composed.UpdateStatus(obj).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeDnsRecordFailed).
+			ErrorLogMessage("Error updating status after deleting DNS record").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+}
+
+// zoneId returns the id the DNS client addresses the zone with, the hosted zone id if set, otherwise the zone name
+func zoneId(zoneName, hostedZoneId string) string {
+	if len(hostedZoneId) > 0 {
+		return hostedZoneId
+	}
+	return zoneName
+}
+
+func desiredRecord(obj ObjWithDns, opts *cloudcontrolv1beta1.DnsOptions, zoneDnsName, endpoint string) Record {
+	recordName := opts.RecordName
+	if len(recordName) == 0 {
+		recordName = obj.GetName()
+	}
+	if !strings.HasSuffix(zoneDnsName, ".") {
+		zoneDnsName = zoneDnsName + "."
+	}
+	record := Record{
+		Name: fmt.Sprintf("%s.%s", recordName, zoneDnsName),
+		Ttl:  DefaultRecordTtl,
+	}
+	if _, err := netip.ParseAddr(endpoint); err == nil {
+		record.Type = RecordTypeA
+		record.Values = []string{endpoint}
+	} else {
+		record.Type = RecordTypeCNAME
+		record.Values = []string{strings.TrimSuffix(endpoint, ".") + "."}
+	}
+	return record
+}
+
+func dnsRecordFailed(ctx context.Context, state composed.State, obj ObjWithDns, err error) (error, context.Context) {
+	composed.LoggerFromCtx(ctx).Error(err, "Error reconciling DNS record")
+	return composed.UpdateStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeDnsRecordFailed,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonDnsRecordFailed,
+			Message: fmt.Sprintf("Failed reconciling DNS record: %s", err),
+		}).
+		ErrorLogMessage("Error updating status with DnsRecordFailed condition").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+		Run(ctx, state)
+}
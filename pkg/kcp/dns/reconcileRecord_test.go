@@ -0,0 +1,209 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeDnsClient struct {
+	zones   map[string]string
+	records map[string]Record
+	err     error
+}
+
+func newFakeDnsClient() *fakeDnsClient {
+	return &fakeDnsClient{
+		zones: map[string]string{
+			"private-zone": "internal.example.com.",
+			"other-zone":   "other.example.com.",
+		},
+		records: map[string]Record{},
+	}
+}
+
+func (c *fakeDnsClient) GetZoneDnsName(ctx context.Context, zoneName string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	dnsName, ok := c.zones[zoneName]
+	if !ok {
+		return "", fmt.Errorf("zone %s not found", zoneName)
+	}
+	return dnsName, nil
+}
+
+func (c *fakeDnsClient) GetRecord(ctx context.Context, zoneName, name, recordType string) (*Record, error) {
+	record, ok := c.records[zoneName+"/"+name+"/"+recordType]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (c *fakeDnsClient) UpsertRecord(ctx context.Context, zoneName string, record Record) error {
+	c.records[zoneName+"/"+record.Name+"/"+record.Type] = record
+	return nil
+}
+
+func (c *fakeDnsClient) DeleteRecord(ctx context.Context, zoneName, name, recordType string) error {
+	delete(c.records, zoneName+"/"+name+"/"+recordType)
+	return nil
+}
+
+type reconcileRecordSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileRecordSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileRecordSuite) newState(dns *cloudcontrolv1beta1.DnsOptions) (composed.State, *cloudcontrolv1beta1.RedisInstance) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	obj := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Dns: dns,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(obj).
+		WithStatusSubresource(obj).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	return composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj), obj
+}
+
+func (suite *reconcileRecordSuite) actions(clnt Client, endpoint *string) (composed.Action, composed.Action) {
+	clientFn := func(composed.State) Client { return clnt }
+	endpointFn := func(composed.State) string { return *endpoint }
+	return ReconcileRecord(clientFn, endpointFn), DeleteRecord(clientFn)
+}
+
+func (suite *reconcileRecordSuite) TestRecordLifecycle() {
+	state, obj := suite.newState(&cloudcontrolv1beta1.DnsOptions{ZoneName: "private-zone"})
+	clnt := newFakeDnsClient()
+	endpoint := ""
+	reconcile, deleteRecord := suite.actions(clnt, &endpoint)
+
+	// endpoint not yet known
+	err, _ := reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), clnt.records)
+	assert.Nil(suite.T(), obj.Status.Dns)
+
+	endpoint = "10.250.4.5"
+	err, _ = reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	expected := Record{Name: "redis.internal.example.com.", Type: RecordTypeA, Ttl: DefaultRecordTtl, Values: []string{"10.250.4.5"}}
+	assert.Equal(suite.T(), map[string]Record{"private-zone/redis.internal.example.com./A": expected}, clnt.records)
+	assert.Equal(suite.T(), &cloudcontrolv1beta1.DnsStatus{
+		Fqdn:       "redis.internal.example.com",
+		ZoneName:   "private-zone",
+		RecordType: RecordTypeA,
+	}, obj.Status.Dns)
+
+	// drift is reconciled
+	clnt.records["private-zone/redis.internal.example.com./A"] = Record{Name: "redis.internal.example.com.", Type: RecordTypeA, Ttl: 60, Values: []string{"10.0.0.1"}}
+	err, _ = reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, clnt.records["private-zone/redis.internal.example.com./A"])
+
+	// teardown
+	err, _ = deleteRecord(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), clnt.records)
+	assert.Nil(suite.T(), obj.Status.Dns)
+}
+
+func (suite *reconcileRecordSuite) TestZoneChangeAndSpecRemoval() {
+	state, obj := suite.newState(&cloudcontrolv1beta1.DnsOptions{ZoneName: "private-zone"})
+	clnt := newFakeDnsClient()
+	endpoint := "redis.example.internal"
+	reconcile, _ := suite.actions(clnt, &endpoint)
+
+	err, _ := reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), clnt.records, "private-zone/redis.internal.example.com./CNAME")
+	assert.Equal(suite.T(), []string{"redis.example.internal."}, clnt.records["private-zone/redis.internal.example.com./CNAME"].Values)
+
+	obj.Spec.Dns = &cloudcontrolv1beta1.DnsOptions{ZoneName: "other-zone", RecordName: "cache"}
+	err, _ = reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), clnt.records, 1)
+	assert.Contains(suite.T(), clnt.records, "other-zone/cache.other.example.com./CNAME")
+	assert.Equal(suite.T(), "cache.other.example.com", obj.Status.Dns.Fqdn)
+
+	obj.Spec.Dns = nil
+	err, _ = reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), clnt.records)
+	assert.Nil(suite.T(), obj.Status.Dns)
+}
+
+func (suite *reconcileRecordSuite) TestHostedZoneIdAddressesZone() {
+	state, obj := suite.newState(&cloudcontrolv1beta1.DnsOptions{ZoneName: "internal.example.com", HostedZoneId: "Z0123456789"})
+	clnt := newFakeDnsClient()
+	clnt.zones["Z0123456789"] = "internal.example.com."
+	endpoint := "10.250.4.5"
+	reconcile, deleteRecord := suite.actions(clnt, &endpoint)
+
+	err, _ := reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), clnt.records, "Z0123456789/redis.internal.example.com./A")
+	assert.Equal(suite.T(), &cloudcontrolv1beta1.DnsStatus{
+		Fqdn:         "redis.internal.example.com",
+		ZoneName:     "internal.example.com",
+		HostedZoneId: "Z0123456789",
+		RecordType:   RecordTypeA,
+	}, obj.Status.Dns)
+
+	err, _ = deleteRecord(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), clnt.records)
+}
+
+func (suite *reconcileRecordSuite) TestApiErrorSetsCondition() {
+	state, obj := suite.newState(&cloudcontrolv1beta1.DnsOptions{ZoneName: "private-zone"})
+	clnt := newFakeDnsClient()
+	clnt.err = errors.New("permission denied")
+	endpoint := "10.250.4.5"
+	reconcile, _ := suite.actions(clnt, &endpoint)
+
+	err, _ := reconcile(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T60000ms()), err)
+	cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeDnsRecordFailed)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), "Failed reconciling DNS record: permission denied", cond.Message)
+
+	clnt.err = nil
+	err, _ = reconcile(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeDnsRecordFailed))
+	assert.NotNil(suite.T(), obj.Status.Dns)
+}
+
+func TestReconcileRecord(t *testing.T) {
+	suite.Run(t, new(reconcileRecordSuite))
+}
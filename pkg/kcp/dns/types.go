@@ -0,0 +1,45 @@
+package dns
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+const (
+	RecordTypeA     = "A"
+	RecordTypeCNAME = "CNAME"
+
+	// DefaultRecordTtl is the TTL in seconds of the records pointing at the resource endpoints
+	DefaultRecordTtl = int64(300)
+)
+
+// Record is the DNS record set, with the fully qualified name ending with the dot
+type Record struct {
+	Name   string
+	Type   string
+	Ttl    int64
+	Values []string
+}
+
+// Client is the provider-agnostic client of the private DNS zones the resource endpoint records are created in.
+// The zone is given by the Cloud DNS managed zone name on GCP and by the Route53 hosted zone id on AWS.
+type Client interface {
+	// GetZoneDnsName returns the DNS name of the zone, ending with the dot
+	GetZoneDnsName(ctx context.Context, zoneName string) (string, error)
+	// GetRecord returns the record set with the given name and type, or nil if it does not exist
+	GetRecord(ctx context.Context, zoneName, name, recordType string) (*Record, error)
+	// UpsertRecord creates the record set, or replaces the existing one with the same name and type
+	UpsertRecord(ctx context.Context, zoneName string, record Record) error
+	// DeleteRecord deletes the record set with the given name and type, and succeeds if it does not exist
+	DeleteRecord(ctx context.Context, zoneName, name, recordType string) error
+}
+
+// ObjWithDns is implemented by the objects having a DNS record pointing at their endpoint
+type ObjWithDns interface {
+	composed.ObjWithConditions
+	DnsOptions() *cloudcontrolv1beta1.DnsOptions
+	DnsStatus() *cloudcontrolv1beta1.DnsStatus
+	SetDnsStatus(dns *cloudcontrolv1beta1.DnsStatus)
+}
@@ -0,0 +1,38 @@
+package ha
+
+import (
+	"strings"
+
+	"github.com/kyma-project/cloud-manager/pkg/config"
+)
+
+func InitConfig(cfg config.Config) {
+	cfg.Path(
+		"highAvailability",
+		config.SourceFile("highAvailability.yaml"),
+		config.DefaultObj(DefaultHaConfig()),
+		config.Bind(HaConfig),
+	)
+}
+
+type HaConfigStruct struct {
+	// MinZones is the minimum number of zones the highly available setup of the resource kind
+	// must be spread over, with lower case kind as the key. Kinds not listed have no minimum.
+	MinZones map[string]int `json:"minZones,omitempty" yaml:"minZones,omitempty"`
+}
+
+func DefaultHaConfig() *HaConfigStruct {
+	return &HaConfigStruct{
+		MinZones: map[string]int{
+			"nfsinstance":   2,
+			"redisinstance": 2,
+		},
+	}
+}
+
+var HaConfig = DefaultHaConfig()
+
+// MinZonesFor returns the minimum number of zones required for the highly available setup of the given kind
+func (c *HaConfigStruct) MinZonesFor(kind string) int {
+	return c.MinZones[strings.ToLower(kind)]
+}
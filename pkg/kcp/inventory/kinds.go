@@ -0,0 +1,86 @@
+package inventory
+
+import (
+	"reflect"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// StateUnknown is the state the resources without the status state are counted under
+const StateUnknown = "Unknown"
+
+type inventoryKind struct {
+	kind  string
+	obj   client.Object
+	list  func() client.ObjectList
+	state func(obj client.Object) string
+}
+
+// inventoryKinds are the managed resource kinds counted in the inventory
+var inventoryKinds = []inventoryKind{
+	{
+		kind:  "IpRange",
+		obj:   &cloudcontrolv1beta1.IpRange{},
+		list:  func() client.ObjectList { return &cloudcontrolv1beta1.IpRangeList{} },
+		state: func(obj client.Object) string { return string(obj.(*cloudcontrolv1beta1.IpRange).Status.State) },
+	},
+	{
+		kind:  "NfsInstance",
+		obj:   &cloudcontrolv1beta1.NfsInstance{},
+		list:  func() client.ObjectList { return &cloudcontrolv1beta1.NfsInstanceList{} },
+		state: func(obj client.Object) string { return string(obj.(*cloudcontrolv1beta1.NfsInstance).Status.State) },
+	},
+	{
+		kind:  "RedisInstance",
+		obj:   &cloudcontrolv1beta1.RedisInstance{},
+		list:  func() client.ObjectList { return &cloudcontrolv1beta1.RedisInstanceList{} },
+		state: func(obj client.Object) string { return string(obj.(*cloudcontrolv1beta1.RedisInstance).Status.State) },
+	},
+	{
+		kind:  "VpcPeering",
+		obj:   &cloudcontrolv1beta1.VpcPeering{},
+		list:  func() client.ObjectList { return &cloudcontrolv1beta1.VpcPeeringList{} },
+		state: func(obj client.Object) string { return obj.(*cloudcontrolv1beta1.VpcPeering).Status.State },
+	},
+	{
+		kind:  "Network",
+		obj:   &cloudcontrolv1beta1.Network{},
+		list:  func() client.ObjectList { return &cloudcontrolv1beta1.NetworkList{} },
+		state: func(obj client.Object) string { return obj.(*cloudcontrolv1beta1.Network).Status.State },
+	},
+}
+
+// WatchedObjects returns the managed resource kinds whose transitions update the inventory
+func WatchedObjects() []client.Object {
+	result := make([]client.Object, 0, len(inventoryKinds))
+	for _, k := range inventoryKinds {
+		result = append(result, k.obj)
+	}
+	return result
+}
+
+func kindOf(obj client.Object) *inventoryKind {
+	for i := range inventoryKinds {
+		if reflect.TypeOf(inventoryKinds[i].obj) == reflect.TypeOf(obj) {
+			return &inventoryKinds[i]
+		}
+	}
+	return nil
+}
+
+// TransitionPredicate passes the creation and the deletion of the managed resources, and the updates
+// changing their status state, ignoring all other updates that do not change the inventory
+func TransitionPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			k := kindOf(e.ObjectNew)
+			if k == nil {
+				return true
+			}
+			return k.state(e.ObjectOld) != k.state(e.ObjectNew)
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package inventory
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type CloudResourceInventoryReconciler interface {
+	reconcile.Reconciler
+}
+
+type cloudResourceInventoryReconciler struct {
+	composedStateFactory composed.StateFactory
+}
+
+func NewCloudResourceInventoryReconciler(composedStateFactory composed.StateFactory) CloudResourceInventoryReconciler {
+	return &cloudResourceInventoryReconciler{
+		composedStateFactory: composedStateFactory,
+	}
+}
+
+func (r *cloudResourceInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if Ignore != nil && Ignore.ShouldIgnoreKey(req) {
+		return ctrl.Result{}, nil
+	}
+
+	state := r.newState(req.NamespacedName)
+	action := r.newAction()
+
+	return composed.Handle(action(ctx, state))
+}
+
+func (r *cloudResourceInventoryReconciler) newAction() composed.Action {
+	return composed.ComposeActions(
+		"cloudResourceInventory",
+		composed.LoadObj,
+		composed.If(
+			composed.Not(composed.MarkedForDeletionPredicate),
+			updateThrottle,
+			resourcesCount,
+			updateStatus,
+		),
+		composed.StopAndForgetAction,
+	)
+}
+
+func (r *cloudResourceInventoryReconciler) newState(name types.NamespacedName) *State {
+	return newState(r.composedStateFactory.NewState(name, &cloudcontrolv1beta1.CloudResourceInventory{}))
+}
@@ -0,0 +1,158 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type inventorySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *inventorySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *inventorySuite) newReconciler(objects ...client.Object) (CloudResourceInventoryReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope-aws"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Provider: cloudcontrolv1beta1.ProviderAws,
+		},
+	}
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objects, scope)...).
+		WithStatusSubresource(&cloudcontrolv1beta1.CloudResourceInventory{}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	return NewCloudResourceInventoryReconciler(composed.NewStateFactory(cluster)), clnt
+}
+
+func (suite *inventorySuite) reconcile(r CloudResourceInventoryReconciler, clnt client.Client) (ctrl.Result, *cloudcontrolv1beta1.CloudResourceInventory) {
+	res, err := r.Reconcile(suite.ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+	assert.NoError(suite.T(), err)
+	inventory := &cloudcontrolv1beta1.CloudResourceInventory{}
+	assert.NoError(suite.T(), clnt.Get(suite.ctx, types.NamespacedName{Name: "default"}, inventory))
+	return res, inventory
+}
+
+func newIpRange(name, scope string, state cloudcontrolv1beta1.StatusState) *cloudcontrolv1beta1.IpRange {
+	return &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: name},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: scope},
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			State: state,
+		},
+	}
+}
+
+func (suite *inventorySuite) TestCountsUpdatedOnCreationAndDeletion() {
+	inventory := &cloudcontrolv1beta1.CloudResourceInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: cloudcontrolv1beta1.CloudResourceInventorySpec{
+			MinUpdateInterval: &metav1.Duration{},
+		},
+	}
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope-aws"},
+		},
+	}
+	r, clnt := suite.newReconciler(inventory, newIpRange("iprange-1", "scope-aws", cloudcontrolv1beta1.ReadyState), nfsInstance)
+
+	_, inventory = suite.reconcile(r, clnt)
+	assert.Equal(suite.T(), 2, inventory.Status.Total)
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.CloudResourceInventoryScope{
+		{
+			Namespace: "kcp-system",
+			Scope:     "scope-aws",
+			Provider:  cloudcontrolv1beta1.ProviderAws,
+			Kinds: []cloudcontrolv1beta1.CloudResourceInventoryKind{
+				{Kind: "IpRange", Count: 1, States: map[string]int{"Ready": 1}},
+				{Kind: "NfsInstance", Count: 1, States: map[string]int{StateUnknown: 1}},
+			},
+		},
+	}, inventory.Status.Scopes)
+	assert.NotNil(suite.T(), inventory.Status.LastUpdateTime)
+
+	// resource created in another scope, and nfs instance deleted
+	assert.NoError(suite.T(), clnt.Create(suite.ctx, newIpRange("iprange-2", "scope-other", cloudcontrolv1beta1.ErrorState)))
+	assert.NoError(suite.T(), clnt.Create(suite.ctx, newIpRange("iprange-3", "scope-aws", cloudcontrolv1beta1.ErrorState)))
+	// resource referencing the Scope of the same name in another namespace
+	crossNamespace := newIpRange("iprange-4", "scope-aws", cloudcontrolv1beta1.ReadyState)
+	crossNamespace.Spec.Scope.Namespace = "central"
+	assert.NoError(suite.T(), clnt.Create(suite.ctx, crossNamespace))
+	assert.NoError(suite.T(), clnt.Delete(suite.ctx, nfsInstance))
+
+	_, inventory = suite.reconcile(r, clnt)
+	assert.Equal(suite.T(), 4, inventory.Status.Total)
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.CloudResourceInventoryScope{
+		{
+			Namespace: "central",
+			Scope:     "scope-aws",
+			Kinds: []cloudcontrolv1beta1.CloudResourceInventoryKind{
+				{Kind: "IpRange", Count: 1, States: map[string]int{"Ready": 1}},
+			},
+		},
+		{
+			Namespace: "kcp-system",
+			Scope:     "scope-aws",
+			Provider:  cloudcontrolv1beta1.ProviderAws,
+			Kinds: []cloudcontrolv1beta1.CloudResourceInventoryKind{
+				{Kind: "IpRange", Count: 2, States: map[string]int{"Ready": 1, "Error": 1}},
+			},
+		},
+		{
+			Namespace: "kcp-system",
+			Scope:     "scope-other",
+			Kinds: []cloudcontrolv1beta1.CloudResourceInventoryKind{
+				{Kind: "IpRange", Count: 1, States: map[string]int{"Error": 1}},
+			},
+		},
+	}, inventory.Status.Scopes)
+}
+
+func (suite *inventorySuite) TestUpdateThrottled() {
+	lastUpdateTime := metav1.NewTime(time.Now().Add(-10 * time.Second).Truncate(time.Second))
+	inventory := &cloudcontrolv1beta1.CloudResourceInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Status: cloudcontrolv1beta1.CloudResourceInventoryStatus{
+			LastUpdateTime: &lastUpdateTime,
+		},
+	}
+	r, clnt := suite.newReconciler(inventory, newIpRange("iprange-1", "scope-aws", cloudcontrolv1beta1.ReadyState))
+
+	res, inventory := suite.reconcile(r, clnt)
+	assert.Greater(suite.T(), res.RequeueAfter, time.Duration(0))
+	assert.LessOrEqual(suite.T(), res.RequeueAfter, DefaultMinUpdateInterval)
+	assert.Equal(suite.T(), 0, inventory.Status.Total)
+	assert.Equal(suite.T(), lastUpdateTime.Unix(), inventory.Status.LastUpdateTime.Unix())
+}
+
+func TestInventory(t *testing.T) {
+	suite.Run(t, new(inventorySuite))
+}
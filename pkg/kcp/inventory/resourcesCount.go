@@ -0,0 +1,99 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resourcesCount counts the managed resources of each kind by their Scope and status state
+func resourcesCount(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	scopeList := &cloudcontrolv1beta1.ScopeList{}
+	if err := state.Cluster().K8sClient().List(ctx, scopeList); err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing Scopes for the inventory", composed.StopWithRequeue, ctx)
+	}
+	providerByScope := make(map[types.NamespacedName]cloudcontrolv1beta1.ProviderType, len(scopeList.Items))
+	for _, scope := range scopeList.Items {
+		providerByScope[client.ObjectKeyFromObject(&scope)] = scope.Spec.Provider
+	}
+
+	// the Scopes are counted by their namespace and name, since the resources can reference Scopes
+	// of the same name in different namespaces
+	kindsByScope := map[types.NamespacedName]map[string]*cloudcontrolv1beta1.CloudResourceInventoryKind{}
+	total := 0
+
+	for _, k := range inventoryKinds {
+		list := k.list()
+		if err := state.Cluster().K8sClient().List(ctx, list); err != nil {
+			return composed.LogErrorAndReturn(err, fmt.Sprintf("Error listing %s for the inventory", k.kind), composed.StopWithRequeue, ctx)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, fmt.Sprintf("Error extracting %s list for the inventory", k.kind), composed.StopAndForget, ctx)
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			scopeKey := types.NamespacedName{Namespace: obj.GetNamespace()}
+			if commonObj, ok := obj.(focal.CommonObject); ok {
+				scopeKey = commonObj.ScopeRef().ObjectKey(obj.GetNamespace())
+			}
+			objState := k.state(obj)
+			if len(objState) == 0 {
+				objState = StateUnknown
+			}
+
+			kinds, ok := kindsByScope[scopeKey]
+			if !ok {
+				kinds = map[string]*cloudcontrolv1beta1.CloudResourceInventoryKind{}
+				kindsByScope[scopeKey] = kinds
+			}
+			kind, ok := kinds[k.kind]
+			if !ok {
+				kind = &cloudcontrolv1beta1.CloudResourceInventoryKind{Kind: k.kind, States: map[string]int{}}
+				kinds[k.kind] = kind
+			}
+			kind.Count++
+			kind.States[objState]++
+			total++
+		}
+	}
+
+	var scopes []cloudcontrolv1beta1.CloudResourceInventoryScope
+	for scopeKey, kinds := range kindsByScope {
+		scope := cloudcontrolv1beta1.CloudResourceInventoryScope{
+			Namespace: scopeKey.Namespace,
+			Scope:     scopeKey.Name,
+			Provider:  providerByScope[scopeKey],
+		}
+		for _, kind := range kinds {
+			scope.Kinds = append(scope.Kinds, *kind)
+		}
+		sort.Slice(scope.Kinds, func(i, j int) bool {
+			return scope.Kinds[i].Kind < scope.Kinds[j].Kind
+		})
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		if scopes[i].Namespace != scopes[j].Namespace {
+			return scopes[i].Namespace < scopes[j].Namespace
+		}
+		return scopes[i].Scope < scopes[j].Scope
+	})
+
+	state.total = total
+	state.scopes = scopes
+
+	return nil, nil
+}
@@ -0,0 +1,21 @@
+package inventory
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+type State struct {
+	composed.State
+
+	total  int
+	scopes []cloudcontrolv1beta1.CloudResourceInventoryScope
+}
+
+func (s *State) ObjAsCloudResourceInventory() *cloudcontrolv1beta1.CloudResourceInventory {
+	return s.Obj().(*cloudcontrolv1beta1.CloudResourceInventory)
+}
+
+func newState(base composed.State) *State {
+	return &State{State: base}
+}
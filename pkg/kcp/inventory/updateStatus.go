@@ -0,0 +1,41 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func updateStatus(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	inventory := state.ObjAsCloudResourceInventory()
+
+	readyCondition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: fmt.Sprintf("Total %d resources in %d scopes", state.total, len(state.scopes)),
+	}
+
+	changed := inventory.Status.Total != state.total ||
+		!reflect.DeepEqual(inventory.Status.Scopes, state.scopes) ||
+		composed.AnyConditionChanged(inventory, readyCondition)
+	if !changed {
+		return composed.StopAndForget, nil
+	}
+
+	inventory.Status.Total = state.total
+	inventory.Status.Scopes = state.scopes
+	inventory.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
+
+	return composed.UpdateStatus(inventory).
+		SetCondition(readyCondition).
+		ErrorLogMessage("Error updating KCP CloudResourceInventory status").
+		SuccessError(composed.StopAndForget).
+		Run(ctx, state)
+}
@@ -0,0 +1,34 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// DefaultMinUpdateInterval is the minimum time between the inventory status updates if not specified
+const DefaultMinUpdateInterval = 30 * time.Second
+
+func minUpdateInterval(state *State) time.Duration {
+	if interval := state.ObjAsCloudResourceInventory().Spec.MinUpdateInterval; interval != nil {
+		return interval.Duration
+	}
+	return DefaultMinUpdateInterval
+}
+
+// updateThrottle delays the status update until the min update interval passed since the last one,
+// so the transitions of many resources are aggregated into a single write. The workqueue collapses
+// the requests triggered in between into the single delayed one.
+func updateThrottle(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	lastUpdateTime := state.ObjAsCloudResourceInventory().Status.LastUpdateTime
+	if lastUpdateTime == nil {
+		return nil, nil
+	}
+	remaining := minUpdateInterval(state) - time.Since(lastUpdateTime.Time)
+	if remaining <= 0 {
+		return nil, nil
+	}
+	return composed.StopWithRequeueDelay(remaining), nil
+}
@@ -18,6 +18,9 @@ var reserved = []string{
 
 const DefaultMaskSize = 22
 
+// ErrNoVacantCidr is returned by AllocateCidr when the address space has no free range of the requested size
+var ErrNoVacantCidr = errors.New("unable to find vacant cidr slot")
+
 // AllocateCidr finds an IP range with given maskOnes size such that does not overlap with any
 // of the existing ranges. It starts from the first existing range upwards.
 func AllocateCidr(maskOnes int, existingRanges []string) (string, error) {
@@ -35,11 +38,14 @@ func AllocateCidr(maskOnes int, existingRanges []string) (string, error) {
 	}
 
 	current, _ := parseRange(existingRanges[0])
-	current.nextWithOnes(maskOnes)
+	current = current.nextWithOnes(maskOnes)
+	if current == nil {
+		return "", ErrNoVacantCidr
+	}
 	for occupied.overlaps(current) {
 		current = current.next()
 		if current == nil {
-			return "", errors.New("unable to find vacant cidr slot")
+			return "", ErrNoVacantCidr
 		}
 	}
 
@@ -14,6 +14,9 @@ func TestAllocateCidr(t *testing.T) {
 		s string
 	}{
 		{22, []string{"10.250.0.0/22", "10.96.0.0/13", "10.104.0.0/13"}, "10.250.4.0/22"},
+		{24, []string{"10.250.0.0/22", "10.250.4.0/24", "10.96.0.0/13"}, "10.250.5.0/24"},
+		{16, []string{"10.250.0.0/22", "10.96.0.0/13"}, "10.251.0.0/16"},
+		{16, []string{"255.255.0.0/16"}, ""},
 	}
 	for x, item := range list {
 		t.Run(strconv.Itoa(x), func(t *testing.T) {
@@ -27,3 +30,31 @@ func TestAllocateCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocateCidrFromPool(t *testing.T) {
+	list := []struct {
+		m int
+		p []string
+		a []string
+		s string
+	}{
+		{22, []string{"10.100.0.0/16"}, nil, "10.100.0.0/22"},
+		{22, []string{"10.100.0.0/16"}, []string{"10.100.0.0/24"}, "10.100.4.0/22"},
+		{22, []string{"10.100.0.0/16"}, []string{"10.100.4.0/22", "10.100.0.0/22"}, "10.100.8.0/22"},
+		{24, []string{"10.100.0.0/16"}, []string{"10.100.0.0/23", "10.100.3.0/24"}, "10.100.2.0/24"},
+		{22, []string{"10.100.0.0/22", "10.200.0.0/21"}, []string{"10.100.1.0/24"}, "10.200.0.0/22"},
+		{22, []string{"10.100.0.0/23"}, nil, ""},
+		{22, []string{"10.100.0.0/21"}, []string{"10.100.0.0/22", "10.100.6.0/24"}, ""},
+	}
+	for x, item := range list {
+		t.Run(strconv.Itoa(x), func(t *testing.T) {
+			actual, err := AllocateCidrFromPool(item.m, item.p, item.a)
+			if item.s == "" {
+				assert.ErrorIs(t, err, ErrPoolExhausted)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, item.s, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package allocate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+var ErrPoolExhausted = errors.New("no vacant cidr slot in the pool")
+
+// AllocateCidrFromPool finds the first IP range with given maskOnes size within the pool ranges
+// that does not overlap with any of the allocated ranges. Returns ErrPoolExhausted if there is none.
+func AllocateCidrFromPool(maskOnes int, poolRanges []string, allocatedRanges []string) (string, error) {
+	if maskOnes < 0 || maskOnes > 32 {
+		return "", fmt.Errorf("invalid mask size %d", maskOnes)
+	}
+	allocated := make([]netip.Prefix, 0, len(allocatedRanges))
+	for _, r := range allocatedRanges {
+		p, err := parseIpv4Prefix(r)
+		if err != nil {
+			return "", err
+		}
+		allocated = append(allocated, p)
+	}
+	sort.Slice(allocated, func(i, j int) bool {
+		return prefixFirst(allocated[i]) < prefixFirst(allocated[j])
+	})
+
+	size := uint64(1) << (32 - maskOnes)
+	for _, r := range poolRanges {
+		pool, err := parseIpv4Prefix(r)
+		if err != nil {
+			return "", err
+		}
+		if pool.Bits() > maskOnes {
+			continue
+		}
+		cursor := prefixFirst(pool)
+		end := prefixFirst(pool) + prefixSize(pool)
+		for _, a := range allocated {
+			aStart, aEnd := prefixFirst(a), prefixFirst(a)+prefixSize(a)
+			if aEnd <= cursor || aStart >= end {
+				continue
+			}
+			if candidate := alignUp(cursor, size); candidate+size <= aStart {
+				return prefixString(candidate, maskOnes), nil
+			}
+			cursor = max(cursor, aEnd)
+		}
+		if candidate := alignUp(cursor, size); candidate+size <= end {
+			return prefixString(candidate, maskOnes), nil
+		}
+	}
+
+	return "", ErrPoolExhausted
+}
+
+func parseIpv4Prefix(r string) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(r)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if !p.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("range %s is not IPv4", r)
+	}
+	return p.Masked(), nil
+}
+
+func prefixFirst(p netip.Prefix) uint64 {
+	return uint64(binary.BigEndian.Uint32(p.Addr().AsSlice()))
+}
+
+func prefixSize(p netip.Prefix) uint64 {
+	return uint64(1) << (32 - p.Bits())
+}
+
+func alignUp(v, size uint64) uint64 {
+	return (v + size - 1) / size * size
+}
+
+func prefixString(first uint64, maskOnes int) string {
+	addr := netip.AddrFrom4([4]byte(binary.BigEndian.AppendUint32(nil, uint32(first))))
+	return netip.PrefixFrom(addr, maskOnes).String()
+}
@@ -2,6 +2,7 @@ package iprange
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
@@ -17,7 +18,15 @@ func shouldAllocateIpRange(ctx context.Context, st composed.State) bool {
 	if len(state.ObjAsIpRange().Status.Cidr) > 0 {
 		return false
 	}
-	if len(state.ObjAsIpRange().Spec.Cidr) > 0 {
+	if len(state.ObjAsIpRange().SpecCidrs()) > 0 {
+		return false
+	}
+	if len(state.ObjAsIpRange().Spec.IpamPoolId) > 0 {
+		// allocated by the provider from the IPAM pool
+		return false
+	}
+	if state.ObjAsIpRange().Spec.ExternalIpam != nil {
+		// leased from the external IPAM
 		return false
 	}
 	return true
@@ -28,7 +37,32 @@ func allocateIpRange(ctx context.Context, st composed.State) (error, context.Con
 
 	logger := composed.LoggerFromCtx(ctx)
 
-	cidr, err := iprangeallocate.AllocateCidr(iprangeallocate.DefaultMaskSize, state.existingCidrRanges)
+	if len(state.ObjAsIpRange().Status.Cidr) > 0 {
+		// allocated by the provider
+		return nil, ctx
+	}
+
+	maskSize := state.ObjAsIpRange().CidrSizeOrDefault(iprangeallocate.DefaultMaskSize)
+	cidr, err := iprangeallocate.AllocateCidr(maskSize, state.existingCidrRanges)
+	if errors.Is(err, iprangeallocate.ErrNoVacantCidr) {
+		logger.
+			WithValues(
+				"existingRanges", fmt.Sprintf("%v", state.existingCidrRanges),
+				"cidrSize", maskSize,
+			).
+			Info("No free cidr left in the SKR address space")
+		state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+		return composed.PatchStatus(state.ObjAsIpRange()).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonNoFreeCidr,
+				Message: fmt.Sprintf("No free /%d CIDR left in the address space outside of the existing ranges", maskSize),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with no free cidr").
+			SuccessLogMsg("Forgetting KCP IpRange with no free cidr").
+			Run(ctx, st)
+	}
 	if err != nil {
 		logger = logger.WithValues(
 			"existingRanges", fmt.Sprintf("%v", state.existingCidrRanges),
@@ -46,6 +80,13 @@ func allocateIpRange(ctx context.Context, st composed.State) (error, context.Con
 			Run(ctx, st)
 	}
 
+	logger.
+		WithValues(
+			"cidr", cidr,
+			"cidrSize", maskSize,
+		).
+		Info("Allocated cidr from the SKR free address space")
+
 	state.ObjAsIpRange().Status.Cidr = cidr
 
 	return composed.PatchStatus(state.ObjAsIpRange()).
@@ -0,0 +1,87 @@
+package iprange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newAllocateIpRangeState(ipRange *cloudcontrolv1beta1.IpRange, existingCidrRanges ...string) *State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	state := newState(focalState, nil).(*State)
+	state.SetExistingCidrRanges(existingCidrRanges)
+	return state
+}
+
+func TestAllocateIpRange(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	t.Run("default size", func(t *testing.T) {
+		ipRange := &cloudcontrolv1beta1.IpRange{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"}}
+		state := newAllocateIpRangeState(ipRange, "10.250.0.0/22", "10.96.0.0/13", "10.104.0.0/13")
+
+		err, _ := allocateIpRange(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.4.0/22", ipRange.Status.Cidr)
+	})
+
+	t.Run("requested size", func(t *testing.T) {
+		ipRange := &cloudcontrolv1beta1.IpRange{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"}}
+		ipRange.Spec.CidrSize = 24
+		state := newAllocateIpRangeState(ipRange, "10.250.0.0/22", "10.250.4.0/24", "10.96.0.0/13", "10.104.0.0/13")
+
+		err, _ := allocateIpRange(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.5.0/24", ipRange.Status.Cidr)
+	})
+
+	t.Run("no free cidr", func(t *testing.T) {
+		ipRange := &cloudcontrolv1beta1.IpRange{ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"}}
+		ipRange.Spec.CidrSize = 16
+		state := newAllocateIpRangeState(ipRange, "255.255.0.0/16")
+
+		err, _ := allocateIpRange(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Empty(t, ipRange.Status.Cidr)
+		assert.Equal(t, cloudcontrolv1beta1.ErrorState, ipRange.Status.State)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonNoFreeCidr, cond.Reason)
+		assert.Contains(t, cond.Message, "/16")
+	})
+}
@@ -0,0 +1,30 @@
+package iprange
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// waitDependentsDeleted blocks the IpRange deletion, and so the deletion of its subnets the cloud
+// resources are placed in, until the NfsInstances and RedisInstances referring to it are deleted
+func waitDependentsDeleted(ctx context.Context, st composed.State) (error, context.Context) {
+	return composed.WaitForDependentsDeleted(
+		util.Timing.T10000ms(),
+		composed.ListDependents(
+			func() client.ObjectList { return &cloudcontrolv1beta1.NfsInstanceList{} },
+			func(obj client.Object, state composed.State) bool {
+				return obj.(*cloudcontrolv1beta1.NfsInstance).Spec.IpRange.Name == state.Name().Name
+			},
+		),
+		composed.ListDependents(
+			func() client.ObjectList { return &cloudcontrolv1beta1.RedisInstanceList{} },
+			func(obj client.Object, state composed.State) bool {
+				return obj.(*cloudcontrolv1beta1.RedisInstance).Spec.IpRange.Name == state.Name().Name
+			},
+		),
+	)(ctx, st)
+}
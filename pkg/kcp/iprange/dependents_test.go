@@ -0,0 +1,76 @@
+package iprange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestIpRangeDeleteWaitsForNfsInstance(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "kcp-system",
+			Name:              "iprange",
+			Finalizers:        []string{cloudcontrolv1beta1.FinalizerName},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	}
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			IpRange: cloudcontrolv1beta1.IpRangeRef{Name: "iprange"},
+		},
+	}
+	otherNfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "other-nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			IpRange: cloudcontrolv1beta1.IpRangeRef{Name: "other-iprange"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange, nfsInstance, otherNfsInstance).
+		WithStatusSubresource(ipRange).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange)
+
+	// the IpRange delete waits while the NfsInstance exists
+	err, _ := waitDependentsDeleted(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+
+	saved := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	cond := meta.FindStatusCondition(saved.Status.Conditions, composed.ConditionTypeWaitingForDependents)
+	assert.NotNil(t, cond)
+	assert.Equal(t, "Waiting for dependents to be deleted: kcp-system/nfs", cond.Message)
+
+	// and continues once the NfsInstance is gone
+	assert.NoError(t, clnt.Delete(ctx, nfsInstance))
+
+	err, _ = waitDependentsDeleted(ctx, state)
+	assert.Nil(t, err)
+
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Nil(t, meta.FindStatusCondition(saved.Status.Conditions, composed.ConditionTypeWaitingForDependents))
+}
@@ -0,0 +1,33 @@
+package iprange
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dryRunValidate stops the IpRange in the dry-run mode on providers not planning the cloud
+// operations, atm only AWS computes the dry-run plan, so the others would change the cloud resources
+func dryRunValidate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if !actions.IsDryRun(st) || focal.AwsProviderPredicate(ctx, st) {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonDryRunNotSupported,
+			Message: "Dry-run mode is not supported by the provider",
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with dry-run not supported").
+		SuccessLogMsg("Forgetting KCP IpRange with dry-run not supported").
+		Run(ctx, st)
+}
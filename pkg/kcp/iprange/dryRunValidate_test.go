@@ -0,0 +1,86 @@
+package iprange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDryRunRejectedWithoutPlan(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newDryRunState := func(provider cloudcontrolv1beta1.ProviderType) (*State, *cloudcontrolv1beta1.IpRange) {
+		ipRange := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "kcp-system",
+				Name:        "iprange",
+				Annotations: map[string]string{actions.AnnotationDryRun: "true"},
+			},
+		}
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+		clnt := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ipRange).
+			WithStatusSubresource(ipRange).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// the fake client does not support the server side apply used to patch the status
+				SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					return nil
+				},
+			}).
+			Build()
+		cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+		focalState := focal.NewStateFactory().NewState(
+			composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+		)
+		focalState.SetScope(&cloudcontrolv1beta1.Scope{
+			Spec: cloudcontrolv1beta1.ScopeSpec{Provider: provider},
+		})
+		return newState(focalState, nil).(*State), ipRange
+	}
+
+	t.Run("aws plans the dry-run", func(t *testing.T) {
+		state, ipRange := newDryRunState(cloudcontrolv1beta1.ProviderAws)
+		err, _ := dryRunValidate(ctx, state)
+		assert.Nil(t, err)
+		assert.Empty(t, ipRange.Status.Conditions)
+	})
+
+	for _, provider := range []cloudcontrolv1beta1.ProviderType{cloudcontrolv1beta1.ProviderGCP, cloudcontrolv1beta1.ProviderAzure} {
+		t.Run(string(provider)+" rejects the dry-run", func(t *testing.T) {
+			state, ipRange := newDryRunState(provider)
+			err, _ := dryRunValidate(ctx, state)
+			assert.Equal(t, composed.StopAndForget, err)
+			assert.Equal(t, cloudcontrolv1beta1.ErrorState, ipRange.Status.State)
+			cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+			assert.NotNil(t, cond)
+			assert.Equal(t, cloudcontrolv1beta1.ReasonDryRunNotSupported, cond.Reason)
+		})
+	}
+
+	t.Run("not in dry-run", func(t *testing.T) {
+		state, ipRange := newDryRunState(cloudcontrolv1beta1.ProviderGCP)
+		ipRange.Annotations = nil
+		err, _ := dryRunValidate(ctx, state)
+		assert.Nil(t, err)
+		assert.Empty(t, ipRange.Status.Conditions)
+	})
+}
@@ -0,0 +1,228 @@
+package iprange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/elliotchance/pie/v2"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func externalIpamAllocate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+
+	if ipRange.Spec.ExternalIpam == nil || composed.IsMarkedForDeletion(ipRange) {
+		return nil, ctx
+	}
+	if len(ipRange.Status.Cidr) > 0 {
+		return nil, ctx
+	}
+
+	logger := composed.LoggerFromCtx(ctx).WithValues("externalIpamUrl", ipRange.Spec.ExternalIpam.Url)
+	ctx = composed.LoggerIntoCtx(ctx, logger)
+
+	if len(ipRange.Status.ExternalIpamLeaseId) > 0 {
+		// the rejected lease that could not be released before
+		if err, ctx := externalIpamRelease(ctx, state); err != nil {
+			return err, ctx
+		}
+	}
+
+	maskSize := ipRange.CidrSizeOrDefault(iprangeallocate.DefaultMaskSize)
+	lease, err := state.externalIpamClient.RequestLease(ctx, ipRange.Spec.ExternalIpam.Url, externalipam.LeaseRequest{
+		Name:         fmt.Sprintf("%s/%s", ipRange.Namespace, ipRange.Name),
+		PrefixLength: maskSize,
+	})
+	if errors.Is(err, externalipam.ErrUnavailable) {
+		return externalIpamUnavailable(ctx, state, err, "Error patching KCP IpRange status with unavailable external IPAM on cidr lease")
+	}
+	if err != nil {
+		logger.Error(err, "External IPAM rejected the cidr lease")
+		ipRange.Status.State = cloudcontrolv1beta1.ErrorState
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonIpamAllocationFailed,
+				Message: fmt.Sprintf("Unable to lease /%d CIDR from the external IPAM: %s", maskSize, err),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status after rejected external IPAM cidr lease").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, st)
+	}
+
+	existingRanges, err := externalIpamExistingRanges(ctx, state)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing KCP IpRanges", composed.StopWithRequeue, ctx)
+	}
+	if overlapping := findOverlappingRange(lease.Cidr, existingRanges); len(overlapping) > 0 {
+		return externalIpamLeaseOverlaps(ctx, state, lease, overlapping)
+	}
+
+	logger.
+		WithValues(
+			"cidr", lease.Cidr,
+			"leaseId", lease.LeaseId,
+		).
+		Info("Leased cidr from the external IPAM")
+
+	ipRange.Status.Cidr = lease.Cidr
+	ipRange.Status.ExternalIpamLeaseId = lease.LeaseId
+
+	return composed.PatchStatus(ipRange).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeIpamUnavailable).
+		ErrorLogMessage("Error patching KCP IpRange status with external IPAM cidr lease").
+		SuccessErrorNil().
+		Run(ctx, st)
+}
+
+func externalIpamRelease(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+
+	if ipRange.Spec.ExternalIpam == nil || len(ipRange.Status.ExternalIpamLeaseId) == 0 {
+		return nil, ctx
+	}
+
+	logger := composed.LoggerFromCtx(ctx).WithValues(
+		"externalIpamUrl", ipRange.Spec.ExternalIpam.Url,
+		"leaseId", ipRange.Status.ExternalIpamLeaseId,
+	)
+	ctx = composed.LoggerIntoCtx(ctx, logger)
+
+	err := state.externalIpamClient.ReleaseLease(ctx, ipRange.Spec.ExternalIpam.Url, ipRange.Status.ExternalIpamLeaseId)
+	if errors.Is(err, externalipam.ErrUnavailable) {
+		return externalIpamUnavailable(ctx, state, err, "Error patching KCP IpRange status with unavailable external IPAM on cidr release")
+	}
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error releasing external IPAM cidr lease", composed.StopWithRequeueDelay(util.Timing.T60000ms()), ctx)
+	}
+
+	logger.Info("Released cidr lease in the external IPAM")
+
+	ipRange.Status.ExternalIpamLeaseId = ""
+
+	return composed.PatchStatus(ipRange).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeIpamUnavailable).
+		ErrorLogMessage("Error patching KCP IpRange status after external IPAM cidr release").
+		SuccessErrorNil().
+		Run(ctx, st)
+}
+
+// externalIpamExistingRanges returns the node, pod and service ranges of the Scope, and the ranges of
+// the other IpRanges of the Scope, the leased cidr must not overlap with
+func externalIpamExistingRanges(ctx context.Context, state *State) ([]string, error) {
+	ipRange := state.ObjAsIpRange()
+
+	var result []string
+	if scope := state.Scope(); scope != nil {
+		result = append(result, scopeNetworkRanges(scope)...)
+	}
+
+	ipRangeList := &cloudcontrolv1beta1.IpRangeList{}
+	if err := state.Cluster().K8sClient().List(ctx, ipRangeList, client.InNamespace(ipRange.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, other := range ipRangeList.Items {
+		if other.Name == ipRange.Name || other.Spec.Scope.Name != ipRange.Spec.Scope.Name {
+			continue
+		}
+		result = append(result, other.Status.Cidr)
+		result = append(result, other.Status.Cidrs...)
+	}
+
+	return pie.Filter(result, func(r string) bool { return len(r) > 0 }), nil
+}
+
+// scopeNetworkRanges returns the node, pod and service ranges of the Scope provider
+func scopeNetworkRanges(scope *cloudcontrolv1beta1.Scope) []string {
+	switch {
+	case scope.Spec.Scope.Aws != nil:
+		n := scope.Spec.Scope.Aws.Network
+		return []string{n.Nodes, n.Pods, n.Services}
+	case scope.Spec.Scope.Gcp != nil:
+		n := scope.Spec.Scope.Gcp.Network
+		return []string{n.Nodes, n.Pods, n.Services}
+	case scope.Spec.Scope.Azure != nil:
+		n := scope.Spec.Scope.Azure.Network
+		return []string{n.Nodes, n.Pods, n.Services}
+	case scope.Spec.Scope.OpenStack != nil:
+		n := scope.Spec.Scope.OpenStack.Network
+		return []string{n.Nodes, n.Pods, n.Services}
+	}
+	return nil
+}
+
+// findOverlappingRange returns the first of the ranges overlapping with the cidr, or empty if none does
+func findOverlappingRange(cidr string, ranges []string) string {
+	_, cidrNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return ""
+	}
+	for _, r := range ranges {
+		_, rNet, err := net.ParseCIDR(r)
+		if err != nil {
+			continue
+		}
+		if util.CidrOverlap(cidrNet, rNet) {
+			return r
+		}
+	}
+	return ""
+}
+
+// externalIpamLeaseOverlaps rejects the leased cidr overlapping with the existing range. The lease is released,
+// or recorded in the status to be released before the next lease request if the release fails, and the
+// lease is requested again after the delay, once the external IPAM might hold the existing ranges.
+func externalIpamLeaseOverlaps(ctx context.Context, state *State, lease *externalipam.Lease, overlapping string) (error, context.Context) {
+	ipRange := state.ObjAsIpRange()
+	logger := composed.LoggerFromCtx(ctx).WithValues(
+		"cidr", lease.Cidr,
+		"leaseId", lease.LeaseId,
+		"overlappingRange", overlapping,
+	)
+	logger.Info("Leased cidr from the external IPAM overlaps with the existing range")
+
+	if err := state.externalIpamClient.ReleaseLease(ctx, ipRange.Spec.ExternalIpam.Url, lease.LeaseId); err != nil {
+		logger.Error(err, "Error releasing the overlapping external IPAM cidr lease")
+		ipRange.Status.ExternalIpamLeaseId = lease.LeaseId
+	}
+
+	ipRange.Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(ipRange).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonCidrOverlap,
+			Message: fmt.Sprintf("CIDR %s leased from the external IPAM overlaps with the existing range %s", lease.Cidr, overlapping),
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status after overlapping external IPAM cidr lease").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+		Run(ctx, state)
+}
+
+// externalIpamUnavailable reports the unavailable external IPAM in the IpamUnavailable condition and
+// requeues to retry the lease or the release once it is back
+func externalIpamUnavailable(ctx context.Context, state *State, err error, errorLogMessage string) (error, context.Context) {
+	composed.LoggerFromCtx(ctx).Error(err, "External IPAM unavailable")
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeIpamUnavailable,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonIpamUnavailable,
+			Message: err.Error(),
+		}).
+		ErrorLogMessage(errorLogMessage).
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+		Run(ctx, state)
+}
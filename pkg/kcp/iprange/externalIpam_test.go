@@ -0,0 +1,257 @@
+package iprange
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeIpam is the external IPAM leasing the fixed cidr, and unavailable while the available is false
+type fakeIpam struct {
+	available bool
+	cidr      string
+	requests  []externalipam.LeaseRequest
+	released  []string
+}
+
+func (f *fakeIpam) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.available {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		req := externalipam.LeaseRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.requests = append(f.requests, req)
+		cidr := f.cidr
+		if len(cidr) == 0 {
+			cidr = "10.250.4.0/22"
+		}
+		_, _ = w.Write([]byte(`{"leaseId": "lease-1", "cidr": "` + cidr + `"}`))
+	case http.MethodDelete:
+		f.released = append(f.released, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newExternalIpamState(ipRange *cloudcontrolv1beta1.IpRange, scope *cloudcontrolv1beta1.Scope, others ...client.Object) *State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(others, ipRange)...).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	focalState.SetScope(scope)
+	return newState(focalState, externalipam.NewClient()).(*State)
+}
+
+func TestExternalIpam(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newIpRange := func(url string) *cloudcontrolv1beta1.IpRange {
+		return &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				Scope:        cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+				ExternalIpam: &cloudcontrolv1beta1.IpRangeExternalIpam{Url: url},
+			},
+		}
+	}
+
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Provider: cloudcontrolv1beta1.ProviderAws,
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Nodes:    "10.250.0.0/22",
+						Pods:     "10.96.0.0/13",
+						Services: "10.104.0.0/13",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("cidr is leased", func(t *testing.T) {
+		ipam := &fakeIpam{available: true}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		ipRange := newIpRange(server.URL)
+		state := newExternalIpamState(ipRange, scope)
+		assert.False(t, shouldAllocateIpRange(ctx, state))
+
+		err, _ := externalIpamAllocate(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.4.0/22", ipRange.Status.Cidr)
+		assert.Equal(t, "lease-1", ipRange.Status.ExternalIpamLeaseId)
+		assert.Equal(t, []externalipam.LeaseRequest{{Name: "kcp-system/iprange", PrefixLength: 22}}, ipam.requests)
+
+		// leased only once
+		err, _ = externalIpamAllocate(ctx, state)
+		assert.Nil(t, err)
+		assert.Len(t, ipam.requests, 1)
+	})
+
+	t.Run("unavailable IPAM is retried", func(t *testing.T) {
+		ipam := &fakeIpam{}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		ipRange := newIpRange(server.URL)
+		state := newExternalIpamState(ipRange, scope)
+
+		err, _ := externalIpamAllocate(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(time.Minute), err)
+		assert.Empty(t, ipRange.Status.Cidr)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeIpamUnavailable)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonIpamUnavailable, cond.Reason)
+
+		ipam.available = true
+		err, _ = externalIpamAllocate(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.4.0/22", ipRange.Status.Cidr)
+		assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeIpamUnavailable))
+	})
+
+	t.Run("lease is released on delete", func(t *testing.T) {
+		ipam := &fakeIpam{}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		ipRange := newIpRange(server.URL)
+		ipRange.Finalizers = []string{cloudcontrolv1beta1.FinalizerName}
+		ipRange.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		ipRange.Status.Cidr = "10.250.4.0/22"
+		ipRange.Status.ExternalIpamLeaseId = "lease-1"
+		state := newExternalIpamState(ipRange, scope)
+
+		err, _ := externalIpamRelease(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(time.Minute), err)
+		assert.Equal(t, "lease-1", ipRange.Status.ExternalIpamLeaseId)
+		assert.NotNil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeIpamUnavailable))
+
+		ipam.available = true
+		err, _ = externalIpamRelease(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Empty(t, ipRange.Status.ExternalIpamLeaseId)
+		assert.Equal(t, []string{"/leases/lease-1"}, ipam.released)
+	})
+
+	t.Run("lease overlapping the Scope nodes is released and retried", func(t *testing.T) {
+		ipam := &fakeIpam{available: true, cidr: "10.250.0.0/22"}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		ipRange := newIpRange(server.URL)
+		state := newExternalIpamState(ipRange, scope)
+
+		err, _ := externalIpamAllocate(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(5*time.Minute), err)
+		assert.Empty(t, ipRange.Status.Cidr)
+		assert.Empty(t, ipRange.Status.ExternalIpamLeaseId)
+		assert.Equal(t, []string{"/leases/lease-1"}, ipam.released)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonCidrOverlap, cond.Reason)
+		assert.Contains(t, cond.Message, "10.250.0.0/22")
+
+		ipam.cidr = "10.250.8.0/22"
+		err, _ = externalIpamAllocate(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.8.0/22", ipRange.Status.Cidr)
+	})
+
+	t.Run("lease overlapping other IpRange of the Scope is rejected", func(t *testing.T) {
+		ipam := &fakeIpam{available: true, cidr: "10.250.4.0/22"}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		other := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "other"},
+			Spec:       cloudcontrolv1beta1.IpRangeSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope"}},
+			Status:     cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.6.0/24"},
+		}
+		otherScope := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "other-scope"},
+			Spec:       cloudcontrolv1beta1.IpRangeSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "other-scope"}},
+			Status:     cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.12.0/22"},
+		}
+		ipRange := newIpRange(server.URL)
+		state := newExternalIpamState(ipRange, scope, other, otherScope)
+
+		err, _ := externalIpamAllocate(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(5*time.Minute), err)
+		assert.Empty(t, ipRange.Status.Cidr)
+		assert.Contains(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError).Message, "10.250.6.0/24")
+
+		// the range of the IpRange of other Scope does not matter
+		ipam.cidr = "10.250.12.0/22"
+		err, _ = externalIpamAllocate(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.250.12.0/22", ipRange.Status.Cidr)
+	})
+
+	t.Run("overlapping lease failed to release is released before the next lease", func(t *testing.T) {
+		ipam := &fakeIpam{available: true, cidr: "10.250.0.0/22"}
+		server := httptest.NewServer(ipam)
+		defer server.Close()
+
+		ipRange := newIpRange(server.URL)
+		state := newExternalIpamState(ipRange, scope)
+		ipRange.Status.ExternalIpamLeaseId = "lease-0"
+		ipam.cidr = "10.250.8.0/22"
+
+		err, _ := externalIpamAllocate(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/leases/lease-0"}, ipam.released)
+		assert.Equal(t, "10.250.8.0/22", ipRange.Status.Cidr)
+		assert.Equal(t, "lease-1", ipRange.Status.ExternalIpamLeaseId)
+	})
+}
@@ -0,0 +1,134 @@
+package externalipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The contract of the external IPAM endpoint, relative to the spec.externalIpam.url of the IpRange:
+//
+//	POST   <url>/leases            {"name": "<namespace>/<name>", "prefixLength": 22}
+//	                               -> 200 or 201 {"leaseId": "lease-1", "cidr": "10.250.4.0/22"}
+//	DELETE <url>/leases/<leaseId>  -> 200 or 204, or 404 if the lease does not exist anymore
+//
+// The lease request must be idempotent by the name, returning the existing lease of the name, since the
+// request is repeated if the lease could not be recorded in the IpRange status. The 5xx responses and the
+// connection failures are reported as ErrUnavailable, the other responses not in the 2xx range as the
+// RejectedError with the response body as the message.
+
+const defaultTimeout = 30 * time.Second
+
+// ErrUnavailable is returned when the external IPAM could not be reached or failed to handle the request
+var ErrUnavailable = errors.New("external IPAM unavailable")
+
+// RejectedError is returned when the external IPAM rejected the request
+type RejectedError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("external IPAM rejected the request with status %d: %s", e.StatusCode, e.Message)
+}
+
+type LeaseRequest struct {
+	Name         string `json:"name"`
+	PrefixLength int    `json:"prefixLength"`
+}
+
+type Lease struct {
+	LeaseId string `json:"leaseId"`
+	Cidr    string `json:"cidr"`
+}
+
+type Client interface {
+	RequestLease(ctx context.Context, baseUrl string, request LeaseRequest) (*Lease, error)
+	ReleaseLease(ctx context.Context, baseUrl string, leaseId string) error
+}
+
+func NewClient() Client {
+	return &client{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+type client struct {
+	httpClient *http.Client
+}
+
+func (c *client) RequestLease(ctx context.Context, baseUrl string, request LeaseRequest) (*Lease, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := c.do(ctx, http.MethodPost, strings.TrimSuffix(baseUrl, "/")+"/leases", body)
+	if err != nil {
+		return nil, err
+	}
+
+	lease := &Lease{}
+	if err := json.Unmarshal(respBody, lease); err != nil {
+		return nil, &RejectedError{StatusCode: http.StatusOK, Message: fmt.Sprintf("invalid lease response: %v", err)}
+	}
+	if lease.LeaseId == "" {
+		return nil, &RejectedError{StatusCode: http.StatusOK, Message: "lease response without leaseId"}
+	}
+	if _, ipNet, err := net.ParseCIDR(lease.Cidr); err != nil {
+		return nil, &RejectedError{StatusCode: http.StatusOK, Message: fmt.Sprintf("lease response with invalid cidr %q", lease.Cidr)}
+	} else if ones, _ := ipNet.Mask.Size(); ones != request.PrefixLength {
+		return nil, &RejectedError{StatusCode: http.StatusOK, Message: fmt.Sprintf("leased cidr %s is not a /%d", lease.Cidr, request.PrefixLength)}
+	}
+	return lease, nil
+}
+
+func (c *client) ReleaseLease(ctx context.Context, baseUrl string, leaseId string) error {
+	_, err := c.do(ctx, http.MethodDelete, strings.TrimSuffix(baseUrl, "/")+"/leases/"+url.PathEscape(leaseId), nil)
+	var rejected *RejectedError
+	if errors.As(err, &rejected) && rejected.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *client) do(ctx context.Context, method, u string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading response: %v", ErrUnavailable, err)
+	}
+	if resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("%w: responded with status %d", ErrUnavailable, resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &RejectedError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+	return respBody, nil
+}
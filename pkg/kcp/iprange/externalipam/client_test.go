@@ -0,0 +1,91 @@
+package externalipam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lease is requested and released", func(t *testing.T) {
+		var requested LeaseRequest
+		var released string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/ipam/leases":
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&requested))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"leaseId": "lease-1", "cidr": "10.250.4.0/22"}`))
+			case r.Method == http.MethodDelete:
+				released = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+		defer server.Close()
+
+		c := NewClient()
+		lease, err := c.RequestLease(ctx, server.URL+"/ipam/", LeaseRequest{Name: "kcp-system/iprange", PrefixLength: 22})
+		assert.NoError(t, err)
+		assert.Equal(t, &Lease{LeaseId: "lease-1", Cidr: "10.250.4.0/22"}, lease)
+		assert.Equal(t, LeaseRequest{Name: "kcp-system/iprange", PrefixLength: 22}, requested)
+
+		assert.NoError(t, c.ReleaseLease(ctx, server.URL+"/ipam", "lease-1"))
+		assert.Equal(t, "/ipam/leases/lease-1", released)
+	})
+
+	t.Run("server errors are unavailability", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewClient().RequestLease(ctx, server.URL, LeaseRequest{Name: "kcp-system/iprange", PrefixLength: 22})
+		assert.ErrorIs(t, err, ErrUnavailable)
+
+		server.Close()
+		err = NewClient().ReleaseLease(ctx, server.URL, "lease-1")
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("client errors are rejections", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("no free /22 block\n"))
+		}))
+		defer server.Close()
+
+		_, err := NewClient().RequestLease(ctx, server.URL, LeaseRequest{Name: "kcp-system/iprange", PrefixLength: 22})
+		var rejected *RejectedError
+		assert.True(t, errors.As(err, &rejected))
+		assert.Equal(t, http.StatusConflict, rejected.StatusCode)
+		assert.Equal(t, "no free /22 block", rejected.Message)
+
+		// the lease already released
+		assert.NoError(t, NewClient().ReleaseLease(ctx, server.URL, "lease-1"))
+	})
+
+	t.Run("lease of another size is rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"leaseId": "lease-1", "cidr": "10.250.4.0/24"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewClient().RequestLease(ctx, server.URL, LeaseRequest{Name: "kcp-system/iprange", PrefixLength: 22})
+		var rejected *RejectedError
+		assert.True(t, errors.As(err, &rejected))
+		assert.Contains(t, rejected.Message, "is not a /22")
+	})
+}
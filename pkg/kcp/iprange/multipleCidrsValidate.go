@@ -0,0 +1,34 @@
+package iprange
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// multipleCidrsValidate stops the IpRange with more than one CIDR block on providers
+// not supporting them, atm only AWS allocates the subnets across multiple blocks
+func multipleCidrsValidate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if composed.MarkedForDeletionPredicate(ctx, st) {
+		return nil, nil
+	}
+	if len(state.ObjAsIpRange().SpecCidrs()) <= 1 || focal.AwsProviderPredicate(ctx, st) {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonMultipleCidrsNotSupported,
+			Message: "Multiple CIDR blocks are not supported by the provider",
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with multiple cidrs not supported").
+		SuccessLogMsg("Forgetting KCP IpRange with multiple cidrs not supported").
+		Run(ctx, st)
+}
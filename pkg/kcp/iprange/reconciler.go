@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/feature"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
 	awsiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange"
 	azureiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/iprange"
 	gcpiprange "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange"
@@ -12,6 +13,7 @@ import (
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -27,6 +29,8 @@ type ipRangeReconciler struct {
 	awsStateFactory   awsiprange.StateFactory
 	azureStateFactory azureiprange.StateFactory
 	gcpStateFactory   gcpiprange.StateFactory
+
+	externalIpamClient externalipam.Client
 }
 
 func NewIPRangeReconciler(
@@ -35,6 +39,7 @@ func NewIPRangeReconciler(
 	awsStateFactory awsiprange.StateFactory,
 	azureStateFactory azureiprange.StateFactory,
 	gcpStateFactory gcpiprange.StateFactory,
+	externalIpamClient externalipam.Client,
 ) IPRangeReconciler {
 	return &ipRangeReconciler{
 		composedStateFactory: composedStateFactory,
@@ -42,6 +47,7 @@ func NewIPRangeReconciler(
 		awsStateFactory:      awsStateFactory,
 		azureStateFactory:    azureStateFactory,
 		gcpStateFactory:      gcpStateFactory,
+		externalIpamClient:   externalIpamClient,
 	}
 }
 
@@ -61,11 +67,19 @@ func (r *ipRangeReconciler) newAction() composed.Action {
 		"main",
 		feature.LoadFeatureContextFromObj(&cloudcontrolv1beta1.IpRange{}),
 		focal.New(),
-		func(ctx context.Context, st composed.State) (error, context.Context) {
+		composed.MigrateDeprecatedSpec,
+		composed.ApiCallLogging,
+		composed.ReconcileHeartbeat(util.Timing.T300000ms()),
+		composed.TtlAfterCreation(composed.NotifyTransitions(composed.ResourceLock(func(ctx context.Context, st composed.State) (error, context.Context) {
 			return composed.ComposeActions(
 				"ipRangeCommon",
 				// common IpRange common actions here
+				focal.ValidateProviderOverride,
 				actions.PatchAddFinalizer,
+				waitDependentsDeleted,
+				multipleCidrsValidate,
+				dryRunValidate,
+				externalIpamAllocate,
 				composed.If(
 					shouldAllocateIpRange,
 					composed.BuildSwitchAction(
@@ -112,11 +126,13 @@ func (r *ipRangeReconciler) newAction() composed.Action {
 					kcpNetworkDelete,
 					kymaPeeringDeleteWait,
 					kcpNetworkDeleteWait,
+					externalIpamRelease,
 					actions.PatchRemoveFinalizer,
+					actions.ReportForeignFinalizers,
 				),
 				statusReady,
-			)(ctx, newState(st.(focal.State)))
-		},
+			)(ctx, newState(st.(focal.State), r.externalIpamClient))
+		}))),
 	)
 }
 
@@ -0,0 +1,23 @@
+package iprange
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	// the spec is written by the SKR IpRange reconciler in the deprecated form, so it's migrated only in memory
+	composed.RegisterSpecMigration(&cloudcontrolv1beta1.IpRange{}, false, migrateSpecCidrToCidrs)
+}
+
+// migrateSpecCidrToCidrs converts the deprecated spec.cidr to the single block of spec.cidrs
+func migrateSpecCidrToCidrs(obj client.Object) bool {
+	ipRange := obj.(*cloudcontrolv1beta1.IpRange)
+	if len(ipRange.Spec.Cidr) == 0 || len(ipRange.Spec.Cidrs) > 0 {
+		return false
+	}
+	ipRange.Spec.Cidrs = []string{ipRange.Spec.Cidr}
+	ipRange.Spec.Cidr = ""
+	return true
+}
@@ -0,0 +1,70 @@
+package iprange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestIpRangeSpecCidrMigratedInMemory(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec:       cloudcontrolv1beta1.IpRangeSpec{Cidr: "10.250.4.0/22"},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange)
+
+	var seenCidr string
+	var seenCidrs []string
+	err, _ := composed.ComposeActions(
+		"test",
+		composed.MigrateDeprecatedSpec,
+		func(ctx context.Context, st composed.State) (error, context.Context) {
+			obj := st.Obj().(*cloudcontrolv1beta1.IpRange)
+			seenCidr = obj.Spec.Cidr
+			seenCidrs = obj.Spec.Cidrs
+			return nil, nil
+		},
+	)(ctx, state)
+	assert.NoError(t, err)
+
+	// downstream actions see the new form
+	assert.Empty(t, seenCidr)
+	assert.Equal(t, []string{"10.250.4.0/22"}, seenCidrs)
+
+	// and the object is not written, since the SKR reconciler owns the deprecated form
+	saved := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Equal(t, "10.250.4.0/22", saved.Spec.Cidr)
+	assert.Empty(t, saved.Spec.Cidrs)
+}
+
+func TestIpRangeSpecCidrsNotMigrated(t *testing.T) {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		Spec: cloudcontrolv1beta1.IpRangeSpec{Cidrs: []string{"10.250.4.0/22", "10.250.16.0/22"}},
+	}
+	assert.False(t, migrateSpecCidrToCidrs(ipRange))
+	assert.Equal(t, []string{"10.250.4.0/22", "10.250.16.0/22"}, ipRange.Spec.Cidrs)
+}
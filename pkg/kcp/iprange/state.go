@@ -3,6 +3,7 @@ package iprange
 import (
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/externalipam"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/iprange/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -10,6 +11,8 @@ import (
 type State struct {
 	focal.State
 
+	externalIpamClient externalipam.Client
+
 	existingCidrRanges []string
 
 	networkKey            client.ObjectKey
@@ -37,6 +40,9 @@ func (s *State) ExistingCidrRanges() []string {
 func (s *State) SetExistingCidrRanges(v []string) {
 	s.existingCidrRanges = v
 }
-func newState(focalState focal.State) types.State {
-	return &State{State: focalState}
+func newState(focalState focal.State, externalIpamClient externalipam.Client) types.State {
+	return &State{
+		State:              focalState,
+		externalIpamClient: externalIpamClient,
+	}
 }
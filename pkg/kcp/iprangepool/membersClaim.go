@@ -0,0 +1,44 @@
+package iprangepool
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// membersClaim records the claimant on the members claimed since the last reconciliation,
+// so they can be recognized as returned once the consumer removes the claim label
+func membersClaim(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	for _, member := range state.members {
+		if !isClaimed(member) {
+			continue
+		}
+		claimant := member.Labels[cloudcontrolv1beta1.LabelIpRangePoolClaim]
+		if member.Annotations[cloudcontrolv1beta1.AnnotationIpRangePoolClaimedBy] == claimant {
+			continue
+		}
+
+		logger.
+			WithValues(
+				"ipRange", member.Name,
+				"claimant", claimant,
+			).
+			Info("IpRange claimed from the pool")
+
+		patch := client.MergeFrom(member.DeepCopy())
+		if member.Annotations == nil {
+			member.Annotations = map[string]string{}
+		}
+		member.Annotations[cloudcontrolv1beta1.AnnotationIpRangePoolClaimedBy] = claimant
+		if err := state.Cluster().K8sClient().Patch(ctx, member, patch); err != nil {
+			return composed.LogErrorAndReturn(err, "Error patching claimed IpRangePool member", composed.StopWithRequeue, ctx)
+		}
+	}
+
+	return nil, ctx
+}
@@ -0,0 +1,46 @@
+package iprangepool
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// membersCreate creates new warm IpRanges until the pool has spec.pool.minAvailable unclaimed members.
+// Cidr of the created IpRanges is left empty, so it is allocated from the Scope.
+func membersCreate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	pool := state.ObjAsIpRangePool()
+
+	n := getMembersToCreate(pool, countMembers(state.members))
+	for i := 0; i < n; i++ {
+		member := &cloudcontrolv1beta1.IpRange{}
+		member.Namespace = pool.Namespace
+		member.Name = uuid.NewString()
+		member.Labels = map[string]string{
+			cloudcontrolv1beta1.LabelIpRangePool: pool.Name,
+		}
+		member.Spec = cloudcontrolv1beta1.IpRangeSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{
+				Namespace: pool.Namespace,
+				Name:      pool.Name,
+			},
+			Scope: pool.Spec.Scope,
+		}
+
+		if err := state.Cluster().K8sClient().Create(ctx, member); err != nil {
+			return composed.LogErrorAndReturn(err, "Error creating IpRangePool member", composed.StopWithRequeue, ctx)
+		}
+
+		logger.
+			WithValues("ipRange", member.Name).
+			Info("Created IpRangePool member")
+
+		state.members = append(state.members, member)
+	}
+
+	return nil, ctx
+}
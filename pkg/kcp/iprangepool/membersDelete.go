@@ -0,0 +1,54 @@
+package iprangepool
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// membersDelete deletes the unclaimed members of the pool being deleted and waits until they are gone.
+// Claimed members are left to their claimants, and returned ones are deleted once they are not used.
+func membersDelete(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	waiting := false
+	for _, member := range state.members {
+		if isClaimed(member) {
+			continue
+		}
+		waiting = true
+		if !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if isReturned(member) {
+			used, err := isMemberUsed(ctx, state, member)
+			if err != nil {
+				return composed.LogErrorAndReturn(err, "Error checking returned IpRangePool member usage", composed.StopWithRequeue, ctx)
+			}
+			if used {
+				continue
+			}
+		}
+
+		logger.
+			WithValues("ipRange", member.Name).
+			Info("Deleting IpRangePool member")
+
+		err := state.Cluster().K8sClient().Delete(ctx, member)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error deleting IpRangePool member", composed.StopWithRequeue, ctx)
+		}
+	}
+
+	if waiting {
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	return nil, ctx
+}
@@ -0,0 +1,31 @@
+package iprangepool
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func membersLoad(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	list := &cloudcontrolv1beta1.IpRangeList{}
+	err := state.Cluster().K8sClient().List(
+		ctx,
+		list,
+		client.InNamespace(state.Name().Namespace),
+		client.MatchingLabels{cloudcontrolv1beta1.LabelIpRangePool: state.Name().Name},
+	)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing IpRangePool member IpRanges", composed.StopWithRequeue, ctx)
+	}
+
+	state.members = make([]*cloudcontrolv1beta1.IpRange, 0, len(list.Items))
+	for i := range list.Items {
+		state.members = append(state.members, &list.Items[i])
+	}
+
+	return nil, ctx
+}
@@ -0,0 +1,79 @@
+package iprangepool
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// membersRecycle scrubs the returned members that are no longer used by any cloud resource,
+// and puts them back into the pool as available
+func membersRecycle(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	for _, member := range state.members {
+		if !isReturned(member) {
+			continue
+		}
+
+		used, err := isMemberUsed(ctx, state, member)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error checking returned IpRangePool member usage", composed.StopWithRequeue, ctx)
+		}
+		if used {
+			state.recyclePending = true
+			continue
+		}
+
+		logger.
+			WithValues("ipRange", member.Name).
+			Info("Recycling IpRange returned to the pool")
+
+		patch := client.MergeFrom(member.DeepCopy())
+		scrubMember(state.ObjAsIpRangePool(), member)
+		if err := state.Cluster().K8sClient().Patch(ctx, member, patch); err != nil {
+			return composed.LogErrorAndReturn(err, "Error patching recycled IpRangePool member", composed.StopWithRequeue, ctx)
+		}
+	}
+
+	return nil, ctx
+}
+
+// scrubMember removes the metadata the claimant has set on the member and points it back to the pool
+func scrubMember(pool *cloudcontrolv1beta1.IpRangePool, member *cloudcontrolv1beta1.IpRange) {
+	delete(member.Annotations, cloudcontrolv1beta1.AnnotationIpRangePoolClaimedBy)
+	delete(member.Labels, cloudcontrolv1beta1.LabelKymaName)
+	delete(member.Labels, cloudcontrolv1beta1.LabelRemoteName)
+	delete(member.Labels, cloudcontrolv1beta1.LabelRemoteNamespace)
+	member.Spec.RemoteRef = cloudcontrolv1beta1.RemoteRef{
+		Namespace: pool.Namespace,
+		Name:      pool.Name,
+	}
+}
+
+func isMemberUsed(ctx context.Context, state *State, member *cloudcontrolv1beta1.IpRange) (bool, error) {
+	nfsList := &cloudcontrolv1beta1.NfsInstanceList{}
+	if err := state.Cluster().K8sClient().List(ctx, nfsList, client.InNamespace(member.Namespace)); err != nil {
+		return false, err
+	}
+	for _, nfs := range nfsList.Items {
+		if nfs.Spec.IpRange.Name == member.Name {
+			return true, nil
+		}
+	}
+
+	redisList := &cloudcontrolv1beta1.RedisInstanceList{}
+	if err := state.Cluster().K8sClient().List(ctx, redisList, client.InNamespace(member.Namespace)); err != nil {
+		return false, err
+	}
+	for _, redis := range redisList.Items {
+		if redis.Spec.IpRange.Name == member.Name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,19 @@
+package iprangepool
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/metrics"
+)
+
+func metricsReport(state *State, counts memberCounts) {
+	metrics.IpRangePoolAvailable.WithLabelValues(state.Name().Namespace, state.Name().Name).Set(float64(counts.available))
+	metrics.IpRangePoolAllocated.WithLabelValues(state.Name().Namespace, state.Name().Name).Set(float64(counts.allocated))
+}
+
+func metricsDelete(ctx context.Context, st composed.State) (error, context.Context) {
+	metrics.IpRangePoolAvailable.DeleteLabelValues(st.Name().Namespace, st.Name().Name)
+	metrics.IpRangePoolAllocated.DeleteLabelValues(st.Name().Namespace, st.Name().Name)
+	return nil, ctx
+}
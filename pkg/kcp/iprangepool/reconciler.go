@@ -0,0 +1,82 @@
+package iprangepool
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type IpRangePoolReconciler interface {
+	reconcile.Reconciler
+}
+
+type ipRangePoolReconciler struct {
+	composedStateFactory composed.StateFactory
+	focalStateFactory    focal.StateFactory
+}
+
+func NewIpRangePoolReconciler(
+	composedStateFactory composed.StateFactory,
+	focalStateFactory focal.StateFactory,
+) IpRangePoolReconciler {
+	return &ipRangePoolReconciler{
+		composedStateFactory: composedStateFactory,
+		focalStateFactory:    focalStateFactory,
+	}
+}
+
+func (r *ipRangePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if Ignore != nil && Ignore.ShouldIgnoreKey(req) {
+		return ctrl.Result{}, nil
+	}
+
+	state := r.newFocalState(req.NamespacedName)
+	action := r.newAction()
+
+	return composed.Handle(action(ctx, state))
+}
+
+func (r *ipRangePoolReconciler) newAction() composed.Action {
+	return composed.ComposeActions(
+		"main",
+		feature.LoadFeatureContextFromObj(&cloudcontrolv1beta1.IpRangePool{}),
+		focal.New(),
+		func(ctx context.Context, st composed.State) (error, context.Context) {
+			return composed.ComposeActions(
+				"ipRangePool",
+				actions.PatchAddFinalizer,
+				membersLoad,
+				composed.IfElse(
+					composed.Not(composed.MarkedForDeletionPredicate),
+					composed.ComposeActions(
+						"ipRangePool-create",
+						membersClaim,
+						membersRecycle,
+						membersCreate,
+						updateStatus,
+					),
+					composed.ComposeActions(
+						"ipRangePool-delete",
+						membersDelete,
+						metricsDelete,
+						actions.PatchRemoveFinalizer,
+					),
+				),
+				composed.StopAndForgetAction,
+			)(ctx, newState(st.(focal.State)))
+		},
+	)
+}
+
+func (r *ipRangePoolReconciler) newFocalState(name types.NamespacedName) focal.State {
+	return r.focalStateFactory.NewState(
+		r.composedStateFactory.NewState(name, &cloudcontrolv1beta1.IpRangePool{}),
+	)
+}
@@ -0,0 +1,23 @@
+package iprangepool
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+)
+
+type State struct {
+	focal.State
+
+	members []*cloudcontrolv1beta1.IpRange
+
+	// recyclePending is set when some returned member is still used and could not be recycled yet
+	recyclePending bool
+}
+
+func (s *State) ObjAsIpRangePool() *cloudcontrolv1beta1.IpRangePool {
+	return s.Obj().(*cloudcontrolv1beta1.IpRangePool)
+}
+
+func newState(focalState focal.State) *State {
+	return &State{State: focalState}
+}
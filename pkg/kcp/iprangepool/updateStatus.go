@@ -0,0 +1,69 @@
+package iprangepool
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func updateStatus(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	pool := state.ObjAsIpRangePool()
+
+	counts := countMembers(state.members)
+	metricsReport(state, counts)
+
+	var successError error = composed.StopAndForget
+	if state.recyclePending || counts.warming > 0 {
+		// members becoming ready are watched, but usage of the returned ones is not
+		successError = composed.StopWithRequeueDelay(util.Timing.T60000ms())
+	}
+
+	exhausted := pool.Spec.Pool.MinAvailable > 0 && counts.available == 0
+
+	changed := pool.Status.Available != counts.available ||
+		pool.Status.Allocated != counts.allocated ||
+		pool.Status.State != cloudcontrolv1beta1.ReadyState
+
+	pool.Status.Available = counts.available
+	pool.Status.Allocated = counts.allocated
+	pool.Status.State = cloudcontrolv1beta1.ReadyState
+
+	readyCondition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: fmt.Sprintf("Available %d, allocated %d", counts.available, counts.allocated),
+	}
+	exhaustedCondition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypePoolExhausted,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonPoolExhausted,
+		Message: "No IpRange is available in the pool",
+	}
+
+	b := composed.UpdateStatus(pool).
+		SetCondition(readyCondition)
+	if exhausted {
+		changed = changed || composed.AnyConditionChanged(pool, readyCondition, exhaustedCondition)
+		b = b.SetCondition(exhaustedCondition)
+	} else {
+		changed = changed || composed.AnyConditionChanged(pool, readyCondition) ||
+			meta.FindStatusCondition(pool.Status.Conditions, cloudcontrolv1beta1.ConditionTypePoolExhausted) != nil
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypePoolExhausted)
+	}
+
+	if !changed {
+		return successError, nil
+	}
+
+	return b.
+		ErrorLogMessage("Error updating KCP IpRangePool status").
+		SuccessError(successError).
+		Run(ctx, state)
+}
@@ -0,0 +1,64 @@
+package iprangepool
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func isClaimed(member *cloudcontrolv1beta1.IpRange) bool {
+	_, ok := member.Labels[cloudcontrolv1beta1.LabelIpRangePoolClaim]
+	return ok
+}
+
+// isReturned tells if the member was claimed and then returned to the pool, but is not yet scrubbed
+func isReturned(member *cloudcontrolv1beta1.IpRange) bool {
+	_, ok := member.Annotations[cloudcontrolv1beta1.AnnotationIpRangePoolClaimedBy]
+	return ok && !isClaimed(member)
+}
+
+func isAvailable(member *cloudcontrolv1beta1.IpRange) bool {
+	return !isClaimed(member) &&
+		!isReturned(member) &&
+		member.DeletionTimestamp.IsZero() &&
+		meta.IsStatusConditionTrue(member.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+}
+
+type memberCounts struct {
+	// available are ready and unclaimed members
+	available int
+	// warming are unclaimed members that are still being provisioned
+	warming int
+	// allocated are claimed members and the returned ones waiting to be recycled
+	allocated int
+}
+
+func (c memberCounts) total() int {
+	return c.available + c.warming + c.allocated
+}
+
+func countMembers(members []*cloudcontrolv1beta1.IpRange) memberCounts {
+	result := memberCounts{}
+	for _, m := range members {
+		switch {
+		case isClaimed(m) || isReturned(m):
+			result.allocated++
+		case !m.DeletionTimestamp.IsZero():
+			// members being deleted are not counted
+		case isAvailable(m):
+			result.available++
+		default:
+			result.warming++
+		}
+	}
+	return result
+}
+
+// getMembersToCreate returns the number of new members needed to keep the pool at the
+// minAvailable warm IpRanges, limited by the pool maxSize
+func getMembersToCreate(pool *cloudcontrolv1beta1.IpRangePool, counts memberCounts) int {
+	n := pool.Spec.Pool.MinAvailable - counts.available - counts.warming
+	if pool.Spec.Pool.MaxSize > 0 {
+		n = min(n, pool.Spec.Pool.MaxSize-counts.total())
+	}
+	return max(n, 0)
+}
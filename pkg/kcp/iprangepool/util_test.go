@@ -0,0 +1,105 @@
+package iprangepool
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type utilSuite struct {
+	suite.Suite
+}
+
+func newMember(ready bool, claimant string, claimedBy string) *cloudcontrolv1beta1.IpRange {
+	m := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{cloudcontrolv1beta1.LabelIpRangePool: "pool"},
+			Annotations: map[string]string{},
+		},
+	}
+	if ready {
+		m.Status.Conditions = []metav1.Condition{{
+			Type:   cloudcontrolv1beta1.ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+		}}
+	}
+	if claimant != "" {
+		m.Labels[cloudcontrolv1beta1.LabelIpRangePoolClaim] = claimant
+	}
+	if claimedBy != "" {
+		m.Annotations[cloudcontrolv1beta1.AnnotationIpRangePoolClaimedBy] = claimedBy
+	}
+	return m
+}
+
+func newPool(minAvailable, maxSize int) *cloudcontrolv1beta1.IpRangePool {
+	return &cloudcontrolv1beta1.IpRangePool{
+		Spec: cloudcontrolv1beta1.IpRangePoolSpec{
+			Pool: cloudcontrolv1beta1.IpRangePoolOptions{
+				MinAvailable: minAvailable,
+				MaxSize:      maxSize,
+			},
+		},
+	}
+}
+
+func (suite *utilSuite) TestCountMembers() {
+	counts := countMembers([]*cloudcontrolv1beta1.IpRange{
+		newMember(true, "", ""),
+		newMember(false, "", ""),
+		newMember(true, "a", "a"),
+		newMember(true, "b", ""),
+		newMember(true, "", "c"),
+	})
+
+	assert.Equal(suite.T(), 1, counts.available)
+	assert.Equal(suite.T(), 1, counts.warming)
+	assert.Equal(suite.T(), 3, counts.allocated)
+	assert.Equal(suite.T(), 5, counts.total())
+}
+
+func (suite *utilSuite) TestGetMembersToCreate() {
+	testCases := []struct {
+		title    string
+		pool     *cloudcontrolv1beta1.IpRangePool
+		counts   memberCounts
+		expected int
+	}{
+		{"empty pool", newPool(3, 0), memberCounts{}, 3},
+		{"warming are counted", newPool(3, 0), memberCounts{available: 1, warming: 1}, 1},
+		{"allocated are not counted", newPool(2, 0), memberCounts{available: 2, allocated: 5}, 0},
+		{"limited by max size", newPool(3, 4), memberCounts{allocated: 2}, 2},
+		{"over max size", newPool(3, 2), memberCounts{allocated: 3}, 0},
+		{"zero min available", newPool(0, 0), memberCounts{}, 0},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.title, func() {
+			assert.Equal(suite.T(), tc.expected, getMembersToCreate(tc.pool, tc.counts))
+		})
+	}
+}
+
+func (suite *utilSuite) TestScrubMember() {
+	pool := newPool(1, 0)
+	pool.Namespace = "kcp-system"
+	pool.Name = "pool"
+	member := newMember(true, "", "a")
+	member.Labels[cloudcontrolv1beta1.LabelKymaName] = "kyma"
+	member.Spec.RemoteRef = cloudcontrolv1beta1.RemoteRef{Namespace: "skr", Name: "consumer"}
+
+	scrubMember(pool, member)
+
+	assert.False(suite.T(), isReturned(member))
+	assert.True(suite.T(), isAvailable(member))
+	assert.NotContains(suite.T(), member.Labels, cloudcontrolv1beta1.LabelKymaName)
+	assert.Contains(suite.T(), member.Labels, cloudcontrolv1beta1.LabelIpRangePool)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.RemoteRef{Namespace: "kcp-system", Name: "pool"}, member.Spec.RemoteRef)
+}
+
+func TestUtil(t *testing.T) {
+	suite.Run(t, new(utilSuite))
+}
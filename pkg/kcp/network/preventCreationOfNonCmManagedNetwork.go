@@ -39,7 +39,7 @@ func preventCreationOfNonCmManagedNetwork(ctx context.Context, st composed.State
 	cond := meta.FindStatusCondition(state.ObjAsNetwork().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
 	if cond == nil {
 		changed = true
-	} else if cond.Status != metav1.ConditionTrue || cond.Reason != cloudcontrolv1beta1.ConditionTypeError || cond.Message != message {
+	} else if cond.Status != metav1.ConditionTrue || cond.Reason != cloudcontrolv1beta1.ConditionTypeError || !composed.ConditionMessageEquals(*cond, message) {
 		changed = true
 	}
 
@@ -0,0 +1,21 @@
+package nfsinstance
+
+import (
+	"context"
+	"net"
+
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	nfsinstancetypes "github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance/types"
+)
+
+const nfsPort = "2049"
+
+// probeEndpoint checks the NFS port of the instance host is reachable
+var probeEndpoint = actions.ProbeEndpoint(func(ctx context.Context, st composed.State) (string, bool) {
+	host := st.(nfsinstancetypes.State).ObjAsNfsInstance().Status.Host
+	if len(host) == 0 {
+		return "", false
+	}
+	return net.JoinHostPort(host, nfsPort), false
+})
@@ -7,13 +7,18 @@ import (
 	azurenfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/nfsinstance"
 	cceenfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/ccee/nfsinstance"
 	gcpnfsinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/validation"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type NfsInstanceReconciler interface {
@@ -64,26 +69,51 @@ func (r *nfsInstanceReconciler) newAction() composed.Action {
 		"main",
 		feature.LoadFeatureContextFromObj(&cloudcontrolv1beta1.NfsInstance{}),
 		focal.New(),
-		func(ctx context.Context, st composed.State) (error, context.Context) {
-			return composed.ComposeActions(
-				"nfsInstanceCommon",
-				// common NfsInstance common actions here
-				loadIpRange,
-				copyStatusHostsToHost,
-				// and now branch to provider specific flow
-				composed.BuildSwitchAction(
-					"providerSwitch",
-					nil,
-					composed.NewCase(focal.AwsProviderPredicate, awsnfsinstance.New(r.awsStateFactory)),
-					composed.NewCase(focal.AzureProviderPredicate, azurenfsinstance.New(r.azureStateFactory)),
-					composed.NewCase(focal.GcpProviderPredicate, gcpnfsinstance.New(r.gcpStateFactory)),
-					composed.NewCase(focal.OpenStackProviderPredicate, cceenfsinstance.New(r.cceeStateFactory)),
-				),
-			)(ctx, newState(st.(focal.State)))
-		},
+		composed.ReconcileHeartbeat(util.Timing.T300000ms()),
+		composed.PausedUntil,
+		composed.ApiCallLogging,
+		composed.DeletionGracePeriod(actions.FinalizerName()),
+		composed.NotifyTransitions(composed.RecordLastError(composed.ResourceLock(composed.IfElse(
+			lastAppliedSpecFastPathPredicate,
+			composed.LastAppliedSpec(util.Timing.T300000ms()*2, r.newCommonAction()),
+			r.newCommonAction(),
+		)))),
 	)
 }
 
+func (r *nfsInstanceReconciler) newCommonAction() composed.Action {
+	return func(ctx context.Context, st composed.State) (error, context.Context) {
+		return composed.ComposeActions(
+			"nfsInstanceCommon",
+			// common NfsInstance common actions here
+			composed.PreventDeleteWhileInUseByWorkloads(
+				util.Timing.T10000ms(),
+				composed.LabelSelectorWorkloadReferences(
+					func() client.ObjectList { return &corev1.SecretList{} },
+					cloudcontrolv1beta1.LabelNfsInstanceBinding,
+				),
+			),
+			actions.ValidateSpec(validation.ValidateNfsInstance),
+			loadIpRange,
+			copyStatusHostsToHost,
+			probeEndpoint,
+			// and now branch to provider specific flow
+			composed.BuildSwitchAction(
+				"providerSwitch",
+				nil,
+				composed.NewCase(focal.AwsProviderPredicate, awsnfsinstance.New(r.awsStateFactory)),
+				composed.NewCase(focal.AzureProviderPredicate, azurenfsinstance.New(r.azureStateFactory)),
+				composed.NewCase(focal.GcpProviderPredicate, gcpnfsinstance.New(r.gcpStateFactory)),
+				composed.NewCase(focal.OpenStackProviderPredicate, cceenfsinstance.New(r.cceeStateFactory)),
+			),
+		)(ctx, newState(st.(focal.State)))
+	}
+}
+
+func lastAppliedSpecFastPathPredicate(ctx context.Context, _ composed.State) bool {
+	return feature.LastAppliedSpecFastPath.Value(ctx)
+}
+
 func (r *nfsInstanceReconciler) newFocalState(name types.NamespacedName) focal.State {
 	return r.focalStateFactory.NewState(
 		r.composedStateFactory.NewState(name, &cloudcontrolv1beta1.NfsInstance{}),
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	sdkmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// ApiCallLoggingMiddleware logs the parameters, the request ID and the response of the AWS API calls made with
+// the context of the object that enabled the API call logging with composed.ApiCallLogging. The credentials
+// are never logged, since the middleware only sees the sanitized operation input and output, and not the
+// signed HTTP request.
+func ApiCallLoggingMiddleware() smithymiddleware.InitializeMiddleware {
+	return smithymiddleware.InitializeMiddlewareFunc("ApiCallLogging", func(
+		ctx context.Context, in smithymiddleware.InitializeInput, next smithymiddleware.InitializeHandler,
+	) (
+		out smithymiddleware.InitializeOutput, metadata smithymiddleware.Metadata, err error,
+	) {
+		if !composed.ApiCallLoggingEnabled(ctx) {
+			return next.HandleInitialize(ctx, in)
+		}
+
+		logger := composed.LoggerFromCtx(ctx).
+			V(composed.ApiCallLogVerbosity).
+			WithValues(
+				"service", sdkmiddleware.GetServiceID(ctx),
+				"operation", sdkmiddleware.GetOperationName(ctx),
+			)
+		logger.
+			WithValues("parameters", composed.SanitizedApiCallPayload(in.Parameters)).
+			Info("AWS API call")
+
+		requestMadeTime := time.Now()
+		out, metadata, err = next.HandleInitialize(ctx, in)
+
+		requestId, _ := sdkmiddleware.GetRequestIDMetadata(metadata)
+		logger = logger.WithValues(
+			"requestId", requestId,
+			"latency", time.Since(requestMadeTime).String(),
+		)
+		if err != nil {
+			logger.
+				WithValues("error", err.Error()).
+				Info("AWS API call failed")
+			return out, metadata, err
+		}
+		logger.
+			WithValues("response", composed.SanitizedApiCallPayload(out.Result)).
+			Info("AWS API call succeeded")
+
+		return out, metadata, err
+	})
+}
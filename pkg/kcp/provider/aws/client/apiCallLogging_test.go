@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sdkmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	smithymiddleware "github.com/aws/smithy-go/middleware"
+	"github.com/go-logr/logr/funcr"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestApiCallLoggingMiddleware(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: composed.ApiCallLogVerbosity})
+	ctx := log.IntoContext(context.Background(), logger)
+
+	handler := func(result any, err error) smithymiddleware.InitializeHandler {
+		return smithymiddleware.InitializeHandlerFunc(func(ctx context.Context, in smithymiddleware.InitializeInput) (smithymiddleware.InitializeOutput, smithymiddleware.Metadata, error) {
+			metadata := smithymiddleware.Metadata{}
+			sdkmiddleware.SetRequestIDMetadata(&metadata, "request-1")
+			return smithymiddleware.InitializeOutput{Result: result}, metadata, err
+		})
+	}
+
+	t.Run("not logged if not enabled", func(t *testing.T) {
+		lines = nil
+
+		_, _, err := ApiCallLoggingMiddleware().HandleInitialize(ctx, smithymiddleware.InitializeInput{
+			Parameters: &ec2.DescribeVpcsInput{VpcIds: []string{"vpc-1"}},
+		}, handler(&ec2.DescribeVpcsOutput{}, nil))
+
+		assert.NoError(t, err)
+		assert.Empty(t, lines)
+	})
+
+	t.Run("parameters, request id and response logged if enabled", func(t *testing.T) {
+		lines = nil
+
+		_, _, err := ApiCallLoggingMiddleware().HandleInitialize(composed.WithApiCallLogging(ctx), smithymiddleware.InitializeInput{
+			Parameters: &ec2.CreateSubnetInput{
+				VpcId:     aws.String("vpc-1"),
+				CidrBlock: aws.String("10.250.8.0/24"),
+			},
+		}, handler(&ec2.CreateSubnetOutput{}, nil))
+
+		assert.NoError(t, err)
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, lines[0], `"msg"="AWS API call"`)
+			assert.Contains(t, lines[0], `\"CidrBlock\":\"10.250.8.0/24\"`)
+			assert.Contains(t, lines[1], `"msg"="AWS API call succeeded"`)
+			assert.Contains(t, lines[1], `"requestId"="request-1"`)
+		}
+	})
+
+	t.Run("failed call logged if enabled", func(t *testing.T) {
+		lines = nil
+
+		_, _, err := ApiCallLoggingMiddleware().HandleInitialize(composed.WithApiCallLogging(ctx), smithymiddleware.InitializeInput{
+			Parameters: &ec2.DeleteSubnetInput{SubnetId: aws.String("subnet-1")},
+		}, handler(nil, errors.New("subnet in use")))
+
+		assert.EqualError(t, err, "subnet in use")
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, lines[1], `"msg"="AWS API call failed"`)
+			assert.Contains(t, lines[1], `"error"="subnet in use"`)
+		}
+	})
+
+	t.Run("secrets are never logged", func(t *testing.T) {
+		lines = nil
+
+		_, _, err := ApiCallLoggingMiddleware().HandleInitialize(composed.WithApiCallLogging(ctx), smithymiddleware.InitializeInput{
+			Parameters: &elasticache.CreateReplicationGroupInput{
+				ReplicationGroupId: aws.String("redis-1"),
+				AuthToken:          aws.String("auth-token-value"),
+			},
+		}, handler(&elasticache.CreateReplicationGroupOutput{}, nil))
+
+		assert.NoError(t, err)
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, lines[0], `\"ReplicationGroupId\":\"redis-1\"`)
+			assert.Contains(t, lines[0], `\"AuthToken\":\"REDACTED\"`)
+			assert.NotContains(t, lines[0], "auth-token-value")
+		}
+	})
+}
@@ -32,6 +32,9 @@ func NewGardenConfig(ctx context.Context, region, key, secret string) (cfg aws.C
 	cfg.APIOptions = append(cfg.APIOptions, func(stack *smithymiddleware.Stack) error {
 		return stack.Deserialize.Add(metrics.AwsReportMetricsMiddleware(), smithymiddleware.After)
 	})
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *smithymiddleware.Stack) error {
+		return stack.Initialize.Add(ApiCallLoggingMiddleware(), smithymiddleware.After)
+	})
 	return
 }
 
@@ -69,5 +72,8 @@ func NewSkrConfig(ctx context.Context, region, key, secret, assumeRole string) (
 	cfg.APIOptions = append(cfg.APIOptions, func(stack *smithymiddleware.Stack) error {
 		return stack.Deserialize.Add(metrics.AwsReportMetricsMiddleware(), smithymiddleware.After)
 	})
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *smithymiddleware.Stack) error {
+		return stack.Initialize.Add(ApiCallLoggingMiddleware(), smithymiddleware.After)
+	})
 	return
 }
@@ -0,0 +1,53 @@
+package cloudevents
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
+)
+
+func NewQueueClientProvider() awsclient.GardenClientProvider[QueueClient] {
+	return func(ctx context.Context, region, key, secret string) (QueueClient, error) {
+		cfg, err := awsclient.NewGardenConfig(ctx, region, key, secret)
+		if err != nil {
+			return nil, err
+		}
+		return newQueueClient(sqs.NewFromConfig(cfg)), nil
+	}
+}
+
+type QueueClient interface {
+	// ReceiveMessages long polls the queue, returning when messages are available or the wait time elapses
+	ReceiveMessages(ctx context.Context, queueUrl string) ([]sqstypes.Message, error)
+	DeleteMessage(ctx context.Context, queueUrl, receiptHandle string) error
+}
+
+func newQueueClient(svc *sqs.Client) QueueClient {
+	return &queueClient{svc: svc}
+}
+
+type queueClient struct {
+	svc *sqs.Client
+}
+
+func (c *queueClient) ReceiveMessages(ctx context.Context, queueUrl string) ([]sqstypes.Message, error) {
+	out, err := c.svc.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            &queueUrl,
+		MaxNumberOfMessages: 10,
+		WaitTimeSeconds:     20,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Messages, nil
+}
+
+func (c *queueClient) DeleteMessage(ctx context.Context, queueUrl, receiptHandle string) error {
+	_, err := c.svc.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      &queueUrl,
+		ReceiptHandle: &receiptHandle,
+	})
+	return err
+}
@@ -0,0 +1,75 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	detailTypeCloudTrailApiCall = "AWS API Call via CloudTrail"
+	sourceEc2                   = "aws.ec2"
+)
+
+// cloudEvent is the EventBridge envelope of the CloudTrail recorded EC2 API call, as the EventBridge rule
+// targeting the queue delivers it
+type cloudEvent struct {
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+	Account    string `json:"account"`
+	Region     string `json:"region"`
+	Detail     struct {
+		EventName         string         `json:"eventName"`
+		ErrorCode         string         `json:"errorCode,omitempty"`
+		RequestParameters map[string]any `json:"requestParameters,omitempty"`
+		ResponseElements  map[string]any `json:"responseElements,omitempty"`
+	} `json:"detail"`
+}
+
+func parseCloudEvent(body string) (*cloudEvent, error) {
+	ev := &cloudEvent{}
+	if err := json.Unmarshal([]byte(body), ev); err != nil {
+		return nil, fmt.Errorf("error parsing cloud event: %w", err)
+	}
+	return ev, nil
+}
+
+// changesNetwork tells if the event is a successful EC2 call that might have changed a VPC or a subnet
+func (ev *cloudEvent) changesNetwork() bool {
+	if ev.DetailType != detailTypeCloudTrailApiCall || ev.Source != sourceEc2 || ev.Detail.ErrorCode != "" {
+		return false
+	}
+	for _, prefix := range []string{"Describe", "Get", "List"} {
+		if strings.HasPrefix(ev.Detail.EventName, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceIds returns the VPC and subnet ids the call refers to. The request and response shapes differ
+// between the calls, and the newer calls nest the parameters under the request name, so the ids are
+// collected from the vpcId and subnetId keys at any depth, regardless of the key case.
+func (ev *cloudEvent) resourceIds() map[string]struct{} {
+	result := map[string]struct{}{}
+	collectResourceIds(ev.Detail.RequestParameters, result)
+	collectResourceIds(ev.Detail.ResponseElements, result)
+	return result
+}
+
+func collectResourceIds(value any, result map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if id, ok := item.(string); ok && (strings.EqualFold(key, "vpcId") || strings.EqualFold(key, "subnetId")) {
+				result[id] = struct{}{}
+				continue
+			}
+			collectResourceIds(item, result)
+		}
+	case []any:
+		for _, item := range v {
+			collectResourceIds(item, result)
+		}
+	}
+}
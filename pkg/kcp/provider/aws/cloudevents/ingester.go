@@ -0,0 +1,172 @@
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
+	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
+	awsiprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const receiveErrorDelay = 10 * time.Second
+
+// Ingester receives the EventBridge notifications of the EC2 API calls from the SQS queue, and emits the
+// IpRanges whose VPC or subnets they changed to the channel watched by the IpRange controller, so the
+// changes made outside of cloud-manager are reconciled right away instead of on the next resync.
+// It runs as the manager runnable, only on the leader.
+type Ingester struct {
+	queueUrl       string
+	clientProvider awsclient.GardenClientProvider[QueueClient]
+	kcpClient      client.Reader
+	cache          *awsiprangeclient.InventoryCache
+	events         chan event.GenericEvent
+	logger         logr.Logger
+}
+
+// NewIngester creates the Ingester of the queue. The cache, if not nil, is invalidated for the account
+// and region of each event, so the triggered reconcile does not read the network inventory from before the change.
+func NewIngester(
+	queueUrl string,
+	clientProvider awsclient.GardenClientProvider[QueueClient],
+	kcpClient client.Reader,
+	cache *awsiprangeclient.InventoryCache,
+) *Ingester {
+	return &Ingester{
+		queueUrl:       queueUrl,
+		clientProvider: clientProvider,
+		kcpClient:      kcpClient,
+		cache:          cache,
+		events:         make(chan event.GenericEvent, 100),
+		logger:         ctrl.Log.WithName("aws-cloud-events").WithValues("queueUrl", queueUrl),
+	}
+}
+
+// IpRangeEvents returns the channel of the IpRanges to reconcile
+func (in *Ingester) IpRangeEvents() <-chan event.GenericEvent {
+	return in.events
+}
+
+func (in *Ingester) Start(ctx context.Context) error {
+	region, err := regionFromQueueUrl(in.queueUrl)
+	if err != nil {
+		return err
+	}
+	queueClient, err := in.clientProvider(
+		ctx,
+		region,
+		awsconfig.AwsConfig.Default.AccessKeyId,
+		awsconfig.AwsConfig.Default.SecretAccessKey,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating cloud events queue client: %w", err)
+	}
+
+	in.logger.Info("Receiving AWS cloud events")
+	for ctx.Err() == nil {
+		if err := in.receive(ctx, queueClient); err != nil && ctx.Err() == nil {
+			in.logger.Error(err, "Error receiving AWS cloud events")
+			select {
+			case <-ctx.Done():
+			case <-time.After(receiveErrorDelay):
+			}
+		}
+	}
+	return nil
+}
+
+// receive handles one batch of the queue messages. The messages are deleted once handled, including the
+// ones not parsable or not relevant so they are not redelivered, but kept if the IpRanges could not be listed.
+func (in *Ingester) receive(ctx context.Context, queueClient QueueClient) error {
+	messages, err := queueClient.ReceiveMessages(ctx, in.queueUrl)
+	if err != nil {
+		return err
+	}
+	for _, msg := range messages {
+		if err := in.handle(ctx, ptr.Deref(msg.Body, "")); err != nil {
+			return err
+		}
+		if err := queueClient.DeleteMessage(ctx, in.queueUrl, ptr.Deref(msg.ReceiptHandle, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *Ingester) handle(ctx context.Context, body string) error {
+	ev, err := parseCloudEvent(body)
+	if err != nil {
+		in.logger.Error(err, "Ignoring invalid cloud event")
+		return nil
+	}
+	if !ev.changesNetwork() {
+		return nil
+	}
+	ids := ev.resourceIds()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if in.cache != nil {
+		in.cache.InvalidateAccount(ev.Account, ev.Region)
+	}
+
+	list := &cloudcontrolv1beta1.IpRangeList{}
+	if err := in.kcpClient.List(ctx, list); err != nil {
+		return fmt.Errorf("error listing IpRanges: %w", err)
+	}
+	for i := range list.Items {
+		ipRange := &list.Items[i]
+		if !ipRangeReferences(ipRange, ids) {
+			continue
+		}
+		in.logger.
+			WithValues(
+				"ipRange", client.ObjectKeyFromObject(ipRange).String(),
+				"eventName", ev.Detail.EventName,
+				"account", ev.Account,
+				"region", ev.Region,
+			).
+			Info("Reconciling IpRange on cloud event")
+		select {
+		case in.events <- event.GenericEvent{Object: ipRange}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func ipRangeReferences(ipRange *cloudcontrolv1beta1.IpRange, ids map[string]struct{}) bool {
+	if _, ok := ids[ipRange.Status.VpcId]; ok && ipRange.Status.VpcId != "" {
+		return true
+	}
+	for _, subnet := range ipRange.Status.Subnets {
+		if _, ok := ids[subnet.Id]; ok && subnet.Id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// regionFromQueueUrl returns the region of the queue url in the https://sqs.<region>.amazonaws.com/<account>/<name> form
+func regionFromQueueUrl(queueUrl string) (string, error) {
+	u, err := url.Parse(queueUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid cloud events queue url: %w", err)
+	}
+	parts := strings.Split(u.Hostname(), ".")
+	if len(parts) < 3 || parts[0] != "sqs" {
+		return "", fmt.Errorf("invalid cloud events queue url %s, expected https://sqs.<region>.amazonaws.com/<account>/<name>", queueUrl)
+	}
+	return parts[1], nil
+}
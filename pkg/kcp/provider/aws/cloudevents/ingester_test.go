@@ -0,0 +1,152 @@
+package cloudevents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type fakeQueueClient struct {
+	messages []sqstypes.Message
+	deleted  []string
+}
+
+func (c *fakeQueueClient) ReceiveMessages(_ context.Context, _ string) ([]sqstypes.Message, error) {
+	messages := c.messages
+	c.messages = nil
+	return messages, nil
+}
+
+func (c *fakeQueueClient) DeleteMessage(_ context.Context, _, receiptHandle string) error {
+	c.deleted = append(c.deleted, receiptHandle)
+	return nil
+}
+
+const deleteSubnetEvent = `{
+  "detail-type": "AWS API Call via CloudTrail",
+  "source": "aws.ec2",
+  "account": "123456789012",
+  "region": "eu-west-1",
+  "detail": {
+    "eventName": "DeleteSubnet",
+    "requestParameters": {"subnetId": "subnet-b"}
+  }
+}`
+
+const createSubnetEvent = `{
+  "detail-type": "AWS API Call via CloudTrail",
+  "source": "aws.ec2",
+  "account": "123456789012",
+  "region": "eu-west-1",
+  "detail": {
+    "eventName": "CreateSubnet",
+    "requestParameters": {"CreateSubnetRequest": {"VpcId": "vpc-a", "CidrBlock": "10.250.8.0/24"}}
+  }
+}`
+
+const failedCallEvent = `{
+  "detail-type": "AWS API Call via CloudTrail",
+  "source": "aws.ec2",
+  "account": "123456789012",
+  "region": "eu-west-1",
+  "detail": {
+    "eventName": "DeleteSubnet",
+    "errorCode": "Client.UnauthorizedOperation",
+    "requestParameters": {"subnetId": "subnet-a"}
+  }
+}`
+
+func newTestIngester() *Ingester {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	ipRangeA := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "a"},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			VpcId:   "vpc-a",
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{{Id: "subnet-a", Zone: "eu-west-1a", Range: "10.250.0.0/24"}},
+		},
+	}
+	ipRangeB := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "b"},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			VpcId:   "vpc-b",
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{{Id: "subnet-b", Zone: "eu-west-1a", Range: "10.251.0.0/24"}},
+		},
+	}
+	clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ipRangeA, ipRangeB).Build()
+	ingester := NewIngester("https://sqs.eu-west-1.amazonaws.com/123456789012/cloud-events", nil, clnt, nil)
+	ingester.logger = logr.Discard()
+	return ingester
+}
+
+func TestIngester(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("injected event enqueues the IpRange of the changed subnet", func(t *testing.T) {
+		ingester := newTestIngester()
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		src := source.Channel(ingester.IpRangeEvents(), &handler.EnqueueRequestForObject{})
+		srcCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		assert.NoError(t, src.Start(srcCtx, queue))
+
+		queueClient := &fakeQueueClient{messages: []sqstypes.Message{
+			{Body: ptr.To(deleteSubnetEvent), ReceiptHandle: ptr.To("rh-1")},
+		}}
+		assert.NoError(t, ingester.receive(ctx, queueClient))
+
+		assert.Eventually(t, func() bool { return queue.Len() == 1 }, 5*time.Second, 10*time.Millisecond)
+		item, _ := queue.Get()
+		assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kcp-system", Name: "b"}}, item)
+		assert.Equal(t, []string{"rh-1"}, queueClient.deleted)
+	})
+
+	t.Run("nested vpc id is matched", func(t *testing.T) {
+		ingester := newTestIngester()
+		queueClient := &fakeQueueClient{messages: []sqstypes.Message{
+			{Body: ptr.To(createSubnetEvent), ReceiptHandle: ptr.To("rh-1")},
+		}}
+		assert.NoError(t, ingester.receive(ctx, queueClient))
+
+		assert.Len(t, ingester.events, 1)
+		ev := <-ingester.events
+		assert.Equal(t, "a", ev.Object.GetName())
+	})
+
+	t.Run("failed calls and invalid messages are dropped", func(t *testing.T) {
+		ingester := newTestIngester()
+		queueClient := &fakeQueueClient{messages: []sqstypes.Message{
+			{Body: ptr.To(failedCallEvent), ReceiptHandle: ptr.To("rh-1")},
+			{Body: ptr.To("not json"), ReceiptHandle: ptr.To("rh-2")},
+		}}
+		assert.NoError(t, ingester.receive(ctx, queueClient))
+
+		assert.Empty(t, ingester.events)
+		assert.Equal(t, []string{"rh-1", "rh-2"}, queueClient.deleted)
+	})
+}
+
+func TestRegionFromQueueUrl(t *testing.T) {
+	region, err := regionFromQueueUrl("https://sqs.eu-central-1.amazonaws.com/123456789012/cloud-events")
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-central-1", region)
+
+	_, err = regionFromQueueUrl("https://example.com/queue")
+	assert.Error(t, err)
+}
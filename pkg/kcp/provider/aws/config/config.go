@@ -8,6 +8,13 @@ type AwsConfigStruct struct {
 	Default        AwsCreds `json:"default" yaml:"default"`
 	Peering        AwsCreds `json:"peering" yaml:"peering"`
 	BackupRoleName string   `json:"backupRoleName" yaml:"backupRoleName"`
+
+	// ResourceShareAllowedAccounts if not empty limits the accounts IpRange subnets can be shared with
+	ResourceShareAllowedAccounts []string `json:"resourceShareAllowedAccounts,omitempty" yaml:"resourceShareAllowedAccounts,omitempty"`
+
+	// ErrorRequeueDelays maps the AWS API error codes to the requeue delay, as a duration or "stop" to not
+	// requeue, overriding and extending the defaults
+	ErrorRequeueDelays map[string]string `json:"errorRequeueDelays,omitempty" yaml:"errorRequeueDelays,omitempty"`
 }
 
 var AwsConfig = &AwsConfigStruct{}
@@ -59,6 +66,10 @@ func InitConfig(cfg config.Config) {
 			config.DefaultScalar("CloudManagerBackupServiceRole"),
 			config.SourceEnv("AWS_BACKUP_ROLE_NAME"),
 		),
+		config.Path(
+			"errorRequeueDelays",
+			config.SourceFile("awsErrorRequeueDelays.yaml"),
+		),
 	)
 
 }
@@ -77,3 +77,23 @@ func TestAllFromFile(t *testing.T) {
 	assert.Equal(t, "secret222", AwsConfig.Default.SecretAccessKey)
 	assert.Equal(t, "role222", AwsConfig.Default.AssumeRoleName)
 }
+
+func TestErrorRequeueDelaysFromFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "cloud-manager-config")
+	assert.NoError(t, err, "error creating tmp dir")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	err = os.WriteFile(filepath.Join(dir, "awsErrorRequeueDelays.yaml"), []byte("RequestLimitExceeded: 1m\nInvalidParameterValue: stop\n"), 0644)
+	assert.NoError(t, err, "error creating error requeue delays file")
+
+	cfg := config.NewConfig(abstractions.NewMockedEnvironment(map[string]string{}))
+	cfg.BaseDir(dir)
+	InitConfig(cfg)
+	cfg.Read()
+
+	assert.Equal(t, map[string]string{
+		"RequestLimitExceeded":  "1m",
+		"InvalidParameterValue": "stop",
+	}, AwsConfig.ErrorRequeueDelays)
+}
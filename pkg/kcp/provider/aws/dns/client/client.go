@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
+)
+
+const (
+	// route53Endpoint is the global Route53 API endpoint, signed in the us-east-1 region
+	route53Endpoint   = "https://route53.amazonaws.com"
+	route53Region     = "us-east-1"
+	route53Service    = "route53"
+	route53ApiVersion = "2013-04-01"
+	route53Namespace  = "https://route53.amazonaws.com/doc/2013-04-01/"
+)
+
+// NewClientProvider returns the provider of the DNS client of the Route53 private hosted zones,
+// addressed by the hosted zone id
+func NewClientProvider() awsclient.SkrClientProvider[kcpdns.Client] {
+	return func(ctx context.Context, region, key, secret, role string) (kcpdns.Client, error) {
+		cfg, err := awsclient.NewSkrConfig(ctx, region, key, secret, role)
+		if err != nil {
+			return nil, err
+		}
+		return newClient(route53Endpoint, cfg.Credentials, cfg.HTTPClient), nil
+	}
+}
+
+func newClient(endpoint string, credentials aws.CredentialsProvider, httpClient aws.HTTPClient) *client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{
+		endpoint:    endpoint,
+		credentials: credentials,
+		httpClient:  httpClient,
+		signer:      v4.NewSigner(),
+	}
+}
+
+// client calls the Route53 REST API directly, since the Route53 service client is not a dependency
+type client struct {
+	endpoint    string
+	credentials aws.CredentialsProvider
+	httpClient  aws.HTTPClient
+	signer      *v4.Signer
+}
+
+type resourceRecordSet struct {
+	Name            string           `xml:"Name"`
+	Type            string           `xml:"Type"`
+	TTL             int64            `xml:"TTL"`
+	ResourceRecords []resourceRecord `xml:"ResourceRecords>ResourceRecord"`
+}
+
+type resourceRecord struct {
+	Value string `xml:"Value"`
+}
+
+type getHostedZoneResponse struct {
+	HostedZone struct {
+		Id   string `xml:"Id"`
+		Name string `xml:"Name"`
+	} `xml:"HostedZone"`
+}
+
+type listResourceRecordSetsResponse struct {
+	ResourceRecordSets []resourceRecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+}
+
+type changeResourceRecordSetsRequest struct {
+	XMLName     xml.Name `xml:"ChangeResourceRecordSetsRequest"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	ChangeBatch struct {
+		Changes []change `xml:"Changes>Change"`
+	} `xml:"ChangeBatch"`
+}
+
+type change struct {
+	Action            string            `xml:"Action"`
+	ResourceRecordSet resourceRecordSet `xml:"ResourceRecordSet"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+}
+
+func (c *client) GetZoneDnsName(ctx context.Context, zoneName string) (string, error) {
+	out := &getHostedZoneResponse{}
+	if err := c.do(ctx, http.MethodGet, hostedZonePath(zoneName), nil, nil, out); err != nil {
+		composed.LoggerFromCtx(ctx).Error(err, "GetHostedZone", "hostedZoneId", zoneName)
+		return "", err
+	}
+	return out.HostedZone.Name, nil
+}
+
+func (c *client) GetRecord(ctx context.Context, zoneName, name, recordType string) (*kcpdns.Record, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("type", recordType)
+	query.Set("maxitems", "1")
+	out := &listResourceRecordSetsResponse{}
+	if err := c.do(ctx, http.MethodGet, hostedZonePath(zoneName)+"/rrset", query, nil, out); err != nil {
+		composed.LoggerFromCtx(ctx).Error(err, "ListResourceRecordSets", "hostedZoneId", zoneName, "name", name)
+		return nil, err
+	}
+	// the list starts at the given name and type, so the first record set is another one if it does not exist
+	for _, rrs := range out.ResourceRecordSets {
+		if !strings.EqualFold(rrs.Name, name) || rrs.Type != recordType {
+			continue
+		}
+		record := &kcpdns.Record{
+			Name: rrs.Name,
+			Type: rrs.Type,
+			Ttl:  rrs.TTL,
+		}
+		for _, rr := range rrs.ResourceRecords {
+			record.Values = append(record.Values, rr.Value)
+		}
+		return record, nil
+	}
+	return nil, nil
+}
+
+func (c *client) UpsertRecord(ctx context.Context, zoneName string, record kcpdns.Record) error {
+	err := c.changeRecord(ctx, zoneName, "UPSERT", record)
+	if err != nil {
+		composed.LoggerFromCtx(ctx).Error(err, "ChangeResourceRecordSets UPSERT", "hostedZoneId", zoneName, "name", record.Name)
+	}
+	return err
+}
+
+func (c *client) DeleteRecord(ctx context.Context, zoneName, name, recordType string) error {
+	// Route53 deletes only the record set matching the existing one exactly, including the TTL and values
+	record, err := c.GetRecord(ctx, zoneName, name, recordType)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return nil
+	}
+	err = c.changeRecord(ctx, zoneName, "DELETE", *record)
+	if err != nil {
+		composed.LoggerFromCtx(ctx).Error(err, "ChangeResourceRecordSets DELETE", "hostedZoneId", zoneName, "name", name)
+	}
+	return err
+}
+
+func (c *client) changeRecord(ctx context.Context, zoneName, action string, record kcpdns.Record) error {
+	in := &changeResourceRecordSetsRequest{Xmlns: route53Namespace}
+	rrs := resourceRecordSet{
+		Name: record.Name,
+		Type: record.Type,
+		TTL:  record.Ttl,
+	}
+	for _, v := range record.Values {
+		rrs.ResourceRecords = append(rrs.ResourceRecords, resourceRecord{Value: v})
+	}
+	in.ChangeBatch.Changes = []change{{Action: action, ResourceRecordSet: rrs}}
+	body, err := xml.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("error marshaling Route53 change batch: %w", err)
+	}
+	return c.do(ctx, http.MethodPost, hostedZonePath(zoneName)+"/rrset/", nil, append([]byte(xml.Header), body...), nil)
+}
+
+// do sends the signed request and decodes the XML response into out, or returns the Route53 error
+// as the smithy API error with its code
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body []byte, out interface{}) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u = u + "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating Route53 request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml")
+	}
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), route53Service, route53Region, time.Now()); err != nil {
+		return fmt.Errorf("error signing Route53 request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading Route53 response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		errResp := &errorResponse{}
+		if xml.Unmarshal(data, errResp) != nil || len(errResp.Error.Code) == 0 {
+			return fmt.Errorf("route53 request failed with status %d: %s", resp.StatusCode, string(data))
+		}
+		return &smithy.GenericAPIError{
+			Code:    errResp.Error.Code,
+			Message: errResp.Error.Message,
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error unmarshaling Route53 response: %w", err)
+	}
+	return nil
+}
+
+// hostedZonePath returns the API path of the hosted zone, given its id with or without the /hostedzone/ prefix
+func hostedZonePath(zoneName string) string {
+	return fmt.Sprintf("/%s/hostedzone/%s", route53ApiVersion, url.PathEscape(strings.TrimPrefix(zoneName, "/hostedzone/")))
+}
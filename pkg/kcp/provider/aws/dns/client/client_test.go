@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRoute53 serves the hosted zone and the record set calls of the Route53 REST API for a single zone
+type fakeRoute53 struct {
+	m       sync.Mutex
+	zoneId  string
+	dnsName string
+	records map[string]resourceRecordSet
+	actions []string
+}
+
+func (f *fakeRoute53) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") ||
+		!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/route53/aws4_request") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	zonePath := "/2013-04-01/hostedzone/" + f.zoneId
+	if !strings.HasPrefix(r.URL.Path, zonePath) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `<ErrorResponse><Error><Type>Sender</Type><Code>NoSuchHostedZone</Code><Message>No hosted zone found</Message></Error></ErrorResponse>`)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == zonePath:
+		_, _ = io.WriteString(w, `<GetHostedZoneResponse><HostedZone><Id>/hostedzone/`+f.zoneId+`</Id><Name>`+f.dnsName+`</Name></HostedZone></GetHostedZoneResponse>`)
+	case r.Method == http.MethodGet && r.URL.Path == zonePath+"/rrset":
+		resp := listResourceRecordSetsResponse{}
+		for _, rrs := range f.records {
+			resp.ResourceRecordSets = append(resp.ResourceRecordSets, rrs)
+		}
+		data, _ := xml.Marshal(resp)
+		_, _ = w.Write(data)
+	case r.Method == http.MethodPost && r.URL.Path == zonePath+"/rrset/":
+		in := &changeResourceRecordSetsRequest{}
+		data, _ := io.ReadAll(r.Body)
+		if err := xml.Unmarshal(data, in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, c := range in.ChangeBatch.Changes {
+			f.actions = append(f.actions, c.Action)
+			key := c.ResourceRecordSet.Name + c.ResourceRecordSet.Type
+			switch c.Action {
+			case "UPSERT":
+				f.records[key] = c.ResourceRecordSet
+			case "DELETE":
+				delete(f.records, key)
+			}
+		}
+		_, _ = io.WriteString(w, `<ChangeResourceRecordSetsResponse><ChangeInfo><Status>PENDING</Status></ChangeInfo></ChangeResourceRecordSetsResponse>`)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestRoute53RecordClient(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeRoute53{
+		zoneId:  "Z0123",
+		dnsName: "internal.example.com.",
+		records: map[string]resourceRecordSet{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	c := newClient(server.URL, credentials.NewStaticCredentialsProvider("key", "secret", ""), server.Client())
+
+	dnsName, err := c.GetZoneDnsName(ctx, "/hostedzone/Z0123")
+	assert.NoError(t, err)
+	assert.Equal(t, "internal.example.com.", dnsName)
+
+	_, err = c.GetZoneDnsName(ctx, "Zunknown")
+	var apiErr smithy.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "NoSuchHostedZone", apiErr.ErrorCode())
+
+	record, err := c.GetRecord(ctx, "Z0123", "nfs.internal.example.com.", kcpdns.RecordTypeCNAME)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	desired := kcpdns.Record{
+		Name:   "nfs.internal.example.com.",
+		Type:   kcpdns.RecordTypeCNAME,
+		Ttl:    kcpdns.DefaultRecordTtl,
+		Values: []string{"fs-123.efs.eu-central-1.amazonaws.com."},
+	}
+	assert.NoError(t, c.UpsertRecord(ctx, "Z0123", desired))
+
+	record, err = c.GetRecord(ctx, "Z0123", "nfs.internal.example.com.", kcpdns.RecordTypeCNAME)
+	assert.NoError(t, err)
+	assert.Equal(t, &desired, record)
+
+	// other record type of the same name is not returned
+	record, err = c.GetRecord(ctx, "Z0123", "nfs.internal.example.com.", kcpdns.RecordTypeA)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	assert.NoError(t, c.DeleteRecord(ctx, "Z0123", "nfs.internal.example.com.", kcpdns.RecordTypeCNAME))
+	assert.Empty(t, fake.records)
+
+	// deleting the missing record succeeds without the change call
+	assert.NoError(t, c.DeleteRecord(ctx, "Z0123", "nfs.internal.example.com.", kcpdns.RecordTypeCNAME))
+	assert.Equal(t, []string{"UPSERT", "DELETE"}, fake.actions)
+}
@@ -0,0 +1,37 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+)
+
+// ValidateHostedZone returns the action checking the spec.dns of the AWS resource gives the id of the
+// Route53 hosted zone, and that the hosted zone is the one named spec.dns.zoneName. Since the record
+// is optional, it returns the error to be downgraded by composed.Optional instead of setting a condition.
+func ValidateHostedZone(clientFn kcpdns.ClientFunc) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		obj, ok := state.Obj().(kcpdns.ObjWithDns)
+		if !ok || obj.DnsOptions() == nil {
+			return nil, nil
+		}
+		opts := obj.DnsOptions()
+
+		if len(opts.HostedZoneId) == 0 {
+			return fmt.Errorf("spec.dns.hostedZoneId of the %s zone is required on AWS", opts.ZoneName), nil
+		}
+
+		zoneDnsName, err := clientFn(state).GetZoneDnsName(ctx, opts.HostedZoneId)
+		if err != nil {
+			return err, nil
+		}
+		if !strings.EqualFold(strings.TrimSuffix(zoneDnsName, "."), strings.TrimSuffix(opts.ZoneName, ".")) {
+			return fmt.Errorf("hosted zone %s is named %s, not %s", opts.HostedZoneId, zoneDnsName, opts.ZoneName), nil
+		}
+
+		return nil, nil
+	}
+}
@@ -0,0 +1,61 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestValidateHostedZone(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+
+	awsMock := awsmock.New()
+	awsMock.AddHostedZone("Z0123456789", "internal.example.com.")
+	validate := ValidateHostedZone(func(composed.State) kcpdns.Client { return awsMock })
+
+	run := func(dns *cloudcontrolv1beta1.DnsOptions) error {
+		obj := &cloudcontrolv1beta1.RedisInstance{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+			Spec:       cloudcontrolv1beta1.RedisInstanceSpec{Dns: dns},
+		}
+		clnt := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).Build()
+		cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+		state := composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, obj)
+		err, _ := validate(ctx, state)
+		return err
+	}
+
+	t.Run("without dns", func(t *testing.T) {
+		assert.NoError(t, run(nil))
+	})
+
+	t.Run("hosted zone id is required", func(t *testing.T) {
+		err := run(&cloudcontrolv1beta1.DnsOptions{ZoneName: "internal.example.com"})
+		assert.EqualError(t, err, "spec.dns.hostedZoneId of the internal.example.com zone is required on AWS")
+	})
+
+	t.Run("hosted zone of other name", func(t *testing.T) {
+		err := run(&cloudcontrolv1beta1.DnsOptions{ZoneName: "other.example.com", HostedZoneId: "Z0123456789"})
+		assert.EqualError(t, err, "hosted zone Z0123456789 is named internal.example.com., not other.example.com")
+	})
+
+	t.Run("hosted zone of zone name", func(t *testing.T) {
+		assert.NoError(t, run(&cloudcontrolv1beta1.DnsOptions{ZoneName: "Internal.Example.com.", HostedZoneId: "Z0123456789"}))
+	})
+}
@@ -0,0 +1,399 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
+	"github.com/kyma-project/cloud-manager/pkg/metrics"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	cacheResourceVpc     = "vpc"
+	cacheResourceVpcs    = "vpcs"
+	cacheResourceSubnets = "subnets"
+)
+
+// InventoryCache holds the VPC and subnet inventory per account and region. Entries expire
+// after the TTL, and while an inventory is in use the poller refreshes its entries so the
+// reconcilers mostly read from the cache. An inventory not read for several TTL periods is
+// dropped. The poller runs as the manager Runnable, so it must be added with mgr.Add.
+type InventoryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	m           sync.Mutex
+	inventories map[string]*inventory
+}
+
+func NewInventoryCache(ttl time.Duration) *InventoryCache {
+	return &InventoryCache{
+		ttl:         ttl,
+		now:         time.Now,
+		inventories: map[string]*inventory{},
+	}
+}
+
+type cacheEntry[T any] struct {
+	value     T
+	fetchedAt time.Time
+}
+
+type inventory struct {
+	cache  *InventoryCache
+	region string
+
+	m sync.Mutex
+	// client is the last live client created for the account and region, used by the poller
+	client Client
+	// generation is increased on each invalidation, so the poller does not store
+	// the data it had read before a write
+	generation int
+	lastUsed   time.Time
+
+	vpcById      map[string]cacheEntry[*ec2types.Vpc]
+	vpcsByName   map[string]cacheEntry[[]ec2types.Vpc]
+	subnetsByVpc map[string]cacheEntry[[]ec2types.Subnet]
+}
+
+// NewCachedClientProvider wraps the client provider so that the VPC and subnet reads are served
+// from the inventory cache when the AwsNetworkCache feature is enabled. All other calls go to the
+// live API, and the calls changing VPC cidr blocks or subnets invalidate the cached entries so the
+// following reads return the live state.
+func NewCachedClientProvider(provider awsclient.SkrClientProvider[Client], cache *InventoryCache) awsclient.SkrClientProvider[Client] {
+	return func(ctx context.Context, region, key, secret, role string) (Client, error) {
+		c, err := provider(ctx, region, key, secret, role)
+		if err != nil {
+			return nil, err
+		}
+		inv := cache.inventory(region, role)
+		inv.m.Lock()
+		inv.client = c
+		inv.m.Unlock()
+		return &cachedClient{Client: c, inv: inv}, nil
+	}
+}
+
+func (c *InventoryCache) inventory(region, role string) *inventory {
+	c.m.Lock()
+	defer c.m.Unlock()
+	key := fmt.Sprintf("%s/%s", role, region)
+	inv, ok := c.inventories[key]
+	if !ok {
+		inv = &inventory{
+			cache:        c,
+			region:       region,
+			lastUsed:     c.now(),
+			vpcById:      map[string]cacheEntry[*ec2types.Vpc]{},
+			vpcsByName:   map[string]cacheEntry[[]ec2types.Vpc]{},
+			subnetsByVpc: map[string]cacheEntry[[]ec2types.Subnet]{},
+		}
+		c.inventories[key] = inv
+	}
+	return inv
+}
+
+func (c *InventoryCache) remove(inv *inventory) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for k, v := range c.inventories {
+		if v == inv {
+			delete(c.inventories, k)
+		}
+	}
+}
+
+// InvalidateAccount drops the cached entries of the account in the region, so the reads following a
+// change made outside of cloud-manager return the live state
+func (c *InventoryCache) InvalidateAccount(accountId, region string) {
+	c.m.Lock()
+	var invalidated []*inventory
+	for k, inv := range c.inventories {
+		if inv.region == region && strings.HasPrefix(k, fmt.Sprintf("arn:aws:iam::%s:", accountId)) {
+			invalidated = append(invalidated, inv)
+		}
+	}
+	c.m.Unlock()
+	for _, inv := range invalidated {
+		inv.invalidate()
+	}
+}
+
+func (inv *inventory) fresh(fetchedAt time.Time) bool {
+	return inv.cache.now().Sub(fetchedAt) < inv.cache.ttl
+}
+
+func (inv *inventory) invalidate() {
+	inv.m.Lock()
+	defer inv.m.Unlock()
+	inv.generation++
+	inv.vpcById = map[string]cacheEntry[*ec2types.Vpc]{}
+	inv.vpcsByName = map[string]cacheEntry[[]ec2types.Vpc]{}
+	inv.subnetsByVpc = map[string]cacheEntry[[]ec2types.Subnet]{}
+}
+
+// touch marks the inventory as used, must be called with the lock held
+func (inv *inventory) touch() {
+	inv.lastUsed = inv.cache.now()
+}
+
+// Start runs the poller refreshing the inventories in use every half of the TTL, until the context is done
+func (c *InventoryCache) Start(ctx context.Context) error {
+	if c.ttl <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(c.ttl / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+func (c *InventoryCache) poll(ctx context.Context) {
+	c.m.Lock()
+	inventories := make([]*inventory, 0, len(c.inventories))
+	for _, inv := range c.inventories {
+		inventories = append(inventories, inv)
+	}
+	c.m.Unlock()
+
+	for _, inv := range inventories {
+		if ctx.Err() != nil {
+			return
+		}
+		inv.m.Lock()
+		if c.now().Sub(inv.lastUsed) > 3*c.ttl {
+			inv.m.Unlock()
+			c.remove(inv)
+			continue
+		}
+		client := inv.client
+		generation := inv.generation
+		vpcIds := keys(inv.vpcById)
+		vpcNames := keys(inv.vpcsByName)
+		subnetVpcIds := keys(inv.subnetsByVpc)
+		inv.m.Unlock()
+
+		if err := inv.refresh(ctx, client, generation, vpcIds, vpcNames, subnetVpcIds); err != nil {
+			ctrl.Log.WithName("aws-network-cache").WithValues("region", inv.region).
+				Error(err, "Error refreshing AWS network inventory cache")
+		}
+	}
+}
+
+func (inv *inventory) refresh(ctx context.Context, client Client, generation int, vpcIds, vpcNames, subnetVpcIds []string) error {
+	for _, id := range vpcIds {
+		vpc, err := client.DescribeVpc(ctx, id)
+		if err != nil {
+			return err
+		}
+		inv.storeVpc(generation, id, vpc)
+	}
+	for _, name := range vpcNames {
+		vpcs, err := client.DescribeVpcs(ctx, name)
+		if err != nil {
+			return err
+		}
+		inv.storeVpcs(generation, name, vpcs)
+	}
+	for _, vpcId := range subnetVpcIds {
+		subnets, err := client.DescribeSubnets(ctx, vpcId)
+		if err != nil {
+			return err
+		}
+		inv.storeSubnets(generation, vpcId, subnets)
+	}
+	return nil
+}
+
+func (inv *inventory) storeVpc(generation int, id string, vpc *ec2types.Vpc) {
+	inv.m.Lock()
+	defer inv.m.Unlock()
+	if generation != inv.generation {
+		return
+	}
+	if vpc == nil || !vpcCacheable(*vpc) {
+		delete(inv.vpcById, id)
+		return
+	}
+	inv.vpcById[id] = cacheEntry[*ec2types.Vpc]{value: vpc, fetchedAt: inv.cache.now()}
+}
+
+func (inv *inventory) storeVpcs(generation int, name string, vpcs []ec2types.Vpc) {
+	inv.m.Lock()
+	defer inv.m.Unlock()
+	if generation != inv.generation {
+		return
+	}
+	for _, vpc := range vpcs {
+		if !vpcCacheable(vpc) {
+			delete(inv.vpcsByName, name)
+			return
+		}
+	}
+	inv.vpcsByName[name] = cacheEntry[[]ec2types.Vpc]{value: vpcs, fetchedAt: inv.cache.now()}
+}
+
+func (inv *inventory) storeSubnets(generation int, vpcId string, subnets []ec2types.Subnet) {
+	inv.m.Lock()
+	defer inv.m.Unlock()
+	if generation != inv.generation {
+		return
+	}
+	for _, subnet := range subnets {
+		if subnet.State != ec2types.SubnetStateAvailable {
+			delete(inv.subnetsByVpc, vpcId)
+			return
+		}
+	}
+	inv.subnetsByVpc[vpcId] = cacheEntry[[]ec2types.Subnet]{value: subnets, fetchedAt: inv.cache.now()}
+}
+
+// vpcCacheable returns false if any of the VPC cidr blocks is in a transitional state, since
+// the reconciler is then waiting for the state change and must see the live data
+func vpcCacheable(vpc ec2types.Vpc) bool {
+	for _, b := range vpc.CidrBlockAssociationSet {
+		if b.CidrBlockState == nil {
+			continue
+		}
+		switch b.CidrBlockState.State {
+		case ec2types.VpcCidrBlockStateCodeAssociating, ec2types.VpcCidrBlockStateCodeDisassociating:
+			return false
+		}
+	}
+	for _, b := range vpc.Ipv6CidrBlockAssociationSet {
+		if b.Ipv6CidrBlockState == nil {
+			continue
+		}
+		switch b.Ipv6CidrBlockState.State {
+		case ec2types.VpcCidrBlockStateCodeAssociating, ec2types.VpcCidrBlockStateCodeDisassociating:
+			return false
+		}
+	}
+	return true
+}
+
+func keys[T any](m map[string]T) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func cacheGet[T any](inv *inventory, resource string, m map[string]cacheEntry[T], key string) (T, bool) {
+	inv.touch()
+	e, ok := m[key]
+	if ok && inv.fresh(e.fetchedAt) {
+		metrics.CloudResourceCacheHit.WithLabelValues(metrics.CloudProviderAWS, resource, inv.region).Inc()
+		return e.value, true
+	}
+	metrics.CloudResourceCacheMiss.WithLabelValues(metrics.CloudProviderAWS, resource, inv.region).Inc()
+	var zero T
+	return zero, false
+}
+
+// cachedClient serves the VPC and subnet reads from the inventory cache
+type cachedClient struct {
+	Client
+	inv *inventory
+}
+
+func (c *cachedClient) DescribeVpc(ctx context.Context, vpcId string) (*ec2types.Vpc, error) {
+	if !feature.AwsNetworkCache.Value(ctx) {
+		return c.Client.DescribeVpc(ctx, vpcId)
+	}
+	c.inv.m.Lock()
+	v, ok := cacheGet(c.inv, cacheResourceVpc, c.inv.vpcById, vpcId)
+	generation := c.inv.generation
+	c.inv.m.Unlock()
+	if ok {
+		return v, nil
+	}
+	vpc, err := c.Client.DescribeVpc(ctx, vpcId)
+	if err != nil {
+		return nil, err
+	}
+	c.inv.storeVpc(generation, vpcId, vpc)
+	return vpc, nil
+}
+
+func (c *cachedClient) DescribeVpcs(ctx context.Context, name string) ([]ec2types.Vpc, error) {
+	if !feature.AwsNetworkCache.Value(ctx) {
+		return c.Client.DescribeVpcs(ctx, name)
+	}
+	c.inv.m.Lock()
+	v, ok := cacheGet(c.inv, cacheResourceVpcs, c.inv.vpcsByName, name)
+	generation := c.inv.generation
+	c.inv.m.Unlock()
+	if ok {
+		return v, nil
+	}
+	vpcs, err := c.Client.DescribeVpcs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	c.inv.storeVpcs(generation, name, vpcs)
+	return vpcs, nil
+}
+
+func (c *cachedClient) DescribeSubnets(ctx context.Context, vpcId string) ([]ec2types.Subnet, error) {
+	if !feature.AwsNetworkCache.Value(ctx) {
+		return c.Client.DescribeSubnets(ctx, vpcId)
+	}
+	c.inv.m.Lock()
+	v, ok := cacheGet(c.inv, cacheResourceSubnets, c.inv.subnetsByVpc, vpcId)
+	generation := c.inv.generation
+	c.inv.m.Unlock()
+	if ok {
+		return v, nil
+	}
+	subnets, err := c.Client.DescribeSubnets(ctx, vpcId)
+	if err != nil {
+		return nil, err
+	}
+	c.inv.storeSubnets(generation, vpcId, subnets)
+	return subnets, nil
+}
+
+func (c *cachedClient) AssociateVpcCidrBlock(ctx context.Context, vpcId, cidr string) (*ec2types.VpcCidrBlockAssociation, error) {
+	defer c.inv.invalidate()
+	return c.Client.AssociateVpcCidrBlock(ctx, vpcId, cidr)
+}
+
+func (c *cachedClient) DisassociateVpcCidrBlockInput(ctx context.Context, associationId string) error {
+	defer c.inv.invalidate()
+	return c.Client.DisassociateVpcCidrBlockInput(ctx, associationId)
+}
+
+func (c *cachedClient) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2types.Tag) (*ec2types.Subnet, error) {
+	defer c.inv.invalidate()
+	return c.Client.CreateSubnet(ctx, vpcId, az, cidr, tags)
+}
+
+func (c *cachedClient) DeleteSubnet(ctx context.Context, subnetId string) error {
+	defer c.inv.invalidate()
+	return c.Client.DeleteSubnet(ctx, subnetId)
+}
+
+func (c *cachedClient) TagSubnet(ctx context.Context, subnetId string, tags []ec2types.Tag) error {
+	defer c.inv.invalidate()
+	return c.Client.TagSubnet(ctx, subnetId, tags)
+}
+
+func (c *cachedClient) UntagSubnet(ctx context.Context, subnetId string, keys []string) error {
+	defer c.inv.invalidate()
+	return c.Client.UntagSubnet(ctx, subnetId, keys)
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+type countingClient struct {
+	Client
+	vpcCalls    int
+	subnetCalls int
+	vpc         ec2types.Vpc
+}
+
+func (c *countingClient) DescribeVpc(ctx context.Context, vpcId string) (*ec2types.Vpc, error) {
+	c.vpcCalls++
+	vpc := c.vpc
+	return &vpc, nil
+}
+
+func (c *countingClient) DescribeSubnets(ctx context.Context, vpcId string) ([]ec2types.Subnet, error) {
+	c.subnetCalls++
+	return []ec2types.Subnet{{SubnetId: ptr.To("subnet-1"), State: ec2types.SubnetStateAvailable}}, nil
+}
+
+func (c *countingClient) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2types.Tag) (*ec2types.Subnet, error) {
+	return &ec2types.Subnet{SubnetId: ptr.To("subnet-2")}, nil
+}
+
+func newCachedTestClient(t *testing.T) (*countingClient, Client, *time.Time) {
+	live, c, now, _ := newCachedTestClientAndCache(t)
+	return live, c, now
+}
+
+func newCachedTestClientAndCache(t *testing.T) (*countingClient, Client, *time.Time, *InventoryCache) {
+	now := time.Now()
+	cache := NewInventoryCache(time.Hour)
+	cache.now = func() time.Time { return now }
+	live := &countingClient{vpc: ec2types.Vpc{VpcId: ptr.To("vpc-1")}}
+	provider := NewCachedClientProvider(func(ctx context.Context, region, key, secret, role string) (Client, error) {
+		return live, nil
+	}, cache)
+	c, err := provider(context.Background(), "eu-west-1", "key", "secret", "role")
+	assert.NoError(t, err)
+	return live, c, &now, cache
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+	feature.InitializeFromStaticConfig(abstractions.NewMockedEnvironment(map[string]string{"FF_AWS_NETWORK_CACHE": "true"}))
+	defer feature.InitializeFromStaticConfig(nil)
+
+	t.Run("reads are served from cache until TTL expires", func(t *testing.T) {
+		live, c, now := newCachedTestClient(t)
+
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 1, live.vpcCalls)
+
+		*now = now.Add(2 * time.Hour)
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 2, live.vpcCalls)
+	})
+
+	t.Run("write invalidates the cache", func(t *testing.T) {
+		live, c, _ := newCachedTestClient(t)
+
+		_, _ = c.DescribeSubnets(ctx, "vpc-1")
+		_, _ = c.DescribeSubnets(ctx, "vpc-1")
+		assert.Equal(t, 1, live.subnetCalls)
+
+		_, err := c.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.0.0.0/24", nil)
+		assert.NoError(t, err)
+
+		_, _ = c.DescribeSubnets(ctx, "vpc-1")
+		assert.Equal(t, 2, live.subnetCalls)
+	})
+
+	t.Run("account invalidation", func(t *testing.T) {
+		cache := NewInventoryCache(time.Hour)
+		live := &countingClient{vpc: ec2types.Vpc{VpcId: ptr.To("vpc-1")}}
+		provider := NewCachedClientProvider(func(ctx context.Context, region, key, secret, role string) (Client, error) {
+			return live, nil
+		}, cache)
+		c, err := provider(ctx, "eu-west-1", "key", "secret", "arn:aws:iam::123456789012:role/CloudManager")
+		assert.NoError(t, err)
+
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		cache.InvalidateAccount("123456789012", "eu-central-1")
+		cache.InvalidateAccount("210987654321", "eu-west-1")
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 1, live.vpcCalls, "other accounts and regions are not invalidated")
+
+		cache.InvalidateAccount("123456789012", "eu-west-1")
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 2, live.vpcCalls)
+	})
+
+	t.Run("vpc with cidr block in transitional state is not cached", func(t *testing.T) {
+		live, c, _ := newCachedTestClient(t)
+		live.vpc.CidrBlockAssociationSet = []ec2types.VpcCidrBlockAssociation{{
+			CidrBlock:      ptr.To("10.1.0.0/16"),
+			CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociating},
+		}}
+
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 2, live.vpcCalls)
+	})
+
+	t.Run("poller refreshes inventory in use and drops unused one", func(t *testing.T) {
+		live, c, now, cache := newCachedTestClientAndCache(t)
+
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 1, live.vpcCalls)
+
+		cache.poll(ctx)
+		assert.Equal(t, 2, live.vpcCalls)
+		assert.Len(t, cache.inventories, 1)
+
+		*now = now.Add(4 * time.Hour)
+		cache.poll(ctx)
+		assert.Equal(t, 2, live.vpcCalls)
+		assert.Empty(t, cache.inventories)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		feature.InitializeFromStaticConfig(nil)
+
+		live, c, _ := newCachedTestClient(t)
+
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		_, _ = c.DescribeVpc(ctx, "vpc-1")
+		assert.Equal(t, 2, live.vpcCalls)
+	})
+}
@@ -5,7 +5,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	"github.com/aws/aws-sdk-go-v2/service/ram"
+	ramtypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	"k8s.io/utils/ptr"
 )
 
@@ -17,6 +21,55 @@ type Client interface {
 	DescribeSubnets(ctx context.Context, vpcId string) ([]ec2types.Subnet, error)
 	CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2types.Tag) (*ec2types.Subnet, error)
 	DeleteSubnet(ctx context.Context, subnetId string) error
+	// TagSubnet adds the tags to the existing subnet, replacing the values of the tags it already has
+	TagSubnet(ctx context.Context, subnetId string, tags []ec2types.Tag) error
+	// UntagSubnet removes the tags with the keys from the existing subnet
+	UntagSubnet(ctx context.Context, subnetId string, keys []string) error
+	// DescribeNetworkInterfaces returns the network interfaces in the subnet
+	DescribeNetworkInterfaces(ctx context.Context, subnetId string) ([]ec2types.NetworkInterface, error)
+	// DescribeAvailabilityZones returns the available zones of the region, with their names and IDs
+	DescribeAvailabilityZones(ctx context.Context) ([]ec2types.AvailabilityZone, error)
+	// DeleteNetworkInterface deletes the detached network interface, it fails if the interface is in use
+	DeleteNetworkInterface(ctx context.Context, networkInterfaceId string) error
+	// DeleteMountTarget deletes the EFS mount target, releasing its network interface
+	DeleteMountTarget(ctx context.Context, mountTargetId string) error
+
+	// GetIpamPoolCidrs returns the CIDRs provisioned to the IPAM pool
+	GetIpamPoolCidrs(ctx context.Context, poolId string) ([]ec2types.IpamPoolCidr, error)
+	// GetIpamPoolAllocations returns the CIDRs allocated from the IPAM pool
+	GetIpamPoolAllocations(ctx context.Context, poolId string) ([]ec2types.IpamPoolAllocation, error)
+	// AllocateIpamPoolCidr allocates the cidr from the pool, the allocation retried with the same client token
+	// returns the allocation already made
+	AllocateIpamPoolCidr(ctx context.Context, poolId string, netmaskLength int, description, clientToken string) (*ec2types.IpamPoolAllocation, error)
+	ReleaseIpamPoolAllocation(ctx context.Context, poolId, allocationId, cidr string) error
+
+	// GetResourceShare returns the active RAM resource share with the given name owned by the account,
+	// or nil if it does not exist
+	GetResourceShare(ctx context.Context, name string) (*ramtypes.ResourceShare, error)
+	CreateResourceShare(ctx context.Context, name string, resourceArns, principals []string, tags []ramtypes.Tag) (*ramtypes.ResourceShare, error)
+	DeleteResourceShare(ctx context.Context, resourceShareArn string) error
+	GetResourceShareAssociations(ctx context.Context, resourceShareArn string, associationType ramtypes.ResourceShareAssociationType) ([]ramtypes.ResourceShareAssociation, error)
+	AssociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error
+	DisassociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error
+
+	// DescribeTransitGateway returns the transit gateway owned by or shared with the account, or nil if it does not exist
+	DescribeTransitGateway(ctx context.Context, tgwId string) (*ec2types.TransitGateway, error)
+	DescribeTransitGatewayVpcAttachments(ctx context.Context, vpcId string) ([]ec2types.TransitGatewayVpcAttachment, error)
+	CreateTransitGatewayVpcAttachment(ctx context.Context, tgwId, vpcId string, subnetIds []string, tags []ec2types.Tag) (*ec2types.TransitGatewayVpcAttachment, error)
+	DeleteTransitGatewayVpcAttachment(ctx context.Context, attachmentId string) error
+	// GetTransitGatewayAttachmentAssociation returns the route table association of the attachment, or nil if it is not associated
+	GetTransitGatewayAttachmentAssociation(ctx context.Context, attachmentId string) (*ec2types.TransitGatewayAttachmentAssociation, error)
+	AssociateTransitGatewayRouteTable(ctx context.Context, routeTableId, attachmentId string) error
+	GetTransitGatewayAttachmentPropagations(ctx context.Context, attachmentId string) ([]ec2types.TransitGatewayAttachmentPropagation, error)
+	EnableTransitGatewayRouteTablePropagation(ctx context.Context, routeTableId, attachmentId string) error
+
+	// DescribeEgressOnlyInternetGateways returns the egress-only internet gateways attached to the VPC
+	DescribeEgressOnlyInternetGateways(ctx context.Context, vpcId string) ([]ec2types.EgressOnlyInternetGateway, error)
+	CreateEgressOnlyInternetGateway(ctx context.Context, vpcId string, tags []ec2types.Tag) (*ec2types.EgressOnlyInternetGateway, error)
+	DeleteEgressOnlyInternetGateway(ctx context.Context, egressOnlyInternetGatewayId string) error
+	DescribeRouteTables(ctx context.Context, vpcId string) ([]ec2types.RouteTable, error)
+	CreateIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock, egressOnlyInternetGatewayId string) error
+	DeleteIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock string) error
 }
 
 func NewClientProvider() awsclient.SkrClientProvider[Client] {
@@ -25,16 +78,18 @@ func NewClientProvider() awsclient.SkrClientProvider[Client] {
 		if err != nil {
 			return nil, err
 		}
-		return newClient(ec2.NewFromConfig(cfg)), nil
+		return newClient(ec2.NewFromConfig(cfg), ram.NewFromConfig(cfg), efs.NewFromConfig(cfg)), nil
 	}
 }
 
-func newClient(svc *ec2.Client) Client {
-	return &client{svc: svc}
+func newClient(svc *ec2.Client, ramSvc *ram.Client, efsSvc *efs.Client) Client {
+	return &client{svc: svc, ramSvc: ramSvc, efsSvc: efsSvc}
 }
 
 type client struct {
-	svc *ec2.Client
+	svc    *ec2.Client
+	ramSvc *ram.Client
+	efsSvc *efs.Client
 }
 
 func (c *client) DescribeVpc(ctx context.Context, vpcId string) (*ec2types.Vpc, error) {
@@ -103,6 +158,9 @@ func (c *client) DescribeSubnets(ctx context.Context, vpcId string) ([]ec2types.
 	return out.Subnets, nil
 }
 
+// CreateSubnet applies the tags with the subnet creation, and not with a separate call that could
+// fail after the subnet is created, since a subnet without tags is not found by the reconciler
+// and would be created again
 func (c *client) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2types.Tag) (*ec2types.Subnet, error) {
 	in := &ec2.CreateSubnetInput{
 		VpcId:            ptr.To(vpcId),
@@ -133,3 +191,392 @@ func (c *client) DeleteSubnet(ctx context.Context, subnetId string) error {
 	}
 	return nil
 }
+
+func (c *client) TagSubnet(ctx context.Context, subnetId string, tags []ec2types.Tag) error {
+	_, err := c.svc.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{subnetId},
+		Tags:      tags,
+	})
+	return err
+}
+
+func (c *client) UntagSubnet(ctx context.Context, subnetId string, keys []string) error {
+	tags := make([]ec2types.Tag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, ec2types.Tag{Key: ptr.To(key)})
+	}
+	_, err := c.svc.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: []string{subnetId},
+		Tags:      tags,
+	})
+	return err
+}
+
+func (c *client) DescribeNetworkInterfaces(ctx context.Context, subnetId string) ([]ec2types.NetworkInterface, error) {
+	var result []ec2types.NetworkInterface
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.svc, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   ptr.To("subnet-id"),
+				Values: []string{subnetId},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.NetworkInterfaces...)
+	}
+	return result, nil
+}
+
+func (c *client) DescribeAvailabilityZones(ctx context.Context) ([]ec2types.AvailabilityZone, error) {
+	out, err := c.svc.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   ptr.To("state"),
+				Values: []string{string(ec2types.AvailabilityZoneStateAvailable)},
+			},
+			{
+				Name:   ptr.To("zone-type"),
+				Values: []string{"availability-zone"},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.AvailabilityZones, nil
+}
+
+func (c *client) DeleteNetworkInterface(ctx context.Context, networkInterfaceId string) error {
+	_, err := c.svc.DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{
+		NetworkInterfaceId: ptr.To(networkInterfaceId),
+	})
+	return err
+}
+
+func (c *client) DeleteMountTarget(ctx context.Context, mountTargetId string) error {
+	_, err := c.efsSvc.DeleteMountTarget(ctx, &efs.DeleteMountTargetInput{
+		MountTargetId: ptr.To(mountTargetId),
+	})
+	return err
+}
+
+func (c *client) GetIpamPoolCidrs(ctx context.Context, poolId string) ([]ec2types.IpamPoolCidr, error) {
+	var result []ec2types.IpamPoolCidr
+	paginator := ec2.NewGetIpamPoolCidrsPaginator(c.svc, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: ptr.To(poolId),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.IpamPoolCidrs...)
+	}
+	return result, nil
+}
+
+func (c *client) GetIpamPoolAllocations(ctx context.Context, poolId string) ([]ec2types.IpamPoolAllocation, error) {
+	var result []ec2types.IpamPoolAllocation
+	paginator := ec2.NewGetIpamPoolAllocationsPaginator(c.svc, &ec2.GetIpamPoolAllocationsInput{
+		IpamPoolId: ptr.To(poolId),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.IpamPoolAllocations...)
+	}
+	return result, nil
+}
+
+func (c *client) AllocateIpamPoolCidr(ctx context.Context, poolId string, netmaskLength int, description, clientToken string) (*ec2types.IpamPoolAllocation, error) {
+	out, err := c.svc.AllocateIpamPoolCidr(ctx, &ec2.AllocateIpamPoolCidrInput{
+		IpamPoolId:    ptr.To(poolId),
+		NetmaskLength: ptr.To(int32(netmaskLength)),
+		Description:   ptr.To(description),
+		ClientToken:   ptr.To(clientToken),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.IpamPoolAllocation, nil
+}
+
+func (c *client) ReleaseIpamPoolAllocation(ctx context.Context, poolId, allocationId, cidr string) error {
+	_, err := c.svc.ReleaseIpamPoolAllocation(ctx, &ec2.ReleaseIpamPoolAllocationInput{
+		IpamPoolId:           ptr.To(poolId),
+		IpamPoolAllocationId: ptr.To(allocationId),
+		Cidr:                 ptr.To(cidr),
+	})
+	return err
+}
+
+func (c *client) GetResourceShare(ctx context.Context, name string) (*ramtypes.ResourceShare, error) {
+	out, err := c.ramSvc.GetResourceShares(ctx, &ram.GetResourceSharesInput{
+		ResourceOwner:       ramtypes.ResourceOwnerSelf,
+		Name:                ptr.To(name),
+		ResourceShareStatus: ramtypes.ResourceShareStatusActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.ResourceShares) > 0 {
+		return &out.ResourceShares[0], nil
+	}
+	return nil, nil
+}
+
+func (c *client) CreateResourceShare(ctx context.Context, name string, resourceArns, principals []string, tags []ramtypes.Tag) (*ramtypes.ResourceShare, error) {
+	out, err := c.ramSvc.CreateResourceShare(ctx, &ram.CreateResourceShareInput{
+		Name:                    ptr.To(name),
+		AllowExternalPrincipals: ptr.To(false),
+		ResourceArns:            resourceArns,
+		Principals:              principals,
+		Tags:                    tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.ResourceShare, nil
+}
+
+func (c *client) DeleteResourceShare(ctx context.Context, resourceShareArn string) error {
+	_, err := c.ramSvc.DeleteResourceShare(ctx, &ram.DeleteResourceShareInput{
+		ResourceShareArn: ptr.To(resourceShareArn),
+	})
+	return err
+}
+
+func (c *client) GetResourceShareAssociations(ctx context.Context, resourceShareArn string, associationType ramtypes.ResourceShareAssociationType) ([]ramtypes.ResourceShareAssociation, error) {
+	var result []ramtypes.ResourceShareAssociation
+	in := &ram.GetResourceShareAssociationsInput{
+		AssociationType:   associationType,
+		ResourceShareArns: []string{resourceShareArn},
+	}
+	for {
+		out, err := c.ramSvc.GetResourceShareAssociations(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.ResourceShareAssociations...)
+		if out.NextToken == nil {
+			return result, nil
+		}
+		in.NextToken = out.NextToken
+	}
+}
+
+func (c *client) AssociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error {
+	_, err := c.ramSvc.AssociateResourceShare(ctx, &ram.AssociateResourceShareInput{
+		ResourceShareArn: ptr.To(resourceShareArn),
+		ResourceArns:     resourceArns,
+		Principals:       principals,
+	})
+	return err
+}
+
+func (c *client) DisassociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error {
+	_, err := c.ramSvc.DisassociateResourceShare(ctx, &ram.DisassociateResourceShareInput{
+		ResourceShareArn: ptr.To(resourceShareArn),
+		ResourceArns:     resourceArns,
+		Principals:       principals,
+	})
+	return err
+}
+
+func (c *client) DescribeTransitGateway(ctx context.Context, tgwId string) (*ec2types.TransitGateway, error) {
+	out, err := c.svc.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: []string{tgwId},
+	})
+	if awsmeta.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out.TransitGateways) > 0 {
+		return &out.TransitGateways[0], nil
+	}
+	return nil, nil
+}
+
+func (c *client) DescribeTransitGatewayVpcAttachments(ctx context.Context, vpcId string) ([]ec2types.TransitGatewayVpcAttachment, error) {
+	var result []ec2types.TransitGatewayVpcAttachment
+	paginator := ec2.NewDescribeTransitGatewayVpcAttachmentsPaginator(c.svc, &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   ptr.To("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.TransitGatewayVpcAttachments...)
+	}
+	return result, nil
+}
+
+func (c *client) CreateTransitGatewayVpcAttachment(ctx context.Context, tgwId, vpcId string, subnetIds []string, tags []ec2types.Tag) (*ec2types.TransitGatewayVpcAttachment, error) {
+	in := &ec2.CreateTransitGatewayVpcAttachmentInput{
+		TransitGatewayId: ptr.To(tgwId),
+		VpcId:            ptr.To(vpcId),
+		SubnetIds:        subnetIds,
+	}
+	if len(tags) > 0 {
+		in.TagSpecifications = []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeTransitGatewayAttachment,
+				Tags:         tags,
+			},
+		}
+	}
+	out, err := c.svc.CreateTransitGatewayVpcAttachment(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out.TransitGatewayVpcAttachment, nil
+}
+
+func (c *client) DeleteTransitGatewayVpcAttachment(ctx context.Context, attachmentId string) error {
+	_, err := c.svc.DeleteTransitGatewayVpcAttachment(ctx, &ec2.DeleteTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: ptr.To(attachmentId),
+	})
+	return err
+}
+
+func (c *client) GetTransitGatewayAttachmentAssociation(ctx context.Context, attachmentId string) (*ec2types.TransitGatewayAttachmentAssociation, error) {
+	out, err := c.svc.DescribeTransitGatewayAttachments(ctx, &ec2.DescribeTransitGatewayAttachmentsInput{
+		TransitGatewayAttachmentIds: []string{attachmentId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.TransitGatewayAttachments) == 0 {
+		return nil, nil
+	}
+	return out.TransitGatewayAttachments[0].Association, nil
+}
+
+func (c *client) AssociateTransitGatewayRouteTable(ctx context.Context, routeTableId, attachmentId string) error {
+	_, err := c.svc.AssociateTransitGatewayRouteTable(ctx, &ec2.AssociateTransitGatewayRouteTableInput{
+		TransitGatewayRouteTableId: ptr.To(routeTableId),
+		TransitGatewayAttachmentId: ptr.To(attachmentId),
+	})
+	return err
+}
+
+func (c *client) GetTransitGatewayAttachmentPropagations(ctx context.Context, attachmentId string) ([]ec2types.TransitGatewayAttachmentPropagation, error) {
+	var result []ec2types.TransitGatewayAttachmentPropagation
+	paginator := ec2.NewGetTransitGatewayAttachmentPropagationsPaginator(c.svc, &ec2.GetTransitGatewayAttachmentPropagationsInput{
+		TransitGatewayAttachmentId: ptr.To(attachmentId),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.TransitGatewayAttachmentPropagations...)
+	}
+	return result, nil
+}
+
+func (c *client) EnableTransitGatewayRouteTablePropagation(ctx context.Context, routeTableId, attachmentId string) error {
+	_, err := c.svc.EnableTransitGatewayRouteTablePropagation(ctx, &ec2.EnableTransitGatewayRouteTablePropagationInput{
+		TransitGatewayRouteTableId: ptr.To(routeTableId),
+		TransitGatewayAttachmentId: ptr.To(attachmentId),
+	})
+	return err
+}
+
+func (c *client) DescribeEgressOnlyInternetGateways(ctx context.Context, vpcId string) ([]ec2types.EgressOnlyInternetGateway, error) {
+	var result []ec2types.EgressOnlyInternetGateway
+	paginator := ec2.NewDescribeEgressOnlyInternetGatewaysPaginator(c.svc, &ec2.DescribeEgressOnlyInternetGatewaysInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, eigw := range out.EgressOnlyInternetGateways {
+			for _, att := range eigw.Attachments {
+				if ptr.Deref(att.VpcId, "") == vpcId {
+					result = append(result, eigw)
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *client) CreateEgressOnlyInternetGateway(ctx context.Context, vpcId string, tags []ec2types.Tag) (*ec2types.EgressOnlyInternetGateway, error) {
+	in := &ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: ptr.To(vpcId),
+	}
+	if len(tags) > 0 {
+		in.TagSpecifications = []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeEgressOnlyInternetGateway,
+				Tags:         tags,
+			},
+		}
+	}
+	out, err := c.svc.CreateEgressOnlyInternetGateway(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out.EgressOnlyInternetGateway, nil
+}
+
+func (c *client) DeleteEgressOnlyInternetGateway(ctx context.Context, egressOnlyInternetGatewayId string) error {
+	_, err := c.svc.DeleteEgressOnlyInternetGateway(ctx, &ec2.DeleteEgressOnlyInternetGatewayInput{
+		EgressOnlyInternetGatewayId: ptr.To(egressOnlyInternetGatewayId),
+	})
+	return err
+}
+
+func (c *client) DescribeRouteTables(ctx context.Context, vpcId string) ([]ec2types.RouteTable, error) {
+	var result []ec2types.RouteTable
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.svc, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   ptr.To("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.RouteTables...)
+	}
+	return result, nil
+}
+
+func (c *client) CreateIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock, egressOnlyInternetGatewayId string) error {
+	_, err := c.svc.CreateRoute(ctx, &ec2.CreateRouteInput{
+		RouteTableId:                ptr.To(routeTableId),
+		DestinationIpv6CidrBlock:    ptr.To(destinationIpv6CidrBlock),
+		EgressOnlyInternetGatewayId: ptr.To(egressOnlyInternetGatewayId),
+	})
+	return err
+}
+
+func (c *client) DeleteIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock string) error {
+	_, err := c.svc.DeleteRoute(ctx, &ec2.DeleteRouteInput{
+		RouteTableId:             ptr.To(routeTableId),
+		DestinationIpv6CidrBlock: ptr.To(destinationIpv6CidrBlock),
+	})
+	return err
+}
@@ -5,17 +5,35 @@ import (
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	iprangetypes "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/types"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func NewAllocateIpRangeAction(_ StateFactory) composed.Action {
+func NewAllocateIpRangeAction(stateFactory StateFactory) composed.Action {
 	return func(ctx context.Context, st composed.State) (error, context.Context) {
-		// atm we're considering only ranges defined in the Shoot, so we're not using
-		// aws/iprange/state, but leaving extension possibility in the future to
-		// create local state and acquire extended information from the AWS
+		// the ranges defined in the Shoot are considered, unless the cidr is allocated from
+		// the preferred secondary CIDR, for which the aws/iprange/state is created to load the VPC
 
 		state := st.(iprangetypes.State)
 
+		if len(state.ObjAsIpRange().Spec.PreferredSecondaryCidr) > 0 {
+			logger := composed.LoggerFromCtx(ctx)
+			awsState, err := stateFactory.NewState(ctx, state, logger)
+			if err != nil {
+				return composed.LogErrorAndReturn(err, "Error creating new aws iprange state", composed.StopWithRequeue, ctx)
+			}
+			err, ctx = composed.ComposeActions(
+				"awsIpRangeI2-allocatePreferred",
+				vpcLoad,
+				vpcFind,
+				subnetsLoadAll,
+				rangeAllocateFromPreferredSecondaryCidr,
+			)(awsmeta.SetAwsAccountId(ctx, state.Scope().Spec.Scope.Aws.AccountId), awsState)
+			if err != nil || len(state.ObjAsIpRange().Status.Cidr) > 0 {
+				return err, ctx
+			}
+		}
+
 		if len(state.Scope().Spec.Scope.Aws.Network.Nodes) == 0 {
 			state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
 			return composed.PatchStatus(state.ObjAsIpRange()).
@@ -0,0 +1,206 @@
+package v2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// CidrAllocatorOptions are the IpRange spec options the allocators use
+type CidrAllocatorOptions struct {
+	ZoneSubnets            []cloudcontrolv1beta1.IpRangeZoneSubnet
+	ReservedCidrRatio      string
+	ZoneSubnetPrefixLength int
+}
+
+// CidrAllocation is the result of the allocation, one zone subnet for each zone in the zones order,
+// and the blocks reserved for the future zones and subnets, if any
+type CidrAllocation struct {
+	ZoneSubnets   []cloudcontrolv1beta1.IpRangeZoneSubnet
+	ReservedCidrs []string
+}
+
+// CidrAllocationError is returned by the allocators when the CIDR blocks can not be allocated to the zones,
+// with the reason set in the IpRange error condition
+type CidrAllocationError struct {
+	Reason  string
+	Message string
+}
+
+func (e *CidrAllocationError) Error() string {
+	return e.Message
+}
+
+// CidrAllocator allocates the zone subnets from the IpRange CIDR blocks
+type CidrAllocator interface {
+	Allocate(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error)
+}
+
+type CidrAllocatorFunc func(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error)
+
+func (f CidrAllocatorFunc) Allocate(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error) {
+	return f(parentCidrs, zones, options)
+}
+
+var (
+	cidrAllocatorsMutex sync.RWMutex
+	cidrAllocators      = map[string]CidrAllocator{
+		cloudcontrolv1beta1.IpRangeAllocationStrategyEven:             CidrAllocatorFunc(allocateEven),
+		cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone: CidrAllocatorFunc(allocateFixedSizePerZone),
+		cloudcontrolv1beta1.IpRangeAllocationStrategyCustom:           CidrAllocatorFunc(allocateCustom),
+		cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth:   CidrAllocatorFunc(allocateReservedGrowth),
+	}
+)
+
+// RegisterCidrAllocator registers the allocator for the strategy, replacing the one already registered, if any
+func RegisterCidrAllocator(strategy string, allocator CidrAllocator) {
+	cidrAllocatorsMutex.Lock()
+	defer cidrAllocatorsMutex.Unlock()
+	cidrAllocators[strategy] = allocator
+}
+
+// GetCidrAllocator returns the allocator registered for the strategy, or nil if there is none
+func GetCidrAllocator(strategy string) CidrAllocator {
+	cidrAllocatorsMutex.RLock()
+	defer cidrAllocatorsMutex.RUnlock()
+	return cidrAllocators[strategy]
+}
+
+// getAllocationStrategy returns the spec allocation strategy, or if not specified the one implied by
+// the other spec options, so the IpRanges created before the strategy was introduced keep their allocation
+func getAllocationStrategy(spec cloudcontrolv1beta1.IpRangeSpec) string {
+	switch {
+	case spec.AllocationStrategy != "":
+		return spec.AllocationStrategy
+	case len(spec.ZoneSubnets) > 0:
+		return cloudcontrolv1beta1.IpRangeAllocationStrategyCustom
+	case spec.ReservedCidrRatio != "":
+		return cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth
+	default:
+		return cloudcontrolv1beta1.IpRangeAllocationStrategyEven
+	}
+}
+
+func getCidrAllocatorOptions(spec cloudcontrolv1beta1.IpRangeSpec) CidrAllocatorOptions {
+	return CidrAllocatorOptions{
+		ZoneSubnets:            spec.ZoneSubnets,
+		ReservedCidrRatio:      spec.ReservedCidrRatio,
+		ZoneSubnetPrefixLength: spec.ZoneSubnetPrefixLength,
+	}
+}
+
+func newCidrAllocation(zones []string, ranges []string, reserved []string) *CidrAllocation {
+	result := &CidrAllocation{ReservedCidrs: reserved}
+	for i, z := range zones {
+		result.ZoneSubnets = append(result.ZoneSubnets, cloudcontrolv1beta1.IpRangeZoneSubnet{
+			Zone: z,
+			Cidr: ranges[i],
+		})
+	}
+	return result
+}
+
+// allocateEven is the default allocator, splitting each CIDR block evenly by its zones
+func allocateEven(parentCidrs []string, zones []string, _ CidrAllocatorOptions) (*CidrAllocation, error) {
+	ranges, reason, msg := splitCidrsByZones(parentCidrs, len(zones))
+	if len(reason) > 0 {
+		return nil, &CidrAllocationError{Reason: reason, Message: msg}
+	}
+	return newCidrAllocation(zones, ranges, nil), nil
+}
+
+// allocateReservedGrowth reserves the last options.ReservedCidrRatio of each CIDR block, by default a half,
+// and splits the rest of the block evenly by its zones
+func allocateReservedGrowth(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error) {
+	ratio := options.ReservedCidrRatio
+	if ratio == "" {
+		ratio = "1/2"
+	}
+	denominator := reservedCidrDenominator(ratio)
+	if denominator == 0 {
+		return nil, &CidrAllocationError{
+			Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+			Message: fmt.Sprintf("Invalid reserved CIDR ratio %s", ratio),
+		}
+	}
+	ranges, reserved, reason, msg := splitCidrsByZonesWithReserve(parentCidrs, len(zones), denominator)
+	if len(reason) > 0 {
+		return nil, &CidrAllocationError{Reason: reason, Message: msg}
+	}
+	return newCidrAllocation(zones, ranges, reserved), nil
+}
+
+// allocateCustom uses the zone subnets specified in the options, in the zones order
+func allocateCustom(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error) {
+	if reason, msg := validateZoneSubnets(parentCidrs, zones, options.ZoneSubnets); len(reason) > 0 {
+		return nil, &CidrAllocationError{Reason: reason, Message: msg}
+	}
+	result := &CidrAllocation{}
+	for _, z := range zones {
+		for _, zs := range options.ZoneSubnets {
+			if zs.Zone == z {
+				result.ZoneSubnets = append(result.ZoneSubnets, zs)
+			}
+		}
+	}
+	return result, nil
+}
+
+// allocateFixedSizePerZone distributes the zones across the CIDR blocks like the even split, but allocates
+// a subnet of options.ZoneSubnetPrefixLength for each zone from the start of its block, and the rest of the
+// block is left free for the future zones and subnets
+func allocateFixedSizePerZone(parentCidrs []string, zones []string, options CidrAllocatorOptions) (*CidrAllocation, error) {
+	if len(parentCidrs) == 0 {
+		return nil, &CidrAllocationError{Reason: cloudcontrolv1beta1.ReasonInvalidCidr, Message: "Can not parse CIDR"}
+	}
+	if len(parentCidrs) > len(zones) {
+		return nil, &CidrAllocationError{
+			Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+			Message: fmt.Sprintf("Can not split %d CIDR blocks to %d zones", len(parentCidrs), len(zones)),
+		}
+	}
+	if options.ZoneSubnetPrefixLength <= 0 || options.ZoneSubnetPrefixLength > 32 {
+		return nil, &CidrAllocationError{
+			Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+			Message: fmt.Sprintf("Invalid zone subnet prefix length %d", options.ZoneSubnetPrefixLength),
+		}
+	}
+
+	var ranges []string
+	for i, c := range parentCidrs {
+		blockZoneCount := len(zones) / len(parentCidrs)
+		if i < len(zones)%len(parentCidrs) {
+			blockZoneCount++
+		}
+
+		prefix, err := netip.ParsePrefix(c)
+		if err != nil || !prefix.Addr().Is4() {
+			return nil, &CidrAllocationError{Reason: cloudcontrolv1beta1.ReasonInvalidCidr, Message: fmt.Sprintf("Can not parse CIDR %s", c)}
+		}
+		prefix = prefix.Masked()
+		if options.ZoneSubnetPrefixLength < prefix.Bits() {
+			return nil, &CidrAllocationError{
+				Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+				Message: fmt.Sprintf("Zone subnet prefix length %d is shorter than the prefix length of CIDR %s", options.ZoneSubnetPrefixLength, c),
+			}
+		}
+		if uint64(blockZoneCount) > uint64(1)<<(options.ZoneSubnetPrefixLength-prefix.Bits()) {
+			return nil, &CidrAllocationError{
+				Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+				Message: fmt.Sprintf("Can not allocate %d subnets of prefix length %d from CIDR %s", blockZoneCount, options.ZoneSubnetPrefixLength, c),
+			}
+		}
+
+		start := binary.BigEndian.Uint32(prefix.Addr().AsSlice())
+		size := uint32(uint64(1) << (32 - options.ZoneSubnetPrefixLength))
+		for z := 0; z < blockZoneCount; z++ {
+			addr := netip.AddrFrom4([4]byte(binary.BigEndian.AppendUint32(nil, start+uint32(z)*size)))
+			ranges = append(ranges, netip.PrefixFrom(addr, options.ZoneSubnetPrefixLength).String())
+		}
+	}
+
+	return newCidrAllocation(zones, ranges, nil), nil
+}
@@ -0,0 +1,198 @@
+package v2
+
+import (
+	"errors"
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCidrAllocators(t *testing.T) {
+	zones := []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"}
+	zoneSubnets := func(cidrs ...string) []cloudcontrolv1beta1.IpRangeZoneSubnet {
+		var result []cloudcontrolv1beta1.IpRangeZoneSubnet
+		for i, c := range cidrs {
+			result = append(result, cloudcontrolv1beta1.IpRangeZoneSubnet{Zone: zones[i], Cidr: c})
+		}
+		return result
+	}
+
+	testCases := []struct {
+		title            string
+		strategy         string
+		cidrs            []string
+		options          CidrAllocatorOptions
+		expectedSubnets  []cloudcontrolv1beta1.IpRangeZoneSubnet
+		expectedReserved []string
+		expectedReason   string
+	}{
+		{
+			"even single block",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyEven,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{},
+			zoneSubnets("10.250.0.0/18", "10.250.64.0/18", "10.250.128.0/18"),
+			nil,
+			"",
+		},
+		{
+			"even ignores reserved ratio",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyEven,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{ReservedCidrRatio: "1/2"},
+			zoneSubnets("10.250.0.0/18", "10.250.64.0/18", "10.250.128.0/18"),
+			nil,
+			"",
+		},
+		{
+			"even invalid cidr",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyEven,
+			[]string{"foo"},
+			CidrAllocatorOptions{},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonInvalidCidr,
+		},
+		{
+			"reserved growth with ratio",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth,
+			[]string{"10.250.0.0/22"},
+			CidrAllocatorOptions{ReservedCidrRatio: "1/4"},
+			zoneSubnets("10.250.0.0/24", "10.250.1.0/24", "10.250.2.0/24"),
+			[]string{"10.250.3.0/24"},
+			"",
+		},
+		{
+			"reserved growth defaults to half",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth,
+			[]string{"10.250.0.0/22"},
+			CidrAllocatorOptions{},
+			zoneSubnets("10.250.0.0/25", "10.250.0.128/25", "10.250.1.0/25"),
+			[]string{"10.250.2.0/23"},
+			"",
+		},
+		{
+			"reserved growth invalid ratio",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth,
+			[]string{"10.250.0.0/22"},
+			CidrAllocatorOptions{ReservedCidrRatio: "1/3"},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+		{
+			"custom in zones order",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyCustom,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{ZoneSubnets: []cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1c", Cidr: "10.250.8.0/24"},
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/22"},
+				{Zone: "eu-west-1b", Cidr: "10.250.4.0/22"},
+			}},
+			zoneSubnets("10.250.0.0/22", "10.250.4.0/22", "10.250.8.0/24"),
+			nil,
+			"",
+		},
+		{
+			"custom missing zone",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyCustom,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{ZoneSubnets: zoneSubnets("10.250.0.0/22", "10.250.4.0/22")},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonIncompleteZoneSubnets,
+		},
+		{
+			"fixed size single block",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{ZoneSubnetPrefixLength: 24},
+			zoneSubnets("10.250.0.0/24", "10.250.1.0/24", "10.250.2.0/24"),
+			nil,
+			"",
+		},
+		{
+			"fixed size two blocks",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone,
+			[]string{"10.250.0.0/16", "10.100.0.0/20"},
+			CidrAllocatorOptions{ZoneSubnetPrefixLength: 22},
+			zoneSubnets("10.250.0.0/22", "10.250.4.0/22", "10.100.0.0/22"),
+			nil,
+			"",
+		},
+		{
+			"fixed size does not fit",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone,
+			[]string{"10.250.0.0/24"},
+			CidrAllocatorOptions{ZoneSubnetPrefixLength: 25},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+		{
+			"fixed size larger than block",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone,
+			[]string{"10.250.0.0/24"},
+			CidrAllocatorOptions{ZoneSubnetPrefixLength: 22},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+		{
+			"fixed size without prefix length",
+			cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone,
+			[]string{"10.250.0.0/16"},
+			CidrAllocatorOptions{},
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			allocator := GetCidrAllocator(tc.strategy)
+			assert.NotNil(t, allocator)
+			allocation, err := allocator.Allocate(tc.cidrs, zones, tc.options)
+			if tc.expectedReason != "" {
+				allocationErr := &CidrAllocationError{}
+				assert.True(t, errors.As(err, &allocationErr))
+				assert.Equal(t, tc.expectedReason, allocationErr.Reason)
+				assert.Nil(t, allocation)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSubnets, allocation.ZoneSubnets)
+			assert.Equal(t, tc.expectedReserved, allocation.ReservedCidrs)
+		})
+	}
+}
+
+func TestGetAllocationStrategy(t *testing.T) {
+	spec := cloudcontrolv1beta1.IpRangeSpec{}
+	assert.Equal(t, cloudcontrolv1beta1.IpRangeAllocationStrategyEven, getAllocationStrategy(spec))
+
+	spec.ReservedCidrRatio = "1/4"
+	assert.Equal(t, cloudcontrolv1beta1.IpRangeAllocationStrategyReservedGrowth, getAllocationStrategy(spec))
+
+	spec.ReservedCidrRatio = ""
+	spec.ZoneSubnets = []cloudcontrolv1beta1.IpRangeZoneSubnet{{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"}}
+	assert.Equal(t, cloudcontrolv1beta1.IpRangeAllocationStrategyCustom, getAllocationStrategy(spec))
+
+	spec.ZoneSubnets = nil
+	spec.AllocationStrategy = cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone
+	assert.Equal(t, cloudcontrolv1beta1.IpRangeAllocationStrategyFixedSizePerZone, getAllocationStrategy(spec))
+}
+
+func TestRegisterCidrAllocator(t *testing.T) {
+	assert.Nil(t, GetCidrAllocator("Test"))
+
+	RegisterCidrAllocator("Test", CidrAllocatorFunc(func(parentCidrs []string, zones []string, _ CidrAllocatorOptions) (*CidrAllocation, error) {
+		return newCidrAllocation(zones, []string{parentCidrs[0]}, nil), nil
+	}))
+
+	allocation, err := GetCidrAllocator("Test").Allocate([]string{"10.250.0.0/24"}, []string{"eu-west-1a"}, CidrAllocatorOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudcontrolv1beta1.IpRangeZoneSubnet{{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"}}, allocation.ZoneSubnets)
+}
@@ -12,7 +12,13 @@ func copyCidrToStatus(ctx context.Context, st composed.State) (error, context.Co
 		return nil, nil
 	}
 
-	state.ObjAsIpRange().Status.Cidr = state.ObjAsIpRange().Spec.Cidr
+	cidrs := state.ObjAsIpRange().SpecCidrs()
+	if len(cidrs) > 0 {
+		state.ObjAsIpRange().Status.Cidr = cidrs[0]
+	}
+	if len(cidrs) > 1 {
+		state.ObjAsIpRange().Status.Cidrs = append([]string{}, cidrs...)
+	}
 
 	return composed.PatchStatus(state.ObjAsIpRange()).
 		SuccessErrorNil().
@@ -0,0 +1,130 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/3th1nk/cidr"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	planResourceIpamPoolAllocation = "IpamPoolAllocation"
+	planResourceVpcCidrBlock       = "VpcCidrBlock"
+	planResourceSubnet             = "Subnet"
+)
+
+// planDryRun contributes the IPAM pool allocation, the VPC CIDR block associations and the subnets the
+// IpRange reconciliation would create, or for the IpRange marked for deletion the ones it would delete
+func planDryRun(_ context.Context, st composed.State, plan actions.PlanBuilder) error {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+
+	if composed.IsMarkedForDeletion(ipRange) {
+		for _, subnet := range state.cloudResourceSubnets {
+			plan.Delete(planResourceSubnet, ptr.Deref(subnet.SubnetId, ""),
+				formatPlanSubnet(ptr.Deref(subnet.AvailabilityZone, ""), ptr.Deref(subnet.CidrBlock, "")))
+		}
+		for _, rangeCidr := range ipRange.StatusCidrs() {
+			if block := findAssociatedCidrBlock(state, rangeCidr); block != nil {
+				plan.Delete(planResourceVpcCidrBlock, ptr.Deref(block.AssociationId, ""), rangeCidr)
+			}
+		}
+		if len(ipRange.Status.IpamPoolAllocationId) > 0 {
+			plan.Delete(planResourceIpamPoolAllocation, ipRange.Status.IpamPoolAllocationId, ipRange.Status.Cidr)
+		}
+		return nil
+	}
+
+	cidrs := ipRange.StatusCidrs()
+	if len(cidrs) == 0 {
+		cidrs = ipRange.SpecCidrs()
+	}
+	if len(cidrs) == 0 && len(ipRange.Spec.IpamPoolId) > 0 {
+		// the block is known only once allocated, so the VPC and subnet operations can not be planned yet
+		plan.Create(planResourceIpamPoolAllocation, ipRange.Spec.IpamPoolId,
+			fmt.Sprintf("netmask=/%d", ipRange.CidrSizeOrDefault(iprangeallocate.DefaultMaskSize)))
+		return nil
+	}
+
+	for _, rangeCidr := range cidrs {
+		if findAssociatedCidrBlock(state, rangeCidr) == nil && findHostingCidrBlock(state, rangeCidr) == nil {
+			plan.Create(planResourceVpcCidrBlock, ptr.Deref(state.vpc.VpcId, ""), rangeCidr)
+		}
+	}
+
+	zoneSubnets := ipRange.Status.ZoneSubnets
+	if len(zoneSubnets) == 0 {
+		zones := state.zoneNames()
+		allocator := GetCidrAllocator(getAllocationStrategy(ipRange.Spec))
+		if allocator == nil {
+			return nil
+		}
+		allocation, err := allocator.Allocate(cidrs, zones, getCidrAllocatorOptions(ipRange.Spec))
+		if err != nil {
+			// the allocation error is reported by the reconciliation once the dry-run mode is disabled
+			return nil
+		}
+		zoneSubnets = allocation.ZoneSubnets
+	}
+
+	fallbackZones := zoneFallbacksByFallbackZone(ipRange)
+	existing := map[string]struct{}{}
+	for _, subnet := range state.cloudResourceSubnets {
+		zone := ptr.Deref(subnet.AvailabilityZone, "")
+		if originalZone, ok := fallbackZones[zone]; ok {
+			zone = originalZone
+		}
+		existing[zone] = struct{}{}
+		existing[ptr.Deref(subnet.CidrBlock, "")] = struct{}{}
+	}
+
+	for i, zs := range zoneSubnets {
+		_, zoneFound := existing[zs.Zone]
+		_, rangeFound := existing[zs.Cidr]
+		if zoneFound || rangeFound {
+			continue
+		}
+		plan.Create(planResourceSubnet, fmt.Sprintf("%s-%d", ipRange.Name, i), formatPlanSubnet(zs.Zone, zs.Cidr))
+	}
+
+	return nil
+}
+
+func formatPlanSubnet(zone, cidr string) string {
+	return strings.Join([]string{"zone=" + zone, "cidr=" + cidr}, " ")
+}
+
+// findAssociatedCidrBlock returns the VPC CIDR block association matching the range, ignoring the disassociated ones,
+// since the state associatedCidrBlocks are not yet loaded when the plan is computed
+func findAssociatedCidrBlock(state *State, rangeCidr string) *ec2Types.VpcCidrBlockAssociation {
+	if state.vpc == nil {
+		return nil
+	}
+	rangeBlock, err := cidr.Parse(rangeCidr)
+	if err != nil {
+		return nil
+	}
+	for _, set := range state.vpc.CidrBlockAssociationSet {
+		cdr, err := cidr.Parse(ptr.Deref(set.CidrBlock, ""))
+		if err != nil {
+			continue
+		}
+		if util.CidrEquals(rangeBlock.CIDR(), cdr.CIDR()) &&
+			set.CidrBlockState != nil &&
+			!pie.Contains([]ec2Types.VpcCidrBlockStateCode{
+				ec2Types.VpcCidrBlockStateCodeDisassociated,
+				ec2Types.VpcCidrBlockStateCodeDisassociating,
+			}, set.CidrBlockState.State) {
+			return &set
+		}
+	}
+	return nil
+}
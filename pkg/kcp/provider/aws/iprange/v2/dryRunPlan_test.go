@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDryRunPlan(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kcp-system",
+			Name:        "iprange",
+			Annotations: map[string]string{actions.AnnotationDryRun: "true"},
+		},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "default", Name: "my-range"},
+			Scope:     cloudcontrolv1beta1.ScopeRef{Name: "kyma"},
+			Cidr:      "10.250.8.0/22",
+		},
+	}
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "kyma"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{
+							{Name: "eu-west-1a"},
+							{Name: "eu-west-1b"},
+							{Name: "eu-west-1c"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	state := newIpamTestState(ipRange, awsMock)
+	state.SetScope(scope)
+	state.vpc = vpc
+
+	err, _ := actions.DryRunPlan(planDryRun)(ctx, state)
+
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+	assert.NotNil(t, ipRange.Status.DryRunPlan)
+	assert.Equal(t, 0, ipRange.Status.DryRunPlan.Truncated)
+	assert.Equal(t, []cloudcontrolv1beta1.DryRunOperation{
+		{Operation: cloudcontrolv1beta1.DryRunOperationCreate, ResourceType: planResourceVpcCidrBlock, Name: "vpc-1", After: "10.250.8.0/22"},
+		{Operation: cloudcontrolv1beta1.DryRunOperationCreate, ResourceType: planResourceSubnet, Name: "iprange-0", After: "zone=eu-west-1a cidr=10.250.8.0/24"},
+		{Operation: cloudcontrolv1beta1.DryRunOperationCreate, ResourceType: planResourceSubnet, Name: "iprange-1", After: "zone=eu-west-1b cidr=10.250.9.0/24"},
+		{Operation: cloudcontrolv1beta1.DryRunOperationCreate, ResourceType: planResourceSubnet, Name: "iprange-2", After: "zone=eu-west-1c cidr=10.250.10.0/24"},
+	}, ipRange.Status.DryRunPlan.Operations)
+
+	// subnets are not created in the dry-run mode
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Empty(t, subnets)
+
+	// once the dry-run mode is disabled the plan is cleared and the flow continues
+	ipRange.Annotations = nil
+
+	err, _ = actions.DryRunPlan(planDryRun)(ctx, state)
+
+	assert.Nil(t, err)
+	assert.Nil(t, ipRange.Status.DryRunPlan)
+}
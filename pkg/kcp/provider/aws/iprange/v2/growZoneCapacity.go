@@ -0,0 +1,202 @@
+package v2
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+// growZoneCapacity adds the supplementary subnet to the zone whose subnets have fewer available IP addresses
+// than spec.zoneCapacityThreshold. AWS subnets can not be resized, so the zone grows by the subnet of the same
+// size immediately after its last subnet, recorded with the other ranges of the zone in the status zonePools.
+// The zone does not grow if that range is outside the cidr or used by another subnet. Only one subnet is added
+// per reconcile, so the capacity of the zone is evaluated again with the new subnet before it grows further.
+func growZoneCapacity(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+	threshold := ipRange.Spec.ZoneCapacityThreshold
+
+	if threshold == 0 {
+		return nil, nil
+	}
+
+	available := map[string]int32{}
+	unknown := map[string]bool{}
+	for _, subnet := range state.cloudResourceSubnets {
+		zone := ptr.Deref(subnet.AvailabilityZone, "")
+		// the count is not known for the subnet just created, the zone is evaluated on the next reconcile
+		if subnet.AvailableIpAddressCount == nil || subnet.State != ec2Types.SubnetStateAvailable {
+			unknown[zone] = true
+			continue
+		}
+		available[zone] += *subnet.AvailableIpAddressCount
+	}
+
+	for _, zone := range pie.Sort(pie.Keys(available)) {
+		if unknown[zone] || available[zone] >= threshold {
+			continue
+		}
+
+		logger := composed.LoggerFromCtx(ctx).
+			WithValues(
+				"zone", zone,
+				"availableIpAddressCount", available[zone],
+				"zoneCapacityThreshold", threshold,
+			)
+
+		pool := zonePoolRanges(state, zone)
+		if len(pool) == 0 {
+			continue
+		}
+		next, err := nextContiguousRange(pool[len(pool)-1])
+		if err != nil {
+			logger.Info(fmt.Sprintf("Zone capacity low, but it can not grow: %s", err))
+			continue
+		}
+		if reason := contiguousRangeUnavailable(state, next); reason != "" {
+			logger.
+				WithValues("range", next).
+				Info(fmt.Sprintf("Zone capacity low, but it can not grow: %s", reason))
+			continue
+		}
+
+		logger = logger.WithValues("range", next)
+		logger.Info("Creating supplementary subnet in the zone with low capacity")
+
+		subnet, err := state.awsClient.CreateSubnet(ctx, aws.ToString(state.vpc.VpcId), zone, next, awsutil.Ec2TagsFromMap(
+			state.tags,
+			"Name", fmt.Sprintf("%s-%s-%d", ipRange.Name, zone, len(pool)),
+			tagKey, "1",
+		))
+		if x := awserrorhandling.HandleError(composed.LoggerIntoCtx(ctx, logger), err, state, "KCP IpRange on create supplementary subnet",
+			cloudcontrolv1beta1.ReasonUnknown, "Failed creating supplementary subnet"); x != nil {
+			return x, nil
+		}
+
+		logger.WithValues("subnetId", subnet.SubnetId).Info("Supplementary subnet created")
+
+		// the supplementary subnet is recorded only in the zone pool, the status subnets keep one subnet
+		// per zone as their consumers expect
+		setZonePool(ipRange, zone, append(pool, next))
+
+		return composed.PatchStatus(ipRange).
+			ErrorLogMessage("Error patching KCP IpRange status with supplementary subnet").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T1000ms())).
+			Run(ctx, state)
+	}
+
+	return nil, nil
+}
+
+// zonePoolRanges returns the ranges of the zone recorded in the status zonePools, or the range of the
+// zone subnet if the zone did not grow yet
+func zonePoolRanges(state *State, zone string) []string {
+	ipRange := state.ObjAsIpRange()
+	for _, pool := range ipRange.Status.ZonePools {
+		if pool.Zone == zone {
+			return append([]string{}, pool.Ranges...)
+		}
+	}
+	for _, subnet := range state.cloudResourceSubnets {
+		rng := ptr.Deref(subnet.CidrBlock, "")
+		if ptr.Deref(subnet.AvailabilityZone, "") == zone && pie.Contains(ipRange.Status.Ranges, rng) {
+			return []string{rng}
+		}
+	}
+	return nil
+}
+
+func setZonePool(ipRange *cloudcontrolv1beta1.IpRange, zone string, ranges []string) {
+	for i, pool := range ipRange.Status.ZonePools {
+		if pool.Zone == zone {
+			ipRange.Status.ZonePools[i].Ranges = ranges
+			return
+		}
+	}
+	ipRange.Status.ZonePools = append(ipRange.Status.ZonePools, cloudcontrolv1beta1.IpRangeZonePool{
+		Zone:   zone,
+		Ranges: ranges,
+	})
+}
+
+// zoneSubnets returns the cloud resource subnets without the supplementary subnets, one subnet per zone
+func zoneSubnets(state *State) []ec2Types.Subnet {
+	return pie.Filter(state.cloudResourceSubnets, func(s ec2Types.Subnet) bool {
+		return !isSupplementaryRange(state.ObjAsIpRange(), ptr.Deref(s.CidrBlock, ""))
+	})
+}
+
+// isSupplementaryRange returns true if the range is of the supplementary subnet of a grown zone
+func isSupplementaryRange(ipRange *cloudcontrolv1beta1.IpRange, rng string) bool {
+	for _, pool := range ipRange.Status.ZonePools {
+		if len(pool.Ranges) > 1 && pie.Contains(pool.Ranges[1:], rng) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextContiguousRange returns the IPv4 range of the same size starting immediately after the given one
+func nextContiguousRange(rng string) (string, error) {
+	prefix, err := netip.ParsePrefix(rng)
+	if err != nil || !prefix.Addr().Is4() {
+		return "", fmt.Errorf("invalid IPv4 range %s", rng)
+	}
+	prefix = prefix.Masked()
+	start := prefix.Addr().As4()
+	nextStart := uint64(binary.BigEndian.Uint32(start[:])) + uint64(1)<<(32-prefix.Bits())
+	if nextStart > math.MaxUint32 {
+		return "", fmt.Errorf("no address space after range %s", rng)
+	}
+	var next [4]byte
+	binary.BigEndian.PutUint32(next[:], uint32(nextStart))
+	return netip.PrefixFrom(netip.AddrFrom4(next), prefix.Bits()).String(), nil
+}
+
+// contiguousRangeUnavailable returns the reason the range can not be used by the supplementary subnet, if
+// it is outside the IpRange cidr, overlaps an existing VPC subnet, or the range of a zone without its subnet
+func contiguousRangeUnavailable(state *State, rng string) string {
+	_, rngNet, err := net.ParseCIDR(rng)
+	if err != nil {
+		return fmt.Sprintf("invalid range %s", rng)
+	}
+
+	within := false
+	for _, c := range state.ObjAsIpRange().StatusCidrs() {
+		if _, cidrNet, err := net.ParseCIDR(c); err == nil && util.CidrContains(cidrNet, rngNet) {
+			within = true
+		}
+	}
+	if !within {
+		return fmt.Sprintf("range %s is outside of the cidr", rng)
+	}
+
+	for _, subnet := range state.allSubnets {
+		_, subnetNet, err := net.ParseCIDR(ptr.Deref(subnet.CidrBlock, ""))
+		if err == nil && util.CidrOverlap(subnetNet, rngNet) {
+			return fmt.Sprintf("range %s overlaps with subnet %s", rng, ptr.Deref(subnet.SubnetId, ""))
+		}
+	}
+
+	for _, r := range state.ObjAsIpRange().Status.Ranges {
+		_, zoneNet, err := net.ParseCIDR(r)
+		if err == nil && util.CidrOverlap(zoneNet, rngNet) {
+			return fmt.Sprintf("range %s overlaps with zone range %s", rng, r)
+		}
+	}
+
+	return ""
+}
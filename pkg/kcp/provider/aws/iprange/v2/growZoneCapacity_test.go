@@ -0,0 +1,158 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNextContiguousRange(t *testing.T) {
+	testData := []struct {
+		rng      string
+		expected string
+	}{
+		{"10.250.8.0/24", "10.250.9.0/24"},
+		{"10.250.8.0/22", "10.250.12.0/22"},
+		{"10.250.255.128/25", "10.251.0.0/25"},
+		{"10.0.0.0/8", "11.0.0.0/8"},
+	}
+	for _, tt := range testData {
+		t.Run(tt.rng, func(t *testing.T) {
+			next, err := nextContiguousRange(tt.rng)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+
+	_, err := nextContiguousRange("255.255.255.0/24")
+	assert.Error(t, err)
+	_, err = nextContiguousRange("2001:db8::/64")
+	assert.Error(t, err)
+}
+
+func TestGrowZoneCapacity(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newGrowState := func() (*State, *cloudcontrolv1beta1.IpRange, awsmock.Server, string) {
+		awsMock := awsmock.New()
+		vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+		_, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/24", awsutil.Ec2Tags(tagKey, "1"))
+		assert.NoError(t, err)
+		subnetB, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1b", "10.250.9.0/24", awsutil.Ec2Tags(tagKey, "1"))
+		assert.NoError(t, err)
+
+		ipRange := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				ZoneCapacityThreshold: 251,
+			},
+			Status: cloudcontrolv1beta1.IpRangeStatus{
+				Cidr:   "10.250.8.0/22",
+				Ranges: []string{"10.250.8.0/24", "10.250.9.0/24"},
+			},
+		}
+		state := newIpamTestState(ipRange, awsMock)
+		state.vpc = vpc
+		return state, ipRange, awsMock, ptr.Deref(subnetB.SubnetId, "")
+	}
+
+	reconcile := func(state *State) error {
+		state.allSubnets = nil
+		state.cloudResourceSubnets = nil
+		err, _ := composed.ComposeActions(
+			"subnets",
+			subnetsLoadAll,
+			subnetsFindCloudResources,
+			growZoneCapacity,
+		)(ctx, state)
+		return err
+	}
+
+	zoneSubnetRanges := func(awsMock awsmock.Server, zone string) []string {
+		subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+		assert.NoError(t, err)
+		var result []string
+		for _, s := range subnets {
+			if ptr.Deref(s.AvailabilityZone, "") == zone {
+				result = append(result, ptr.Deref(s.CidrBlock, ""))
+			}
+		}
+		return result
+	}
+
+	t.Run("zone above threshold does not grow", func(t *testing.T) {
+		state, ipRange, awsMock, _ := newGrowState()
+
+		assert.NoError(t, reconcile(state))
+
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1b"), 1)
+		assert.Empty(t, ipRange.Status.ZonePools)
+	})
+
+	t.Run("zone below threshold grows into contiguous space", func(t *testing.T) {
+		state, ipRange, awsMock, subnetB := newGrowState()
+		awsMock.AddNetworkInterface(subnetB, "nfs mount target")
+
+		err := reconcile(state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+		assert.Equal(t, []string{"10.250.9.0/24", "10.250.10.0/24"}, zoneSubnetRanges(awsMock, "eu-west-1b"))
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1a"), 1)
+		assert.Equal(t, []cloudcontrolv1beta1.IpRangeZonePool{
+			{Zone: "eu-west-1b", Ranges: []string{"10.250.9.0/24", "10.250.10.0/24"}},
+		}, ipRange.Status.ZonePools)
+		assert.True(t, isSupplementaryRange(ipRange, "10.250.10.0/24"))
+		assert.False(t, isSupplementaryRange(ipRange, "10.250.9.0/24"))
+
+		// the zone with the supplementary subnet is above the threshold
+		assert.NoError(t, reconcile(state))
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1b"), 2)
+
+		// the supplementary subnet is kept out of the subnets of the zones
+		assert.Empty(t, ipRange.Status.Subnets)
+		assert.ElementsMatch(t, []string{"10.250.8.0/24", "10.250.9.0/24"}, pie.Map(zoneSubnets(state), func(s ec2Types.Subnet) string {
+			return ptr.Deref(s.CidrBlock, "")
+		}))
+	})
+
+	t.Run("zone grows until the end of the cidr", func(t *testing.T) {
+		state, ipRange, awsMock, _ := newGrowState()
+		ipRange.Spec.ZoneCapacityThreshold = 1000
+
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), reconcile(state))
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), reconcile(state))
+		assert.Equal(t, []string{"10.250.9.0/24", "10.250.10.0/24", "10.250.11.0/24"}, zoneSubnetRanges(awsMock, "eu-west-1b"))
+
+		// 10.250.12.0/24 is outside of the cidr
+		assert.NoError(t, reconcile(state))
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1b"), 3)
+		assert.Equal(t, []string{"10.250.9.0/24", "10.250.10.0/24", "10.250.11.0/24"}, ipRange.Status.ZonePools[0].Ranges)
+	})
+
+	t.Run("zone does not grow over other zone or foreign subnet", func(t *testing.T) {
+		state, ipRange, awsMock, subnetB := newGrowState()
+		awsMock.AddNetworkInterface(subnetB, "nfs mount target")
+		_, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1c", "10.250.10.0/24", nil)
+		assert.NoError(t, err)
+
+		// zone a is followed by the zone b range, and zone b by the foreign subnet
+		ipRange.Spec.ZoneCapacityThreshold = 1000
+		assert.NoError(t, reconcile(state))
+
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1a"), 1)
+		assert.Len(t, zoneSubnetRanges(awsMock, "eu-west-1b"), 1)
+		assert.Empty(t, ipRange.Status.ZonePools)
+	})
+}
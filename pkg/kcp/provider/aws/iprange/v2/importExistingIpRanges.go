@@ -0,0 +1,314 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImportOptions configures the import of the existing subnets of a Scope VPC as IpRanges
+type ImportOptions struct {
+	// Namespace the IpRanges are created in
+	Namespace string
+	// MatchTags select the subnets not tagged by cloud-manager that are imported as well, if they
+	// have all of them with the same values. If empty, only the subnets tagged by cloud-manager are imported.
+	MatchTags map[string]string
+	// DryRun only reports the IpRanges that would be created, without creating them or tagging the subnets
+	DryRun bool
+}
+
+// ImportSummary lists the IpRanges created by the import, and the skipped ones with the reason
+type ImportSummary struct {
+	DryRun  bool
+	Created []string
+	Skipped []string
+}
+
+func (s *ImportSummary) String() string {
+	var sb strings.Builder
+	created := "Created"
+	if s.DryRun {
+		created = "Would create"
+	}
+	sb.WriteString(fmt.Sprintf("%s %d IpRanges, skipped %d\n", created, len(s.Created), len(s.Skipped)))
+	for _, x := range s.Created {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", strings.ToLower(created), x))
+	}
+	for _, x := range s.Skipped {
+		sb.WriteString(fmt.Sprintf("  skipped: %s\n", x))
+	}
+	return sb.String()
+}
+
+// importGroup are the imported subnets within one VPC CIDR block, becoming one IpRange
+type importGroup struct {
+	cidr    string
+	subnets []ec2Types.Subnet
+}
+
+// NewImportExistingIpRanges returns an Action that creates the IpRanges for the existing subnets of the
+// VPC of the Scope that is the state object. It is an onboarding tool for the environments provisioned
+// before cloud-manager, run once on bootstrap, and not part of the IpRange reconciliation.
+// The subnets tagged by cloud-manager, or having all the match tags, are grouped by the VPC CIDR block
+// they are in, and each group is created as an IpRange with that cidr and the subnets as zone subnets,
+// with the Adopted condition. The subnets matched by tags are tagged so the IpRange reconciler finds them.
+// The groups already covered by an existing IpRange, or not having exactly one subnet in each Scope zone,
+// are skipped, so running it again does not create anything new. The outcome is recorded in the summary.
+func NewImportExistingIpRanges(awsClient iprangeclient.Client, options ImportOptions, summary *ImportSummary) composed.Action {
+	return func(ctx context.Context, state composed.State) (error, context.Context) {
+		logger := composed.LoggerFromCtx(ctx)
+		scope, ok := state.Obj().(*cloudcontrolv1beta1.Scope)
+		if !ok || scope.Spec.Scope.Aws == nil {
+			return errors.New("importing IpRanges requires an AWS Scope"), ctx
+		}
+		summary.DryRun = options.DryRun
+
+		vpcNetworkName := scope.Spec.Scope.Aws.VpcNetwork
+		vpcList, err := awsClient.DescribeVpcs(ctx, vpcNetworkName)
+		if err != nil {
+			return fmt.Errorf("error loading AWS VPC %s: %w", vpcNetworkName, err), ctx
+		}
+		idx := pie.FindFirstUsing(vpcList, func(v ec2Types.Vpc) bool {
+			return awsutil.NameEc2TagEquals(v.Tags, vpcNetworkName)
+		})
+		if idx == -1 {
+			return fmt.Errorf("AWS VPC %s not found", vpcNetworkName), ctx
+		}
+		vpc := vpcList[idx]
+
+		subnets, err := awsClient.DescribeSubnets(ctx, ptr.Deref(vpc.VpcId, ""))
+		if err != nil {
+			return fmt.Errorf("error loading AWS VPC subnets: %w", err), ctx
+		}
+
+		ipRangeList := &cloudcontrolv1beta1.IpRangeList{}
+		if err := state.Cluster().K8sClient().List(ctx, ipRangeList, client.InNamespace(options.Namespace)); err != nil {
+			return fmt.Errorf("error listing IpRanges: %w", err), ctx
+		}
+
+		groups, skipped := groupImportedSubnets(&vpc, subnets, options.MatchTags)
+		summary.Skipped = append(summary.Skipped, skipped...)
+
+		zones := pie.Sort(pie.Map(scope.Spec.Scope.Aws.Network.Zones, func(z cloudcontrolv1beta1.AwsZone) string {
+			return z.Name
+		}))
+
+		for _, group := range groups {
+			ipRange := newImportedIpRange(scope, options.Namespace, group)
+			description := fmt.Sprintf("%s/%s (%s)", ipRange.Namespace, ipRange.Name, group.cidr)
+
+			if reason := importSkipReason(ipRange, group, zones, ipRangeList.Items); reason != "" {
+				summary.Skipped = append(summary.Skipped, fmt.Sprintf("%s: %s", description, reason))
+				continue
+			}
+
+			if options.DryRun {
+				summary.Created = append(summary.Created, description)
+				continue
+			}
+
+			logger.
+				WithValues(
+					"ipRange", description,
+					"subnetIds", pie.Map(group.subnets, func(s ec2Types.Subnet) string { return ptr.Deref(s.SubnetId, "") }),
+				).
+				Info("Importing existing subnets as IpRange")
+
+			tags := awsutil.Ec2TagsFromMap(
+				map[string]string{
+					common.TagCloudManagerName:       fmt.Sprintf("%s/%s", ipRange.Namespace, ipRange.Name),
+					common.TagCloudManagerRemoteName: ipRange.Spec.RemoteRef.String(),
+					common.TagScope:                  ipRange.Spec.Scope.Name,
+				},
+				tagKey, "1",
+			)
+			for _, subnet := range group.subnets {
+				if awsutil.GetEc2TagValue(subnet.Tags, tagKey) != "" {
+					continue
+				}
+				if err := awsClient.TagSubnet(ctx, ptr.Deref(subnet.SubnetId, ""), tags); err != nil {
+					return fmt.Errorf("error tagging subnet %s of IpRange %s: %w", ptr.Deref(subnet.SubnetId, ""), description, err), ctx
+				}
+			}
+
+			if err := state.Cluster().K8sClient().Create(ctx, ipRange); err != nil {
+				return fmt.Errorf("error creating IpRange %s: %w", description, err), ctx
+			}
+
+			meta.SetStatusCondition(&ipRange.Status.Conditions, metav1.Condition{
+				Type:   cloudcontrolv1beta1.ConditionTypeAdopted,
+				Status: metav1.ConditionTrue,
+				Reason: cloudcontrolv1beta1.ReasonAdopted,
+				Message: fmt.Sprintf("Adopted existing subnets %s", strings.Join(pie.Map(group.subnets, func(s ec2Types.Subnet) string {
+					return ptr.Deref(s.SubnetId, "")
+				}), ", ")),
+			})
+			if err := state.Cluster().K8sClient().Status().Update(ctx, ipRange); err != nil {
+				return fmt.Errorf("error updating IpRange %s status: %w", description, err), ctx
+			}
+
+			ipRangeList.Items = append(ipRangeList.Items, *ipRange)
+			summary.Created = append(summary.Created, description)
+		}
+
+		logger.
+			WithValues(
+				"dryRun", options.DryRun,
+				"created", summary.Created,
+				"skipped", summary.Skipped,
+			).
+			Info("IpRange import completed")
+
+		return nil, ctx
+	}
+}
+
+// groupImportedSubnets returns the subnets tagged by cloud-manager or having all the match tags grouped by
+// the associated VPC CIDR block they are in, sorted by cidr. The subnets in the VPC primary CIDR block,
+// or not in any associated block, are returned as skipped.
+func groupImportedSubnets(vpc *ec2Types.Vpc, subnets []ec2Types.Subnet, matchTags map[string]string) ([]*importGroup, []string) {
+	var blocks []netip.Prefix
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState == nil || assoc.CidrBlockState.State != ec2Types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		if ptr.Deref(assoc.CidrBlock, "") == ptr.Deref(vpc.CidrBlock, "") {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(ptr.Deref(assoc.CidrBlock, "")); err == nil {
+			blocks = append(blocks, prefix)
+		}
+	}
+
+	var skipped []string
+	groups := map[string]*importGroup{}
+	for _, subnet := range subnets {
+		if awsutil.GetEc2TagValue(subnet.Tags, tagKey) == "" && !subnetTagsMatch(subnet.Tags, matchTags) {
+			continue
+		}
+		subnetPrefix, err := netip.ParsePrefix(ptr.Deref(subnet.CidrBlock, ""))
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("subnet %s: invalid cidr %q", ptr.Deref(subnet.SubnetId, ""), ptr.Deref(subnet.CidrBlock, "")))
+			continue
+		}
+		blockIdx := pie.FindFirstUsing(blocks, func(b netip.Prefix) bool {
+			return b.Bits() <= subnetPrefix.Bits() && b.Contains(subnetPrefix.Addr())
+		})
+		if blockIdx == -1 {
+			skipped = append(skipped, fmt.Sprintf("subnet %s (%s): not in a secondary VPC cidr block", ptr.Deref(subnet.SubnetId, ""), subnetPrefix))
+			continue
+		}
+		cidr := blocks[blockIdx].String()
+		if _, ok := groups[cidr]; !ok {
+			groups[cidr] = &importGroup{cidr: cidr}
+		}
+		groups[cidr].subnets = append(groups[cidr].subnets, subnet)
+	}
+
+	result := make([]*importGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Slice(g.subnets, func(i, j int) bool {
+			return ptr.Deref(g.subnets[i].AvailabilityZone, "") < ptr.Deref(g.subnets[j].AvailabilityZone, "")
+		})
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].cidr < result[j].cidr
+	})
+	return result, skipped
+}
+
+// newImportedIpRange returns the IpRange for the group. The name and the remote ref are taken from the
+// cloud-manager tags of the subnets if they have them, so the previously deleted IpRange is restored as it was.
+func newImportedIpRange(scope *cloudcontrolv1beta1.Scope, namespace string, group *importGroup) *cloudcontrolv1beta1.IpRange {
+	name := fmt.Sprintf("imported-%s", strings.NewReplacer(".", "-", "/", "-").Replace(group.cidr))
+	remoteRef := cloudcontrolv1beta1.RemoteRef{Namespace: namespace, Name: name}
+
+	first := group.subnets[0].Tags
+	if tagName := awsutil.GetEc2TagValue(first, common.TagCloudManagerName); tagName != "" {
+		if _, n, found := strings.Cut(tagName, "/"); found && n != "" {
+			name = n
+		}
+	}
+	if tagRemoteName := awsutil.GetEc2TagValue(first, common.TagCloudManagerRemoteName); tagRemoteName != "" {
+		if ns, n, found := strings.Cut(tagRemoteName, "--"); found && ns != "" && n != "" {
+			remoteRef = cloudcontrolv1beta1.RemoteRef{Namespace: ns, Name: n}
+		}
+	}
+
+	return &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				cloudcontrolv1beta1.LabelKymaName: scope.Name,
+			},
+		},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			RemoteRef: remoteRef,
+			Scope:     cloudcontrolv1beta1.ScopeRef{Name: scope.Name},
+			Cidr:      group.cidr,
+			Network:   &klog.ObjectRef{Name: common.KcpNetworkKymaCommonName(scope.Name)},
+			ZoneSubnets: pie.Map(group.subnets, func(s ec2Types.Subnet) cloudcontrolv1beta1.IpRangeZoneSubnet {
+				return cloudcontrolv1beta1.IpRangeZoneSubnet{
+					Zone: ptr.Deref(s.AvailabilityZone, ""),
+					Cidr: ptr.Deref(s.CidrBlock, ""),
+				}
+			}),
+		},
+	}
+}
+
+// importSkipReason returns why the IpRange of the group is not imported, or empty string if it should be
+func importSkipReason(ipRange *cloudcontrolv1beta1.IpRange, group *importGroup, zones []string, existing []cloudcontrolv1beta1.IpRange) string {
+	for _, x := range existing {
+		if x.Name == ipRange.Name {
+			return "IpRange with the same name already exists"
+		}
+		if x.Spec.Scope.Name != ipRange.Spec.Scope.Name {
+			continue
+		}
+		if x.Spec.Cidr == group.cidr || pie.Contains(x.Spec.Cidrs, group.cidr) || pie.Contains(x.StatusCidrs(), group.cidr) {
+			return fmt.Sprintf("cidr already used by IpRange %s", x.Name)
+		}
+	}
+
+	subnetZones := pie.Map(group.subnets, func(s ec2Types.Subnet) string {
+		return ptr.Deref(s.AvailabilityZone, "")
+	})
+	if len(pie.Unique(subnetZones)) != len(subnetZones) {
+		return "more than one subnet in a zone"
+	}
+	if !pie.Equals(pie.Sort(subnetZones), zones) {
+		return fmt.Sprintf("subnet zones %v do not match the scope zones %v", pie.Sort(subnetZones), zones)
+	}
+	return ""
+}
+
+func subnetTagsMatch(tags []ec2Types.Tag, matchTags map[string]string) bool {
+	if len(matchTags) == 0 {
+		return false
+	}
+	for k, v := range matchTags {
+		if awsutil.GetEc2TagValue(tags, k) != v || !awsutil.HasEc2Tag(tags, k) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestImportExistingIpRanges(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "kyma"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					VpcNetwork: "shoot--kyma",
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b"}},
+					},
+				},
+			},
+		},
+	}
+	existing := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "existing"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "kyma"},
+			Cidr:  "10.253.0.0/16",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(scope, existing).
+		WithStatusSubresource(&cloudcontrolv1beta1.IpRange{}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	state := composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: scope.Namespace, Name: scope.Name}, scope)
+
+	awsMock := awsmock.New()
+	awsMock.AddVpc("vpc-1", "10.250.0.0/16", awsutil.Ec2Tags("Name", "shoot--kyma"), nil)
+	for _, cidr := range []string{"10.251.0.0/16", "10.252.0.0/16", "10.253.0.0/16"} {
+		_, err := awsMock.AssociateVpcCidrBlock(ctx, "vpc-1", cidr)
+		assert.NoError(t, err)
+	}
+	managedTags := awsutil.Ec2Tags(tagKey, "1", common.TagCloudManagerName, "kcp-system/managed", common.TagCloudManagerRemoteName, "default--my-range")
+	userTags := awsutil.Ec2Tags("team", "network")
+	for _, x := range []struct {
+		zone, cidr string
+		managed    bool
+	}{
+		{"eu-west-1a", "10.251.0.0/18", true},
+		{"eu-west-1b", "10.251.64.0/18", true},
+		{"eu-west-1a", "10.252.0.0/18", false},
+		{"eu-west-1b", "10.252.64.0/18", false},
+		{"eu-west-1a", "10.253.0.0/18", true},
+		{"eu-west-1b", "10.253.64.0/18", true},
+		{"eu-west-1a", "10.250.0.0/20", false},
+	} {
+		tags := userTags
+		if x.managed {
+			tags = managedTags
+		}
+		_, err := awsMock.CreateSubnet(ctx, "vpc-1", x.zone, x.cidr, tags)
+		assert.NoError(t, err)
+	}
+
+	options := ImportOptions{
+		Namespace: "kcp-system",
+		MatchTags: map[string]string{"team": "network"},
+		DryRun:    true,
+	}
+
+	// dry-run only reports
+	summary := &ImportSummary{}
+	err, _ := NewImportExistingIpRanges(awsMock, options, summary)(ctx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"kcp-system/managed (10.251.0.0/16)",
+		"kcp-system/imported-10-252-0-0-16 (10.252.0.0/16)",
+	}, summary.Created)
+	assert.Len(t, summary.Skipped, 2)
+	assert.Contains(t, summary.String(), "Would create 2 IpRanges, skipped 2")
+
+	list := &cloudcontrolv1beta1.IpRangeList{}
+	assert.NoError(t, clnt.List(ctx, list, client.InNamespace("kcp-system")))
+	assert.Len(t, list.Items, 1)
+
+	// the IpRanges are created in adopted state
+	options.DryRun = false
+	summary = &ImportSummary{}
+	err, _ = NewImportExistingIpRanges(awsMock, options, summary)(ctx, state)
+	assert.NoError(t, err)
+	assert.Len(t, summary.Created, 2)
+
+	managed := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, clnt.Get(ctx, types.NamespacedName{Namespace: "kcp-system", Name: "managed"}, managed))
+	assert.Equal(t, cloudcontrolv1beta1.RemoteRef{Namespace: "default", Name: "my-range"}, managed.Spec.RemoteRef)
+	assert.Equal(t, "10.251.0.0/16", managed.Spec.Cidr)
+	assert.Equal(t, []cloudcontrolv1beta1.IpRangeZoneSubnet{
+		{Zone: "eu-west-1a", Cidr: "10.251.0.0/18"},
+		{Zone: "eu-west-1b", Cidr: "10.251.64.0/18"},
+	}, managed.Spec.ZoneSubnets)
+	assert.NotNil(t, meta.FindStatusCondition(managed.Status.Conditions, cloudcontrolv1beta1.ConditionTypeAdopted))
+
+	imported := &cloudcontrolv1beta1.IpRange{}
+	assert.NoError(t, clnt.Get(ctx, types.NamespacedName{Namespace: "kcp-system", Name: "imported-10-252-0-0-16"}, imported))
+	assert.Equal(t, "kyma", imported.Spec.Scope.Name)
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	for _, s := range subnets {
+		cidr := ptr.Deref(s.CidrBlock, "")
+		if cidr == "10.252.0.0/18" || cidr == "10.252.64.0/18" {
+			assert.Equal(t, "1", awsutil.GetEc2TagValue(s.Tags, tagKey))
+			assert.Equal(t, "kcp-system/imported-10-252-0-0-16", awsutil.GetEc2TagValue(s.Tags, common.TagCloudManagerName))
+		}
+		if cidr == "10.250.0.0/20" {
+			assert.False(t, awsutil.HasEc2Tag(s.Tags, tagKey), "subnet in the primary cidr block must not be tagged")
+		}
+	}
+
+	// and importing again is a no-op
+	summary = &ImportSummary{}
+	err, _ = NewImportExistingIpRanges(awsMock, options, summary)(ctx, state)
+	assert.NoError(t, err)
+	assert.Empty(t, summary.Created)
+	assert.Len(t, summary.Skipped, 4)
+}
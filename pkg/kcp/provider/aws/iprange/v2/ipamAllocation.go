@@ -0,0 +1,155 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// ipamAllocate allocates the IpRange cidr from the spec.ipamPoolId IPAM pool, and keeps the
+// allocation id in the status so it can be released on delete. If the pool has no space left
+// for the cidr the IpamPoolExhausted error is set, and the allocation is retried later, since
+// more cidrs can be provisioned to the pool. The allocation made before the status was patched
+// is found by its description, and the allocation is made with the IpRange UID as the client
+// token, so a retried allocation does not allocate another cidr.
+func ipamAllocate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	ipRange := state.ObjAsIpRange()
+
+	if len(ipRange.Spec.IpamPoolId) == 0 || len(ipRange.Status.Cidr) > 0 {
+		return nil, nil
+	}
+
+	poolId := ipRange.Spec.IpamPoolId
+	maskSize := ipRange.CidrSizeOrDefault(iprangeallocate.DefaultMaskSize)
+	logger = logger.WithValues("ipamPoolId", poolId)
+
+	poolCidrs, err := state.awsClient.GetIpamPoolCidrs(ctx, poolId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS IPAM pool cidrs", ctx)
+	}
+	allocations, err := state.awsClient.GetIpamPoolAllocations(ctx, poolId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS IPAM pool allocations", ctx)
+	}
+
+	description := fmt.Sprintf("%s/%s", ipRange.Namespace, ipRange.Name)
+	if idx := pie.FindFirstUsing(allocations, func(a ec2types.IpamPoolAllocation) bool {
+		return ptr.Deref(a.Description, "") == description
+	}); idx != -1 {
+		logger.Info("Found existing AWS IPAM pool allocation")
+		return ipamAllocationToStatus(ctx, state, &allocations[idx])
+	}
+
+	provisioned := pie.Map(
+		pie.Filter(poolCidrs, func(c ec2types.IpamPoolCidr) bool {
+			return c.State == ec2types.IpamPoolCidrStateProvisioned
+		}),
+		func(c ec2types.IpamPoolCidr) string {
+			return ptr.Deref(c.Cidr, "")
+		},
+	)
+	allocated := pie.Map(allocations, func(a ec2types.IpamPoolAllocation) string {
+		return ptr.Deref(a.Cidr, "")
+	})
+
+	_, err = iprangeallocate.AllocateCidrFromPool(maskSize, provisioned, allocated)
+	if errors.Is(err, iprangeallocate.ErrPoolExhausted) {
+		logger.Info("AWS IPAM pool exhausted")
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonIpamPoolExhausted,
+				Message: fmt.Sprintf("IPAM pool %s has no space for a /%d cidr", poolId, maskSize),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with exhausted IPAM pool").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error checking AWS IPAM pool space", composed.StopAndForget, ctx)
+	}
+
+	allocation, err := state.awsClient.AllocateIpamPoolCidr(ctx, poolId, maskSize, description, string(ipRange.UID))
+	if err != nil {
+		logger.Error(err, "Error allocating cidr from AWS IPAM pool")
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonIpamAllocationFailed,
+				Message: fmt.Sprintf("Failed allocating cidr from IPAM pool %s: %s", poolId, awsmeta.GetErrorMessage(err)),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with failed IPAM allocation").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+			Run(ctx, state)
+	}
+
+	return ipamAllocationToStatus(ctx, state, allocation)
+}
+
+// ipamAllocationToStatus sets the cidr and the id of the IPAM pool allocation to the status
+func ipamAllocationToStatus(ctx context.Context, state *State, allocation *ec2types.IpamPoolAllocation) (error, context.Context) {
+	logger := composed.LoggerFromCtx(ctx)
+	ipRange := state.ObjAsIpRange()
+
+	ipRange.Status.Cidr = ptr.Deref(allocation.Cidr, "")
+	ipRange.Status.IpamPoolAllocationId = ptr.Deref(allocation.IpamPoolAllocationId, "")
+
+	logger.
+		WithValues(
+			"cidr", ipRange.Status.Cidr,
+			"ipamPoolAllocationId", ipRange.Status.IpamPoolAllocationId,
+		).
+		Info("Cidr allocated from AWS IPAM pool")
+
+	err := state.PatchObjStatus(ctx)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error patching KCP IpRange status with IPAM allocation", composed.StopWithRequeue, ctx)
+	}
+
+	return nil, nil
+}
+
+// ipamRelease releases the IPAM pool allocation of the IpRange cidr, once it is disassociated from the VPC
+func ipamRelease(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	ipRange := state.ObjAsIpRange()
+
+	if len(ipRange.Spec.IpamPoolId) == 0 || len(ipRange.Status.IpamPoolAllocationId) == 0 {
+		return nil, nil
+	}
+
+	logger.
+		WithValues(
+			"ipamPoolId", ipRange.Spec.IpamPoolId,
+			"ipamPoolAllocationId", ipRange.Status.IpamPoolAllocationId,
+		).
+		Info("Releasing AWS IPAM pool allocation")
+
+	err := state.awsClient.ReleaseIpamPoolAllocation(ctx, ipRange.Spec.IpamPoolId, ipRange.Status.IpamPoolAllocationId, ipRange.Status.Cidr)
+	if err != nil && !awsmeta.IsNotFound(err) {
+		return awsmeta.LogErrorAndReturn(err, "Error releasing AWS IPAM pool allocation", ctx)
+	}
+
+	ipRange.Status.IpamPoolAllocationId = ""
+	err = state.PatchObjStatus(ctx)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error patching KCP IpRange status after releasing IPAM allocation", composed.StopWithRequeue, ctx)
+	}
+
+	return nil, nil
+}
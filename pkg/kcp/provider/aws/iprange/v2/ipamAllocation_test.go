@@ -0,0 +1,123 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newIpamTestState(ipRange *cloudcontrolv1beta1.IpRange, awsMock awsmock.Server) *State {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	return newState(&testIpRangeState{State: focalState}, awsMock)
+}
+
+func TestIpamAllocateAndRelease(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			IpamPoolId: "ipam-pool-1",
+		},
+	}
+	awsMock := awsmock.New()
+	awsMock.AddIpamPool("ipam-pool-1", "10.100.0.0/20")
+	awsMock.AddIpamPoolAllocation("ipam-pool-1", "10.100.0.0/22")
+	state := newIpamTestState(ipRange, awsMock)
+
+	err, _ := ipamAllocate(ctx, state)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.100.4.0/22", ipRange.Status.Cidr)
+	assert.NotEmpty(t, ipRange.Status.IpamPoolAllocationId)
+
+	allocations, err := awsMock.GetIpamPoolAllocations(ctx, "ipam-pool-1")
+	assert.NoError(t, err)
+	assert.Len(t, allocations, 2)
+	assert.Equal(t, "kcp-system/iprange", ptr.Deref(allocations[1].Description, ""))
+
+	// allocated only once
+	err, _ = ipamAllocate(ctx, state)
+	assert.Nil(t, err)
+	allocations, _ = awsMock.GetIpamPoolAllocations(ctx, "ipam-pool-1")
+	assert.Len(t, allocations, 2)
+
+	// the allocation whose status was not persisted is found again
+	allocationId := ipRange.Status.IpamPoolAllocationId
+	ipRange.Status.Cidr = ""
+	ipRange.Status.IpamPoolAllocationId = ""
+	err, _ = ipamAllocate(ctx, state)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.100.4.0/22", ipRange.Status.Cidr)
+	assert.Equal(t, allocationId, ipRange.Status.IpamPoolAllocationId)
+	allocations, _ = awsMock.GetIpamPoolAllocations(ctx, "ipam-pool-1")
+	assert.Len(t, allocations, 2)
+
+	err, _ = ipamRelease(ctx, state)
+	assert.Nil(t, err)
+	assert.Empty(t, ipRange.Status.IpamPoolAllocationId)
+	allocations, _ = awsMock.GetIpamPoolAllocations(ctx, "ipam-pool-1")
+	assert.Len(t, allocations, 1)
+	assert.Equal(t, "10.100.0.0/22", ptr.Deref(allocations[0].Cidr, ""))
+}
+
+func TestIpamAllocatePoolExhausted(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			IpamPoolId: "ipam-pool-1",
+		},
+	}
+	awsMock := awsmock.New()
+	awsMock.AddIpamPool("ipam-pool-1", "10.100.0.0/21")
+	awsMock.AddIpamPoolAllocation("ipam-pool-1", "10.100.0.0/22")
+	awsMock.AddIpamPoolAllocation("ipam-pool-1", "10.100.6.0/24")
+	state := newIpamTestState(ipRange, awsMock)
+
+	err, _ := ipamAllocate(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+	assert.Empty(t, ipRange.Status.Cidr)
+
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(t, cond)
+	assert.Equal(t, cloudcontrolv1beta1.ReasonIpamPoolExhausted, cond.Reason)
+
+	allocations, _ := awsMock.GetIpamPoolAllocations(ctx, "ipam-pool-1")
+	assert.Len(t, allocations, 2)
+}
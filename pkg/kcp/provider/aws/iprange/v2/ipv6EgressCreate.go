@@ -0,0 +1,122 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"strings"
+)
+
+// ipv6EgressCreate ensures the egress-only internet gateway exists on the VPC and the route tables of
+// the IPv6 subnets have the ::/0 route to it. Only the route tables owned by the IpRange are modified, if
+// there are no IPv6 subnets or any of them uses other route table the Ipv6EgressConfigured condition is
+// set to False and nothing is created.
+func ipv6EgressCreate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if !isIpv6EgressRequested(state.ObjAsIpRange()) {
+		return nil, nil
+	}
+
+	ipv6Subnets := getIpv6Subnets(state.cloudResourceSubnets)
+	if len(ipv6Subnets) == 0 {
+		return ipv6EgressNotConfigured(ctx, state, "None of the IpRange subnets has an IPv6 CIDR block")
+	}
+
+	routeTables, err := state.awsClient.DescribeRouteTables(ctx, ptr.Deref(state.vpc.VpcId, ""))
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load route tables",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS route tables"); x != nil {
+		return x, nil
+	}
+
+	routeTableIds, notOwnedSubnetIds := getSubnetsRouteTableIds(routeTables, ipv6Subnets, state.Name().Name)
+	if len(notOwnedSubnetIds) > 0 {
+		return ipv6EgressNotConfigured(ctx, state, fmt.Sprintf(
+			"Subnets %s are not explicitly associated with route tables tagged %s=%s",
+			strings.Join(notOwnedSubnetIds, ", "), tagKey, state.Name().Name))
+	}
+
+	if state.egressOnlyGateway == nil {
+		logger.Info("Creating AWS egress-only internet gateway")
+		gateway, err := state.awsClient.CreateEgressOnlyInternetGateway(ctx, ptr.Deref(state.vpc.VpcId, ""), awsutil.Ec2TagsFromMap(
+			state.tags,
+			"Name", state.ObjAsIpRange().Name,
+			tagKey, state.Name().Name,
+		))
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on create egress-only internet gateway",
+			cloudcontrolv1beta1.ReasonIpv6EgressFailed, "Error creating AWS egress-only internet gateway"); x != nil {
+			return x, nil
+		}
+		state.egressOnlyGateway = gateway
+	}
+	gatewayId := ptr.Deref(state.egressOnlyGateway.EgressOnlyInternetGatewayId, "")
+
+	for _, rt := range routeTables {
+		rtId := ptr.Deref(rt.RouteTableId, "")
+		if !pie.Contains(routeTableIds, rtId) {
+			continue
+		}
+		route := findIpv6DefaultRoute(rt)
+		if route != nil && ptr.Deref(route.EgressOnlyInternetGatewayId, "") == gatewayId {
+			continue
+		}
+		if route != nil {
+			state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+			return composed.PatchStatus(state.ObjAsIpRange()).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonIpv6EgressFailed,
+					Message: fmt.Sprintf("Route table %s already has the %s route to other target", rtId, ipv6DefaultRoute),
+				}).
+				ErrorLogMessage("Error patching KCP IpRange status with conflicting IPv6 default route").
+				SuccessLogMsg("KCP IpRange route table has conflicting IPv6 default route").
+				SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+				Run(ctx, state)
+		}
+
+		logger.WithValues("routeTableId", rtId).Info("Creating IPv6 default route to AWS egress-only internet gateway")
+		err = state.awsClient.CreateIpv6Route(ctx, rtId, ipv6DefaultRoute, gatewayId)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on create IPv6 default route",
+			cloudcontrolv1beta1.ReasonIpv6EgressFailed, "Error creating IPv6 default route"); x != nil {
+			return x, nil
+		}
+	}
+
+	cond := meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured)
+	if state.ObjAsIpRange().Status.EgressOnlyInternetGatewayId == gatewayId &&
+		cond != nil && cond.Status == metav1.ConditionTrue {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.EgressOnlyInternetGatewayId = gatewayId
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetCondition(ipv6EgressConfiguredCondition(gatewayId)).
+		ErrorLogMessage("Error patching KCP IpRange status with egress-only internet gateway").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+func ipv6EgressNotConfigured(ctx context.Context, state *State, message string) (error, context.Context) {
+	condition := ipv6EgressNotConfiguredCondition(message)
+	if !composed.AnyConditionChanged(state.ObjAsIpRange(), condition) {
+		return nil, nil
+	}
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetCondition(condition).
+		ErrorLogMessage("Error patching KCP IpRange status with IPv6 egress not configured").
+		SuccessLogMsg("KCP IpRange IPv6 egress can not be configured").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,74 @@
+package v2
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+)
+
+// ipv6EgressDelete removes the IPv6 default routes to the egress-only internet gateway and deletes
+// the gateway once the IPv6 egress is not requested, no IPv6 subnets remain, or the IpRange is being deleted.
+// The routes are deleted only if they target the gateway of the IpRange.
+func ipv6EgressDelete(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if isIpv6EgressRequired(state) {
+		return nil, nil
+	}
+
+	if state.egressOnlyGateway != nil {
+		gatewayId := ptr.Deref(state.egressOnlyGateway.EgressOnlyInternetGatewayId, "")
+
+		routeTables, err := state.awsClient.DescribeRouteTables(ctx, ptr.Deref(state.vpc.VpcId, ""))
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load route tables",
+			cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS route tables"); x != nil {
+			return x, nil
+		}
+
+		for _, rt := range routeTables {
+			route := findIpv6DefaultRoute(rt)
+			if route == nil || ptr.Deref(route.EgressOnlyInternetGatewayId, "") != gatewayId {
+				continue
+			}
+			logger.WithValues("routeTableId", ptr.Deref(rt.RouteTableId, "")).Info("Deleting IPv6 default route to AWS egress-only internet gateway")
+			err = state.awsClient.DeleteIpv6Route(ctx, ptr.Deref(rt.RouteTableId, ""), ipv6DefaultRoute)
+			if awsmeta.IsNotFound(err) {
+				err = nil
+			}
+			if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on delete IPv6 default route",
+				cloudcontrolv1beta1.ReasonIpv6EgressFailed, "Error deleting IPv6 default route"); x != nil {
+				return x, nil
+			}
+		}
+
+		logger.Info("Deleting AWS egress-only internet gateway")
+		err = state.awsClient.DeleteEgressOnlyInternetGateway(ctx, gatewayId)
+		if awsmeta.IsNotFound(err) {
+			err = nil
+		}
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on delete egress-only internet gateway",
+			cloudcontrolv1beta1.ReasonIpv6EgressFailed, "Error deleting AWS egress-only internet gateway"); x != nil {
+			return x, nil
+		}
+		state.egressOnlyGateway = nil
+	}
+
+	if state.ObjAsIpRange().Status.EgressOnlyInternetGatewayId == "" &&
+		(isIpv6EgressRequested(state.ObjAsIpRange()) ||
+			meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured) == nil) {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.EgressOnlyInternetGatewayId = ""
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured).
+		ErrorLogMessage("Error patching KCP IpRange status after deleting egress-only internet gateway").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,43 @@
+package v2
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+// ipv6EgressLoad loads the egress-only internet gateway of the IpRange. The gateway is looked up only
+// when the IPv6 egress is requested or the gateway in the status is still to be cleaned up.
+func ipv6EgressLoad(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.vpc == nil {
+		return nil, nil
+	}
+	if !isIpv6EgressRequested(state.ObjAsIpRange()) && state.ObjAsIpRange().Status.EgressOnlyInternetGatewayId == "" {
+		return nil, nil
+	}
+
+	gateways, err := state.awsClient.DescribeEgressOnlyInternetGateways(ctx, ptr.Deref(state.vpc.VpcId, ""))
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load egress-only internet gateways",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS egress-only internet gateways"); x != nil {
+		return x, nil
+	}
+
+	for _, g := range gateways {
+		if awsutil.GetEc2TagValue(g.Tags, tagKey) != state.Name().Name {
+			continue
+		}
+		gateway := g
+		state.egressOnlyGateway = &gateway
+
+		logger = logger.WithValues("egressOnlyInternetGatewayId", ptr.Deref(gateway.EgressOnlyInternetGatewayId, ""))
+		return nil, composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,88 @@
+package v2
+
+import (
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetIpv6Subnets(t *testing.T) {
+	subnets := []ec2Types.Subnet{
+		{SubnetId: ptr.To("subnet-ipv4")},
+		{
+			SubnetId: ptr.To("subnet-ipv6"),
+			Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+				{
+					Ipv6CidrBlock:      ptr.To("2600:1f18::/64"),
+					Ipv6CidrBlockState: &ec2Types.SubnetCidrBlockState{State: ec2Types.SubnetCidrBlockStateCodeAssociated},
+				},
+			},
+		},
+		{
+			SubnetId: ptr.To("subnet-ipv6-disassociated"),
+			Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+				{
+					Ipv6CidrBlock:      ptr.To("2600:1f19::/64"),
+					Ipv6CidrBlockState: &ec2Types.SubnetCidrBlockState{State: ec2Types.SubnetCidrBlockStateCodeDisassociated},
+				},
+			},
+		},
+	}
+
+	actual := getIpv6Subnets(subnets)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "subnet-ipv6", ptr.Deref(actual[0].SubnetId, ""))
+
+	assert.Empty(t, getIpv6Subnets(subnets[:1]), "IPv4 only subnets")
+}
+
+func TestGetSubnetsRouteTableIds(t *testing.T) {
+	routeTables := []ec2Types.RouteTable{
+		{
+			RouteTableId: ptr.To("rtb-main"),
+			Associations: []ec2Types.RouteTableAssociation{{Main: ptr.To(true)}},
+		},
+		{
+			RouteTableId: ptr.To("rtb-owned"),
+			Associations: []ec2Types.RouteTableAssociation{{SubnetId: ptr.To("subnet-a")}, {SubnetId: ptr.To("subnet-d")}},
+			Tags:         []ec2Types.Tag{{Key: ptr.To(tagKey), Value: ptr.To("iprange-1")}},
+		},
+		{
+			RouteTableId: ptr.To("rtb-other"),
+			Associations: []ec2Types.RouteTableAssociation{{SubnetId: ptr.To("subnet-c")}},
+			Tags:         []ec2Types.Tag{{Key: ptr.To(tagKey), Value: ptr.To("iprange-2")}},
+		},
+	}
+
+	owned, notOwned := getSubnetsRouteTableIds(routeTables, []ec2Types.Subnet{
+		{SubnetId: ptr.To("subnet-a")},
+		{SubnetId: ptr.To("subnet-d")},
+	}, "iprange-1")
+	assert.Equal(t, []string{"rtb-owned"}, owned)
+	assert.Empty(t, notOwned)
+
+	owned, notOwned = getSubnetsRouteTableIds(routeTables, []ec2Types.Subnet{
+		{SubnetId: ptr.To("subnet-a")},
+		{SubnetId: ptr.To("subnet-b")},
+		{SubnetId: ptr.To("subnet-c")},
+	}, "iprange-1")
+	assert.Equal(t, []string{"rtb-owned"}, owned)
+	assert.Equal(t, []string{"subnet-b", "subnet-c"}, notOwned, "main route table and route table of other IpRange are not owned")
+}
+
+func TestFindIpv6DefaultRoute(t *testing.T) {
+	assert.Nil(t, findIpv6DefaultRoute(ec2Types.RouteTable{
+		Routes: []ec2Types.Route{{DestinationCidrBlock: ptr.To("0.0.0.0/0")}},
+	}))
+
+	route := findIpv6DefaultRoute(ec2Types.RouteTable{
+		Routes: []ec2Types.Route{
+			{DestinationCidrBlock: ptr.To("0.0.0.0/0")},
+			{DestinationIpv6CidrBlock: ptr.To("::/0"), EgressOnlyInternetGatewayId: ptr.To("eigw-1")},
+		},
+	})
+	assert.NotNil(t, route)
+	assert.Equal(t, "eigw-1", ptr.Deref(route.EgressOnlyInternetGatewayId, ""))
+}
@@ -3,6 +3,8 @@ package v2
 import (
 	"context"
 	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	iprangetypes "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/types"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
@@ -30,28 +32,61 @@ func New(stateFactory StateFactory) composed.Action {
 			vpcFind,
 			subnetsLoadAll,
 			subnetsFindCloudResources,
+			zonesResolve,
+			actions.DryRunPlan(planDryRun),
+			composed.DriftGuard(subnetsDrift, subnetsRestoreTags),
+			resourceShareLoad,
+			tgwAttachmentLoad,
+			ipv6EgressLoad,
 			composed.IfElse(composed.Not(composed.MarkedForDeletionPredicate),
 				composed.ComposeActions(
 					"kcpIpRangeI2-create",
 					preventCidrEdit,
+					resourceShareValidate,
+					actions.ValidateTags(
+						cloudcontrolv1beta1.ProviderAws,
+						func(st composed.State) map[string]string { return getTagsWithPropagated(st.(*State)) },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
+					ipamAllocate,
 					copyCidrToStatus,
+					zoneSubnetsValidate,
 					rangeSplitByZones,
 					ensureShootZonesAndRangeSubnetsMatch,
 					rangeCheckOverlap,
 					rangeCheckBlockStatus,
+					subnetsReclaimUntagged,
 					rangeCheckSubnetOverlap,
 					rangeExtendVpcAddressSpace,
 					subnetsCreate,
 					subnetsCheckState,
+					subnetsReportMetrics,
+					growZoneCapacity,
+					subnetsPropagateTags,
+					tgwAttachmentDelete,
+					tgwValidate,
+					tgwAttachmentCreate,
+					tgwAttachmentWait,
+					tgwRouteTable,
+					ipv6EgressDelete,
+					ipv6EgressCreate,
+					resourceShareDelete,
+					resourceShareCreate,
+					resourceShareAssociate,
 					statusSuccess,
 				),
 				composed.ComposeActions(
 					"kcpIpRangeI2-delete",
 					statusRemoveReadyCondition,
+					resourceShareDelete,
+					tgwAttachmentDelete,
+					ipv6EgressDelete,
 					subnetsDelete,
 					subnetsWaitDeleted,
+					subnetsDeleteMetrics,
 					rangeDisassociateVpcAddressSpace,
 					rangeWaitCidrBlockDisassociated,
+					ipamRelease,
 				),
 			),
 		)(awsmeta.SetAwsAccountId(ctx, ipRangeState.Scope().Spec.Scope.Aws.AccountId), state)
@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestPreventCidrEdit(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newReadyState := func(specCidr string) *State {
+		state, ipRange, _ := newZonesResolveState()
+		ipRange.Spec.Cidr = specCidr
+		ipRange.Status.Cidr = "10.250.8.0/22"
+		ipRange.Status.Conditions = []metav1.Condition{{
+			Type:    cloudcontrolv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReady,
+			Message: "Ready",
+		}}
+		return state
+	}
+
+	t.Run("unchanged cidr", func(t *testing.T) {
+		state := newReadyState("10.250.8.0/22")
+
+		err, _ := preventCidrEdit(ctx, state)
+		assert.Nil(t, err)
+	})
+
+	t.Run("changed cidr is rejected with the Error condition", func(t *testing.T) {
+		state := newReadyState("10.250.16.0/22")
+
+		err, _ := preventCidrEdit(ctx, state)
+		assert.Equal(t, composed.StopAndForget, err)
+
+		cond := meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, cloudcontrolv1beta1.ReasonCidrCanNotChange, cond.Reason)
+		}
+		assert.Len(t, state.ObjAsIpRange().Status.Conditions, 1)
+	})
+}
@@ -18,57 +18,73 @@ func rangeCheckBlockStatus(ctx context.Context, st composed.State) (error, conte
 	logger := composed.LoggerFromCtx(ctx)
 	state := st.(*State)
 
-	rangeCidr, _ := cidr.Parse(state.ObjAsIpRange().Status.Cidr)
-	for _, set := range state.vpc.CidrBlockAssociationSet {
-		cdr, err := cidr.Parse(ptr.Deref(set.CidrBlock, ""))
-		if err != nil {
-			logger.Error(err, "Error parsing AWS CIDR")
-			continue
-		}
+	state.associatedCidrBlocks = map[string]*ec2Types.VpcCidrBlockAssociation{}
+
+	for _, rangeCidrText := range state.ObjAsIpRange().StatusCidrs() {
+		rangeCidr, _ := cidr.Parse(rangeCidrText)
+		for _, set := range state.vpc.CidrBlockAssociationSet {
+			cdr, err := cidr.Parse(ptr.Deref(set.CidrBlock, ""))
+			if err != nil {
+				logger.Error(err, "Error parsing AWS CIDR")
+				continue
+			}
 
-		if util.CidrEquals(rangeCidr.CIDR(), cdr.CIDR()) &&
-			// we must ignore disassociated sets
-			!pie.Contains([]ec2Types.VpcCidrBlockStateCode{
-				ec2Types.VpcCidrBlockStateCodeDisassociated,
-				ec2Types.VpcCidrBlockStateCodeDisassociating,
-			}, set.CidrBlockState.State) {
-			state.associatedCidrBlock = &set
-			break
+			if util.CidrEquals(rangeCidr.CIDR(), cdr.CIDR()) &&
+				// we must ignore disassociated sets
+				!pie.Contains([]ec2Types.VpcCidrBlockStateCode{
+					ec2Types.VpcCidrBlockStateCodeDisassociated,
+					ec2Types.VpcCidrBlockStateCodeDisassociating,
+				}, set.CidrBlockState.State) {
+				state.associatedCidrBlocks[rangeCidrText] = &set
+				break
+			}
+		}
+		if _, found := state.associatedCidrBlocks[rangeCidrText]; !found {
+			// the range allocated from the preferred secondary CIDR needs no block of its own
+			if hosting := findHostingCidrBlock(state, rangeCidrText); hosting != nil {
+				state.associatedCidrBlocks[rangeCidrText] = hosting
+			}
 		}
 	}
 
-	if state.associatedCidrBlock == nil {
-		logger.Info("Matching AWS CIDR block not found")
-		return nil, nil
-	}
+	for _, rangeCidrText := range state.ObjAsIpRange().StatusCidrs() {
+		associatedCidrBlock, found := state.associatedCidrBlocks[rangeCidrText]
+		if !found {
+			logger.WithValues("cidr", rangeCidrText).Info("Matching AWS CIDR block not found")
+			continue
+		}
 
-	logger.
-		WithValues(
-			"cidrBlockAssociationId", state.associatedCidrBlock.AssociationId,
-			"cidrBlockAssociationState", state.associatedCidrBlock.CidrBlockState.State,
-			"cidrBlockAssociationMessage", state.associatedCidrBlock.CidrBlockState.StatusMessage,
-		).
-		Info("Found matching AWS CIDR block")
+		logger.
+			WithValues(
+				"cidr", rangeCidrText,
+				"cidrBlockAssociationId", associatedCidrBlock.AssociationId,
+				"cidrBlockAssociationState", associatedCidrBlock.CidrBlockState.State,
+				"cidrBlockAssociationMessage", associatedCidrBlock.CidrBlockState.StatusMessage,
+			).
+			Info("Found matching AWS CIDR block")
 
-	if pie.Contains([]ec2Types.VpcCidrBlockStateCode{
-		ec2Types.VpcCidrBlockStateCodeAssociated,
-		ec2Types.VpcCidrBlockStateCodeDisassociated,
-		ec2Types.VpcCidrBlockStateCodeDisassociating,
-	}, state.associatedCidrBlock.CidrBlockState.State) {
-		return nil, nil
-	}
-	if state.associatedCidrBlock.CidrBlockState.State == ec2Types.VpcCidrBlockStateCodeAssociating {
-		return composed.StopWithRequeueDelay(10 * time.Second), nil
+		if pie.Contains([]ec2Types.VpcCidrBlockStateCode{
+			ec2Types.VpcCidrBlockStateCodeAssociated,
+			ec2Types.VpcCidrBlockStateCodeDisassociated,
+			ec2Types.VpcCidrBlockStateCodeDisassociating,
+		}, associatedCidrBlock.CidrBlockState.State) {
+			continue
+		}
+		if associatedCidrBlock.CidrBlockState.State == ec2Types.VpcCidrBlockStateCodeAssociating {
+			return composed.StopWithRequeueDelay(10 * time.Second), nil
+		}
+
+		return composed.PatchStatus(state.ObjAsIpRange()).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonCidrAssociationFailed,
+				Message: fmt.Sprintf("CIDR block %s status state is %s", rangeCidrText, associatedCidrBlock.CidrBlockState.State),
+			}).
+			ErrorLogMessage("Failed patching KCP IpRange CidrAssociationFailed status").
+			SuccessLogMsg("Forgetting KCP IpRange with unhandled CidrBlock state").
+			Run(ctx, st)
 	}
 
-	return composed.PatchStatus(state.ObjAsIpRange()).
-		SetExclusiveConditions(metav1.Condition{
-			Type:    cloudcontrolv1beta1.ConditionTypeError,
-			Status:  metav1.ConditionTrue,
-			Reason:  cloudcontrolv1beta1.ReasonCidrAssociationFailed,
-			Message: fmt.Sprintf("CIDR block status state is %s", state.associatedCidrBlock.CidrBlockState.State),
-		}).
-		ErrorLogMessage("Failed patching KCP IpRange CidrAssociationFailed status").
-		SuccessLogMsg("Forgetting KCP IpRange with unhandled CidrBlock state").
-		Run(ctx, st)
+	return nil, nil
 }
@@ -3,9 +3,13 @@ package v2
 import (
 	"context"
 	"fmt"
+	"net"
+
 	"github.com/3th1nk/cidr"
+	"github.com/elliotchance/pie/v2"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -15,32 +19,113 @@ func rangeCheckOverlap(ctx context.Context, st composed.State) (error, context.C
 	logger := composed.LoggerFromCtx(ctx)
 	state := st.(*State)
 
-	rangeCidr, _ := cidr.Parse(state.ObjAsIpRange().Status.Cidr)
-	for _, set := range state.vpc.CidrBlockAssociationSet {
-		cdr, err := cidr.Parse(ptr.Deref(set.CidrBlock, ""))
-		if err != nil {
-			logger.Error(err, "Error parsing AWS CIDR")
-			continue
+	rangeCidrs := state.ObjAsIpRange().StatusCidrs()
+
+	var msg string
+	if a, b := findOverlappingCidrs(rangeCidrs); len(a) > 0 {
+		msg = fmt.Sprintf("CIDR %s overlaps with CIDR %s", a, b)
+	}
+
+	for _, rangeCidrText := range rangeCidrs {
+		if len(msg) > 0 {
+			break
 		}
+		rangeCidr, _ := cidr.Parse(rangeCidrText)
+		for _, set := range state.vpc.CidrBlockAssociationSet {
+			cdr, err := cidr.Parse(ptr.Deref(set.CidrBlock, ""))
+			if err != nil {
+				logger.Error(err, "Error parsing AWS CIDR")
+				continue
+			}
+
+			if util.CidrEquals(rangeCidr.CIDR(), cdr.CIDR()) {
+				continue
+			}
+
+			// the range allocated from the preferred secondary CIDR is within it
+			if hosting := findHostingCidrBlock(state, rangeCidrText); hosting != nil && ptr.Deref(hosting.AssociationId, "") == ptr.Deref(set.AssociationId, "") {
+				continue
+			}
+
+			if util.CidrOverlap(rangeCidr.CIDR(), cdr.CIDR()) {
+				msg = fmt.Sprintf("CIDR overlaps with VPC adress range cidr %s", ptr.Deref(set.CidrBlock, ""))
+				if len(rangeCidrs) > 1 {
+					msg = fmt.Sprintf("CIDR %s overlaps with VPC adress range cidr %s", rangeCidrText, ptr.Deref(set.CidrBlock, ""))
+				}
+				if suggested := suggestFreeCidr(state, rangeCidrText); len(suggested) > 0 {
+					msg = fmt.Sprintf("%s, the free CIDR %s of the same size can be used instead", msg, suggested)
+				}
+				break
+			}
+		}
+	}
+
+	if len(msg) == 0 {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  "True",
+			Reason:  cloudcontrolv1beta1.ReasonCidrOverlap,
+			Message: msg,
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status due to cidr overlap").
+		SuccessLogMsg("Forgetting KCP IpRange due to cidr overlap").
+		Run(ctx, st)
+}
 
-		if util.CidrEquals(rangeCidr.CIDR(), cdr.CIDR()) {
+// findOverlappingCidrs returns the first pair of the IpRange CIDR blocks that overlap with each other,
+// or empty strings if none overlap
+func findOverlappingCidrs(cidrs []string) (string, string) {
+	for i := range cidrs {
+		a, err := cidr.Parse(cidrs[i])
+		if err != nil {
 			continue
 		}
+		for j := i + 1; j < len(cidrs); j++ {
+			b, err := cidr.Parse(cidrs[j])
+			if err != nil {
+				continue
+			}
+			if util.CidrOverlap(a.CIDR(), b.CIDR()) {
+				return cidrs[i], cidrs[j]
+			}
+		}
+	}
+	return "", ""
+}
 
-		if util.CidrOverlap(rangeCidr.CIDR(), cdr.CIDR()) {
-			state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
-			return composed.PatchStatus(state.ObjAsIpRange()).
-				SetExclusiveConditions(metav1.Condition{
-					Type:    cloudcontrolv1beta1.ConditionTypeError,
-					Status:  "True",
-					Reason:  cloudcontrolv1beta1.ReasonCidrOverlap,
-					Message: fmt.Sprintf("CIDR overlaps with VPC adress range cidr %s", ptr.Deref(set.CidrBlock, "")),
-				}).
-				ErrorLogMessage("Error patching KCP IpRange status due to cidr overlap").
-				SuccessLogMsg("Forgetting KCP IpRange due to cidr overlap").
-				Run(ctx, st)
+// suggestFreeCidr returns a CIDR of the same size as the overlapping range cidr that is free in the SKR
+// address space and the VPC, or empty if there is none
+func suggestFreeCidr(state *State, rangeCidr string) string {
+	_, ipNet, err := net.ParseCIDR(rangeCidr)
+	if err != nil {
+		return ""
+	}
+	maskSize, _ := ipNet.Mask.Size()
+
+	network := state.Scope().Spec.Scope.Aws.Network
+	existing := pie.Filter([]string{network.Nodes, network.Pods, network.Services}, func(s string) bool {
+		return len(s) > 0
+	})
+	if len(existing) == 0 {
+		return ""
+	}
+	for _, set := range state.vpc.CidrBlockAssociationSet {
+		existing = append(existing, ptr.Deref(set.CidrBlock, ""))
+	}
+	for _, c := range state.ObjAsIpRange().StatusCidrs() {
+		if c != rangeCidr {
+			existing = append(existing, c)
 		}
 	}
 
-	return nil, nil
+	suggested, err := iprangeallocate.AllocateCidr(maskSize, existing)
+	if err != nil {
+		return ""
+	}
+	return suggested
 }
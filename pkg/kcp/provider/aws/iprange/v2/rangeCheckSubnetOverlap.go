@@ -25,7 +25,9 @@ func rangeCheckSubnetOverlap(ctx context.Context, st composed.State) (error, con
 			continue
 		}
 
-		for _, r := range state.ObjAsIpRange().Status.Ranges {
+		// the reserved blocks must stay free for the future subnets as well
+		ranges := append(append([]string{}, state.ObjAsIpRange().Status.Ranges...), state.ObjAsIpRange().Status.ReservedCidrs...)
+		for _, r := range ranges {
 			rangeCidr, err := cidr.Parse(r)
 			if err != nil {
 				continue
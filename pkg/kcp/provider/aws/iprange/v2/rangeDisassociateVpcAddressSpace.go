@@ -14,49 +14,54 @@ func rangeDisassociateVpcAddressSpace(ctx context.Context, st composed.State) (e
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
 
-	var theBlock *ec2Types.VpcCidrBlockAssociation
-	for _, cidrBlock := range state.vpc.CidrBlockAssociationSet {
-		if ptr.Deref(cidrBlock.CidrBlock, "") == state.ObjAsIpRange().Status.Cidr {
-			theBlock = &cidrBlock
+	anyDisassociated := false
+	for _, rangeCidr := range state.ObjAsIpRange().StatusCidrs() {
+		theBlock := findVpcCidrBlock(state.vpc, rangeCidr)
+		if theBlock == nil {
+			continue
 		}
-	}
 
-	if theBlock == nil {
-		return nil, nil
-	}
+		if theBlock.CidrBlockState == nil {
+			logger.Info("VPC Cidr block without state")
+			continue
+		}
 
-	if theBlock.CidrBlockState == nil {
-		logger.Info("VPC Cidr block without state")
-		return nil, nil
-	}
+		logger := logger.WithValues(
+			"cidr", rangeCidr,
+			"cidrBlockState", theBlock.CidrBlockState.State,
+		)
+		ctx := composed.LoggerIntoCtx(ctx, logger)
 
-	logger = logger.WithValues("cidrBlockState", theBlock.CidrBlockState.State)
-	ctx = composed.LoggerIntoCtx(ctx, logger)
+		actMap := util.NewDelayActIgnoreBuilder[ec2Types.VpcCidrBlockStateCode](util.Ignore).
+			Delay(ec2Types.VpcCidrBlockStateCodeAssociating).
+			Act(ec2Types.VpcCidrBlockStateCodeAssociated).
+			Build()
 
-	actMap := util.NewDelayActIgnoreBuilder[ec2Types.VpcCidrBlockStateCode](util.Ignore).
-		Delay(ec2Types.VpcCidrBlockStateCodeAssociating).
-		Act(ec2Types.VpcCidrBlockStateCodeAssociated).
-		Build()
+		outcome := actMap.Case(theBlock.CidrBlockState.State)
 
-	outcome := actMap.Case(theBlock.CidrBlockState.State)
+		if outcome == util.Delay {
+			logger.Info("Waiting for VPC Cidr block state")
 
-	if outcome == util.Delay {
-		logger.Info("Waiting for VPC Cidr block state")
+			return composed.StopWithRequeueDelay(util.Timing.T1000ms()), ctx
+		}
 
-		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), ctx
-	}
+		if outcome == util.Ignore {
+			continue
+		}
 
-	if outcome == util.Ignore {
-		return nil, ctx
-	}
+		logger.Info("Disassociating VPC Cidr block")
 
-	logger.Info("Disassociating VPC Cidr block")
+		err := state.awsClient.DisassociateVpcCidrBlockInput(ctx, ptr.Deref(theBlock.AssociationId, ""))
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange after DisassociateVpcCidrBlock",
+			cloudcontrolv1beta1.ReasonUnknown, "Failed deleting VPC CIDR address block"); x != nil {
+			return x, nil
+		}
+		anyDisassociated = true
+	}
 
-	err := state.awsClient.DisassociateVpcCidrBlockInput(ctx, ptr.Deref(theBlock.AssociationId, ""))
-	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange after DisassociateVpcCidrBlock",
-		cloudcontrolv1beta1.ReasonUnknown, "Failed deleting VPC CIDR address block"); x != nil {
-		return x, nil
+	if anyDisassociated {
+		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), ctx
 	}
 
-	return composed.StopWithRequeueDelay(util.Timing.T1000ms()), ctx
+	return nil, ctx
 }
@@ -12,19 +12,31 @@ func rangeExtendVpcAddressSpace(ctx context.Context, st composed.State) (error,
 	logger := composed.LoggerFromCtx(ctx)
 	state := st.(*State)
 
-	if state.associatedCidrBlock != nil {
-		return nil, nil
-	}
+	anyAssociated := false
+	for i, rangeCidr := range state.ObjAsIpRange().StatusCidrs() {
+		if _, found := state.associatedCidrBlocks[rangeCidr]; found {
+			continue
+		}
+
+		logger.WithValues("cidr", rangeCidr).Info("Associating vpc cidr block")
 
-	logger.Info("Associating vpc cidr block")
+		block, err := state.awsClient.AssociateVpcCidrBlock(ctx, ptr.Deref(state.vpc.VpcId, ""), rangeCidr)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on associate vpc cidr block",
+			cloudcontrolv1beta1.ReasonFailedExtendingVpcAddressSpace, "Failed extending vpc address space"); x != nil {
+			return x, nil
+		}
 
-	block, err := state.awsClient.AssociateVpcCidrBlock(ctx, ptr.Deref(state.vpc.VpcId, ""), state.ObjAsIpRange().Status.Cidr)
-	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on associate vpc cidr block",
-		cloudcontrolv1beta1.ReasonFailedExtendingVpcAddressSpace, "Failed extending vpc address space"); x != nil {
-		return x, nil
+		// the address space id is kept for the first block only, as before multiple blocks were supported
+		if i == 0 {
+			state.ObjAsIpRange().Status.AddressSpaceId = ptr.Deref(block.AssociationId, "")
+			state.ObjAsIpRange().Status.SecondaryCidr = rangeCidr
+		}
+		anyAssociated = true
 	}
 
-	state.ObjAsIpRange().Status.AddressSpaceId = ptr.Deref(block.AssociationId, "")
+	if !anyAssociated {
+		return nil, nil
+	}
 
 	return composed.PatchStatus(state.ObjAsIpRange()).
 		SuccessErrorNil().
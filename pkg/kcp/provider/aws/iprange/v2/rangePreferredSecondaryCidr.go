@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rangeAllocateFromPreferredSecondaryCidr allocates the cidr from the spec.preferredSecondaryCidr VPC block,
+// avoiding the existing subnets in it and the cidrs of the other IpRanges of the scope, whose subnets might
+// not be created yet. If the block has no room left the cidr is not set, so it is
+// allocated from the SKR free address space by the common iprange flow. The block not associated with
+// the VPC is rejected with the InvalidPreferredSecondaryCidr reason.
+func rangeAllocateFromPreferredSecondaryCidr(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	ipRange := state.ObjAsIpRange()
+
+	preferred := ipRange.Spec.PreferredSecondaryCidr
+	if len(preferred) == 0 || len(ipRange.Status.Cidr) > 0 {
+		return nil, nil
+	}
+
+	block := findVpcCidrBlock(state.vpc, preferred)
+	if block == nil || block.CidrBlockState == nil || block.CidrBlockState.State != ec2Types.VpcCidrBlockStateCodeAssociated {
+		ipRange.Status.State = cloudcontrolv1beta1.ErrorState
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInvalidPreferredSecondaryCidr,
+				Message: fmt.Sprintf("Preferred secondary CIDR %s is not associated with the VPC %s", preferred, ptr.Deref(state.vpc.VpcId, "")),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with invalid preferred secondary cidr").
+			SuccessLogMsg("Forgetting KCP IpRange with invalid preferred secondary cidr").
+			Run(ctx, state)
+	}
+
+	var used []string
+	for _, subnet := range state.allSubnets {
+		if cidrContains(preferred, ptr.Deref(subnet.CidrBlock, "")) {
+			used = append(used, ptr.Deref(subnet.CidrBlock, ""))
+		}
+	}
+
+	ipRangeList := &cloudcontrolv1beta1.IpRangeList{}
+	if err := state.Cluster().K8sClient().List(ctx, ipRangeList, client.InNamespace(ipRange.Namespace)); err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing KCP IpRanges", composed.StopWithRequeue, ctx)
+	}
+	for _, other := range ipRangeList.Items {
+		if other.Name == ipRange.Name || other.Spec.Scope.Name != ipRange.Spec.Scope.Name {
+			continue
+		}
+		for _, c := range append([]string{other.Status.Cidr}, other.Status.Cidrs...) {
+			if cidrContains(preferred, c) && !pie.Contains(used, c) {
+				used = append(used, c)
+			}
+		}
+	}
+
+	rangeCidr, err := iprangeallocate.AllocateCidrFromPool(ipRange.CidrSizeOrDefault(iprangeallocate.DefaultMaskSize), []string{preferred}, used)
+	if err != nil {
+		logger.
+			WithValues(
+				"preferredSecondaryCidr", preferred,
+				"usedRanges", fmt.Sprintf("%v", used),
+			).
+			Info(fmt.Sprintf("Preferred secondary CIDR has no room left, falling back to the SKR free address space: %s", err))
+		return nil, nil
+	}
+
+	logger.
+		WithValues(
+			"preferredSecondaryCidr", preferred,
+			"cidr", rangeCidr,
+		).
+		Info("Allocated cidr from the preferred secondary CIDR")
+
+	ipRange.Status.Cidr = rangeCidr
+	ipRange.Status.SecondaryCidr = preferred
+
+	return composed.PatchStatus(ipRange).
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// findHostingCidrBlock returns the VPC block of the status secondaryCidr the range cidr was allocated from,
+// that is not associated for the range itself, or nil if the range is associated as its own block
+func findHostingCidrBlock(state *State, rangeCidr string) *ec2Types.VpcCidrBlockAssociation {
+	secondaryCidr := state.ObjAsIpRange().Status.SecondaryCidr
+	if len(secondaryCidr) == 0 || secondaryCidr == rangeCidr || !cidrContains(secondaryCidr, rangeCidr) {
+		return nil
+	}
+	return findVpcCidrBlock(state.vpc, secondaryCidr)
+}
+
+// cidrContains returns true if the inner cidr is within the outer cidr
+func cidrContains(outer, inner string) bool {
+	_, o, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false
+	}
+	_, i, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false
+	}
+	outerOnes, _ := o.Mask.Size()
+	innerOnes, _ := i.Mask.Size()
+	return outerOnes <= innerOnes && o.Contains(i.IP)
+}
@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestRangeAllocateFromPreferredSecondaryCidr(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	newIpRange := func(preferred string) *cloudcontrolv1beta1.IpRange {
+		return &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				PreferredSecondaryCidr: preferred,
+			},
+		}
+	}
+
+	newTestState := func(t *testing.T, ipRange *cloudcontrolv1beta1.IpRange, blockCidr string, subnetCidrs ...string) (*State, awsmock.Server) {
+		awsMock := awsmock.New()
+		vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+		_, err := awsMock.AssociateVpcCidrBlock(ctx, "vpc-1", "100.64.0.0/20")
+		assert.NoError(t, err)
+		_, err = awsMock.AssociateVpcCidrBlock(ctx, "vpc-1", blockCidr)
+		assert.NoError(t, err)
+		for _, c := range subnetCidrs {
+			_, err = awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", c, nil)
+			assert.NoError(t, err)
+		}
+		state := newIpamTestState(ipRange, awsMock)
+		state.vpc = vpc
+		err, _ = subnetsLoadAll(ctx, state)
+		assert.NoError(t, err)
+		return state, awsMock
+	}
+
+	t.Run("preferred available", func(t *testing.T) {
+		ipRange := newIpRange("100.96.0.0/16")
+		state, _ := newTestState(t, ipRange, "100.96.0.0/16", "100.96.0.0/24")
+
+		err, _ := rangeAllocateFromPreferredSecondaryCidr(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "100.96.4.0/22", ipRange.Status.Cidr)
+		assert.Equal(t, "100.96.0.0/16", ipRange.Status.SecondaryCidr)
+
+		// the allocated range is placed in the preferred block, without associating a block of its own
+		blocksBefore := len(state.vpc.CidrBlockAssociationSet)
+		err, _ = composed.ComposeActions(
+			"test",
+			rangeCheckOverlap,
+			rangeCheckBlockStatus,
+			rangeExtendVpcAddressSpace,
+		)(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Len(t, state.vpc.CidrBlockAssociationSet, blocksBefore)
+		assert.Equal(t, "100.96.0.0/16", ipRange.Status.SecondaryCidr)
+	})
+
+	t.Run("cidrs of other ranges are avoided", func(t *testing.T) {
+		ipRange := newIpRange("100.96.0.0/16")
+		state, _ := newTestState(t, ipRange, "100.96.0.0/16", "100.96.0.0/24")
+		// allocated from the preferred block, but its subnets are not created yet
+		other := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "other"},
+			Status:     cloudcontrolv1beta1.IpRangeStatus{Cidr: "100.96.4.0/22"},
+		}
+		assert.NoError(t, state.Cluster().K8sClient().Create(ctx, other))
+		// of other scope
+		otherScope := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "other-scope"},
+			Spec:       cloudcontrolv1beta1.IpRangeSpec{Scope: cloudcontrolv1beta1.ScopeRef{Name: "other"}},
+			Status:     cloudcontrolv1beta1.IpRangeStatus{Cidr: "100.96.8.0/22"},
+		}
+		assert.NoError(t, state.Cluster().K8sClient().Create(ctx, otherScope))
+
+		err, _ := rangeAllocateFromPreferredSecondaryCidr(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "100.96.8.0/22", ipRange.Status.Cidr)
+	})
+
+	t.Run("preferred full falls back", func(t *testing.T) {
+		ipRange := newIpRange("100.96.0.0/22")
+		state, _ := newTestState(t, ipRange, "100.96.0.0/22", "100.96.0.0/24")
+
+		err, _ := rangeAllocateFromPreferredSecondaryCidr(ctx, state)
+
+		assert.Nil(t, err)
+		assert.Empty(t, ipRange.Status.Cidr, "left for the allocation from the SKR free address space")
+		assert.Empty(t, ipRange.Status.SecondaryCidr)
+		assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+	})
+
+	t.Run("invalid preferred", func(t *testing.T) {
+		ipRange := newIpRange("100.80.0.0/16")
+		state, _ := newTestState(t, ipRange, "100.96.0.0/16")
+
+		err, _ := rangeAllocateFromPreferredSecondaryCidr(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Empty(t, ipRange.Status.Cidr)
+		assert.Equal(t, cloudcontrolv1beta1.ErrorState, ipRange.Status.State)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonInvalidPreferredSecondaryCidr, cond.Reason)
+	})
+}
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/3th1nk/cidr"
 	"github.com/elliotchance/pie/v2"
@@ -19,53 +20,55 @@ func rangeSplitByZones(ctx context.Context, st composed.State) (error, context.C
 		return nil, nil
 	}
 
-	logger = logger.WithValues("cidr", ipRangeObj.Status.Cidr)
+	logger = logger.WithValues("cidrs", ipRangeObj.StatusCidrs())
 
-	wholeRange, err := cidr.Parse(ipRangeObj.Status.Cidr)
-	if err != nil {
-		logger.Error(err, "error parsing KCP IpRange CIDR")
+	zones := state.zoneNames()
 
-		return composed.PatchStatus(state.ObjAsIpRange()).
-			SetExclusiveConditions(metav1.Condition{
-				Type:    cloudcontrolv1beta1.ConditionTypeError,
-				Status:  metav1.ConditionTrue,
-				Reason:  cloudcontrolv1beta1.ReasonInvalidCidr,
-				Message: "Can not parse CIDR",
-			}).
-			ErrorLogMessage("Error patching KCP IpRange status with can not parse CIDR condition").
-			SuccessLogMsg("Forgetting KCP IpRange with CIDR parse error").
-			SuccessLogMsg("Forgetting KCP IpRange with invalid CIDR").
-			Run(ctx, st)
-	}
+	strategy := getAllocationStrategy(ipRangeObj.Spec)
+	logger = logger.WithValues("allocationStrategy", strategy)
 
-	numberOfSubnets := 1
-	zoneCount := len(state.Scope().Spec.Scope.Aws.Network.Zones)
-	for numberOfSubnets < zoneCount {
-		numberOfSubnets = numberOfSubnets * 2
+	var allocation *CidrAllocation
+	var err error
+	allocator := GetCidrAllocator(strategy)
+	if allocator == nil {
+		err = &CidrAllocationError{
+			Reason:  cloudcontrolv1beta1.ReasonUnknownAllocationStrategy,
+			Message: fmt.Sprintf("Unknown allocation strategy %s", strategy),
+		}
+	} else {
+		allocation, err = allocator.Allocate(ipRangeObj.StatusCidrs(), zones, getCidrAllocatorOptions(ipRangeObj.Spec))
+	}
+	if err == nil && len(allocation.ZoneSubnets) != len(zones) {
+		err = fmt.Errorf("allocation strategy %s allocated %d zone subnets for %d zones", strategy, len(allocation.ZoneSubnets), len(zones))
 	}
-	subnetRanges, err := wholeRange.SubNetting(cidr.MethodSubnetNum, numberOfSubnets)
 	if err != nil {
-		logger.Error(err, "error splitting IpRange cidr")
+		allocationErr := &CidrAllocationError{Reason: cloudcontrolv1beta1.ReasonCidrCanNotSplit, Message: err.Error()}
+		errors.As(err, &allocationErr)
+		logger.Info(allocationErr.Message)
 
-		return composed.PatchStatus(state.ObjAsIpRange()).
+		return composed.PatchStatus(ipRangeObj).
 			SetExclusiveConditions(metav1.Condition{
 				Type:    cloudcontrolv1beta1.ConditionTypeError,
 				Status:  metav1.ConditionTrue,
-				Reason:  cloudcontrolv1beta1.ReasonCidrCanNotSplit,
-				Message: fmt.Sprintf("Can not split CIDR to %d subnets", numberOfSubnets),
+				Reason:  allocationErr.Reason,
+				Message: allocationErr.Message,
 			}).
 			ErrorLogMessage("Error patching KCP IpRange status after failed cidr splitting").
 			SuccessLogMsg("Forgetting KCP IpRange after failed cidr splitting").
 			Run(ctx, st)
 	}
-	subnetRanges = subnetRanges[:zoneCount]
 
-	state.ObjAsIpRange().Status.Ranges = pie.Map(subnetRanges, func(c *cidr.CIDR) string {
-		return c.CIDR().String()
+	ipRangeObj.Status.ZoneSubnets = allocation.ZoneSubnets
+	ipRangeObj.Status.ReservedCidrs = allocation.ReservedCidrs
+	ipRangeObj.Status.Ranges = pie.Map(allocation.ZoneSubnets, func(zs cloudcontrolv1beta1.IpRangeZoneSubnet) string {
+		return zs.Cidr
 	})
 
 	logger.
-		WithValues("ranges", state.ObjAsIpRange().Status.Ranges).
+		WithValues(
+			"zoneSubnets", ipRangeObj.Status.ZoneSubnets,
+			"reservedCidrs", ipRangeObj.Status.ReservedCidrs,
+		).
 		Info("IpRange CIDR split")
 
 	err = state.PatchObjStatus(ctx)
@@ -75,3 +78,84 @@ func rangeSplitByZones(ctx context.Context, st composed.State) (error, context.C
 
 	return nil, nil
 }
+
+// splitCidrsByZones splits the CIDR blocks to one range per zone. Zones are distributed across
+// the blocks as evenly as possible, in the blocks order, and each block is split evenly by the
+// number of its zones. Returns the error reason and message if blocks can not be split.
+func splitCidrsByZones(cidrs []string, zoneCount int) ([]string, string, string) {
+	ranges, _, reason, msg := splitCidrsByZonesWithReserve(cidrs, zoneCount, 0)
+	return ranges, reason, msg
+}
+
+// splitCidrsByZonesWithReserve splits the CIDR blocks like splitCidrsByZones, but when the reserved
+// denominator is not zero, the last 1/reservedDenominator of each block is reserved and returned
+// separately, and the zone ranges are split only from the rest of the block.
+func splitCidrsByZonesWithReserve(cidrs []string, zoneCount int, reservedDenominator int) ([]string, []string, string, string) {
+	if len(cidrs) == 0 {
+		return nil, nil, cloudcontrolv1beta1.ReasonInvalidCidr, "Can not parse CIDR"
+	}
+	if len(cidrs) > zoneCount {
+		return nil, nil, cloudcontrolv1beta1.ReasonCidrCanNotSplit, fmt.Sprintf("Can not split %d CIDR blocks to %d zones", len(cidrs), zoneCount)
+	}
+
+	var result []string
+	var reserved []string
+	for i, c := range cidrs {
+		blockZoneCount := zoneCount / len(cidrs)
+		if i < zoneCount%len(cidrs) {
+			blockZoneCount++
+		}
+
+		block, err := cidr.Parse(c)
+		if err != nil {
+			return nil, nil, cloudcontrolv1beta1.ReasonInvalidCidr, fmt.Sprintf("Can not parse CIDR %s", c)
+		}
+
+		if reservedDenominator > 0 {
+			reservedRanges, err := block.SubNetting(cidr.MethodSubnetNum, reservedDenominator)
+			if err != nil || len(reservedRanges) < reservedDenominator {
+				return nil, nil, cloudcontrolv1beta1.ReasonCidrCanNotSplit, fmt.Sprintf("Can not reserve 1/%d of CIDR %s", reservedDenominator, c)
+			}
+			reserved = append(reserved, reservedRanges[reservedDenominator-1].CIDR().String())
+		}
+
+		// the zone ranges are the first ones, so they must all fit before the reserved block
+		numberOfSubnets := 1
+		for !zoneRangesFit(blockZoneCount, numberOfSubnets, reservedDenominator) {
+			numberOfSubnets = numberOfSubnets * 2
+		}
+		subnetRanges, err := block.SubNetting(cidr.MethodSubnetNum, numberOfSubnets)
+		if err != nil || len(subnetRanges) < blockZoneCount {
+			return nil, nil, cloudcontrolv1beta1.ReasonCidrCanNotSplit, fmt.Sprintf("Can not split CIDR %s to %d subnets", c, numberOfSubnets)
+		}
+
+		result = append(result, pie.Map(subnetRanges[:blockZoneCount], func(c *cidr.CIDR) string {
+			return c.CIDR().String()
+		})...)
+	}
+
+	return result, reserved, "", ""
+}
+
+// zoneRangesFit returns true if the zone ranges, being the first of the block split to the given
+// number of subnets, do not overlap with the last 1/reservedDenominator of the block
+func zoneRangesFit(zoneCount, numberOfSubnets, reservedDenominator int) bool {
+	if reservedDenominator == 0 {
+		return zoneCount <= numberOfSubnets
+	}
+	return zoneCount*reservedDenominator <= numberOfSubnets*(reservedDenominator-1)
+}
+
+// reservedCidrDenominator returns the denominator of the spec reserved CIDR ratio, or zero if none is reserved
+func reservedCidrDenominator(ratio string) int {
+	switch ratio {
+	case "1/2":
+		return 2
+	case "1/4":
+		return 4
+	case "1/8":
+		return 8
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,189 @@
+package v2
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCidrsByZones(t *testing.T) {
+	testCases := []struct {
+		title          string
+		cidrs          []string
+		zoneCount      int
+		expectedRanges []string
+		expectedReason string
+	}{
+		{
+			"single block three zones",
+			[]string{"10.250.0.0/16"},
+			3,
+			[]string{"10.250.0.0/18", "10.250.64.0/18", "10.250.128.0/18"},
+			"",
+		},
+		{
+			"two blocks three zones",
+			[]string{"10.250.0.0/16", "10.251.0.0/17"},
+			3,
+			[]string{"10.250.0.0/17", "10.250.128.0/17", "10.251.0.0/17"},
+			"",
+		},
+		{
+			"two blocks two zones",
+			[]string{"10.250.0.0/24", "10.100.0.0/24"},
+			2,
+			[]string{"10.250.0.0/24", "10.100.0.0/24"},
+			"",
+		},
+		{
+			"two blocks four zones",
+			[]string{"10.250.0.0/24", "10.100.0.0/24"},
+			4,
+			[]string{"10.250.0.0/25", "10.250.0.128/25", "10.100.0.0/25", "10.100.0.128/25"},
+			"",
+		},
+		{
+			"more blocks than zones",
+			[]string{"10.250.0.0/24", "10.100.0.0/24"},
+			1,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+		{
+			"second block invalid",
+			[]string{"10.250.0.0/24", "foo"},
+			3,
+			nil,
+			cloudcontrolv1beta1.ReasonInvalidCidr,
+		},
+		{
+			"second block too small",
+			[]string{"10.250.0.0/16", "10.251.0.0/32"},
+			4,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+		{
+			"no blocks",
+			nil,
+			3,
+			nil,
+			cloudcontrolv1beta1.ReasonInvalidCidr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ranges, reason, _ := splitCidrsByZones(tc.cidrs, tc.zoneCount)
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedRanges, ranges)
+		})
+	}
+}
+
+func TestFindOverlappingCidrs(t *testing.T) {
+	a, b := findOverlappingCidrs([]string{"10.250.0.0/16", "10.251.0.0/16"})
+	assert.Empty(t, a)
+	assert.Empty(t, b)
+
+	a, b = findOverlappingCidrs([]string{"10.250.0.0/16", "10.100.0.0/16", "10.250.4.0/24"})
+	assert.Equal(t, "10.250.0.0/16", a)
+	assert.Equal(t, "10.250.4.0/24", b)
+
+	a, _ = findOverlappingCidrs([]string{"10.250.0.0/16"})
+	assert.Empty(t, a, "single block")
+}
+
+func TestIpRangeCidrsFallback(t *testing.T) {
+	ipRange := &cloudcontrolv1beta1.IpRange{}
+	assert.Nil(t, ipRange.SpecCidrs())
+	assert.Nil(t, ipRange.StatusCidrs())
+
+	ipRange.Spec.Cidr = "10.250.0.0/16"
+	ipRange.Status.Cidr = "10.250.0.0/16"
+	assert.Equal(t, []string{"10.250.0.0/16"}, ipRange.SpecCidrs(), "legacy single cidr")
+	assert.Equal(t, []string{"10.250.0.0/16"}, ipRange.StatusCidrs(), "legacy single cidr")
+
+	ipRange.Spec.Cidr = ""
+	ipRange.Spec.Cidrs = []string{"10.250.0.0/16", "10.251.0.0/16"}
+	ipRange.Status.Cidrs = []string{"10.250.0.0/16", "10.251.0.0/16"}
+	assert.Equal(t, ipRange.Spec.Cidrs, ipRange.SpecCidrs())
+	assert.Equal(t, ipRange.Status.Cidrs, ipRange.StatusCidrs())
+}
+
+func TestSplitCidrsByZonesWithReserve(t *testing.T) {
+	testCases := []struct {
+		title            string
+		cidrs            []string
+		zoneCount        int
+		ratio            string
+		expectedRanges   []string
+		expectedReserved []string
+		expectedReason   string
+	}{
+		{
+			"quarter reserved three zones",
+			[]string{"10.250.0.0/22"},
+			3,
+			"1/4",
+			[]string{"10.250.0.0/24", "10.250.1.0/24", "10.250.2.0/24"},
+			[]string{"10.250.3.0/24"},
+			"",
+		},
+		{
+			"half reserved three zones",
+			[]string{"10.250.0.0/22"},
+			3,
+			"1/2",
+			[]string{"10.250.0.0/25", "10.250.0.128/25", "10.250.1.0/25"},
+			[]string{"10.250.2.0/23"},
+			"",
+		},
+		{
+			"eighth reserved one zone",
+			[]string{"10.250.0.0/22"},
+			1,
+			"1/8",
+			[]string{"10.250.0.0/23"},
+			[]string{"10.250.3.128/25"},
+			"",
+		},
+		{
+			"reserved in each block",
+			[]string{"10.250.0.0/24", "10.100.0.0/24"},
+			2,
+			"1/2",
+			[]string{"10.250.0.0/25", "10.100.0.0/25"},
+			[]string{"10.250.0.128/25", "10.100.0.128/25"},
+			"",
+		},
+		{
+			"nothing reserved",
+			[]string{"10.250.0.0/16"},
+			3,
+			"",
+			[]string{"10.250.0.0/18", "10.250.64.0/18", "10.250.128.0/18"},
+			nil,
+			"",
+		},
+		{
+			"block too small to reserve",
+			[]string{"10.250.0.0/32"},
+			1,
+			"1/2",
+			nil,
+			nil,
+			cloudcontrolv1beta1.ReasonCidrCanNotSplit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			ranges, reserved, reason, _ := splitCidrsByZonesWithReserve(tc.cidrs, tc.zoneCount, reservedCidrDenominator(tc.ratio))
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedRanges, ranges)
+			assert.Equal(t, tc.expectedReserved, reserved)
+		})
+	}
+}
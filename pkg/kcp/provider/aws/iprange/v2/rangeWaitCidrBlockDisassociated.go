@@ -7,42 +7,42 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/ptr"
 )
 
 func rangeWaitCidrBlockDisassociated(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
 
-	var theBlock *ec2Types.VpcCidrBlockAssociation
-	for _, cidrBlock := range state.vpc.CidrBlockAssociationSet {
-		if ptr.Deref(cidrBlock.CidrBlock, "") == state.ObjAsIpRange().Status.Cidr {
-			theBlock = &cidrBlock
+	failed := false
+	for _, rangeCidr := range state.ObjAsIpRange().StatusCidrs() {
+		theBlock := findVpcCidrBlock(state.vpc, rangeCidr)
+		if theBlock == nil || theBlock.CidrBlockState == nil {
+			continue
 		}
-	}
 
-	if theBlock == nil || theBlock.CidrBlockState == nil {
-		return nil, nil
-	}
+		actMap := util.NewDelayActIgnoreBuilder[ec2Types.VpcCidrBlockStateCode](util.Ignore).
+			Delay(ec2Types.VpcCidrBlockStateCodeDisassociating).
+			Error(
+				ec2Types.VpcCidrBlockStateCodeFailing,
+				ec2Types.VpcCidrBlockStateCodeFailed,
+				ec2Types.VpcCidrBlockStateCodeAssociated,
+				ec2Types.VpcCidrBlockStateCodeAssociating,
+			).
+			Build()
+
+		outcome := actMap.Case(theBlock.CidrBlockState.State)
+
+		if outcome == util.Delay {
+			logger.WithValues("cidr", rangeCidr).Info("Waiting for VPC Cidr block to get disassociated")
+			return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+		}
 
-	actMap := util.NewDelayActIgnoreBuilder[ec2Types.VpcCidrBlockStateCode](util.Ignore).
-		Delay(ec2Types.VpcCidrBlockStateCodeDisassociating).
-		Error(
-			ec2Types.VpcCidrBlockStateCodeFailing,
-			ec2Types.VpcCidrBlockStateCodeFailed,
-			ec2Types.VpcCidrBlockStateCodeAssociated,
-			ec2Types.VpcCidrBlockStateCodeAssociating,
-		).
-		Build()
-
-	outcome := actMap.Case(theBlock.CidrBlockState.State)
-
-	if outcome == util.Delay {
-		logger.Info("Waiting for VPC Cidr block to get disassociated")
-		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+		if outcome != util.Ignore {
+			failed = true
+		}
 	}
 
-	if outcome == util.Ignore {
+	if !failed {
 		// all fine, it's disassociated
 		return nil, nil
 	}
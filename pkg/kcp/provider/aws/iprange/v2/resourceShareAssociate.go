@@ -0,0 +1,110 @@
+package v2
+
+import (
+	"context"
+	"reflect"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ramtypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+// resourceShareAssociate reconciles the subnets and accounts associated to the resource share,
+// and reports the share arn and accounts that accepted the share in the status
+func resourceShareAssociate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.resourceShare == nil {
+		return nil, nil
+	}
+	accounts := getShareWithAccounts(state.ObjAsIpRange())
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	arn := ptr.Deref(state.resourceShare.ResourceShareArn, "")
+
+	resourceAssociations, err := state.awsClient.GetResourceShareAssociations(ctx, arn, ramtypes.ResourceShareAssociationTypeResource)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load resource share resources",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS RAM resource share resources"); x != nil {
+		return x, nil
+	}
+	principalAssociations, err := state.awsClient.GetResourceShareAssociations(ctx, arn, ramtypes.ResourceShareAssociationTypePrincipal)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load resource share principals",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS RAM resource share principals"); x != nil {
+		return x, nil
+	}
+
+	desiredResources := pie.Map(state.cloudResourceSubnets, func(s ec2Types.Subnet) string {
+		return ptr.Deref(s.SubnetArn, "")
+	})
+	currentResources := associatedEntities(resourceAssociations)
+	currentPrincipals := associatedEntities(principalAssociations)
+
+	resourcesToAdd, resourcesToRemove := pie.Diff(currentResources, desiredResources)
+	principalsToAdd, principalsToRemove := pie.Diff(currentPrincipals, accounts)
+
+	if len(resourcesToRemove) > 0 || len(principalsToRemove) > 0 {
+		logger.
+			WithValues("resources", resourcesToRemove, "accounts", principalsToRemove).
+			Info("Disassociating from AWS RAM resource share")
+		err = state.awsClient.DisassociateResourceShare(ctx, arn, resourcesToRemove, principalsToRemove)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on disassociate resource share",
+			cloudcontrolv1beta1.ReasonResourceShareFailed, "Error disassociating AWS RAM resource share"); x != nil {
+			return x, nil
+		}
+	}
+	if len(resourcesToAdd) > 0 || len(principalsToAdd) > 0 {
+		logger.
+			WithValues("resources", resourcesToAdd, "accounts", principalsToAdd).
+			Info("Associating to AWS RAM resource share")
+		err = state.awsClient.AssociateResourceShare(ctx, arn, resourcesToAdd, principalsToAdd)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on associate resource share",
+			cloudcontrolv1beta1.ReasonResourceShareFailed, "Error associating AWS RAM resource share"); x != nil {
+			return x, nil
+		}
+	}
+	if len(resourcesToRemove)+len(principalsToRemove)+len(resourcesToAdd)+len(principalsToAdd) > 0 {
+		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+	}
+
+	acceptedAccounts := pie.Sort(pie.Map(
+		pie.Filter(principalAssociations, func(a ramtypes.ResourceShareAssociation) bool {
+			return a.Status == ramtypes.ResourceShareAssociationStatusAssociated
+		}),
+		func(a ramtypes.ResourceShareAssociation) string {
+			return ptr.Deref(a.AssociatedEntity, "")
+		},
+	))
+	resourceShareStatus := &cloudcontrolv1beta1.IpRangeResourceShare{
+		Arn:              arn,
+		AcceptedAccounts: acceptedAccounts,
+	}
+	if reflect.DeepEqual(state.ObjAsIpRange().Status.ResourceShare, resourceShareStatus) {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.ResourceShare = resourceShareStatus
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		ErrorLogMessage("Error patching KCP IpRange status with resource share").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+func associatedEntities(associations []ramtypes.ResourceShareAssociation) []string {
+	return pie.Map(
+		pie.Filter(associations, func(a ramtypes.ResourceShareAssociation) bool {
+			return a.Status == ramtypes.ResourceShareAssociationStatusAssociated ||
+				a.Status == ramtypes.ResourceShareAssociationStatusAssociating
+		}),
+		func(a ramtypes.ResourceShareAssociation) string {
+			return ptr.Deref(a.AssociatedEntity, "")
+		},
+	)
+}
@@ -0,0 +1,53 @@
+package v2
+
+import (
+	"context"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ramtypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+func resourceShareCreate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.resourceShare != nil {
+		return nil, nil
+	}
+	accounts := getShareWithAccounts(state.ObjAsIpRange())
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+
+	subnetArns := pie.Map(state.cloudResourceSubnets, func(s ec2Types.Subnet) string {
+		return ptr.Deref(s.SubnetArn, "")
+	})
+
+	name := getResourceShareName(state.ObjAsIpRange())
+	logger.WithValues("resourceShareName", name, "accounts", accounts).Info("Creating AWS RAM resource share")
+
+	tags := []ramtypes.Tag{
+		{
+			Key:   ptr.To(tagKey),
+			Value: ptr.To(state.Name().Name),
+		},
+	}
+	for _, t := range awsutil.Ec2TagsFromMap(state.tags) {
+		tags = append(tags, ramtypes.Tag{Key: t.Key, Value: t.Value})
+	}
+
+	resourceShare, err := state.awsClient.CreateResourceShare(ctx, name, subnetArns, accounts, tags)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on create resource share",
+		cloudcontrolv1beta1.ReasonResourceShareFailed, "Error creating AWS RAM resource share"); x != nil {
+		return x, nil
+	}
+
+	state.resourceShare = resourceShare
+
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package v2
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+func resourceShareDelete(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if len(getShareWithAccounts(state.ObjAsIpRange())) > 0 {
+		return nil, nil
+	}
+
+	if state.resourceShare != nil {
+		logger.Info("Deleting AWS RAM resource share")
+		err := state.awsClient.DeleteResourceShare(ctx, ptr.Deref(state.resourceShare.ResourceShareArn, ""))
+		if awsmeta.IsNotFound(err) {
+			err = nil
+		}
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on delete resource share",
+			cloudcontrolv1beta1.ReasonResourceShareFailed, "Error deleting AWS RAM resource share"); x != nil {
+			return x, nil
+		}
+		state.resourceShare = nil
+	}
+
+	if state.ObjAsIpRange().Status.ResourceShare == nil {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.ResourceShare = nil
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		ErrorLogMessage("Error patching KCP IpRange status after deleting resource share").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,36 @@
+package v2
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	"k8s.io/utils/ptr"
+)
+
+// resourceShareLoad loads the RAM resource share of the IpRange. The share is looked up only when the
+// IpRange shares its subnets or has the share in the status to clean up, so IpRanges that do not share
+// their subnets do not call AWS RAM at all.
+func resourceShareLoad(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if len(getShareWithAccounts(state.ObjAsIpRange())) == 0 && state.ObjAsIpRange().Status.ResourceShare == nil {
+		return nil, nil
+	}
+
+	resourceShare, err := state.awsClient.GetResourceShare(ctx, getResourceShareName(state.ObjAsIpRange()))
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load resource share",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS RAM resource share"); x != nil {
+		return x, nil
+	}
+	if resourceShare == nil {
+		return nil, nil
+	}
+
+	state.resourceShare = resourceShare
+
+	logger = logger.WithValues("resourceShareArn", ptr.Deref(resourceShare.ResourceShareArn, ""))
+
+	return nil, composed.LoggerIntoCtx(ctx, logger)
+}
@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resourceShareValidate checks the requested accounts against the configured allow-list.
+// Accounts outside the organization are rejected by AWS since external principals are
+// never allowed on the resource share.
+func resourceShareValidate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	accounts := getShareWithAccounts(state.ObjAsIpRange())
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+
+	notAllowed := getResourceShareNotAllowedAccounts(accounts, awsconfig.AwsConfig.ResourceShareAllowedAccounts)
+	if len(notAllowed) == 0 {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonResourceShareNotAllowed,
+			Message: fmt.Sprintf("Sharing with accounts %s is not allowed", strings.Join(notAllowed, ", ")),
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with not allowed resource share accounts").
+		SuccessLogMsg("Forgetting KCP IpRange with not allowed resource share accounts").
+		Run(ctx, state)
+}
+
+func getResourceShareNotAllowedAccounts(accounts, allowedAccounts []string) []string {
+	if len(allowedAccounts) == 0 {
+		return nil
+	}
+	return pie.Filter(accounts, func(account string) bool {
+		return !pie.Contains(allowedAccounts, account)
+	})
+}
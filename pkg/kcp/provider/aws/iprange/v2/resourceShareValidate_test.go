@@ -0,0 +1,16 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResourceShareNotAllowedAccounts(t *testing.T) {
+	assert.Empty(t, getResourceShareNotAllowedAccounts([]string{"111122223333"}, nil), "all allowed without allow-list")
+	assert.Empty(t, getResourceShareNotAllowedAccounts([]string{"111122223333"}, []string{"111122223333", "444455556666"}))
+	assert.Equal(t,
+		[]string{"777788889999"},
+		getResourceShareNotAllowedAccounts([]string{"111122223333", "777788889999"}, []string{"111122223333"}),
+	)
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ramtypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/elliotchance/pie/v2"
 	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	iprangetypes "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/types"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
@@ -17,9 +20,28 @@ type State struct {
 	awsClient iprangeclient.Client
 
 	vpc                  *ec2Types.Vpc
-	associatedCidrBlock  *ec2Types.VpcCidrBlockAssociation
+	associatedCidrBlocks map[string]*ec2Types.VpcCidrBlockAssociation
 	allSubnets           []ec2Types.Subnet
 	cloudResourceSubnets []ec2Types.Subnet
+	resourceShare        *ramtypes.ResourceShare
+	tgwAttachment        *ec2Types.TransitGatewayVpcAttachment
+	egressOnlyGateway    *ec2Types.EgressOnlyInternetGateway
+	tags                 map[string]string
+
+	// zones are the resolved spec availabilityZoneIds, and zoneIds their IDs by name
+	zones   []string
+	zoneIds map[string]string
+}
+
+// zoneNames returns the names of the zones the subnets are created in, the resolved spec
+// availabilityZoneIds if specified, or the Scope zones
+func (s *State) zoneNames() []string {
+	if len(s.zones) > 0 {
+		return s.zones
+	}
+	return pie.Map(s.Scope().Spec.Scope.Aws.Network.Zones, func(z cloudcontrolv1beta1.AwsZone) string {
+		return z.Name
+	})
 }
 
 type StateFactory interface {
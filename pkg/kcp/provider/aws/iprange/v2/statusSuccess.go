@@ -21,27 +21,45 @@ func statusSuccess(ctx context.Context, st composed.State) (error, context.Conte
 		changed = true
 	}
 
-	expectedSubnets := pie.Map(state.cloudResourceSubnets, func(s ec2Types.Subnet) cloudcontrolv1beta1.IpRangeSubnet {
+	expectedSubnets := pie.Map(zoneSubnets(state), func(s ec2Types.Subnet) cloudcontrolv1beta1.IpRangeSubnet {
 		return cloudcontrolv1beta1.IpRangeSubnet{
-			Id:    ptr.Deref(s.SubnetId, ""),
-			Zone:  ptr.Deref(s.AvailabilityZone, ""),
-			Range: ptr.Deref(s.CidrBlock, ""),
+			Id:                      ptr.Deref(s.SubnetId, ""),
+			Zone:                    ptr.Deref(s.AvailabilityZone, ""),
+			Range:                   ptr.Deref(s.CidrBlock, ""),
+			ZoneId:                  subnetZoneId(state, s),
+			AvailableIpAddressCount: ptr.Deref(s.AvailableIpAddressCount, 0),
 		}
 	})
+	if !composed.EnforcesDrift(state.ObjAsIpRange()) {
+		// the drifted subnets are kept, so they are not created again
+		expectedSubnets = append(expectedSubnets, subnetsDrifted(state)...)
+	}
 	if !state.ObjAsIpRange().Status.Subnets.Equals(expectedSubnets) {
 		state.ObjAsIpRange().Status.Subnets = expectedSubnets
 		changed = true
 	}
 
-	if len(state.ObjAsIpRange().Status.Conditions) != 1 {
-		changed = true
+	conditions := []metav1.Condition{
+		{
+			Type:    cloudcontrolv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReady,
+			Message: "Additional IpRange(s) are provisioned",
+		},
+	}
+	for _, conditionType := range []string{cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured, cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored, composed.ConditionTypeDriftDetected} {
+		if cond := meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, conditionType); cond != nil {
+			conditions = append(conditions, *cond)
+		}
 	}
 
-	cond := meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
-	if cond == nil {
+	if len(state.ObjAsIpRange().Status.Conditions) != len(conditions) {
 		changed = true
-	} else {
-		if cond.Status != metav1.ConditionTrue || cond.Reason != cloudcontrolv1beta1.ReasonReady {
+	}
+
+	for _, expected := range conditions {
+		cond := meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, expected.Type)
+		if cond == nil || cond.Status != expected.Status || cond.Reason != expected.Reason {
 			changed = true
 		}
 	}
@@ -51,12 +69,7 @@ func statusSuccess(ctx context.Context, st composed.State) (error, context.Conte
 	}
 
 	return composed.PatchStatus(state.ObjAsIpRange()).
-		SetExclusiveConditions(metav1.Condition{
-			Type:    cloudcontrolv1beta1.ConditionTypeReady,
-			Status:  metav1.ConditionTrue,
-			Reason:  cloudcontrolv1beta1.ReasonReady,
-			Message: "Additional IpRange(s) are provisioned",
-		}).
+		SetExclusiveConditions(conditions...).
 		ErrorLogMessage("Error patching KCP IpRange status with ready state").
 		SuccessLogMsg("Forgetting KCP IpRange with ready state").
 		Run(ctx, state)
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/elliotchance/pie/v2"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
 	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
@@ -30,8 +30,22 @@ func subnetsCreate(ctx context.Context, st composed.State) (error, context.Conte
 	}
 
 	zoneMap := make(map[string]interface{}, count)
-	for _, z := range state.Scope().Spec.Scope.Aws.Network.Zones {
-		zoneMap[z.Name] = nil
+	for _, z := range state.zoneNames() {
+		zoneMap[z] = nil
+	}
+
+	// the subnets created in the fallback zones stand for their original zones
+	fallbackZones := zoneFallbacksByFallbackZone(state.ObjAsIpRange())
+
+	// the drifted subnets are recreated only if the drift is enforced
+	if !composed.EnforcesDrift(state.ObjAsIpRange()) {
+		for _, drifted := range subnetsDrifted(state) {
+			delete(zoneMap, drifted.Zone)
+			if originalZone, ok := fallbackZones[drifted.Zone]; ok {
+				delete(zoneMap, originalZone)
+			}
+			delete(rangeMap, drifted.Range)
+		}
 	}
 
 	foundCount := 0
@@ -54,6 +68,11 @@ func subnetsCreate(ctx context.Context, st composed.State) (error, context.Conte
 			continue
 		}
 
+		// the supplementary subnet of the grown zone does not stand for the zone subnet
+		if isSupplementaryRange(state.ObjAsIpRange(), rangeValue) {
+			continue
+		}
+
 		logger.
 			WithValues(
 				"zone", zoneValue,
@@ -64,18 +83,43 @@ func subnetsCreate(ctx context.Context, st composed.State) (error, context.Conte
 			Info("Zone already exist")
 
 		delete(zoneMap, zoneValue)
+		if originalZone, ok := fallbackZones[zoneValue]; ok {
+			delete(zoneMap, originalZone)
+		}
 		delete(rangeMap, rangeValue)
 		foundCount++
 	}
 
 	indexMap := make(map[string]int, count)
-	for i, z := range state.Scope().Spec.Scope.Aws.Network.Zones {
-		indexMap[z.Name] = i
+	for i, z := range state.zoneNames() {
+		indexMap[z] = i
+	}
+
+	// pair the zones and ranges still missing a subnet, by the recorded zone subnets mapping if any,
+	// otherwise in the order of the zones and the ranges
+	zones := pie.Filter(state.zoneNames(), func(z string) bool {
+		_, ok := zoneMap[z]
+		return ok
+	})
+	ranges := pie.Filter(state.ObjAsIpRange().Status.Ranges, func(r string) bool {
+		_, ok := rangeMap[r]
+		return ok
+	})
+	if len(state.ObjAsIpRange().Status.ZoneSubnets) > 0 {
+		zones = nil
+		ranges = nil
+		for _, zs := range state.ObjAsIpRange().Status.ZoneSubnets {
+			_, zoneMissing := zoneMap[zs.Zone]
+			_, rangeMissing := rangeMap[zs.Cidr]
+			if zoneMissing && rangeMissing {
+				zones = append(zones, zs.Zone)
+				ranges = append(ranges, zs.Cidr)
+			}
+		}
 	}
 
 	anyCreated := false
-	zones := pie.Keys(zoneMap)
-	for i, rng := range pie.Keys(rangeMap) {
+	for i, rng := range ranges {
 		zn := zones[i]
 		logger := logger.
 			WithValues(
@@ -85,13 +129,27 @@ func subnetsCreate(ctx context.Context, st composed.State) (error, context.Conte
 		logger.Info("Creating subnet")
 
 		idx := indexMap[zn]
-		subnet, err := state.awsClient.CreateSubnet(ctx, aws.ToString(state.vpc.VpcId), zn, rng, awsutil.Ec2Tags(
-			"Name", fmt.Sprintf("%s-%d", state.ObjAsIpRange().Name, idx),
-			common.TagCloudManagerName, state.Name().String(),
-			common.TagCloudManagerRemoteName, state.ObjAsIpRange().Spec.RemoteRef.String(),
-			common.TagScope, state.ObjAsIpRange().Spec.Scope.Name,
-			tagKey, "1",
-		))
+		createSubnet := func(az string) (*ec2Types.Subnet, error) {
+			return state.awsClient.CreateSubnet(ctx, aws.ToString(state.vpc.VpcId), az, rng, awsutil.Ec2TagsFromMap(
+				state.tags,
+				"Name", fmt.Sprintf("%s-%d", state.ObjAsIpRange().Name, idx),
+				tagKey, "1",
+			))
+		}
+		az := zn
+		for fallbackZone, originalZone := range fallbackZones {
+			if originalZone == zn {
+				az = fallbackZone
+			}
+		}
+		subnet, err := createSubnet(az)
+		if isZoneCapacityError(err) && az == zn {
+			fallbackZone, x := subnetsZoneFallback(composed.LoggerIntoCtx(ctx, logger), state, zn, err)
+			if x != nil {
+				return x, nil
+			}
+			subnet, err = createSubnet(fallbackZone)
+		}
 		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on create subnet",
 			cloudcontrolv1beta1.ReasonUnknown, "Failed creating subnet"); x != nil {
 			return x, nil
@@ -101,12 +159,14 @@ func subnetsCreate(ctx context.Context, st composed.State) (error, context.Conte
 		logger.WithValues("subnetId", subnet.SubnetId).Info("Subnet created")
 
 		state.ObjAsIpRange().Status.Subnets = append(state.ObjAsIpRange().Status.Subnets, cloudcontrolv1beta1.IpRangeSubnet{
-			Id:    ptr.Deref(subnet.SubnetId, ""),
-			Zone:  ptr.Deref(subnet.AvailabilityZone, ""),
-			Range: ptr.Deref(subnet.CidrBlock, ""),
+			Id:     ptr.Deref(subnet.SubnetId, ""),
+			Zone:   ptr.Deref(subnet.AvailabilityZone, ""),
+			Range:  ptr.Deref(subnet.CidrBlock, ""),
+			ZoneId: subnetZoneId(state, *subnet),
 		})
 
 		x, _ := composed.PatchStatus(state.ObjAsIpRange()).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeZoneCapacityUnavailable).
 			SuccessErrorNil().
 			Run(ctx, state)
 		if x != nil {
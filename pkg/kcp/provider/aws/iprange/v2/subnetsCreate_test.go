@@ -0,0 +1,326 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type testIpRangeState struct {
+	focal.State
+	existingCidrRanges []string
+}
+
+func (s *testIpRangeState) ObjAsIpRange() *cloudcontrolv1beta1.IpRange {
+	return s.Obj().(*cloudcontrolv1beta1.IpRange)
+}
+
+func (s *testIpRangeState) ExistingCidrRanges() []string {
+	return s.existingCidrRanges
+}
+
+func (s *testIpRangeState) SetExistingCidrRanges(v []string) {
+	s.existingCidrRanges = v
+}
+
+func (s *testIpRangeState) Network() *cloudcontrolv1beta1.Network {
+	return nil
+}
+
+// failingAfterCreateSubnetClient creates the subnet but returns an error, like the call
+// failing after the subnet was created
+type failingAfterCreateSubnetClient struct {
+	iprangeclient.Client
+}
+
+func (c *failingAfterCreateSubnetClient) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2Types.Tag) (*ec2Types.Subnet, error) {
+	_, _ = c.Client.CreateSubnet(ctx, vpcId, az, cidr, tags)
+	return nil, &smithy.GenericAPIError{Code: "RequestLimitExceeded", Message: "simulated failure after subnet created"}
+}
+
+func TestSubnetsCreateNoDuplicateAfterFailure(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Ranges: []string{"10.250.8.0/22"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}},
+					},
+				},
+			},
+		},
+	})
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+
+	state := newState(&testIpRangeState{State: focalState}, &failingAfterCreateSubnetClient{Client: awsMock})
+	state.vpc = vpc
+
+	err, _ := subnetsCreate(ctx, state)
+	assert.Error(t, err)
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 1)
+	assert.Equal(t, "1", awsutil.GetEc2TagValue(subnets[0].Tags, tagKey), "subnet is tagged on creation")
+
+	// next reconcile finds the created subnet by its tag
+	state.cloudResourceSubnets = nil
+	err, _ = composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		subnetsCreate,
+	)(ctx, state)
+	assert.NoError(t, err)
+
+	subnets, err = awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 1, "no duplicate subnet is created")
+	assert.Len(t, state.cloudResourceSubnets, 1)
+	assert.Equal(t, ptr.Deref(subnets[0].SubnetId, ""), ptr.Deref(state.cloudResourceSubnets[0].SubnetId, ""))
+}
+
+func TestSubnetsReclaimUntaggedAfterTwoStepCreateFailure(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	// the former two-step creation created the subnet, but the separate CreateTags call failed
+	untagged, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/23", nil)
+	assert.NoError(t, err)
+	// subnet not of the IpRange ranges is left untouched
+	_, err = awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.0.0/22", nil)
+	assert.NoError(t, err)
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Ranges: []string{"10.250.8.0/23", "10.250.10.0/23"},
+		},
+	}
+	state := newIpamTestState(ipRange, awsMock)
+	state.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b"}},
+					},
+				},
+			},
+		},
+	})
+	state.vpc = vpc
+
+	err, _ = composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		subnetsReclaimUntagged,
+		rangeCheckSubnetOverlap,
+		subnetsCreate,
+	)(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err, "only the missing subnet is created")
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 3, "no duplicate subnet is created")
+	assert.Equal(t, 1, countSubnetsByCidr(subnets, "10.250.8.0/23"))
+	assert.Equal(t, 1, countSubnetsByCidr(subnets, "10.250.10.0/23"))
+	for _, s := range subnets {
+		if ptr.Deref(s.SubnetId, "") == ptr.Deref(untagged.SubnetId, "") {
+			assert.Equal(t, "1", awsutil.GetEc2TagValue(s.Tags, tagKey))
+			assert.Equal(t, "iprange-0", awsutil.GetEc2TagValue(s.Tags, "Name"))
+		}
+		if ptr.Deref(s.CidrBlock, "") == "10.250.0.0/22" {
+			assert.Empty(t, s.Tags)
+		}
+	}
+
+	assert.Len(t, ipRange.Status.Subnets, 2)
+	assert.Equal(t, ptr.Deref(untagged.SubnetId, ""), ipRange.Status.Subnets[0].Id)
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored)
+	assert.NotNil(t, cond)
+	assert.Equal(t, "Tagged untagged subnets "+ptr.Deref(untagged.SubnetId, ""), cond.Message)
+}
+
+func countSubnetsByCidr(subnets []ec2Types.Subnet, cidr string) int {
+	count := 0
+	for _, s := range subnets {
+		if ptr.Deref(s.CidrBlock, "") == cidr {
+			count++
+		}
+	}
+	return count
+}
+
+// zoneCapacityClient fails the subnet creation in the zone without capacity
+type zoneCapacityClient struct {
+	iprangeclient.Client
+	zone string
+}
+
+func (c *zoneCapacityClient) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tags []ec2Types.Tag) (*ec2Types.Subnet, error) {
+	if az == c.zone {
+		return nil, &smithy.GenericAPIError{Code: "InsufficientCapacity", Message: "simulated zone capacity error"}
+	}
+	return c.Client.CreateSubnet(ctx, vpcId, az, cidr, tags)
+}
+
+func newZoneCapacityState(allowZoneFallback bool) (*State, *cloudcontrolv1beta1.IpRange, awsmock.Server, *record.FakeRecorder) {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			AllowZoneFallback: allowZoneFallback,
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Ranges: []string{"10.250.8.0/23", "10.250.10.0/23"},
+			ZoneSubnets: []cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.8.0/23"},
+				{Zone: "eu-west-1b", Cidr: "10.250.10.0/23"},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	cluster := composed.NewStateCluster(clnt, clnt, recorder, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b"}},
+					},
+				},
+			},
+		},
+	})
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	awsMock.SetAvailabilityZones("eu-west-1c", "eu-west-1b", "eu-west-1a")
+
+	state := newState(&testIpRangeState{State: focalState}, &zoneCapacityClient{Client: awsMock, zone: "eu-west-1a"})
+	state.vpc = vpc
+	return state, ipRange, awsMock, recorder
+}
+
+func TestSubnetsCreateZoneFallback(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	state, ipRange, awsMock, recorder := newZoneCapacityState(true)
+
+	err, _ := subnetsCreate(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	zoneByRange := map[string]string{}
+	for _, subnet := range subnets {
+		zoneByRange[ptr.Deref(subnet.CidrBlock, "")] = ptr.Deref(subnet.AvailabilityZone, "")
+	}
+	assert.Equal(t, map[string]string{
+		"10.250.8.0/23":  "eu-west-1c",
+		"10.250.10.0/23": "eu-west-1b",
+	}, zoneByRange)
+
+	assert.Equal(t, []cloudcontrolv1beta1.IpRangeZoneFallback{{Zone: "eu-west-1a", FallbackZone: "eu-west-1c"}}, ipRange.Status.ZoneFallbacks)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Subnet of zone eu-west-1a is created in zone eu-west-1c")
+	assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeZoneCapacityUnavailable))
+
+	// the subnet in the fallback zone stands for its original zone, no other subnet is created
+	state.cloudResourceSubnets = nil
+	err, _ = composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		subnetsCreate,
+	)(ctx, state)
+	assert.NoError(t, err)
+
+	subnets, err = awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 2)
+}
+
+func TestSubnetsCreateZoneCapacityUnavailableWithoutFallback(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	state, ipRange, awsMock, recorder := newZoneCapacityState(false)
+
+	err, _ := subnetsCreate(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeZoneCapacityUnavailable)
+	assert.NotNil(t, cond)
+	assert.Equal(t, cloudcontrolv1beta1.ReasonZoneCapacityUnavailable, cond.Reason)
+	assert.Contains(t, cond.Message, "Zone eu-west-1a has no capacity for the subnet")
+	assert.Empty(t, ipRange.Status.ZoneFallbacks)
+	assert.Empty(t, recorder.Events)
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	for _, subnet := range subnets {
+		assert.NotEqual(t, "10.250.8.0/23", ptr.Deref(subnet.CidrBlock, ""))
+	}
+}
@@ -31,6 +31,9 @@ func subnetsDelete(ctx context.Context, st composed.State) (error, context.Conte
 		ccc := composed.LoggerIntoCtx(ctx, lll)
 
 		err := state.awsClient.DeleteSubnet(ctx, subnetId)
+		if isDependencyViolation(err) {
+			return subnetHasDependencies(ccc, state, subnetId)
+		}
 		if x := awserrorhandling.HandleError(ccc, err, state, "KCP IpRange on delete subnet",
 			cloudcontrolv1beta1.ReasonUnknown, "Error deleting AWS subnet"); x != nil {
 			return x, nil
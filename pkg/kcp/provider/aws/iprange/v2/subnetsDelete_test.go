@@ -0,0 +1,196 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSubnetsDeleteWaitsForLingeringNetworkInterface(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+
+	awsMock := awsmock.New()
+	awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	subnet, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/22", nil)
+	assert.NoError(t, err)
+	eniId := awsMock.AddNetworkInterface(ptr.Deref(subnet.SubnetId, ""), "EFS mount target")
+
+	state := newState(&testIpRangeState{State: focalState}, awsMock)
+	state.cloudResourceSubnets = append(state.cloudResourceSubnets, *subnet)
+
+	// the lingering network interface is reported, and the deletion is retried
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetHasDependencies)
+	assert.NotNil(t, cond)
+	assert.Contains(t, cond.Message, eniId+" (EFS mount target)")
+	assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 1)
+
+	// the longer it waits, the longer the delay
+	cond.LastTransitionTime = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T60000ms()), err)
+
+	// and the deletion fails after the timeout
+	cond = meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetHasDependencies)
+	cond.LastTransitionTime = metav1.NewTime(time.Now().Add(-subnetDependenciesTimeout - time.Minute))
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopAndForget, err)
+	errCond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(t, errCond)
+	assert.Equal(t, cloudcontrolv1beta1.ReasonSubnetDependenciesTimeout, errCond.Reason)
+
+	// once the network interface is gone the subnet is deleted
+	awsMock.RemoveNetworkInterface(eniId)
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+
+	subnets, err = awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Empty(t, subnets)
+}
+
+func TestSubnetsDeleteForceDeletesDependencies(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			ForceDelete: true,
+		},
+	}
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Status: cloudcontrolv1beta1.NfsInstanceStatus{
+			Id: "fs-owned",
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange, nfsInstance).
+		WithStatusSubresource(ipRange, nfsInstance).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	cluster := composed.NewStateCluster(clnt, clnt, recorder, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+
+	awsMock := awsmock.New()
+	awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	subnet, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/22", nil)
+	assert.NoError(t, err)
+	subnetId := ptr.Deref(subnet.SubnetId, "")
+
+	availableEniId := awsMock.AddNetworkInterface(subnetId, "detached interface")
+	awsMock.SetNetworkInterfaceStatus(availableEniId, ec2Types.NetworkInterfaceStatusAvailable)
+	inUseEniId := awsMock.AddNetworkInterface(subnetId, "instance interface")
+
+	staleMountTargetId, err := awsMock.CreateMountTarget(ctx, "fs-stale", subnetId, "10.250.8.10", nil)
+	assert.NoError(t, err)
+	staleMountTargetEniId := awsMock.AddNetworkInterface(subnetId, fmt.Sprintf("EFS mount target for fs-stale (%s)", staleMountTargetId))
+	ownedMountTargetId, err := awsMock.CreateMountTarget(ctx, "fs-owned", subnetId, "10.250.8.11", nil)
+	assert.NoError(t, err)
+	awsMock.AddNetworkInterface(subnetId, fmt.Sprintf("EFS mount target for fs-owned (%s)", ownedMountTargetId))
+
+	state := newState(&testIpRangeState{State: focalState}, awsMock)
+	state.cloudResourceSubnets = append(state.cloudResourceSubnets, *subnet)
+
+	// the available network interface and the stale mount target are deleted
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeForceDeleting)
+	assert.NotNil(t, cond)
+	assert.Contains(t, cond.Message, "network interface "+availableEniId)
+	assert.Contains(t, cond.Message, "mount target "+staleMountTargetId)
+	assert.Len(t, recorder.Events, 2)
+
+	networkInterfaces, err := awsMock.DescribeNetworkInterfaces(ctx, subnetId)
+	assert.NoError(t, err)
+	eniIds := make([]string, 0, len(networkInterfaces))
+	for _, x := range networkInterfaces {
+		eniIds = append(eniIds, ptr.Deref(x.NetworkInterfaceId, ""))
+	}
+	assert.NotContains(t, eniIds, availableEniId)
+	assert.Contains(t, eniIds, inUseEniId)
+
+	mountTargets, err := awsMock.DescribeMountTargets(ctx, "fs-stale")
+	assert.NoError(t, err)
+	assert.Empty(t, mountTargets)
+	mountTargets, err = awsMock.DescribeMountTargets(ctx, "fs-owned")
+	assert.NoError(t, err)
+	assert.Len(t, mountTargets, 1)
+
+	// once the stale mount target interface is released, the in use interfaces are only reported
+	awsMock.RemoveNetworkInterface(staleMountTargetEniId)
+	err, _ = subnetsDelete(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+
+	depsCond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetHasDependencies)
+	assert.NotNil(t, depsCond)
+	assert.Contains(t, depsCond.Message, inUseEniId)
+	assert.Len(t, recorder.Events, 2)
+
+	networkInterfaces, err = awsMock.DescribeNetworkInterfaces(ctx, subnetId)
+	assert.NoError(t, err)
+	assert.Len(t, networkInterfaces, 2)
+}
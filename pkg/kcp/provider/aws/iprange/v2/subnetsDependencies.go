@@ -0,0 +1,125 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// subnetDependenciesTimeout is how long the subnet deletion waits for the network interfaces
+// in the subnet to be deleted, before it is reported as failed
+const subnetDependenciesTimeout = 30 * time.Minute
+
+func isDependencyViolation(err error) bool {
+	apiErr := awsmeta.AsApiError(err)
+	return apiErr != nil && apiErr.ErrorCode() == "DependencyViolation"
+}
+
+// subnetHasDependencies handles the DependencyViolation error of the subnet deletion, usually caused by the
+// network interfaces of the deleted cloud resources lingering for a while. It lists the network interfaces
+// remaining in the subnet in the SubnetHasDependencies condition and requeues with a growing delay. Once
+// waiting longer than subnetDependenciesTimeout, the Error condition is set and the object is not requeued.
+// With the spec.forceDelete the dependencies safe to delete are deleted first, and the ForceDeleting
+// condition is set.
+func subnetHasDependencies(ctx context.Context, state *State, subnetId string) (error, context.Context) {
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsIpRange()
+
+	networkInterfaces, err := state.awsClient.DescribeNetworkInterfaces(ctx, subnetId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing network interfaces of the subnet with dependencies", ctx)
+	}
+
+	if obj.Spec.ForceDelete {
+		forced, err := subnetsForceDeleteDependencies(ctx, state, subnetId, networkInterfaces)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error force deleting dependencies of the subnet", ctx)
+		}
+		if len(forced) > 0 {
+			return composed.PatchStatus(obj).
+				SetCondition(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeForceDeleting,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonForceDeleting,
+					Message: fmt.Sprintf("Force deleted dependencies of subnet %s: %s", subnetId, strings.Join(forced, ", ")),
+				}).
+				ErrorLogMessage("Error patching KCP IpRange status with ForceDeleting condition").
+				SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+				Run(ctx, state)
+		}
+	}
+
+	dependencies := pie.Map(networkInterfaces, func(x ec2Types.NetworkInterface) string {
+		if description := ptr.Deref(x.Description, ""); len(description) > 0 {
+			return fmt.Sprintf("%s (%s)", ptr.Deref(x.NetworkInterfaceId, ""), description)
+		}
+		return ptr.Deref(x.NetworkInterfaceId, "")
+	})
+
+	logger.
+		WithValues("networkInterfaces", dependencies).
+		Info("Subnet can not be deleted while it has dependencies")
+
+	message := fmt.Sprintf("Subnet %s can not be deleted while it has dependencies", subnetId)
+	if len(dependencies) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(dependencies, ", "))
+	}
+
+	waitingSince := time.Now()
+	if cond := meta.FindStatusCondition(obj.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetHasDependencies); cond != nil {
+		waitingSince = cond.LastTransitionTime.Time
+	}
+	waiting := time.Since(waitingSince)
+
+	b := composed.PatchStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeSubnetHasDependencies,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonSubnetHasDependencies,
+			Message: message,
+		})
+
+	if waiting > subnetDependenciesTimeout {
+		obj.Status.State = cloudcontrolv1beta1.ErrorState
+		return b.
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReady).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonSubnetDependenciesTimeout,
+				Message: fmt.Sprintf("Timeout waiting for the dependencies of subnet %s to be deleted", subnetId),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status after timeout waiting for subnet dependencies").
+			SuccessLogMsg("Timeout waiting for subnet dependencies to be deleted").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	return b.
+		ErrorLogMessage("Error patching KCP IpRange status with SubnetHasDependencies condition").
+		SuccessError(composed.StopWithRequeueDelay(subnetDependenciesRequeueDelay(waiting))).
+		Run(ctx, state)
+}
+
+// subnetDependenciesRequeueDelay returns the requeue delay growing with the time waited
+func subnetDependenciesRequeueDelay(waiting time.Duration) time.Duration {
+	switch {
+	case waiting < time.Minute:
+		return util.Timing.T10000ms()
+	case waiting < 10*time.Minute:
+		return util.Timing.T60000ms()
+	default:
+		return util.Timing.T300000ms()
+	}
+}
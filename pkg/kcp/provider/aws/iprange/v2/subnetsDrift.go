@@ -0,0 +1,35 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/utils/ptr"
+)
+
+// subnetsDrifted returns the subnets recorded in the status that are not found by their ownership tag,
+// since they were deleted or lost the tag outside of cloud-manager
+func subnetsDrifted(state *State) []cloudcontrolv1beta1.IpRangeSubnet {
+	return pie.Filter(state.ObjAsIpRange().Status.Subnets, func(statusSubnet cloudcontrolv1beta1.IpRangeSubnet) bool {
+		return !pie.Any(state.cloudResourceSubnets, func(s ec2Types.Subnet) bool {
+			return ptr.Deref(s.SubnetId, "") == statusSubnet.Id
+		})
+	})
+}
+
+// subnetsDrift describes the drifted subnets for the composed.DriftGuard, the ones with the lost
+// tags are restored and the deleted ones are recreated only if the drift is enforced
+func subnetsDrift(_ context.Context, st composed.State) string {
+	drifted := subnetsDrifted(st.(*State))
+	if len(drifted) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Subnets %s were deleted or lost their tags", strings.Join(pie.Map(drifted, func(s cloudcontrolv1beta1.IpRangeSubnet) string {
+		return fmt.Sprintf("%s (%s %s)", s.Id, s.Zone, s.Range)
+	}), ", "))
+}
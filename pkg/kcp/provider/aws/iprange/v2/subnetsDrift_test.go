@@ -0,0 +1,123 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSubnetsDriftDetection(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	reconcile := composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		composed.DriftGuard(subnetsDrift, subnetsRestoreTags),
+		subnetsCreate,
+		statusSuccess,
+	)
+
+	// newDriftedState returns the state of the Ready IpRange whose subnet in the eu-west-1b zone was deleted externally
+	newDriftedState := func(t *testing.T, driftDetection string) (*State, *cloudcontrolv1beta1.IpRange, awsmock.Server) {
+		awsMock := awsmock.New()
+		vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+		subnetA, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/23", awsutil.Ec2Tags(tagKey, "1"))
+		assert.NoError(t, err)
+
+		ipRange := &cloudcontrolv1beta1.IpRange{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+			Spec: cloudcontrolv1beta1.IpRangeSpec{
+				DriftDetection: driftDetection,
+			},
+			Status: cloudcontrolv1beta1.IpRangeStatus{
+				State:  cloudcontrolv1beta1.ReadyState,
+				Ranges: []string{"10.250.8.0/23", "10.250.10.0/23"},
+				Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+					{Id: ptr.Deref(subnetA.SubnetId, ""), Zone: "eu-west-1a", Range: "10.250.8.0/23", ZoneId: "eu-west-1a"},
+					{Id: "subnet-deleted", Zone: "eu-west-1b", Range: "10.250.10.0/23", ZoneId: "eu-west-1b"},
+				},
+				Conditions: []metav1.Condition{{
+					Type:    cloudcontrolv1beta1.ConditionTypeReady,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonReady,
+					Message: "Additional IpRange(s) are provisioned",
+				}},
+			},
+		}
+		state := newIpamTestState(ipRange, awsMock)
+		state.State.(*testIpRangeState).SetScope(&cloudcontrolv1beta1.Scope{
+			Spec: cloudcontrolv1beta1.ScopeSpec{
+				Scope: cloudcontrolv1beta1.ScopeInfo{
+					Aws: &cloudcontrolv1beta1.AwsScope{
+						Network: cloudcontrolv1beta1.AwsNetwork{
+							Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b"}},
+						},
+					},
+				},
+			},
+		})
+		state.vpc = vpc
+		return state, ipRange, awsMock
+	}
+
+	subnetCount := func(t *testing.T, awsMock awsmock.Server) int {
+		subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+		assert.NoError(t, err)
+		return len(subnets)
+	}
+
+	t.Run("Enforce recreates the deleted subnet", func(t *testing.T) {
+		state, ipRange, awsMock := newDriftedState(t, composed.DriftDetectionEnforce)
+
+		_, _ = reconcile(ctx, state)
+		assert.Equal(t, 2, subnetCount(t, awsMock))
+
+		err, _ := reconcile(ctx, state)
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Len(t, ipRange.Status.Subnets, 2)
+		for _, subnet := range ipRange.Status.Subnets {
+			assert.NotEqual(t, "subnet-deleted", subnet.Id)
+		}
+		assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, composed.ConditionTypeDriftDetected))
+	})
+
+	t.Run("Detect reports the deleted subnet", func(t *testing.T) {
+		state, ipRange, awsMock := newDriftedState(t, composed.DriftDetectionDetect)
+
+		_, _ = reconcile(ctx, state)
+		assert.Equal(t, 1, subnetCount(t, awsMock))
+
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, composed.ConditionTypeDriftDetected)
+		assert.NotNil(t, cond)
+		assert.Contains(t, cond.Message, "subnet-deleted")
+		assert.NotNil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+		assert.Len(t, ipRange.Status.Subnets, 2)
+
+		// the drift is reconciled once enforced, and the condition removed
+		ipRange.Spec.DriftDetection = composed.DriftDetectionEnforce
+		assert.NoError(t, state.Cluster().K8sClient().Update(ctx, ipRange))
+		_, _ = reconcile(ctx, state)
+		assert.Equal(t, 2, subnetCount(t, awsMock))
+		assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, composed.ConditionTypeDriftDetected))
+	})
+
+	t.Run("Off ignores the deleted subnet", func(t *testing.T) {
+		state, ipRange, awsMock := newDriftedState(t, composed.DriftDetectionOff)
+
+		_, _ = reconcile(ctx, state)
+		assert.Equal(t, 1, subnetCount(t, awsMock))
+		assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, composed.ConditionTypeDriftDetected))
+		assert.Len(t, ipRange.Status.Subnets, 2)
+	})
+}
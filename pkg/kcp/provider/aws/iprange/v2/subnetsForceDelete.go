@@ -0,0 +1,110 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// mountTargetNetworkInterfaceDescription matches the description AWS gives to the network interface of the
+// EFS mount target, capturing the file system and the mount target ids
+var mountTargetNetworkInterfaceDescription = regexp.MustCompile(`^EFS mount target for (\S+) \((\S+)\)$`)
+
+// subnetsForceDeleteDependencies deletes the dependencies of the subnet that are safe to delete with the
+// IpRange spec.forceDelete: the detached network interfaces in the available state, and the mount targets
+// of the EFS file systems not owned by any KCP NfsInstance. The network interfaces in use are never touched,
+// except the ones of such stale mount targets, which are released by the mount target deletion. Each forced
+// deletion is reported as a warning event, and the descriptions of all of them are returned.
+func subnetsForceDeleteDependencies(ctx context.Context, state *State, subnetId string, networkInterfaces []ec2Types.NetworkInterface) ([]string, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsIpRange()
+
+	var ownedFileSystems map[string]struct{}
+	var forced []string
+
+	for _, networkInterface := range networkInterfaces {
+		networkInterfaceId := ptr.Deref(networkInterface.NetworkInterfaceId, "")
+
+		if networkInterface.Status == ec2Types.NetworkInterfaceStatusAvailable {
+			logger.
+				WithValues("networkInterfaceId", networkInterfaceId).
+				Info("Force deleting available network interface of the subnet")
+			err := state.awsClient.DeleteNetworkInterface(ctx, networkInterfaceId)
+			if awsmeta.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return forced, fmt.Errorf("error force deleting network interface %s: %w", networkInterfaceId, err)
+			}
+			forced = append(forced, fmt.Sprintf("network interface %s", networkInterfaceId))
+			subnetsForceDeleteEvent(state, obj, "Deleted available network interface %s of subnet %s", networkInterfaceId, subnetId)
+			continue
+		}
+
+		match := mountTargetNetworkInterfaceDescription.FindStringSubmatch(ptr.Deref(networkInterface.Description, ""))
+		if match == nil {
+			continue
+		}
+		fileSystemId, mountTargetId := match[1], match[2]
+
+		if ownedFileSystems == nil {
+			owned, err := subnetsForceDeleteOwnedFileSystems(ctx, state)
+			if err != nil {
+				return forced, err
+			}
+			ownedFileSystems = owned
+		}
+		if _, owned := ownedFileSystems[fileSystemId]; owned {
+			// the NfsInstance deletion deletes its mount targets
+			continue
+		}
+
+		logger.
+			WithValues(
+				"efsId", fileSystemId,
+				"mountTargetId", mountTargetId,
+			).
+			Info("Force deleting stale mount target of the subnet")
+		err := state.awsClient.DeleteMountTarget(ctx, mountTargetId)
+		if awsmeta.IsNotFound(err) {
+			// already deleted, its network interface is being released
+			continue
+		}
+		if err != nil {
+			return forced, fmt.Errorf("error force deleting mount target %s: %w", mountTargetId, err)
+		}
+		forced = append(forced, fmt.Sprintf("mount target %s", mountTargetId))
+		subnetsForceDeleteEvent(state, obj, "Deleted stale mount target %s of EFS %s in subnet %s", mountTargetId, fileSystemId, subnetId)
+	}
+
+	return forced, nil
+}
+
+// subnetsForceDeleteOwnedFileSystems returns the ids of the EFS file systems of the NfsInstances in the IpRange namespace
+func subnetsForceDeleteOwnedFileSystems(ctx context.Context, state *State) (map[string]struct{}, error) {
+	list := &cloudcontrolv1beta1.NfsInstanceList{}
+	if err := state.Cluster().K8sClient().List(ctx, list, client.InNamespace(state.ObjAsIpRange().Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing NfsInstances: %w", err)
+	}
+	result := make(map[string]struct{}, len(list.Items))
+	for _, nfsInstance := range list.Items {
+		if len(nfsInstance.Status.Id) > 0 {
+			result[nfsInstance.Status.Id] = struct{}{}
+		}
+	}
+	return result, nil
+}
+
+func subnetsForceDeleteEvent(state *State, obj *cloudcontrolv1beta1.IpRange, messageFmt string, args ...interface{}) {
+	if recorder := state.Cluster().EventRecorder(); recorder != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, cloudcontrolv1beta1.ReasonForceDeleting, messageFmt, args...)
+	}
+}
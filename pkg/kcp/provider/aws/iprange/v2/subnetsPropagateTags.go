@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+// subnetsPropagateTags reconciles the tags propagated from the Scope labels and default tags on the existing
+// subnets. The cloud-manager tags, and the Name and the subnet tag put on the created subnets, take precedence.
+var subnetsPropagateTags = actions.ReconcilePropagatedTags(
+	cloudcontrolv1beta1.ProviderAws,
+	func(st composed.State) []actions.TaggedResource {
+		var result []actions.TaggedResource
+		for _, subnet := range st.(*State).cloudResourceSubnets {
+			result = append(result, actions.TaggedResource{
+				Id:   ptr.Deref(subnet.SubnetId, ""),
+				Tags: awsutil.Ec2TagsToMap(subnet.Tags),
+			})
+		}
+		return result
+	},
+	nil,
+	func(st composed.State) map[string]string {
+		tags := getTags(st.(*State))
+		tags["Name"] = ""
+		tags[tagKey] = ""
+		return tags
+	},
+	func(ctx context.Context, st composed.State, id string, tags map[string]string) error {
+		return st.(*State).awsClient.TagSubnet(ctx, id, awsutil.Ec2TagsFromMap(tags))
+	},
+	func(ctx context.Context, st composed.State, id string, keys []string) error {
+		return st.(*State).awsClient.UntagSubnet(ctx, id, keys)
+	},
+)
@@ -0,0 +1,126 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSubnetsPropagateTags(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	common.SetPropagateLabelPrefix("tags.example.com/")
+	defer common.SetPropagateLabelPrefix("")
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	subnet, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/23", awsutil.Ec2Tags(tagKey, "1"))
+	assert.NoError(t, err)
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "default", Name: "my-range"},
+			Scope:     cloudcontrolv1beta1.ScopeRef{Name: "kyma"},
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+				{Id: ptr.Deref(subnet.SubnetId, ""), Zone: "eu-west-1a", Range: "10.250.8.0/23"},
+			},
+		},
+	}
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kcp-system",
+			Name:      "kyma",
+			Labels: map[string]string{
+				"tags.example.com/cost-center": "cc 1234",
+				"tags.example.com/team":        "networking",
+				"other.example.com/owner":      "someone",
+			},
+		},
+	}
+	state := newIpamTestState(ipRange, awsMock)
+	state.SetScope(scope)
+	state.vpc = vpc
+
+	reconcile := func() {
+		state.cloudResourceSubnets = nil
+		err, _ := composed.ComposeActions(
+			"subnets",
+			subnetsLoadAll,
+			subnetsFindCloudResources,
+			subnetsPropagateTags,
+		)(ctx, state)
+		assert.NoError(t, err)
+	}
+
+	getSubnetTags := func() map[string]string {
+		subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+		assert.NoError(t, err)
+		assert.Len(t, subnets, 1)
+		result := map[string]string{}
+		for _, tag := range subnets[0].Tags {
+			result[ptr.Deref(tag.Key, "")] = ptr.Deref(tag.Value, "")
+		}
+		return result
+	}
+
+	reconcile()
+
+	tags := getSubnetTags()
+	assert.Equal(t, "cc 1234", tags["cost-center"])
+	assert.Equal(t, "networking", tags["team"])
+	assert.NotContains(t, tags, "owner")
+	assert.NotContains(t, tags, "other.example.com/owner")
+	assert.Equal(t, "1", tags[tagKey])
+	assert.Equal(t, []string{"cost-center", "team"}, ipRange.Status.PropagatedTags)
+
+	// changed label value is updated and deleted label is removed from the subnet
+	scope.Labels["tags.example.com/cost-center"] = "cc-5678"
+	delete(scope.Labels, "tags.example.com/team")
+
+	reconcile()
+
+	tags = getSubnetTags()
+	assert.Equal(t, "cc-5678", tags["cost-center"])
+	assert.NotContains(t, tags, "team")
+	assert.Equal(t, "1", tags[tagKey])
+	assert.Equal(t, []string{"cost-center"}, ipRange.Status.PropagatedTags)
+
+	// default tags are added below the label tags and the cloud-manager tags
+	scope.Spec.DefaultTags = map[string]string{
+		"environment": "prod",
+		"cost-center": "cc-0000",
+		tagKey:        "overridden",
+	}
+
+	reconcile()
+
+	tags = getSubnetTags()
+	assert.Equal(t, "prod", tags["environment"])
+	assert.Equal(t, "cc-5678", tags["cost-center"])
+	assert.Equal(t, "1", tags[tagKey])
+	assert.Equal(t, []string{"cost-center", "environment"}, ipRange.Status.PropagatedTags)
+
+	// changed default tag is updated and deleted default tag is removed from the subnet
+	scope.Spec.DefaultTags = map[string]string{"owner": "platform"}
+
+	reconcile()
+
+	tags = getSubnetTags()
+	assert.Equal(t, "platform", tags["owner"])
+	assert.NotContains(t, tags, "environment")
+	assert.Equal(t, "cc-5678", tags["cost-center"])
+	assert.Equal(t, []string{"cost-center", "owner"}, ipRange.Status.PropagatedTags)
+}
@@ -0,0 +1,92 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// subnetsReclaimUntagged cleans up after the subnet creation that failed after the subnet was created
+// but before it was tagged, as with the former separate CreateTags call. Such subnet has no tags at all,
+// so it is not found by the tag based discovery, and it would make the range overlap check fail or the
+// subnet be created again. The subnet without any tag and with the cidr of one of the IpRange ranges is
+// tagged as created, and added to the cloud resource subnets and to the status.
+func subnetsReclaimUntagged(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	ipRange := state.ObjAsIpRange()
+
+	indexMap := make(map[string]int, len(state.zoneNames()))
+	for i, z := range state.zoneNames() {
+		indexMap[z] = i
+	}
+	fallbackZones := zoneFallbacksByFallbackZone(ipRange)
+
+	var reclaimed []string
+	for i, subnet := range state.allSubnets {
+		if len(subnet.Tags) > 0 || !pie.Contains(ipRange.Status.Ranges, ptr.Deref(subnet.CidrBlock, "")) {
+			continue
+		}
+		subnetId := ptr.Deref(subnet.SubnetId, "")
+		zone := ptr.Deref(subnet.AvailabilityZone, "")
+		if originalZone, ok := fallbackZones[zone]; ok {
+			zone = originalZone
+		}
+
+		logger.
+			WithValues(
+				"subnetId", subnetId,
+				"zone", ptr.Deref(subnet.AvailabilityZone, ""),
+				"range", ptr.Deref(subnet.CidrBlock, ""),
+			).
+			Info("Tagging the untagged subnet of the range left by the failed subnet creation")
+
+		tags := awsutil.Ec2TagsFromMap(
+			state.tags,
+			"Name", fmt.Sprintf("%s-%d", ipRange.Name, indexMap[zone]),
+			tagKey, "1",
+		)
+		err := state.awsClient.TagSubnet(ctx, subnetId, tags)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on tag untagged subnet",
+			cloudcontrolv1beta1.ReasonUnknown, "Failed tagging untagged subnet"); x != nil {
+			return x, nil
+		}
+
+		subnet.Tags = tags
+		state.allSubnets[i] = subnet
+		state.cloudResourceSubnets = append(state.cloudResourceSubnets, subnet)
+		if !pie.Any(ipRange.Status.Subnets, func(s cloudcontrolv1beta1.IpRangeSubnet) bool { return s.Id == subnetId }) {
+			ipRange.Status.Subnets = append(ipRange.Status.Subnets, cloudcontrolv1beta1.IpRangeSubnet{
+				Id:     subnetId,
+				Zone:   ptr.Deref(subnet.AvailabilityZone, ""),
+				Range:  ptr.Deref(subnet.CidrBlock, ""),
+				ZoneId: subnetZoneId(state, subnet),
+			})
+		}
+		reclaimed = append(reclaimed, subnetId)
+	}
+
+	if len(reclaimed) == 0 {
+		return nil, nil
+	}
+
+	return composed.PatchStatus(ipRange).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonSubnetTagsRestored,
+			Message: fmt.Sprintf("Tagged untagged subnets %s", strings.Join(reclaimed, ", ")),
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with tagged untagged subnets").
+		SuccessLogMsg("KCP IpRange untagged subnets tagged").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,46 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/ptr"
+)
+
+// subnetsReportMetrics sets the available IP addresses gauge of each IpRange subnet. The series of the
+// IpRange are replaced, so the ones of the subnets deleted in the meantime do not go stale.
+func subnetsReportMetrics(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	metrics.IpRangeSubnetAvailableIps.DeletePartialMatch(subnetMetricsLabels(state))
+	for _, subnet := range state.cloudResourceSubnets {
+		// the count is not known for the subnet just created, it is reported on the next reconcile
+		if subnet.AvailableIpAddressCount == nil {
+			continue
+		}
+		metrics.IpRangeSubnetAvailableIps.
+			WithLabelValues(
+				state.Name().Namespace,
+				state.Name().Name,
+				ptr.Deref(subnet.AvailabilityZone, ""),
+				ptr.Deref(subnet.SubnetId, ""),
+			).
+			Set(float64(*subnet.AvailableIpAddressCount))
+	}
+
+	return nil, nil
+}
+
+func subnetsDeleteMetrics(ctx context.Context, st composed.State) (error, context.Context) {
+	metrics.IpRangeSubnetAvailableIps.DeletePartialMatch(subnetMetricsLabels(st.(*State)))
+	return nil, nil
+}
+
+func subnetMetricsLabels(state *State) prometheus.Labels {
+	return prometheus.Labels{
+		"namespace": state.Name().Namespace,
+		"iprange":   state.Name().Name,
+	}
+}
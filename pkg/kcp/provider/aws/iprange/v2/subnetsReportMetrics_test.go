@@ -0,0 +1,70 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSubnetsReportMetrics(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	defer metrics.IpRangeSubnetAvailableIps.Reset()
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	subnetA, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/24", awsutil.Ec2Tags(tagKey, "1"))
+	assert.NoError(t, err)
+	subnetB, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1b", "10.250.9.0/24", awsutil.Ec2Tags(tagKey, "1"))
+	assert.NoError(t, err)
+	awsMock.AddNetworkInterface(ptr.Deref(subnetA.SubnetId, ""), "nfs mount target")
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+	}
+	state := newIpamTestState(ipRange, awsMock)
+	state.vpc = vpc
+
+	reconcile := func() {
+		err, _ := composed.ComposeActions(
+			"subnets",
+			subnetsLoadAll,
+			subnetsFindCloudResources,
+			subnetsReportMetrics,
+		)(ctx, state)
+		assert.NoError(t, err)
+	}
+
+	gauge := func(zone string, subnetId *string) float64 {
+		return testutil.ToFloat64(metrics.IpRangeSubnetAvailableIps.WithLabelValues("kcp-system", "iprange", zone, ptr.Deref(subnetId, "")))
+	}
+
+	reconcile()
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	for _, subnet := range subnets {
+		assert.Equal(t, float64(ptr.Deref(subnet.AvailableIpAddressCount, 0)), gauge(ptr.Deref(subnet.AvailabilityZone, ""), subnet.SubnetId))
+	}
+	assert.Equal(t, float64(250), gauge("eu-west-1a", subnetA.SubnetId))
+	assert.Equal(t, float64(251), gauge("eu-west-1b", subnetB.SubnetId))
+
+	// deleted subnet series is removed
+	assert.NoError(t, awsMock.DeleteSubnet(ctx, ptr.Deref(subnetB.SubnetId, "")))
+	reconcile()
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.IpRangeSubnetAvailableIps))
+
+	err, _ = subnetsDeleteMetrics(ctx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.IpRangeSubnetAvailableIps))
+}
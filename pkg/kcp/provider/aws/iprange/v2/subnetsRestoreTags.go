@@ -0,0 +1,89 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// subnetsRestoreTags is the fallback of the tag based subnet discovery. If it found fewer subnets
+// than recorded in the status, the missing ones are looked up by their ids, the lost ownership tags are
+// re-applied, and they are added to the cloud resource subnets so they are not created again.
+func subnetsRestoreTags(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	statusSubnets := state.ObjAsIpRange().Status.Subnets
+	if len(state.cloudResourceSubnets) >= len(statusSubnets) {
+		return nil, nil
+	}
+
+	var restored []string
+	for _, statusSubnet := range statusSubnets {
+		found := pie.Any(state.cloudResourceSubnets, func(s ec2Types.Subnet) bool {
+			return ptr.Deref(s.SubnetId, "") == statusSubnet.Id
+		})
+		if found {
+			continue
+		}
+		idx := pie.FindFirstUsing(state.allSubnets, func(s ec2Types.Subnet) bool {
+			return ptr.Deref(s.SubnetId, "") == statusSubnet.Id
+		})
+		if idx == -1 {
+			// subnet does not exist anymore
+			continue
+		}
+		subnet := state.allSubnets[idx]
+
+		logger.
+			WithValues(
+				"subnetId", statusSubnet.Id,
+				"zone", statusSubnet.Zone,
+				"range", statusSubnet.Range,
+			).
+			Info("Restoring lost tags of the subnet found by id")
+
+		tags := awsutil.Ec2TagsFromMap(getTags(state), tagKey, "1")
+		err := state.awsClient.TagSubnet(ctx, statusSubnet.Id, tags)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on restore subnet tags",
+			cloudcontrolv1beta1.ReasonUnknown, "Failed restoring subnet tags"); x != nil {
+			return x, nil
+		}
+
+		subnetTags := append([]ec2Types.Tag{}, subnet.Tags...)
+		for _, tag := range tags {
+			if !awsutil.HasEc2Tag(subnetTags, ptr.Deref(tag.Key, "")) {
+				subnetTags = append(subnetTags, tag)
+			}
+		}
+		subnet.Tags = subnetTags
+		state.allSubnets[idx] = subnet
+		state.cloudResourceSubnets = append(state.cloudResourceSubnets, subnet)
+		restored = append(restored, statusSubnet.Id)
+	}
+
+	if len(restored) == 0 {
+		return nil, nil
+	}
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonSubnetTagsRestored,
+			Message: fmt.Sprintf("Restored lost tags of subnets %s", strings.Join(restored, ", ")),
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with restored subnet tags").
+		SuccessLogMsg("KCP IpRange subnet tags restored").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSubnetsRestoreTags(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	// tags of the subnets are lost, only the ids are recorded in the status
+	subnetA, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.8.0/23", awsutil.Ec2Tags("Name", "iprange-0"))
+	assert.NoError(t, err)
+	subnetB, err := awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1b", "10.250.10.0/23", awsutil.Ec2Tags(tagKey, "1"))
+	assert.NoError(t, err)
+	_, err = awsMock.CreateSubnet(ctx, "vpc-1", "eu-west-1a", "10.250.0.0/22", nil)
+	assert.NoError(t, err)
+
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "default", Name: "my-range"},
+			Scope:     cloudcontrolv1beta1.ScopeRef{Name: "kyma"},
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+				{Id: ptr.Deref(subnetA.SubnetId, ""), Zone: "eu-west-1a", Range: "10.250.8.0/23"},
+				{Id: ptr.Deref(subnetB.SubnetId, ""), Zone: "eu-west-1b", Range: "10.250.10.0/23"},
+				{Id: "subnet-deleted", Zone: "eu-west-1c", Range: "10.250.12.0/23"},
+			},
+		},
+	}
+	state := newIpamTestState(ipRange, awsMock)
+	state.vpc = vpc
+
+	err, _ = composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		subnetsRestoreTags,
+	)(ctx, state)
+	assert.NoError(t, err)
+
+	assert.Len(t, state.cloudResourceSubnets, 2)
+	cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored)
+	assert.NotNil(t, cond)
+	assert.Equal(t, "Restored lost tags of subnets "+ptr.Deref(subnetA.SubnetId, ""), cond.Message)
+
+	subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 3)
+	for _, s := range subnets {
+		if ptr.Deref(s.SubnetId, "") != ptr.Deref(subnetA.SubnetId, "") {
+			continue
+		}
+		assert.Equal(t, "1", awsutil.GetEc2TagValue(s.Tags, tagKey))
+		assert.Equal(t, "kcp-system/iprange", awsutil.GetEc2TagValue(s.Tags, common.TagCloudManagerName))
+		assert.Equal(t, "iprange-0", awsutil.GetEc2TagValue(s.Tags, "Name"))
+	}
+
+	// the restored subnets are found by tag on the next reconcile
+	state.cloudResourceSubnets = nil
+	ipRange.Status.Conditions = nil
+	err, _ = composed.ComposeActions(
+		"subnets",
+		subnetsLoadAll,
+		subnetsFindCloudResources,
+		subnetsRestoreTags,
+	)(ctx, state)
+	assert.NoError(t, err)
+	assert.Len(t, state.cloudResourceSubnets, 2)
+	assert.Nil(t, meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeSubnetTagsRestored))
+}
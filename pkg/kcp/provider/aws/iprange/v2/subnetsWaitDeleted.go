@@ -10,11 +10,15 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// subnetPollResourceType is the resource type of the subnets deletion poll interval
+const subnetPollResourceType = "AwsSubnet"
+
 func subnetsWaitDeleted(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
 
 	if len(state.cloudResourceSubnets) == 0 {
+		composed.ResetPoll(state, subnetPollResourceType)
 		return nil, nil
 	}
 
@@ -33,5 +37,8 @@ func subnetsWaitDeleted(ctx context.Context, st composed.State) (error, context.
 		).
 		Info("Waiting for subnets to get deleted")
 
-	return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+	return composed.StopWithPollRequeue(state, subnetPollResourceType, composed.PollInterval{
+		Initial: util.Timing.T1000ms(),
+		Max:     util.Timing.T10000ms(),
+	}), nil
 }
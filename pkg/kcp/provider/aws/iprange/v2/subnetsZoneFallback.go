@@ -0,0 +1,103 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// zoneCapacityErrorCodes are the CreateSubnet error codes specific to the zone the subnet is created in
+var zoneCapacityErrorCodes = map[string]struct{}{
+	"InsufficientFreeAddressesInSubnet": {},
+	"InsufficientCapacity":              {},
+	"Unsupported":                       {},
+}
+
+func isZoneCapacityError(err error) bool {
+	apiErr := awsmeta.AsApiError(err)
+	if apiErr == nil {
+		return false
+	}
+	_, ok := zoneCapacityErrorCodes[apiErr.ErrorCode()]
+	return ok
+}
+
+// zoneFallbacksByFallbackZone returns the original zones by their fallback zone
+func zoneFallbacksByFallbackZone(ipRange *cloudcontrolv1beta1.IpRange) map[string]string {
+	result := make(map[string]string, len(ipRange.Status.ZoneFallbacks))
+	for _, zf := range ipRange.Status.ZoneFallbacks {
+		result[zf.FallbackZone] = zf.Zone
+	}
+	return result
+}
+
+// subnetsZoneFallback handles the zone capacity error of the subnet creation in the zone. With the
+// spec.allowZoneFallback it picks the first alternate available zone of the region that is neither used
+// by the Scope nor already a fallback zone, records the substitution in the status and returns it.
+// Otherwise, or if there is no alternate zone, the ZoneCapacityUnavailable condition is set and the
+// IpRange is requeued.
+func subnetsZoneFallback(ctx context.Context, state *State, zone string, createErr error) (string, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsIpRange()
+
+	if obj.Spec.AllowZoneFallback {
+		azs, err := state.awsClient.DescribeAvailabilityZones(ctx)
+		if err != nil {
+			x, _ := awsmeta.LogErrorAndReturn(err, "Error listing availability zones for the zone fallback", ctx)
+			return "", x
+		}
+		used := zoneFallbacksByFallbackZone(obj)
+		for _, z := range state.zoneNames() {
+			used[z] = z
+		}
+		zones := pie.Map(azs, func(az ec2Types.AvailabilityZone) string {
+			return ptr.Deref(az.ZoneName, "")
+		})
+		sort.Strings(zones)
+		for _, fallbackZone := range zones {
+			if _, ok := used[fallbackZone]; ok {
+				continue
+			}
+			logger.
+				WithValues("fallbackZone", fallbackZone).
+				Info("Zone capacity unavailable, falling back to alternate zone")
+			obj.Status.ZoneFallbacks = append(obj.Status.ZoneFallbacks, cloudcontrolv1beta1.IpRangeZoneFallback{
+				Zone:         zone,
+				FallbackZone: fallbackZone,
+			})
+			if recorder := state.Cluster().EventRecorder(); recorder != nil {
+				recorder.Eventf(obj, corev1.EventTypeWarning, cloudcontrolv1beta1.ReasonZoneFallback,
+					"Subnet of zone %s is created in zone %s: %s", zone, fallbackZone, awsmeta.GetErrorMessage(createErr))
+			}
+			return fallbackZone, nil
+		}
+	}
+
+	message := fmt.Sprintf("Zone %s has no capacity for the subnet: %s", zone, awsmeta.GetErrorMessage(createErr))
+	if obj.Spec.AllowZoneFallback {
+		message = fmt.Sprintf("%s, and no alternate zone is available", message)
+	}
+	logger.Info("Zone capacity unavailable for the subnet")
+
+	x, _ := composed.PatchStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeZoneCapacityUnavailable,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonZoneCapacityUnavailable,
+			Message: message,
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with ZoneCapacityUnavailable condition").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+		Run(ctx, state)
+	return "", x
+}
@@ -0,0 +1,50 @@
+package v2
+
+import (
+	"context"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+// tgwAttachmentCreate attaches the cloud resource subnets, one in each zone, to the requested transit gateway
+func tgwAttachmentCreate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	tgw := getTransitGateway(state.ObjAsIpRange())
+	if tgw == nil || state.tgwAttachment != nil {
+		return nil, nil
+	}
+
+	// the attachment takes only one subnet per zone
+	subnetIds := pie.Map(zoneSubnets(state), func(s ec2Types.Subnet) string {
+		return ptr.Deref(s.SubnetId, "")
+	})
+
+	logger.
+		WithValues("transitGatewayId", tgw.Id, "subnets", subnetIds).
+		Info("Creating AWS transit gateway attachment")
+
+	attachment, err := state.awsClient.CreateTransitGatewayVpcAttachment(ctx, tgw.Id, ptr.Deref(state.vpc.VpcId, ""), subnetIds, awsutil.Ec2TagsFromMap(
+		state.tags,
+		"Name", state.ObjAsIpRange().Name,
+		tagKey, state.Name().Name,
+	))
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on create transit gateway attachment",
+		cloudcontrolv1beta1.ReasonTgwAttachmentFailed, "Error creating AWS transit gateway attachment"); x != nil {
+		return x, nil
+	}
+
+	state.tgwAttachment = attachment
+	state.ObjAsIpRange().Status.TransitGatewayAttachmentId = ptr.Deref(attachment.TransitGatewayAttachmentId, "")
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		ErrorLogMessage("Error patching KCP IpRange status with transit gateway attachment id").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
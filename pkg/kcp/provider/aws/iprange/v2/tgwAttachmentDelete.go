@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"context"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+// tgwAttachmentDelete deletes the transit gateway attachment when it is no longer requested,
+// when it belongs to other transit gateway than requested, or when the IpRange is being deleted,
+// and waits until the attachment is gone
+func tgwAttachmentDelete(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	tgw := getTransitGateway(state.ObjAsIpRange())
+
+	if state.tgwAttachment != nil {
+		if tgw != nil && ptr.Deref(state.tgwAttachment.TransitGatewayId, "") == tgw.Id {
+			return nil, nil
+		}
+
+		if state.tgwAttachment.State == ec2Types.TransitGatewayAttachmentStateDeleting {
+			logger.Info("Waiting for AWS transit gateway attachment to get deleted")
+			return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+		}
+
+		logger.Info("Deleting AWS transit gateway attachment")
+		err := state.awsClient.DeleteTransitGatewayVpcAttachment(ctx, ptr.Deref(state.tgwAttachment.TransitGatewayAttachmentId, ""))
+		if awsmeta.IsNotFound(err) {
+			err = nil
+		}
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on delete transit gateway attachment",
+			cloudcontrolv1beta1.ReasonTgwAttachmentFailed, "Error deleting AWS transit gateway attachment"); x != nil {
+			return x, nil
+		}
+
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	if state.ObjAsIpRange().Status.TransitGatewayAttachmentId == "" {
+		return nil, nil
+	}
+
+	state.ObjAsIpRange().Status.TransitGatewayAttachmentId = ""
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeTgwAttachmentPending).
+		ErrorLogMessage("Error patching KCP IpRange status after deleting transit gateway attachment").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
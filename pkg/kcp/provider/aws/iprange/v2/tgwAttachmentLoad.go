@@ -0,0 +1,50 @@
+package v2
+
+import (
+	"context"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+// tgwAttachmentLoad loads the transit gateway attachment of the IpRange. The attachment is looked up only
+// when the transit gateway is specified or the attachment in the status is still to be cleaned up.
+func tgwAttachmentLoad(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.vpc == nil {
+		return nil, nil
+	}
+	if getTransitGateway(state.ObjAsIpRange()) == nil && state.ObjAsIpRange().Status.TransitGatewayAttachmentId == "" {
+		return nil, nil
+	}
+
+	attachments, err := state.awsClient.DescribeTransitGatewayVpcAttachments(ctx, ptr.Deref(state.vpc.VpcId, ""))
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load transit gateway attachments",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS transit gateway attachments"); x != nil {
+		return x, nil
+	}
+
+	for _, a := range attachments {
+		if a.State == ec2Types.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+		if awsutil.GetEc2TagValue(a.Tags, tagKey) != state.Name().Name {
+			continue
+		}
+		attachment := a
+		state.tgwAttachment = &attachment
+
+		logger = logger.WithValues(
+			"tgwAttachmentId", ptr.Deref(attachment.TransitGatewayAttachmentId, ""),
+			"tgwAttachmentState", attachment.State,
+		)
+		return nil, composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, nil
+}
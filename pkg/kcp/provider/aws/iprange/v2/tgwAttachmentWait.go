@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// tgwAttachmentWait waits for the transit gateway attachment to become available,
+// reporting the TgwAttachmentPending condition meanwhile
+func tgwAttachmentWait(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if getTransitGateway(state.ObjAsIpRange()) == nil || state.tgwAttachment == nil {
+		return nil, nil
+	}
+
+	attachmentId := ptr.Deref(state.tgwAttachment.TransitGatewayAttachmentId, "")
+	changed := false
+	if state.ObjAsIpRange().Status.TransitGatewayAttachmentId != attachmentId {
+		state.ObjAsIpRange().Status.TransitGatewayAttachmentId = attachmentId
+		changed = true
+	}
+
+	if isTgwAttachmentPending(state.tgwAttachment.State) {
+		logger.Info("Waiting for AWS transit gateway attachment to become available")
+		return composed.PatchStatus(state.ObjAsIpRange()).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeTgwAttachmentPending,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonTgwAttachmentPending,
+				Message: fmt.Sprintf("Transit gateway attachment %s is %s", attachmentId, state.tgwAttachment.State),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with pending transit gateway attachment").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+			Run(ctx, state)
+	}
+
+	if isTgwAttachmentFailed(state.tgwAttachment.State) {
+		state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+		return composed.PatchStatus(state.ObjAsIpRange()).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonTgwAttachmentFailed,
+				Message: fmt.Sprintf("Transit gateway attachment %s is %s", attachmentId, state.tgwAttachment.State),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with failed transit gateway attachment").
+			SuccessLogMsg("KCP IpRange transit gateway attachment failed").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+
+	if !changed && meta.FindStatusCondition(state.ObjAsIpRange().Status.Conditions, cloudcontrolv1beta1.ConditionTypeTgwAttachmentPending) == nil {
+		return nil, nil
+	}
+
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeTgwAttachmentPending).
+		ErrorLogMessage("Error patching KCP IpRange status with available transit gateway attachment").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,83 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// tgwRouteTable associates the transit gateway attachment with the requested transit gateway
+// route table and enables the propagation of the subnet routes to it
+func tgwRouteTable(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	tgw := getTransitGateway(state.ObjAsIpRange())
+	if tgw == nil || tgw.RouteTableId == "" || state.tgwAttachment == nil {
+		return nil, nil
+	}
+	attachmentId := ptr.Deref(state.tgwAttachment.TransitGatewayAttachmentId, "")
+
+	association, err := state.awsClient.GetTransitGatewayAttachmentAssociation(ctx, attachmentId)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load transit gateway attachment association",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS transit gateway attachment association"); x != nil {
+		return x, nil
+	}
+
+	if association == nil {
+		logger.WithValues("tgwRouteTableId", tgw.RouteTableId).Info("Associating AWS transit gateway attachment with route table")
+		err = state.awsClient.AssociateTransitGatewayRouteTable(ctx, tgw.RouteTableId, attachmentId)
+		if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on associate transit gateway route table",
+			cloudcontrolv1beta1.ReasonTgwAttachmentFailed, "Error associating AWS transit gateway route table"); x != nil {
+			return x, nil
+		}
+		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+	}
+
+	if ptr.Deref(association.TransitGatewayRouteTableId, "") != tgw.RouteTableId {
+		state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+		return composed.PatchStatus(state.ObjAsIpRange()).
+			SetExclusiveConditions(metav1.Condition{
+				Type:   cloudcontrolv1beta1.ConditionTypeError,
+				Status: metav1.ConditionTrue,
+				Reason: cloudcontrolv1beta1.ReasonTgwAttachmentFailed,
+				Message: fmt.Sprintf("Transit gateway attachment %s is associated with route table %s instead of %s",
+					attachmentId, ptr.Deref(association.TransitGatewayRouteTableId, ""), tgw.RouteTableId),
+			}).
+			ErrorLogMessage("Error patching KCP IpRange status with transit gateway route table mismatch").
+			SuccessLogMsg("KCP IpRange transit gateway attachment associated with other route table").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+
+	if association.State == ec2Types.TransitGatewayAssociationStateAssociating {
+		logger.Info("Waiting for AWS transit gateway route table association")
+		return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+	}
+
+	propagations, err := state.awsClient.GetTransitGatewayAttachmentPropagations(ctx, attachmentId)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load transit gateway attachment propagations",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS transit gateway attachment propagations"); x != nil {
+		return x, nil
+	}
+	for _, p := range propagations {
+		if ptr.Deref(p.TransitGatewayRouteTableId, "") == tgw.RouteTableId {
+			return nil, nil
+		}
+	}
+
+	logger.WithValues("tgwRouteTableId", tgw.RouteTableId).Info("Enabling AWS transit gateway route table propagation")
+	err = state.awsClient.EnableTransitGatewayRouteTablePropagation(ctx, tgw.RouteTableId, attachmentId)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on enable transit gateway route table propagation",
+		cloudcontrolv1beta1.ReasonTgwAttachmentFailed, "Error enabling AWS transit gateway route table propagation"); x != nil {
+		return x, nil
+	}
+
+	return nil, nil
+}
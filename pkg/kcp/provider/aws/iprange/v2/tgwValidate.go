@@ -0,0 +1,50 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tgwValidate checks the requested transit gateway is visible to the account, owned or shared
+// through AWS Resource Access Manager, and available for attachments
+func tgwValidate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	tgw := getTransitGateway(state.ObjAsIpRange())
+	if tgw == nil || state.tgwAttachment != nil {
+		return nil, nil
+	}
+
+	transitGateway, err := state.awsClient.DescribeTransitGateway(ctx, tgw.Id)
+	if x := awserrorhandling.HandleError(ctx, err, state, "KCP IpRange on load transit gateway",
+		cloudcontrolv1beta1.ReasonUnknown, "Error loading AWS transit gateway"); x != nil {
+		return x, nil
+	}
+	if transitGateway != nil && transitGateway.State == ec2Types.TransitGatewayStateAvailable {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("Transit gateway %s is not shared with the account", tgw.Id)
+	if transitGateway != nil {
+		message = fmt.Sprintf("Transit gateway %s is not available, its state is %s", tgw.Id, transitGateway.State)
+	}
+
+	state.ObjAsIpRange().Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(state.ObjAsIpRange()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonTgwNotAvailable,
+			Message: message,
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with not available transit gateway").
+		SuccessLogMsg("KCP IpRange transit gateway is not available").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+		Run(ctx, state)
+}
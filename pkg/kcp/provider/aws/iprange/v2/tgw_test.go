@@ -0,0 +1,32 @@
+package v2
+
+import (
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetTransitGateway(t *testing.T) {
+	ipRange := &cloudcontrolv1beta1.IpRange{}
+	assert.Nil(t, getTransitGateway(ipRange), "no aws options")
+
+	tgw := &cloudcontrolv1beta1.IpRangeAwsTransitGateway{Id: "tgw-0123456789abcdef0"}
+	ipRange.Spec.Options.Aws = &cloudcontrolv1beta1.IpRangeAws{TransitGateway: tgw}
+	assert.Equal(t, tgw, getTransitGateway(ipRange))
+
+	ipRange.DeletionTimestamp = ptr.To(metav1.Now())
+	assert.Nil(t, getTransitGateway(ipRange), "marked for deletion")
+}
+
+func TestTgwAttachmentState(t *testing.T) {
+	assert.True(t, isTgwAttachmentPending(ec2Types.TransitGatewayAttachmentStatePending))
+	assert.True(t, isTgwAttachmentPending(ec2Types.TransitGatewayAttachmentStatePendingAcceptance))
+	assert.False(t, isTgwAttachmentPending(ec2Types.TransitGatewayAttachmentStateAvailable))
+	assert.False(t, isTgwAttachmentFailed(ec2Types.TransitGatewayAttachmentStateAvailable))
+	assert.True(t, isTgwAttachmentFailed(ec2Types.TransitGatewayAttachmentStateFailed))
+	assert.True(t, isTgwAttachmentFailed(ec2Types.TransitGatewayAttachmentStateRejected))
+}
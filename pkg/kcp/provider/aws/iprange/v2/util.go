@@ -1,5 +1,188 @@
 package v2
 
+import (
+	"fmt"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
 const (
 	tagKey = "cloud-manager.kyma-project.io/iprange"
+
+	ipv6DefaultRoute = "::/0"
 )
+
+func getResourceShareName(ipRange *cloudcontrolv1beta1.IpRange) string {
+	return fmt.Sprintf("cm-%s", ipRange.Name)
+}
+
+// getShareWithAccounts returns the accounts the IpRange subnets should be shared with,
+// or nil if sharing is not requested or the IpRange is being deleted
+func getShareWithAccounts(ipRange *cloudcontrolv1beta1.IpRange) []string {
+	if composed.IsMarkedForDeletion(ipRange) {
+		return nil
+	}
+	if ipRange.Spec.Options.Aws == nil {
+		return nil
+	}
+	return ipRange.Spec.Options.Aws.ShareWithAccounts
+}
+
+// getTransitGateway returns the transit gateway the IpRange subnets should be attached to,
+// or nil if the attachment is not requested or the IpRange is being deleted
+func getTransitGateway(ipRange *cloudcontrolv1beta1.IpRange) *cloudcontrolv1beta1.IpRangeAwsTransitGateway {
+	if composed.IsMarkedForDeletion(ipRange) {
+		return nil
+	}
+	if ipRange.Spec.Options.Aws == nil {
+		return nil
+	}
+	return ipRange.Spec.Options.Aws.TransitGateway
+}
+
+func isTgwAttachmentPending(state ec2Types.TransitGatewayAttachmentState) bool {
+	switch state {
+	case ec2Types.TransitGatewayAttachmentStateInitiating,
+		ec2Types.TransitGatewayAttachmentStateInitiatingRequest,
+		ec2Types.TransitGatewayAttachmentStatePendingAcceptance,
+		ec2Types.TransitGatewayAttachmentStatePending,
+		ec2Types.TransitGatewayAttachmentStateModifying:
+		return true
+	}
+	return false
+}
+
+func isTgwAttachmentFailed(state ec2Types.TransitGatewayAttachmentState) bool {
+	switch state {
+	case ec2Types.TransitGatewayAttachmentStateFailing,
+		ec2Types.TransitGatewayAttachmentStateFailed,
+		ec2Types.TransitGatewayAttachmentStateRejecting,
+		ec2Types.TransitGatewayAttachmentStateRejected:
+		return true
+	}
+	return false
+}
+
+// getTags returns the tags put on all AWS resources created for the IpRange
+func getTags(state *State) map[string]string {
+	return map[string]string{
+		common.TagCloudManagerName:       state.Name().String(),
+		common.TagCloudManagerRemoteName: state.ObjAsIpRange().Spec.RemoteRef.String(),
+		common.TagScope:                  state.ObjAsIpRange().Spec.Scope.Name,
+	}
+}
+
+// getTagsWithPropagated returns the tags of the created cloud resources, the Scope label and default
+// tags included, with the cloud-manager tags taking precedence
+func getTagsWithPropagated(state *State) map[string]string {
+	return common.TagsWithPropagated(cloudcontrolv1beta1.ProviderAws, state.Scope(), nil, getTags(state))
+}
+
+// getIpv6Subnets returns the subnets with an associated IPv6 CIDR block
+func getIpv6Subnets(subnets []ec2Types.Subnet) []ec2Types.Subnet {
+	var result []ec2Types.Subnet
+	for _, subnet := range subnets {
+		for _, assoc := range subnet.Ipv6CidrBlockAssociationSet {
+			if assoc.Ipv6CidrBlockState != nil && assoc.Ipv6CidrBlockState.State == ec2Types.SubnetCidrBlockStateCodeAssociated {
+				result = append(result, subnet)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// isIpv6EgressRequested returns true if the IPv6 egress is requested and the IpRange is not being deleted
+func isIpv6EgressRequested(ipRange *cloudcontrolv1beta1.IpRange) bool {
+	if composed.IsMarkedForDeletion(ipRange) {
+		return false
+	}
+	return ipRange.Spec.Options.Aws != nil && ipRange.Spec.Options.Aws.Ipv6Egress
+}
+
+// isIpv6EgressRequired returns true if the IPv6 egress is requested and there are IPv6 subnets
+// to route it for, and the IpRange is not being deleted
+func isIpv6EgressRequired(state *State) bool {
+	if !isIpv6EgressRequested(state.ObjAsIpRange()) {
+		return false
+	}
+	return len(getIpv6Subnets(state.cloudResourceSubnets)) > 0
+}
+
+// getSubnetsRouteTableIds returns the ids of the route tables explicitly associated with the subnets and
+// owned by the IpRange, and the ids of the subnets using other route tables, either explicitly associated
+// ones not owned by the IpRange, or the VPC main route table. Only the owned route tables may be modified.
+func getSubnetsRouteTableIds(routeTables []ec2Types.RouteTable, subnets []ec2Types.Subnet, ipRangeName string) ([]string, []string) {
+	explicit := map[string]ec2Types.RouteTable{}
+	for _, rt := range routeTables {
+		for _, assoc := range rt.Associations {
+			if assoc.SubnetId != nil {
+				explicit[*assoc.SubnetId] = rt
+			}
+		}
+	}
+
+	var result []string
+	var notOwned []string
+	seen := map[string]struct{}{}
+	for _, subnet := range subnets {
+		subnetId := ptr.Deref(subnet.SubnetId, "")
+		rt, ok := explicit[subnetId]
+		if !ok || awsutil.GetEc2TagValue(rt.Tags, tagKey) != ipRangeName {
+			notOwned = append(notOwned, subnetId)
+			continue
+		}
+		rtId := ptr.Deref(rt.RouteTableId, "")
+		if _, dup := seen[rtId]; dup {
+			continue
+		}
+		seen[rtId] = struct{}{}
+		result = append(result, rtId)
+	}
+	return result, notOwned
+}
+
+// findIpv6DefaultRoute returns the ::/0 route of the route table, or nil if there is none
+func findIpv6DefaultRoute(routeTable ec2Types.RouteTable) *ec2Types.Route {
+	for _, r := range routeTable.Routes {
+		if ptr.Deref(r.DestinationIpv6CidrBlock, "") == ipv6DefaultRoute {
+			route := r
+			return &route
+		}
+	}
+	return nil
+}
+
+func ipv6EgressConfiguredCondition(egressOnlyGatewayId string) metav1.Condition {
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonIpv6EgressConfigured,
+		Message: fmt.Sprintf("IPv6 egress is routed through egress-only internet gateway %s", egressOnlyGatewayId),
+	}
+}
+
+func ipv6EgressNotConfiguredCondition(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeIpv6EgressConfigured,
+		Status:  metav1.ConditionFalse,
+		Reason:  cloudcontrolv1beta1.ReasonIpv6EgressFailed,
+		Message: message,
+	}
+}
+
+// findVpcCidrBlock returns the last VPC cidr block association of the given cidr, or nil if there's none
+func findVpcCidrBlock(vpc *ec2Types.Vpc, cidr string) *ec2Types.VpcCidrBlockAssociation {
+	var theBlock *ec2Types.VpcCidrBlockAssociation
+	for _, cidrBlock := range vpc.CidrBlockAssociationSet {
+		if ptr.Deref(cidrBlock.CidrBlock, "") == cidr {
+			theBlock = &cidrBlock
+		}
+	}
+	return theBlock
+}
@@ -0,0 +1,88 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// zoneSubnetsValidate checks the user supplied subnet CIDRs per zone, if any, are within the
+// IpRange cidr blocks, do not overlap, and cover all the Scope zones
+func zoneSubnetsValidate(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+
+	if len(ipRange.Spec.ZoneSubnets) == 0 || ipRange.Status.Ranges != nil {
+		return nil, nil
+	}
+
+	zones := state.zoneNames()
+
+	reason, msg := validateZoneSubnets(ipRange.StatusCidrs(), zones, ipRange.Spec.ZoneSubnets)
+	if len(reason) == 0 {
+		return nil, nil
+	}
+
+	ipRange.Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(ipRange).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: msg,
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with invalid zone subnets").
+		SuccessLogMsg("Forgetting KCP IpRange with invalid zone subnets").
+		Run(ctx, state)
+}
+
+// validateZoneSubnets returns the error reason and message if zone subnets are not valid, or empty strings if they are
+func validateZoneSubnets(parentCidrs []string, zones []string, zoneSubnets []cloudcontrolv1beta1.IpRangeZoneSubnet) (string, string) {
+	parents := make([]*net.IPNet, 0, len(parentCidrs))
+	for _, parentCidr := range parentCidrs {
+		_, parent, err := net.ParseCIDR(parentCidr)
+		if err != nil {
+			return cloudcontrolv1beta1.ReasonInvalidCidr, fmt.Sprintf("Can not parse CIDR %s", parentCidr)
+		}
+		parents = append(parents, parent)
+	}
+
+	specifiedZones := pie.Map(zoneSubnets, func(zs cloudcontrolv1beta1.IpRangeZoneSubnet) string {
+		return zs.Zone
+	})
+	if unknown, _ := pie.Diff(zones, specifiedZones); len(unknown) > 0 {
+		return cloudcontrolv1beta1.ReasonInvalidZoneSubnets, fmt.Sprintf("Zones %s are not in the Scope", strings.Join(unknown, ", "))
+	}
+	if missing, _ := pie.Diff(specifiedZones, zones); len(missing) > 0 {
+		return cloudcontrolv1beta1.ReasonIncompleteZoneSubnets, fmt.Sprintf("Zone subnets missing for zones %s", strings.Join(missing, ", "))
+	}
+
+	nets := make([]*net.IPNet, 0, len(zoneSubnets))
+	for _, zs := range zoneSubnets {
+		_, n, err := net.ParseCIDR(zs.Cidr)
+		if err != nil {
+			return cloudcontrolv1beta1.ReasonInvalidZoneSubnets, fmt.Sprintf("Can not parse CIDR %s of zone %s", zs.Cidr, zs.Zone)
+		}
+		if !pie.Any(parents, func(parent *net.IPNet) bool {
+			return util.CidrContains(parent, n)
+		}) {
+			return cloudcontrolv1beta1.ReasonInvalidZoneSubnets, fmt.Sprintf("CIDR %s of zone %s is not within %s", zs.Cidr, zs.Zone, strings.Join(parentCidrs, ", "))
+		}
+		for i, other := range nets {
+			if util.CidrOverlap(n, other) {
+				return cloudcontrolv1beta1.ReasonInvalidZoneSubnets, fmt.Sprintf("CIDR %s of zone %s overlaps with CIDR %s of zone %s",
+					zs.Cidr, zs.Zone, zoneSubnets[i].Cidr, zoneSubnets[i].Zone)
+			}
+		}
+		nets = append(nets, n)
+	}
+
+	return "", ""
+}
@@ -0,0 +1,105 @@
+package v2
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateZoneSubnets(t *testing.T) {
+	zones := []string{"eu-west-1a", "eu-west-1b"}
+
+	testCases := []struct {
+		title          string
+		zoneSubnets    []cloudcontrolv1beta1.IpRangeZoneSubnet
+		expectedReason string
+	}{
+		{
+			"valid",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+				{Zone: "eu-west-1b", Cidr: "10.250.4.0/22"},
+			},
+			"",
+		},
+		{
+			"missing zone",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+			},
+			cloudcontrolv1beta1.ReasonIncompleteZoneSubnets,
+		},
+		{
+			"unknown zone",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+				{Zone: "eu-west-1b", Cidr: "10.250.1.0/24"},
+				{Zone: "eu-west-1c", Cidr: "10.250.2.0/24"},
+			},
+			cloudcontrolv1beta1.ReasonInvalidZoneSubnets,
+		},
+		{
+			"outside parent range",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+				{Zone: "eu-west-1b", Cidr: "10.251.0.0/24"},
+			},
+			cloudcontrolv1beta1.ReasonInvalidZoneSubnets,
+		},
+		{
+			"larger than parent range",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+				{Zone: "eu-west-1b", Cidr: "10.250.0.0/15"},
+			},
+			cloudcontrolv1beta1.ReasonInvalidZoneSubnets,
+		},
+		{
+			"overlapping",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/22"},
+				{Zone: "eu-west-1b", Cidr: "10.250.1.0/24"},
+			},
+			cloudcontrolv1beta1.ReasonInvalidZoneSubnets,
+		},
+		{
+			"invalid cidr",
+			[]cloudcontrolv1beta1.IpRangeZoneSubnet{
+				{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+				{Zone: "eu-west-1b", Cidr: "foo"},
+			},
+			cloudcontrolv1beta1.ReasonInvalidZoneSubnets,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			reason, _ := validateZoneSubnets([]string{"10.250.0.0/16"}, zones, tc.zoneSubnets)
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
+
+func TestValidateZoneSubnetsTwoBlocks(t *testing.T) {
+	zones := []string{"eu-west-1a", "eu-west-1b"}
+	blocks := []string{"10.250.0.0/16", "10.100.0.0/24"}
+
+	reason, _ := validateZoneSubnets(blocks, zones, []cloudcontrolv1beta1.IpRangeZoneSubnet{
+		{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+		{Zone: "eu-west-1b", Cidr: "10.100.0.0/25"},
+	})
+	assert.Empty(t, reason, "each zone subnet within one of the blocks")
+
+	reason, _ = validateZoneSubnets(blocks, zones, []cloudcontrolv1beta1.IpRangeZoneSubnet{
+		{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+		{Zone: "eu-west-1b", Cidr: "10.100.1.0/25"},
+	})
+	assert.Equal(t, cloudcontrolv1beta1.ReasonInvalidZoneSubnets, reason, "zone subnet outside of all blocks")
+
+	reason, _ = validateZoneSubnets([]string{"10.250.0.0/16", "foo"}, zones, []cloudcontrolv1beta1.IpRangeZoneSubnet{
+		{Zone: "eu-west-1a", Cidr: "10.250.0.0/24"},
+		{Zone: "eu-west-1b", Cidr: "10.250.1.0/24"},
+	})
+	assert.Equal(t, cloudcontrolv1beta1.ReasonInvalidCidr, reason, "invalid block")
+}
@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// zonesResolve resolves the spec availabilityZoneIds to the zone names in the account, used instead of
+// the Scope zones for the subnets. The IpRange is forgotten with the InvalidAvailabilityZoneIds reason
+// if an ID does not exist in the region, or the IDs do not match the number of the Scope zones.
+func zonesResolve(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	ipRange := state.ObjAsIpRange()
+
+	if len(ipRange.Spec.AvailabilityZoneIds) == 0 || composed.IsMarkedForDeletion(ipRange) {
+		return nil, nil
+	}
+
+	azs, err := state.awsClient.DescribeAvailabilityZones(ctx)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing availability zones to resolve the zone IDs", ctx)
+	}
+	mapping := newZoneMapping(azs)
+
+	names, unknown := mapping.names(ipRange.Spec.AvailabilityZoneIds)
+	var msg string
+	if len(unknown) > 0 {
+		msg = fmt.Sprintf("Availability zone IDs %s do not exist in region %s", strings.Join(unknown, ", "), state.Scope().Spec.Region)
+	} else if scopeZoneCount := len(state.Scope().Spec.Scope.Aws.Network.Zones); len(names) != scopeZoneCount {
+		msg = fmt.Sprintf("%d availability zone IDs specified for %d Scope zones", len(names), scopeZoneCount)
+	}
+	if msg == "" {
+		state.zones = names
+		state.zoneIds = mapping.idByName
+		return nil, nil
+	}
+
+	ipRange.Status.State = cloudcontrolv1beta1.ErrorState
+	return composed.PatchStatus(ipRange).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonInvalidAvailabilityZoneIds,
+			Message: msg,
+		}).
+		ErrorLogMessage("Error patching KCP IpRange status with invalid availability zone IDs").
+		SuccessLogMsg("Forgetting KCP IpRange with invalid availability zone IDs").
+		Run(ctx, state)
+}
+
+// subnetZoneId returns the zone ID of the subnet, or of its zone if the subnet does not have it
+func subnetZoneId(state *State, subnet ec2Types.Subnet) string {
+	if id := ptr.Deref(subnet.AvailabilityZoneId, ""); id != "" {
+		return id
+	}
+	return state.zoneIds[ptr.Deref(subnet.AvailabilityZone, "")]
+}
+
+// zoneMapping maps the availability zone names of the account to the zone IDs, and back
+type zoneMapping struct {
+	idByName map[string]string
+	nameById map[string]string
+}
+
+func newZoneMapping(azs []ec2Types.AvailabilityZone) *zoneMapping {
+	m := &zoneMapping{
+		idByName: make(map[string]string, len(azs)),
+		nameById: make(map[string]string, len(azs)),
+	}
+	for _, az := range azs {
+		name := ptr.Deref(az.ZoneName, "")
+		id := ptr.Deref(az.ZoneId, "")
+		if name == "" || id == "" {
+			continue
+		}
+		m.idByName[name] = id
+		m.nameById[id] = name
+	}
+	return m
+}
+
+// names returns the zone names of the IDs, in the same order, and the IDs that do not exist
+func (m *zoneMapping) names(ids []string) ([]string, []string) {
+	return translateZones(ids, m.nameById)
+}
+
+// ids returns the zone IDs of the names, in the same order, and the names that do not exist
+func (m *zoneMapping) ids(names []string) ([]string, []string) {
+	return translateZones(names, m.idByName)
+}
+
+func translateZones(values []string, mapping map[string]string) ([]string, []string) {
+	var unknown []string
+	result := pie.Map(values, func(v string) string {
+		translated, ok := mapping[v]
+		if !ok {
+			unknown = append(unknown, v)
+		}
+		return translated
+	})
+	return result, unknown
+}
@@ -0,0 +1,170 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestZoneMapping(t *testing.T) {
+	mapping := newZoneMapping([]ec2Types.AvailabilityZone{
+		{ZoneName: ptr.To("eu-west-1a"), ZoneId: ptr.To("euw1-az3")},
+		{ZoneName: ptr.To("eu-west-1b"), ZoneId: ptr.To("euw1-az1")},
+		{ZoneName: ptr.To("eu-west-1c"), ZoneId: ptr.To("euw1-az2")},
+	})
+
+	t.Run("ids to names", func(t *testing.T) {
+		names, unknown := mapping.names([]string{"euw1-az1", "euw1-az2"})
+		assert.Equal(t, []string{"eu-west-1b", "eu-west-1c"}, names)
+		assert.Empty(t, unknown)
+
+		_, unknown = mapping.names([]string{"euw1-az1", "use1-az1"})
+		assert.Equal(t, []string{"use1-az1"}, unknown)
+	})
+
+	t.Run("names to ids", func(t *testing.T) {
+		ids, unknown := mapping.ids([]string{"eu-west-1a", "eu-west-1c"})
+		assert.Equal(t, []string{"euw1-az3", "euw1-az2"}, ids)
+		assert.Empty(t, unknown)
+
+		_, unknown = mapping.ids([]string{"eu-west-1d"})
+		assert.Equal(t, []string{"eu-west-1d"}, unknown)
+	})
+}
+
+func newZonesResolveState(availabilityZoneIds ...string) (*State, *cloudcontrolv1beta1.IpRange, awsmock.Server) {
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "iprange"},
+		Spec: cloudcontrolv1beta1.IpRangeSpec{
+			AvailabilityZoneIds: availabilityZoneIds,
+		},
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Ranges: []string{"10.250.8.0/23", "10.250.10.0/23"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Region: "eu-west-1",
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						Zones: []cloudcontrolv1beta1.AwsZone{{Name: "eu-west-1a"}, {Name: "eu-west-1b"}},
+					},
+				},
+			},
+		},
+	})
+
+	awsMock := awsmock.New()
+	vpc := awsMock.AddVpc("vpc-1", "10.250.0.0/16", nil, nil)
+	awsMock.SetAvailabilityZones("eu-west-1a", "eu-west-1b", "eu-west-1c")
+	awsMock.SetAvailabilityZoneIds(map[string]string{
+		"eu-west-1a": "euw1-az3",
+		"eu-west-1b": "euw1-az1",
+		"eu-west-1c": "euw1-az2",
+	})
+
+	state := newState(&testIpRangeState{State: focalState}, awsMock)
+	state.vpc = vpc
+	return state, ipRange, awsMock
+}
+
+func TestZonesResolve(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	t.Run("subnets are created in the zones of the ids", func(t *testing.T) {
+		state, ipRange, awsMock := newZonesResolveState("euw1-az1", "euw1-az2")
+
+		err, _ := composed.ComposeActions(
+			"zones",
+			zonesResolve,
+			subnetsCreate,
+		)(ctx, state)
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+		assert.Equal(t, []string{"eu-west-1b", "eu-west-1c"}, state.zoneNames())
+
+		subnets, err := awsMock.DescribeSubnets(ctx, "vpc-1")
+		assert.NoError(t, err)
+		zoneByRange := map[string]string{}
+		for _, subnet := range subnets {
+			zoneByRange[ptr.Deref(subnet.CidrBlock, "")] = ptr.Deref(subnet.AvailabilityZone, "")
+		}
+		assert.Equal(t, map[string]string{
+			"10.250.8.0/23":  "eu-west-1b",
+			"10.250.10.0/23": "eu-west-1c",
+		}, zoneByRange)
+
+		zoneIdByZone := map[string]string{}
+		for _, subnet := range ipRange.Status.Subnets {
+			zoneIdByZone[subnet.Zone] = subnet.ZoneId
+		}
+		assert.Equal(t, map[string]string{"eu-west-1b": "euw1-az1", "eu-west-1c": "euw1-az2"}, zoneIdByZone)
+	})
+
+	t.Run("scope zones are used without ids", func(t *testing.T) {
+		state, _, _ := newZonesResolveState()
+
+		err, _ := zonesResolve(ctx, state)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"eu-west-1a", "eu-west-1b"}, state.zoneNames())
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		state, ipRange, _ := newZonesResolveState("euw1-az1", "use1-az1")
+
+		err, _ := zonesResolve(ctx, state)
+		assert.Equal(t, composed.StopAndForget, err)
+		assert.Equal(t, cloudcontrolv1beta1.ErrorState, ipRange.Status.State)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, cloudcontrolv1beta1.ReasonInvalidAvailabilityZoneIds, cond.Reason)
+		assert.Contains(t, cond.Message, "use1-az1")
+	})
+
+	t.Run("ids not matching the scope zones", func(t *testing.T) {
+		state, ipRange, _ := newZonesResolveState("euw1-az1")
+
+		err, _ := zonesResolve(ctx, state)
+		assert.Equal(t, composed.StopAndForget, err)
+		cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+		assert.NotNil(t, cond)
+		assert.Equal(t, "1 availability zone IDs specified for 2 Scope zones", cond.Message)
+	})
+}
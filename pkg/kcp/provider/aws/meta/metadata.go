@@ -9,6 +9,8 @@ import (
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
 
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	ramTypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
 	secretsmanagerTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	"github.com/aws/smithy-go"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
@@ -59,14 +61,24 @@ func GetErrorMessage(err error) string {
 }
 
 var notFoundErrorCodes = map[string]struct{}{
-	(&efsTypes.FileSystemNotFound{}).ErrorCode():                    {},
-	(&efsTypes.AccessPointNotFound{}).ErrorCode():                   {},
-	(&efsTypes.MountTargetNotFound{}).ErrorCode():                   {},
-	(&efsTypes.PolicyNotFound{}).ErrorCode():                        {},
-	(&elasticacheTypes.CacheSubnetGroupNotFoundFault{}).ErrorCode(): {},
-	(&elasticacheTypes.CacheClusterNotFoundFault{}).ErrorCode():     {},
-	(&secretsmanagerTypes.ResourceNotFoundException{}).ErrorCode():  {},
-	"InvalidVpcPeeringConnectionID.NotFound":                        {},
+	(&efsTypes.FileSystemNotFound{}).ErrorCode():                          {},
+	(&efsTypes.AccessPointNotFound{}).ErrorCode():                         {},
+	(&efsTypes.MountTargetNotFound{}).ErrorCode():                         {},
+	(&efsTypes.PolicyNotFound{}).ErrorCode():                              {},
+	(&efsTypes.ReplicationNotFound{}).ErrorCode():                         {},
+	(&elasticacheTypes.CacheSubnetGroupNotFoundFault{}).ErrorCode():       {},
+	(&elasticacheTypes.CacheClusterNotFoundFault{}).ErrorCode():           {},
+	(&elasticacheTypes.GlobalReplicationGroupNotFoundFault{}).ErrorCode(): {},
+	(&secretsmanagerTypes.ResourceNotFoundException{}).ErrorCode():        {},
+	(&iamTypes.NoSuchEntityException{}).ErrorCode():                       {},
+	(&ramTypes.UnknownResourceException{}).ErrorCode():                    {},
+	"InvalidVpcPeeringConnectionID.NotFound":                              {},
+	"InvalidTransitGatewayID.NotFound":                                    {},
+	"InvalidTransitGatewayAttachmentID.NotFound":                          {},
+	"InvalidEgressOnlyInternetGatewayId.NotFound":                         {},
+	"InvalidRoute.NotFound":                                               {},
+	"InvalidIpamPoolAllocationId.NotFound":                                {},
+	"InvalidNetworkInterfaceID.NotFound":                                  {},
 }
 
 func IsNotFound(err error) bool {
@@ -96,10 +108,15 @@ func RetryableErrorToRequeueResponse(err error) error {
 	return nil
 }
 
+// ErrorToRequeueResponse returns the requeue response configured for the API error code, if any,
+// otherwise a short requeue delay for the retryable errors and a long one for all others
 func ErrorToRequeueResponse(err error) error {
 	if err == nil {
 		return nil
 	}
+	if res, ok := errorCodeToRequeueResponse(err); ok {
+		return res
+	}
 	if IsErrorRetryable(err) {
 		return composed.StopWithRequeueDelay(util.Timing.T10000ms())
 	}
@@ -126,6 +143,25 @@ const (
 	ElastiCache_SNAPSHOTTING  ElastiCacheState = "snapshotting"
 )
 
+type ElastiCacheGlobalReplicationGroupState = string
+
+// Status *string
+// The status of the global datastore, the primary-only while it has no secondary members
+const (
+	ElastiCache_Global_AVAILABLE    ElastiCacheGlobalReplicationGroupState = "available"
+	ElastiCache_Global_PRIMARY_ONLY ElastiCacheGlobalReplicationGroupState = "primary-only"
+)
+
+type ElastiCacheGlobalMemberState = string
+
+// Status *string
+// The status of the membership of the replication group in the global datastore
+const (
+	ElastiCache_GlobalMember_ASSOCIATING    ElastiCacheGlobalMemberState = "associating"
+	ElastiCache_GlobalMember_ASSOCIATED     ElastiCacheGlobalMemberState = "associated"
+	ElastiCache_GlobalMember_DISASSOCIATING ElastiCacheGlobalMemberState = "disassociating"
+)
+
 type ElastiCacheUserGroupState = string
 
 const (
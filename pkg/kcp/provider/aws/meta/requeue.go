@@ -0,0 +1,55 @@
+package meta
+
+import (
+	"time"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+)
+
+// RequeueStop is the error requeue delay value that stops the reconciliation without requeue
+const RequeueStop = "stop"
+
+// defaultErrorRequeueDelays are the requeue delays of the AWS API error codes, overridden and
+// extended by the aws.config.errorRequeueDelays config
+var defaultErrorRequeueDelays = map[string]string{
+	"RequestLimitExceeded":  "30s",
+	"Throttling":            "30s",
+	"ThrottlingException":   "30s",
+	"DependencyViolation":   "10s",
+	"UnauthorizedOperation": RequeueStop,
+}
+
+// errorCodeToRequeueResponse returns the requeue response configured for the API error code of the
+// given error, and false if the error is not an API error, or no valid requeue delay is configured for
+// its code. The invalid configured delays fall back to the default ones.
+func errorCodeToRequeueResponse(err error) (error, bool) {
+	apiErr := AsApiError(err)
+	if apiErr == nil {
+		return nil, false
+	}
+	if value, ok := awsconfig.AwsConfig.ErrorRequeueDelays[apiErr.ErrorCode()]; ok {
+		if res, ok := requeueDelayToResponse(value); ok {
+			return res, true
+		}
+	}
+	if value, ok := defaultErrorRequeueDelays[apiErr.ErrorCode()]; ok {
+		return requeueDelayToResponse(value)
+	}
+	return nil, false
+}
+
+func requeueDelayToResponse(value string) (error, bool) {
+	if value == RequeueStop {
+		return composed.StopAndForget, true
+	}
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay <= 0 {
+		return nil, false
+	}
+	if divider := util.Timing.Divider(); divider > 0 {
+		delay = time.Duration(int64(delay) / divider)
+	}
+	return composed.StopWithRequeueDelay(delay), true
+}
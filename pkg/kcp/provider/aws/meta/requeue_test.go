@@ -0,0 +1,71 @@
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func apiError(code string) error {
+	return fmt.Errorf("wrapped: %w", &smithy.GenericAPIError{Code: code, Message: "simulated"})
+}
+
+func TestErrorToRequeueResponse(t *testing.T) {
+	util.SetRealTiming()
+	defer util.SetRealTiming()
+
+	testData := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"throttling requeues long", apiError("RequestLimitExceeded"), composed.StopWithRequeueDelay(30 * time.Second)},
+		{"throttling exception requeues long", apiError("ThrottlingException"), composed.StopWithRequeueDelay(30 * time.Second)},
+		{"dependency violation requeues short", apiError("DependencyViolation"), composed.StopWithRequeueDelay(10 * time.Second)},
+		{"unauthorized operation stops", apiError("UnauthorizedOperation"), composed.StopAndForget},
+		{"unmapped code falls back to non retryable delay", apiError("InvalidParameterValue"), composed.StopWithRequeueDelay(util.Timing.T300000ms())},
+		{"non api error falls back to non retryable delay", errors.New("boom"), composed.StopWithRequeueDelay(util.Timing.T300000ms())},
+		{"nil error", nil, nil},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ErrorToRequeueResponse(tt.err))
+		})
+	}
+}
+
+func TestErrorToRequeueResponseConfigOverride(t *testing.T) {
+	util.SetRealTiming()
+	defer util.SetRealTiming()
+	defer func() {
+		awsconfig.AwsConfig.ErrorRequeueDelays = nil
+	}()
+
+	awsconfig.AwsConfig.ErrorRequeueDelays = map[string]string{
+		"RequestLimitExceeded":  "2m",
+		"InvalidParameterValue": RequeueStop,
+		"DependencyViolation":   "invalid",
+	}
+
+	assert.Equal(t, composed.StopWithRequeueDelay(2*time.Minute), ErrorToRequeueResponse(apiError("RequestLimitExceeded")))
+	assert.Equal(t, composed.StopAndForget, ErrorToRequeueResponse(apiError("InvalidParameterValue")))
+	// invalid configured delay falls back to the default one
+	assert.Equal(t, composed.StopWithRequeueDelay(10*time.Second), ErrorToRequeueResponse(apiError("DependencyViolation")))
+	// defaults of codes not overridden still apply
+	assert.Equal(t, composed.StopAndForget, ErrorToRequeueResponse(apiError("UnauthorizedOperation")))
+}
+
+func TestErrorToRequeueResponseSpeedyTiming(t *testing.T) {
+	util.SetSpeedyTimingForTests()
+	defer util.SetRealTiming()
+
+	assert.Equal(t, composed.StopWithRequeueDelay(300*time.Millisecond), ErrorToRequeueResponse(apiError("RequestLimitExceeded")))
+}
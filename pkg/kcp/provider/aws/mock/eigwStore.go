@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/google/uuid"
+	"k8s.io/utils/ptr"
+	"strings"
+	"sync"
+)
+
+type EgressOnlyInternetGatewayConfig interface {
+	GetEgressOnlyInternetGatewaysByVpc(vpcId string) []ec2types.EgressOnlyInternetGateway
+}
+
+type eigwStore struct {
+	m     sync.Mutex
+	items []*ec2types.EgressOnlyInternetGateway
+}
+
+func (s *eigwStore) GetEgressOnlyInternetGatewaysByVpc(vpcId string) []ec2types.EgressOnlyInternetGateway {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.byVpc(vpcId)
+}
+
+func (s *eigwStore) byVpc(vpcId string) []ec2types.EgressOnlyInternetGateway {
+	var result []ec2types.EgressOnlyInternetGateway
+	for _, eigw := range s.items {
+		for _, att := range eigw.Attachments {
+			if ptr.Deref(att.VpcId, "") == vpcId {
+				result = append(result, *eigw)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (s *eigwStore) DescribeEgressOnlyInternetGateways(ctx context.Context, vpcId string) ([]ec2types.EgressOnlyInternetGateway, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.byVpc(vpcId), nil
+}
+
+func (s *eigwStore) CreateEgressOnlyInternetGateway(ctx context.Context, vpcId string, tags []ec2types.Tag) (*ec2types.EgressOnlyInternetGateway, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	eigw := &ec2types.EgressOnlyInternetGateway{
+		EgressOnlyInternetGatewayId: ptr.To("eigw-" + strings.ReplaceAll(uuid.NewString(), "-", "")[:17]),
+		Attachments: []ec2types.InternetGatewayAttachment{
+			{
+				VpcId: ptr.To(vpcId),
+				State: ec2types.AttachmentStatusAttached,
+			},
+		},
+		Tags: append([]ec2types.Tag{}, tags...),
+	}
+	s.items = append(s.items, eigw)
+	cpy := *eigw
+	return &cpy, nil
+}
+
+func (s *eigwStore) DeleteEgressOnlyInternetGateway(ctx context.Context, egressOnlyInternetGatewayId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i, eigw := range s.items {
+		if ptr.Deref(eigw.EgressOnlyInternetGatewayId, "") == egressOnlyInternetGatewayId {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			return nil
+		}
+	}
+	return &smithy.GenericAPIError{
+		Code:    "InvalidEgressOnlyInternetGatewayId.NotFound",
+		Message: fmt.Sprintf("egress-only internet gateway %s does not exist", egressOnlyInternetGatewayId),
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"sync"
 
 	aws "github.com/aws/aws-sdk-go-v2/aws"
+	cloudwatchlogsTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	firehoseTypes "github.com/aws/aws-sdk-go-v2/service/firehose/types"
 	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	secretsmanagerTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	"github.com/elliotchance/pie/v2"
@@ -20,11 +22,18 @@ import (
 
 type AwsElastiCacheMockUtils interface {
 	GetAwsElastiCacheByName(name string) *elasticacheTypes.ReplicationGroup
+	GetAwsElastiCacheTagsByName(name string) map[string]string
 	SetAwsElastiCacheLifeCycleState(name string, state awsmeta.ElastiCacheState)
 	SetAwsElastiCacheUserGroupLifeCycleState(name string, state awsmeta.ElastiCacheUserGroupState)
 	DeleteAwsElastiCacheByName(name string)
 	DeleteAwsElastiCacheUserGroupByName(name string)
 	DescribeAwsElastiCacheParametersByName(groupName string) map[string]string
+	GetAwsElastiCacheGlobalReplicationGroupById(id string) *elasticacheTypes.GlobalReplicationGroup
+	SetAwsElastiCacheGlobalReplicationGroupMemberStatus(id, replicationGroupId, status string)
+	AddAwsLogGroup(name string)
+	AddAwsFirehoseDeliveryStream(name string)
+	// DenyAwsLogDestinationWrite denies all actions on the log group or the delivery stream with the given name
+	DenyAwsLogDestinationWrite(name string)
 }
 
 func getDefaultParams() map[string]elasticacheTypes.Parameter {
@@ -45,26 +54,46 @@ func getDefaultParams() map[string]elasticacheTypes.Parameter {
 }
 
 type elastiCacheClientFake struct {
-	subnetGroupMutex    *sync.Mutex
-	parameterGroupMutex *sync.Mutex
-	elasticacheMutex    *sync.Mutex
-	secretStoreMutex    *sync.Mutex
-	userGroupsMutex     *sync.Mutex
-	securityGroupsMutex *sync.Mutex
-	replicationGroups   map[string]*elasticacheTypes.ReplicationGroup
-	cacheClusters       map[string]*elasticacheTypes.CacheCluster
-	parameters          map[string]map[string]elasticacheTypes.Parameter
-	parameterGroups     map[string]*elasticacheTypes.CacheParameterGroup
-	subnetGroups        map[string]*elasticacheTypes.CacheSubnetGroup
-	userGroups          map[string]*elasticacheTypes.UserGroup
-	secretStore         map[string]*secretsmanager.GetSecretValueOutput
-	securityGroups      []*ec2Types.SecurityGroup
+	subnetGroupMutex        *sync.Mutex
+	parameterGroupMutex     *sync.Mutex
+	elasticacheMutex        *sync.Mutex
+	secretStoreMutex        *sync.Mutex
+	userGroupsMutex         *sync.Mutex
+	securityGroupsMutex     *sync.Mutex
+	replicationGroups       map[string]*elasticacheTypes.ReplicationGroup
+	globalReplicationGroups map[string]*elasticacheTypes.GlobalReplicationGroup
+	cacheClusters           map[string]*elasticacheTypes.CacheCluster
+	parameters              map[string]map[string]elasticacheTypes.Parameter
+	parameterGroups         map[string]*elasticacheTypes.CacheParameterGroup
+	subnetGroups            map[string]*elasticacheTypes.CacheSubnetGroup
+	userGroups              map[string]*elasticacheTypes.UserGroup
+	secretStore             map[string]*secretsmanager.GetSecretValueOutput
+	securityGroups          []*ec2Types.SecurityGroup
+	logGroups               map[string]*cloudwatchlogsTypes.LogGroup
+	deliveryStreams         map[string]*firehoseTypes.DeliveryStreamDescription
+	tags                    map[string][]elasticacheTypes.Tag
+	deniedResourceArns      map[string]bool
 }
 
 func (client *elastiCacheClientFake) GetAwsElastiCacheByName(name string) *elasticacheTypes.ReplicationGroup {
 	return client.replicationGroups[name]
 }
 
+func (client *elastiCacheClientFake) GetAwsElastiCacheTagsByName(name string) map[string]string {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	result := map[string]string{}
+	for _, t := range client.tags[elastiCacheReplicationGroupArn(name)] {
+		result[ptr.Deref(t.Key, "")] = ptr.Deref(t.Value, "")
+	}
+	return result
+}
+
+func elastiCacheReplicationGroupArn(name string) string {
+	return fmt.Sprintf("arn:aws:elasticache:eu-west-1:123456789012:replicationgroup:%s", name)
+}
+
 func (client *elastiCacheClientFake) SetAwsElastiCacheLifeCycleState(name string, state awsmeta.ElastiCacheState) {
 	if instance, ok := client.replicationGroups[name]; ok {
 		instance.Status = ptr.To(state)
@@ -101,6 +130,59 @@ func (client *elastiCacheClientFake) DescribeAwsElastiCacheParametersByName(grou
 	return result
 }
 
+func (client *elastiCacheClientFake) GetAwsElastiCacheGlobalReplicationGroupById(id string) *elasticacheTypes.GlobalReplicationGroup {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	return client.globalReplicationGroups[id]
+}
+
+func (client *elastiCacheClientFake) SetAwsElastiCacheGlobalReplicationGroupMemberStatus(id, replicationGroupId, status string) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	if instance, ok := client.globalReplicationGroups[id]; ok {
+		for i := range instance.Members {
+			if ptr.Deref(instance.Members[i].ReplicationGroupId, "") == replicationGroupId {
+				instance.Members[i].Status = ptr.To(status)
+			}
+		}
+	}
+}
+
+func (client *elastiCacheClientFake) AddAwsLogGroup(name string) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	client.logGroups[name] = &cloudwatchlogsTypes.LogGroup{
+		LogGroupName: ptr.To(name),
+		Arn:          ptr.To(fmt.Sprintf("arn:aws:logs:eu-west-1:123456789012:log-group:%s", name)),
+	}
+}
+
+func (client *elastiCacheClientFake) AddAwsFirehoseDeliveryStream(name string) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	client.deliveryStreams[name] = &firehoseTypes.DeliveryStreamDescription{
+		DeliveryStreamName:   ptr.To(name),
+		DeliveryStreamARN:    ptr.To(fmt.Sprintf("arn:aws:firehose:eu-west-1:123456789012:deliverystream/%s", name)),
+		DeliveryStreamStatus: firehoseTypes.DeliveryStreamStatusActive,
+	}
+}
+
+func (client *elastiCacheClientFake) DenyAwsLogDestinationWrite(name string) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	if logGroup, ok := client.logGroups[name]; ok {
+		client.deniedResourceArns[ptr.Deref(logGroup.Arn, "")] = true
+	}
+	if deliveryStream, ok := client.deliveryStreams[name]; ok {
+		client.deniedResourceArns[ptr.Deref(deliveryStream.DeliveryStreamARN, "")] = true
+	}
+}
+
 func (client *elastiCacheClientFake) DescribeElastiCacheSubnetGroup(ctx context.Context, name string) ([]elasticacheTypes.CacheSubnetGroup, error) {
 	client.subnetGroupMutex.Lock()
 	defer client.subnetGroupMutex.Unlock()
@@ -120,6 +202,9 @@ func (client *elastiCacheClientFake) CreateElastiCacheSubnetGroup(ctx context.Co
 
 	client.subnetGroups[name] = &elasticacheTypes.CacheSubnetGroup{
 		CacheSubnetGroupName: ptr.To(name),
+		Subnets: pie.Map(subnetIds, func(id string) elasticacheTypes.Subnet {
+			return elasticacheTypes.Subnet{SubnetIdentifier: ptr.To(id)}
+		}),
 	}
 
 	return &elasticache.CreateCacheSubnetGroupOutput{CacheSubnetGroup: &elasticacheTypes.CacheSubnetGroup{
@@ -127,6 +212,21 @@ func (client *elastiCacheClientFake) CreateElastiCacheSubnetGroup(ctx context.Co
 	}}, nil
 }
 
+func (client *elastiCacheClientFake) ModifyElastiCacheSubnetGroup(ctx context.Context, name string, subnetIds []string) error {
+	client.subnetGroupMutex.Lock()
+	defer client.subnetGroupMutex.Unlock()
+
+	subnetGroup := client.subnetGroups[name]
+	if subnetGroup == nil {
+		return &elasticacheTypes.CacheSubnetGroupNotFoundFault{Message: ptr.To(fmt.Sprintf("subnet group %s not found", name))}
+	}
+	subnetGroup.Subnets = pie.Map(subnetIds, func(id string) elasticacheTypes.Subnet {
+		return elasticacheTypes.Subnet{SubnetIdentifier: ptr.To(id)}
+	})
+
+	return nil
+}
+
 func (client *elastiCacheClientFake) DeleteElastiCacheSubnetGroup(ctx context.Context, name string) error {
 	client.subnetGroupMutex.Lock()
 	defer client.subnetGroupMutex.Unlock()
@@ -244,6 +344,7 @@ func (client *elastiCacheClientFake) CreateElastiCacheReplicationGroup(ctx conte
 	client.cacheClusters[options.Name] = &elasticacheTypes.CacheCluster{
 		CacheClusterId:             ptr.To(options.Name),
 		PreferredMaintenanceWindow: options.PreferredMaintenanceWindow,
+		EngineVersion:              ptr.To(options.EngineVersion),
 	}
 
 	authTokenEnabled := false
@@ -251,7 +352,9 @@ func (client *elastiCacheClientFake) CreateElastiCacheReplicationGroup(ctx conte
 		authTokenEnabled = true
 	}
 
+	client.tags[elastiCacheReplicationGroupArn(options.Name)] = append([]elasticacheTypes.Tag{}, tags...)
 	client.replicationGroups[options.Name] = &elasticacheTypes.ReplicationGroup{
+		ARN:                      ptr.To(elastiCacheReplicationGroupArn(options.Name)),
 		ReplicationGroupId:       ptr.To(options.Name),
 		Status:                   ptr.To("creating"),
 		CacheNodeType:            ptr.To(options.CacheNodeType),
@@ -276,6 +379,20 @@ func (client *elastiCacheClientFake) CreateElastiCacheReplicationGroup(ctx conte
 	if options.TransitEncryptionEnabled {
 		client.replicationGroups[options.Name].TransitEncryptionMode = elasticacheTypes.TransitEncryptionModeRequired
 	}
+	client.replicationGroups[options.Name].DataTiering = elasticacheTypes.DataTieringStatusDisabled
+	if options.DataTieringEnabled {
+		client.replicationGroups[options.Name].DataTiering = elasticacheTypes.DataTieringStatusEnabled
+	}
+	client.replicationGroups[options.Name].ClusterEnabled = ptr.To(options.Shards != nil)
+	if options.Shards != nil {
+		client.replicationGroups[options.Name].ClusterMode = elasticacheTypes.ClusterModeEnabled
+		client.replicationGroups[options.Name].ConfigurationEndpoint = &elasticacheTypes.Endpoint{
+			Address: ptr.To("192.168.3.5"),
+			Port:    aws.Int32(6949),
+		}
+		client.replicationGroups[options.Name].NodeGroups = nil
+		setFakeNodeGroups(client.replicationGroups[options.Name], *options.Shards, options.ReplicasPerShard, nil)
+	}
 
 	return &elasticache.CreateReplicationGroupOutput{}, nil
 }
@@ -303,6 +420,19 @@ func (client *elastiCacheClientFake) ModifyElastiCacheReplicationGroup(ctx conte
 		if options.TransitEncryptionMode != nil {
 			instance.TransitEncryptionMode = *options.TransitEncryptionMode
 		}
+		if options.EngineVersion != nil {
+			for _, memberClusterId := range instance.MemberClusters {
+				cacheCluster, ok := client.cacheClusters[memberClusterId]
+				if !ok {
+					continue
+				}
+				if ptr.Deref(options.ApplyImmediately, true) {
+					cacheCluster.EngineVersion = options.EngineVersion
+				} else {
+					cacheCluster.PendingModifiedValues = &elasticacheTypes.PendingModifiedValues{EngineVersion: options.EngineVersion}
+				}
+			}
+		}
 
 		if len(options.UserGroupIdsToAdd) > 0 {
 			instance.UserGroupIds = append(instance.UserGroupIds, options.UserGroupIdsToAdd...)
@@ -316,6 +446,22 @@ func (client *elastiCacheClientFake) ModifyElastiCacheReplicationGroup(ctx conte
 		if options.AuthTokenSecretString != nil {
 			instance.AuthTokenEnabled = ptr.To(true)
 		}
+
+		for _, request := range options.LogDeliveryConfigurations {
+			instance.LogDeliveryConfigurations = pie.Filter(instance.LogDeliveryConfigurations, func(c elasticacheTypes.LogDeliveryConfiguration) bool {
+				return c.LogType != request.LogType
+			})
+			if !ptr.Deref(request.Enabled, false) {
+				continue
+			}
+			instance.LogDeliveryConfigurations = append(instance.LogDeliveryConfigurations, elasticacheTypes.LogDeliveryConfiguration{
+				LogType:            request.LogType,
+				DestinationType:    request.DestinationType,
+				DestinationDetails: request.DestinationDetails,
+				LogFormat:          request.LogFormat,
+				Status:             elasticacheTypes.LogDeliveryConfigurationStatusActive,
+			})
+		}
 	}
 
 	return &elasticache.ModifyReplicationGroupOutput{}, nil
@@ -332,6 +478,61 @@ func (client *elastiCacheClientFake) DeleteElastiCacheReplicationGroup(ctx conte
 	return nil
 }
 
+func (client *elastiCacheClientFake) ModifyElastiCacheReplicationGroupShardConfiguration(ctx context.Context, id string, nodeGroupCount int32, nodeGroupsToRemove []string) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	if instance, ok := client.replicationGroups[id]; ok {
+		instance.Status = ptr.To("modifying")
+		replicas := int32(0)
+		if len(instance.NodeGroups) > 0 {
+			replicas = int32(len(instance.NodeGroups[0].NodeGroupMembers) - 1)
+		}
+		setFakeNodeGroups(instance, nodeGroupCount, replicas, nodeGroupsToRemove)
+	}
+
+	return nil
+}
+
+func (client *elastiCacheClientFake) ModifyElastiCacheReplicaCount(ctx context.Context, id string, currentReplicaCount, newReplicaCount int32) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	if instance, ok := client.replicationGroups[id]; ok {
+		instance.Status = ptr.To("modifying")
+		setFakeNodeGroups(instance, int32(len(instance.NodeGroups)), newReplicaCount, nil)
+	}
+
+	return nil
+}
+
+// setFakeNodeGroups removes the given node groups and adds or removes the last ones to the given count,
+// each with the primary and the given number of replica members
+func setFakeNodeGroups(instance *elasticacheTypes.ReplicationGroup, count, replicas int32, toRemove []string) {
+	nodeGroups := pie.Filter(instance.NodeGroups, func(ng elasticacheTypes.NodeGroup) bool {
+		return !pie.Contains(toRemove, ptr.Deref(ng.NodeGroupId, ""))
+	})
+	for i := len(nodeGroups); i < int(count); i++ {
+		nodeGroups = append(nodeGroups, elasticacheTypes.NodeGroup{
+			NodeGroupId: ptr.To(fmt.Sprintf("%04d", i+1)),
+			Status:      ptr.To("available"),
+		})
+	}
+	if len(nodeGroups) > int(count) {
+		nodeGroups = nodeGroups[:count]
+	}
+	for i := range nodeGroups {
+		var members []elasticacheTypes.NodeGroupMember
+		for m := 0; m <= int(replicas); m++ {
+			members = append(members, elasticacheTypes.NodeGroupMember{
+				CacheClusterId: ptr.To(fmt.Sprintf("%s-%s-%03d", ptr.Deref(instance.ReplicationGroupId, ""), ptr.Deref(nodeGroups[i].NodeGroupId, ""), m+1)),
+			})
+		}
+		nodeGroups[i].NodeGroupMembers = members
+	}
+	instance.NodeGroups = nodeGroups
+}
+
 func (client *elastiCacheClientFake) DescribeElastiCacheCluster(ctx context.Context, id string) ([]elasticacheTypes.CacheCluster, error) {
 	client.elasticacheMutex.Lock()
 	defer client.elasticacheMutex.Unlock()
@@ -345,6 +546,176 @@ func (client *elastiCacheClientFake) DescribeElastiCacheCluster(ctx context.Cont
 	return []elasticacheTypes.CacheCluster{*cacheCluster}, nil
 }
 
+func (client *elastiCacheClientFake) ListElastiCacheTags(ctx context.Context, arn string) ([]elasticacheTypes.Tag, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	return append([]elasticacheTypes.Tag{}, client.tags[arn]...), nil
+}
+
+func (client *elastiCacheClientFake) AddElastiCacheTags(ctx context.Context, arn string, tags []elasticacheTypes.Tag) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	for _, t := range tags {
+		client.tags[arn] = pie.Filter(client.tags[arn], func(x elasticacheTypes.Tag) bool {
+			return ptr.Deref(x.Key, "") != ptr.Deref(t.Key, "")
+		})
+		client.tags[arn] = append(client.tags[arn], t)
+	}
+	return nil
+}
+
+func (client *elastiCacheClientFake) RemoveElastiCacheTags(ctx context.Context, arn string, keys []string) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	client.tags[arn] = pie.Filter(client.tags[arn], func(x elasticacheTypes.Tag) bool {
+		return !pie.Contains(keys, ptr.Deref(x.Key, ""))
+	})
+	return nil
+}
+
+func (client *elastiCacheClientFake) CreateElastiCacheSecondaryReplicationGroup(ctx context.Context, globalReplicationGroupId, name string, tags []elasticacheTypes.Tag, options awsclient.CreateElastiCacheSecondaryReplicationGroupOptions) (*elasticache.CreateReplicationGroupOutput, error) {
+	client.subnetGroupMutex.Lock()
+	_, subnetGroupExists := client.subnetGroups[options.SubnetGroupName]
+	client.subnetGroupMutex.Unlock()
+	if !subnetGroupExists {
+		return nil, &elasticacheTypes.CacheSubnetGroupNotFoundFault{Message: ptr.To(fmt.Sprintf("subnet group %s not found", options.SubnetGroupName))}
+	}
+
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	globalReplicationGroup, ok := client.globalReplicationGroups[globalReplicationGroupId]
+	if !ok {
+		return nil, &elasticacheTypes.GlobalReplicationGroupNotFoundFault{Message: ptr.To(fmt.Sprintf("global replication group %s does not exist", globalReplicationGroupId))}
+	}
+
+	client.cacheClusters[name] = &elasticacheTypes.CacheCluster{
+		CacheClusterId:       ptr.To(name),
+		CacheSubnetGroupName: ptr.To(options.SubnetGroupName),
+		SecurityGroups: pie.Map(options.SecurityGroupIds, func(id string) elasticacheTypes.SecurityGroupMembership {
+			return elasticacheTypes.SecurityGroupMembership{SecurityGroupId: ptr.To(id)}
+		}),
+	}
+	client.tags[elastiCacheReplicationGroupArn(name)] = append([]elasticacheTypes.Tag{}, tags...)
+	client.replicationGroups[name] = &elasticacheTypes.ReplicationGroup{
+		ARN:                ptr.To(elastiCacheReplicationGroupArn(name)),
+		ReplicationGroupId: ptr.To(name),
+		Status:             ptr.To(awsmeta.ElastiCache_CREATING),
+		MemberClusters:     []string{name},
+		UserGroupIds:       []string{},
+		GlobalReplicationGroupInfo: &elasticacheTypes.GlobalReplicationGroupInfo{
+			GlobalReplicationGroupId:         ptr.To(globalReplicationGroupId),
+			GlobalReplicationGroupMemberRole: ptr.To("SECONDARY"),
+		},
+		NodeGroups: []elasticacheTypes.NodeGroup{
+			{
+				PrimaryEndpoint: &elasticacheTypes.Endpoint{
+					Address: ptr.To("192.168.4.3"),
+					Port:    aws.Int32(6949),
+				},
+				ReaderEndpoint: &elasticacheTypes.Endpoint{
+					Address: ptr.To("192.168.4.4"),
+					Port:    aws.Int32(6949),
+				},
+			},
+		},
+	}
+	globalReplicationGroup.Members = append(globalReplicationGroup.Members, elasticacheTypes.GlobalReplicationGroupMember{
+		ReplicationGroupId: ptr.To(name),
+		Role:               ptr.To("SECONDARY"),
+		Status:             ptr.To(awsmeta.ElastiCache_GlobalMember_ASSOCIATING),
+	})
+
+	return &elasticache.CreateReplicationGroupOutput{}, nil
+}
+
+func (client *elastiCacheClientFake) DescribeGlobalReplicationGroup(ctx context.Context, id string) (*elasticacheTypes.GlobalReplicationGroup, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	globalReplicationGroup, ok := client.globalReplicationGroups[id]
+	if !ok {
+		return nil, nil
+	}
+
+	result := *globalReplicationGroup
+	result.Members = append([]elasticacheTypes.GlobalReplicationGroupMember{}, globalReplicationGroup.Members...)
+
+	return &result, nil
+}
+
+func (client *elastiCacheClientFake) CreateGlobalReplicationGroup(ctx context.Context, idSuffix, primaryReplicationGroupId string) (*elasticache.CreateGlobalReplicationGroupOutput, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	primary, ok := client.replicationGroups[primaryReplicationGroupId]
+	if !ok {
+		return nil, fmt.Errorf("replication group %s does not exist", primaryReplicationGroupId)
+	}
+
+	id := fmt.Sprintf("mock-%s", idSuffix)
+	client.globalReplicationGroups[id] = &elasticacheTypes.GlobalReplicationGroup{
+		GlobalReplicationGroupId: ptr.To(id),
+		Status:                   ptr.To(awsmeta.ElastiCache_Global_AVAILABLE),
+		Members: []elasticacheTypes.GlobalReplicationGroupMember{
+			{
+				ReplicationGroupId: ptr.To(primaryReplicationGroupId),
+				Role:               ptr.To("PRIMARY"),
+				Status:             ptr.To(awsmeta.ElastiCache_GlobalMember_ASSOCIATED),
+			},
+		},
+	}
+	primary.GlobalReplicationGroupInfo = &elasticacheTypes.GlobalReplicationGroupInfo{
+		GlobalReplicationGroupId:         ptr.To(id),
+		GlobalReplicationGroupMemberRole: ptr.To("PRIMARY"),
+	}
+
+	return &elasticache.CreateGlobalReplicationGroupOutput{
+		GlobalReplicationGroup: client.globalReplicationGroups[id],
+	}, nil
+}
+
+func (client *elastiCacheClientFake) DisassociateGlobalReplicationGroup(ctx context.Context, id, replicationGroupId, replicationGroupRegion string) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	globalReplicationGroup, ok := client.globalReplicationGroups[id]
+	if !ok {
+		return &elasticacheTypes.GlobalReplicationGroupNotFoundFault{Message: ptr.To(fmt.Sprintf("global replication group %s does not exist", id))}
+	}
+
+	globalReplicationGroup.Members = pie.Filter(globalReplicationGroup.Members, func(m elasticacheTypes.GlobalReplicationGroupMember) bool {
+		return ptr.Deref(m.ReplicationGroupId, "") != replicationGroupId
+	})
+	if instance, ok := client.replicationGroups[replicationGroupId]; ok {
+		instance.GlobalReplicationGroupInfo = nil
+	}
+
+	return nil
+}
+
+func (client *elastiCacheClientFake) DeleteGlobalReplicationGroup(ctx context.Context, id string) error {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	globalReplicationGroup, ok := client.globalReplicationGroups[id]
+	if !ok {
+		return &elasticacheTypes.GlobalReplicationGroupNotFoundFault{Message: ptr.To(fmt.Sprintf("global replication group %s does not exist", id))}
+	}
+
+	for _, m := range globalReplicationGroup.Members {
+		if instance, ok := client.replicationGroups[ptr.Deref(m.ReplicationGroupId, "")]; ok {
+			instance.GlobalReplicationGroupInfo = nil
+		}
+	}
+	delete(client.globalReplicationGroups, id)
+
+	return nil
+}
+
 func (client *elastiCacheClientFake) DescribeUserGroup(ctx context.Context, id string) (*elasticacheTypes.UserGroup, error) {
 	client.userGroupsMutex.Lock()
 	defer client.userGroupsMutex.Unlock()
@@ -391,7 +762,7 @@ func (client *elastiCacheClientFake) DescribeElastiCacheSecurityGroups(ctx conte
 	}
 	if filters != nil {
 		list = pie.Filter(list, func(sg *ec2Types.SecurityGroup) bool {
-			return anyFilterMatchTags(sg.Tags, filters)
+			return allFiltersMatchTags(sg.Tags, filters)
 		})
 	}
 	result := make([]ec2Types.SecurityGroup, 0, len(list))
@@ -447,3 +818,27 @@ func (client *elastiCacheClientFake) DeleteElastiCacheSecurityGroup(ctx context.
 	})
 	return nil
 }
+
+func (client *elastiCacheClientFake) DescribeLogGroup(ctx context.Context, name string) (*cloudwatchlogsTypes.LogGroup, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	return client.logGroups[name], nil
+}
+
+func (client *elastiCacheClientFake) DescribeDeliveryStream(ctx context.Context, name string) (*firehoseTypes.DeliveryStreamDescription, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	return client.deliveryStreams[name], nil
+}
+
+func (client *elastiCacheClientFake) GetDeniedActions(ctx context.Context, resourceArn string, actionNames []string) ([]string, error) {
+	client.elasticacheMutex.Lock()
+	defer client.elasticacheMutex.Unlock()
+
+	if client.deniedResourceArns[resourceArn] {
+		return actionNames, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,186 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+	"sync"
+	"time"
+)
+
+type IamConfig interface {
+	GetRoleByName(name string) *iamTypes.Role
+	GetRolePolicyDocument(roleName, policyName string) string
+}
+
+type roleItem struct {
+	role     *iamTypes.Role
+	policies map[string]string
+}
+
+type iamStore struct {
+	m     sync.Mutex
+	roles map[string]*roleItem
+}
+
+// Config =======
+
+func (s *iamStore) GetRoleByName(name string) *iamTypes.Role {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[name]
+	if !ok {
+		return nil
+	}
+	return item.role
+}
+
+func (s *iamStore) GetRolePolicyDocument(roleName, policyName string) string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[roleName]
+	if !ok {
+		return ""
+	}
+	return item.policies[policyName]
+}
+
+// Client =======
+
+func (s *iamStore) GetRole(ctx context.Context, name string) (*iamTypes.Role, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[name]
+	if !ok {
+		return nil, roleNotFoundError(name)
+	}
+	role := *item.role
+	return &role, nil
+}
+
+func (s *iamStore) CreateRole(ctx context.Context, name, assumeRolePolicyDocument string, tags []iamTypes.Tag) (*iamTypes.Role, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.roles == nil {
+		s.roles = map[string]*roleItem{}
+	}
+	if _, ok := s.roles[name]; ok {
+		return nil, &iamTypes.EntityAlreadyExistsException{
+			Message: ptr.To(fmt.Sprintf("role %s already exists", name)),
+		}
+	}
+	role := &iamTypes.Role{
+		Arn:                      ptr.To(fmt.Sprintf("arn:aws:iam::%s:role/%s", awsmeta.GetAwsAccountId(ctx), name)),
+		RoleName:                 ptr.To(name),
+		RoleId:                   ptr.To(name),
+		Path:                     ptr.To("/"),
+		CreateDate:               ptr.To(time.Now()),
+		AssumeRolePolicyDocument: ptr.To(assumeRolePolicyDocument),
+		Tags:                     tags,
+	}
+	s.roles[name] = &roleItem{
+		role:     role,
+		policies: map[string]string{},
+	}
+	result := *role
+	return &result, nil
+}
+
+func (s *iamStore) UpdateAssumeRolePolicy(ctx context.Context, name, assumeRolePolicyDocument string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[name]
+	if !ok {
+		return roleNotFoundError(name)
+	}
+	item.role.AssumeRolePolicyDocument = ptr.To(assumeRolePolicyDocument)
+	return nil
+}
+
+func (s *iamStore) DeleteRole(ctx context.Context, name string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[name]
+	if !ok {
+		return roleNotFoundError(name)
+	}
+	if len(item.policies) > 0 {
+		return &iamTypes.DeleteConflictException{
+			Message: ptr.To(fmt.Sprintf("role %s has inline policies attached", name)),
+		}
+	}
+	delete(s.roles, name)
+	return nil
+}
+
+func (s *iamStore) GetRolePolicy(ctx context.Context, roleName, policyName string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[roleName]
+	if !ok {
+		return "", roleNotFoundError(roleName)
+	}
+	doc, ok := item.policies[policyName]
+	if !ok {
+		return "", &iamTypes.NoSuchEntityException{
+			Message: ptr.To(fmt.Sprintf("policy %s of role %s does not exist", policyName, roleName)),
+		}
+	}
+	return doc, nil
+}
+
+func (s *iamStore) PutRolePolicy(ctx context.Context, roleName, policyName, policyDocument string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[roleName]
+	if !ok {
+		return roleNotFoundError(roleName)
+	}
+	item.policies[policyName] = policyDocument
+	return nil
+}
+
+func (s *iamStore) DeleteRolePolicy(ctx context.Context, roleName, policyName string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, ok := s.roles[roleName]
+	if !ok {
+		return roleNotFoundError(roleName)
+	}
+	if _, ok := item.policies[policyName]; !ok {
+		return &iamTypes.NoSuchEntityException{
+			Message: ptr.To(fmt.Sprintf("policy %s of role %s does not exist", policyName, roleName)),
+		}
+	}
+	delete(item.policies, policyName)
+	return nil
+}
+
+func roleNotFoundError(name string) error {
+	return &iamTypes.NoSuchEntityException{
+		Message: ptr.To(fmt.Sprintf("role %s does not exist", name)),
+	}
+}
@@ -0,0 +1,166 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/elliotchance/pie/v2"
+	"github.com/google/uuid"
+	iprangeallocate "github.com/kyma-project/cloud-manager/pkg/kcp/iprange/allocate"
+	"k8s.io/utils/ptr"
+)
+
+type IpamConfig interface {
+	// AddIpamPool adds the IPAM pool with the given provisioned CIDRs
+	AddIpamPool(poolId string, cidrs ...string)
+	// AddIpamPoolAllocation allocates the CIDR from the pool, as if it was allocated by someone else, and returns the allocation id
+	AddIpamPoolAllocation(poolId, cidr string) string
+}
+
+type ipamPool struct {
+	cidrs       []string
+	allocations []ec2types.IpamPoolAllocation
+	// allocationIdsByClientToken are the ids of the allocations made with the client token
+	allocationIdsByClientToken map[string]string
+}
+
+type ipamStore struct {
+	m     sync.Mutex
+	pools map[string]*ipamPool
+}
+
+func (s *ipamStore) AddIpamPool(poolId string, cidrs ...string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.pools == nil {
+		s.pools = map[string]*ipamPool{}
+	}
+	s.pools[poolId] = &ipamPool{cidrs: cidrs}
+}
+
+func (s *ipamStore) AddIpamPoolAllocation(poolId, cidr string) string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	pool, err := s.getPool(poolId)
+	if err != nil {
+		panic(err)
+	}
+	return ptr.Deref(s.allocate(pool, cidr, "").IpamPoolAllocationId, "")
+}
+
+func (s *ipamStore) getPool(poolId string) (*ipamPool, error) {
+	pool, ok := s.pools[poolId]
+	if !ok {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InvalidIpamPoolId.NotFound",
+			Message: fmt.Sprintf("IPAM pool %s does not exist", poolId),
+		}
+	}
+	return pool, nil
+}
+
+func (s *ipamStore) allocate(pool *ipamPool, cidr, description string) *ec2types.IpamPoolAllocation {
+	allocation := ec2types.IpamPoolAllocation{
+		IpamPoolAllocationId: ptr.To("ipam-pool-alloc-" + strings.ReplaceAll(uuid.NewString(), "-", "")[:17]),
+		Cidr:                 ptr.To(cidr),
+		Description:          ptr.To(description),
+		ResourceType:         ec2types.IpamPoolAllocationResourceTypeCustom,
+	}
+	pool.allocations = append(pool.allocations, allocation)
+	return &allocation
+}
+
+func (s *ipamStore) GetIpamPoolCidrs(ctx context.Context, poolId string) ([]ec2types.IpamPoolCidr, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	pool, err := s.getPool(poolId)
+	if err != nil {
+		return nil, err
+	}
+	return pie.Map(pool.cidrs, func(c string) ec2types.IpamPoolCidr {
+		return ec2types.IpamPoolCidr{
+			Cidr:  ptr.To(c),
+			State: ec2types.IpamPoolCidrStateProvisioned,
+		}
+	}), nil
+}
+
+func (s *ipamStore) GetIpamPoolAllocations(ctx context.Context, poolId string) ([]ec2types.IpamPoolAllocation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	pool, err := s.getPool(poolId)
+	if err != nil {
+		return nil, err
+	}
+	return append([]ec2types.IpamPoolAllocation{}, pool.allocations...), nil
+}
+
+func (s *ipamStore) AllocateIpamPoolCidr(ctx context.Context, poolId string, netmaskLength int, description, clientToken string) (*ec2types.IpamPoolAllocation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	pool, err := s.getPool(poolId)
+	if err != nil {
+		return nil, err
+	}
+	if id, ok := pool.allocationIdsByClientToken[clientToken]; ok && len(clientToken) > 0 {
+		for _, a := range pool.allocations {
+			if ptr.Deref(a.IpamPoolAllocationId, "") == id {
+				return &a, nil
+			}
+		}
+	}
+	allocated := pie.Map(pool.allocations, func(a ec2types.IpamPoolAllocation) string {
+		return ptr.Deref(a.Cidr, "")
+	})
+	cidr, err := iprangeallocate.AllocateCidrFromPool(netmaskLength, pool.cidrs, allocated)
+	if err != nil {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InsufficientCidrBlocks",
+			Message: fmt.Sprintf("IPAM pool %s has no space for a /%d allocation", poolId, netmaskLength),
+		}
+	}
+	allocation := s.allocate(pool, cidr, description)
+	if len(clientToken) > 0 {
+		if pool.allocationIdsByClientToken == nil {
+			pool.allocationIdsByClientToken = map[string]string{}
+		}
+		pool.allocationIdsByClientToken[clientToken] = ptr.Deref(allocation.IpamPoolAllocationId, "")
+	}
+	return allocation, nil
+}
+
+func (s *ipamStore) ReleaseIpamPoolAllocation(ctx context.Context, poolId, allocationId, cidr string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	pool, err := s.getPool(poolId)
+	if err != nil {
+		return err
+	}
+	idx := pie.FindFirstUsing(pool.allocations, func(a ec2types.IpamPoolAllocation) bool {
+		return ptr.Deref(a.IpamPoolAllocationId, "") == allocationId && ptr.Deref(a.Cidr, "") == cidr
+	})
+	if idx == -1 {
+		return &smithy.GenericAPIError{
+			Code:    "InvalidIpamPoolAllocationId.NotFound",
+			Message: fmt.Sprintf("IPAM pool allocation %s of %s does not exist", allocationId, cidr),
+		}
+	}
+	pool.allocations = append(pool.allocations[:idx], pool.allocations[idx+1:]...)
+	return nil
+}
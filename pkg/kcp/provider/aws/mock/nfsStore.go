@@ -3,19 +3,40 @@ package mock
 import (
 	"context"
 	"fmt"
+	backupTypes "github.com/aws/aws-sdk-go-v2/service/backup/types"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
 	"github.com/elliotchance/pie/v2"
 	"github.com/google/uuid"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 	"k8s.io/utils/ptr"
+	"strings"
 	"sync"
+	"time"
 )
 
 type NfsConfig interface {
 	SetFileSystemLifeCycleState(id string, state efsTypes.LifeCycleState)
 	GetFileSystemById(id string) *efsTypes.FileSystemDescription
+	SetReplicationDestinationStatus(fsId string, status efsTypes.ReplicationStatus)
+	GetLifecyclePolicies(fsId string) []efsTypes.LifecyclePolicy
+	GetBackupPolicy(fsId string) efsTypes.Status
+	GetFileSystemPolicy(fsId string) string
+	SetThroughputUtilization(fsId string, percent *float64)
+	AddFileSystem(name string, tags []efsTypes.Tag) *efsTypes.FileSystemDescription
+	// SetBackupRegion sets the region in the ARNs of the created backup vaults, mock by default
+	SetBackupRegion(region string)
+	GetBackupPlanByName(name string) *backupTypes.BackupPlan
+	GetBackupSelections(planId string) []backupTypes.BackupSelection
+	AddCopyJob(job backupTypes.CopyJob)
+}
+
+type backupPlanItem struct {
+	id         string
+	plan       backupTypes.BackupPlan
+	selections map[string]backupTypes.BackupSelection
 }
 
 type mountTargetItem struct {
@@ -28,12 +49,22 @@ type nfsStore struct {
 	sg           []*ec2Types.SecurityGroup
 	fs           []*efsTypes.FileSystemDescription
 	mountTargets map[string][]mountTargetItem
+	replications map[string]*efsTypes.ReplicationConfigurationDescription
+	lifecycles   map[string][]efsTypes.LifecyclePolicy
+	backups      map[string]efsTypes.Status
+	fsPolicies   map[string]string
+	utilization  map[string]float64
+
+	backupRegion string
+	backupVaults map[string]string
+	backupPlans  []*backupPlanItem
+	copyJobs     []backupTypes.CopyJob
 }
 
 func filterMatchesTags(tags []ec2Types.Tag, filter ec2Types.Filter) bool {
 	for _, t := range tags {
 		tagKey := ptr.Deref(t.Key, "")
-		filterName := ptr.Deref(filter.Name, "")
+		filterName := strings.TrimPrefix(ptr.Deref(filter.Name, ""), "tag:")
 		if tagKey != filterName {
 			continue
 		}
@@ -56,6 +87,16 @@ func anyFilterMatchTags(tags []ec2Types.Tag, filters []ec2Types.Filter) bool {
 	return false
 }
 
+// allFiltersMatchTags matches the tags as AWS does, requiring all the filters to match
+func allFiltersMatchTags(tags []ec2Types.Tag, filters []ec2Types.Filter) bool {
+	for _, f := range filters {
+		if !filterMatchesTags(tags, f) {
+			return false
+		}
+	}
+	return true
+}
+
 // Config =======
 
 func (s *nfsStore) SetFileSystemLifeCycleState(id string, state efsTypes.LifeCycleState) {
@@ -78,8 +119,72 @@ func (s *nfsStore) GetFileSystemById(id string) *efsTypes.FileSystemDescription
 	return nil
 }
 
+func (s *nfsStore) SetReplicationDestinationStatus(fsId string, status efsTypes.ReplicationStatus) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	r, ok := s.replications[fsId]
+	if !ok {
+		return
+	}
+	for i := range r.Destinations {
+		r.Destinations[i].Status = status
+		if status == efsTypes.ReplicationStatusEnabled {
+			r.Destinations[i].LastReplicatedTimestamp = ptr.To(time.Now())
+		}
+	}
+}
+
+func (s *nfsStore) GetLifecyclePolicies(fsId string) []efsTypes.LifecyclePolicy {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]efsTypes.LifecyclePolicy{}, s.lifecycles[fsId]...)
+}
+
+func (s *nfsStore) GetBackupPolicy(fsId string) efsTypes.Status {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.backups[fsId]
+}
+
+func (s *nfsStore) GetFileSystemPolicy(fsId string) string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.fsPolicies[fsId]
+}
+
+func (s *nfsStore) SetThroughputUtilization(fsId string, percent *float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.utilization == nil {
+		s.utilization = map[string]float64{}
+	}
+	if percent == nil {
+		delete(s.utilization, fsId)
+		return
+	}
+	s.utilization[fsId] = *percent
+}
+
 // Client ===============================
 
+func (s *nfsStore) AddFileSystem(name string, tags []efsTypes.Tag) *efsTypes.FileSystemDescription {
+	s.m.Lock()
+	defer s.m.Unlock()
+	id := uuid.NewString()
+	fs := &efsTypes.FileSystemDescription{
+		FileSystemId:    ptr.To(id),
+		FileSystemArn:   ptr.To(fmt.Sprintf("arn:aws:elasticfilesystem:mock::file-system/%s", id)),
+		LifeCycleState:  efsTypes.LifeCycleStateAvailable,
+		PerformanceMode: efsTypes.PerformanceModeGeneralPurpose,
+		ThroughputMode:  efsTypes.ThroughputModeBursting,
+		Tags:            append([]efsTypes.Tag{{Key: ptr.To("Name"), Value: ptr.To(name)}}, tags...),
+		Name:            ptr.To(name),
+	}
+	s.fs = append(s.fs, fs)
+	cpy := *fs
+	return &cpy
+}
+
 func (s *nfsStore) DescribeSecurityGroups(ctx context.Context, filters []ec2Types.Filter, groupIds []string) ([]ec2Types.SecurityGroup, error) {
 	if isContextCanceled(ctx) {
 		return nil, context.Canceled
@@ -153,7 +258,45 @@ func (s *nfsStore) AuthorizeSecurityGroupIngress(ctx context.Context, groupId st
 	if securityGroup == nil {
 		return fmt.Errorf("security group with id %s does not exist", groupId)
 	}
-	securityGroup.IpPermissions = ipPermissions
+	securityGroup.IpPermissions = append(securityGroup.IpPermissions, ipPermissions...)
+	return nil
+}
+
+func (s *nfsStore) RevokeSecurityGroupIngress(ctx context.Context, groupId string, ipPermissions []ec2Types.IpPermission) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	var securityGroup *ec2Types.SecurityGroup
+	for _, sg := range s.sg {
+		if ptr.Deref(sg.GroupId, "") == groupId {
+			securityGroup = sg
+			break
+		}
+	}
+	if securityGroup == nil {
+		return fmt.Errorf("security group with id %s does not exist", groupId)
+	}
+	var result []ec2Types.IpPermission
+	for _, perm := range securityGroup.IpPermissions {
+		for _, revoke := range ipPermissions {
+			if ptr.Deref(perm.IpProtocol, "") != ptr.Deref(revoke.IpProtocol, "") ||
+				ptr.Deref(perm.FromPort, 0) != ptr.Deref(revoke.FromPort, 0) ||
+				ptr.Deref(perm.ToPort, 0) != ptr.Deref(revoke.ToPort, 0) {
+				continue
+			}
+			perm.IpRanges = pie.Filter(perm.IpRanges, func(rng ec2Types.IpRange) bool {
+				return !pie.Any(revoke.IpRanges, func(x ec2Types.IpRange) bool {
+					return ptr.Deref(x.CidrIp, "") == ptr.Deref(rng.CidrIp, "")
+				})
+			})
+		}
+		if len(perm.IpRanges) > 0 {
+			result = append(result, perm)
+		}
+	}
+	securityGroup.IpPermissions = result
 	return nil
 }
 
@@ -170,7 +313,7 @@ func (s *nfsStore) DescribeFileSystems(ctx context.Context) ([]efsTypes.FileSyst
 	return result, nil
 }
 
-func (s *nfsStore) CreateFileSystem(ctx context.Context, performanceMode efsTypes.PerformanceMode, throughputMode efsTypes.ThroughputMode, tags []efsTypes.Tag) (*efs.CreateFileSystemOutput, error) {
+func (s *nfsStore) CreateFileSystem(ctx context.Context, performanceMode efsTypes.PerformanceMode, throughputMode efsTypes.ThroughputMode, provisionedThroughputInMibps *float64, backup *bool, availabilityZoneName string, tags []efsTypes.Tag) (*efs.CreateFileSystemOutput, error) {
 	if isContextCanceled(ctx) {
 		return nil, context.Canceled
 	}
@@ -183,6 +326,7 @@ func (s *nfsStore) CreateFileSystem(ctx context.Context, performanceMode efsType
 	}
 	fs := &efsTypes.FileSystemDescription{
 		FileSystemId:         ptr.To(id),
+		FileSystemArn:        ptr.To(fmt.Sprintf("arn:aws:elasticfilesystem:mock:%s:file-system/%s", awsmeta.GetAwsAccountId(ctx), id)),
 		LifeCycleState:       efsTypes.LifeCycleStateAvailable,
 		NumberOfMountTargets: 0,
 		PerformanceMode:      performanceMode,
@@ -190,7 +334,22 @@ func (s *nfsStore) CreateFileSystem(ctx context.Context, performanceMode efsType
 		Name:                 ptr.To(name),
 		ThroughputMode:       throughputMode,
 	}
+	if len(availabilityZoneName) > 0 {
+		fs.AvailabilityZoneName = ptr.To(availabilityZoneName)
+	}
+	if throughputMode == efsTypes.ThroughputModeProvisioned {
+		fs.ProvisionedThroughputInMibps = provisionedThroughputInMibps
+	}
 	s.fs = append(s.fs, fs)
+	if backup != nil {
+		if s.backups == nil {
+			s.backups = map[string]efsTypes.Status{}
+		}
+		s.backups[id] = efsTypes.StatusDisabled
+		if *backup {
+			s.backups[id] = efsTypes.StatusEnabled
+		}
+	}
 
 	return &efs.CreateFileSystemOutput{
 		CreationTime:                 fs.CreationTime,
@@ -226,6 +385,45 @@ func (s *nfsStore) DeleteFileSystem(ctx context.Context, fsId string) error {
 	return nil
 }
 
+func (s *nfsStore) TagResource(ctx context.Context, fsId string, tags []efsTypes.Tag) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, fs := range s.fs {
+		if ptr.Deref(fs.FileSystemId, "") != fsId {
+			continue
+		}
+		for _, t := range tags {
+			fs.Tags = pie.Filter(fs.Tags, func(x efsTypes.Tag) bool {
+				return ptr.Deref(x.Key, "") != ptr.Deref(t.Key, "")
+			})
+			fs.Tags = append(fs.Tags, t)
+		}
+		return nil
+	}
+	return &efsTypes.FileSystemNotFound{Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId))}
+}
+
+func (s *nfsStore) UntagResource(ctx context.Context, fsId string, tagKeys []string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, fs := range s.fs {
+		if ptr.Deref(fs.FileSystemId, "") != fsId {
+			continue
+		}
+		fs.Tags = pie.Filter(fs.Tags, func(x efsTypes.Tag) bool {
+			return !pie.Contains(tagKeys, ptr.Deref(x.Key, ""))
+		})
+		return nil
+	}
+	return &efsTypes.FileSystemNotFound{Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId))}
+}
+
 func (s *nfsStore) DescribeMountTargets(ctx context.Context, fsId string) ([]efsTypes.MountTargetDescription, error) {
 	if isContextCanceled(ctx) {
 		return nil, context.Canceled
@@ -244,7 +442,7 @@ func (s *nfsStore) DescribeMountTargets(ctx context.Context, fsId string) ([]efs
 	}), nil
 }
 
-func (s *nfsStore) CreateMountTarget(ctx context.Context, fsId, subnetId string, securityGroups []string) (string, error) {
+func (s *nfsStore) CreateMountTarget(ctx context.Context, fsId, subnetId, ipAddress string, securityGroups []string) (string, error) {
 	if isContextCanceled(ctx) {
 		return "", context.Canceled
 	}
@@ -253,6 +451,17 @@ func (s *nfsStore) CreateMountTarget(ctx context.Context, fsId, subnetId string,
 	if s.mountTargets == nil {
 		s.mountTargets = map[string][]mountTargetItem{}
 	}
+	if len(ipAddress) > 0 {
+		for _, list := range s.mountTargets {
+			for _, mt := range list {
+				if ptr.Deref(mt.desc.IpAddress, "") == ipAddress {
+					return "", &efsTypes.IpAddressInUse{Message: ptr.To(fmt.Sprintf("IP address %s is already in use", ipAddress))}
+				}
+			}
+		}
+	} else {
+		ipAddress = "1.2.3.4"
+	}
 	list := s.mountTargets[fsId]
 	id := uuid.NewString()
 	item := mountTargetItem{
@@ -261,7 +470,7 @@ func (s *nfsStore) CreateMountTarget(ctx context.Context, fsId, subnetId string,
 			LifeCycleState: efsTypes.LifeCycleStateAvailable,
 			MountTargetId:  ptr.To(id),
 			SubnetId:       ptr.To(subnetId),
-			IpAddress:      ptr.To("1.2.3.4"),
+			IpAddress:      ptr.To(ipAddress),
 		},
 		sg: securityGroups,
 	}
@@ -310,3 +519,528 @@ func (s *nfsStore) DescribeMountTargetSecurityGroups(ctx context.Context, mountT
 	}
 	return nil, fmt.Errorf("mount target with id %s does not exist", mountTargetId)
 }
+
+func (s *nfsStore) ModifyMountTargetSecurityGroups(ctx context.Context, mountTargetId string, securityGroups []string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, list := range s.mountTargets {
+		for i := range list {
+			if ptr.Deref(list[i].desc.MountTargetId, "") == mountTargetId {
+				list[i].sg = append([]string{}, securityGroups...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("mount target with id %s does not exist", mountTargetId)
+}
+
+func (s *nfsStore) DescribeReplicationConfigurations(ctx context.Context, fsId string) ([]efsTypes.ReplicationConfigurationDescription, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	r, ok := s.replications[fsId]
+	if !ok {
+		return nil, &efsTypes.ReplicationNotFound{
+			Message: ptr.To(fmt.Sprintf("replication configuration for file system %s does not exist", fsId)),
+		}
+	}
+	return []efsTypes.ReplicationConfigurationDescription{*r}, nil
+}
+
+func (s *nfsStore) CreateReplicationConfiguration(ctx context.Context, fsId, destinationRegion string) (*efs.CreateReplicationConfigurationOutput, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.replications == nil {
+		s.replications = map[string]*efsTypes.ReplicationConfigurationDescription{}
+	}
+	if _, ok := s.replications[fsId]; ok {
+		return nil, fmt.Errorf("replication configuration for file system %s already exists", fsId)
+	}
+	destinationId := uuid.NewString()
+	s.fs = append(s.fs, &efsTypes.FileSystemDescription{
+		FileSystemId:   ptr.To(destinationId),
+		LifeCycleState: efsTypes.LifeCycleStateAvailable,
+		Name:           ptr.To(destinationId),
+		FileSystemProtection: &efsTypes.FileSystemProtectionDescription{
+			ReplicationOverwriteProtection: efsTypes.ReplicationOverwriteProtectionReplicating,
+		},
+	})
+	r := &efsTypes.ReplicationConfigurationDescription{
+		CreationTime:       ptr.To(time.Now()),
+		SourceFileSystemId: ptr.To(fsId),
+		Destinations: []efsTypes.Destination{
+			{
+				FileSystemId: ptr.To(destinationId),
+				Region:       ptr.To(destinationRegion),
+				Status:       efsTypes.ReplicationStatusEnabling,
+			},
+		},
+	}
+	s.replications[fsId] = r
+	return &efs.CreateReplicationConfigurationOutput{
+		CreationTime:       r.CreationTime,
+		Destinations:       r.Destinations,
+		SourceFileSystemId: r.SourceFileSystemId,
+	}, nil
+}
+
+func (s *nfsStore) DeleteReplicationConfiguration(ctx context.Context, fsId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if r, ok := s.replications[fsId]; ok {
+		// destination becomes writable, and its overwrite protection re-enabled
+		for _, d := range r.Destinations {
+			for _, fs := range s.fs {
+				if ptr.Deref(fs.FileSystemId, "") == ptr.Deref(d.FileSystemId, "") {
+					fs.FileSystemProtection = &efsTypes.FileSystemProtectionDescription{
+						ReplicationOverwriteProtection: efsTypes.ReplicationOverwriteProtectionEnabled,
+					}
+				}
+			}
+		}
+	}
+	delete(s.replications, fsId)
+	return nil
+}
+
+func (s *nfsStore) DescribeLifecycleConfiguration(ctx context.Context, fsId string) ([]efsTypes.LifecyclePolicy, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return nil, &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	return append([]efsTypes.LifecyclePolicy{}, s.lifecycles[fsId]...), nil
+}
+
+func (s *nfsStore) PutLifecycleConfiguration(ctx context.Context, fsId string, policies []efsTypes.LifecyclePolicy) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	if s.lifecycles == nil {
+		s.lifecycles = map[string][]efsTypes.LifecyclePolicy{}
+	}
+	s.lifecycles[fsId] = append([]efsTypes.LifecyclePolicy{}, policies...)
+	return nil
+}
+
+func (s *nfsStore) DescribeBackupPolicy(ctx context.Context, fsId string) (efsTypes.Status, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return "", &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	status, ok := s.backups[fsId]
+	if !ok {
+		return "", &efsTypes.PolicyNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s has no backup policy", fsId)),
+		}
+	}
+	return status, nil
+}
+
+func (s *nfsStore) PutBackupPolicy(ctx context.Context, fsId string, status efsTypes.Status) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	if s.backups == nil {
+		s.backups = map[string]efsTypes.Status{}
+	}
+	s.backups[fsId] = status
+	return nil
+}
+
+func (s *nfsStore) DescribeFileSystemPolicy(ctx context.Context, fsId string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return "", &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	policy, ok := s.fsPolicies[fsId]
+	if !ok {
+		return "", &efsTypes.PolicyNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s has no file system policy", fsId)),
+		}
+	}
+	return policy, nil
+}
+
+func (s *nfsStore) PutFileSystemPolicy(ctx context.Context, fsId, policy string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	if s.fsPolicies == nil {
+		s.fsPolicies = map[string]string{}
+	}
+	s.fsPolicies[fsId] = policy
+	return nil
+}
+
+func (s *nfsStore) DeleteFileSystemPolicy(ctx context.Context, fsId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !pie.Any(s.fs, func(fs *efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == fsId
+	}) {
+		return &efsTypes.FileSystemNotFound{
+			Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+		}
+	}
+	delete(s.fsPolicies, fsId)
+	return nil
+}
+
+func (s *nfsStore) UpdateProvisionedThroughput(ctx context.Context, fsId string, mibps float64) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, fs := range s.fs {
+		if ptr.Deref(fs.FileSystemId, "") == fsId {
+			fs.ThroughputMode = efsTypes.ThroughputModeProvisioned
+			fs.ProvisionedThroughputInMibps = ptr.To(mibps)
+			return nil
+		}
+	}
+	return &efsTypes.FileSystemNotFound{
+		Message: ptr.To(fmt.Sprintf("file system %s does not exist", fsId)),
+	}
+}
+
+func (s *nfsStore) GetThroughputUtilization(ctx context.Context, fsId string, period time.Duration) (*float64, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	v, ok := s.utilization[fsId]
+	if !ok {
+		return nil, nil
+	}
+	return ptr.To(v), nil
+}
+
+// Backup Config =======
+
+func (s *nfsStore) SetBackupRegion(region string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.backupRegion = region
+}
+
+func (s *nfsStore) GetBackupPlanByName(name string) *backupTypes.BackupPlan {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, item := range s.backupPlans {
+		if ptr.Deref(item.plan.BackupPlanName, "") == name {
+			plan := item.plan
+			return &plan
+		}
+	}
+	return nil
+}
+
+func (s *nfsStore) GetBackupSelections(planId string) []backupTypes.BackupSelection {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var result []backupTypes.BackupSelection
+	for _, item := range s.backupPlans {
+		if item.id == planId {
+			for _, sel := range item.selections {
+				result = append(result, sel)
+			}
+		}
+	}
+	return result
+}
+
+func (s *nfsStore) AddCopyJob(job backupTypes.CopyJob) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if job.CreationDate == nil {
+		job.CreationDate = ptr.To(time.Now())
+	}
+	s.copyJobs = append(s.copyJobs, job)
+}
+
+// Backup Client =======
+
+func (s *nfsStore) findBackupPlan(planId string) (*backupPlanItem, error) {
+	for _, item := range s.backupPlans {
+		if item.id == planId {
+			return item, nil
+		}
+	}
+	return nil, &backupTypes.ResourceNotFoundException{
+		Message: ptr.To(fmt.Sprintf("backup plan %s does not exist", planId)),
+	}
+}
+
+func (s *nfsStore) DescribeBackupVault(ctx context.Context, name string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	arn, ok := s.backupVaults[name]
+	if !ok {
+		return "", &backupTypes.ResourceNotFoundException{
+			Message: ptr.To(fmt.Sprintf("backup vault %s does not exist", name)),
+		}
+	}
+	return arn, nil
+}
+
+func (s *nfsStore) CreateBackupVault(ctx context.Context, name string, tags map[string]string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, ok := s.backupVaults[name]; ok {
+		return "", &backupTypes.AlreadyExistsException{
+			Message: ptr.To(fmt.Sprintf("backup vault %s already exists", name)),
+		}
+	}
+	if s.backupVaults == nil {
+		s.backupVaults = map[string]string{}
+	}
+	region := s.backupRegion
+	if region == "" {
+		region = "mock"
+	}
+	arn := fmt.Sprintf("arn:aws:backup:%s:%s:backup-vault:%s", region, awsmeta.GetAwsAccountId(ctx), name)
+	s.backupVaults[name] = arn
+	return arn, nil
+}
+
+func (s *nfsStore) ListBackupPlans(ctx context.Context) ([]backupTypes.BackupPlansListMember, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	var result []backupTypes.BackupPlansListMember
+	for _, item := range s.backupPlans {
+		result = append(result, backupTypes.BackupPlansListMember{
+			BackupPlanId:   ptr.To(item.id),
+			BackupPlanName: item.plan.BackupPlanName,
+		})
+	}
+	return result, nil
+}
+
+func (s *nfsStore) GetBackupPlan(ctx context.Context, planId string) (*backupTypes.BackupPlan, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return nil, err
+	}
+	plan := item.plan
+	return &plan, nil
+}
+
+func backupPlanFromInput(in *backupTypes.BackupPlanInput) backupTypes.BackupPlan {
+	plan := backupTypes.BackupPlan{BackupPlanName: in.BackupPlanName}
+	for _, r := range in.Rules {
+		plan.Rules = append(plan.Rules, backupTypes.BackupRule{
+			RuleId:                ptr.To(uuid.NewString()),
+			RuleName:              r.RuleName,
+			TargetBackupVaultName: r.TargetBackupVaultName,
+			ScheduleExpression:    r.ScheduleExpression,
+			Lifecycle:             r.Lifecycle,
+			CopyActions:           append([]backupTypes.CopyAction(nil), r.CopyActions...),
+		})
+	}
+	return plan
+}
+
+func (s *nfsStore) CreateBackupPlan(ctx context.Context, plan *backupTypes.BackupPlanInput, tags map[string]string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, item := range s.backupPlans {
+		if ptr.Deref(item.plan.BackupPlanName, "") == ptr.Deref(plan.BackupPlanName, "") {
+			return "", &backupTypes.AlreadyExistsException{
+				Message: ptr.To(fmt.Sprintf("backup plan %s already exists", ptr.Deref(plan.BackupPlanName, ""))),
+			}
+		}
+	}
+	item := &backupPlanItem{
+		id:         uuid.NewString(),
+		plan:       backupPlanFromInput(plan),
+		selections: map[string]backupTypes.BackupSelection{},
+	}
+	s.backupPlans = append(s.backupPlans, item)
+	return item.id, nil
+}
+
+func (s *nfsStore) UpdateBackupPlan(ctx context.Context, planId string, plan *backupTypes.BackupPlanInput) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return err
+	}
+	item.plan = backupPlanFromInput(plan)
+	return nil
+}
+
+func (s *nfsStore) DeleteBackupPlan(ctx context.Context, planId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return err
+	}
+	if len(item.selections) > 0 {
+		return &backupTypes.InvalidRequestException{
+			Message: ptr.To(fmt.Sprintf("backup plan %s has selections", planId)),
+		}
+	}
+	s.backupPlans = pie.Filter(s.backupPlans, func(x *backupPlanItem) bool {
+		return x.id != planId
+	})
+	return nil
+}
+
+func (s *nfsStore) ListBackupSelections(ctx context.Context, planId string) ([]backupTypes.BackupSelectionsListMember, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return nil, err
+	}
+	var result []backupTypes.BackupSelectionsListMember
+	for id, sel := range item.selections {
+		result = append(result, backupTypes.BackupSelectionsListMember{
+			BackupPlanId:  ptr.To(planId),
+			SelectionId:   ptr.To(id),
+			SelectionName: sel.SelectionName,
+			IamRoleArn:    sel.IamRoleArn,
+		})
+	}
+	return result, nil
+}
+
+func (s *nfsStore) CreateBackupSelection(ctx context.Context, planId string, selection *backupTypes.BackupSelection) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return "", err
+	}
+	id := uuid.NewString()
+	item.selections[id] = *selection
+	return id, nil
+}
+
+func (s *nfsStore) DeleteBackupSelection(ctx context.Context, planId, selectionId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.findBackupPlan(planId)
+	if err != nil {
+		return err
+	}
+	if _, ok := item.selections[selectionId]; !ok {
+		return &backupTypes.ResourceNotFoundException{
+			Message: ptr.To(fmt.Sprintf("backup selection %s does not exist", selectionId)),
+		}
+	}
+	delete(item.selections, selectionId)
+	return nil
+}
+
+func (s *nfsStore) ListCopyJobs(ctx context.Context, resourceArn string, createdAfter time.Time) ([]backupTypes.CopyJob, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return pie.Filter(s.copyJobs, func(job backupTypes.CopyJob) bool {
+		return ptr.Deref(job.ResourceArn, "") == resourceArn && ptr.Deref(job.CreationDate, time.Time{}).After(createdAfter)
+	}), nil
+}
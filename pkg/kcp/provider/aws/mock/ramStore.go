@@ -0,0 +1,210 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	ramtypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/google/uuid"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+	"sync"
+	"time"
+)
+
+type ResourceShareConfig interface {
+	GetResourceShareByName(name string) *ramtypes.ResourceShare
+	GetResourceShareAssociationsByName(name string) []ramtypes.ResourceShareAssociation
+}
+
+type resourceShareItem struct {
+	share        *ramtypes.ResourceShare
+	associations []ramtypes.ResourceShareAssociation
+}
+
+type ramStore struct {
+	m      sync.Mutex
+	shares []*resourceShareItem
+}
+
+func (s *ramStore) itemByArn(arn string) (*resourceShareItem, error) {
+	for _, item := range s.shares {
+		if ptr.Deref(item.share.ResourceShareArn, "") == arn {
+			return item, nil
+		}
+	}
+	return nil, &ramtypes.UnknownResourceException{
+		Message: ptr.To(fmt.Sprintf("resource share %s does not exist", arn)),
+	}
+}
+
+func (s *ramStore) itemByName(name string) *resourceShareItem {
+	for _, item := range s.shares {
+		if ptr.Deref(item.share.Name, "") == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func (item *resourceShareItem) associate(associationType ramtypes.ResourceShareAssociationType, entities []string) {
+	for _, entity := range entities {
+		found := false
+		for _, a := range item.associations {
+			if a.AssociationType == associationType && ptr.Deref(a.AssociatedEntity, "") == entity {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		item.associations = append(item.associations, ramtypes.ResourceShareAssociation{
+			AssociatedEntity:  ptr.To(entity),
+			AssociationType:   associationType,
+			CreationTime:      ptr.To(time.Now()),
+			External:          ptr.To(false),
+			ResourceShareArn:  item.share.ResourceShareArn,
+			ResourceShareName: item.share.Name,
+			Status:            ramtypes.ResourceShareAssociationStatusAssociated,
+		})
+	}
+}
+
+func (item *resourceShareItem) disassociate(associationType ramtypes.ResourceShareAssociationType, entities []string) {
+	for _, entity := range entities {
+		for i, a := range item.associations {
+			if a.AssociationType == associationType && ptr.Deref(a.AssociatedEntity, "") == entity {
+				item.associations = append(item.associations[:i], item.associations[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Config =======
+
+func (s *ramStore) GetResourceShareByName(name string) *ramtypes.ResourceShare {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item := s.itemByName(name)
+	if item == nil {
+		return nil
+	}
+	return item.share
+}
+
+func (s *ramStore) GetResourceShareAssociationsByName(name string) []ramtypes.ResourceShareAssociation {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item := s.itemByName(name)
+	if item == nil {
+		return nil
+	}
+	return append([]ramtypes.ResourceShareAssociation{}, item.associations...)
+}
+
+// Client =======
+
+func (s *ramStore) GetResourceShare(ctx context.Context, name string) (*ramtypes.ResourceShare, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item := s.itemByName(name)
+	if item == nil {
+		return nil, nil
+	}
+	share := *item.share
+	return &share, nil
+}
+
+func (s *ramStore) CreateResourceShare(ctx context.Context, name string, resourceArns, principals []string, tags []ramtypes.Tag) (*ramtypes.ResourceShare, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item := &resourceShareItem{
+		share: &ramtypes.ResourceShare{
+			AllowExternalPrincipals: ptr.To(false),
+			CreationTime:            ptr.To(time.Now()),
+			Name:                    ptr.To(name),
+			OwningAccountId:         ptr.To(awsmeta.GetAwsAccountId(ctx)),
+			ResourceShareArn:        ptr.To(fmt.Sprintf("arn:aws:ram:mock:%s:resource-share/%s", awsmeta.GetAwsAccountId(ctx), uuid.NewString())),
+			Status:                  ramtypes.ResourceShareStatusActive,
+			Tags:                    tags,
+		},
+	}
+	item.associate(ramtypes.ResourceShareAssociationTypeResource, resourceArns)
+	item.associate(ramtypes.ResourceShareAssociationTypePrincipal, principals)
+	s.shares = append(s.shares, item)
+	share := *item.share
+	return &share, nil
+}
+
+func (s *ramStore) DeleteResourceShare(ctx context.Context, resourceShareArn string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i, item := range s.shares {
+		if ptr.Deref(item.share.ResourceShareArn, "") == resourceShareArn {
+			s.shares = append(s.shares[:i], s.shares[i+1:]...)
+			return nil
+		}
+	}
+	return &ramtypes.UnknownResourceException{
+		Message: ptr.To(fmt.Sprintf("resource share %s does not exist", resourceShareArn)),
+	}
+}
+
+func (s *ramStore) GetResourceShareAssociations(ctx context.Context, resourceShareArn string, associationType ramtypes.ResourceShareAssociationType) ([]ramtypes.ResourceShareAssociation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.itemByArn(resourceShareArn)
+	if err != nil {
+		return nil, err
+	}
+	var result []ramtypes.ResourceShareAssociation
+	for _, a := range item.associations {
+		if a.AssociationType == associationType {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (s *ramStore) AssociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.itemByArn(resourceShareArn)
+	if err != nil {
+		return err
+	}
+	item.associate(ramtypes.ResourceShareAssociationTypeResource, resourceArns)
+	item.associate(ramtypes.ResourceShareAssociationTypePrincipal, principals)
+	return nil
+}
+
+func (s *ramStore) DisassociateResourceShare(ctx context.Context, resourceShareArn string, resourceArns, principals []string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.itemByArn(resourceShareArn)
+	if err != nil {
+		return err
+	}
+	item.disassociate(ramtypes.ResourceShareAssociationTypeResource, resourceArns)
+	item.disassociate(ramtypes.ResourceShareAssociationTypePrincipal, principals)
+	return nil
+}
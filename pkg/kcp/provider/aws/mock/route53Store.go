@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+)
+
+type Route53Config interface {
+	// AddHostedZone adds the private hosted zone with the given id and DNS name ending with the dot
+	AddHostedZone(zoneId, dnsName string)
+	GetDnsRecord(zoneId, name, recordType string) *kcpdns.Record
+}
+
+type route53Store struct {
+	m       sync.Mutex
+	zones   map[string]string
+	records map[string]*kcpdns.Record
+}
+
+func route53RecordKey(zoneId, name, recordType string) string {
+	return fmt.Sprintf("%s/%s/%s", zoneId, name, recordType)
+}
+
+func (s *route53Store) AddHostedZone(zoneId, dnsName string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.zones == nil {
+		s.zones = map[string]string{}
+	}
+	s.zones[zoneId] = dnsName
+}
+
+func (s *route53Store) GetDnsRecord(zoneId, name, recordType string) *kcpdns.Record {
+	s.m.Lock()
+	defer s.m.Unlock()
+	record, ok := s.records[route53RecordKey(zoneId, name, recordType)]
+	if !ok {
+		return nil
+	}
+	cpy := *record
+	cpy.Values = append([]string{}, record.Values...)
+	return &cpy
+}
+
+func (s *route53Store) getZone(zoneId string) (string, error) {
+	dnsName, ok := s.zones[strings.TrimPrefix(zoneId, "/hostedzone/")]
+	if !ok {
+		return "", &smithy.GenericAPIError{
+			Code:    "NoSuchHostedZone",
+			Message: fmt.Sprintf("No hosted zone found with ID: %s", zoneId),
+		}
+	}
+	return dnsName, nil
+}
+
+func (s *route53Store) GetZoneDnsName(ctx context.Context, zoneName string) (string, error) {
+	if isContextCanceled(ctx) {
+		return "", context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.getZone(zoneName)
+}
+
+func (s *route53Store) GetRecord(ctx context.Context, zoneName, name, recordType string) (*kcpdns.Record, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	_, err := s.getZone(zoneName)
+	s.m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetDnsRecord(strings.TrimPrefix(zoneName, "/hostedzone/"), name, recordType), nil
+}
+
+func (s *route53Store) UpsertRecord(ctx context.Context, zoneName string, record kcpdns.Record) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, err := s.getZone(zoneName); err != nil {
+		return err
+	}
+	if s.records == nil {
+		s.records = map[string]*kcpdns.Record{}
+	}
+	cpy := record
+	cpy.Values = append([]string{}, record.Values...)
+	s.records[route53RecordKey(strings.TrimPrefix(zoneName, "/hostedzone/"), record.Name, record.Type)] = &cpy
+	return nil
+}
+
+func (s *route53Store) DeleteRecord(ctx context.Context, zoneName, name, recordType string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, err := s.getZone(zoneName); err != nil {
+		return err
+	}
+	delete(s.records, route53RecordKey(strings.TrimPrefix(zoneName, "/hostedzone/"), name, recordType))
+	return nil
+}
@@ -2,7 +2,9 @@ package mock
 
 import (
 	"context"
+	"fmt"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/elliotchance/pie/v2"
 	"k8s.io/utils/ptr"
 	"sync"
@@ -88,3 +90,47 @@ func (s *routeTablesStore) DeleteRoute(ctx context.Context, routeTableId, destin
 
 	return nil
 }
+
+func (s *routeTablesStore) CreateIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock, egressOnlyInternetGatewayId string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	entry := pie.First(pie.Filter(s.items, func(r *routeTableEntry) bool {
+		return ptr.Deref(r.routeTable.RouteTableId, "") == routeTableId
+	}))
+	if entry == nil {
+		return &smithy.GenericAPIError{
+			Code:    "InvalidRouteTableID.NotFound",
+			Message: fmt.Sprintf("route table %s does not exist", routeTableId),
+		}
+	}
+
+	entry.routeTable.Routes = append(entry.routeTable.Routes, ec2types.Route{
+		DestinationIpv6CidrBlock:    ptr.To(destinationIpv6CidrBlock),
+		EgressOnlyInternetGatewayId: ptr.To(egressOnlyInternetGatewayId),
+		State:                       ec2types.RouteStateActive,
+	})
+
+	return nil
+}
+
+func (s *routeTablesStore) DeleteIpv6Route(ctx context.Context, routeTableId, destinationIpv6CidrBlock string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	entry := pie.First(pie.Filter(s.items, func(r *routeTableEntry) bool {
+		return ptr.Deref(r.routeTable.RouteTableId, "") == routeTableId
+	}))
+	if entry == nil {
+		return &smithy.GenericAPIError{
+			Code:    "InvalidRouteTableID.NotFound",
+			Message: fmt.Sprintf("route table %s does not exist", routeTableId),
+		}
+	}
+
+	entry.routeTable.Routes = pie.Filter(entry.routeTable.Routes, func(r ec2types.Route) bool {
+		return ptr.Deref(r.DestinationIpv6CidrBlock, "") != destinationIpv6CidrBlock
+	})
+
+	return nil
+}
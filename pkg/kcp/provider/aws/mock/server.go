@@ -4,9 +4,12 @@ import (
 	"context"
 	"sync"
 
+	cloudwatchlogsTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	firehoseTypes "github.com/aws/aws-sdk-go-v2/service/firehose/types"
 	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
 	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
@@ -21,24 +24,35 @@ func New() Server {
 	return &server{
 		vpcStore:         &vpcStore{},
 		nfsStore:         &nfsStore{},
+		iamStore:         &iamStore{},
+		ramStore:         &ramStore{},
+		tgwStore:         &tgwStore{},
+		eigwStore:        &eigwStore{},
 		scopeStore:       &scopeStore{},
 		vpcPeeringStore:  &vpcPeeringStore{},
 		routeTablesStore: &routeTablesStore{},
+		ipamStore:        &ipamStore{},
+		route53Store:     &route53Store{},
 		elastiCacheClientFake: &elastiCacheClientFake{
-			elasticacheMutex:    &sync.Mutex{},
-			subnetGroupMutex:    &sync.Mutex{},
-			parameterGroupMutex: &sync.Mutex{},
-			secretStoreMutex:    &sync.Mutex{},
-			userGroupsMutex:     &sync.Mutex{},
-			securityGroupsMutex: &sync.Mutex{},
-			replicationGroups:   map[string]*elasticacheTypes.ReplicationGroup{},
-			cacheClusters:       map[string]*elasticacheTypes.CacheCluster{},
-			subnetGroups:        map[string]*elasticacheTypes.CacheSubnetGroup{},
-			parameterGroups:     map[string]*elasticacheTypes.CacheParameterGroup{},
-			parameters:          map[string]map[string]elasticacheTypes.Parameter{},
-			secretStore:         map[string]*secretsmanager.GetSecretValueOutput{},
-			userGroups:          map[string]*elasticacheTypes.UserGroup{},
-			securityGroups:      []*ec2Types.SecurityGroup{},
+			elasticacheMutex:        &sync.Mutex{},
+			subnetGroupMutex:        &sync.Mutex{},
+			parameterGroupMutex:     &sync.Mutex{},
+			secretStoreMutex:        &sync.Mutex{},
+			userGroupsMutex:         &sync.Mutex{},
+			securityGroupsMutex:     &sync.Mutex{},
+			replicationGroups:       map[string]*elasticacheTypes.ReplicationGroup{},
+			globalReplicationGroups: map[string]*elasticacheTypes.GlobalReplicationGroup{},
+			cacheClusters:           map[string]*elasticacheTypes.CacheCluster{},
+			subnetGroups:            map[string]*elasticacheTypes.CacheSubnetGroup{},
+			parameterGroups:         map[string]*elasticacheTypes.CacheParameterGroup{},
+			parameters:              map[string]map[string]elasticacheTypes.Parameter{},
+			secretStore:             map[string]*secretsmanager.GetSecretValueOutput{},
+			userGroups:              map[string]*elasticacheTypes.UserGroup{},
+			securityGroups:          []*ec2Types.SecurityGroup{},
+			logGroups:               map[string]*cloudwatchlogsTypes.LogGroup{},
+			deliveryStreams:         map[string]*firehoseTypes.DeliveryStreamDescription{},
+			tags:                    map[string][]elasticacheTypes.Tag{},
+			deniedResourceArns:      map[string]bool{},
 		},
 	}
 }
@@ -46,10 +60,16 @@ func New() Server {
 type server struct {
 	*vpcStore
 	*nfsStore
+	*iamStore
+	*ramStore
+	*tgwStore
+	*eigwStore
 	*scopeStore
 	*vpcPeeringStore
 	*elastiCacheClientFake
 	*routeTablesStore
+	*ipamStore
+	*route53Store
 }
 
 func (s *server) ScopeGardenProvider() awsclient.GardenClientProvider[scopeclient.AwsStsClient] {
@@ -81,3 +101,9 @@ func (s *server) ElastiCacheProviderFake() awsclient.SkrClientProvider[redisinst
 		return s, nil
 	}
 }
+
+func (s *server) Route53SkrProvider() awsclient.SkrClientProvider[kcpdns.Client] {
+	return func(ctx context.Context, region, key, secret, role string) (kcpdns.Client, error) {
+		return s, nil
+	}
+}
@@ -0,0 +1,230 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/google/uuid"
+	"k8s.io/utils/ptr"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TransitGatewayConfig interface {
+	AddTransitGateway(tgwId string, state ec2types.TransitGatewayState)
+	GetTransitGatewayVpcAttachmentById(attachmentId string) *ec2types.TransitGatewayVpcAttachment
+	SetTransitGatewayVpcAttachmentState(attachmentId string, state ec2types.TransitGatewayAttachmentState)
+	GetTransitGatewayAttachmentPropagationsById(attachmentId string) []ec2types.TransitGatewayAttachmentPropagation
+}
+
+type tgwAttachmentItem struct {
+	attachment   *ec2types.TransitGatewayVpcAttachment
+	association  *ec2types.TransitGatewayAttachmentAssociation
+	propagations []ec2types.TransitGatewayAttachmentPropagation
+}
+
+type tgwStore struct {
+	m           sync.Mutex
+	gateways    []*ec2types.TransitGateway
+	attachments []*tgwAttachmentItem
+}
+
+func (s *tgwStore) attachmentById(attachmentId string) (*tgwAttachmentItem, error) {
+	for _, item := range s.attachments {
+		if ptr.Deref(item.attachment.TransitGatewayAttachmentId, "") == attachmentId &&
+			item.attachment.State != ec2types.TransitGatewayAttachmentStateDeleted {
+			return item, nil
+		}
+	}
+	return nil, &smithy.GenericAPIError{
+		Code:    "InvalidTransitGatewayAttachmentID.NotFound",
+		Message: fmt.Sprintf("transit gateway attachment %s does not exist", attachmentId),
+	}
+}
+
+// Config ===============================
+
+func (s *tgwStore) AddTransitGateway(tgwId string, state ec2types.TransitGatewayState) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.gateways = append(s.gateways, &ec2types.TransitGateway{
+		TransitGatewayId: ptr.To(tgwId),
+		State:            state,
+		CreationTime:     ptr.To(time.Now()),
+	})
+}
+
+func (s *tgwStore) GetTransitGatewayVpcAttachmentById(attachmentId string) *ec2types.TransitGatewayVpcAttachment {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return nil
+	}
+	return item.attachment
+}
+
+func (s *tgwStore) SetTransitGatewayVpcAttachmentState(attachmentId string, state ec2types.TransitGatewayAttachmentState) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return
+	}
+	item.attachment.State = state
+}
+
+func (s *tgwStore) GetTransitGatewayAttachmentPropagationsById(attachmentId string) []ec2types.TransitGatewayAttachmentPropagation {
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return nil
+	}
+	return append([]ec2types.TransitGatewayAttachmentPropagation{}, item.propagations...)
+}
+
+// Client ===============================
+
+func (s *tgwStore) DescribeTransitGateway(ctx context.Context, tgwId string) (*ec2types.TransitGateway, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, tgw := range s.gateways {
+		if ptr.Deref(tgw.TransitGatewayId, "") == tgwId {
+			cpy := *tgw
+			return &cpy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *tgwStore) DescribeTransitGatewayVpcAttachments(ctx context.Context, vpcId string) ([]ec2types.TransitGatewayVpcAttachment, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	var result []ec2types.TransitGatewayVpcAttachment
+	for _, item := range s.attachments {
+		if ptr.Deref(item.attachment.VpcId, "") == vpcId {
+			result = append(result, *item.attachment)
+		}
+	}
+	return result, nil
+}
+
+func (s *tgwStore) CreateTransitGatewayVpcAttachment(ctx context.Context, tgwId, vpcId string, subnetIds []string, tags []ec2types.Tag) (*ec2types.TransitGatewayVpcAttachment, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	found := false
+	for _, tgw := range s.gateways {
+		if ptr.Deref(tgw.TransitGatewayId, "") == tgwId && tgw.State == ec2types.TransitGatewayStateAvailable {
+			found = true
+		}
+	}
+	if !found {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InvalidTransitGatewayID.NotFound",
+			Message: fmt.Sprintf("transit gateway %s does not exist", tgwId),
+		}
+	}
+	attachment := &ec2types.TransitGatewayVpcAttachment{
+		TransitGatewayAttachmentId: ptr.To("tgw-attach-" + strings.ReplaceAll(uuid.NewString(), "-", "")[:17]),
+		TransitGatewayId:           ptr.To(tgwId),
+		VpcId:                      ptr.To(vpcId),
+		SubnetIds:                  append([]string{}, subnetIds...),
+		State:                      ec2types.TransitGatewayAttachmentStatePending,
+		Tags:                       append([]ec2types.Tag{}, tags...),
+		CreationTime:               ptr.To(time.Now()),
+	}
+	s.attachments = append(s.attachments, &tgwAttachmentItem{attachment: attachment})
+	cpy := *attachment
+	return &cpy, nil
+}
+
+func (s *tgwStore) DeleteTransitGatewayVpcAttachment(ctx context.Context, attachmentId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return err
+	}
+	item.attachment.State = ec2types.TransitGatewayAttachmentStateDeleted
+	return nil
+}
+
+func (s *tgwStore) GetTransitGatewayAttachmentAssociation(ctx context.Context, attachmentId string) (*ec2types.TransitGatewayAttachmentAssociation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	return item.association, nil
+}
+
+func (s *tgwStore) AssociateTransitGatewayRouteTable(ctx context.Context, routeTableId, attachmentId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return err
+	}
+	item.association = &ec2types.TransitGatewayAttachmentAssociation{
+		TransitGatewayRouteTableId: ptr.To(routeTableId),
+		State:                      ec2types.TransitGatewayAssociationStateAssociated,
+	}
+	return nil
+}
+
+func (s *tgwStore) GetTransitGatewayAttachmentPropagations(ctx context.Context, attachmentId string) ([]ec2types.TransitGatewayAttachmentPropagation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	return append([]ec2types.TransitGatewayAttachmentPropagation{}, item.propagations...), nil
+}
+
+func (s *tgwStore) EnableTransitGatewayRouteTablePropagation(ctx context.Context, routeTableId, attachmentId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	item, err := s.attachmentById(attachmentId)
+	if err != nil {
+		return err
+	}
+	for _, p := range item.propagations {
+		if ptr.Deref(p.TransitGatewayRouteTableId, "") == routeTableId {
+			return nil
+		}
+	}
+	item.propagations = append(item.propagations, ec2types.TransitGatewayAttachmentPropagation{
+		TransitGatewayRouteTableId: ptr.To(routeTableId),
+		State:                      ec2types.TransitGatewayPropagationStateEnabled,
+	})
+	return nil
+}
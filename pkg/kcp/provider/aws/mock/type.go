@@ -1,6 +1,7 @@
 package mock
 
 import (
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/iprange/client"
 	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
@@ -31,6 +32,7 @@ type Clients interface {
 	ScopeClient
 	VpcPeeringClient
 	redisinstanceclient.ElastiCacheClient
+	kcpdns.Client
 }
 
 type Providers interface {
@@ -39,6 +41,7 @@ type Providers interface {
 	NfsInstanceSkrProvider() awsclient.SkrClientProvider[nfsinstanceclient.Client]
 	VpcPeeringSkrProvider() awsclient.SkrClientProvider[vpcpeeringclient.Client]
 	ElastiCacheProviderFake() awsclient.SkrClientProvider[redisinstanceclient.ElastiCacheClient]
+	Route53SkrProvider() awsclient.SkrClientProvider[kcpdns.Client]
 }
 
 type Server interface {
@@ -48,8 +51,14 @@ type Server interface {
 
 	VpcConfig
 	NfsConfig
+	IamConfig
+	ResourceShareConfig
+	TransitGatewayConfig
+	EgressOnlyInternetGatewayConfig
 	ScopeConfig
 	VpcPeeringConfig
 	RouteTableConfig
+	IpamConfig
 	AwsElastiCacheMockUtils
+	Route53Config
 }
@@ -8,8 +8,11 @@ import (
 	"github.com/elliotchance/pie/v2"
 	"github.com/google/uuid"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 	"k8s.io/utils/ptr"
+	"maps"
+	"net"
 	"sync"
 )
 
@@ -47,6 +50,16 @@ type VpcSubnet struct {
 
 type VpcConfig interface {
 	AddVpc(id, cidr string, tags []ec2Types.Tag, subnets []VpcSubnet) *ec2Types.Vpc
+	// AddNetworkInterface adds a network interface to the subnet, preventing the subnet deletion
+	// by DependencyViolation error until it is removed, and returns its id
+	AddNetworkInterface(subnetId, description string) string
+	RemoveNetworkInterface(networkInterfaceId string)
+	// SetNetworkInterfaceStatus sets the status of the network interface, the in-use ones can not be deleted
+	SetNetworkInterfaceStatus(networkInterfaceId string, status ec2Types.NetworkInterfaceStatus)
+	// SetAvailabilityZones sets the available zones of the region
+	SetAvailabilityZones(zones ...string)
+	// SetAvailabilityZoneIds sets the IDs of the available zones by their names, the ID of the zone without one is its name
+	SetAvailabilityZoneIds(zoneIds map[string]string)
 }
 
 type vpcEntry struct {
@@ -55,8 +68,11 @@ type vpcEntry struct {
 }
 
 type vpcStore struct {
-	m     sync.Mutex
-	items []*vpcEntry
+	m                 sync.Mutex
+	items             []*vpcEntry
+	networkInterfaces []ec2Types.NetworkInterface
+	availabilityZones []string
+	zoneIds           map[string]string
 }
 
 func (s *vpcStore) itemByVpcId(vpcId string) (*vpcEntry, error) {
@@ -104,6 +120,56 @@ func (s *vpcStore) AddVpc(id, cidr string, tags []ec2Types.Tag, subnets []VpcSub
 	return &item.vpc
 }
 
+func (s *vpcStore) AddNetworkInterface(subnetId, description string) string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	id := "eni-" + uuid.NewString()
+	s.networkInterfaces = append(s.networkInterfaces, ec2Types.NetworkInterface{
+		NetworkInterfaceId: ptr.To(id),
+		SubnetId:           ptr.To(subnetId),
+		Description:        ptr.To(description),
+		Status:             ec2Types.NetworkInterfaceStatusInUse,
+	})
+	return id
+}
+
+func (s *vpcStore) RemoveNetworkInterface(networkInterfaceId string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.networkInterfaces = pie.Filter(s.networkInterfaces, func(x ec2Types.NetworkInterface) bool {
+		return ptr.Deref(x.NetworkInterfaceId, "") != networkInterfaceId
+	})
+}
+
+func (s *vpcStore) SetNetworkInterfaceStatus(networkInterfaceId string, status ec2Types.NetworkInterfaceStatus) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i := range s.networkInterfaces {
+		if ptr.Deref(s.networkInterfaces[i].NetworkInterfaceId, "") == networkInterfaceId {
+			s.networkInterfaces[i].Status = status
+		}
+	}
+}
+
+func (s *vpcStore) SetAvailabilityZones(zones ...string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.availabilityZones = append([]string{}, zones...)
+}
+
+func (s *vpcStore) SetAvailabilityZoneIds(zoneIds map[string]string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.zoneIds = maps.Clone(zoneIds)
+}
+
+func (s *vpcStore) zoneId(zone string) string {
+	if id, ok := s.zoneIds[zone]; ok {
+		return id
+	}
+	return zone
+}
+
 // Client implementation ========================================
 
 func (s *vpcStore) DescribeVpc(ctx context.Context, vpcId string) (*ec2Types.Vpc, error) {
@@ -201,9 +267,30 @@ func (s *vpcStore) DescribeSubnets(ctx context.Context, vpcId string) ([]ec2Type
 	if err != nil {
 		return nil, err
 	}
+	for i := range item.subnets {
+		s.setAvailableIpAddressCount(&item.subnets[i])
+	}
 	return item.subnets, nil
 }
 
+// setAvailableIpAddressCount sets the count of the subnet addresses not reserved by AWS
+// and not used by the network interfaces, must be called with the lock held
+func (s *vpcStore) setAvailableIpAddressCount(subnet *ec2Types.Subnet) {
+	_, ipNet, err := net.ParseCIDR(ptr.Deref(subnet.CidrBlock, ""))
+	if err != nil {
+		return
+	}
+	ones, bits := ipNet.Mask.Size()
+	// AWS reserves the first four and the last address of each subnet
+	available := 1<<(bits-ones) - 5
+	for _, ni := range s.networkInterfaces {
+		if ptr.Deref(ni.SubnetId, "") == ptr.Deref(subnet.SubnetId, "") {
+			available--
+		}
+	}
+	subnet.AvailableIpAddressCount = ptr.To(int32(max(available, 0)))
+}
+
 func (s *vpcStore) DescribeSubnet(ctx context.Context, subnetId string) (*ec2Types.Subnet, error) {
 	if isContextCanceled(ctx) {
 		return nil, context.Canceled
@@ -214,6 +301,7 @@ func (s *vpcStore) DescribeSubnet(ctx context.Context, subnetId string) (*ec2Typ
 	for _, item := range s.items {
 		for _, subnet := range item.subnets {
 			if ptr.Deref(subnet.SubnetId, "") == subnetId {
+				s.setAvailableIpAddressCount(&subnet)
 				return &subnet, nil
 			}
 		}
@@ -232,12 +320,14 @@ func (s *vpcStore) CreateSubnet(ctx context.Context, vpcId, az, cidr string, tag
 	if err != nil {
 		return nil, err
 	}
+	subnetId := uuid.NewString()
 	subnet := ec2Types.Subnet{
 		AvailabilityZone:   ptr.To(az),
-		AvailabilityZoneId: ptr.To(az),
+		AvailabilityZoneId: ptr.To(s.zoneId(az)),
 		CidrBlock:          ptr.To(cidr),
 		State:              ec2Types.SubnetStateAvailable,
-		SubnetId:           ptr.To(uuid.NewString()),
+		SubnetId:           ptr.To(subnetId),
+		SubnetArn:          ptr.To(fmt.Sprintf("arn:aws:ec2:mock:%s:subnet/%s", awsmeta.GetAwsAccountId(ctx), subnetId)),
 		Tags:               append(make([]ec2Types.Tag, 0, len(tags)), tags...),
 		VpcId:              ptr.To(vpcId),
 	}
@@ -251,6 +341,14 @@ func (s *vpcStore) DeleteSubnet(ctx context.Context, subnetId string) error {
 	}
 	s.m.Lock()
 	defer s.m.Unlock()
+	if pie.Any(s.networkInterfaces, func(x ec2Types.NetworkInterface) bool {
+		return ptr.Deref(x.SubnetId, "") == subnetId
+	}) {
+		return &smithy.GenericAPIError{
+			Code:    "DependencyViolation",
+			Message: fmt.Sprintf("The subnet '%s' has dependencies and cannot be deleted.", subnetId),
+		}
+	}
 	for _, item := range s.items {
 		idx := -1
 		for i, subnet := range item.subnets {
@@ -269,3 +367,110 @@ func (s *vpcStore) DeleteSubnet(ctx context.Context, subnetId string) error {
 		Message: fmt.Sprintf("subnet %s does not exist", subnetId),
 	}
 }
+
+func (s *vpcStore) TagSubnet(ctx context.Context, subnetId string, tags []ec2Types.Tag) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, item := range s.items {
+		for i, subnet := range item.subnets {
+			if ptr.Deref(subnet.SubnetId, "") != subnetId {
+				continue
+			}
+			for _, tag := range tags {
+				idx := pie.FindFirstUsing(subnet.Tags, func(t ec2Types.Tag) bool {
+					return ptr.Deref(t.Key, "") == ptr.Deref(tag.Key, "")
+				})
+				if idx > -1 {
+					subnet.Tags[idx] = tag
+				} else {
+					subnet.Tags = append(subnet.Tags, tag)
+				}
+			}
+			item.subnets[i] = subnet
+			return nil
+		}
+	}
+	return &smithy.GenericAPIError{
+		Code:    "404",
+		Message: fmt.Sprintf("subnet %s does not exist", subnetId),
+	}
+}
+
+func (s *vpcStore) UntagSubnet(ctx context.Context, subnetId string, keys []string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, item := range s.items {
+		for i, subnet := range item.subnets {
+			if ptr.Deref(subnet.SubnetId, "") != subnetId {
+				continue
+			}
+			subnet.Tags = pie.Filter(subnet.Tags, func(t ec2Types.Tag) bool {
+				return !pie.Contains(keys, ptr.Deref(t.Key, ""))
+			})
+			item.subnets[i] = subnet
+			return nil
+		}
+	}
+	return &smithy.GenericAPIError{
+		Code:    "404",
+		Message: fmt.Sprintf("subnet %s does not exist", subnetId),
+	}
+}
+
+func (s *vpcStore) DescribeNetworkInterfaces(ctx context.Context, subnetId string) ([]ec2Types.NetworkInterface, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return pie.Filter(s.networkInterfaces, func(x ec2Types.NetworkInterface) bool {
+		return ptr.Deref(x.SubnetId, "") == subnetId
+	}), nil
+}
+
+func (s *vpcStore) DeleteNetworkInterface(ctx context.Context, networkInterfaceId string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	idx := pie.FindFirstUsing(s.networkInterfaces, func(x ec2Types.NetworkInterface) bool {
+		return ptr.Deref(x.NetworkInterfaceId, "") == networkInterfaceId
+	})
+	if idx == -1 {
+		return &smithy.GenericAPIError{
+			Code:    "InvalidNetworkInterfaceID.NotFound",
+			Message: fmt.Sprintf("network interface %s does not exist", networkInterfaceId),
+		}
+	}
+	if s.networkInterfaces[idx].Status == ec2Types.NetworkInterfaceStatusInUse {
+		return &smithy.GenericAPIError{
+			Code:    "InvalidNetworkInterface.InUse",
+			Message: fmt.Sprintf("network interface %s is currently in use", networkInterfaceId),
+		}
+	}
+	s.networkInterfaces = append(s.networkInterfaces[:idx], s.networkInterfaces[idx+1:]...)
+	return nil
+}
+
+func (s *vpcStore) DescribeAvailabilityZones(ctx context.Context) ([]ec2Types.AvailabilityZone, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return pie.Map(s.availabilityZones, func(zone string) ec2Types.AvailabilityZone {
+		return ec2Types.AvailabilityZone{
+			ZoneName: ptr.To(zone),
+			ZoneId:   ptr.To(s.zoneId(zone)),
+			State:    ec2Types.AvailabilityZoneStateAvailable,
+			ZoneType: ptr.To("availability-zone"),
+		}
+	}), nil
+}
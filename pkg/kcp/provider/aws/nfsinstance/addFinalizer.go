@@ -2,7 +2,7 @@ package nfsinstance
 
 import (
 	"context"
-	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -14,12 +14,12 @@ func addFinalizer(ctx context.Context, state composed.State) (error, context.Con
 	}
 
 	// If finalizer already present, don't add it again.
-	if controllerutil.ContainsFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName) {
+	if controllerutil.ContainsFinalizer(state.Obj(), actions.FinalizerName()) {
 		return nil, nil
 	}
 
 	// Add finalizer
-	controllerutil.AddFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName)
+	controllerutil.AddFinalizer(state.Obj(), actions.FinalizerName())
 	if err := state.UpdateObj(ctx); err != nil {
 		return composed.LogErrorAndReturn(err, "Error adding Finalizer", composed.StopWithRequeue, ctx)
 	}
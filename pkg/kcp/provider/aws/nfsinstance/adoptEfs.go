@@ -0,0 +1,108 @@
+package nfsinstance
+
+import (
+	"context"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+// adoptEfs binds the NfsInstance to the already existing EFS matching the adopt tags, if requested
+var adoptEfs = actions.AdoptByTags(&efsAdopter{})
+
+type efsAdopter struct{}
+
+func (a *efsAdopter) IsBound(_ context.Context, st composed.State) bool {
+	return st.(*State).efs != nil
+}
+
+func (a *efsAdopter) ListCandidates(ctx context.Context, st composed.State) ([]actions.AdoptionCandidate, error) {
+	state := st.(*State)
+	list, err := state.awsClient.DescribeFileSystems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]actions.AdoptionCandidate, 0, len(list))
+	for _, fs := range list {
+		if fs.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+			continue
+		}
+		tags := make(map[string]string, len(fs.Tags))
+		for _, t := range fs.Tags {
+			tags[ptr.Deref(t.Key, "")] = ptr.Deref(t.Value, "")
+		}
+		if !isEfsAdoptable(state, tags) {
+			continue
+		}
+		result = append(result, actions.AdoptionCandidate{
+			Id:   ptr.Deref(fs.FileSystemId, ""),
+			Tags: tags,
+		})
+	}
+	return result, nil
+}
+
+func (a *efsAdopter) Bind(ctx context.Context, st composed.State, id string) error {
+	state := st.(*State)
+	return state.awsClient.TagResource(ctx, id, efsOwnershipTags(state))
+}
+
+// isEfsAdoptable returns true if the EFS has the cloud-manager scope and shoot tags of the Scope, so it
+// belongs to the same cluster, and is not bound to other NfsInstance
+func isEfsAdoptable(state *State, tags map[string]string) bool {
+	if tags[common.TagScope] != state.ObjAsNfsInstance().Spec.Scope.Name ||
+		tags[common.TagShoot] != state.Scope().Spec.ShootName {
+		return false
+	}
+	name, bound := tags[common.TagCloudManagerName]
+	return !bound || name == state.Name().String()
+}
+
+// efsOwnershipTags are the tags cloud-manager puts on the EFS it created or adopted
+func efsOwnershipTags(state *State) []efsTypes.Tag {
+	return []efsTypes.Tag{
+		{
+			Key:   ptr.To(common.TagCloudManagerName),
+			Value: ptr.To(state.Name().String()),
+		},
+		{
+			Key:   ptr.To(common.TagCloudManagerRemoteName),
+			Value: ptr.To(state.ObjAsNfsInstance().Spec.RemoteRef.String()),
+		},
+		{
+			Key:   ptr.To(common.TagScope),
+			Value: ptr.To(state.ObjAsNfsInstance().Spec.Scope.Name),
+		},
+		{
+			Key:   ptr.To(common.TagShoot),
+			Value: ptr.To(state.Scope().Spec.ShootName),
+		},
+	}
+}
+
+// releaseAdoptedEfs removes the tags binding the adopted EFS that is retained on delete to the NfsInstance,
+// so it is left in place by the following deleteEfs and waitEfsDeleted. The scope and shoot tags are
+// kept, so it can be adopted again.
+func releaseAdoptedEfs(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.efs == nil || !actions.IsAdoptedAndRetained(state.ObjAsNfsInstance()) {
+		return nil, nil
+	}
+
+	logger.Info("Releasing adopted EFS retained on delete")
+
+	keys := []string{common.TagCloudManagerName, common.TagCloudManagerRemoteName}
+	err := state.awsClient.UntagResource(ctx, ptr.Deref(state.efs.FileSystemId, ""), keys)
+	if err != nil && !awsmeta.IsNotFound(err) {
+		return awsmeta.LogErrorAndReturn(err, "Error removing ownership tags from adopted EFS", ctx)
+	}
+
+	state.efs = nil
+
+	return nil, nil
+}
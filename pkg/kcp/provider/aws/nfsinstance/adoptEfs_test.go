@@ -0,0 +1,96 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestAdoptEfsRequiresOwnershipTags(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+			Adopt: &cloudcontrolv1beta1.AdoptOptions{MatchTags: map[string]string{"team": "a"}},
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{},
+			},
+		},
+	}
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec:       cloudcontrolv1beta1.ScopeSpec{ShootName: "shoot"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	focalState.SetScope(scope)
+
+	awsMock := awsmock.New()
+	createFs := func(tags map[string]string) string {
+		var efsTags []efsTypes.Tag
+		for k, v := range tags {
+			efsTags = append(efsTags, efsTypes.Tag{Key: ptr.To(k), Value: ptr.To(v)})
+		}
+		out, err := awsMock.CreateFileSystem(ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", efsTags)
+		assert.NoError(t, err)
+		return ptr.Deref(out.FileSystemId, "")
+	}
+
+	// file system not created by cloud-manager
+	createFs(map[string]string{"team": "a"})
+	// file system of other cluster
+	createFs(map[string]string{"team": "a", common.TagScope: "scope", common.TagShoot: "other"})
+	// file system bound to other NfsInstance
+	createFs(map[string]string{"team": "a", common.TagScope: "scope", common.TagShoot: "shoot", common.TagCloudManagerName: "kcp-system/other"})
+
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, nil)
+
+	err, _ := adoptEfs(ctx, state)
+	assert.Nil(t, err)
+	assert.Empty(t, nfsInstance.Status.AdoptedResourceId, "no file system is adoptable")
+
+	id := createFs(map[string]string{"team": "a", common.TagScope: "scope", common.TagShoot: "shoot"})
+
+	err, _ = adoptEfs(ctx, state)
+	assert.Equal(t, composed.StopWithRequeue, err)
+	assert.Equal(t, id, nfsInstance.Status.AdoptedResourceId)
+
+	fs := awsMock.GetFileSystemById(id)
+	assert.Contains(t, fs.Tags, efsTypes.Tag{Key: ptr.To(common.TagCloudManagerName), Value: ptr.To("kcp-system/nfs")})
+
+	// the released file system keeps the scope and shoot tags
+	state.efs = fs
+	err, _ = releaseAdoptedEfs(ctx, state)
+	assert.Nil(t, err)
+	fs = awsMock.GetFileSystemById(id)
+	assert.NotContains(t, fs.Tags, efsTypes.Tag{Key: ptr.To(common.TagCloudManagerName), Value: ptr.To("kcp-system/nfs")})
+	assert.Contains(t, fs.Tags, efsTypes.Tag{Key: ptr.To(common.TagShoot), Value: ptr.To("shoot")})
+}
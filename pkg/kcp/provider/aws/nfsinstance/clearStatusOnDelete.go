@@ -0,0 +1,20 @@
+package nfsinstance
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// clearStatusOnDelete resets the status fields that are no longer valid once the file system is deleted
+func clearStatusOnDelete(obj composed.ObjWithConditions) {
+	nfsInstance := obj.(*cloudcontrolv1beta1.NfsInstance)
+	nfsInstance.Status.Hosts = nil
+	nfsInstance.Status.Host = ""
+	nfsInstance.Status.Path = ""
+	nfsInstance.Status.Replica = nil
+	nfsInstance.Status.WorkloadAccessRoleArn = ""
+	nfsInstance.Status.LifecyclePolicy = nil
+	nfsInstance.Status.SecurityGroupId = ""
+	nfsInstance.Status.StorageClass = ""
+	nfsInstance.Status.AvailabilityZone = ""
+}
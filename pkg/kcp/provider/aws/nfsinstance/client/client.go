@@ -4,13 +4,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	backupTypes "github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/elliotchance/pie/v2"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	"k8s.io/utils/ptr"
+	"net/url"
+	"time"
 )
 
 const (
@@ -27,42 +35,109 @@ func NewClientProvider() awsclient.SkrClientProvider[Client] {
 		return newClient(
 			ec2.NewFromConfig(cfg),
 			efs.NewFromConfig(cfg),
+			iam.NewFromConfig(cfg),
+			cloudwatch.NewFromConfig(cfg),
+			backup.NewFromConfig(cfg),
 		), nil
 	}
 }
 
 type Client interface {
 	DescribeSubnet(ctx context.Context, subnetId string) (*ec2Types.Subnet, error)
+	DescribeVpcs(ctx context.Context, name string) ([]ec2Types.Vpc, error)
+	DescribeSubnets(ctx context.Context, vpcId string) ([]ec2Types.Subnet, error)
 	DescribeSecurityGroups(ctx context.Context, filters []ec2Types.Filter, groupIds []string) ([]ec2Types.SecurityGroup, error)
 	CreateSecurityGroup(ctx context.Context, vpcId, name string, tags []ec2Types.Tag) (string, error)
 	DeleteSecurityGroup(ctx context.Context, id string) error
 	AuthorizeSecurityGroupIngress(ctx context.Context, groupId string, ipPermissions []ec2Types.IpPermission) error
+	RevokeSecurityGroupIngress(ctx context.Context, groupId string, ipPermissions []ec2Types.IpPermission) error
 
 	DescribeFileSystems(ctx context.Context) ([]efsTypes.FileSystemDescription, error)
 	CreateFileSystem(
 		ctx context.Context,
 		performanceMode efsTypes.PerformanceMode,
 		throughputMode efsTypes.ThroughputMode,
+		provisionedThroughputInMibps *float64,
+		backup *bool,
+		availabilityZoneName string,
 		tags []efsTypes.Tag,
 	) (*efs.CreateFileSystemOutput, error)
 	DeleteFileSystem(ctx context.Context, fsId string) error
+	TagResource(ctx context.Context, fsId string, tags []efsTypes.Tag) error
+	UntagResource(ctx context.Context, fsId string, tagKeys []string) error
 	DescribeMountTargets(ctx context.Context, fsId string) ([]efsTypes.MountTargetDescription, error)
-	CreateMountTarget(ctx context.Context, fsId, subnetId string, securityGroups []string) (string, error)
+	// CreateMountTarget creates the mount target in the subnet, with the given IP address or with the
+	// IP address assigned by AWS if empty
+	CreateMountTarget(ctx context.Context, fsId, subnetId, ipAddress string, securityGroups []string) (string, error)
 	DeleteMountTarget(ctx context.Context, mountTargetId string) error
 
 	DescribeMountTargetSecurityGroups(ctx context.Context, mountTargetId string) ([]string, error)
+	// ModifyMountTargetSecurityGroups replaces all the security groups of the mount target with the given ones
+	ModifyMountTargetSecurityGroups(ctx context.Context, mountTargetId string, securityGroups []string) error
+
+	DescribeReplicationConfigurations(ctx context.Context, fsId string) ([]efsTypes.ReplicationConfigurationDescription, error)
+	CreateReplicationConfiguration(ctx context.Context, fsId, destinationRegion string) (*efs.CreateReplicationConfigurationOutput, error)
+	DeleteReplicationConfiguration(ctx context.Context, fsId string) error
+
+	DescribeLifecycleConfiguration(ctx context.Context, fsId string) ([]efsTypes.LifecyclePolicy, error)
+	PutLifecycleConfiguration(ctx context.Context, fsId string, policies []efsTypes.LifecyclePolicy) error
+
+	DescribeBackupPolicy(ctx context.Context, fsId string) (efsTypes.Status, error)
+	PutBackupPolicy(ctx context.Context, fsId string, status efsTypes.Status) error
+
+	DescribeFileSystemPolicy(ctx context.Context, fsId string) (string, error)
+	// PutFileSystemPolicy replaces the file system policy, with the policy lockout safety check enabled
+	PutFileSystemPolicy(ctx context.Context, fsId, policy string) error
+	DeleteFileSystemPolicy(ctx context.Context, fsId string) error
+
+	// UpdateProvisionedThroughput switches the file system to the provisioned throughput mode with the given throughput
+	UpdateProvisionedThroughput(ctx context.Context, fsId string, mibps float64) error
+	// GetThroughputUtilization returns the average percent of the permitted throughput the file system used
+	// over the last period, or nil if CloudWatch has no data points for it
+	GetThroughputUtilization(ctx context.Context, fsId string, period time.Duration) (*float64, error)
+
+	// GetRole returns the IAM role with the already url decoded AssumeRolePolicyDocument
+	GetRole(ctx context.Context, name string) (*iamTypes.Role, error)
+	CreateRole(ctx context.Context, name, assumeRolePolicyDocument string, tags []iamTypes.Tag) (*iamTypes.Role, error)
+	UpdateAssumeRolePolicy(ctx context.Context, name, assumeRolePolicyDocument string) error
+	DeleteRole(ctx context.Context, name string) error
+	// GetRolePolicy returns the already url decoded document of the role inline policy
+	GetRolePolicy(ctx context.Context, roleName, policyName string) (string, error)
+	PutRolePolicy(ctx context.Context, roleName, policyName, policyDocument string) error
+	DeleteRolePolicy(ctx context.Context, roleName, policyName string) error
+
+	// DescribeBackupVault returns the ARN of the AWS Backup vault
+	DescribeBackupVault(ctx context.Context, name string) (string, error)
+	// CreateBackupVault creates the AWS Backup vault and returns its ARN
+	CreateBackupVault(ctx context.Context, name string, tags map[string]string) (string, error)
+	ListBackupPlans(ctx context.Context) ([]backupTypes.BackupPlansListMember, error)
+	GetBackupPlan(ctx context.Context, planId string) (*backupTypes.BackupPlan, error)
+	CreateBackupPlan(ctx context.Context, plan *backupTypes.BackupPlanInput, tags map[string]string) (string, error)
+	UpdateBackupPlan(ctx context.Context, planId string, plan *backupTypes.BackupPlanInput) error
+	DeleteBackupPlan(ctx context.Context, planId string) error
+	ListBackupSelections(ctx context.Context, planId string) ([]backupTypes.BackupSelectionsListMember, error)
+	CreateBackupSelection(ctx context.Context, planId string, selection *backupTypes.BackupSelection) (string, error)
+	DeleteBackupSelection(ctx context.Context, planId, selectionId string) error
+	// ListCopyJobs returns the copy jobs of the recovery points of the resource created after the given time
+	ListCopyJobs(ctx context.Context, resourceArn string, createdAfter time.Time) ([]backupTypes.CopyJob, error)
 }
 
-func newClient(ec2Svc *ec2.Client, efsSvc *efs.Client) Client {
+func newClient(ec2Svc *ec2.Client, efsSvc *efs.Client, iamSvc *iam.Client, cwSvc *cloudwatch.Client, backupSvc *backup.Client) Client {
 	return &client{
-		ec2Svc: ec2Svc,
-		efsSvc: efsSvc,
+		ec2Svc:    ec2Svc,
+		efsSvc:    efsSvc,
+		iamSvc:    iamSvc,
+		cwSvc:     cwSvc,
+		backupSvc: backupSvc,
 	}
 }
 
 type client struct {
-	ec2Svc *ec2.Client
-	efsSvc *efs.Client
+	ec2Svc    *ec2.Client
+	efsSvc    *efs.Client
+	iamSvc    *iam.Client
+	cwSvc     *cloudwatch.Client
+	backupSvc *backup.Client
 }
 
 func (c *client) DescribeSubnet(ctx context.Context, subnetId string) (*ec2Types.Subnet, error) {
@@ -89,6 +164,36 @@ func (c *client) DescribeSubnet(ctx context.Context, subnetId string) (*ec2Types
 	return result, nil
 }
 
+func (c *client) DescribeVpcs(ctx context.Context, name string) ([]ec2Types.Vpc, error) {
+	out, err := c.ec2Svc.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		Filters: []ec2Types.Filter{
+			{
+				Name:   ptr.To("tag:Name"),
+				Values: []string{name},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Vpcs, nil
+}
+
+func (c *client) DescribeSubnets(ctx context.Context, vpcId string) ([]ec2Types.Subnet, error) {
+	out, err := c.ec2Svc.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []ec2Types.Filter{
+			{
+				Name:   ptr.To("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Subnets, nil
+}
+
 func (c *client) DescribeSecurityGroups(ctx context.Context, filters []ec2Types.Filter, groupIds []string) ([]ec2Types.SecurityGroup, error) {
 	out, err := c.ec2Svc.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
 		Filters:  filters,
@@ -137,6 +242,14 @@ func (c *client) AuthorizeSecurityGroupIngress(ctx context.Context, groupId stri
 	return nil
 }
 
+func (c *client) RevokeSecurityGroupIngress(ctx context.Context, groupId string, ipPermissions []ec2Types.IpPermission) error {
+	_, err := c.ec2Svc.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+		GroupId:       ptr.To(groupId),
+		IpPermissions: ipPermissions,
+	})
+	return err
+}
+
 func (c *client) DescribeFileSystems(ctx context.Context) ([]efsTypes.FileSystemDescription, error) {
 	in := &efs.DescribeFileSystemsInput{}
 	out, err := c.efsSvc.DescribeFileSystems(ctx, in)
@@ -146,12 +259,18 @@ func (c *client) DescribeFileSystems(ctx context.Context) ([]efsTypes.FileSystem
 	return out.FileSystems, nil
 }
 
-func (c *client) CreateFileSystem(ctx context.Context, performanceMode efsTypes.PerformanceMode, throughputMode efsTypes.ThroughputMode, tags []efsTypes.Tag) (*efs.CreateFileSystemOutput, error) {
+func (c *client) CreateFileSystem(ctx context.Context, performanceMode efsTypes.PerformanceMode, throughputMode efsTypes.ThroughputMode, provisionedThroughputInMibps *float64, backup *bool, availabilityZoneName string, tags []efsTypes.Tag) (*efs.CreateFileSystemOutput, error) {
 	in := &efs.CreateFileSystemInput{
-		Encrypted:       aws.Bool(true),
-		PerformanceMode: performanceMode,
-		Tags:            tags,
-		ThroughputMode:  throughputMode,
+		Backup:                       backup,
+		Encrypted:                    aws.Bool(true),
+		PerformanceMode:              performanceMode,
+		ProvisionedThroughputInMibps: provisionedThroughputInMibps,
+		Tags:                         tags,
+		ThroughputMode:               throughputMode,
+	}
+	if len(availabilityZoneName) > 0 {
+		// One Zone storage class
+		in.AvailabilityZoneName = aws.String(availabilityZoneName)
 	}
 	out, err := c.efsSvc.CreateFileSystem(ctx, in)
 	if err != nil {
@@ -166,6 +285,22 @@ func (c *client) DeleteFileSystem(ctx context.Context, fsId string) error {
 	return err
 }
 
+func (c *client) TagResource(ctx context.Context, fsId string, tags []efsTypes.Tag) error {
+	_, err := c.efsSvc.TagResource(ctx, &efs.TagResourceInput{
+		ResourceId: ptr.To(fsId),
+		Tags:       tags,
+	})
+	return err
+}
+
+func (c *client) UntagResource(ctx context.Context, fsId string, tagKeys []string) error {
+	_, err := c.efsSvc.UntagResource(ctx, &efs.UntagResourceInput{
+		ResourceId: ptr.To(fsId),
+		TagKeys:    tagKeys,
+	})
+	return err
+}
+
 func (c *client) DescribeMountTargets(ctx context.Context, fsId string) ([]efsTypes.MountTargetDescription, error) {
 	out, err := c.efsSvc.DescribeMountTargets(ctx, &efs.DescribeMountTargetsInput{
 		FileSystemId: ptr.To(fsId),
@@ -176,12 +311,16 @@ func (c *client) DescribeMountTargets(ctx context.Context, fsId string) ([]efsTy
 	return out.MountTargets, nil
 }
 
-func (c *client) CreateMountTarget(ctx context.Context, fsId, subnetId string, securityGroups []string) (string, error) {
-	out, err := c.efsSvc.CreateMountTarget(ctx, &efs.CreateMountTargetInput{
+func (c *client) CreateMountTarget(ctx context.Context, fsId, subnetId, ipAddress string, securityGroups []string) (string, error) {
+	in := &efs.CreateMountTargetInput{
 		FileSystemId:   ptr.To(fsId),
 		SubnetId:       ptr.To(subnetId),
 		SecurityGroups: securityGroups,
-	})
+	}
+	if len(ipAddress) > 0 {
+		in.IpAddress = ptr.To(ipAddress)
+	}
+	out, err := c.efsSvc.CreateMountTarget(ctx, in)
 	if err != nil {
 		return "", err
 	}
@@ -205,3 +344,358 @@ func (c *client) DescribeMountTargetSecurityGroups(ctx context.Context, mountTar
 	}
 	return out.SecurityGroups, nil
 }
+
+func (c *client) ModifyMountTargetSecurityGroups(ctx context.Context, mountTargetId string, securityGroups []string) error {
+	_, err := c.efsSvc.ModifyMountTargetSecurityGroups(ctx, &efs.ModifyMountTargetSecurityGroupsInput{
+		MountTargetId:  ptr.To(mountTargetId),
+		SecurityGroups: securityGroups,
+	})
+	return err
+}
+
+func (c *client) DescribeReplicationConfigurations(ctx context.Context, fsId string) ([]efsTypes.ReplicationConfigurationDescription, error) {
+	out, err := c.efsSvc.DescribeReplicationConfigurations(ctx, &efs.DescribeReplicationConfigurationsInput{
+		FileSystemId: ptr.To(fsId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Replications, nil
+}
+
+func (c *client) CreateReplicationConfiguration(ctx context.Context, fsId, destinationRegion string) (*efs.CreateReplicationConfigurationOutput, error) {
+	out, err := c.efsSvc.CreateReplicationConfiguration(ctx, &efs.CreateReplicationConfigurationInput{
+		SourceFileSystemId: ptr.To(fsId),
+		Destinations: []efsTypes.DestinationToCreate{
+			{
+				Region: ptr.To(destinationRegion),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) DeleteReplicationConfiguration(ctx context.Context, fsId string) error {
+	_, err := c.efsSvc.DeleteReplicationConfiguration(ctx, &efs.DeleteReplicationConfigurationInput{
+		SourceFileSystemId: ptr.To(fsId),
+	})
+	return err
+}
+
+func (c *client) DescribeLifecycleConfiguration(ctx context.Context, fsId string) ([]efsTypes.LifecyclePolicy, error) {
+	out, err := c.efsSvc.DescribeLifecycleConfiguration(ctx, &efs.DescribeLifecycleConfigurationInput{
+		FileSystemId: ptr.To(fsId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.LifecyclePolicies, nil
+}
+
+func (c *client) PutLifecycleConfiguration(ctx context.Context, fsId string, policies []efsTypes.LifecyclePolicy) error {
+	_, err := c.efsSvc.PutLifecycleConfiguration(ctx, &efs.PutLifecycleConfigurationInput{
+		FileSystemId:      ptr.To(fsId),
+		LifecyclePolicies: policies,
+	})
+	return err
+}
+
+func (c *client) DescribeBackupPolicy(ctx context.Context, fsId string) (efsTypes.Status, error) {
+	out, err := c.efsSvc.DescribeBackupPolicy(ctx, &efs.DescribeBackupPolicyInput{
+		FileSystemId: ptr.To(fsId),
+	})
+	if err != nil {
+		return "", err
+	}
+	if out.BackupPolicy == nil {
+		return "", nil
+	}
+	return out.BackupPolicy.Status, nil
+}
+
+func (c *client) PutBackupPolicy(ctx context.Context, fsId string, status efsTypes.Status) error {
+	_, err := c.efsSvc.PutBackupPolicy(ctx, &efs.PutBackupPolicyInput{
+		FileSystemId: ptr.To(fsId),
+		BackupPolicy: &efsTypes.BackupPolicy{Status: status},
+	})
+	return err
+}
+
+func (c *client) DescribeFileSystemPolicy(ctx context.Context, fsId string) (string, error) {
+	out, err := c.efsSvc.DescribeFileSystemPolicy(ctx, &efs.DescribeFileSystemPolicyInput{
+		FileSystemId: ptr.To(fsId),
+	})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(out.Policy, ""), nil
+}
+
+func (c *client) PutFileSystemPolicy(ctx context.Context, fsId, policy string) error {
+	_, err := c.efsSvc.PutFileSystemPolicy(ctx, &efs.PutFileSystemPolicyInput{
+		FileSystemId:                   ptr.To(fsId),
+		Policy:                         ptr.To(policy),
+		BypassPolicyLockoutSafetyCheck: false,
+	})
+	return err
+}
+
+func (c *client) DeleteFileSystemPolicy(ctx context.Context, fsId string) error {
+	_, err := c.efsSvc.DeleteFileSystemPolicy(ctx, &efs.DeleteFileSystemPolicyInput{
+		FileSystemId: ptr.To(fsId),
+	})
+	return err
+}
+
+func (c *client) UpdateProvisionedThroughput(ctx context.Context, fsId string, mibps float64) error {
+	_, err := c.efsSvc.UpdateFileSystem(ctx, &efs.UpdateFileSystemInput{
+		FileSystemId:                 ptr.To(fsId),
+		ThroughputMode:               efsTypes.ThroughputModeProvisioned,
+		ProvisionedThroughputInMibps: ptr.To(mibps),
+	})
+	return err
+}
+
+func (c *client) GetThroughputUtilization(ctx context.Context, fsId string, period time.Duration) (*float64, error) {
+	dimensions := []cwTypes.Dimension{
+		{Name: ptr.To("FileSystemId"), Value: ptr.To(fsId)},
+	}
+	seconds := int32(period.Seconds())
+	end := time.Now()
+	out, err := c.cwSvc.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: ptr.To(end.Add(-period)),
+		EndTime:   ptr.To(end),
+		MetricDataQueries: []cwTypes.MetricDataQuery{
+			{
+				Id: ptr.To("metered"),
+				MetricStat: &cwTypes.MetricStat{
+					Metric: &cwTypes.Metric{
+						Namespace:  ptr.To("AWS/EFS"),
+						MetricName: ptr.To("MeteredIOBytes"),
+						Dimensions: dimensions,
+					},
+					Period: ptr.To(seconds),
+					Stat:   ptr.To("Sum"),
+				},
+				ReturnData: ptr.To(false),
+			},
+			{
+				Id: ptr.To("permitted"),
+				MetricStat: &cwTypes.MetricStat{
+					Metric: &cwTypes.Metric{
+						Namespace:  ptr.To("AWS/EFS"),
+						MetricName: ptr.To("PermittedThroughput"),
+						Dimensions: dimensions,
+					},
+					Period: ptr.To(seconds),
+					Stat:   ptr.To("Average"),
+				},
+				ReturnData: ptr.To(false),
+			},
+			{
+				Id:         ptr.To("utilization"),
+				Expression: ptr.To("(metered/PERIOD(metered))/permitted*100"),
+				ReturnData: ptr.To(true),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range out.MetricDataResults {
+		if ptr.Deref(r.Id, "") == "utilization" && len(r.Values) > 0 {
+			return ptr.To(r.Values[0]), nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *client) GetRole(ctx context.Context, name string) (*iamTypes.Role, error) {
+	out, err := c.iamSvc.GetRole(ctx, &iam.GetRoleInput{
+		RoleName: ptr.To(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.Role.AssumeRolePolicyDocument != nil {
+		doc, err := url.QueryUnescape(*out.Role.AssumeRolePolicyDocument)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding assume role policy document: %w", err)
+		}
+		out.Role.AssumeRolePolicyDocument = ptr.To(doc)
+	}
+	return out.Role, nil
+}
+
+func (c *client) CreateRole(ctx context.Context, name, assumeRolePolicyDocument string, tags []iamTypes.Tag) (*iamTypes.Role, error) {
+	out, err := c.iamSvc.CreateRole(ctx, &iam.CreateRoleInput{
+		RoleName:                 ptr.To(name),
+		AssumeRolePolicyDocument: ptr.To(assumeRolePolicyDocument),
+		Tags:                     tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Role, nil
+}
+
+func (c *client) UpdateAssumeRolePolicy(ctx context.Context, name, assumeRolePolicyDocument string) error {
+	_, err := c.iamSvc.UpdateAssumeRolePolicy(ctx, &iam.UpdateAssumeRolePolicyInput{
+		RoleName:       ptr.To(name),
+		PolicyDocument: ptr.To(assumeRolePolicyDocument),
+	})
+	return err
+}
+
+func (c *client) DeleteRole(ctx context.Context, name string) error {
+	_, err := c.iamSvc.DeleteRole(ctx, &iam.DeleteRoleInput{
+		RoleName: ptr.To(name),
+	})
+	return err
+}
+
+func (c *client) GetRolePolicy(ctx context.Context, roleName, policyName string) (string, error) {
+	out, err := c.iamSvc.GetRolePolicy(ctx, &iam.GetRolePolicyInput{
+		RoleName:   ptr.To(roleName),
+		PolicyName: ptr.To(policyName),
+	})
+	if err != nil {
+		return "", err
+	}
+	doc, err := url.QueryUnescape(ptr.Deref(out.PolicyDocument, ""))
+	if err != nil {
+		return "", fmt.Errorf("error decoding role policy document: %w", err)
+	}
+	return doc, nil
+}
+
+func (c *client) PutRolePolicy(ctx context.Context, roleName, policyName, policyDocument string) error {
+	_, err := c.iamSvc.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
+		RoleName:       ptr.To(roleName),
+		PolicyName:     ptr.To(policyName),
+		PolicyDocument: ptr.To(policyDocument),
+	})
+	return err
+}
+
+func (c *client) DeleteRolePolicy(ctx context.Context, roleName, policyName string) error {
+	_, err := c.iamSvc.DeleteRolePolicy(ctx, &iam.DeleteRolePolicyInput{
+		RoleName:   ptr.To(roleName),
+		PolicyName: ptr.To(policyName),
+	})
+	return err
+}
+
+func (c *client) DescribeBackupVault(ctx context.Context, name string) (string, error) {
+	out, err := c.backupSvc.DescribeBackupVault(ctx, &backup.DescribeBackupVaultInput{
+		BackupVaultName: ptr.To(name),
+	})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(out.BackupVaultArn, ""), nil
+}
+
+func (c *client) CreateBackupVault(ctx context.Context, name string, tags map[string]string) (string, error) {
+	out, err := c.backupSvc.CreateBackupVault(ctx, &backup.CreateBackupVaultInput{
+		BackupVaultName:  ptr.To(name),
+		BackupVaultTags:  tags,
+		CreatorRequestId: ptr.To(name),
+	})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(out.BackupVaultArn, ""), nil
+}
+
+func (c *client) ListBackupPlans(ctx context.Context) ([]backupTypes.BackupPlansListMember, error) {
+	var result []backupTypes.BackupPlansListMember
+	paginator := backup.NewListBackupPlansPaginator(c.backupSvc, &backup.ListBackupPlansInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out.BackupPlansList...)
+	}
+	return result, nil
+}
+
+func (c *client) GetBackupPlan(ctx context.Context, planId string) (*backupTypes.BackupPlan, error) {
+	out, err := c.backupSvc.GetBackupPlan(ctx, &backup.GetBackupPlanInput{
+		BackupPlanId: ptr.To(planId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.BackupPlan, nil
+}
+
+func (c *client) CreateBackupPlan(ctx context.Context, plan *backupTypes.BackupPlanInput, tags map[string]string) (string, error) {
+	out, err := c.backupSvc.CreateBackupPlan(ctx, &backup.CreateBackupPlanInput{
+		BackupPlan:     plan,
+		BackupPlanTags: tags,
+	})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(out.BackupPlanId, ""), nil
+}
+
+func (c *client) UpdateBackupPlan(ctx context.Context, planId string, plan *backupTypes.BackupPlanInput) error {
+	_, err := c.backupSvc.UpdateBackupPlan(ctx, &backup.UpdateBackupPlanInput{
+		BackupPlanId: ptr.To(planId),
+		BackupPlan:   plan,
+	})
+	return err
+}
+
+func (c *client) DeleteBackupPlan(ctx context.Context, planId string) error {
+	_, err := c.backupSvc.DeleteBackupPlan(ctx, &backup.DeleteBackupPlanInput{
+		BackupPlanId: ptr.To(planId),
+	})
+	return err
+}
+
+func (c *client) ListBackupSelections(ctx context.Context, planId string) ([]backupTypes.BackupSelectionsListMember, error) {
+	out, err := c.backupSvc.ListBackupSelections(ctx, &backup.ListBackupSelectionsInput{
+		BackupPlanId: ptr.To(planId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.BackupSelectionsList, nil
+}
+
+func (c *client) CreateBackupSelection(ctx context.Context, planId string, selection *backupTypes.BackupSelection) (string, error) {
+	out, err := c.backupSvc.CreateBackupSelection(ctx, &backup.CreateBackupSelectionInput{
+		BackupPlanId:    ptr.To(planId),
+		BackupSelection: selection,
+	})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(out.SelectionId, ""), nil
+}
+
+func (c *client) DeleteBackupSelection(ctx context.Context, planId, selectionId string) error {
+	_, err := c.backupSvc.DeleteBackupSelection(ctx, &backup.DeleteBackupSelectionInput{
+		BackupPlanId: ptr.To(planId),
+		SelectionId:  ptr.To(selectionId),
+	})
+	return err
+}
+
+func (c *client) ListCopyJobs(ctx context.Context, resourceArn string, createdAfter time.Time) ([]backupTypes.CopyJob, error) {
+	out, err := c.backupSvc.ListCopyJobs(ctx, &backup.ListCopyJobsInput{
+		ByResourceArn:  ptr.To(resourceArn),
+		ByCreatedAfter: ptr.To(createdAfter),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.CopyJobs, nil
+}
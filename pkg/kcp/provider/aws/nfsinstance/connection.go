@@ -0,0 +1,17 @@
+package nfsinstance
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// efsMountOptions are the mount options recommended by AWS for the EFS file systems
+var efsMountOptions = []string{"nfsvers=4.1", "rsize=1048576", "wsize=1048576", "hard", "timeo=600", "retrans=2", "noresvport"}
+
+func newConnection(host string, mountTargets []cloudcontrolv1beta1.NfsMountTarget) *cloudcontrolv1beta1.NfsConnection {
+	return &cloudcontrolv1beta1.NfsConnection{
+		Host:         host,
+		Path:         "/",
+		MountTargets: mountTargets,
+		MountOptions: efsMountOptions,
+	}
+}
@@ -0,0 +1,22 @@
+package nfsinstance
+
+import (
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnection(t *testing.T) {
+	mountTargets := []cloudcontrolv1beta1.NfsMountTarget{
+		{Zone: "eu-west-1a", IpAddress: "10.250.8.10"},
+		{Zone: "eu-west-1b", IpAddress: "10.250.10.10"},
+	}
+
+	connection := newConnection("fs-1.efs.eu-west-1.amazonaws.com", mountTargets)
+
+	assert.Equal(t, "fs-1.efs.eu-west-1.amazonaws.com", connection.Host)
+	assert.Equal(t, "/", connection.Path)
+	assert.Equal(t, mountTargets, connection.MountTargets)
+	assert.Contains(t, connection.MountOptions, "nfsvers=4.1")
+}
@@ -0,0 +1,21 @@
+package nfsinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/pricing"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// reconcileCostEstimate estimates the EFS storage cost from its metered size, mount targets
+// and the IpRange subnets are free
+var reconcileCostEstimate = actions.ReconcileCostEstimate(estimateCost)
+
+func estimateCost(ctx context.Context, st composed.State) (string, bool) {
+	state := st.(*State)
+	if state.efs == nil || state.efs.SizeInBytes == nil {
+		return "", false
+	}
+	return pricing.Format(pricing.AwsEfsMonthly(state.Scope().Spec.Region, state.efs.SizeInBytes.Value)), true
+}
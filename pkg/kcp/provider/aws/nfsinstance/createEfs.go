@@ -6,9 +6,9 @@ import (
 	"fmt"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -23,32 +23,21 @@ func createEfs(ctx context.Context, st composed.State) (error, context.Context)
 		return nil, nil
 	}
 
+	// provisioned throughput file system starts with the lowest throughput of its bounds
+	var provisionedThroughput *float64
+	if awsSpec := state.ObjAsNfsInstance().Spec.Instance.Aws; awsSpec.Throughput == cloudcontrolv1beta1.AwsThroughputModeProvisioned &&
+		awsSpec.ProvisionedThroughput != nil {
+		provisionedThroughput = ptr.To(float64(awsSpec.ProvisionedThroughput.Min))
+	}
+
 	out, err := state.awsClient.CreateFileSystem(
 		ctx,
 		efsTypes.PerformanceMode(state.ObjAsNfsInstance().Spec.Instance.Aws.PerformanceMode),
 		efsTypes.ThroughputMode(state.ObjAsNfsInstance().Spec.Instance.Aws.Throughput),
-		[]efsTypes.Tag{
-			{
-				Key:   ptr.To("Name"),
-				Value: ptr.To(state.Obj().GetName()),
-			},
-			{
-				Key:   ptr.To(common.TagCloudManagerName),
-				Value: ptr.To(state.Name().String()),
-			},
-			{
-				Key:   ptr.To(common.TagCloudManagerRemoteName),
-				Value: ptr.To(state.ObjAsNfsInstance().Spec.RemoteRef.String()),
-			},
-			{
-				Key:   ptr.To(common.TagScope),
-				Value: ptr.To(state.ObjAsNfsInstance().Spec.Scope.Name),
-			},
-			{
-				Key:   ptr.To(common.TagShoot),
-				Value: ptr.To(state.Scope().Spec.ShootName),
-			},
-		},
+		provisionedThroughput,
+		state.ObjAsNfsInstance().Spec.Instance.Aws.AutomaticBackups,
+		state.ObjAsNfsInstance().Spec.Instance.Aws.OneZone,
+		state.efsTags(),
 	)
 
 	if err != nil {
@@ -92,3 +81,7 @@ func createEfs(ctx context.Context, st composed.State) (error, context.Context)
 
 	return nil, ctx
 }
+
+func (s *State) efsTags() []efsTypes.Tag {
+	return awsutil.EfsTagsFromMap(s.tags, "Name", s.Obj().GetName())
+}
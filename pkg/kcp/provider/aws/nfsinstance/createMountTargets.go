@@ -2,12 +2,21 @@ package nfsinstance
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+// createMountTargets creates the missing mount targets in the IpRange subnets. The mount targets get the
+// IPv4 address only, also in the dual-stack subnets, and the IPv6 only subnets are rejected, since the EFS
+// API version in use does not support the dual-stack and IPv6 mount targets.
 func createMountTargets(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
@@ -18,26 +27,82 @@ func createMountTargets(ctx context.Context, st composed.State) (error, context.
 	}
 
 	anyCreated := false
+	zone := oneZone(state.ObjAsNfsInstance())
 
 	for _, subnet := range state.IpRange().Status.Subnets {
 		_, ok := mountTargetsBySubnetId[subnet.Id]
 		if ok {
 			continue
 		}
+		if len(zone) > 0 && subnet.Zone != zone {
+			// One Zone file system has a single mount target in its own zone
+			continue
+		}
+
+		awsSubnet, err := state.awsClient.DescribeSubnet(ctx, subnet.Id)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error describing mount target subnet", ctx)
+		}
+		if awsSubnet != nil && ptr.Deref(awsSubnet.Ipv6Native, false) {
+			// the EFS API version in use creates the IPv4 mount targets only
+			return composed.UpdateStatus(state.ObjAsNfsInstance()).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonIpv6MountTargetNotSupported,
+					Message: fmt.Sprintf("Subnet %s in zone %s is IPv6 only, and the IPv6 mount targets are not supported", subnet.Id, subnet.Zone),
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status with IPv6 mount target not supported").
+				SuccessLogMsg("Forgetting KCP NfsInstance with IPv6 only subnet").
+				SuccessError(composed.StopAndForget).
+				Run(ctx, state)
+		}
+
+		ipAddress := mountTargetIp(state.ObjAsNfsInstance(), subnet.Zone)
 
 		logger.
 			WithValues(
 				"subnetId", subnet.Id,
 				"subnetZone", subnet.Zone,
+				"ipAddress", ipAddress,
 			).
 			Info("Creating mount target")
 
-		_, err := state.awsClient.CreateMountTarget(
+		_, err = state.awsClient.CreateMountTarget(
 			ctx,
 			ptr.Deref(state.efs.FileSystemId, ""),
 			subnet.Id,
+			ipAddress,
 			[]string{state.securityGroupId},
 		)
+		var ipInUse *efsTypes.IpAddressInUse
+		if errors.As(err, &ipInUse) {
+			logger.Info("Mount target IP address is in use")
+			msg := fmt.Sprintf("Mount target IP %s in zone %s is in use", ipAddress, subnet.Zone)
+			return composed.UpdateStatus(state.ObjAsNfsInstance()).
+				SetExclusiveConditions(
+					metav1.Condition{
+						Type:    cloudcontrolv1beta1.ConditionTypeError,
+						Status:  metav1.ConditionTrue,
+						Reason:  cloudcontrolv1beta1.ReasonMountTargetIpInUse,
+						Message: msg,
+					},
+					metav1.Condition{
+						Type:    cloudcontrolv1beta1.ConditionTypeMountTargetIpInUse,
+						Status:  metav1.ConditionTrue,
+						Reason:  cloudcontrolv1beta1.ReasonMountTargetIpInUse,
+						Message: msg,
+					},
+				).
+				ErrorLogMessage("Error updating KCP NfsInstance status with mount target IP in use").
+				SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+				Run(ctx, state)
+		}
+		var zonesMismatch *efsTypes.AvailabilityZonesMismatch
+		if errors.As(err, &zonesMismatch) {
+			return oneZoneConstraintError(ctx, state, fmt.Sprintf(
+				"One Zone file system can not have a mount target in zone %s: %s", subnet.Zone, ptr.Deref(zonesMismatch.Message, "")))
+		}
 		if err != nil {
 			return awsmeta.LogErrorAndReturn(err, "Error creating Mount point", ctx)
 		}
@@ -50,3 +115,11 @@ func createMountTargets(ctx context.Context, st composed.State) (error, context.
 
 	return nil, nil
 }
+
+// mountTargetIp returns the spec.aws.mountTargetIps IP of the zone, or empty to have it assigned by AWS
+func mountTargetIp(nfsInstance *cloudcontrolv1beta1.NfsInstance, zone string) string {
+	if nfsInstance.Spec.Instance.Aws == nil {
+		return ""
+	}
+	return nfsInstance.Spec.Instance.Aws.MountTargetIps[zone]
+}
@@ -0,0 +1,217 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type createMountTargetsSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *createMountTargetsSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *createMountTargetsSuite) newState(mountTargetIps map[string]string, awsMock awsmock.Server) *State {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					MountTargetIps: mountTargetIps,
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+				{Id: "subnet-a", Zone: "eu-west-1a", Range: "10.250.4.0/24"},
+				{Id: "subnet-b", Zone: "eu-west-1b", Range: "10.250.5.0/24"},
+			},
+		},
+	}
+	state := newState(&testNfsInstanceState{State: focalState, ipRange: ipRange}, awsMock, nil)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: ptr.To("fs-1")}
+	state.securityGroupId = "sg-1"
+	return state
+}
+
+func (suite *createMountTargetsSuite) mountTargetIps(awsMock awsmock.Server) map[string]string {
+	list, err := awsMock.DescribeMountTargets(suite.ctx, "fs-1")
+	assert.NoError(suite.T(), err)
+	result := map[string]string{}
+	for _, mt := range list {
+		result[ptr.Deref(mt.SubnetId, "")] = ptr.Deref(mt.IpAddress, "")
+	}
+	return result
+}
+
+func (suite *createMountTargetsSuite) TestFixedIp() {
+	awsMock := awsmock.New()
+	state := suite.newState(map[string]string{"eu-west-1a": "10.250.4.10"}, awsMock)
+
+	err, _ := createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	assert.Equal(suite.T(), map[string]string{
+		"subnet-a": "10.250.4.10",
+		"subnet-b": "1.2.3.4",
+	}, suite.mountTargetIps(awsMock))
+}
+
+func (suite *createMountTargetsSuite) TestIpInUse() {
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateMountTarget(suite.ctx, "fs-other", "subnet-a", "10.250.4.10", nil)
+	assert.NoError(suite.T(), err)
+	state := suite.newState(map[string]string{"eu-west-1a": "10.250.4.10"}, awsMock)
+
+	err, _ = createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+	assert.Empty(suite.T(), suite.mountTargetIps(awsMock))
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeMountTargetIpInUse)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+	assert.Equal(suite.T(), "Mount target IP 10.250.4.10 in zone eu-west-1a is in use", cond.Message)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+}
+
+func (suite *createMountTargetsSuite) TestValidateMountTargetIp() {
+	subnets := cloudcontrolv1beta1.IpRangeSubnets{
+		{Id: "subnet-a", Zone: "eu-west-1a", Range: "10.250.4.0/24"},
+	}
+	for _, tc := range []struct {
+		zone  string
+		ip    string
+		valid bool
+	}{
+		{"eu-west-1a", "10.250.4.10", true},
+		{"eu-west-1a", "10.250.4.4", true},
+		{"eu-west-1a", "10.250.4.254", true},
+		{"eu-west-1a", "10.250.4.3", false},
+		{"eu-west-1a", "10.250.4.0", false},
+		{"eu-west-1a", "10.250.4.255", false},
+		{"eu-west-1a", "10.250.5.10", false},
+		{"eu-west-1a", "not-an-ip", false},
+		{"eu-west-1a", "fd00::1", false},
+		{"eu-west-1b", "10.250.4.10", false},
+	} {
+		msg := validateMountTargetIp(subnets, tc.zone, tc.ip)
+		assert.Equal(suite.T(), tc.valid, msg == "", "%s %s: %s", tc.zone, tc.ip, msg)
+	}
+}
+
+func (suite *createMountTargetsSuite) TestValidateMountTargetIpsSetsError() {
+	state := suite.newState(map[string]string{"eu-west-1a": "10.250.9.10"}, awsmock.New())
+
+	err, _ := validateMountTargetIps(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonInvalidMountTargetIp, cond.Reason)
+}
+
+func (suite *createMountTargetsSuite) TestMountTargetIpEditableUntilCreated() {
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateMountTarget(suite.ctx, "fs-1", "subnet-a", "10.250.4.10", nil)
+	assert.NoError(suite.T(), err)
+
+	state := suite.newState(map[string]string{"eu-west-1a": "10.250.4.10", "eu-west-1b": "10.250.5.20"}, awsMock)
+	err, _ = loadMountTargets(suite.ctx, state)
+	assert.NoError(suite.T(), err)
+	err, _ = validateExistingMountTargetIps(suite.ctx, state)
+	assert.NoError(suite.T(), err, "the IP of the zone without the mount target can be edited")
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.MountTargetIps["eu-west-1a"] = "10.250.4.11"
+	err, _ = validateExistingMountTargetIps(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonInvalidMountTargetIp, cond.Reason)
+	assert.Equal(suite.T(), "Mount target IP of zone eu-west-1a can not be changed from 10.250.4.10 to 10.250.4.11 once the mount target exists", cond.Message)
+}
+
+type ipv6SubnetClient struct {
+	nfsinstanceclient.Client
+	ipv6OnlySubnetId string
+}
+
+func (c *ipv6SubnetClient) DescribeSubnet(ctx context.Context, subnetId string) (*ec2Types.Subnet, error) {
+	subnet := &ec2Types.Subnet{
+		SubnetId: ptr.To(subnetId),
+		Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+			{Ipv6CidrBlock: ptr.To("2600:1f18::/64")},
+		},
+	}
+	if subnetId == c.ipv6OnlySubnetId {
+		subnet.Ipv6Native = ptr.To(true)
+	} else {
+		subnet.CidrBlock = ptr.To("10.250.4.0/24")
+	}
+	return subnet, nil
+}
+
+func (suite *createMountTargetsSuite) TestDualStackSubnetGetsIpv4MountTarget() {
+	awsMock := awsmock.New()
+	state := suite.newState(nil, awsMock)
+	state.awsClient = &ipv6SubnetClient{Client: awsMock}
+
+	err, _ := createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	assert.Len(suite.T(), suite.mountTargetIps(awsMock), 2)
+}
+
+func (suite *createMountTargetsSuite) TestIpv6OnlySubnetIsRejected() {
+	awsMock := awsmock.New()
+	state := suite.newState(nil, awsMock)
+	state.awsClient = &ipv6SubnetClient{Client: awsMock, ipv6OnlySubnetId: "subnet-b"}
+
+	err, _ := createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.NotContains(suite.T(), suite.mountTargetIps(awsMock), "subnet-b")
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonIpv6MountTargetNotSupported, cond.Reason)
+}
+
+func TestCreateMountTargets(t *testing.T) {
+	suite.Run(t, new(createMountTargetsSuite))
+}
@@ -0,0 +1,56 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"time"
+)
+
+func createReplication(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replication != nil || !state.isReplicationDesired() {
+		return nil, nil
+	}
+
+	region := state.ObjAsNfsInstance().Spec.Instance.Aws.Replication.Region
+
+	logger.WithValues("replicaRegion", region).Info("Creating AWS EFS replication")
+
+	_, err := state.awsClient.CreateReplicationConfiguration(ctx, ptr.Deref(state.efs.FileSystemId, ""), region)
+	if err != nil {
+		logger.Error(err, "Error creating AWS EFS replication")
+		return composed.UpdateStatus(state.ObjAsNfsInstance()).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+				Message: fmt.Sprintf("Failed creating replication to region %s: %s", region, err),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating KCP NfsInstance status after failed creating replication").
+			SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+			Run(ctx, state)
+	}
+
+	state.ObjAsNfsInstance().Status.Replica = &cloudcontrolv1beta1.ReplicaStatus{
+		Region: region,
+	}
+
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReplicationSyncing,
+			Message: fmt.Sprintf("Replication to region %s is being established", region),
+		}).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationReady).
+		ErrorLogMessage("Error updating KCP NfsInstance status after creating replication").
+		SuccessError(composed.StopWithRequeue).
+		Run(ctx, state)
+}
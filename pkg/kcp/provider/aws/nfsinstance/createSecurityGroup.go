@@ -3,10 +3,9 @@ package nfsinstance
 import (
 	"context"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
-	"k8s.io/utils/ptr"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 )
 
 func createSecurityGroup(ctx context.Context, st composed.State) (error, context.Context) {
@@ -17,24 +16,7 @@ func createSecurityGroup(ctx context.Context, st composed.State) (error, context
 
 	logger := composed.LoggerFromCtx(ctx)
 
-	sgId, err := state.awsClient.CreateSecurityGroup(ctx, state.IpRange().Status.VpcId, state.Obj().GetName(), []ec2Types.Tag{
-		{
-			Key:   ptr.To("Name"),
-			Value: ptr.To(state.Obj().GetName()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(state.ObjAsNfsInstance().Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(state.ObjAsNfsInstance().Spec.Scope.Name),
-		},
-	})
+	sgId, err := state.awsClient.CreateSecurityGroup(ctx, state.IpRange().Status.VpcId, state.Obj().GetName(), state.securityGroupTags())
 	if err != nil {
 		return awsmeta.LogErrorAndReturn(err, "Error creating security group", ctx)
 	}
@@ -46,3 +28,7 @@ func createSecurityGroup(ctx context.Context, st composed.State) (error, context
 
 	return nil, composed.LoggerIntoCtx(ctx, logger)
 }
+
+func (s *State) securityGroupTags() []ec2Types.Tag {
+	return awsutil.Ec2TagsFromMap(s.tags, "Name", s.Obj().GetName())
+}
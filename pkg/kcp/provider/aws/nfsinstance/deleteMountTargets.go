@@ -3,6 +3,8 @@ package nfsinstance
 import (
 	"context"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	"github.com/kyma-project/cloud-manager/pkg/util"
@@ -27,8 +29,21 @@ func deleteMountTargets(ctx context.Context, st composed.State) (error, context.
 		efsTypes.LifeCycleStateAvailable: {},
 	}
 
+	// only the mount targets in the IpRange subnets are created by cloud-manager and
+	// deleted from the adopted EFS that is retained
+	retained := actions.IsAdoptedAndRetained(state.ObjAsNfsInstance())
+	var ipRangeSubnetIds []string
+	if state.IpRange() != nil {
+		for _, sn := range state.IpRange().Status.Subnets {
+			ipRangeSubnetIds = append(ipRangeSubnetIds, sn.Id)
+		}
+	}
+
 	anyDeleted := false
 	for _, mt := range state.mountTargets {
+		if retained && !pie.Contains(ipRangeSubnetIds, ptr.Deref(mt.SubnetId, "")) {
+			continue
+		}
 		lll := logger.
 			WithValues(
 				"mountTargetId", ptr.Deref(mt.MountTargetId, ""),
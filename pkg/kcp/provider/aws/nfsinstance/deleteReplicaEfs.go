@@ -0,0 +1,48 @@
+package nfsinstance
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+)
+
+func deleteReplicaEfs(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replication != nil || state.isReplicationDesired() {
+		return nil, nil
+	}
+
+	replica := state.ObjAsNfsInstance().Status.Replica
+	if replica == nil {
+		return nil, nil
+	}
+
+	// the promoted replica is the writable file system workloads failed over to, and is left in
+	// place even when the NfsInstance is deleted, so it can be adopted by another NfsInstance
+	if replica.Promoted {
+		return nil, nil
+	}
+
+	if len(replica.Id) > 0 && state.replicaClient != nil {
+		logger.WithValues("replicaEfsId", replica.Id, "replicaRegion", replica.Region).Info("Deleting AWS EFS replica")
+		err := state.replicaClient.DeleteFileSystem(ctx, replica.Id)
+		if err != nil && !awsmeta.IsNotFound(err) {
+			return awsmeta.LogErrorAndReturn(err, "Error deleting AWS EFS replica", ctx)
+		}
+	}
+
+	state.ObjAsNfsInstance().Status.Replica = nil
+
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		RemoveConditions(
+			cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+			cloudcontrolv1beta1.ConditionTypeReplicationReady,
+		).
+		ErrorLogMessage("Error updating KCP NfsInstance status after deleting replica").
+		SuccessLogMsg("KCP NfsInstance replica deleted").
+		SuccessError(composed.StopWithRequeue).
+		Run(ctx, state)
+}
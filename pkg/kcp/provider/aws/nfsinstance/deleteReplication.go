@@ -0,0 +1,43 @@
+package nfsinstance
+
+import (
+	"context"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+	"time"
+)
+
+func deleteReplication(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replication == nil || state.isReplicationDesired() {
+		return nil, nil
+	}
+
+	// replication of the adopted EFS that was not created by cloud-manager is left in place
+	if state.ObjAsNfsInstance().Status.AdoptedResourceId != "" && state.ObjAsNfsInstance().Status.Replica == nil {
+		return nil, nil
+	}
+
+	for _, destination := range state.replication.Destinations {
+		if destination.Status == efsTypes.ReplicationStatusDeleting {
+			logger.Info("Waiting AWS EFS replication to get deleted")
+			return composed.StopWithRequeueDelay(10 * time.Second), nil
+		}
+	}
+
+	logger.Info("Deleting AWS EFS replication")
+
+	err := state.awsClient.DeleteReplicationConfiguration(ctx, ptr.Deref(state.efs.FileSystemId, ""))
+	if awsmeta.IsNotFound(err) {
+		return composed.StopWithRequeue, nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting AWS EFS replication", ctx)
+	}
+
+	return composed.StopWithRequeue, nil
+}
@@ -0,0 +1,47 @@
+package nfsinstance
+
+import (
+	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func deleteWorkloadAccessRole(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.isWorkloadAccessDesired() {
+		return nil, nil
+	}
+
+	nfsInstance := state.ObjAsNfsInstance()
+	if len(nfsInstance.Status.WorkloadAccessRoleArn) == 0 &&
+		meta.FindStatusCondition(*nfsInstance.Conditions(), cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady) == nil {
+		return nil, nil
+	}
+
+	roleName := getWorkloadAccessRoleName(nfsInstance)
+	logger = logger.WithValues("workloadAccessRole", roleName)
+
+	err := state.awsClient.DeleteRolePolicy(ctx, roleName, workloadAccessPolicyName)
+	if err != nil && !awsmeta.IsNotFound(err) {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting AWS IAM workload access role policy", ctx)
+	}
+
+	logger.Info("Deleting AWS IAM workload access role")
+	err = state.awsClient.DeleteRole(ctx, roleName)
+	if err != nil && !awsmeta.IsNotFound(err) {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting AWS IAM workload access role", ctx)
+	}
+
+	nfsInstance.Status.WorkloadAccessRoleArn = ""
+
+	return composed.UpdateStatus(nfsInstance).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady).
+		ErrorLogMessage("Error updating KCP NfsInstance status after deleting workload access role").
+		SuccessLogMsg("KCP NfsInstance workload access role deleted").
+		SuccessError(composed.StopWithRequeue).
+		Run(ctx, state)
+}
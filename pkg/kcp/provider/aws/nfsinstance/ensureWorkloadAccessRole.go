@@ -0,0 +1,144 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"time"
+)
+
+func ensureWorkloadAccessRole(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.efs == nil || !state.isWorkloadAccessDesired() {
+		return nil, nil
+	}
+
+	nfsInstance := state.ObjAsNfsInstance()
+	options := nfsInstance.Spec.Instance.Aws.WorkloadAccess
+	roleName := getWorkloadAccessRoleName(nfsInstance)
+	logger = logger.WithValues("workloadAccessRole", roleName)
+
+	issuer, err := getOidcProviderIssuer(options.OidcProviderArn, state.Scope().Spec.Scope.Aws.AccountId)
+	if err != nil {
+		logger.Error(err, "Invalid workload access OIDC provider")
+		condition := metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  cloudcontrolv1beta1.ReasonWorkloadAccessInvalid,
+			Message: err.Error(),
+		}
+		if !composed.AnyConditionChanged(nfsInstance, condition) {
+			return composed.StopAndForget, nil
+		}
+		return composed.UpdateStatus(nfsInstance).
+			SetCondition(condition).
+			ErrorLogMessage("Error updating KCP NfsInstance status with invalid workload access").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	trustPolicy, err := newWorkloadAccessTrustPolicy(options, issuer)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error creating workload access role trust policy", composed.StopAndForget, ctx)
+	}
+	accessPolicy, err := newWorkloadAccessPolicy(ptr.Deref(state.efs.FileSystemArn, ""))
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error creating workload access role policy", composed.StopAndForget, ctx)
+	}
+
+	role, err := state.awsClient.GetRole(ctx, roleName)
+	if awsmeta.IsNotFound(err) {
+		role = nil
+		err = nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS IAM workload access role", ctx)
+	}
+
+	if role == nil {
+		logger.Info("Creating AWS IAM workload access role")
+		role, err = state.awsClient.CreateRole(ctx, roleName, trustPolicy, []iamTypes.Tag{
+			{
+				Key:   ptr.To(common.TagCloudManagerName),
+				Value: ptr.To(state.Name().String()),
+			},
+			{
+				Key:   ptr.To(common.TagCloudManagerRemoteName),
+				Value: ptr.To(nfsInstance.Spec.RemoteRef.String()),
+			},
+			{
+				Key:   ptr.To(common.TagScope),
+				Value: ptr.To(nfsInstance.Spec.Scope.Name),
+			},
+			{
+				Key:   ptr.To(common.TagShoot),
+				Value: ptr.To(state.Scope().Spec.ShootName),
+			},
+		})
+		if err != nil {
+			logger.Error(err, "Error creating AWS IAM workload access role")
+			return composed.UpdateStatus(nfsInstance).
+				SetCondition(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  cloudcontrolv1beta1.ReasonWorkloadAccessFailed,
+					Message: fmt.Sprintf("Failed creating workload access role: %s", awsmeta.GetErrorMessage(err)),
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status after failed creating workload access role").
+				SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+				Run(ctx, state)
+		}
+	} else if !policyDocumentsEqual(ptr.Deref(role.AssumeRolePolicyDocument, ""), trustPolicy) {
+		// the trust policy must reference only the expected OIDC provider and service account
+		logger.Info("Updating AWS IAM workload access role trust policy")
+		err = state.awsClient.UpdateAssumeRolePolicy(ctx, roleName, trustPolicy)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error updating AWS IAM workload access role trust policy", ctx)
+		}
+	}
+
+	currentPolicy, err := state.awsClient.GetRolePolicy(ctx, roleName, workloadAccessPolicyName)
+	if awsmeta.IsNotFound(err) {
+		currentPolicy = ""
+		err = nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS IAM workload access role policy", ctx)
+	}
+	if !policyDocumentsEqual(currentPolicy, accessPolicy) {
+		logger.Info("Putting AWS IAM workload access role policy")
+		err = state.awsClient.PutRolePolicy(ctx, roleName, workloadAccessPolicyName, accessPolicy)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error putting AWS IAM workload access role policy", ctx)
+		}
+	}
+
+	roleArn := ptr.Deref(role.Arn, "")
+	if nfsInstance.Status.WorkloadAccessRoleArn == roleArn &&
+		meta.IsStatusConditionTrue(*nfsInstance.Conditions(), cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady) {
+		return nil, nil
+	}
+
+	nfsInstance.Status.WorkloadAccessRoleArn = roleArn
+
+	return composed.UpdateStatus(nfsInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeWorkloadAccessReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonWorkloadAccessReady,
+			Message: fmt.Sprintf("Workload access role %s is ready", roleArn),
+		}).
+		ErrorLogMessage("Error updating KCP NfsInstance status with workload access role").
+		SuccessLogMsg("KCP NfsInstance workload access role is ready").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
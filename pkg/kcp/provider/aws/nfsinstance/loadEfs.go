@@ -16,8 +16,10 @@ func loadEfs(ctx context.Context, st composed.State) (error, context.Context) {
 		return awsmeta.LogErrorAndReturn(err, "Error listing AWS file systems", ctx)
 	}
 
+	adoptedId := state.ObjAsNfsInstance().Status.AdoptedResourceId
 	for _, fs := range list {
-		if ptr.Deref(fs.Name, "") == state.Obj().GetName() {
+		if ptr.Deref(fs.Name, "") == state.Obj().GetName() ||
+			adoptedId != "" && ptr.Deref(fs.FileSystemId, "") == adoptedId {
 			state.efs = &fs
 			break
 		}
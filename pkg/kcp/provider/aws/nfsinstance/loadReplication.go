@@ -0,0 +1,41 @@
+package nfsinstance
+
+import (
+	"context"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+func loadReplication(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.efs == nil {
+		return nil, nil
+	}
+
+	list, err := state.awsClient.DescribeReplicationConfigurations(ctx, ptr.Deref(state.efs.FileSystemId, ""))
+	if awsmeta.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS EFS replication configuration", ctx)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	state.replication = &list[0]
+
+	if len(state.replication.Destinations) > 0 {
+		logger = logger.WithValues(
+			"replicaEfsId", ptr.Deref(state.replication.Destinations[0].FileSystemId, ""),
+			"replicaRegion", ptr.Deref(state.replication.Destinations[0].Region, ""),
+			"replicationStatus", state.replication.Destinations[0].Status,
+		)
+		ctx = composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, ctx
+}
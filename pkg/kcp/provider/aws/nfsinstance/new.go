@@ -3,6 +3,8 @@ package nfsinstance
 import (
 	"context"
 	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	nfsinstancetypes "github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance/types"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
@@ -26,21 +28,51 @@ func New(stateFactory StateFactory) composed.Action {
 				composed.ComposeActions(
 					"awsNfsInstance-non-delete",
 					validateIpRangeSubnets,
+					validateZoneCount,
+					validateMountTargetIps,
+					validateOneZone,
+					actions.ValidateTags(
+						cloudcontrolv1beta1.ProviderAws,
+						func(st composed.State) map[string]string { return getTags(st.(*State)) },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
 					addFinalizer,
 					findSecurityGroup,
 					createSecurityGroup,
 					loadSecurityGroup,
-					authorizeSecurityGroupIngress,
+					reconcileSecurityGroupIngress,
 					loadEfs,
+					adoptEfs,
+					preventOneZoneEdit,
 					createEfs,
 					waitEfsAvailable,
+					reconcilePropagatedTags,
 					loadMountTargets,
 					validateExistingMountTargets,
+					validateExistingMountTargetIps,
 					createMountTargets,
 					waitMountTargetsAvailable,
+					reconcileDnsRecord,
 					removeMountTargetsFromOtherVpcs,
+					reconcileMountTargetSecurityGroups,
+					updateMountTargetsStatus,
 					updateStatus,
 
+					loadReplication,
+					promoteReplica,
+					deleteReplication,
+					deleteReplicaEfs,
+					createReplication,
+					waitReplicationSynced,
+					reconcileReplicaReadOnly,
+
+					deleteWorkloadAccessRole,
+					ensureWorkloadAccessRole,
+
+					reconcilePolicies,
+
+					reconcileRegions,
+
 					composed.StopAndForgetAction,
 				),
 				// delete
@@ -49,18 +81,30 @@ func New(stateFactory StateFactory) composed.Action {
 					composed.ComposeActions(
 						"awsNfsInstance-delete",
 						removeReadyCondition,
+						deleteDnsRecord,
 						loadEfs,
 						findSecurityGroup,
 						loadMountTargets,
 
+						loadReplication,
+						deleteReplication,
+						deleteReplicaEfs,
+
+						deleteWorkloadAccessRole,
+						deleteBackupPlan,
+
+						deleteRegions,
+
 						deleteMountTargets,
 						waitMountTargetsDeleted,
 
+						releaseAdoptedEfs,
 						deleteEfs,
 						waitEfsDeleted,
 
 						deleteSecurityGroup,
 
+						composed.CleanupStatusOnDelete("", clearStatusOnDelete),
 						removeFinalizer,
 
 						composed.StopAndForgetAction,
@@ -71,3 +115,21 @@ func New(stateFactory StateFactory) composed.Action {
 		)(awsmeta.SetAwsAccountId(ctx, nfsState.Scope().Spec.Scope.Aws.AccountId), state)
 	}
 }
+
+// reconcilePolicies reconciles the policies of the available file system. Each of them records its state
+// in the status, so the status is written once after all of them.
+var reconcilePolicies = composed.WithDeferredStatusUpdate(
+	composed.ComposeActions(
+		"awsNfsInstance-policies",
+		reconcileLifecyclePolicy,
+		reconcileBackupPolicy,
+		composed.Optional(
+			cloudcontrolv1beta1.ConditionTypeBackupConfigurationFailed,
+			cloudcontrolv1beta1.ReasonBackupConfigurationFailed,
+			reconcileBackupPlan,
+		),
+		reconcileFileSystemPolicy,
+		reconcileCostEstimate,
+		reconcileThroughputScaling,
+	),
+)
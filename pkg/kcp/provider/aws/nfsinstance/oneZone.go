@@ -0,0 +1,108 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// oneZone returns the spec.aws.oneZone availability zone, or empty for the Regional storage class
+func oneZone(nfsInstance *cloudcontrolv1beta1.NfsInstance) string {
+	if nfsInstance.Spec.Instance.Aws == nil {
+		return ""
+	}
+	return nfsInstance.Spec.Instance.Aws.OneZone
+}
+
+// storagePlacement returns the availability zone of the One Zone storage class, or Regional
+func storagePlacement(availabilityZone string) string {
+	if len(availabilityZone) > 0 {
+		return availabilityZone
+	}
+	return cloudcontrolv1beta1.AwsStorageClassRegional
+}
+
+// validateOneZone checks the spec.aws.oneZone is one of the IpRange subnet zones, and that no
+// spec.aws.mountTargetIps is specified for other zones, since the One Zone file system can
+// have a mount target only in its own zone
+func validateOneZone(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	nfsInstance := state.ObjAsNfsInstance()
+	zone := oneZone(nfsInstance)
+	if len(zone) == 0 {
+		return nil, nil
+	}
+
+	if state.IpRange().Status.Subnets.SubnetByZone(zone) == nil {
+		return composed.UpdateStatus(nfsInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonValidationFailed,
+				Message: fmt.Sprintf("OneZone %s is not one of the IpRange subnet zones", zone),
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with invalid One Zone").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	var otherZones []string
+	for mtZone := range nfsInstance.Spec.Instance.Aws.MountTargetIps {
+		if mtZone != zone {
+			otherZones = append(otherZones, mtZone)
+		}
+	}
+	if len(otherZones) > 0 {
+		sort.Strings(otherZones)
+		return oneZoneConstraintError(ctx, state, fmt.Sprintf(
+			"One Zone file system in zone %s can not have mount targets in zones %v", zone, otherZones))
+	}
+
+	return nil, nil
+}
+
+// oneZoneConstraintError sets the OneZoneConstraint condition and forgets the NfsInstance
+func oneZoneConstraintError(ctx context.Context, state *State, msg string) (error, context.Context) {
+	composed.LoggerFromCtx(ctx).Info(msg)
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		SetExclusiveConditions(
+			metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonOneZoneConstraint,
+				Message: msg,
+			},
+			metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeOneZoneConstraint,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonOneZoneConstraint,
+				Message: msg,
+			},
+		).
+		ErrorLogMessage("Error updating KCP NfsInstance status with OneZoneConstraint condition").
+		SuccessError(composed.StopAndForget).
+		Run(ctx, state)
+}
+
+// preventOneZoneEdit rejects the spec.aws.oneZone not matching the storage class and the zone
+// of the already existing file system, since they can not change after the creation
+func preventOneZoneEdit(ctx context.Context, st composed.State) (error, context.Context) {
+	return composed.PreventFieldEditWithOriginal(
+		"spec.instance.aws.oneZone",
+		func(st composed.State) any {
+			return storagePlacement(oneZone(st.(*State).ObjAsNfsInstance()))
+		},
+		func(st composed.State) any {
+			state := st.(*State)
+			if state.efs == nil {
+				return nil
+			}
+			return storagePlacement(ptr.Deref(state.efs.AvailabilityZoneName, ""))
+		},
+	)(ctx, st)
+}
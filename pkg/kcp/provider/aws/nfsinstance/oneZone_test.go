@@ -0,0 +1,218 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// zonesMismatchClient fails the mount target creation as AWS does for the One Zone file system
+// and a subnet in another zone
+type zonesMismatchClient struct {
+	nfsinstanceclient.Client
+}
+
+func (c *zonesMismatchClient) CreateMountTarget(_ context.Context, _, _, _ string, _ []string) (string, error) {
+	return "", &efsTypes.AvailabilityZonesMismatch{Message: ptr.To("Availability zones do not match")}
+}
+
+type oneZoneSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *oneZoneSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *oneZoneSuite) newState(awsSpec *cloudcontrolv1beta1.NfsInstanceAws, awsClient nfsinstanceclient.Client) *State {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: awsSpec,
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Region:    "eu-west-1",
+			ShootName: "shoot",
+		},
+	})
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+				{Id: "subnet-a", Zone: "eu-west-1a", Range: "10.250.4.0/24"},
+				{Id: "subnet-b", Zone: "eu-west-1b", Range: "10.250.5.0/24"},
+			},
+		},
+	}
+	state := newState(&testNfsInstanceState{State: focalState, ipRange: ipRange}, awsClient, nil)
+	state.securityGroupId = "sg-1"
+	return state
+}
+
+func (suite *oneZoneSuite) TestPlacement() {
+	awsMock := awsmock.New()
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{OneZone: "eu-west-1b"}, awsMock)
+
+	err, _ := validateOneZone(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	err, _ = createEfs(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	assert.NotNil(suite.T(), state.efs)
+	assert.Equal(suite.T(), "eu-west-1b", ptr.Deref(state.efs.AvailabilityZoneName, ""))
+
+	err, _ = createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	list, err := awsMock.DescribeMountTargets(suite.ctx, ptr.Deref(state.efs.FileSystemId, ""))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), list, 1)
+	assert.Equal(suite.T(), "subnet-b", ptr.Deref(list[0].SubnetId, ""))
+
+	state.ObjAsNfsInstance().Status.Hosts = nil
+	_, _ = updateStatus(suite.ctx, state)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.AwsStorageClassOneZone, state.ObjAsNfsInstance().Status.StorageClass)
+	assert.Equal(suite.T(), "eu-west-1b", state.ObjAsNfsInstance().Status.AvailabilityZone)
+}
+
+func (suite *oneZoneSuite) TestRegionalPlacement() {
+	awsMock := awsmock.New()
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{}, awsMock)
+
+	_, _ = createEfs(suite.ctx, state)
+	assert.Nil(suite.T(), state.efs.AvailabilityZoneName)
+
+	_, _ = createMountTargets(suite.ctx, state)
+	list, err := awsMock.DescribeMountTargets(suite.ctx, ptr.Deref(state.efs.FileSystemId, ""))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), list, 2)
+
+	_, _ = updateStatus(suite.ctx, state)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.AwsStorageClassRegional, state.ObjAsNfsInstance().Status.StorageClass)
+	assert.Empty(suite.T(), state.ObjAsNfsInstance().Status.AvailabilityZone)
+}
+
+func (suite *oneZoneSuite) TestZoneNotInIpRangeSubnets() {
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{OneZone: "eu-west-1c"}, awsmock.New())
+
+	err, _ := validateOneZone(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonValidationFailed, cond.Reason)
+	assert.Equal(suite.T(), "OneZone eu-west-1c is not one of the IpRange subnet zones", cond.Message)
+}
+
+func (suite *oneZoneSuite) TestMountTargetIpInOtherZoneRejected() {
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{
+		OneZone:        "eu-west-1a",
+		MountTargetIps: map[string]string{"eu-west-1a": "10.250.4.10", "eu-west-1b": "10.250.5.10"},
+	}, awsmock.New())
+
+	err, _ := validateOneZone(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeOneZoneConstraint)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), "One Zone file system in zone eu-west-1a can not have mount targets in zones [eu-west-1b]", cond.Message)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+}
+
+func (suite *oneZoneSuite) TestMountTargetInOtherZoneRejectedByAws() {
+	// adopted One Zone file system without the spec.aws.oneZone
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{}, &zonesMismatchClient{Client: awsmock.New()})
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: ptr.To("fs-1"), AvailabilityZoneName: ptr.To("eu-west-1a")}
+
+	err, _ := createMountTargets(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeOneZoneConstraint)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonOneZoneConstraint, cond.Reason)
+}
+
+func (suite *oneZoneSuite) TestEditRejected() {
+	for _, tc := range []struct {
+		title      string
+		oneZone    string
+		efsZone    *string
+		rejected   bool
+		rejectedTo string
+	}{
+		{"unchanged one zone", "eu-west-1a", ptr.To("eu-west-1a"), false, ""},
+		{"unchanged regional", "", nil, false, ""},
+		{"zone changed", "eu-west-1b", ptr.To("eu-west-1a"), true, "eu-west-1b"},
+		{"one zone removed", "", ptr.To("eu-west-1a"), true, cloudcontrolv1beta1.AwsStorageClassRegional},
+		{"one zone added", "eu-west-1a", nil, true, "eu-west-1a"},
+	} {
+		suite.Run(tc.title, func() {
+			state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{OneZone: tc.oneZone}, awsmock.New())
+			state.efs = &efsTypes.FileSystemDescription{FileSystemId: ptr.To("fs-1"), AvailabilityZoneName: tc.efsZone}
+
+			err, _ := preventOneZoneEdit(suite.ctx, state)
+			cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, composed.ConditionTypeImmutableFieldEdited)
+			if !tc.rejected {
+				assert.Nil(suite.T(), err)
+				assert.Nil(suite.T(), cond)
+				return
+			}
+			assert.Equal(suite.T(), composed.StopAndForget, err)
+			assert.NotNil(suite.T(), cond)
+			assert.Contains(suite.T(), cond.Message, "spec.instance.aws.oneZone")
+			assert.Contains(suite.T(), cond.Message, "to "+tc.rejectedTo)
+		})
+	}
+}
+
+func (suite *oneZoneSuite) TestEditNotCheckedBeforeCreation() {
+	state := suite.newState(&cloudcontrolv1beta1.NfsInstanceAws{OneZone: "eu-west-1a"}, awsmock.New())
+
+	err, _ := preventOneZoneEdit(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestOneZone(t *testing.T) {
+	suite.Run(t, new(oneZoneSuite))
+}
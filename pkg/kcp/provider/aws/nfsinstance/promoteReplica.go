@@ -0,0 +1,86 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"time"
+)
+
+// promoteReplica deletes the replication configuration once the failover is requested in the spec,
+// what makes the replica writable, and marks the replica in the status as promoted
+func promoteReplica(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if composed.IsMarkedForDeletion(state.Obj()) || !state.isFailoverRequested() {
+		return nil, nil
+	}
+
+	nfsInstance := state.ObjAsNfsInstance()
+	replica := nfsInstance.Status.Replica
+	if replica == nil || len(replica.Id) == 0 {
+		// replica was never fully provisioned, there is nothing to promote
+		return nil, nil
+	}
+
+	if state.replication != nil {
+		for _, destination := range state.replication.Destinations {
+			if destination.Status == efsTypes.ReplicationStatusDeleting {
+				logger.Info("Waiting AWS EFS replication to get deleted for replica promotion")
+				return composed.StopWithRequeueDelay(10 * time.Second), nil
+			}
+		}
+
+		logger.WithValues("replicaEfsId", replica.Id, "replicaRegion", replica.Region).Info("Promoting AWS EFS replica")
+
+		err := state.awsClient.DeleteReplicationConfiguration(ctx, ptr.Deref(state.efs.FileSystemId, ""))
+		if err != nil && !awsmeta.IsNotFound(err) {
+			return awsmeta.LogErrorAndReturn(err, "Error deleting AWS EFS replication for replica promotion", ctx)
+		}
+
+		if meta.FindStatusCondition(*nfsInstance.Conditions(), cloudcontrolv1beta1.ConditionTypeReplicaPromoted) != nil {
+			return composed.StopWithRequeueDelay(10 * time.Second), nil
+		}
+
+		return composed.UpdateStatus(nfsInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicaPromoted,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicaPromoting,
+				Message: fmt.Sprintf("Replica in region %s is being promoted", replica.Region),
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with promoting replica").
+			SuccessError(composed.StopWithRequeueDelay(10*time.Second)).
+			Run(ctx, state)
+	}
+
+	if replica.Promoted {
+		return nil, nil
+	}
+
+	replica.Promoted = true
+
+	return composed.UpdateStatus(nfsInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicaPromoted,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReplicaPromoted,
+			Message: fmt.Sprintf("Replica %s in region %s is promoted and writable", replica.Id, replica.Region),
+		}).
+		RemoveConditions(
+			cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+			cloudcontrolv1beta1.ConditionTypeReplicationReady,
+			cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly,
+		).
+		ErrorLogMessage("Error updating KCP NfsInstance status with promoted replica").
+		SuccessLogMsg("KCP NfsInstance replica promoted").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,149 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type promoteReplicaSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *promoteReplicaSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *promoteReplicaSuite) newState(failover bool) (*State, awsmock.Server) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					Replication: &cloudcontrolv1beta1.ReplicationOptions{Region: "eu-west-1", Failover: failover},
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(suite.T(), err)
+	replication, err := awsMock.CreateReplicationConfiguration(suite.ctx, ptr.Deref(out.FileSystemId, ""), "eu-west-1")
+	assert.NoError(suite.T(), err)
+	awsMock.SetReplicationDestinationStatus(ptr.Deref(out.FileSystemId, ""), efsTypes.ReplicationStatusEnabled)
+
+	nfsInstance.Status.Replica = &cloudcontrolv1beta1.ReplicaStatus{
+		Region: "eu-west-1",
+		Id:     ptr.Deref(replication.Destinations[0].FileSystemId, ""),
+	}
+
+	// the mock is not region aware, so it serves as the replica region client as well
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, awsMock)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	return state, awsMock
+}
+
+func (suite *promoteReplicaSuite) TestReplicaIsReadOnly() {
+	state, _ := suite.newState(false)
+
+	_, _ = loadReplication(suite.ctx, state)
+	assert.NotNil(suite.T(), state.replication)
+
+	err, _ := reconcileReplicaReadOnly(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonReplicationDestinationReadOnly, cond.Reason)
+}
+
+func (suite *promoteReplicaSuite) TestNotPromotedWithoutFailover() {
+	state, _ := suite.newState(false)
+
+	_, _ = loadReplication(suite.ctx, state)
+	err, _ := promoteReplica(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), state.replication)
+	assert.False(suite.T(), state.ObjAsNfsInstance().Status.Replica.Promoted)
+
+	state.replication = nil
+	_, _ = loadReplication(suite.ctx, state)
+	assert.NotNil(suite.T(), state.replication, "replication is not deleted")
+}
+
+func (suite *promoteReplicaSuite) TestFailoverPromotesReplica() {
+	state, awsMock := suite.newState(true)
+	nfsInstance := state.ObjAsNfsInstance()
+	replicaId := nfsInstance.Status.Replica.Id
+
+	_, _ = loadReplication(suite.ctx, state)
+	err, _ := promoteReplica(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(10*time.Second), err)
+	cond := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicaPromoted)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonReplicaPromoting, cond.Reason)
+
+	state.replication = nil
+	_, _ = loadReplication(suite.ctx, state)
+	assert.Nil(suite.T(), state.replication)
+
+	err, _ = promoteReplica(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), nfsInstance.Status.Replica.Promoted)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicaPromoted))
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly))
+
+	replicaFs := awsMock.GetFileSystemById(replicaId)
+	assert.NotNil(suite.T(), replicaFs)
+	assert.Equal(suite.T(), efsTypes.ReplicationOverwriteProtectionEnabled, replicaFs.FileSystemProtection.ReplicationOverwriteProtection)
+
+	// promoted replica is neither deleted nor replicated to again
+	err, _ = deleteReplicaEfs(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), awsMock.GetFileSystemById(replicaId))
+	assert.Equal(suite.T(), replicaId, nfsInstance.Status.Replica.Id)
+
+	err, _ = createReplication(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	state.replication = nil
+	_, _ = loadReplication(suite.ctx, state)
+	assert.Nil(suite.T(), state.replication)
+}
+
+func TestPromoteReplica(t *testing.T) {
+	suite.Run(t, new(promoteReplicaSuite))
+}
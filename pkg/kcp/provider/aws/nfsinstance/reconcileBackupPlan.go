@@ -0,0 +1,423 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	backupTypes "github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/gorhill/cronexpr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	backupRuleName = "scheduled"
+
+	// backupCopyFailedThreshold is the number of the latest copy jobs that must all fail for the
+	// BackupCopyFailed condition to be set
+	backupCopyFailedThreshold = 3
+	backupCopyJobsWindow      = 7 * 24 * time.Hour
+)
+
+var (
+	awsRegionRegex = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-\d$`)
+	numberRegex    = regexp.MustCompile(`\d+`)
+)
+
+func getBackupCopyRegion(nfsInstance *cloudcontrolv1beta1.NfsInstance) string {
+	if nfsInstance.Spec.Backup == nil || !nfsInstance.Spec.Backup.Enabled {
+		return ""
+	}
+	return nfsInstance.Spec.Backup.CopyToRegion
+}
+
+// getBackupVaultName returns the name of the vault of the scope, shared with the AwsNfsVolumeBackup
+func getBackupVaultName(state *State) string {
+	return fmt.Sprintf("cm-%s", state.Scope().Name)
+}
+
+func getBackupPlanName(nfsInstance *cloudcontrolv1beta1.NfsInstance) string {
+	return fmt.Sprintf("cm-%s", nfsInstance.Name)
+}
+
+// getBackupScheduleExpression converts the spec.backup schedule to the AWS Backup cron expression. The
+// interval must be whole hours dividing the day, or whole days. In the five fields cron expression
+// either the day of month or the day of week must be *, and the days of week are shifted to 1-7.
+func getBackupScheduleExpression(schedule string) (string, error) {
+	if interval, err := time.ParseDuration(schedule); err == nil {
+		hours := int(interval / time.Hour)
+		switch {
+		case interval%time.Hour != 0 || hours < 1:
+			return "", fmt.Errorf("backup interval %s must be whole hours", schedule)
+		case hours < 24 && 24%hours == 0:
+			return fmt.Sprintf("cron(0 0/%d * * ? *)", hours), nil
+		case hours%24 == 0:
+			return fmt.Sprintf("cron(0 0 1/%d * ? *)", hours/24), nil
+		}
+		return "", fmt.Errorf("backup interval %s must divide the day or be whole days", schedule)
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("invalid backup schedule %q: expected five fields cron expression", schedule)
+	}
+	if _, err := cronexpr.Parse(schedule); err != nil {
+		return "", fmt.Errorf("invalid backup schedule %q: %w", schedule, err)
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+	switch {
+	case dow == "*":
+		dow = "?"
+	case dom == "*":
+		dom = "?"
+		if strings.Contains(dow, "/") {
+			return "", fmt.Errorf("invalid backup schedule %q: day of week step is not supported", schedule)
+		}
+		dow = numberRegex.ReplaceAllStringFunc(dow, func(d string) string {
+			n, _ := strconv.Atoi(d)
+			return strconv.Itoa(n%7 + 1)
+		})
+	default:
+		return "", fmt.Errorf("invalid backup schedule %q: either the day of month or the day of week must be *", schedule)
+	}
+	return fmt.Sprintf("cron(%s %s %s %s %s *)", minute, hour, dom, month, dow), nil
+}
+
+// findBackupPlanId returns the id of the backup plan with the given name, or empty if not found
+func findBackupPlanId(ctx context.Context, c nfsinstanceclient.Client, name string) (string, error) {
+	plans, err := c.ListBackupPlans(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range plans {
+		if ptr.Deref(p.BackupPlanName, "") == name {
+			return ptr.Deref(p.BackupPlanId, ""), nil
+		}
+	}
+	return "", nil
+}
+
+// loadOrCreateBackupVault returns the ARN of the vault, creating it if it does not exist
+func loadOrCreateBackupVault(ctx context.Context, c nfsinstanceclient.Client, name string, tags map[string]string) (string, error) {
+	arn, err := c.DescribeBackupVault(ctx, name)
+	if awsmeta.IsNotFound(err) {
+		return c.CreateBackupVault(ctx, name, tags)
+	}
+	return arn, err
+}
+
+func backupPlanMatches(current *backupTypes.BackupPlan, desired *backupTypes.BackupPlanInput) bool {
+	if current == nil || len(current.Rules) != 1 {
+		return false
+	}
+	c, d := current.Rules[0], desired.Rules[0]
+	if ptr.Deref(c.RuleName, "") != ptr.Deref(d.RuleName, "") ||
+		ptr.Deref(c.TargetBackupVaultName, "") != ptr.Deref(d.TargetBackupVaultName, "") ||
+		ptr.Deref(c.ScheduleExpression, "") != ptr.Deref(d.ScheduleExpression, "") ||
+		deleteAfterDays(c.Lifecycle) != deleteAfterDays(d.Lifecycle) ||
+		len(c.CopyActions) != len(d.CopyActions) {
+		return false
+	}
+	for i := range c.CopyActions {
+		if ptr.Deref(c.CopyActions[i].DestinationBackupVaultArn, "") != ptr.Deref(d.CopyActions[i].DestinationBackupVaultArn, "") ||
+			deleteAfterDays(c.CopyActions[i].Lifecycle) != deleteAfterDays(d.CopyActions[i].Lifecycle) {
+			return false
+		}
+	}
+	return true
+}
+
+func deleteAfterDays(lifecycle *backupTypes.Lifecycle) int64 {
+	if lifecycle == nil {
+		return 0
+	}
+	return ptr.Deref(lifecycle.DeleteAfterDays, 0)
+}
+
+// hasBackupPlanError returns true if the Error condition is set by reconcileBackupPlan
+func hasBackupPlanError(nfsInstance *cloudcontrolv1beta1.NfsInstance) bool {
+	errorCondition := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	if errorCondition == nil {
+		return false
+	}
+	switch errorCondition.Reason {
+	case cloudcontrolv1beta1.ReasonInvalidSchedule, cloudcontrolv1beta1.ReasonInvalidBackupCopyRegion, cloudcontrolv1beta1.ReasonBackupPlanFailed:
+		return true
+	}
+	return false
+}
+
+func backupPlanError(ctx context.Context, state *State, reason, message string, result error) (error, context.Context) {
+	nfsInstance := state.ObjAsNfsInstance()
+	return composed.UpdateStatus(nfsInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		}).
+		ErrorLogMessage("Error updating KCP NfsInstance status after failed reconciling backup plan").
+		SuccessLogMsg(message).
+		SuccessError(result).
+		Run(ctx, state)
+}
+
+// reconcileBackupPlan converges the AWS Backup plan copying the EFS backups to the spec.backup copyToRegion.
+// The plan exists only for the copy action, with a single rule backing up to the vault of the scope on the
+// schedule with the retention, and copying the backups to the vault of the same name in the copyToRegion,
+// whose ARN is set in the status. The BackupCopyFailed condition is set when the latest copy jobs all failed.
+// When the copy is not requested the plan is deleted, and the already created backups are kept.
+func reconcileBackupPlan(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if state.efs == nil {
+		return nil, nil
+	}
+
+	copyRegion := getBackupCopyRegion(nfsInstance)
+	if len(copyRegion) == 0 && nfsInstance.Status.Backup == nil && !hasBackupPlanError(nfsInstance) {
+		// the plan is created only with the copy, and the status is set once it's created
+		return nil, nil
+	}
+
+	planName := getBackupPlanName(nfsInstance)
+	planId, err := findBackupPlanId(ctx, state.awsClient, planName)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing AWS backup plans", ctx)
+	}
+
+	backupOptions := nfsInstance.Spec.Backup
+	if len(copyRegion) == 0 {
+		if len(planId) > 0 {
+			logger.Info("Deleting AWS backup plan of disabled backup copy")
+			if err := deleteBackupPlanWithSelections(ctx, state.awsClient, planId); err != nil {
+				return awsmeta.LogErrorAndReturn(err, "Error deleting AWS backup plan", ctx)
+			}
+		}
+		if nfsInstance.Status.Backup == nil && !hasBackupPlanError(nfsInstance) {
+			return nil, nil
+		}
+		nfsInstance.Status.Backup = nil
+		b := composed.UpdateStatus(nfsInstance)
+		if hasBackupPlanError(nfsInstance) {
+			b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeError)
+		}
+		return b.
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeBackupCopyFailed).
+			ErrorLogMessage("Error updating KCP NfsInstance status with disabled backup copy").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	scheduleExpression, err := getBackupScheduleExpression(backupOptions.Schedule)
+	if err != nil {
+		return backupPlanError(ctx, state, cloudcontrolv1beta1.ReasonInvalidSchedule, err.Error(), composed.StopAndForget)
+	}
+
+	vaultTags := map[string]string{
+		common.TagScope: state.Scope().Name,
+	}
+	vaultName := getBackupVaultName(state)
+	if _, err := loadOrCreateBackupVault(ctx, state.awsClient, vaultName, vaultTags); err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS backup vault", ctx)
+	}
+
+	lifecycle := &backupTypes.Lifecycle{DeleteAfterDays: ptr.To(int64(backupOptions.RetentionDays))}
+	rule := backupTypes.BackupRuleInput{
+		RuleName:              ptr.To(backupRuleName),
+		TargetBackupVaultName: ptr.To(vaultName),
+		ScheduleExpression:    ptr.To(scheduleExpression),
+		Lifecycle:             lifecycle,
+	}
+
+	if copyRegion == state.Scope().Spec.Region || !awsRegionRegex.MatchString(copyRegion) {
+		return backupPlanError(ctx, state, cloudcontrolv1beta1.ReasonInvalidBackupCopyRegion,
+			fmt.Sprintf("Backup copy region %s must be a valid region other than the region of the file system", copyRegion),
+			composed.StopAndForget)
+	}
+	if state.backupCopyClient == nil {
+		return composed.StopWithRequeue, nil
+	}
+	copyDestinationVaultArn, err := loadOrCreateBackupVault(ctx, state.backupCopyClient, vaultName, vaultTags)
+	if err != nil {
+		logger.Error(err, "Error loading AWS backup copy destination vault")
+		return backupPlanError(ctx, state, cloudcontrolv1beta1.ReasonInvalidBackupCopyRegion,
+			fmt.Sprintf("Backup vault in the copy region %s is not available: %s", copyRegion, awsmeta.GetErrorMessage(err)),
+			awsmeta.ErrorToRequeueResponse(err))
+	}
+	rule.CopyActions = []backupTypes.CopyAction{{
+		DestinationBackupVaultArn: ptr.To(copyDestinationVaultArn),
+		Lifecycle:                 lifecycle,
+	}}
+
+	desiredPlan := &backupTypes.BackupPlanInput{
+		BackupPlanName: ptr.To(planName),
+		Rules:          []backupTypes.BackupRuleInput{rule},
+	}
+
+	if len(planId) == 0 {
+		logger.
+			WithValues("backupPlan", planName, "copyDestinationVaultArn", copyDestinationVaultArn).
+			Info("Creating AWS backup plan")
+		planId, err = state.awsClient.CreateBackupPlan(ctx, desiredPlan, state.tags)
+		if err != nil {
+			logger.Error(err, "Error creating AWS backup plan")
+			return backupPlanError(ctx, state, cloudcontrolv1beta1.ReasonBackupPlanFailed,
+				fmt.Sprintf("Failed creating backup plan: %s", awsmeta.GetErrorMessage(err)),
+				awsmeta.ErrorToRequeueResponse(err))
+		}
+	} else {
+		currentPlan, err := state.awsClient.GetBackupPlan(ctx, planId)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error loading AWS backup plan", ctx)
+		}
+		if !backupPlanMatches(currentPlan, desiredPlan) {
+			logger.
+				WithValues("backupPlan", planName, "copyDestinationVaultArn", copyDestinationVaultArn).
+				Info("Updating AWS backup plan")
+			if err := state.awsClient.UpdateBackupPlan(ctx, planId, desiredPlan); err != nil {
+				logger.Error(err, "Error updating AWS backup plan")
+				return backupPlanError(ctx, state, cloudcontrolv1beta1.ReasonBackupPlanFailed,
+					fmt.Sprintf("Failed updating backup plan: %s", awsmeta.GetErrorMessage(err)),
+					awsmeta.ErrorToRequeueResponse(err))
+			}
+		}
+	}
+
+	selections, err := state.awsClient.ListBackupSelections(ctx, planId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing AWS backup selections", ctx)
+	}
+	if len(selections) == 0 {
+		_, err = state.awsClient.CreateBackupSelection(ctx, planId, &backupTypes.BackupSelection{
+			SelectionName: ptr.To(planName),
+			IamRoleArn:    ptr.To(fmt.Sprintf("arn:aws:iam::%s:role/%s", state.Scope().Spec.Scope.Aws.AccountId, awsconfig.AwsConfig.BackupRoleName)),
+			Resources:     []string{ptr.Deref(state.efs.FileSystemArn, "")},
+		})
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error creating AWS backup selection", ctx)
+		}
+	}
+
+	copyFailedCondition, err := getBackupCopyFailedCondition(ctx, state, copyDestinationVaultArn)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing AWS backup copy jobs", ctx)
+	}
+	currentCopyFailed := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeBackupCopyFailed)
+
+	statusBackup := nfsInstance.Status.Backup
+	if statusBackup == nil {
+		statusBackup = &cloudcontrolv1beta1.BackupStatus{}
+	}
+	statusChanged := nfsInstance.Status.Backup == nil || statusBackup.CopyDestinationVaultArn != copyDestinationVaultArn
+	copyFailedChanged := currentCopyFailed != nil
+	if copyFailedCondition != nil {
+		copyFailedChanged = composed.AnyConditionChanged(nfsInstance, *copyFailedCondition)
+	}
+
+	if !statusChanged && !copyFailedChanged && !hasBackupPlanError(nfsInstance) {
+		return nil, nil
+	}
+
+	statusBackup.CopyDestinationVaultArn = copyDestinationVaultArn
+	nfsInstance.Status.Backup = statusBackup
+	b := composed.UpdateStatus(nfsInstance)
+	if hasBackupPlanError(nfsInstance) {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeError)
+	}
+	if copyFailedCondition != nil {
+		b = b.SetCondition(*copyFailedCondition)
+	} else {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeBackupCopyFailed)
+	}
+	return b.
+		ErrorLogMessage("Error updating KCP NfsInstance status with backup plan").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// getBackupCopyFailedCondition returns the BackupCopyFailed condition if the latest copy jobs of
+// the file system to the destination vault all failed, or nil otherwise
+func getBackupCopyFailedCondition(ctx context.Context, state *State, destinationVaultArn string) (*metav1.Condition, error) {
+	if len(destinationVaultArn) == 0 {
+		return nil, nil
+	}
+	jobs, err := state.awsClient.ListCopyJobs(ctx, ptr.Deref(state.efs.FileSystemArn, ""), time.Now().Add(-backupCopyJobsWindow))
+	if err != nil {
+		return nil, err
+	}
+	var copyJobs []backupTypes.CopyJob
+	for _, job := range jobs {
+		if ptr.Deref(job.DestinationBackupVaultArn, "") == destinationVaultArn {
+			copyJobs = append(copyJobs, job)
+		}
+	}
+	sort.Slice(copyJobs, func(i, j int) bool {
+		return ptr.Deref(copyJobs[i].CreationDate, time.Time{}).After(ptr.Deref(copyJobs[j].CreationDate, time.Time{}))
+	})
+	if len(copyJobs) < backupCopyFailedThreshold {
+		return nil, nil
+	}
+	for _, job := range copyJobs[:backupCopyFailedThreshold] {
+		if job.State != backupTypes.CopyJobStateFailed {
+			return nil, nil
+		}
+	}
+	return &metav1.Condition{
+		Type:   cloudcontrolv1beta1.ConditionTypeBackupCopyFailed,
+		Status: metav1.ConditionTrue,
+		Reason: cloudcontrolv1beta1.ReasonBackupCopyFailed,
+		Message: fmt.Sprintf("The latest %d backup copies to %s failed: %s",
+			backupCopyFailedThreshold, destinationVaultArn, ptr.Deref(copyJobs[0].StatusMessage, "")),
+	}, nil
+}
+
+func deleteBackupPlanWithSelections(ctx context.Context, c nfsinstanceclient.Client, planId string) error {
+	selections, err := c.ListBackupSelections(ctx, planId)
+	if err != nil {
+		return err
+	}
+	for _, s := range selections {
+		if err := c.DeleteBackupSelection(ctx, planId, ptr.Deref(s.SelectionId, "")); err != nil && !awsmeta.IsNotFound(err) {
+			return err
+		}
+	}
+	if err := c.DeleteBackupPlan(ctx, planId); err != nil && !awsmeta.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteBackupPlan deletes the AWS Backup plan of the deleted NfsInstance, the backups are kept in the vault
+func deleteBackupPlan(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	planId, err := findBackupPlanId(ctx, state.awsClient, getBackupPlanName(state.ObjAsNfsInstance()))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing AWS backup plans", ctx)
+	}
+	if len(planId) == 0 {
+		return nil, nil
+	}
+
+	logger.Info("Deleting AWS backup plan")
+	if err := deleteBackupPlanWithSelections(ctx, state.awsClient, planId); err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting AWS backup plan", ctx)
+	}
+	return nil, nil
+}
@@ -0,0 +1,239 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	backupTypes "github.com/aws/aws-sdk-go-v2/service/backup/types"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGetBackupScheduleExpression(t *testing.T) {
+	for schedule, expected := range map[string]string{
+		"0 2 * * *":   "cron(0 2 * * ? *)",
+		"30 4 1 * *":  "cron(30 4 1 * ? *)",
+		"0 3 * * 0,6": "cron(0 3 ? * 1,7 *)",
+		"0 3 * * 1-5": "cron(0 3 ? * 2-6 *)",
+		"6h":          "cron(0 0/6 * * ? *)",
+		"48h":         "cron(0 0 1/2 * ? *)",
+	} {
+		actual, err := getBackupScheduleExpression(schedule)
+		assert.NoError(t, err, schedule)
+		assert.Equal(t, expected, actual, schedule)
+	}
+
+	for _, schedule := range []string{"30m", "5h", "36h", "0 2 1 * 1", "every day"} {
+		_, err := getBackupScheduleExpression(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
+
+type reconcileBackupPlanSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+type listBackupPlansCountingClient struct {
+	nfsinstanceclient.Client
+	listCount int
+}
+
+func (c *listBackupPlansCountingClient) ListBackupPlans(ctx context.Context) ([]backupTypes.BackupPlansListMember, error) {
+	c.listCount++
+	return c.Client.ListBackupPlans(ctx)
+}
+
+func (suite *reconcileBackupPlanSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileBackupPlanSuite) newState(backup *cloudcontrolv1beta1.BackupOptions) (*State, awsmock.Server, awsmock.Server) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{},
+			},
+			Backup: backup,
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Name: "scope"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Region: "eu-west-1",
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{AccountId: "123456789012"},
+			},
+		},
+	})
+
+	awsMock := awsmock.New()
+	awsMock.SetBackupRegion("eu-west-1")
+	copyMock := awsmock.New()
+	copyMock.SetBackupRegion("eu-central-1")
+
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, nil)
+	state.backupCopyClient = copyMock
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId, FileSystemArn: out.FileSystemArn}
+
+	return state, awsMock, copyMock
+}
+
+func (suite *reconcileBackupPlanSuite) TestCopyActionAdded() {
+	state, awsMock, copyMock := suite.newState(&cloudcontrolv1beta1.BackupOptions{
+		Enabled:       true,
+		Schedule:      "0 2 * * *",
+		RetentionDays: 7,
+	})
+	nfsInstance := state.ObjAsNfsInstance()
+
+	err, _ := reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Nil(suite.T(), awsMock.GetBackupPlanByName("cm-nfs"), "no plan unless copyToRegion is set")
+	assert.Nil(suite.T(), nfsInstance.Status.Backup)
+
+	nfsInstance.Spec.Backup.CopyToRegion = "eu-central-1"
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Update(suite.ctx, nfsInstance))
+
+	err, _ = reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	destinationVaultArn, err := copyMock.DescribeBackupVault(suite.ctx, "cm-scope")
+	assert.NoError(suite.T(), err, "destination vault created in the copy region")
+	assert.Equal(suite.T(), "arn:aws:backup:eu-central-1::backup-vault:cm-scope", destinationVaultArn)
+
+	plan := awsMock.GetBackupPlanByName("cm-nfs")
+	assert.NotNil(suite.T(), plan)
+	assert.Len(suite.T(), plan.Rules, 1)
+	assert.Equal(suite.T(), "cron(0 2 * * ? *)", ptr.Deref(plan.Rules[0].ScheduleExpression, ""))
+	assert.Equal(suite.T(), "cm-scope", ptr.Deref(plan.Rules[0].TargetBackupVaultName, ""))
+	assert.Len(suite.T(), plan.Rules[0].CopyActions, 1)
+	assert.Equal(suite.T(), destinationVaultArn, ptr.Deref(plan.Rules[0].CopyActions[0].DestinationBackupVaultArn, ""))
+	assert.Equal(suite.T(), int64(7), ptr.Deref(plan.Rules[0].CopyActions[0].Lifecycle.DeleteAfterDays, 0))
+	assert.Equal(suite.T(), destinationVaultArn, nfsInstance.Status.Backup.CopyDestinationVaultArn)
+
+	planId, err := findBackupPlanId(suite.ctx, awsMock, "cm-nfs")
+	assert.NoError(suite.T(), err)
+	selections := awsMock.GetBackupSelections(planId)
+	assert.Len(suite.T(), selections, 1)
+	assert.Equal(suite.T(), []string{ptr.Deref(state.efs.FileSystemArn, "")}, selections[0].Resources)
+
+	// removing the copy deletes the plan
+	nfsInstance.Spec.Backup.CopyToRegion = ""
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Update(suite.ctx, nfsInstance))
+
+	err, _ = reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), awsMock.GetBackupPlanByName("cm-nfs"))
+	assert.Nil(suite.T(), nfsInstance.Status.Backup)
+}
+
+func (suite *reconcileBackupPlanSuite) TestPlansNotListedWithoutCopy() {
+	state, awsMock, _ := suite.newState(nil)
+	countingClient := &listBackupPlansCountingClient{Client: awsMock}
+	state.awsClient = countingClient
+
+	err, _ := reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, countingClient.listCount)
+}
+
+func (suite *reconcileBackupPlanSuite) TestInvalidCopyRegion() {
+	state, awsMock, _ := suite.newState(&cloudcontrolv1beta1.BackupOptions{
+		Enabled:       true,
+		Schedule:      "0 2 * * *",
+		RetentionDays: 7,
+		CopyToRegion:  "eu-west-1",
+	})
+
+	err, _ := reconcileBackupPlan(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonInvalidBackupCopyRegion, cond.Reason)
+	assert.Nil(suite.T(), awsMock.GetBackupPlanByName("cm-nfs"))
+}
+
+func (suite *reconcileBackupPlanSuite) TestBackupCopyFailed() {
+	state, awsMock, _ := suite.newState(&cloudcontrolv1beta1.BackupOptions{
+		Enabled:       true,
+		Schedule:      "0 2 * * *",
+		RetentionDays: 7,
+		CopyToRegion:  "eu-central-1",
+	})
+	nfsInstance := state.ObjAsNfsInstance()
+
+	err, _ := reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	destinationVaultArn := nfsInstance.Status.Backup.CopyDestinationVaultArn
+
+	addCopyJob := func(age time.Duration, jobState backupTypes.CopyJobState) {
+		awsMock.AddCopyJob(backupTypes.CopyJob{
+			ResourceArn:               state.efs.FileSystemArn,
+			DestinationBackupVaultArn: ptr.To(destinationVaultArn),
+			CreationDate:              ptr.To(time.Now().Add(-age)),
+			State:                     jobState,
+			StatusMessage:             ptr.To("Access denied"),
+		})
+	}
+	addCopyJob(4*time.Hour, backupTypes.CopyJobStateCompleted)
+	addCopyJob(3*time.Hour, backupTypes.CopyJobStateFailed)
+	addCopyJob(2*time.Hour, backupTypes.CopyJobStateFailed)
+
+	err, _ = reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeBackupCopyFailed), "single failures are retried")
+
+	addCopyJob(time.Hour, backupTypes.CopyJobStateFailed)
+
+	err, _ = reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	cond := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeBackupCopyFailed)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonBackupCopyFailed, cond.Reason)
+
+	addCopyJob(time.Minute, backupTypes.CopyJobStateCompleted)
+
+	err, _ = reconcileBackupPlan(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeBackupCopyFailed))
+}
+
+func TestReconcileBackupPlan(t *testing.T) {
+	suite.Run(t, new(reconcileBackupPlanSuite))
+}
@@ -0,0 +1,93 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileBackupPolicy converges the EFS automatic backups toggle with the spec and reports
+// the backup policy state in the status. If automatic backups are not specified, the backup
+// policy is not changed and the region default of the account stays in effect.
+func reconcileBackupPolicy(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if state.efs == nil {
+		return nil, nil
+	}
+
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	current, err := state.awsClient.DescribeBackupPolicy(ctx, fsId)
+	if awsmeta.IsNotFound(err) {
+		current = efsTypes.StatusDisabled
+		err = nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS EFS backup policy", ctx)
+	}
+
+	if desired := getDesiredBackupPolicy(nfsInstance); len(desired) > 0 && current != desired {
+		logger.
+			WithValues(
+				"currentBackupPolicy", current,
+				"desiredBackupPolicy", desired,
+			).
+			Info("Updating AWS EFS backup policy")
+
+		err = state.awsClient.PutBackupPolicy(ctx, fsId, desired)
+		if err != nil {
+			logger.Error(err, "Error updating AWS EFS backup policy")
+			return composed.UpdateStatus(nfsInstance).
+				SetCondition(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonBackupPolicyFailed,
+					Message: fmt.Sprintf("Failed updating backup policy: %s", err),
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status after failed updating backup policy").
+				SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+				Run(ctx, state)
+		}
+		current = desired
+	}
+
+	errorCondition := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	hasBackupError := errorCondition != nil && errorCondition.Reason == cloudcontrolv1beta1.ReasonBackupPolicyFailed
+
+	if nfsInstance.Status.BackupPolicy == string(current) && !hasBackupError {
+		return nil, nil
+	}
+
+	nfsInstance.Status.BackupPolicy = string(current)
+	b := composed.UpdateStatus(nfsInstance)
+	if hasBackupError {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeError)
+	}
+
+	return b.
+		ErrorLogMessage("Error updating KCP NfsInstance status with backup policy").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// getDesiredBackupPolicy returns the backup policy status from the spec, or empty if automatic
+// backups are not managed
+func getDesiredBackupPolicy(nfsInstance *cloudcontrolv1beta1.NfsInstance) efsTypes.Status {
+	if nfsInstance.Spec.Instance.Aws == nil || nfsInstance.Spec.Instance.Aws.AutomaticBackups == nil {
+		return ""
+	}
+	if *nfsInstance.Spec.Instance.Aws.AutomaticBackups {
+		return efsTypes.StatusEnabled
+	}
+	return efsTypes.StatusDisabled
+}
@@ -0,0 +1,115 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type putBackupRecordingClient struct {
+	nfsinstanceclient.Client
+	puts []efsTypes.Status
+}
+
+func (c *putBackupRecordingClient) PutBackupPolicy(ctx context.Context, fsId string, status efsTypes.Status) error {
+	c.puts = append(c.puts, status)
+	return c.Client.PutBackupPolicy(ctx, fsId, status)
+}
+
+type reconcileBackupPolicySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileBackupPolicySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileBackupPolicySuite) newState(automaticBackups *bool) (*State, *putBackupRecordingClient, awsmock.Server) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{AutomaticBackups: automaticBackups},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, automaticBackups, "", nil)
+	assert.NoError(suite.T(), err)
+
+	recordingClient := &putBackupRecordingClient{Client: awsMock}
+	state := newState(&testNfsInstanceState{State: focalState}, recordingClient, nil)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	return state, recordingClient, awsMock
+}
+
+func (suite *reconcileBackupPolicySuite) TestTogglingPutsBackupPolicy() {
+	state, recordingClient, awsMock := suite.newState(ptr.To(true))
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+
+	err, _ := reconcileBackupPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), recordingClient.puts, "backup policy applied on creation is not put again")
+	assert.Equal(suite.T(), string(efsTypes.StatusEnabled), state.ObjAsNfsInstance().Status.BackupPolicy)
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.AutomaticBackups = ptr.To(false)
+
+	err, _ = reconcileBackupPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []efsTypes.Status{efsTypes.StatusDisabled}, recordingClient.puts)
+	assert.Equal(suite.T(), efsTypes.StatusDisabled, awsMock.GetBackupPolicy(fsId))
+	assert.Equal(suite.T(), string(efsTypes.StatusDisabled), state.ObjAsNfsInstance().Status.BackupPolicy)
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.AutomaticBackups = ptr.To(true)
+
+	err, _ = reconcileBackupPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []efsTypes.Status{efsTypes.StatusDisabled, efsTypes.StatusEnabled}, recordingClient.puts)
+	assert.Equal(suite.T(), efsTypes.StatusEnabled, awsMock.GetBackupPolicy(fsId))
+	assert.Equal(suite.T(), string(efsTypes.StatusEnabled), state.ObjAsNfsInstance().Status.BackupPolicy)
+}
+
+func (suite *reconcileBackupPolicySuite) TestNotSpecifiedIsNotManaged() {
+	state, recordingClient, _ := suite.newState(nil)
+
+	err, _ := reconcileBackupPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), recordingClient.puts)
+	assert.Equal(suite.T(), string(efsTypes.StatusDisabled), state.ObjAsNfsInstance().Status.BackupPolicy)
+}
+
+func TestReconcileBackupPolicy(t *testing.T) {
+	suite.Run(t, new(reconcileBackupPolicySuite))
+}
@@ -0,0 +1,40 @@
+package nfsinstance
+
+import (
+	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	awsdns "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/dns"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileDnsRecord keeps the spec.dns record in the spec.dns.hostedZoneId Route53 hosted zone pointing
+// at the DNS name of the available EFS, resolved to the mount target of the zone. The record is optional,
+// so its failure does not keep the NfsInstance from getting Ready.
+var reconcileDnsRecord = composed.Optional(
+	cloudcontrolv1beta1.ConditionTypeDnsRecordFailed,
+	cloudcontrolv1beta1.ReasonDnsRecordFailed,
+	composed.ComposeActions(
+		"dnsRecord",
+		awsdns.ValidateHostedZone(dnsClient),
+		kcpdns.ReconcileRecord(dnsClient, efsEndpoint),
+	),
+)
+
+// deleteDnsRecord deletes the spec.dns record on the NfsInstance teardown
+var deleteDnsRecord = kcpdns.DeleteRecord(dnsClient)
+
+func dnsClient(st composed.State) kcpdns.Client {
+	return st.(*State).dnsClient
+}
+
+func efsEndpoint(st composed.State) string {
+	state := st.(*State)
+	if state.efs == nil || state.efs.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+		return ""
+	}
+	return fmt.Sprintf("%s.efs.%s.amazonaws.com", ptr.Deref(state.efs.FileSystemId, ""), state.Scope().Spec.Region)
+}
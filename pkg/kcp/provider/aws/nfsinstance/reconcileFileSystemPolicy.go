@@ -0,0 +1,248 @@
+package nfsinstance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	fileSystemPolicyAllowSid   = "CloudManagerAllowMountTargetAccess"
+	fileSystemPolicyEnforceSid = "CloudManagerEnforceInTransitEncryption"
+)
+
+type fileSystemPolicyDocument struct {
+	Version   string                      `json:"Version"`
+	Statement []fileSystemPolicyStatement `json:"Statement"`
+}
+
+type fileSystemPolicyStatement struct {
+	Sid       string                       `json:"Sid"`
+	Effect    string                       `json:"Effect"`
+	Principal map[string]string            `json:"Principal"`
+	Action    []string                     `json:"Action"`
+	Condition map[string]map[string]string `json:"Condition"`
+}
+
+// fileSystemPolicyClientActions are the NFS client actions the policy applies to. The EFS management
+// actions are never denied, so the cloud-manager can not lock itself out of the file system.
+var fileSystemPolicyClientActions = []string{
+	"elasticfilesystem:ClientMount",
+	"elasticfilesystem:ClientWrite",
+	"elasticfilesystem:ClientRootAccess",
+}
+
+// getInTransitEncryptionPolicy returns the file system policy that keeps the NFS client access through the
+// mount targets allowed, as it is without the file system policy, but denies it if TLS is not used
+func getInTransitEncryptionPolicy() fileSystemPolicyDocument {
+	return fileSystemPolicyDocument{
+		Version: "2012-10-17",
+		Statement: []fileSystemPolicyStatement{
+			{
+				Sid:       fileSystemPolicyAllowSid,
+				Effect:    "Allow",
+				Principal: map[string]string{"AWS": "*"},
+				Action:    fileSystemPolicyClientActions,
+				Condition: map[string]map[string]string{
+					"Bool": {"elasticfilesystem:AccessedViaMountTarget": "true"},
+				},
+			},
+			{
+				Sid:       fileSystemPolicyEnforceSid,
+				Effect:    "Deny",
+				Principal: map[string]string{"AWS": "*"},
+				Action:    fileSystemPolicyClientActions,
+				Condition: map[string]map[string]string{
+					"Bool": {"aws:SecureTransport": "false"},
+				},
+			},
+		},
+	}
+}
+
+// reconcileFileSystemPolicy converges the EFS file system policy enforcing in-transit encryption with the spec.
+// If the enforcement is disabled, the file system policy is removed only if it was applied by the cloud-manager,
+// so a policy created otherwise is left as it is. The policy is not loaded if the enforcement is neither
+// requested nor was applied before.
+func reconcileFileSystemPolicy(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if state.efs == nil {
+		return nil, nil
+	}
+
+	enforce := nfsInstance.Spec.Instance.Aws != nil && nfsInstance.Spec.Instance.Aws.EnforceInTransitEncryption
+	errorCondition := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	hasPolicyError := errorCondition != nil && errorCondition.Reason == cloudcontrolv1beta1.ReasonFileSystemPolicyFailed
+	enforced := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeInTransitEncryptionEnforced) != nil
+
+	if !enforce && !enforced && !hasPolicyError {
+		return nil, nil
+	}
+
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	current, err := state.awsClient.DescribeFileSystemPolicy(ctx, fsId)
+	if awsmeta.IsNotFound(err) {
+		current = ""
+		err = nil
+	}
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS EFS file system policy", ctx)
+	}
+
+	desired := getInTransitEncryptionPolicy()
+
+	if enforce && !isFileSystemPolicyEqual(current, desired) {
+		logger.Info("Updating AWS EFS file system policy enforcing in-transit encryption")
+		doc, err := json.Marshal(desired)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, "Error marshaling AWS EFS file system policy", composed.StopAndForget, ctx)
+		}
+		err = state.awsClient.PutFileSystemPolicy(ctx, fsId, string(doc))
+		if err != nil {
+			return fileSystemPolicyFailed(ctx, state, fmt.Sprintf("Failed updating file system policy: %s", err))
+		}
+	}
+
+	if !enforce && isFileSystemPolicyManaged(current) {
+		logger.Info("Deleting AWS EFS file system policy enforcing in-transit encryption")
+		err = state.awsClient.DeleteFileSystemPolicy(ctx, fsId)
+		if err != nil && !awsmeta.IsNotFound(err) {
+			return fileSystemPolicyFailed(ctx, state, fmt.Sprintf("Failed deleting file system policy: %s", err))
+		}
+	}
+
+	b := composed.UpdateStatus(nfsInstance)
+	changed := hasPolicyError
+	if hasPolicyError {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeError)
+	}
+	if enforce {
+		condition := metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeInTransitEncryptionEnforced,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonInTransitEncryptionEnforced,
+			Message: "File system policy denies the NFS clients not using TLS",
+		}
+		if composed.AnyConditionChanged(nfsInstance, condition) {
+			b = b.SetCondition(condition)
+			changed = true
+		}
+	} else if enforced {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeInTransitEncryptionEnforced)
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return b.
+		ErrorLogMessage("Error updating KCP NfsInstance status with in-transit encryption").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+func fileSystemPolicyFailed(ctx context.Context, state *State, message string) (error, context.Context) {
+	composed.LoggerFromCtx(ctx).Info(message)
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonFileSystemPolicyFailed,
+			Message: message,
+		}).
+		ErrorLogMessage("Error updating KCP NfsInstance status after failed file system policy reconciliation").
+		SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+		Run(ctx, state)
+}
+
+// isFileSystemPolicyEqual returns true if the policy document is semantically equal to the desired one,
+// ignoring the formatting and the equivalent forms AWS might return it in
+func isFileSystemPolicyEqual(policy string, desired fileSystemPolicyDocument) bool {
+	if len(policy) == 0 {
+		return false
+	}
+	var actual interface{}
+	if err := json.Unmarshal([]byte(policy), &actual); err != nil {
+		return false
+	}
+	desiredJson, err := json.Marshal(desired)
+	if err != nil {
+		return false
+	}
+	var expected interface{}
+	if err := json.Unmarshal(desiredJson, &expected); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizePolicyValue("", actual), normalizePolicyValue("", expected))
+}
+
+// normalizePolicyValue returns the IAM policy value in its canonical form, so the equivalent policies
+// compare equal. The single element lists are unwrapped, the lists are sorted, the booleans and numbers
+// are turned into strings, and the {"AWS": "*"} principal is turned into "*".
+func normalizePolicyValue(key string, v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(x))
+		for k, item := range x {
+			result[k] = normalizePolicyValue(k, item)
+		}
+		if key == "Principal" && len(result) == 1 && result["AWS"] == "*" {
+			return "*"
+		}
+		return result
+	case []interface{}:
+		items := make([]interface{}, 0, len(x))
+		for _, item := range x {
+			items = append(items, normalizePolicyValue(key, item))
+		}
+		if len(items) == 1 {
+			return items[0]
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return fmt.Sprintf("%v", items[i]) < fmt.Sprintf("%v", items[j])
+		})
+		return items
+	case bool, float64:
+		return fmt.Sprintf("%v", x)
+	default:
+		return v
+	}
+}
+
+// isFileSystemPolicyManaged returns true if the policy document has the statement applied by the cloud-manager
+func isFileSystemPolicyManaged(policy string) bool {
+	if len(policy) == 0 {
+		return false
+	}
+	var actual interface{}
+	if err := json.Unmarshal([]byte(policy), &actual); err != nil {
+		return false
+	}
+	doc, ok := normalizePolicyValue("", actual).(map[string]interface{})
+	if !ok {
+		return false
+	}
+	statements, ok := doc["Statement"].([]interface{})
+	if !ok {
+		statements = []interface{}{doc["Statement"]}
+	}
+	return pie.Any(statements, func(x interface{}) bool {
+		statement, ok := x.(map[string]interface{})
+		return ok && statement["Sid"] == fileSystemPolicyEnforceSid
+	})
+}
@@ -0,0 +1,195 @@
+package nfsinstance
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type describePolicyCountingClient struct {
+	nfsinstanceclient.Client
+	describeCount int
+}
+
+func (c *describePolicyCountingClient) DescribeFileSystemPolicy(ctx context.Context, fsId string) (string, error) {
+	c.describeCount++
+	return c.Client.DescribeFileSystemPolicy(ctx, fsId)
+}
+
+type reconcileFileSystemPolicySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileFileSystemPolicySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileFileSystemPolicySuite) newState(enforce bool) (*State, awsmock.Server, string) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{EnforceInTransitEncryption: enforce},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, nil)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	return state, awsMock, ptr.Deref(out.FileSystemId, "")
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestPolicyDocumentDeniesNonTlsClients() {
+	state, awsMock, fsId := suite.newState(true)
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	doc := map[string]interface{}{}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(awsMock.GetFileSystemPolicy(fsId)), &doc))
+	expected := map[string]interface{}{}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "CloudManagerAllowMountTargetAccess",
+				"Effect": "Allow",
+				"Principal": {"AWS": "*"},
+				"Action": ["elasticfilesystem:ClientMount", "elasticfilesystem:ClientWrite", "elasticfilesystem:ClientRootAccess"],
+				"Condition": {"Bool": {"elasticfilesystem:AccessedViaMountTarget": "true"}}
+			},
+			{
+				"Sid": "CloudManagerEnforceInTransitEncryption",
+				"Effect": "Deny",
+				"Principal": {"AWS": "*"},
+				"Action": ["elasticfilesystem:ClientMount", "elasticfilesystem:ClientWrite", "elasticfilesystem:ClientRootAccess"],
+				"Condition": {"Bool": {"aws:SecureTransport": "false"}}
+			}
+		]
+	}`), &expected))
+	assert.Equal(suite.T(), expected, doc)
+
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeInTransitEncryptionEnforced)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionTrue, cond.Status)
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestDriftIsReconciled() {
+	state, awsMock, fsId := suite.newState(true)
+	assert.NoError(suite.T(), awsMock.PutFileSystemPolicy(suite.ctx, fsId, `{"Version":"2012-10-17","Statement":[]}`))
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.True(suite.T(), isFileSystemPolicyEqual(awsMock.GetFileSystemPolicy(fsId), getInTransitEncryptionPolicy()))
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestDisabledRemovesManagedPolicy() {
+	state, awsMock, fsId := suite.newState(true)
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.EnforceInTransitEncryption = false
+
+	err, _ = reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	_, err = awsMock.DescribeFileSystemPolicy(suite.ctx, fsId)
+	assert.Error(suite.T(), err, "managed policy must be deleted")
+	assert.Nil(suite.T(), meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeInTransitEncryptionEnforced))
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestDisabledKeepsOtherPolicy() {
+	state, awsMock, fsId := suite.newState(false)
+	other := `{"Version":"2012-10-17","Statement":[{"Sid":"Other","Effect":"Allow","Principal":{"AWS":"*"},"Action":["elasticfilesystem:ClientMount"]}]}`
+	assert.NoError(suite.T(), awsMock.PutFileSystemPolicy(suite.ctx, fsId, other))
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Equal(suite.T(), other, awsMock.GetFileSystemPolicy(fsId))
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestEquivalentPolicyIsNotUpdated() {
+	state, awsMock, fsId := suite.newState(true)
+	// the same policy in the form AWS might return it, with reordered statements and actions,
+	// the wildcard principal and the boolean condition values
+	equivalent := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Sid": "CloudManagerEnforceInTransitEncryption",
+				"Effect": "Deny",
+				"Principal": "*",
+				"Action": ["elasticfilesystem:ClientWrite", "elasticfilesystem:ClientMount", "elasticfilesystem:ClientRootAccess"],
+				"Condition": {"Bool": {"aws:SecureTransport": false}}
+			},
+			{
+				"Sid": "CloudManagerAllowMountTargetAccess",
+				"Effect": "Allow",
+				"Principal": {"AWS": ["*"]},
+				"Action": ["elasticfilesystem:ClientRootAccess", "elasticfilesystem:ClientMount", "elasticfilesystem:ClientWrite"],
+				"Condition": {"Bool": {"elasticfilesystem:AccessedViaMountTarget": "true"}}
+			}
+		]
+	}`
+	assert.NoError(suite.T(), awsMock.PutFileSystemPolicy(suite.ctx, fsId, equivalent))
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	assert.Equal(suite.T(), equivalent, awsMock.GetFileSystemPolicy(fsId))
+	assert.True(suite.T(), isFileSystemPolicyManaged(equivalent))
+}
+
+func (suite *reconcileFileSystemPolicySuite) TestPolicyNotLoadedIfNotRequested() {
+	state, awsMock, _ := suite.newState(false)
+	countingClient := &describePolicyCountingClient{Client: awsMock}
+	state.awsClient = countingClient
+
+	err, _ := reconcileFileSystemPolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, countingClient.describeCount)
+}
+
+func TestReconcileFileSystemPolicy(t *testing.T) {
+	suite.Run(t, new(reconcileFileSystemPolicySuite))
+}
@@ -0,0 +1,143 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileLifecyclePolicy converges the EFS transition to Infrequent Access with the spec.
+// If the lifecycle policy is not specified, EFS lifecycle configuration is not managed, unless
+// it was specified before, in which case the transition is removed.
+func reconcileLifecyclePolicy(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if state.efs == nil {
+		return nil, nil
+	}
+
+	desired := getDesiredTransitionToIA(nfsInstance)
+	if len(desired) == 0 {
+		return nil, nil
+	}
+
+	if !isTransitionToIAValid(desired) {
+		return composed.UpdateStatus(nfsInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonLifecyclePolicyInvalid,
+				Message: fmt.Sprintf("Invalid lifecycle policy transitionToIA value %s", desired),
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with invalid lifecycle policy").
+			SuccessLogMsg("Forgetting KCP NfsInstance with invalid lifecycle policy").
+			Run(ctx, state)
+	}
+
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	policies, err := state.awsClient.DescribeLifecycleConfiguration(ctx, fsId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS EFS lifecycle configuration", ctx)
+	}
+
+	if getTransitionToIA(policies) != desired {
+		logger.
+			WithValues(
+				"currentTransitionToIA", getTransitionToIA(policies),
+				"desiredTransitionToIA", desired,
+			).
+			Info("Updating AWS EFS lifecycle configuration")
+
+		err = state.awsClient.PutLifecycleConfiguration(ctx, fsId, setTransitionToIA(policies, desired))
+		if err != nil {
+			logger.Error(err, "Error updating AWS EFS lifecycle configuration")
+			return composed.UpdateStatus(nfsInstance).
+				SetCondition(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonLifecyclePolicyFailed,
+					Message: fmt.Sprintf("Failed updating lifecycle policy: %s", err),
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status after failed updating lifecycle policy").
+				SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+				Run(ctx, state)
+		}
+	}
+
+	var active *cloudcontrolv1beta1.AwsLifecyclePolicy
+	if desired != cloudcontrolv1beta1.AwsTransitionToIANone {
+		active = &cloudcontrolv1beta1.AwsLifecyclePolicy{TransitionToIA: desired}
+	}
+	errorCondition := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	hasLifecycleError := errorCondition != nil &&
+		(errorCondition.Reason == cloudcontrolv1beta1.ReasonLifecyclePolicyFailed || errorCondition.Reason == cloudcontrolv1beta1.ReasonLifecyclePolicyInvalid)
+
+	if ptr.Equal(nfsInstance.Status.LifecyclePolicy, active) && !hasLifecycleError {
+		return nil, nil
+	}
+
+	nfsInstance.Status.LifecyclePolicy = active
+	b := composed.UpdateStatus(nfsInstance)
+	if hasLifecycleError {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeError)
+	}
+
+	return b.
+		ErrorLogMessage("Error updating KCP NfsInstance status with active lifecycle policy").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// getDesiredTransitionToIA returns the transition to IA from the spec, NONE if the spec lifecycle
+// policy was removed after being applied, or empty if lifecycle configuration is not managed
+func getDesiredTransitionToIA(nfsInstance *cloudcontrolv1beta1.NfsInstance) cloudcontrolv1beta1.AwsTransitionToIA {
+	if nfsInstance.Spec.Instance.Aws != nil && nfsInstance.Spec.Instance.Aws.LifecyclePolicy != nil {
+		return nfsInstance.Spec.Instance.Aws.LifecyclePolicy.TransitionToIA
+	}
+	if nfsInstance.Status.LifecyclePolicy != nil {
+		return cloudcontrolv1beta1.AwsTransitionToIANone
+	}
+	return ""
+}
+
+func isTransitionToIAValid(v cloudcontrolv1beta1.AwsTransitionToIA) bool {
+	if v == cloudcontrolv1beta1.AwsTransitionToIANone {
+		return true
+	}
+	return pie.Contains(efsTypes.TransitionToIARules("").Values(), efsTypes.TransitionToIARules(v))
+}
+
+// getTransitionToIA returns the transition to IA found in the EFS lifecycle policies, or NONE if there's none
+func getTransitionToIA(policies []efsTypes.LifecyclePolicy) cloudcontrolv1beta1.AwsTransitionToIA {
+	for _, p := range policies {
+		if len(p.TransitionToIA) > 0 {
+			return cloudcontrolv1beta1.AwsTransitionToIA(p.TransitionToIA)
+		}
+	}
+	return cloudcontrolv1beta1.AwsTransitionToIANone
+}
+
+// setTransitionToIA returns the EFS lifecycle policies with the transition to IA replaced by the given one,
+// keeping the other transitions as they are. Each EFS lifecycle policy holds only one transition.
+func setTransitionToIA(policies []efsTypes.LifecyclePolicy, v cloudcontrolv1beta1.AwsTransitionToIA) []efsTypes.LifecyclePolicy {
+	result := pie.Filter(policies, func(p efsTypes.LifecyclePolicy) bool {
+		return len(p.TransitionToIA) == 0
+	})
+	if v != cloudcontrolv1beta1.AwsTransitionToIANone {
+		result = append(result, efsTypes.LifecyclePolicy{
+			TransitionToIA: efsTypes.TransitionToIARules(v),
+		})
+	}
+	return result
+}
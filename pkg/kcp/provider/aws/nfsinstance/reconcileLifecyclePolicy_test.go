@@ -0,0 +1,166 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type putLifecycleCountingClient struct {
+	nfsinstanceclient.Client
+	putCount int
+}
+
+func (c *putLifecycleCountingClient) PutLifecycleConfiguration(ctx context.Context, fsId string, policies []efsTypes.LifecyclePolicy) error {
+	c.putCount++
+	return c.Client.PutLifecycleConfiguration(ctx, fsId, policies)
+}
+
+type testNfsInstanceState struct {
+	focal.State
+	ipRange *cloudcontrolv1beta1.IpRange
+}
+
+func (s *testNfsInstanceState) ObjAsNfsInstance() *cloudcontrolv1beta1.NfsInstance {
+	return s.Obj().(*cloudcontrolv1beta1.NfsInstance)
+}
+
+func (s *testNfsInstanceState) IpRange() *cloudcontrolv1beta1.IpRange {
+	return s.ipRange
+}
+
+func (s *testNfsInstanceState) SetIpRange(ipRange *cloudcontrolv1beta1.IpRange) {
+	s.ipRange = ipRange
+}
+
+type reconcileLifecyclePolicySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileLifecyclePolicySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileLifecyclePolicySuite) newState(nfsInstance *cloudcontrolv1beta1.NfsInstance) (*State, *putLifecycleCountingClient, awsmock.Server) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(suite.T(), err)
+
+	countingClient := &putLifecycleCountingClient{Client: awsMock}
+	state := newState(&testNfsInstanceState{State: focalState}, countingClient, nil)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	return state, countingClient, awsMock
+}
+
+func newLifecycleNfsInstance(transitionToIA cloudcontrolv1beta1.AwsTransitionToIA) *cloudcontrolv1beta1.NfsInstance {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{},
+			},
+		},
+	}
+	if len(transitionToIA) > 0 {
+		nfsInstance.Spec.Instance.Aws.LifecyclePolicy = &cloudcontrolv1beta1.AwsLifecyclePolicy{TransitionToIA: transitionToIA}
+	}
+	return nfsInstance
+}
+
+func (suite *reconcileLifecyclePolicySuite) TestChangeFrom30To90DaysPutsLifecycleConfiguration() {
+	state, countingClient, awsMock := suite.newState(newLifecycleNfsInstance("AFTER_30_DAYS"))
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+
+	err, _ := reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, countingClient.putCount)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.AwsTransitionToIA("AFTER_30_DAYS"), state.ObjAsNfsInstance().Status.LifecyclePolicy.TransitionToIA)
+
+	err, _ = reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, countingClient.putCount, "unchanged policy is not put again")
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.LifecyclePolicy.TransitionToIA = "AFTER_90_DAYS"
+
+	err, _ = reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, countingClient.putCount)
+	assert.Equal(suite.T(), []efsTypes.LifecyclePolicy{{TransitionToIA: efsTypes.TransitionToIARulesAfter90Days}}, awsMock.GetLifecyclePolicies(fsId))
+	assert.Equal(suite.T(), cloudcontrolv1beta1.AwsTransitionToIA("AFTER_90_DAYS"), state.ObjAsNfsInstance().Status.LifecyclePolicy.TransitionToIA)
+}
+
+func (suite *reconcileLifecyclePolicySuite) TestRemovedPolicyClearsTransition() {
+	state, countingClient, awsMock := suite.newState(newLifecycleNfsInstance("AFTER_30_DAYS"))
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+
+	err, _ := reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	state.ObjAsNfsInstance().Spec.Instance.Aws.LifecyclePolicy = nil
+
+	err, _ = reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, countingClient.putCount)
+	assert.Empty(suite.T(), awsMock.GetLifecyclePolicies(fsId))
+	assert.Nil(suite.T(), state.ObjAsNfsInstance().Status.LifecyclePolicy)
+}
+
+func (suite *reconcileLifecyclePolicySuite) TestNotSpecifiedIsNotManaged() {
+	state, countingClient, _ := suite.newState(newLifecycleNfsInstance(""))
+
+	err, _ := reconcileLifecyclePolicy(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, countingClient.putCount)
+}
+
+func (suite *reconcileLifecyclePolicySuite) TestSetTransitionToIAKeepsOtherTransitions() {
+	policies := []efsTypes.LifecyclePolicy{
+		{TransitionToIA: efsTypes.TransitionToIARulesAfter30Days},
+		{TransitionToPrimaryStorageClass: efsTypes.TransitionToPrimaryStorageClassRulesAfter1Access},
+	}
+
+	assert.Equal(suite.T(), []efsTypes.LifecyclePolicy{
+		{TransitionToPrimaryStorageClass: efsTypes.TransitionToPrimaryStorageClassRulesAfter1Access},
+		{TransitionToIA: efsTypes.TransitionToIARulesAfter90Days},
+	}, setTransitionToIA(policies, "AFTER_90_DAYS"))
+
+	assert.Equal(suite.T(), []efsTypes.LifecyclePolicy{
+		{TransitionToPrimaryStorageClass: efsTypes.TransitionToPrimaryStorageClassRulesAfter1Access},
+	}, setTransitionToIA(policies, cloudcontrolv1beta1.AwsTransitionToIANone))
+}
+
+func TestReconcileLifecyclePolicy(t *testing.T) {
+	suite.Run(t, new(reconcileLifecyclePolicySuite))
+}
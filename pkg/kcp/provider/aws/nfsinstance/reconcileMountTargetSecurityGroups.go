@@ -0,0 +1,60 @@
+package nfsinstance
+
+import (
+	"context"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileMountTargetSecurityGroups attaches the NFS security group to each available mount target
+// missing it, keeping the other security groups of the mount target, and publishes the security group
+// id in the status
+func reconcileMountTargetSecurityGroups(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if len(state.securityGroupId) == 0 {
+		return nil, nil
+	}
+
+	for _, mt := range state.mountTargets {
+		if mt.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+			continue
+		}
+		mtID := ptr.Deref(mt.MountTargetId, "")
+		sgList := state.mountTargetSecurityGroups[mtID]
+		if pie.Contains(sgList, state.securityGroupId) {
+			continue
+		}
+
+		sgList = append(append([]string{}, sgList...), state.securityGroupId)
+
+		logger.
+			WithValues(
+				"mountTargetId", mtID,
+				"securityGroups", sgList,
+			).
+			Info("Attaching NFS security group to mount target")
+
+		err := state.awsClient.ModifyMountTargetSecurityGroups(ctx, mtID, sgList)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error attaching security group to mount target", ctx)
+		}
+		state.mountTargetSecurityGroups[mtID] = sgList
+	}
+
+	if state.ObjAsNfsInstance().Status.SecurityGroupId == state.securityGroupId {
+		return nil, nil
+	}
+
+	state.ObjAsNfsInstance().Status.SecurityGroupId = state.securityGroupId
+
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		ErrorLogMessage("Error updating KCP NfsInstance status with security group id").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
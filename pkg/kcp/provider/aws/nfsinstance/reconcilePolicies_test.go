@@ -0,0 +1,78 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcilePoliciesWritesStatusOnce(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					AutomaticBackups: ptr.To(true),
+					LifecyclePolicy:  &cloudcontrolv1beta1.AwsLifecyclePolicy{TransitionToIA: "AFTER_30_DAYS"},
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	writes := 0
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				writes++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				writes++
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(t, err)
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, nil)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	err, _ = reconcilePolicies(ctx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, writes)
+
+	loaded := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(nfsInstance), loaded))
+	assert.NotNil(t, loaded.Status.LifecyclePolicy)
+	assert.Equal(t, cloudcontrolv1beta1.AwsTransitionToIA("AFTER_30_DAYS"), loaded.Status.LifecyclePolicy.TransitionToIA)
+}
@@ -0,0 +1,40 @@
+package nfsinstance
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"k8s.io/utils/ptr"
+)
+
+// reconcilePropagatedTags reconciles the spec tags and the tags propagated from the Scope labels and default tags
+// on the existing EFS. The cloud-manager tags and the Name tag take precedence over the spec tags, and the spec
+// tags over the propagated ones.
+var reconcilePropagatedTags = actions.ReconcilePropagatedTags(
+	cloudcontrolv1beta1.ProviderAws,
+	func(st composed.State) []actions.TaggedResource {
+		state := st.(*State)
+		if state.efs == nil {
+			return nil
+		}
+		return []actions.TaggedResource{{
+			Id:   ptr.Deref(state.efs.FileSystemId, ""),
+			Tags: awsutil.EfsTagsToMap(state.efs.Tags),
+		}}
+	},
+	func(st composed.State) map[string]string { return st.(*State).ObjAsNfsInstance().Spec.Tags },
+	func(st composed.State) map[string]string {
+		tags := getOwnTags(st.(*State))
+		tags["Name"] = st.Obj().GetName()
+		return tags
+	},
+	func(ctx context.Context, st composed.State, id string, tags map[string]string) error {
+		return st.(*State).awsClient.TagResource(ctx, id, awsutil.EfsTagsFromMap(tags))
+	},
+	func(ctx context.Context, st composed.State, id string, keys []string) error {
+		return st.(*State).awsClient.UntagResource(ctx, id, keys)
+	},
+)
@@ -0,0 +1,73 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileReplicaReadOnly surfaces the ReplicationDestinationReadOnly condition from the replication
+// overwrite protection of the replica file system. While replicating, EFS keeps the destination
+// read-only so it is not accidentally written to, until the replica is promoted on failover.
+func reconcileReplicaReadOnly(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	nfsInstance := state.ObjAsNfsInstance()
+	replica := nfsInstance.Status.Replica
+	if state.replication == nil || !state.isReplicationDesired() || state.replicaClient == nil {
+		return nil, nil
+	}
+	if replica == nil || len(replica.Id) == 0 {
+		return nil, nil
+	}
+
+	list, err := state.replicaClient.DescribeFileSystems(ctx)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading AWS EFS replica", ctx)
+	}
+	idx := pie.FindFirstUsing(list, func(fs efsTypes.FileSystemDescription) bool {
+		return ptr.Deref(fs.FileSystemId, "") == replica.Id
+	})
+	if idx == -1 {
+		// replica file system is still being created
+		return nil, nil
+	}
+
+	protection := efsTypes.ReplicationOverwriteProtection("")
+	if list[idx].FileSystemProtection != nil {
+		protection = list[idx].FileSystemProtection.ReplicationOverwriteProtection
+	}
+
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReplicationDestinationReadOnly,
+		Message: fmt.Sprintf("Replica %s in region %s is read-only", replica.Id, replica.Region),
+	}
+	if protection != efsTypes.ReplicationOverwriteProtectionReplicating {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = cloudcontrolv1beta1.ReasonReplicationDestinationWritable
+		condition.Message = fmt.Sprintf("Replica %s in region %s is not protected from writes, replication overwrite protection is %s", replica.Id, replica.Region, protection)
+	}
+
+	existing := meta.FindStatusCondition(*nfsInstance.Conditions(), condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason {
+		return nil, nil
+	}
+
+	logger.WithValues("replicationOverwriteProtection", protection).Info("Updating AWS EFS replica read-only condition")
+
+	return composed.UpdateStatus(nfsInstance).
+		SetCondition(condition).
+		ErrorLogMessage("Error updating KCP NfsInstance status with replica read-only condition").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
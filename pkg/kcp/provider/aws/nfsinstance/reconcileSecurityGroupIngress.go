@@ -0,0 +1,125 @@
+package nfsinstance
+
+import (
+	"context"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+const nfsPort = int32(2049)
+
+// nfsIngressRuleDescription marks the NFS ingress rules authorized by cloud-manager, the only ones it revokes
+const nfsIngressRuleDescription = "cloud-manager nfs ingress"
+
+// nfsIngressCidrs returns the CIDRs allowed to reach the mount targets, the owning IpRange CIDRs together
+// with the cluster VPC and Pods CIDRs the workloads mount from. Nothing is returned while the IpRange
+// CIDRs are not known.
+func nfsIngressCidrs(state *State) []string {
+	cidrs := state.IpRange().Status.Cidrs
+	if len(cidrs) == 0 && len(state.IpRange().Status.Cidr) > 0 {
+		cidrs = []string{state.IpRange().Status.Cidr}
+	}
+	if len(cidrs) == 0 {
+		return nil
+	}
+	result := append([]string{}, cidrs...)
+	if state.Scope() != nil && state.Scope().Spec.Scope.Aws != nil {
+		result = append(result,
+			state.Scope().Spec.Scope.Aws.Network.VPC.CIDR,
+			state.Scope().Spec.Scope.Aws.Network.Pods,
+		)
+	}
+	result = pie.Filter(result, func(cidr string) bool {
+		return len(cidr) > 0
+	})
+	return pie.Sort(pie.Unique(result))
+}
+
+func isNfsIpPermission(perm ec2Types.IpPermission) bool {
+	return ptr.Deref(perm.IpProtocol, "") == "tcp" &&
+		ptr.Deref(perm.FromPort, 0) == nfsPort &&
+		ptr.Deref(perm.ToPort, 0) == nfsPort
+}
+
+func nfsIpPermission(cidrs []string) ec2Types.IpPermission {
+	perm := ec2Types.IpPermission{
+		IpProtocol: ptr.To("tcp"),
+		FromPort:   ptr.To(nfsPort),
+		ToPort:     ptr.To(nfsPort),
+	}
+	for _, cidr := range cidrs {
+		if strings.Contains(cidr, ":") {
+			perm.Ipv6Ranges = append(perm.Ipv6Ranges, ec2Types.Ipv6Range{CidrIpv6: ptr.To(cidr), Description: ptr.To(nfsIngressRuleDescription)})
+		} else {
+			perm.IpRanges = append(perm.IpRanges, ec2Types.IpRange{CidrIp: ptr.To(cidr), Description: ptr.To(nfsIngressRuleDescription)})
+		}
+	}
+	return perm
+}
+
+// reconcileSecurityGroupIngress authorizes the NFS port ingress of the IpRange, VPC and Pods CIDRs missing in
+// the security group, and revokes only the rules cloud-manager authorized for CIDRs no longer desired. The rules
+// created otherwise, as the VPC and Pods CIDR rules of the existing file systems, are never revoked.
+func reconcileSecurityGroupIngress(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	desired := nfsIngressCidrs(state)
+	if len(desired) == 0 {
+		// never revoke all the rules while the IpRange CIDRs are not known
+		return nil, nil
+	}
+
+	var actual []string
+	var managed []string
+	for _, perm := range state.securityGroup.IpPermissions {
+		if !isNfsIpPermission(perm) {
+			continue
+		}
+		for _, rng := range perm.IpRanges {
+			actual = append(actual, ptr.Deref(rng.CidrIp, ""))
+			if ptr.Deref(rng.Description, "") == nfsIngressRuleDescription {
+				managed = append(managed, ptr.Deref(rng.CidrIp, ""))
+			}
+		}
+		for _, rng := range perm.Ipv6Ranges {
+			actual = append(actual, ptr.Deref(rng.CidrIpv6, ""))
+			if ptr.Deref(rng.Description, "") == nfsIngressRuleDescription {
+				managed = append(managed, ptr.Deref(rng.CidrIpv6, ""))
+			}
+		}
+	}
+
+	toAuthorize, _ := pie.Diff(pie.Unique(actual), desired)
+	toRevoke, _ := pie.Diff(desired, pie.Unique(managed))
+	if len(toAuthorize) == 0 && len(toRevoke) == 0 {
+		return nil, nil
+	}
+
+	if len(toAuthorize) > 0 {
+		logger.
+			WithValues("cidrs", toAuthorize).
+			Info("Authorizing NFS security group ingress")
+		err := state.awsClient.AuthorizeSecurityGroupIngress(ctx, state.securityGroupId, []ec2Types.IpPermission{nfsIpPermission(toAuthorize)})
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error adding security group ingress", ctx)
+		}
+	}
+
+	if len(toRevoke) > 0 {
+		logger.
+			WithValues("cidrs", toRevoke).
+			Info("Revoking NFS security group ingress")
+		err := state.awsClient.RevokeSecurityGroupIngress(ctx, state.securityGroupId, []ec2Types.IpPermission{nfsIpPermission(toRevoke)})
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error revoking security group ingress", ctx)
+		}
+	}
+
+	return composed.StopWithRequeue, nil
+}
@@ -0,0 +1,253 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type reconcileSecurityGroupSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileSecurityGroupSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileSecurityGroupSuite) newState(ipRange *cloudcontrolv1beta1.IpRange) (*State, awsmock.Server) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	sgId, err := awsMock.CreateSecurityGroup(suite.ctx, "vpc-1", "nfs", nil)
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testNfsInstanceState{State: focalState, ipRange: ipRange}, awsMock, nil)
+	state.securityGroupId = sgId
+	suite.loadSecurityGroup(state)
+
+	return state, awsMock
+}
+
+func (suite *reconcileSecurityGroupSuite) loadSecurityGroup(state *State) {
+	list, err := state.awsClient.DescribeSecurityGroups(suite.ctx, nil, []string{state.securityGroupId})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), list, 1)
+	state.securityGroup = &list[0]
+}
+
+func (suite *reconcileSecurityGroupSuite) nfsIngressCidrs(state *State) []string {
+	var result []string
+	for _, perm := range state.securityGroup.IpPermissions {
+		if !isNfsIpPermission(perm) {
+			continue
+		}
+		for _, rng := range perm.IpRanges {
+			result = append(result, ptr.Deref(rng.CidrIp, ""))
+		}
+	}
+	return pie.Sort(result)
+}
+
+func (suite *reconcileSecurityGroupSuite) TestIngressDefaultsToIpRangeCidr() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.4.0/22"},
+	})
+
+	err, _ := reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+
+	suite.loadSecurityGroup(state)
+	assert.Equal(suite.T(), []string{"10.250.4.0/22"}, suite.nfsIngressCidrs(state))
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *reconcileSecurityGroupSuite) TestIngressIncludesScopeVpcAndPodsCidrs() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.4.0/22"},
+	})
+	state.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						VPC:  cloudcontrolv1beta1.AwsVPC{CIDR: "10.250.0.0/16"},
+						Pods: "100.64.0.0/12",
+					},
+				},
+			},
+		},
+	})
+
+	err, _ := reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+
+	suite.loadSecurityGroup(state)
+	assert.Equal(suite.T(), []string{"10.250.0.0/16", "10.250.4.0/22", "100.64.0.0/12"}, suite.nfsIngressCidrs(state))
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *reconcileSecurityGroupSuite) TestIngressDriftIsReconciled() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Cidr:  "10.250.4.0/22",
+			Cidrs: []string{"10.250.4.0/22", "10.250.16.0/22"},
+		},
+	})
+	err := state.awsClient.AuthorizeSecurityGroupIngress(suite.ctx, state.securityGroupId, []ec2Types.IpPermission{
+		nfsIpPermission([]string{"10.250.4.0/22", "10.250.8.0/22"}),
+		{
+			// the VPC CIDR rule not authorized by cloud-manager
+			IpProtocol: ptr.To("tcp"),
+			FromPort:   ptr.To(nfsPort),
+			ToPort:     ptr.To(nfsPort),
+			IpRanges:   []ec2Types.IpRange{{CidrIp: ptr.To("10.250.0.0/16")}},
+		},
+		{
+			IpProtocol: ptr.To("tcp"),
+			FromPort:   ptr.To(int32(22)),
+			ToPort:     ptr.To(int32(22)),
+			IpRanges:   []ec2Types.IpRange{{CidrIp: ptr.To("10.250.8.0/22")}},
+		},
+	})
+	assert.NoError(suite.T(), err)
+	suite.loadSecurityGroup(state)
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+
+	suite.loadSecurityGroup(state)
+	assert.Equal(suite.T(), []string{"10.250.0.0/16", "10.250.16.0/22", "10.250.4.0/22"}, suite.nfsIngressCidrs(state),
+		"only the rules authorized by cloud-manager must be revoked")
+	assert.True(suite.T(), pie.Any(state.securityGroup.IpPermissions, func(perm ec2Types.IpPermission) bool {
+		return ptr.Deref(perm.ToPort, 0) == 22
+	}), "rules for other ports must not be revoked")
+}
+
+func (suite *reconcileSecurityGroupSuite) TestExistingRulesAreKept() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.4.0/22"},
+	})
+	err := state.awsClient.AuthorizeSecurityGroupIngress(suite.ctx, state.securityGroupId, []ec2Types.IpPermission{
+		{
+			IpProtocol: ptr.To("tcp"),
+			FromPort:   ptr.To(nfsPort),
+			ToPort:     ptr.To(nfsPort),
+			IpRanges: []ec2Types.IpRange{
+				{CidrIp: ptr.To("10.250.0.0/16")},
+				{CidrIp: ptr.To("100.64.0.0/12")},
+			},
+		},
+	})
+	assert.NoError(suite.T(), err)
+	suite.loadSecurityGroup(state)
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+
+	suite.loadSecurityGroup(state)
+	assert.Equal(suite.T(), []string{"10.250.0.0/16", "10.250.4.0/22", "100.64.0.0/12"}, suite.nfsIngressCidrs(state))
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *reconcileSecurityGroupSuite) TestNothingRevokedWithoutIpRangeCidrs() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{})
+	err := state.awsClient.AuthorizeSecurityGroupIngress(suite.ctx, state.securityGroupId, []ec2Types.IpPermission{
+		nfsIpPermission([]string{"10.250.4.0/22"}),
+	})
+	assert.NoError(suite.T(), err)
+	suite.loadSecurityGroup(state)
+
+	err, _ = reconcileSecurityGroupIngress(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	suite.loadSecurityGroup(state)
+	assert.Equal(suite.T(), []string{"10.250.4.0/22"}, suite.nfsIngressCidrs(state))
+}
+
+func (suite *reconcileSecurityGroupSuite) TestSecurityGroupAttachedToMountTargets() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{Cidr: "10.250.4.0/22"},
+	})
+	withSg, err := awsMock.CreateMountTarget(suite.ctx, "fs-1", "subnet-a", "", []string{state.securityGroupId})
+	assert.NoError(suite.T(), err)
+	withoutSg, err := awsMock.CreateMountTarget(suite.ctx, "fs-1", "subnet-b", "", []string{"sg-other"})
+	assert.NoError(suite.T(), err)
+
+	state.mountTargets, err = awsMock.DescribeMountTargets(suite.ctx, "fs-1")
+	assert.NoError(suite.T(), err)
+	state.mountTargetSecurityGroups = map[string][]string{
+		withSg:    {state.securityGroupId},
+		withoutSg: {"sg-other"},
+	}
+
+	err, _ = reconcileMountTargetSecurityGroups(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	sgList, err := awsMock.DescribeMountTargetSecurityGroups(suite.ctx, withoutSg)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"sg-other", state.securityGroupId}, sgList)
+
+	sgList, err = awsMock.DescribeMountTargetSecurityGroups(suite.ctx, withSg)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{state.securityGroupId}, sgList)
+
+	saved := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	assert.Equal(suite.T(), state.securityGroupId, saved.Status.SecurityGroupId)
+}
+
+func (suite *reconcileSecurityGroupSuite) TestDeletingMountTargetsAreSkipped() {
+	state, _ := suite.newState(&cloudcontrolv1beta1.IpRange{})
+	state.ObjAsNfsInstance().Status.SecurityGroupId = state.securityGroupId
+	state.mountTargets = []efsTypes.MountTargetDescription{
+		{MountTargetId: ptr.To("mt-deleting"), LifeCycleState: efsTypes.LifeCycleStateDeleting},
+	}
+	state.mountTargetSecurityGroups = map[string][]string{"mt-deleting": {}}
+
+	err, _ := reconcileMountTargetSecurityGroups(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), state.mountTargetSecurityGroups["mt-deleting"])
+}
+
+func TestReconcileSecurityGroup(t *testing.T) {
+	suite.Run(t, new(reconcileSecurityGroupSuite))
+}
@@ -0,0 +1,160 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// throughputUtilizationPeriod is the period the throughput utilization is averaged over
+	throughputUtilizationPeriod = 5 * time.Minute
+	// throughputScaleUpUtilization is the utilization percent above which the throughput is scaled up
+	throughputScaleUpUtilization = 80.0
+	// throughputScaleDownUtilization is the utilization percent below which the throughput is scaled down
+	throughputScaleDownUtilization = 30.0
+	// throughputScaleDownCooldown is the time after the last scaling before the throughput can be scaled down
+	throughputScaleDownCooldown = 30 * time.Minute
+	// throughputDecreaseInterval is how often AWS allows the provisioned throughput to be decreased
+	throughputDecreaseInterval = 24 * time.Hour
+)
+
+// reconcileThroughputScaling keeps the provisioned throughput of the file system within the spec bounds.
+// With the EfsThroughputAutoscaling feature enabled it also scales the throughput up when its utilization
+// exceeds the threshold, and down when the utilization is low and the cooldown after last scaling passed.
+// Since AWS allows the decrease once per day, a decrease attempted sooner is not made and the
+// ThroughputScaleThrottled condition is set instead. The scaling actions are recorded as events.
+func reconcileThroughputScaling(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if state.efs == nil || nfsInstance.Spec.Instance.Aws.Throughput != cloudcontrolv1beta1.AwsThroughputModeProvisioned ||
+		nfsInstance.Spec.Instance.Aws.ProvisionedThroughput == nil {
+		return nil, nil
+	}
+
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	bounds := nfsInstance.Spec.Instance.Aws.ProvisionedThroughput
+	autoscaling := feature.EfsThroughputAutoscaling.Value(ctx)
+	now := time.Now()
+
+	current := 0
+	if state.efs.ThroughputMode == efsTypes.ThroughputModeProvisioned {
+		current = int(math.Round(ptr.Deref(state.efs.ProvisionedThroughputInMibps, 0)))
+	}
+
+	desired := min(max(current, bounds.Min), bounds.Max)
+	if desired == current && autoscaling {
+		utilization, err := state.awsClient.GetThroughputUtilization(ctx, fsId, throughputUtilizationPeriod)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error getting AWS EFS throughput utilization", ctx)
+		}
+		if utilization != nil {
+			desired = scaledThroughput(current, *utilization, bounds, nfsInstance.Status.LastThroughputScaleTime, now)
+		}
+	}
+
+	// changing the mode to provisioned is not a decrease
+	decrease := current > 0 && desired < current
+	throttled := decrease && nfsInstance.Status.LastThroughputDecreaseTime != nil &&
+		now.Sub(nfsInstance.Status.LastThroughputDecreaseTime.Time) < throughputDecreaseInterval
+	if throttled {
+		desired = current
+	}
+
+	changed := false
+	if desired != current {
+		logger.
+			WithValues(
+				"currentThroughputMibps", current,
+				"desiredThroughputMibps", desired,
+			).
+			Info("Updating AWS EFS provisioned throughput")
+
+		err := state.awsClient.UpdateProvisionedThroughput(ctx, fsId, float64(desired))
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error updating AWS EFS provisioned throughput", ctx)
+		}
+		state.efs.ThroughputMode = efsTypes.ThroughputModeProvisioned
+		state.efs.ProvisionedThroughputInMibps = ptr.To(float64(desired))
+
+		if recorder := state.Cluster().EventRecorder(); recorder != nil {
+			recorder.Eventf(nfsInstance, corev1.EventTypeNormal, "ThroughputScaled",
+				"Provisioned throughput scaled from %d to %d MiB/s", current, desired)
+		}
+
+		nfsInstance.Status.LastThroughputScaleTime = ptr.To(metav1.NewTime(now))
+		if decrease {
+			nfsInstance.Status.LastThroughputDecreaseTime = ptr.To(metav1.NewTime(now))
+		}
+		changed = true
+	}
+
+	if nfsInstance.Status.ProvisionedThroughputMibps != desired {
+		nfsInstance.Status.ProvisionedThroughputMibps = desired
+		changed = true
+	}
+
+	throttledCondition := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeThroughputScaleThrottled)
+	b := composed.UpdateStatus(nfsInstance)
+	if throttled {
+		nextDecrease := nfsInstance.Status.LastThroughputDecreaseTime.Add(throughputDecreaseInterval)
+		message := fmt.Sprintf("Provisioned throughput can be decreased once per day, next decrease is possible after %s", nextDecrease.Format(time.RFC3339))
+		if throttledCondition == nil || !composed.ConditionMessageEquals(*throttledCondition, message) {
+			b = b.SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeThroughputScaleThrottled,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonThroughputDecreaseLimit,
+				Message: message,
+			})
+			changed = true
+		}
+	} else if throttledCondition != nil {
+		b = b.RemoveConditions(cloudcontrolv1beta1.ConditionTypeThroughputScaleThrottled)
+		changed = true
+	}
+
+	successError := error(nil)
+	if autoscaling {
+		// keep monitoring the utilization
+		successError = composed.StopWithRequeueDelay(util.Timing.T300000ms())
+	}
+
+	if !changed {
+		return successError, nil
+	}
+
+	b = b.ErrorLogMessage("Error updating KCP NfsInstance status with provisioned throughput")
+	if successError == nil {
+		b = b.SuccessErrorNil()
+	} else {
+		b = b.SuccessError(successError)
+	}
+	return b.Run(ctx, state)
+}
+
+// scaledThroughput returns the provisioned throughput for the given utilization, scaled up by half when
+// the utilization is high, and down by a third when it is low and the cooldown after last scaling passed
+func scaledThroughput(current int, utilization float64, bounds *cloudcontrolv1beta1.AwsProvisionedThroughput, lastScaleTime *metav1.Time, now time.Time) int {
+	if utilization > throughputScaleUpUtilization {
+		return min(max(int(math.Ceil(float64(current)*1.5)), current+1), bounds.Max)
+	}
+	if utilization < throughputScaleDownUtilization &&
+		(lastScaleTime == nil || now.Sub(lastScaleTime.Time) >= throughputScaleDownCooldown) {
+		return max(int(math.Floor(float64(current)*2/3)), bounds.Min)
+	}
+	return current
+}
@@ -0,0 +1,148 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/feature"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestScaledThroughput(t *testing.T) {
+	bounds := &cloudcontrolv1beta1.AwsProvisionedThroughput{Min: 10, Max: 100}
+	now := time.Now()
+	recently := ptr.To(metav1.NewTime(now.Add(-time.Minute)))
+	longAgo := ptr.To(metav1.NewTime(now.Add(-time.Hour)))
+
+	assert.Equal(t, 30, scaledThroughput(20, 90, bounds, recently, now), "high utilization scales up")
+	assert.Equal(t, 100, scaledThroughput(80, 90, bounds, recently, now), "scale up is limited by max")
+	assert.Equal(t, 20, scaledThroughput(20, 50, bounds, longAgo, now), "moderate utilization keeps throughput")
+	assert.Equal(t, 20, scaledThroughput(20, 10, bounds, recently, now), "scale down waits for cooldown")
+	assert.Equal(t, 13, scaledThroughput(20, 10, bounds, longAgo, now), "low utilization scales down after cooldown")
+	assert.Equal(t, 10, scaledThroughput(12, 10, bounds, nil, now), "scale down is limited by min")
+}
+
+type reconcileThroughputScalingSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileThroughputScalingSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+	feature.InitializeFromStaticConfig(abstractions.NewMockedEnvironment(map[string]string{
+		"FF_EFS_THROUGHPUT_AUTOSCALING": "true",
+	}))
+}
+
+func (suite *reconcileThroughputScalingSuite) TearDownTest() {
+	feature.InitializeFromStaticConfig(nil)
+}
+
+func (suite *reconcileThroughputScalingSuite) newState(current float64) (*State, awsmock.Server, *record.FakeRecorder) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					Throughput:            cloudcontrolv1beta1.AwsThroughputModeProvisioned,
+					ProvisionedThroughput: &cloudcontrolv1beta1.AwsProvisionedThroughput{Min: 10, Max: 100},
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	cluster := composed.NewStateCluster(clnt, clnt, recorder, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeProvisioned, ptr.To(current), nil, "", nil)
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, nil)
+	state.efs = awsMock.GetFileSystemById(ptr.Deref(out.FileSystemId, ""))
+
+	return state, awsMock, recorder
+}
+
+func (suite *reconcileThroughputScalingSuite) TestScalesUpOnHighUtilization() {
+	state, awsMock, recorder := suite.newState(20)
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	awsMock.SetThroughputUtilization(fsId, ptr.To(95.0))
+
+	err, _ := reconcileThroughputScaling(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+
+	assert.Equal(suite.T(), 30.0, ptr.Deref(awsMock.GetFileSystemById(fsId).ProvisionedThroughputInMibps, 0))
+	assert.Equal(suite.T(), 30, state.ObjAsNfsInstance().Status.ProvisionedThroughputMibps)
+	assert.NotNil(suite.T(), state.ObjAsNfsInstance().Status.LastThroughputScaleTime)
+	assert.Nil(suite.T(), state.ObjAsNfsInstance().Status.LastThroughputDecreaseTime)
+	assert.Len(suite.T(), recorder.Events, 1)
+}
+
+func (suite *reconcileThroughputScalingSuite) TestDecreaseIsThrottledOncePerDay() {
+	state, awsMock, _ := suite.newState(60)
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+	awsMock.SetThroughputUtilization(fsId, ptr.To(5.0))
+
+	err, _ := reconcileThroughputScaling(suite.ctx, state)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), 40.0, ptr.Deref(awsMock.GetFileSystemById(fsId).ProvisionedThroughputInMibps, 0))
+	assert.NotNil(suite.T(), state.ObjAsNfsInstance().Status.LastThroughputDecreaseTime)
+	assert.Nil(suite.T(), meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeThroughputScaleThrottled))
+
+	// the cooldown after last scaling passed, but the decrease was made less than a day ago
+	state.ObjAsNfsInstance().Status.LastThroughputScaleTime = ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))
+
+	err, _ = reconcileThroughputScaling(suite.ctx, state)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), 40.0, ptr.Deref(awsMock.GetFileSystemById(fsId).ProvisionedThroughputInMibps, 0))
+	assert.Equal(suite.T(), 40, state.ObjAsNfsInstance().Status.ProvisionedThroughputMibps)
+	cond := meta.FindStatusCondition(state.ObjAsNfsInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeThroughputScaleThrottled)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonThroughputDecreaseLimit, cond.Reason)
+}
+
+func (suite *reconcileThroughputScalingSuite) TestClampsToBoundsWithoutAutoscaling() {
+	feature.InitializeFromStaticConfig(nil)
+	state, awsMock, _ := suite.newState(200)
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+
+	err, _ := reconcileThroughputScaling(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 100.0, ptr.Deref(awsMock.GetFileSystemById(fsId).ProvisionedThroughputInMibps, 0))
+	assert.Equal(suite.T(), 100, state.ObjAsNfsInstance().Status.ProvisionedThroughputMibps)
+}
+
+func TestReconcileThroughputScaling(t *testing.T) {
+	suite.Run(t, new(reconcileThroughputScalingSuite))
+}
@@ -0,0 +1,487 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileRegions provisions the file system with a mount target in each zone of the Scope network VPC
+// in every additional region of the spec, and deletes the file systems of the regions removed from the spec.
+// It runs after the file system in the Scope region is reconciled, and each region advances one step per
+// reconciliation. While any region is not ready the NfsInstance Ready condition is False, so it is Ready
+// only when the file systems in all regions are healthy.
+func reconcileRegions(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if len(state.regionClients) == 0 {
+		return nil, nil
+	}
+
+	desired := state.desiredRegions()
+	oldRegions := make([]cloudcontrolv1beta1.NfsRegionStatus, 0, len(nfsInstance.Status.Regions))
+	for _, rs := range nfsInstance.Status.Regions {
+		oldRegions = append(oldRegions, *rs.DeepCopy())
+	}
+
+	for _, region := range desired {
+		if !pie.Any(nfsInstance.Status.Regions, func(rs cloudcontrolv1beta1.NfsRegionStatus) bool {
+			return rs.Region == region
+		}) {
+			nfsInstance.Status.Regions = append(nfsInstance.Status.Regions, cloudcontrolv1beta1.NfsRegionStatus{Region: region})
+		}
+	}
+	sort.Slice(nfsInstance.Status.Regions, func(i, j int) bool {
+		return nfsInstance.Status.Regions[i].Region < nfsInstance.Status.Regions[j].Region
+	})
+
+	var deleted []string
+	for i := range nfsInstance.Status.Regions {
+		rs := &nfsInstance.Status.Regions[i]
+		c := state.regionClients[rs.Region]
+		if c == nil {
+			continue
+		}
+		if !pie.Contains(desired, rs.Region) {
+			gone, err := state.deleteRegionEfs(ctx, c, rs)
+			if err != nil {
+				logger.Error(err, "Error deleting file system in region", "region", rs.Region)
+				setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionFailed, fmt.Sprintf("Failed deleting file system: %s", err))
+			}
+			if gone {
+				logger.Info("Deleted file system in removed region", "region", rs.Region)
+				deleted = append(deleted, rs.Region)
+			}
+			continue
+		}
+
+		err := state.reconcileRegionEfs(ctx, c, rs)
+		if err != nil {
+			logger.Error(err, "Error provisioning file system in region", "region", rs.Region)
+			setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionFailed, fmt.Sprintf("Failed provisioning file system: %s", err))
+		}
+	}
+
+	nfsInstance.Status.Regions = pie.Filter(nfsInstance.Status.Regions, func(rs cloudcontrolv1beta1.NfsRegionStatus) bool {
+		return !pie.Contains(deleted, rs.Region)
+	})
+	if len(nfsInstance.Status.Regions) == 0 {
+		nfsInstance.Status.Regions = nil
+	}
+
+	notReady := state.regionsNotReady()
+	readyCondition := regionsReadyCondition(notReady)
+
+	regionsChanged := !equality.Semantic.DeepEqual(oldRegions, nfsInstance.Status.Regions) &&
+		(len(oldRegions) > 0 || len(nfsInstance.Status.Regions) > 0)
+	if !regionsChanged && !composed.AnyConditionChanged(nfsInstance, readyCondition) {
+		if len(notReady) > 0 {
+			return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+		}
+		return nil, nil
+	}
+
+	if len(notReady) > 0 {
+		return composed.UpdateStatus(nfsInstance).
+			SetCondition(readyCondition).
+			ErrorLogMessage("Error updating KCP NfsInstance status with regions").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+			Run(ctx, state)
+	}
+
+	return composed.UpdateStatus(nfsInstance).
+		SetCondition(readyCondition).
+		ErrorLogMessage("Error updating KCP NfsInstance status with ready regions").
+		SuccessLogMsg("KCP NfsInstance file systems ready in all regions").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// regionsNotReady returns the additional regions of the spec without the ready file system, and the regions
+// removed from the spec whose file system is not deleted yet
+func (s *State) regionsNotReady() []string {
+	nfsInstance := s.ObjAsNfsInstance()
+	desired := s.desiredRegions()
+	var result []string
+	for _, region := range desired {
+		if !pie.Any(nfsInstance.Status.Regions, func(rs cloudcontrolv1beta1.NfsRegionStatus) bool {
+			return rs.Region == region && meta.IsStatusConditionTrue(rs.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+		}) {
+			result = append(result, region)
+		}
+	}
+	for _, rs := range nfsInstance.Status.Regions {
+		if !pie.Contains(desired, rs.Region) {
+			result = append(result, rs.Region)
+		}
+	}
+	return pie.Sort(result)
+}
+
+// regionsReadyCondition returns the NfsInstance Ready condition, False while the file systems in any
+// of the regions are not ready
+func regionsReadyCondition(notReady []string) metav1.Condition {
+	if len(notReady) > 0 {
+		return metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  cloudcontrolv1beta1.ReasonRegionNotReady,
+			Message: fmt.Sprintf("File system not ready in regions: %s", strings.Join(notReady, ", ")),
+		}
+	}
+	return metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonReady,
+		Message: "NFS instance is ready",
+	}
+}
+
+// deleteRegions deletes the file systems in all additional regions before the file system in the
+// Scope region is deleted
+func deleteRegions(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if len(state.regionClients) == 0 {
+		return nil, nil
+	}
+
+	regions := pie.Sort(pie.Keys(state.regionClients))
+	pending := false
+	for _, region := range regions {
+		rs := &cloudcontrolv1beta1.NfsRegionStatus{Region: region}
+		gone, err := state.deleteRegionEfs(ctx, state.regionClients[region], rs)
+		if err != nil {
+			return composed.LogErrorAndReturn(err, fmt.Sprintf("Error deleting file system in region %s", region), composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+		}
+		if !gone {
+			pending = true
+		}
+	}
+
+	if pending {
+		logger.Info("Waiting for file systems in additional regions to be deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	if len(nfsInstance.Status.Regions) == 0 {
+		return nil, nil
+	}
+
+	nfsInstance.Status.Regions = nil
+	return composed.UpdateStatus(nfsInstance).
+		ErrorLogMessage("Error updating KCP NfsInstance status after deleting file systems in regions").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// desiredRegions returns the additional regions of the spec the file system is provisioned in
+func (s *State) desiredRegions() []string {
+	nfsInstance := s.ObjAsNfsInstance()
+	if composed.IsMarkedForDeletion(nfsInstance) || nfsInstance.Spec.Instance.Aws == nil {
+		return nil
+	}
+	return pie.Filter(nfsInstance.Spec.Instance.Aws.Regions, func(region string) bool {
+		return region != s.Scope().Spec.Region
+	})
+}
+
+// reconcileRegionEfs makes one step of provisioning the file system and its mount targets in the
+// region, and sets the region status and Ready condition accordingly
+func (s *State) reconcileRegionEfs(ctx context.Context, c nfsinstanceclient.Client, rs *cloudcontrolv1beta1.NfsRegionStatus) error {
+	fs, err := s.findRegionEfs(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if fs == nil {
+		awsSpec := s.ObjAsNfsInstance().Spec.Instance.Aws
+		var provisionedThroughput *float64
+		if awsSpec.Throughput == cloudcontrolv1beta1.AwsThroughputModeProvisioned && awsSpec.ProvisionedThroughput != nil {
+			provisionedThroughput = ptr.To(float64(awsSpec.ProvisionedThroughput.Min))
+		}
+		out, err := c.CreateFileSystem(
+			ctx,
+			efsTypes.PerformanceMode(awsSpec.PerformanceMode),
+			efsTypes.ThroughputMode(awsSpec.Throughput),
+			provisionedThroughput,
+			awsSpec.AutomaticBackups,
+			"",
+			s.efsTags(),
+		)
+		if err != nil {
+			return err
+		}
+		composed.LoggerFromCtx(ctx).Info("Created file system in region", "region", rs.Region, "efsId", ptr.Deref(out.FileSystemId, ""))
+		rs.Id = ptr.Deref(out.FileSystemId, "")
+		setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionNotReady, "File system is being created")
+		return nil
+	}
+
+	rs.Id = ptr.Deref(fs.FileSystemId, "")
+	if fs.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+		setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionNotReady, fmt.Sprintf("File system is %s", fs.LifeCycleState))
+		return nil
+	}
+
+	vpcs, err := c.DescribeVpcs(ctx, s.Scope().Spec.Scope.Aws.VpcNetwork)
+	if err != nil {
+		return err
+	}
+	if len(vpcs) == 0 {
+		setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionNetworkNotFound,
+			fmt.Sprintf("VPC %s not found in the region", s.Scope().Spec.Scope.Aws.VpcNetwork))
+		return nil
+	}
+	subnets, err := c.DescribeSubnets(ctx, ptr.Deref(vpcs[0].VpcId, ""))
+	if err != nil {
+		return err
+	}
+	securityGroupId, err := s.ensureRegionSecurityGroup(ctx, c, vpcs[0])
+	if err != nil {
+		return err
+	}
+
+	// the mount target of a zone is created in the subnet of the zone with the lowest id
+	sort.Slice(subnets, func(i, j int) bool {
+		return ptr.Deref(subnets[i].SubnetId, "") < ptr.Deref(subnets[j].SubnetId, "")
+	})
+	subnetZones := map[string]string{}
+	zoneSubnets := map[string]string{}
+	for _, sn := range subnets {
+		zone := ptr.Deref(sn.AvailabilityZone, "")
+		subnetZones[ptr.Deref(sn.SubnetId, "")] = zone
+		if _, ok := zoneSubnets[zone]; !ok {
+			zoneSubnets[zone] = ptr.Deref(sn.SubnetId, "")
+		}
+	}
+
+	mountTargets, err := c.DescribeMountTargets(ctx, rs.Id)
+	if err != nil {
+		return err
+	}
+	zoneMountTargets := map[string]efsTypes.MountTargetDescription{}
+	for _, mt := range mountTargets {
+		zoneMountTargets[subnetZones[ptr.Deref(mt.SubnetId, "")]] = mt
+	}
+
+	zones := pie.Sort(pie.Keys(zoneSubnets))
+	pending := false
+	for _, zone := range zones {
+		mt, ok := zoneMountTargets[zone]
+		if !ok {
+			_, err := c.CreateMountTarget(ctx, rs.Id, zoneSubnets[zone], "", []string{securityGroupId})
+			if err != nil {
+				return err
+			}
+			pending = true
+			continue
+		}
+		if mt.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+			pending = true
+			continue
+		}
+		// the mount targets created with the default security group of the VPC, that does not allow the NFS ingress
+		securityGroups, err := c.DescribeMountTargetSecurityGroups(ctx, ptr.Deref(mt.MountTargetId, ""))
+		if err != nil {
+			return err
+		}
+		if !slices.Equal(securityGroups, []string{securityGroupId}) {
+			if err := c.ModifyMountTargetSecurityGroups(ctx, ptr.Deref(mt.MountTargetId, ""), []string{securityGroupId}); err != nil {
+				return err
+			}
+		}
+	}
+	if pending {
+		setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionNotReady, "Mount targets are being created")
+		return nil
+	}
+
+	rs.Host = fmt.Sprintf("%s.efs.%s.amazonaws.com", rs.Id, rs.Region)
+	rs.MountTargets = pie.Map(zones, func(zone string) cloudcontrolv1beta1.NfsMountTarget {
+		return cloudcontrolv1beta1.NfsMountTarget{
+			Zone:      zone,
+			IpAddress: ptr.Deref(zoneMountTargets[zone].IpAddress, ""),
+		}
+	})
+	setRegionCondition(rs, metav1.ConditionTrue, cloudcontrolv1beta1.ReasonReady, "File system is ready")
+	return nil
+}
+
+// deleteRegionEfs makes one step of deleting the mount targets and the file system in the region,
+// and returns true once the file system is gone
+func (s *State) deleteRegionEfs(ctx context.Context, c nfsinstanceclient.Client, rs *cloudcontrolv1beta1.NfsRegionStatus) (bool, error) {
+	fs, err := s.findRegionEfs(ctx, c)
+	if err != nil {
+		return false, err
+	}
+	if fs == nil || fs.LifeCycleState == efsTypes.LifeCycleStateDeleted {
+		return s.deleteRegionSecurityGroup(ctx, c)
+	}
+
+	setRegionCondition(rs, metav1.ConditionFalse, cloudcontrolv1beta1.ReasonRegionNotReady, "File system is being deleted")
+	if fs.LifeCycleState == efsTypes.LifeCycleStateDeleting {
+		return false, nil
+	}
+
+	mountTargets, err := c.DescribeMountTargets(ctx, ptr.Deref(fs.FileSystemId, ""))
+	if err != nil {
+		return false, err
+	}
+	if len(mountTargets) > 0 {
+		for _, mt := range mountTargets {
+			if mt.LifeCycleState != efsTypes.LifeCycleStateAvailable {
+				continue
+			}
+			if err := c.DeleteMountTarget(ctx, ptr.Deref(mt.MountTargetId, "")); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	if err := c.DeleteFileSystem(ctx, ptr.Deref(fs.FileSystemId, "")); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func (s *State) findRegionEfs(ctx context.Context, c nfsinstanceclient.Client) (*efsTypes.FileSystemDescription, error) {
+	list, err := c.DescribeFileSystems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, fs := range list {
+		if ptr.Deref(fs.Name, "") == s.Obj().GetName() {
+			return &fs, nil
+		}
+	}
+	return nil, nil
+}
+
+// ensureRegionSecurityGroup finds or creates the NFS security group of the mount targets in the VPC of the
+// region, and authorizes the NFS port ingress of the VPC CIDRs missing in it. The default security group of
+// the VPC the mount targets would get otherwise does not allow the NFS ingress.
+func (s *State) ensureRegionSecurityGroup(ctx context.Context, c nfsinstanceclient.Client, vpc ec2Types.Vpc) (string, error) {
+	sg, err := s.findRegionSecurityGroup(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	var securityGroupId string
+	var actual []string
+	if sg == nil {
+		securityGroupId, err = c.CreateSecurityGroup(ctx, ptr.Deref(vpc.VpcId, ""), s.Obj().GetName(), s.securityGroupTags())
+		if err != nil {
+			return "", err
+		}
+		composed.LoggerFromCtx(ctx).Info("Created NFS security group in region", "vpcId", ptr.Deref(vpc.VpcId, ""), "securityGroupId", securityGroupId)
+	} else {
+		securityGroupId = ptr.Deref(sg.GroupId, "")
+		for _, perm := range sg.IpPermissions {
+			if !isNfsIpPermission(perm) {
+				continue
+			}
+			for _, rng := range perm.IpRanges {
+				actual = append(actual, ptr.Deref(rng.CidrIp, ""))
+			}
+		}
+	}
+
+	toAuthorize, _ := pie.Diff(pie.Unique(actual), vpcCidrs(vpc))
+	if len(toAuthorize) > 0 {
+		if err := c.AuthorizeSecurityGroupIngress(ctx, securityGroupId, []ec2Types.IpPermission{nfsIpPermission(toAuthorize)}); err != nil {
+			return "", err
+		}
+	}
+	return securityGroupId, nil
+}
+
+// deleteRegionSecurityGroup deletes the NFS security group in the region once its mount targets are gone,
+// and returns true once it is deleted
+func (s *State) deleteRegionSecurityGroup(ctx context.Context, c nfsinstanceclient.Client) (bool, error) {
+	sg, err := s.findRegionSecurityGroup(ctx, c)
+	if err != nil {
+		return false, err
+	}
+	if sg == nil {
+		return true, nil
+	}
+	err = c.DeleteSecurityGroup(ctx, ptr.Deref(sg.GroupId, ""))
+	if isDependencyViolation(err) {
+		// the network interfaces of the deleted mount targets are released with a delay
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *State) findRegionSecurityGroup(ctx context.Context, c nfsinstanceclient.Client) (*ec2Types.SecurityGroup, error) {
+	list, err := c.DescribeSecurityGroups(ctx, []ec2Types.Filter{
+		{
+			Name:   ptr.To("tag:Name"),
+			Values: []string{s.Obj().GetName()},
+		},
+		{
+			Name:   ptr.To(fmt.Sprintf("tag:%s", common.TagCloudManagerName)),
+			Values: []string{s.Name().String()},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &list[0], nil
+}
+
+// vpcCidrs returns the IPv4 CIDRs associated with the VPC
+func vpcCidrs(vpc ec2Types.Vpc) []string {
+	var result []string
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState != nil && assoc.CidrBlockState.State != ec2Types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		result = append(result, ptr.Deref(assoc.CidrBlock, ""))
+	}
+	if len(result) == 0 && len(ptr.Deref(vpc.CidrBlock, "")) > 0 {
+		result = append(result, ptr.Deref(vpc.CidrBlock, ""))
+	}
+	return pie.Sort(pie.Unique(result))
+}
+
+func isDependencyViolation(err error) bool {
+	apiErr := awsmeta.AsApiError(err)
+	return apiErr != nil && apiErr.ErrorCode() == "DependencyViolation"
+}
+
+func setRegionCondition(rs *cloudcontrolv1beta1.NfsRegionStatus, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&rs.Conditions, metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeReady,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,241 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	nfsinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/nfsinstance/client"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type regionsSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *regionsSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+// newRegionMock returns the mock of the region with the VPC of the Scope network in two zones
+func (suite *regionsSuite) newRegionMock(region string) awsmock.Server {
+	m := awsmock.New()
+	m.AddVpc("vpc-"+region, "10.180.0.0/16", awsutil.Ec2Tags("Name", "shoot-vpc"), []awsmock.VpcSubnet{
+		{AZ: region + "a", Cidr: "10.180.0.0/20"},
+		{AZ: region + "b", Cidr: "10.180.16.0/20"},
+	})
+	return m
+}
+
+func (suite *regionsSuite) newState(regions []string, regionClients map[string]nfsinstanceclient.Client) *State {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: "scope"},
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					PerformanceMode: cloudcontrolv1beta1.AwsPerformanceModeGeneralPurpose,
+					Throughput:      cloudcontrolv1beta1.AwsThroughputModeBursting,
+					Regions:         regions,
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Region:    "eu-west-1",
+			ShootName: "shoot",
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{VpcNetwork: "shoot-vpc"},
+			},
+		},
+	})
+	state := newState(&testNfsInstanceState{State: focalState}, awsmock.New(), nil)
+	state.regionClients = regionClients
+	return state
+}
+
+// reconcileRegionsUntilDone runs the action until it does not requeue, and returns the number of runs
+func (suite *regionsSuite) reconcileRegionsUntilDone(state *State) int {
+	for i := 1; i <= 10; i++ {
+		err, _ := reconcileRegions(suite.ctx, state)
+		if err == nil {
+			return i
+		}
+		assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	}
+	suite.T().Fatal("regions not reconciled")
+	return 0
+}
+
+func (suite *regionsSuite) TestTwoRegionsProvisioned() {
+	central := suite.newRegionMock("eu-central-1")
+	east := suite.newRegionMock("us-east-1")
+	state := suite.newState([]string{"eu-central-1", "us-east-1"}, map[string]nfsinstanceclient.Client{
+		"eu-central-1": central,
+		"us-east-1":    east,
+	})
+	nfsInstance := state.ObjAsNfsInstance()
+
+	err, _ := reconcileRegions(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	ready := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+	if assert.NotNil(suite.T(), ready) {
+		assert.Equal(suite.T(), metav1.ConditionFalse, ready.Status)
+		assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonRegionNotReady, ready.Reason)
+		assert.Equal(suite.T(), "File system not ready in regions: eu-central-1, us-east-1", ready.Message)
+	}
+
+	suite.reconcileRegionsUntilDone(state)
+
+	if assert.Len(suite.T(), nfsInstance.Status.Regions, 2) {
+		for i, m := range []awsmock.Server{central, east} {
+			rs := nfsInstance.Status.Regions[i]
+			list, err := m.DescribeFileSystems(suite.ctx)
+			assert.NoError(suite.T(), err)
+			if assert.Len(suite.T(), list, 1) {
+				assert.Equal(suite.T(), ptr.Deref(list[0].FileSystemId, ""), rs.Id)
+			}
+			assert.Equal(suite.T(), rs.Id+".efs."+rs.Region+".amazonaws.com", rs.Host)
+			assert.True(suite.T(), meta.IsStatusConditionTrue(rs.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+
+			mountTargets, err := m.DescribeMountTargets(suite.ctx, rs.Id)
+			assert.NoError(suite.T(), err)
+			assert.Len(suite.T(), mountTargets, 2)
+			assert.Equal(suite.T(), []string{rs.Region + "a", rs.Region + "b"}, []string{rs.MountTargets[0].Zone, rs.MountTargets[1].Zone})
+
+			// the mount targets have the NFS security group allowing the ingress from the region VPC
+			sgs, err := m.DescribeSecurityGroups(suite.ctx, nil, nil)
+			assert.NoError(suite.T(), err)
+			if assert.Len(suite.T(), sgs, 1) {
+				assert.Equal(suite.T(), []ec2Types.IpPermission{nfsIpPermission([]string{"10.180.0.0/16"})}, sgs[0].IpPermissions)
+				for _, mt := range mountTargets {
+					mtSgs, err := m.DescribeMountTargetSecurityGroups(suite.ctx, ptr.Deref(mt.MountTargetId, ""))
+					assert.NoError(suite.T(), err)
+					assert.Equal(suite.T(), []string{ptr.Deref(sgs[0].GroupId, "")}, mtSgs)
+				}
+			}
+		}
+		assert.Equal(suite.T(), "eu-central-1", nfsInstance.Status.Regions[0].Region)
+		assert.Equal(suite.T(), "us-east-1", nfsInstance.Status.Regions[1].Region)
+	}
+
+	// once ready in all regions, the NfsInstance is Ready
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	err, _ = reconcileRegions(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *regionsSuite) TestExistingMountTargetGetsSecurityGroup() {
+	central := suite.newRegionMock("eu-central-1")
+	state := suite.newState([]string{"eu-central-1"}, map[string]nfsinstanceclient.Client{
+		"eu-central-1": central,
+	})
+	suite.reconcileRegionsUntilDone(state)
+
+	// the mount target created before with the default security group of the VPC
+	rs := state.ObjAsNfsInstance().Status.Regions[0]
+	mountTargets, err := central.DescribeMountTargets(suite.ctx, rs.Id)
+	assert.NoError(suite.T(), err)
+	mtId := ptr.Deref(mountTargets[0].MountTargetId, "")
+	assert.NoError(suite.T(), central.ModifyMountTargetSecurityGroups(suite.ctx, mtId, nil))
+
+	suite.reconcileRegionsUntilDone(state)
+
+	sgs, err := central.DescribeSecurityGroups(suite.ctx, nil, nil)
+	assert.NoError(suite.T(), err)
+	mtSgs, err := central.DescribeMountTargetSecurityGroups(suite.ctx, mtId)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{ptr.Deref(sgs[0].GroupId, "")}, mtSgs)
+}
+
+func (suite *regionsSuite) TestRemovedRegionDeleted() {
+	central := suite.newRegionMock("eu-central-1")
+	east := suite.newRegionMock("us-east-1")
+	state := suite.newState([]string{"eu-central-1", "us-east-1"}, map[string]nfsinstanceclient.Client{
+		"eu-central-1": central,
+		"us-east-1":    east,
+	})
+	nfsInstance := state.ObjAsNfsInstance()
+	suite.reconcileRegionsUntilDone(state)
+
+	nfsInstance.Spec.Instance.Aws.Regions = []string{"eu-central-1"}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Update(suite.ctx, nfsInstance))
+	suite.reconcileRegionsUntilDone(state)
+
+	if assert.Len(suite.T(), nfsInstance.Status.Regions, 1) {
+		assert.Equal(suite.T(), "eu-central-1", nfsInstance.Status.Regions[0].Region)
+	}
+	list, err := east.DescribeFileSystems(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), list)
+	sgs, err := east.DescribeSecurityGroups(suite.ctx, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), sgs)
+	list, err = central.DescribeFileSystems(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), list, 1)
+}
+
+func (suite *regionsSuite) TestRegionWithoutNetworkNotReady() {
+	state := suite.newState([]string{"eu-central-1"}, map[string]nfsinstanceclient.Client{
+		"eu-central-1": awsmock.New(),
+	})
+	nfsInstance := state.ObjAsNfsInstance()
+
+	_, _ = reconcileRegions(suite.ctx, state)
+	err, _ := reconcileRegions(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+
+	if assert.Len(suite.T(), nfsInstance.Status.Regions, 1) {
+		cond := meta.FindStatusCondition(nfsInstance.Status.Regions[0].Conditions, cloudcontrolv1beta1.ConditionTypeReady)
+		if assert.NotNil(suite.T(), cond) {
+			assert.Equal(suite.T(), metav1.ConditionFalse, cond.Status)
+			assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonRegionNetworkNotFound, cond.Reason)
+		}
+	}
+	assert.False(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+}
+
+func TestRegions(t *testing.T) {
+	suite.Run(t, new(regionsSuite))
+}
@@ -17,7 +17,8 @@ func removeMountTargetsFromOtherVpcs(ctx context.Context, st composed.State) (er
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
 
-	if len(state.mountTargets) <= 3 {
+	// the adopted EFS mount targets are pre-existing infrastructure
+	if len(state.mountTargets) <= 3 || state.ObjAsNfsInstance().Status.AdoptedResourceId != "" {
 		return nil, nil
 	}
 
@@ -0,0 +1,161 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type replicationSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *replicationSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *replicationSuite) newState() (*State, awsmock.Server) {
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+		Spec: cloudcontrolv1beta1.NfsInstanceSpec{
+			Instance: cloudcontrolv1beta1.NfsInstanceInfo{
+				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
+					Replication: &cloudcontrolv1beta1.ReplicationOptions{Region: "eu-west-1"},
+				},
+			},
+		},
+	}
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+		Spec:       cloudcontrolv1beta1.ScopeSpec{Region: "eu-central-1"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	focalState.SetScope(scope)
+
+	awsMock := awsmock.New()
+	out, err := awsMock.CreateFileSystem(suite.ctx, efsTypes.PerformanceModeGeneralPurpose, efsTypes.ThroughputModeBursting, nil, nil, "", nil)
+	assert.NoError(suite.T(), err)
+
+	// the mock is not region aware, so it serves as the replica region client as well
+	state := newState(&testNfsInstanceState{State: focalState}, awsMock, awsMock)
+	state.efs = &efsTypes.FileSystemDescription{FileSystemId: out.FileSystemId}
+
+	return state, awsMock
+}
+
+func (suite *replicationSuite) TestReplicationCreatedAndSynced() {
+	state, awsMock := suite.newState()
+	nfsInstance := state.ObjAsNfsInstance()
+	fsId := ptr.Deref(state.efs.FileSystemId, "")
+
+	_, _ = loadReplication(suite.ctx, state)
+	assert.Nil(suite.T(), state.replication)
+
+	err, _ := createReplication(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationSyncing))
+	assert.Equal(suite.T(), "eu-west-1", nfsInstance.Status.Replica.Region)
+
+	_, _ = loadReplication(suite.ctx, state)
+	assert.NotNil(suite.T(), state.replication)
+
+	// replica is not in sync yet
+	err, _ = waitReplicationSynced(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(10*time.Second), err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationSyncing))
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationReady))
+	assert.Equal(suite.T(), ptr.Deref(state.replication.Destinations[0].FileSystemId, ""), nfsInstance.Status.Replica.Id)
+
+	awsMock.SetReplicationDestinationStatus(fsId, efsTypes.ReplicationStatusEnabled)
+	state.replication = nil
+	_, _ = loadReplication(suite.ctx, state)
+
+	err, _ = waitReplicationSynced(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationReady))
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationSyncing))
+
+	// replication already exists, so it is not created again
+	err, _ = createReplication(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *replicationSuite) TestReplicationError() {
+	state, awsMock := suite.newState()
+	nfsInstance := state.ObjAsNfsInstance()
+
+	_, _ = createReplication(suite.ctx, state)
+	awsMock.SetReplicationDestinationStatus(ptr.Deref(state.efs.FileSystemId, ""), efsTypes.ReplicationStatusError)
+	_, _ = loadReplication(suite.ctx, state)
+
+	err, _ := waitReplicationSynced(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(time.Minute), err)
+	cond := meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationReady)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), metav1.ConditionFalse, cond.Status)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonReplicationFailed, cond.Reason)
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationSyncing))
+}
+
+func (suite *replicationSuite) TestUpdateStatusKeepsReplicationConditions() {
+	state, awsMock := suite.newState()
+	nfsInstance := state.ObjAsNfsInstance()
+
+	_, _ = createReplication(suite.ctx, state)
+	awsMock.SetReplicationDestinationStatus(ptr.Deref(state.efs.FileSystemId, ""), efsTypes.ReplicationStatusEnabled)
+	_, _ = loadReplication(suite.ctx, state)
+	_, _ = waitReplicationSynced(suite.ctx, state)
+	_, _ = reconcileReplicaReadOnly(suite.ctx, state)
+	meta.SetStatusCondition(&nfsInstance.Status.Conditions, metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeError,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonFailedCreatingFileSystem,
+		Message: "failed",
+	})
+
+	err, _ := updateStatus(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReady))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationReady))
+	assert.True(suite.T(), meta.IsStatusConditionTrue(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly))
+	assert.Nil(suite.T(), meta.FindStatusCondition(nfsInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError))
+
+	// replication conditions are not changed by the next updateStatus, so they are not written again
+	err, _ = updateStatus(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestReplication(t *testing.T) {
+	suite.Run(t, new(replicationSuite))
+}
@@ -5,6 +5,11 @@ import (
 	"fmt"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	nfsinstancetypes "github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance/types"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
@@ -14,27 +19,42 @@ import (
 type State struct {
 	nfsinstancetypes.State
 
-	awsClient nfsinstanceclient.Client
+	awsClient     nfsinstanceclient.Client
+	replicaClient nfsinstanceclient.Client
+	// backupCopyClient is the client in the spec.backup.copyToRegion
+	backupCopyClient nfsinstanceclient.Client
+	// regionClients are the clients by the additional regions in the spec and in the status
+	regionClients map[string]nfsinstanceclient.Client
+	dnsClient     kcpdns.Client
 
 	efs                       *efsTypes.FileSystemDescription
 	mountTargets              []efsTypes.MountTargetDescription
 	mountTargetSecurityGroups map[string][]string
 	securityGroupId           string
 	securityGroup             *ec2Types.SecurityGroup
+	replication               *efsTypes.ReplicationConfigurationDescription
+
+	// tags are the validated tags put on the AWS resources created for the NfsInstance
+	tags map[string]string
 }
 
 type StateFactory interface {
 	NewState(ctx context.Context, nfsInstanceState nfsinstancetypes.State) (*State, error)
 }
 
-func NewStateFactory(skrProvider awsclient.SkrClientProvider[nfsinstanceclient.Client]) StateFactory {
+func NewStateFactory(
+	skrProvider awsclient.SkrClientProvider[nfsinstanceclient.Client],
+	dnsProvider awsclient.SkrClientProvider[kcpdns.Client],
+) StateFactory {
 	return &stateFactory{
 		skrProvider: skrProvider,
+		dnsProvider: dnsProvider,
 	}
 }
 
 type stateFactory struct {
 	skrProvider awsclient.SkrClientProvider[nfsinstanceclient.Client]
+	dnsProvider awsclient.SkrClientProvider[kcpdns.Client]
 }
 
 func (f *stateFactory) NewState(ctx context.Context, nfsInstanceState nfsinstancetypes.State) (*State, error) {
@@ -51,12 +71,154 @@ func (f *stateFactory) NewState(ctx context.Context, nfsInstanceState nfsinstanc
 		return nil, err
 	}
 
-	return newState(nfsInstanceState, c), nil
+	var replicaClient nfsinstanceclient.Client
+	if replicaRegion := getReplicaRegion(nfsInstanceState); len(replicaRegion) > 0 {
+		replicaClient, err = f.skrProvider(
+			ctx,
+			replicaRegion,
+			awsconfig.AwsConfig.Default.AccessKeyId,
+			awsconfig.AwsConfig.Default.SecretAccessKey,
+			roleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var backupCopyClient nfsinstanceclient.Client
+	if copyRegion := getBackupCopyRegion(nfsInstanceState.ObjAsNfsInstance()); len(copyRegion) > 0 {
+		backupCopyClient, err = f.skrProvider(
+			ctx,
+			copyRegion,
+			awsconfig.AwsConfig.Default.AccessKeyId,
+			awsconfig.AwsConfig.Default.SecretAccessKey,
+			roleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	regionClients := map[string]nfsinstanceclient.Client{}
+	for _, region := range getRegions(nfsInstanceState) {
+		regionClients[region], err = f.skrProvider(
+			ctx,
+			region,
+			awsconfig.AwsConfig.Default.AccessKeyId,
+			awsconfig.AwsConfig.Default.SecretAccessKey,
+			roleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dnsClient, err := f.dnsProvider(
+		ctx,
+		nfsInstanceState.Scope().Spec.Region,
+		awsconfig.AwsConfig.Default.AccessKeyId,
+		awsconfig.AwsConfig.Default.SecretAccessKey,
+		roleName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newState(nfsInstanceState, c, replicaClient)
+	state.backupCopyClient = backupCopyClient
+	state.regionClients = regionClients
+	state.dnsClient = dnsClient
+	return state, nil
 }
 
-func newState(nfsInstanceState nfsinstancetypes.State, c nfsinstanceclient.Client) *State {
+func newState(nfsInstanceState nfsinstancetypes.State, c nfsinstanceclient.Client, replicaClient nfsinstanceclient.Client) *State {
 	return &State{
-		State:     nfsInstanceState,
-		awsClient: c,
+		State:         nfsInstanceState,
+		awsClient:     c,
+		replicaClient: replicaClient,
+	}
+}
+
+// getReplicaRegion returns the region of the already provisioned replica, or if none exists
+// the region of the replica requested in the spec
+func getReplicaRegion(nfsInstanceState nfsinstancetypes.State) string {
+	nfsInstance := nfsInstanceState.ObjAsNfsInstance()
+	if nfsInstance.Status.Replica != nil && len(nfsInstance.Status.Replica.Region) > 0 {
+		return nfsInstance.Status.Replica.Region
+	}
+	if nfsInstance.Spec.Instance.Aws != nil && nfsInstance.Spec.Instance.Aws.Replication != nil {
+		return nfsInstance.Spec.Instance.Aws.Replication.Region
+	}
+	return ""
+}
+
+// getRegions returns the additional regions requested in the spec, and the regions the file
+// systems are already provisioned in, so the ones removed from the spec can be deleted
+func getRegions(nfsInstanceState nfsinstancetypes.State) []string {
+	nfsInstance := nfsInstanceState.ObjAsNfsInstance()
+	var result []string
+	if nfsInstance.Spec.Instance.Aws != nil {
+		result = append(result, nfsInstance.Spec.Instance.Aws.Regions...)
+	}
+	for _, rs := range nfsInstance.Status.Regions {
+		result = append(result, rs.Region)
+	}
+	return pie.Filter(pie.Sort(pie.Unique(result)), func(region string) bool {
+		return region != nfsInstanceState.Scope().Spec.Region
+	})
+}
+
+// isReplicationDesired returns true if the spec requests a replica in the same region
+// the existing replica, if any, is provisioned in, the failover is not requested,
+// and the NfsInstance is not being deleted
+func (s *State) isReplicationDesired() bool {
+	if composed.IsMarkedForDeletion(s.Obj()) {
+		return false
+	}
+	nfsInstance := s.ObjAsNfsInstance()
+	if nfsInstance.Spec.Instance.Aws == nil || nfsInstance.Spec.Instance.Aws.Replication == nil {
+		return false
+	}
+	if s.isFailoverRequested() {
+		return false
+	}
+	if nfsInstance.Status.Replica != nil && len(nfsInstance.Status.Replica.Region) > 0 &&
+		nfsInstance.Status.Replica.Region != nfsInstance.Spec.Instance.Aws.Replication.Region {
+		return false
+	}
+	return true
+}
+
+// isFailoverRequested returns true if the spec requests the replica to be promoted
+func (s *State) isFailoverRequested() bool {
+	nfsInstance := s.ObjAsNfsInstance()
+	return nfsInstance.Spec.Instance.Aws != nil &&
+		nfsInstance.Spec.Instance.Aws.Replication != nil &&
+		nfsInstance.Spec.Instance.Aws.Replication.Failover
+}
+
+// isWorkloadAccessDesired returns true if the spec requests the workload access role
+// and the NfsInstance is not being deleted
+func (s *State) isWorkloadAccessDesired() bool {
+	if composed.IsMarkedForDeletion(s.Obj()) {
+		return false
+	}
+	nfsInstance := s.ObjAsNfsInstance()
+	return nfsInstance.Spec.Instance.Aws != nil && nfsInstance.Spec.Instance.Aws.WorkloadAccess != nil
+}
+
+// getTags returns the tags put on the AWS resources created for the NfsInstance, the spec tags and the tags propagated
+// from the Scope included
+func getTags(state *State) map[string]string {
+	return common.TagsWithPropagated(cloudcontrolv1beta1.ProviderAws, state.Scope(), state.ObjAsNfsInstance().Spec.Tags, getOwnTags(state))
+}
+
+// getOwnTags returns the cloud-manager tags of the AWS resources created for the NfsInstance
+func getOwnTags(state *State) map[string]string {
+	return map[string]string{
+		common.TagCloudManagerName:       state.Name().String(),
+		common.TagCloudManagerRemoteName: state.ObjAsNfsInstance().Spec.RemoteRef.String(),
+		common.TagScope:                  state.ObjAsNfsInstance().Spec.Scope.Name,
+		common.TagShoot:                  state.Scope().Spec.ShootName,
 	}
 }
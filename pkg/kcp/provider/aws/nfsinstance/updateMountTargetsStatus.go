@@ -0,0 +1,52 @@
+package nfsinstance
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	efstypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/utils/ptr"
+)
+
+// updateMountTargetsStatus publishes the IP addresses of the available mount targets by zone. The mount
+// targets are created in the IpRange subnets with the IPv4 address only, see createMountTargets.
+func updateMountTargetsStatus(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	zoneBySubnetId := make(map[string]string, len(state.IpRange().Status.Subnets))
+	for _, subnet := range state.IpRange().Status.Subnets {
+		zoneBySubnetId[subnet.Id] = subnet.Zone
+	}
+
+	var mountTargets []cloudcontrolv1beta1.NfsMountTarget
+	for _, mt := range state.mountTargets {
+		if mt.LifeCycleState != efstypes.LifeCycleStateAvailable {
+			continue
+		}
+		zone, ok := zoneBySubnetId[ptr.Deref(mt.SubnetId, "")]
+		if !ok {
+			zone = ptr.Deref(mt.AvailabilityZoneName, "")
+		}
+		mountTargets = append(mountTargets, cloudcontrolv1beta1.NfsMountTarget{
+			Zone:      zone,
+			IpAddress: ptr.Deref(mt.IpAddress, ""),
+		})
+	}
+	sort.Slice(mountTargets, func(i, j int) bool {
+		return mountTargets[i].Zone < mountTargets[j].Zone
+	})
+
+	if reflect.DeepEqual(mountTargets, state.ObjAsNfsInstance().Status.MountTargets) {
+		return nil, nil
+	}
+
+	state.ObjAsNfsInstance().Status.MountTargets = mountTargets
+
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		ErrorLogMessage("Error updating KCP NfsInstance status with mount targets").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,70 @@
+package nfsinstance
+
+import (
+	"context"
+	"testing"
+
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestUpdateMountTargetsStatus(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	nfsInstance := &cloudcontrolv1beta1.NfsInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "nfs"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nfsInstance).
+		WithStatusSubresource(nfsInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: nfsInstance.Namespace, Name: nfsInstance.Name}, nfsInstance),
+	)
+	ipRange := &cloudcontrolv1beta1.IpRange{
+		Status: cloudcontrolv1beta1.IpRangeStatus{
+			Subnets: cloudcontrolv1beta1.IpRangeSubnets{
+				{Id: "subnet-b", Zone: "eu-west-1b", Range: "10.250.4.0/24"},
+				{Id: "subnet-a", Zone: "eu-west-1a", Range: "10.250.5.0/24"},
+			},
+		},
+	}
+
+	state := newState(&testNfsInstanceState{State: focalState, ipRange: ipRange}, awsmock.New(), nil)
+	state.mountTargets = []efsTypes.MountTargetDescription{
+		{SubnetId: ptr.To("subnet-b"), IpAddress: ptr.To("10.250.4.10"), LifeCycleState: efsTypes.LifeCycleStateAvailable},
+		{SubnetId: ptr.To("subnet-a"), IpAddress: ptr.To("10.250.5.10"), LifeCycleState: efsTypes.LifeCycleStateAvailable},
+		{SubnetId: ptr.To("subnet-other"), IpAddress: ptr.To("10.250.6.10"), LifeCycleState: efsTypes.LifeCycleStateDeleting},
+	}
+
+	err, _ := updateMountTargetsStatus(ctx, state)
+	assert.Nil(t, err)
+
+	expected := []cloudcontrolv1beta1.NfsMountTarget{
+		{Zone: "eu-west-1a", IpAddress: "10.250.5.10"},
+		{Zone: "eu-west-1b", IpAddress: "10.250.4.10"},
+	}
+	assert.Equal(t, expected, state.ObjAsNfsInstance().Status.MountTargets)
+
+	saved := &cloudcontrolv1beta1.NfsInstance{}
+	assert.NoError(t, clnt.Get(ctx, state.Name(), saved))
+	assert.Equal(t, expected, saved.Status.MountTargets)
+}
@@ -3,12 +3,23 @@ package nfsinstance
 import (
 	"context"
 	"fmt"
+	"reflect"
+
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
-	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
+// keptConditionTypes are the Ready condition and the conditions of the replication flow, that runs
+// after updateStatus and sets them again only when they change
+var keptConditionTypes = []string{
+	cloudcontrolv1beta1.ConditionTypeReady,
+	cloudcontrolv1beta1.ConditionTypeReplicationReady,
+	cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+	cloudcontrolv1beta1.ConditionTypeReplicationDestinationReadOnly,
+	cloudcontrolv1beta1.ConditionTypeReplicaPromoted,
+}
+
 func updateStatus(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 
@@ -16,32 +27,52 @@ func updateStatus(ctx context.Context, st composed.State) (error, context.Contex
 		return nil, nil
 	}
 
+	host := fmt.Sprintf(
+		"%s.efs.%s.amazonaws.com",
+		*state.efs.FileSystemId,
+		state.Scope().Spec.Region,
+	)
+	connection := newConnection(host, state.ObjAsNfsInstance().Status.MountTargets)
+	// the file system in the Scope region is ready, but the NfsInstance is Ready only with all regions ready
+	notReadyRegions := state.regionsNotReady()
+	readyCondition := regionsReadyCondition(notReadyRegions)
+
 	if len(state.ObjAsNfsInstance().Status.Id) > 0 &&
 		len(state.ObjAsNfsInstance().Status.Hosts) > 0 &&
 		len(state.ObjAsNfsInstance().Status.Hosts[0]) > 0 &&
-		meta.IsStatusConditionTrue(*state.ObjAsNfsInstance().Conditions(), cloudcontrolv1beta1.ConditionTypeReady) {
+		len(state.ObjAsNfsInstance().Status.StorageClass) > 0 &&
+		reflect.DeepEqual(state.ObjAsNfsInstance().Status.Connection, connection) &&
+		!composed.AnyConditionChanged(state.ObjAsNfsInstance(), readyCondition) {
 		// all already set and saved
 		return nil, nil
 	}
 
-	host := fmt.Sprintf(
-		"%s.efs.%s.amazonaws.com",
-		*state.efs.FileSystemId,
-		state.Scope().Spec.Region,
-	)
 	state.ObjAsNfsInstance().Status.Hosts = []string{host}
 	state.ObjAsNfsInstance().Status.Host = host
 	state.ObjAsNfsInstance().Status.Path = "/"
+	state.ObjAsNfsInstance().Status.Connection = connection
 
 	state.ObjAsNfsInstance().Status.Id = *state.efs.FileSystemId
 
+	state.ObjAsNfsInstance().Status.StorageClass = cloudcontrolv1beta1.AwsStorageClassRegional
+	state.ObjAsNfsInstance().Status.AvailabilityZone = ptr.Deref(state.efs.AvailabilityZoneName, "")
+	if len(state.ObjAsNfsInstance().Status.AvailabilityZone) > 0 {
+		state.ObjAsNfsInstance().Status.StorageClass = cloudcontrolv1beta1.AwsStorageClassOneZone
+	}
+
+	if len(notReadyRegions) > 0 {
+		// the flow continues to the regions reconciliation, that requeues until they are ready
+		return composed.UpdateStatus(state.ObjAsNfsInstance()).
+			SetCondition(readyCondition).
+			KeepConditions(keptConditionTypes...).
+			ErrorLogMessage("Error updating KCP NfsInstance status while regions are not ready").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
 	return composed.UpdateStatus(state.ObjAsNfsInstance()).
-		SetExclusiveConditions(metav1.Condition{
-			Type:    cloudcontrolv1beta1.ConditionTypeReady,
-			Status:  metav1.ConditionTrue,
-			Reason:  cloudcontrolv1beta1.ReasonReady,
-			Message: "NFS instance is ready",
-		}).
+		SetCondition(readyCondition).
+		KeepConditions(keptConditionTypes...).
 		ErrorLogMessage("Error updating KCP NfsInstance status after setting Ready condition").
 		SuccessLogMsg("KCP NfsInstance is ready").
 		SuccessError(composed.StopAndForget).
@@ -0,0 +1,113 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// validateMountTargetIps checks each of the spec.aws.mountTargetIps is within the IpRange subnet
+// of its zone, and is not one of the first four and the last subnet addresses reserved by AWS
+func validateMountTargetIps(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	nfsInstance := state.ObjAsNfsInstance()
+	if nfsInstance.Spec.Instance.Aws == nil || len(nfsInstance.Spec.Instance.Aws.MountTargetIps) == 0 {
+		return nil, nil
+	}
+
+	zones := make([]string, 0, len(nfsInstance.Spec.Instance.Aws.MountTargetIps))
+	for zone := range nfsInstance.Spec.Instance.Aws.MountTargetIps {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		ip := nfsInstance.Spec.Instance.Aws.MountTargetIps[zone]
+		if msg := validateMountTargetIp(state.IpRange().Status.Subnets, zone, ip); len(msg) > 0 {
+			return composed.UpdateStatus(nfsInstance).
+				SetExclusiveConditions(metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonInvalidMountTargetIp,
+					Message: msg,
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status with invalid mount target IP").
+				SuccessError(composed.StopAndForget).
+				Run(ctx, state)
+		}
+	}
+
+	return nil, nil
+}
+
+// validateExistingMountTargetIps checks the spec.aws.mountTargetIps of the zones with the existing mount
+// target equal its IP, since the IP of the mount target can not be changed. The IPs of the zones without
+// the mount target can be edited until it is created.
+func validateExistingMountTargetIps(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	nfsInstance := state.ObjAsNfsInstance()
+	if nfsInstance.Spec.Instance.Aws == nil || len(nfsInstance.Spec.Instance.Aws.MountTargetIps) == 0 {
+		return nil, nil
+	}
+
+	for _, mt := range state.mountTargets {
+		subnet := state.IpRange().Status.Subnets.SubnetById(ptr.Deref(mt.SubnetId, ""))
+		if subnet == nil {
+			continue
+		}
+		ip := mountTargetIp(nfsInstance, subnet.Zone)
+		if len(ip) == 0 || ip == ptr.Deref(mt.IpAddress, "") {
+			continue
+		}
+		return composed.UpdateStatus(nfsInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInvalidMountTargetIp,
+				Message: fmt.Sprintf("Mount target IP of zone %s can not be changed from %s to %s once the mount target exists", subnet.Zone, ptr.Deref(mt.IpAddress, ""), ip),
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with changed mount target IP").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	return nil, nil
+}
+
+func validateMountTargetIp(subnets cloudcontrolv1beta1.IpRangeSubnets, zone, ip string) string {
+	subnet := subnets.SubnetByZone(zone)
+	if subnet == nil {
+		return fmt.Sprintf("Mount target IP %s is specified for zone %s without IpRange subnet", ip, zone)
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.Is4() {
+		return fmt.Sprintf("Mount target IP %s of zone %s is not a valid IPv4 address", ip, zone)
+	}
+	prefix, err := netip.ParsePrefix(subnet.Range)
+	if err != nil {
+		return fmt.Sprintf("IpRange subnet %s of zone %s has invalid range %s", subnet.Id, zone, subnet.Range)
+	}
+	prefix = prefix.Masked()
+	if !prefix.Contains(addr) {
+		return fmt.Sprintf("Mount target IP %s is not within the zone %s subnet %s", ip, zone, prefix)
+	}
+
+	first := prefix.Addr()
+	for i := 0; i < 4; i++ {
+		if addr == first {
+			return fmt.Sprintf("Mount target IP %s is reserved by AWS in the zone %s subnet %s", ip, zone, prefix)
+		}
+		first = first.Next()
+	}
+	if !prefix.Contains(addr.Next()) {
+		return fmt.Sprintf("Mount target IP %s is reserved by AWS in the zone %s subnet %s", ip, zone, prefix)
+	}
+
+	return ""
+}
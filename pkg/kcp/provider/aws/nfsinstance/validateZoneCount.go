@@ -0,0 +1,23 @@
+package nfsinstance
+
+import (
+	"context"
+
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// validateZoneCount checks the EFS mount targets, created one per IpRange subnet,
+// are spread over enough zones. The One Zone file system is not highly available.
+var validateZoneCount = actions.ValidateZoneCount("NfsInstance", func(ctx context.Context, st composed.State) ([]string, bool) {
+	state := st.(*State)
+	if zone := oneZone(state.ObjAsNfsInstance()); len(zone) > 0 {
+		return []string{zone}, false
+	}
+	zones := pie.Unique(pie.Map(state.IpRange().Status.Subnets, func(s cloudcontrolv1beta1.IpRangeSubnet) string {
+		return s.Zone
+	}))
+	return zones, true
+})
@@ -4,9 +4,13 @@ import (
 	"context"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
 	"time"
 )
 
+// efsPollResourceType is the resource type of the EFS availability poll interval
+const efsPollResourceType = "AwsEfs"
+
 func waitEfsAvailable(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
@@ -17,6 +21,7 @@ func waitEfsAvailable(ctx context.Context, st composed.State) (error, context.Co
 
 	if state.efs.LifeCycleState == efsTypes.LifeCycleStateAvailable {
 		logger.Info("EFS state is Available")
+		composed.ResetPoll(state, efsPollResourceType)
 		return nil, nil
 	}
 
@@ -24,5 +29,8 @@ func waitEfsAvailable(ctx context.Context, st composed.State) (error, context.Co
 		WithValues("efsState", state.efs.LifeCycleState).
 		Info("Waiting EFS state to become Available")
 
-	return composed.StopWithRequeueDelay(time.Second), nil
+	return composed.StopWithPollRequeue(state, efsPollResourceType, composed.PollInterval{
+		Initial: time.Second,
+		Max:     util.Timing.T10000ms(),
+	}), nil
 }
@@ -12,6 +12,9 @@ import (
 	"time"
 )
 
+// mountTargetPollResourceType is the resource type of the EFS mount targets availability poll interval
+const mountTargetPollResourceType = "AwsMountTarget"
+
 func waitMountTargetsAvailable(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
@@ -49,7 +52,10 @@ func waitMountTargetsAvailable(ctx context.Context, st composed.State) (error, c
 
 		case util.Delay:
 			lll.Info("Waiting for mount target to be available")
-			return composed.StopWithRequeueDelay(300 * time.Millisecond), nil
+			return composed.StopWithPollRequeue(state, mountTargetPollResourceType, composed.PollInterval{
+				Initial: 300 * time.Millisecond,
+				Max:     util.Timing.T10000ms(),
+			}), nil
 
 		case util.Error:
 			lll.Info("Mount target in error state")
@@ -69,5 +75,6 @@ func waitMountTargetsAvailable(ctx context.Context, st composed.State) (error, c
 		} // switch
 	} // for
 
+	composed.ResetPoll(state, mountTargetPollResourceType)
 	return nil, nil
 }
@@ -0,0 +1,85 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"time"
+)
+
+func waitReplicationSynced(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replication == nil || !state.isReplicationDesired() {
+		return nil, nil
+	}
+	if len(state.replication.Destinations) == 0 {
+		return nil, nil
+	}
+
+	destination := state.replication.Destinations[0]
+	region := ptr.Deref(destination.Region, "")
+	replica := &cloudcontrolv1beta1.ReplicaStatus{
+		Region:   region,
+		Id:       ptr.Deref(destination.FileSystemId, ""),
+		Endpoint: fmt.Sprintf("%s.efs.%s.amazonaws.com", ptr.Deref(destination.FileSystemId, ""), region),
+		State:    string(destination.Status),
+	}
+	replicaChanged := ptr.Deref(state.ObjAsNfsInstance().Status.Replica, cloudcontrolv1beta1.ReplicaStatus{}) != *replica
+	state.ObjAsNfsInstance().Status.Replica = replica
+
+	if destination.Status == efsTypes.ReplicationStatusError {
+		return composed.UpdateStatus(state.ObjAsNfsInstance()).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+				Message: fmt.Sprintf("Replication to region %s is in error state", region),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating KCP NfsInstance status after replication error").
+			SuccessError(composed.StopWithRequeueDelay(time.Minute)).
+			Run(ctx, state)
+	}
+
+	if destination.Status != efsTypes.ReplicationStatusEnabled || destination.LastReplicatedTimestamp == nil {
+		logger.Info("Waiting AWS EFS replica to get in sync")
+		if !replicaChanged && meta.IsStatusConditionTrue(*state.ObjAsNfsInstance().Conditions(), cloudcontrolv1beta1.ConditionTypeReplicationSyncing) {
+			return composed.StopWithRequeueDelay(10 * time.Second), nil
+		}
+		return composed.UpdateStatus(state.ObjAsNfsInstance()).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationSyncing,
+				Message: fmt.Sprintf("Replication to region %s is syncing", region),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationReady).
+			ErrorLogMessage("Error updating KCP NfsInstance status with syncing replica").
+			SuccessError(composed.StopWithRequeueDelay(10*time.Second)).
+			Run(ctx, state)
+	}
+
+	if !replicaChanged && meta.IsStatusConditionTrue(*state.ObjAsNfsInstance().Conditions(), cloudcontrolv1beta1.ConditionTypeReplicationReady) {
+		return nil, nil
+	}
+
+	return composed.UpdateStatus(state.ObjAsNfsInstance()).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReplicationReady,
+			Message: fmt.Sprintf("Replica in region %s is ready", region),
+		}).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+		ErrorLogMessage("Error updating KCP NfsInstance status with ready replica").
+		SuccessLogMsg("KCP NfsInstance replica is ready").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,104 @@
+package nfsinstance
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+const workloadAccessPolicyName = "cm-efs-access"
+
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect    string                       `json:"Effect"`
+	Principal map[string]string            `json:"Principal,omitempty"`
+	Action    []string                     `json:"Action"`
+	Resource  string                       `json:"Resource,omitempty"`
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
+}
+
+func getWorkloadAccessRoleName(nfsInstance *cloudcontrolv1beta1.NfsInstance) string {
+	return fmt.Sprintf("cm-%s-workload", nfsInstance.Name)
+}
+
+// getOidcProviderIssuer validates that the OIDC provider arn belongs to the given account and
+// returns the issuer part of it, for example oidc.eks.region.amazonaws.com/id/XYZ
+func getOidcProviderIssuer(oidcProviderArn, accountId string) (string, error) {
+	prefix := fmt.Sprintf("arn:aws:iam::%s:oidc-provider/", accountId)
+	if !strings.HasPrefix(oidcProviderArn, prefix) {
+		return "", fmt.Errorf("OIDC provider %s is not an IAM OIDC provider of the account %s", oidcProviderArn, accountId)
+	}
+	issuer := strings.TrimPrefix(oidcProviderArn, prefix)
+	if len(issuer) == 0 {
+		return "", fmt.Errorf("OIDC provider %s has no issuer", oidcProviderArn)
+	}
+	return issuer, nil
+}
+
+// newWorkloadAccessTrustPolicy returns the trust policy allowing only the given service account
+// to assume the role through the OIDC provider
+func newWorkloadAccessTrustPolicy(options *cloudcontrolv1beta1.WorkloadAccessOptions, issuer string) (string, error) {
+	doc := policyDocument{
+		Version: "2012-10-17",
+		Statement: []policyStatement{
+			{
+				Effect:    "Allow",
+				Principal: map[string]string{"Federated": options.OidcProviderArn},
+				Action:    []string{"sts:AssumeRoleWithWebIdentity"},
+				Condition: map[string]map[string]string{
+					"StringEquals": {
+						issuer + ":sub": fmt.Sprintf("system:serviceaccount:%s:%s", options.ServiceAccountNamespace, options.ServiceAccountName),
+						issuer + ":aud": "sts.amazonaws.com",
+					},
+				},
+			},
+		},
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// newWorkloadAccessPolicy returns the least privilege policy allowing only NFS client access
+// to the given file system
+func newWorkloadAccessPolicy(fileSystemArn string) (string, error) {
+	doc := policyDocument{
+		Version: "2012-10-17",
+		Statement: []policyStatement{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"elasticfilesystem:ClientMount",
+					"elasticfilesystem:ClientWrite",
+				},
+				Resource: fileSystemArn,
+			},
+		},
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// policyDocumentsEqual compares two policy documents ignoring formatting and key order
+func policyDocumentsEqual(a, b string) bool {
+	var aa, bb any
+	if err := json.Unmarshal([]byte(a), &aa); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aa, bb)
+}
@@ -0,0 +1,57 @@
+package nfsinstance
+
+import (
+	"encoding/json"
+	"testing"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOidcProviderIssuer(t *testing.T) {
+	issuer, err := getOidcProviderIssuer("arn:aws:iam::111122223333:oidc-provider/oidc.eks.eu-west-1.amazonaws.com/id/ABC", "111122223333")
+	assert.NoError(t, err)
+	assert.Equal(t, "oidc.eks.eu-west-1.amazonaws.com/id/ABC", issuer)
+
+	_, err = getOidcProviderIssuer("arn:aws:iam::444455556666:oidc-provider/oidc.eks.eu-west-1.amazonaws.com/id/ABC", "111122223333")
+	assert.Error(t, err, "provider from other account")
+
+	_, err = getOidcProviderIssuer("arn:aws:iam::111122223333:oidc-provider/", "111122223333")
+	assert.Error(t, err, "provider without issuer")
+
+	_, err = getOidcProviderIssuer("arn:aws:iam::111122223333:role/foo", "111122223333")
+	assert.Error(t, err, "not an OIDC provider")
+}
+
+func TestNewWorkloadAccessTrustPolicy(t *testing.T) {
+	options := &cloudcontrolv1beta1.WorkloadAccessOptions{
+		OidcProviderArn:         "arn:aws:iam::111122223333:oidc-provider/oidc.example.com/id/ABC",
+		ServiceAccountNamespace: "ns",
+		ServiceAccountName:      "sa",
+	}
+	doc, err := newWorkloadAccessTrustPolicy(options, "oidc.example.com/id/ABC")
+	assert.NoError(t, err)
+
+	var parsed policyDocument
+	assert.NoError(t, json.Unmarshal([]byte(doc), &parsed))
+	assert.Len(t, parsed.Statement, 1)
+	assert.Equal(t, options.OidcProviderArn, parsed.Statement[0].Principal["Federated"])
+	assert.Equal(t, []string{"sts:AssumeRoleWithWebIdentity"}, parsed.Statement[0].Action)
+	assert.Equal(t, "system:serviceaccount:ns:sa", parsed.Statement[0].Condition["StringEquals"]["oidc.example.com/id/ABC:sub"])
+}
+
+func TestNewWorkloadAccessPolicyIsScopedToFileSystem(t *testing.T) {
+	doc, err := newWorkloadAccessPolicy("arn:aws:elasticfilesystem:eu-west-1:111122223333:file-system/fs-1")
+	assert.NoError(t, err)
+
+	var parsed policyDocument
+	assert.NoError(t, json.Unmarshal([]byte(doc), &parsed))
+	assert.Len(t, parsed.Statement, 1)
+	assert.Equal(t, "arn:aws:elasticfilesystem:eu-west-1:111122223333:file-system/fs-1", parsed.Statement[0].Resource)
+}
+
+func TestPolicyDocumentsEqual(t *testing.T) {
+	assert.True(t, policyDocumentsEqual(`{"a": 1, "b": [2]}`, `{"b":[2],"a":1}`))
+	assert.False(t, policyDocumentsEqual(`{"a": 1}`, `{"a": 2}`))
+	assert.False(t, policyDocumentsEqual(``, `{"a": 1}`))
+}
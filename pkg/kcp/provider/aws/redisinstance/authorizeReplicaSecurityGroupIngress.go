@@ -0,0 +1,29 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+// authorizeReplicaSecurityGroupIngress allows the same clients to connect to the replica as to the primary
+func authorizeReplicaSecurityGroupIngress(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if !state.IsReplicationDesired() || state.replicaSecurityGroup == nil {
+		return nil, nil
+	}
+
+	permissions := missingIngressPermissions(ctx, state, state.replicaSecurityGroup)
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+
+	err := state.replicaClient.AuthorizeElastiCacheSecurityGroupIngress(ctx, ptr.Deref(state.replicaSecurityGroup.GroupId, ""), permissions)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error adding replica security group ingress", ctx)
+	}
+
+	return composed.StopWithRequeue, nil
+}
@@ -11,6 +11,23 @@ import (
 
 func authorizeSecurityGroupIngress(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
+
+	permissions := missingIngressPermissions(ctx, state, state.securityGroup)
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+
+	err := state.awsClient.AuthorizeElastiCacheSecurityGroupIngress(ctx, state.securityGroupId, permissions)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error adding security group ingress", ctx)
+	}
+
+	return composed.StopWithRequeue, nil
+}
+
+// missingIngressPermissions returns the permissions of the Redis port from the Scope pods and vpc cidrs
+// the security group does not have yet
+func missingIngressPermissions(ctx context.Context, state *State, securityGroup *ec2Types.SecurityGroup) []ec2Types.IpPermission {
 	logger := composed.LoggerFromCtx(ctx)
 
 	toPort := int32(6379)
@@ -18,7 +35,7 @@ func authorizeSecurityGroupIngress(ctx context.Context, st composed.State) (erro
 	hasPods := state.Scope().Spec.Scope.Aws.Network.Pods == ""
 	hasNet := state.Scope().Spec.Scope.Aws.Network.VPC.CIDR == ""
 
-	for _, perm := range state.securityGroup.IpPermissions {
+	for _, perm := range securityGroup.IpPermissions {
 		if ptr.Deref(perm.ToPort, 0) != toPort {
 			continue
 		}
@@ -34,7 +51,7 @@ func authorizeSecurityGroupIngress(ctx context.Context, st composed.State) (erro
 			}
 		}
 		if hasPods && hasNet {
-			return nil, nil
+			return nil
 		}
 	}
 
@@ -67,14 +84,5 @@ func authorizeSecurityGroupIngress(ctx context.Context, st composed.State) (erro
 		})
 	}
 
-	if len(permissions) == 0 {
-		return nil, nil
-	}
-
-	err := state.awsClient.AuthorizeElastiCacheSecurityGroupIngress(ctx, state.securityGroupId, permissions)
-	if err != nil {
-		return awsmeta.LogErrorAndReturn(err, "Error adding security group ingress", ctx)
-	}
-
-	return composed.StopWithRequeue, nil
+	return permissions
 }
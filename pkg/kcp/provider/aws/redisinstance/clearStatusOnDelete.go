@@ -0,0 +1,17 @@
+package redisinstance
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// clearStatusOnDelete resets the status fields that are no longer valid once the cluster is deleted
+func clearStatusOnDelete(obj composed.ObjWithConditions) {
+	redisInstance := obj.(*cloudcontrolv1beta1.RedisInstance)
+	redisInstance.Status.PrimaryEndpoint = ""
+	redisInstance.Status.ReadEndpoint = ""
+	redisInstance.Status.AuthString = ""
+	redisInstance.Status.CaCert = ""
+	redisInstance.Status.Replica = nil
+	redisInstance.Status.SubnetGroupName = ""
+}
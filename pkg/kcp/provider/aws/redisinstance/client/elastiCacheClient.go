@@ -4,15 +4,23 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cloudwatchlogsTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	elasticache "github.com/aws/aws-sdk-go-v2/service/elasticache"
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	firehoseTypes "github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	secretsmanagerTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/google/uuid"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
@@ -31,10 +39,21 @@ func NewClientProvider() awsclient.SkrClientProvider[ElastiCacheClient] {
 			ec2.NewFromConfig(cfg),
 			elasticache.NewFromConfig(cfg),
 			secretsmanager.NewFromConfig(cfg),
+			cloudwatchlogs.NewFromConfig(cfg),
+			firehose.NewFromConfig(cfg),
+			iam.NewFromConfig(cfg),
+			sts.NewFromConfig(cfg),
 		), nil
 	}
 }
 
+// CreateElastiCacheSecondaryReplicationGroupOptions are the network options of the secondary replication group
+// of the global datastore, that are in the region of the secondary replication group
+type CreateElastiCacheSecondaryReplicationGroupOptions struct {
+	SubnetGroupName  string
+	SecurityGroupIds []string
+}
+
 type CreateElastiCacheClusterOptions struct {
 	Name                       string
 	SubnetGroupName            string
@@ -46,6 +65,10 @@ type CreateElastiCacheClusterOptions struct {
 	TransitEncryptionEnabled   bool
 	PreferredMaintenanceWindow *string
 	SecurityGroupIds           []string
+	DataTieringEnabled         bool
+	// Shards if set creates the cluster mode enabled replication group with that many node groups
+	Shards           *int32
+	ReplicasPerShard int32
 }
 
 type ModifyElastiCacheClusterOptions struct {
@@ -58,11 +81,17 @@ type ModifyElastiCacheClusterOptions struct {
 	AuthTokenSecretString      *string
 	UserGroupIdsToAdd          []string
 	UserGroupIdsToRemove       []string
+	LogDeliveryConfigurations  []elasticacheTypes.LogDeliveryConfigurationRequest
+	// ApplyImmediately if false defers the modifications to the next maintenance window,
+	// if not set they are applied immediately
+	ApplyImmediately *bool
 }
 
 type ElastiCacheClient interface {
 	DescribeElastiCacheSubnetGroup(ctx context.Context, name string) ([]elasticacheTypes.CacheSubnetGroup, error)
 	CreateElastiCacheSubnetGroup(ctx context.Context, name string, subnetIds []string, tags []elasticacheTypes.Tag) (*elasticache.CreateCacheSubnetGroupOutput, error)
+	// ModifyElastiCacheSubnetGroup replaces all the subnets of the subnet group with the given ones
+	ModifyElastiCacheSubnetGroup(ctx context.Context, name string, subnetIds []string) error
 	DeleteElastiCacheSubnetGroup(ctx context.Context, name string) error
 
 	DescribeElastiCacheParameterGroup(ctx context.Context, name string) ([]elasticacheTypes.CacheParameterGroup, error)
@@ -80,7 +109,23 @@ type ElastiCacheClient interface {
 	CreateElastiCacheReplicationGroup(ctx context.Context, tags []elasticacheTypes.Tag, options CreateElastiCacheClusterOptions) (*elasticache.CreateReplicationGroupOutput, error)
 	ModifyElastiCacheReplicationGroup(ctx context.Context, id string, options ModifyElastiCacheClusterOptions) (*elasticache.ModifyReplicationGroupOutput, error)
 	DeleteElastiCacheReplicationGroup(ctx context.Context, id string) error
+	// ModifyElastiCacheReplicationGroupShardConfiguration reshards the cluster mode replication group to the given
+	// number of node groups, removing the given node groups when scaling in
+	ModifyElastiCacheReplicationGroupShardConfiguration(ctx context.Context, id string, nodeGroupCount int32, nodeGroupsToRemove []string) error
+	// ModifyElastiCacheReplicaCount changes the number of the replicas in each node group of the replication group
+	ModifyElastiCacheReplicaCount(ctx context.Context, id string, currentReplicaCount, newReplicaCount int32) error
 	DescribeElastiCacheCluster(ctx context.Context, id string) ([]elasticacheTypes.CacheCluster, error)
+	// ListElastiCacheTags returns the tags of the ElastiCache resource with the given ARN
+	ListElastiCacheTags(ctx context.Context, arn string) ([]elasticacheTypes.Tag, error)
+	// AddElastiCacheTags adds the tags to the ElastiCache resource with the given ARN, replacing the values of the existing ones
+	AddElastiCacheTags(ctx context.Context, arn string, tags []elasticacheTypes.Tag) error
+	RemoveElastiCacheTags(ctx context.Context, arn string, keys []string) error
+	CreateElastiCacheSecondaryReplicationGroup(ctx context.Context, globalReplicationGroupId, name string, tags []elasticacheTypes.Tag, options CreateElastiCacheSecondaryReplicationGroupOptions) (*elasticache.CreateReplicationGroupOutput, error)
+
+	DescribeGlobalReplicationGroup(ctx context.Context, id string) (*elasticacheTypes.GlobalReplicationGroup, error)
+	CreateGlobalReplicationGroup(ctx context.Context, idSuffix, primaryReplicationGroupId string) (*elasticache.CreateGlobalReplicationGroupOutput, error)
+	DisassociateGlobalReplicationGroup(ctx context.Context, id, replicationGroupId, replicationGroupRegion string) error
+	DeleteGlobalReplicationGroup(ctx context.Context, id string) error
 
 	DescribeUserGroup(ctx context.Context, id string) (*elasticacheTypes.UserGroup, error)
 	CreateUserGroup(ctx context.Context, id string, tags []elasticacheTypes.Tag) (*elasticache.CreateUserGroupOutput, error)
@@ -90,13 +135,33 @@ type ElastiCacheClient interface {
 	CreateElastiCacheSecurityGroup(ctx context.Context, vpcId, name string, tags []ec2Types.Tag) (string, error)
 	DeleteElastiCacheSecurityGroup(ctx context.Context, id string) error
 	AuthorizeElastiCacheSecurityGroupIngress(ctx context.Context, groupId string, ipPermissions []ec2Types.IpPermission) error
+
+	// DescribeLogGroup returns the CloudWatch log group with the given name, or nil if it does not exist
+	DescribeLogGroup(ctx context.Context, name string) (*cloudwatchlogsTypes.LogGroup, error)
+	// DescribeDeliveryStream returns the Kinesis Data Firehose delivery stream with the given name, or nil if it does not exist
+	DescribeDeliveryStream(ctx context.Context, name string) (*firehoseTypes.DeliveryStreamDescription, error)
+	// GetDeniedActions simulates the IAM policies of the cloud-manager principal and returns the given actions
+	// it is not allowed to call on the resource with the given ARN
+	GetDeniedActions(ctx context.Context, resourceArn string, actionNames []string) ([]string, error)
 }
 
-func newClient(ec2Svc *ec2.Client, elastiCacheSvc *elasticache.Client, secretsManagerSvc *secretsmanager.Client) ElastiCacheClient {
+func newClient(
+	ec2Svc *ec2.Client,
+	elastiCacheSvc *elasticache.Client,
+	secretsManagerSvc *secretsmanager.Client,
+	cloudWatchLogsSvc *cloudwatchlogs.Client,
+	firehoseSvc *firehose.Client,
+	iamSvc *iam.Client,
+	stsSvc *sts.Client,
+) ElastiCacheClient {
 	return &client{
 		ec2Svc:            ec2Svc,
 		elastiCacheSvc:    elastiCacheSvc,
 		secretsManagerSvc: secretsManagerSvc,
+		cloudWatchLogsSvc: cloudWatchLogsSvc,
+		firehoseSvc:       firehoseSvc,
+		iamSvc:            iamSvc,
+		stsSvc:            stsSvc,
 	}
 }
 
@@ -104,6 +169,10 @@ type client struct {
 	ec2Svc            *ec2.Client
 	elastiCacheSvc    *elasticache.Client
 	secretsManagerSvc *secretsmanager.Client
+	cloudWatchLogsSvc *cloudwatchlogs.Client
+	firehoseSvc       *firehose.Client
+	iamSvc            *iam.Client
+	stsSvc            *sts.Client
 }
 
 func (c *client) DescribeElastiCacheSubnetGroup(ctx context.Context, name string) ([]elasticacheTypes.CacheSubnetGroup, error) {
@@ -137,6 +206,14 @@ func (c *client) CreateElastiCacheSubnetGroup(ctx context.Context, name string,
 	return out, nil
 }
 
+func (c *client) ModifyElastiCacheSubnetGroup(ctx context.Context, name string, subnetIds []string) error {
+	_, err := c.elastiCacheSvc.ModifyCacheSubnetGroup(ctx, &elasticache.ModifyCacheSubnetGroupInput{
+		CacheSubnetGroupName: ptr.To(name),
+		SubnetIds:            subnetIds,
+	})
+	return err
+}
+
 func (c *client) DeleteElastiCacheSubnetGroup(ctx context.Context, name string) error {
 	_, err := c.elastiCacheSvc.DeleteCacheSubnetGroup(ctx, &elasticache.DeleteCacheSubnetGroupInput{
 		CacheSubnetGroupName: ptr.To(name),
@@ -334,6 +411,16 @@ func (c *client) CreateElastiCacheReplicationGroup(ctx context.Context, tags []e
 		SecurityGroupIds:            options.SecurityGroupIds,
 		Tags:                        tags,
 	}
+	if options.DataTieringEnabled {
+		params.DataTieringEnabled = aws.Bool(true)
+	}
+	if options.Shards != nil {
+		params.NumCacheClusters = nil
+		params.NumNodeGroups = options.Shards
+		params.ReplicasPerNodeGroup = aws.Int32(options.ReplicasPerShard)
+		params.ClusterMode = elasticacheTypes.ClusterModeEnabled
+		params.AutomaticFailoverEnabled = aws.Bool(true)
+	}
 	res, err := c.elastiCacheSvc.CreateReplicationGroup(ctx, params)
 
 	if err != nil {
@@ -346,7 +433,7 @@ func (c *client) CreateElastiCacheReplicationGroup(ctx context.Context, tags []e
 func (c *client) ModifyElastiCacheReplicationGroup(ctx context.Context, id string, options ModifyElastiCacheClusterOptions) (*elasticache.ModifyReplicationGroupOutput, error) {
 	params := &elasticache.ModifyReplicationGroupInput{
 		ReplicationGroupId: aws.String(id),
-		ApplyImmediately:   aws.Bool(true),
+		ApplyImmediately:   aws.Bool(ptr.Deref(options.ApplyImmediately, true)),
 	}
 	if options.CacheNodeType != nil {
 		params.CacheNodeType = options.CacheNodeType
@@ -376,6 +463,9 @@ func (c *client) ModifyElastiCacheReplicationGroup(ctx context.Context, id strin
 	if len(options.UserGroupIdsToRemove) > 0 {
 		params.UserGroupIdsToRemove = options.UserGroupIdsToRemove
 	}
+	if len(options.LogDeliveryConfigurations) > 0 {
+		params.LogDeliveryConfigurations = options.LogDeliveryConfigurations
+	}
 
 	res, err := c.elastiCacheSvc.ModifyReplicationGroup(ctx, params)
 
@@ -397,6 +487,33 @@ func (c *client) DeleteElastiCacheReplicationGroup(ctx context.Context, id strin
 	return err
 }
 
+func (c *client) ModifyElastiCacheReplicationGroupShardConfiguration(ctx context.Context, id string, nodeGroupCount int32, nodeGroupsToRemove []string) error {
+	_, err := c.elastiCacheSvc.ModifyReplicationGroupShardConfiguration(ctx, &elasticache.ModifyReplicationGroupShardConfigurationInput{
+		ReplicationGroupId: aws.String(id),
+		NodeGroupCount:     aws.Int32(nodeGroupCount),
+		NodeGroupsToRemove: nodeGroupsToRemove,
+		ApplyImmediately:   aws.Bool(true),
+	})
+	return err
+}
+
+func (c *client) ModifyElastiCacheReplicaCount(ctx context.Context, id string, currentReplicaCount, newReplicaCount int32) error {
+	if newReplicaCount > currentReplicaCount {
+		_, err := c.elastiCacheSvc.IncreaseReplicaCount(ctx, &elasticache.IncreaseReplicaCountInput{
+			ReplicationGroupId: aws.String(id),
+			NewReplicaCount:    aws.Int32(newReplicaCount),
+			ApplyImmediately:   aws.Bool(true),
+		})
+		return err
+	}
+	_, err := c.elastiCacheSvc.DecreaseReplicaCount(ctx, &elasticache.DecreaseReplicaCountInput{
+		ReplicationGroupId: aws.String(id),
+		NewReplicaCount:    aws.Int32(newReplicaCount),
+		ApplyImmediately:   aws.Bool(true),
+	})
+	return err
+}
+
 func (c *client) DescribeElastiCacheCluster(ctx context.Context, id string) ([]elasticacheTypes.CacheCluster, error) {
 	out, err := c.elastiCacheSvc.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{
 		CacheClusterId: ptr.To(id),
@@ -411,6 +528,76 @@ func (c *client) DescribeElastiCacheCluster(ctx context.Context, id string) ([]e
 	return out.CacheClusters, nil
 }
 
+func (c *client) CreateElastiCacheSecondaryReplicationGroup(ctx context.Context, globalReplicationGroupId, name string, tags []elasticacheTypes.Tag, options CreateElastiCacheSecondaryReplicationGroupOptions) (*elasticache.CreateReplicationGroupOutput, error) {
+	params := &elasticache.CreateReplicationGroupInput{
+		ReplicationGroupId:          aws.String(name),
+		ReplicationGroupDescription: aws.String("ElastiCache replica managed by Kyma Cloud Manager"),
+		GlobalReplicationGroupId:    aws.String(globalReplicationGroupId),
+		NumCacheClusters:            aws.Int32(1),
+		CacheSubnetGroupName:        aws.String(options.SubnetGroupName),
+		SecurityGroupIds:            options.SecurityGroupIds,
+		Tags:                        tags,
+	}
+	res, err := c.elastiCacheSvc.CreateReplicationGroup(ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *client) DescribeGlobalReplicationGroup(ctx context.Context, id string) (*elasticacheTypes.GlobalReplicationGroup, error) {
+	out, err := c.elastiCacheSvc.DescribeGlobalReplicationGroups(ctx, &elasticache.DescribeGlobalReplicationGroupsInput{
+		GlobalReplicationGroupId: ptr.To(id),
+		ShowMemberInfo:           aws.Bool(true),
+	})
+
+	if err != nil {
+		if awsmeta.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(out.GlobalReplicationGroups) == 0 {
+		return nil, nil
+	}
+	return &out.GlobalReplicationGroups[0], nil
+}
+
+func (c *client) CreateGlobalReplicationGroup(ctx context.Context, idSuffix, primaryReplicationGroupId string) (*elasticache.CreateGlobalReplicationGroupOutput, error) {
+	res, err := c.elastiCacheSvc.CreateGlobalReplicationGroup(ctx, &elasticache.CreateGlobalReplicationGroupInput{
+		GlobalReplicationGroupIdSuffix:    aws.String(idSuffix),
+		PrimaryReplicationGroupId:         aws.String(primaryReplicationGroupId),
+		GlobalReplicationGroupDescription: aws.String("ElastiCache global datastore managed by Kyma Cloud Manager"),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *client) DisassociateGlobalReplicationGroup(ctx context.Context, id, replicationGroupId, replicationGroupRegion string) error {
+	_, err := c.elastiCacheSvc.DisassociateGlobalReplicationGroup(ctx, &elasticache.DisassociateGlobalReplicationGroupInput{
+		GlobalReplicationGroupId: aws.String(id),
+		ReplicationGroupId:       aws.String(replicationGroupId),
+		ReplicationGroupRegion:   aws.String(replicationGroupRegion),
+	})
+
+	return err
+}
+
+func (c *client) DeleteGlobalReplicationGroup(ctx context.Context, id string) error {
+	_, err := c.elastiCacheSvc.DeleteGlobalReplicationGroup(ctx, &elasticache.DeleteGlobalReplicationGroupInput{
+		GlobalReplicationGroupId:      aws.String(id),
+		RetainPrimaryReplicationGroup: aws.Bool(true),
+	})
+
+	return err
+}
+
 func (c *client) DescribeUserGroup(ctx context.Context, id string) (*elasticacheTypes.UserGroup, error) {
 	res, err := c.elastiCacheSvc.DescribeUserGroups(ctx, &elasticache.DescribeUserGroupsInput{
 		UserGroupId: ptr.To(id),
@@ -445,6 +632,33 @@ func (c *client) CreateUserGroup(ctx context.Context, id string, tags []elastica
 	return res, nil
 }
 
+func (c *client) ListElastiCacheTags(ctx context.Context, arn string) ([]elasticacheTypes.Tag, error) {
+	out, err := c.elastiCacheSvc.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{
+		ResourceName: ptr.To(arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.TagList, nil
+}
+
+func (c *client) AddElastiCacheTags(ctx context.Context, arn string, tags []elasticacheTypes.Tag) error {
+	_, err := c.elastiCacheSvc.AddTagsToResource(ctx, &elasticache.AddTagsToResourceInput{
+		ResourceName: ptr.To(arn),
+		Tags:         tags,
+	})
+	return err
+}
+
+func (c *client) RemoveElastiCacheTags(ctx context.Context, arn string, keys []string) error {
+	_, err := c.elastiCacheSvc.RemoveTagsFromResource(ctx, &elasticache.RemoveTagsFromResourceInput{
+		ResourceName: ptr.To(arn),
+		TagKeys:      keys,
+	})
+	return err
+}
+
 func (c *client) DeleteUserGroup(ctx context.Context, id string) error {
 	_, err := c.elastiCacheSvc.DeleteUserGroup(ctx, &elasticache.DeleteUserGroupInput{
 		UserGroupId: ptr.To(id),
@@ -504,3 +718,82 @@ func (c *client) AuthorizeElastiCacheSecurityGroupIngress(ctx context.Context, g
 	}
 	return nil
 }
+
+func (c *client) DescribeLogGroup(ctx context.Context, name string) (*cloudwatchlogsTypes.LogGroup, error) {
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(c.cloudWatchLogsSvc, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: ptr.To(name),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, logGroup := range page.LogGroups {
+			if ptr.Deref(logGroup.LogGroupName, "") == name {
+				return ptr.To(logGroup), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (c *client) DescribeDeliveryStream(ctx context.Context, name string) (*firehoseTypes.DeliveryStreamDescription, error) {
+	out, err := c.firehoseSvc.DescribeDeliveryStream(ctx, &firehose.DescribeDeliveryStreamInput{
+		DeliveryStreamName: ptr.To(name),
+	})
+	if err != nil {
+		if awsmeta.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return out.DeliveryStreamDescription, nil
+}
+
+func (c *client) GetDeniedActions(ctx context.Context, resourceArn string, actionNames []string) ([]string, error) {
+	principalArn, err := c.getPrincipalArn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var denied []string
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(c.iamSvc, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: ptr.To(principalArn),
+		ActionNames:     actionNames,
+		ResourceArns:    []string{resourceArn},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range page.EvaluationResults {
+			if result.EvalDecision != iamTypes.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, ptr.Deref(result.EvalActionName, ""))
+			}
+		}
+	}
+	return denied, nil
+}
+
+// getPrincipalArn returns the ARN of the IAM user or role of the caller, since the policies of the
+// assumed role session can not be simulated
+func (c *client) getPrincipalArn(ctx context.Context) (string, error) {
+	identity, err := c.stsSvc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	callerArn := ptr.Deref(identity.Arn, "")
+
+	// arn:aws:sts::123456789012:assumed-role/RoleName/SessionName
+	parts := strings.Split(callerArn, ":")
+	if len(parts) < 6 || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return callerArn, nil
+	}
+	roleName := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")[0]
+	role, err := c.iamSvc.GetRole(ctx, &iam.GetRoleInput{RoleName: ptr.To(roleName)})
+	if err != nil {
+		return "", err
+	}
+	return ptr.Deref(role.Role.Arn, ""), nil
+}
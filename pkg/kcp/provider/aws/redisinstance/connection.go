@@ -0,0 +1,30 @@
+package redisinstance
+
+import (
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+func newConnection(replicationGroup *elasticacheTypes.ReplicationGroup) *cloudcontrolv1beta1.RedisConnection {
+	connection := &cloudcontrolv1beta1.RedisConnection{
+		Tls:         ptr.Deref(replicationGroup.TransitEncryptionEnabled, false),
+		ClusterMode: ptr.Deref(replicationGroup.ClusterEnabled, false),
+	}
+	if connection.ClusterMode {
+		connection.Host, connection.Port = endpointHostPort(replicationGroup.ConfigurationEndpoint)
+		return connection
+	}
+	if len(replicationGroup.NodeGroups) > 0 {
+		connection.Host, connection.Port = endpointHostPort(replicationGroup.NodeGroups[0].PrimaryEndpoint)
+		connection.ReadHost, connection.ReadPort = endpointHostPort(replicationGroup.NodeGroups[0].ReaderEndpoint)
+	}
+	return connection
+}
+
+func endpointHostPort(endpoint *elasticacheTypes.Endpoint) (string, int32) {
+	if endpoint == nil {
+		return "", 0
+	}
+	return ptr.Deref(endpoint.Address, ""), ptr.Deref(endpoint.Port, 0)
+}
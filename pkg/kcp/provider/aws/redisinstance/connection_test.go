@@ -0,0 +1,43 @@
+package redisinstance
+
+import (
+	"testing"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewConnection(t *testing.T) {
+	t.Run("primary and reader endpoints", func(t *testing.T) {
+		connection := newConnection(&elasticacheTypes.ReplicationGroup{
+			TransitEncryptionEnabled: ptr.To(true),
+			NodeGroups: []elasticacheTypes.NodeGroup{{
+				PrimaryEndpoint: &elasticacheTypes.Endpoint{Address: ptr.To("primary.cache.amazonaws.com"), Port: ptr.To(int32(6379))},
+				ReaderEndpoint:  &elasticacheTypes.Endpoint{Address: ptr.To("reader.cache.amazonaws.com"), Port: ptr.To(int32(6379))},
+			}},
+		})
+
+		assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{
+			Host:     "primary.cache.amazonaws.com",
+			Port:     6379,
+			ReadHost: "reader.cache.amazonaws.com",
+			ReadPort: 6379,
+			Tls:      true,
+		}, connection)
+	})
+
+	t.Run("configuration endpoint in the cluster mode", func(t *testing.T) {
+		connection := newConnection(&elasticacheTypes.ReplicationGroup{
+			ClusterEnabled:        ptr.To(true),
+			ConfigurationEndpoint: &elasticacheTypes.Endpoint{Address: ptr.To("config.cache.amazonaws.com"), Port: ptr.To(int32(6379))},
+		})
+
+		assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{
+			Host:        "config.cache.amazonaws.com",
+			Port:        6379,
+			ClusterMode: true,
+		}, connection)
+	})
+}
@@ -0,0 +1,37 @@
+package redisinstance
+
+import (
+	"context"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/pricing"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileCostEstimate estimates the cost of the provisioned ElastiCache nodes, including
+// the nodes of the replica in the other region if any
+var reconcileCostEstimate = actions.ReconcileCostEstimate(estimateCost)
+
+func estimateCost(ctx context.Context, st composed.State) (string, bool) {
+	state := st.(*State)
+	if state.elastiCacheReplicationGroup == nil {
+		return "", false
+	}
+
+	total := 0.0
+	for _, rg := range []*elasticacheTypes.ReplicationGroup{state.elastiCacheReplicationGroup, state.replicaReplicationGroup} {
+		if rg == nil {
+			continue
+		}
+		nodeType := ptr.Deref(rg.CacheNodeType, state.ObjAsRedisInstance().Spec.Instance.Aws.CacheNodeType)
+		cost, ok := pricing.AwsElastiCacheMonthly(nodeType, max(len(rg.MemberClusters), 1))
+		if !ok {
+			return "", false
+		}
+		total += cost
+	}
+
+	return pricing.Format(total), true
+}
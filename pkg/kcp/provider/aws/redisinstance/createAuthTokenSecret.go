@@ -3,13 +3,8 @@ package redisinstance
 import (
 	"context"
 
-	secretsmanagerTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
-
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
-
-	"k8s.io/utils/ptr"
 )
 
 func createAuthTokenSecret(ctx context.Context, st composed.State) (error, context.Context) {
@@ -26,24 +21,7 @@ func createAuthTokenSecret(ctx context.Context, st composed.State) (error, conte
 
 	logger := composed.LoggerFromCtx(ctx)
 
-	err := state.awsClient.CreateAuthTokenSecret(ctx, GetAwsAuthTokenSecretName(state.Obj().GetName()), []secretsmanagerTypes.Tag{
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(redisInstance.Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(redisInstance.Spec.Scope.Name),
-		},
-		{
-			Key:   ptr.To(common.TagShoot),
-			Value: ptr.To(state.Scope().Spec.ShootName),
-		},
-	})
+	err := state.awsClient.CreateAuthTokenSecret(ctx, GetAwsAuthTokenSecretName(state.Obj().GetName()), state.secretsManagerTags())
 	if err != nil {
 		return awsmeta.LogErrorAndReturn(err, "Error creating authToken secret", ctx)
 	}
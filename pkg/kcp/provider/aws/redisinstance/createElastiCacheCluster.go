@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
 	"github.com/kyma-project/cloud-manager/pkg/util"
@@ -33,24 +31,7 @@ func createElastiCacheCluster(ctx context.Context, st composed.State) (error, co
 		authTokenSecetString = state.authTokenValue.SecretString
 	}
 
-	_, err := state.awsClient.CreateElastiCacheReplicationGroup(ctx, []types.Tag{
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(redisInstance.Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(redisInstance.Spec.Scope.Name),
-		},
-		{
-			Key:   ptr.To(common.TagShoot),
-			Value: ptr.To(state.Scope().Spec.ShootName),
-		},
-	}, client.CreateElastiCacheClusterOptions{
+	_, err := state.awsClient.CreateElastiCacheReplicationGroup(ctx, state.elastiCacheTags(), client.CreateElastiCacheClusterOptions{
 		Name:                       GetAwsElastiCacheClusterName(state.Obj().GetName()),
 		SubnetGroupName:            ptr.Deref(state.subnetGroup.CacheSubnetGroupName, ""),
 		ParameterGroupName:         ptr.Deref(state.parameterGroup.CacheParameterGroupName, ""),
@@ -61,6 +42,9 @@ func createElastiCacheCluster(ctx context.Context, st composed.State) (error, co
 		TransitEncryptionEnabled:   redisInstance.Spec.Instance.Aws.TransitEncryptionEnabled,
 		PreferredMaintenanceWindow: redisInstance.Spec.Instance.Aws.PreferredMaintenanceWindow,
 		SecurityGroupIds:           []string{state.securityGroupId},
+		DataTieringEnabled:         redisInstance.Spec.Instance.Aws.DataTieringEnabled,
+		Shards:                     redisInstance.Spec.Instance.Aws.Shards,
+		ReplicasPerShard:           ptr.Deref(redisInstance.Spec.Instance.Aws.ReplicasPerShard, 0),
 	})
 
 	if err != nil {
@@ -0,0 +1,61 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func createGlobalReplicationGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	if !state.IsReplicationDesired() || state.globalReplicationGroup != nil || state.elastiCacheReplicationGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+	region := redisInstance.Spec.Instance.Aws.Replication.Region
+
+	logger.Info("Creating elasticache global replication group")
+	_, err := state.awsClient.CreateGlobalReplicationGroup(
+		ctx,
+		GetAwsElastiCacheGlobalReplicationGroupIdSuffix(state.Obj().GetName()),
+		ptr.Deref(state.elastiCacheReplicationGroup.ReplicationGroupId, ""),
+	)
+	if err != nil {
+		logger.Error(err, "Error creating elasticache global replication group")
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+				Message: fmt.Sprintf("Failed creating global replication group: %s", err),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating RedisInstance status due failed global replication group creation").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+			Run(ctx, state)
+	}
+
+	redisInstance.Status.Replica = &cloudcontrolv1beta1.ReplicaStatus{
+		Region: region,
+	}
+
+	return composed.UpdateStatus(redisInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReplicationSyncing,
+			Message: fmt.Sprintf("Replication to region %s is being established", region),
+		}).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationReady).
+		ErrorLogMessage("Error updating RedisInstance status after creating global replication group").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+		Run(ctx, state)
+}
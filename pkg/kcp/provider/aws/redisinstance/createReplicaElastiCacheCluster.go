@@ -0,0 +1,62 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func createReplicaElastiCacheCluster(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	if !state.IsReplicationDesired() || state.replicaReplicationGroup != nil || state.globalReplicationGroup == nil || state.replicaClient == nil {
+		return nil, nil
+	}
+	if state.replicaSubnetGroup == nil || state.replicaSecurityGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	globalState := ptr.Deref(state.globalReplicationGroup.Status, "")
+	if globalState != awsmeta.ElastiCache_Global_AVAILABLE && globalState != awsmeta.ElastiCache_Global_PRIMARY_ONLY {
+		logger.Info("Global replication group is not ready yet, requeueing with delay")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	logger.Info("Creating replica elasticache cluster")
+	_, err := state.replicaClient.CreateElastiCacheSecondaryReplicationGroup(
+		ctx,
+		ptr.Deref(state.globalReplicationGroup.GlobalReplicationGroupId, ""),
+		GetAwsElastiCacheReplicaClusterName(state.Obj().GetName()),
+		state.elastiCacheTags(),
+		client.CreateElastiCacheSecondaryReplicationGroupOptions{
+			SubnetGroupName:  ptr.Deref(state.replicaSubnetGroup.CacheSubnetGroupName, ""),
+			SecurityGroupIds: []string{ptr.Deref(state.replicaSecurityGroup.GroupId, "")},
+		},
+	)
+	if err != nil {
+		logger.Error(err, "Error creating replica elasticache cluster")
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+				Message: fmt.Sprintf("Failed creating replica: %s", err),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating RedisInstance status due failed replica creation").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+			Run(ctx, state)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
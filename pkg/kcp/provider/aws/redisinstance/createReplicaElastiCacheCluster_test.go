@@ -0,0 +1,201 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type createReplicaElastiCacheClusterSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *createReplicaElastiCacheClusterSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *createReplicaElastiCacheClusterSuite) newState(replication *cloudcontrolv1beta1.ReplicationOptions) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &cloudcontrolv1beta1.RedisInstanceAws{Replication: replication},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+	scope := &cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			ShootName: "shoot",
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Aws: &cloudcontrolv1beta1.AwsScope{
+					Network: cloudcontrolv1beta1.AwsNetwork{
+						VPC:  cloudcontrolv1beta1.AwsVPC{CIDR: "10.180.0.0/16"},
+						Pods: "100.64.0.0/12",
+					},
+				},
+			},
+		},
+	}
+	focalState.SetScope(scope)
+
+	awsMock := awsmock.New()
+	state := newState(&testRedisInstanceState{State: focalState, ipRange: &cloudcontrolv1beta1.IpRange{}}, awsMock, awsMock)
+	state.tags = getTags(state)
+
+	_, err := awsMock.CreateElastiCacheReplicationGroup(suite.ctx, nil, awsclient.CreateElastiCacheClusterOptions{Name: GetAwsElastiCacheClusterName("redis")})
+	assert.NoError(suite.T(), err)
+	global, err := awsMock.CreateGlobalReplicationGroup(suite.ctx, GetAwsElastiCacheGlobalReplicationGroupIdSuffix("redis"), GetAwsElastiCacheClusterName("redis"))
+	assert.NoError(suite.T(), err)
+	state.globalReplicationGroup = global.GlobalReplicationGroup
+
+	return state, awsMock
+}
+
+// reconcile runs the replica actions the way the reconciler does, reloading the replica resources after each requeue
+func (suite *createReplicaElastiCacheClusterSuite) reconcile(state *State) error {
+	for i := 0; i < 10; i++ {
+		state.replicaSubnetGroup = nil
+		state.replicaSecurityGroup = nil
+		state.replicaReplicationGroup = nil
+		err, _ := composed.ComposeActions(
+			"test",
+			loadReplicaElastiCacheCluster,
+			loadReplicaSubnetGroup,
+			findReplicaSecurityGroup,
+			createReplicaSubnetGroup,
+			createReplicaSecurityGroup,
+			authorizeReplicaSecurityGroupIngress,
+			createReplicaElastiCacheCluster,
+		)(suite.ctx, state)
+		if err != composed.StopWithRequeue {
+			return err
+		}
+	}
+	return nil
+}
+
+func (suite *createReplicaElastiCacheClusterSuite) TestCreatedInReplicaNetwork() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.ReplicationOptions{
+		Region:    "eu-west-1",
+		VpcId:     "vpc-replica",
+		SubnetIds: []string{"subnet-replica-a", "subnet-replica-b"},
+	})
+
+	err := suite.reconcile(state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(10*time.Second), err)
+
+	assert.NotNil(suite.T(), state.replicaSubnetGroup)
+	assert.Equal(suite.T(), []string{"subnet-replica-a", "subnet-replica-b"}, []string{
+		ptr.Deref(state.replicaSubnetGroup.Subnets[0].SubnetIdentifier, ""),
+		ptr.Deref(state.replicaSubnetGroup.Subnets[1].SubnetIdentifier, ""),
+	})
+
+	assert.NotNil(suite.T(), state.replicaSecurityGroup)
+	assert.Equal(suite.T(), "vpc-replica", ptr.Deref(state.replicaSecurityGroup.VpcId, ""))
+	var cidrs []string
+	for _, perm := range state.replicaSecurityGroup.IpPermissions {
+		assert.Equal(suite.T(), int32(6379), ptr.Deref(perm.ToPort, 0))
+		for _, rng := range perm.IpRanges {
+			cidrs = append(cidrs, ptr.Deref(rng.CidrIp, ""))
+		}
+	}
+	assert.ElementsMatch(suite.T(), []string{"10.180.0.0/16", "100.64.0.0/12"}, cidrs)
+
+	clusters, err := awsMock.DescribeElastiCacheCluster(suite.ctx, GetAwsElastiCacheReplicaClusterName("redis"))
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), clusters, 1) {
+		assert.Equal(suite.T(), GetAwsElastiCacheReplicaSubnetGroupName("redis"), ptr.Deref(clusters[0].CacheSubnetGroupName, ""))
+		if assert.Len(suite.T(), clusters[0].SecurityGroups, 1) {
+			assert.Equal(suite.T(), ptr.Deref(state.replicaSecurityGroup.GroupId, ""), ptr.Deref(clusters[0].SecurityGroups[0].SecurityGroupId, ""))
+		}
+	}
+}
+
+func (suite *createReplicaElastiCacheClusterSuite) TestNotCreatedWithoutReplicaNetwork() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.ReplicationOptions{Region: "eu-west-1"})
+
+	err := suite.reconcile(state)
+	assert.Nil(suite.T(), err)
+
+	clusters, err := awsMock.DescribeElastiCacheCluster(suite.ctx, GetAwsElastiCacheReplicaClusterName("redis"))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), clusters)
+
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationReady)
+	if assert.NotNil(suite.T(), cond) {
+		assert.Equal(suite.T(), metav1.ConditionFalse, cond.Status)
+		assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonReplicationFailed, cond.Reason)
+	}
+}
+
+func (suite *createReplicaElastiCacheClusterSuite) TestReplicaNetworkDeletedAfterReplica() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.ReplicationOptions{
+		Region:    "eu-west-1",
+		VpcId:     "vpc-replica",
+		SubnetIds: []string{"subnet-replica-a"},
+	})
+	_ = suite.reconcile(state)
+	_, _ = loadReplicaElastiCacheCluster(suite.ctx, state)
+	assert.NotNil(suite.T(), state.replicaReplicationGroup)
+
+	// replication disabled
+	state.ObjAsRedisInstance().Spec.Instance.Aws.Replication = nil
+
+	err, _ := deleteReplicaSecurityGroup(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(10*time.Second), err)
+	sgs, _ := awsMock.DescribeElastiCacheSecurityGroups(suite.ctx, []ec2Types.Filter{{
+		Name:   ptr.To("tag:Name"),
+		Values: []string{GetAwsElastiCacheReplicaSecurityGroupName("redis")},
+	}}, nil)
+	assert.Len(suite.T(), sgs, 1, "security group is used by the replica")
+
+	assert.NoError(suite.T(), awsMock.DeleteElastiCacheReplicationGroup(suite.ctx, GetAwsElastiCacheReplicaClusterName("redis")))
+	state.replicaReplicationGroup = nil
+
+	_, _ = deleteReplicaSecurityGroup(suite.ctx, state)
+	_, _ = deleteReplicaSubnetGroup(suite.ctx, state)
+
+	sgs, _ = awsMock.DescribeElastiCacheSecurityGroups(suite.ctx, []ec2Types.Filter{{
+		Name:   ptr.To("tag:Name"),
+		Values: []string{GetAwsElastiCacheReplicaSecurityGroupName("redis")},
+	}}, nil)
+	assert.Empty(suite.T(), sgs)
+	subnetGroups, _ := awsMock.DescribeElastiCacheSubnetGroup(suite.ctx, GetAwsElastiCacheReplicaSubnetGroupName("redis"))
+	assert.Empty(suite.T(), subnetGroups)
+}
+
+func TestCreateReplicaElastiCacheCluster(t *testing.T) {
+	suite.Run(t, new(createReplicaElastiCacheClusterSuite))
+}
@@ -0,0 +1,30 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+)
+
+func createReplicaSecurityGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if !state.IsReplicationDesired() || state.replicaSecurityGroup != nil || state.replicaSubnetGroup == nil || state.replicaClient == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	sgName := GetAwsElastiCacheReplicaSecurityGroupName(state.Obj().GetName())
+	vpcId := state.ObjAsRedisInstance().Spec.Instance.Aws.Replication.VpcId
+	sgId, err := state.replicaClient.CreateElastiCacheSecurityGroup(ctx, vpcId, sgName, awsutil.Ec2TagsFromMap(state.tags, "Name", sgName))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error creating replica security group", ctx)
+	}
+
+	logger = logger.WithValues("replicaSecurityGroupId", sgId)
+	logger.Info("Replica security group created")
+
+	return composed.StopWithRequeue, nil
+}
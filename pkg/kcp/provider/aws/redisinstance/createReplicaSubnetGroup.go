@@ -0,0 +1,55 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createReplicaSubnetGroup creates the ElastiCache subnet group of the replica over the subnets of the replica
+// region given in the spec. If the replica network is not given, the replica is not created, what is reported
+// with the ReplicationReady condition.
+func createReplicaSubnetGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	if !state.IsReplicationDesired() || state.replicaSubnetGroup != nil || state.replicaClient == nil {
+		return nil, nil
+	}
+
+	replication := redisInstance.Spec.Instance.Aws.Replication
+	if len(replication.VpcId) == 0 || len(replication.SubnetIds) == 0 {
+		condition := metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+			Message: fmt.Sprintf("Replica in region %s requires the vpcId and subnetIds", replication.Region),
+		}
+		if !composed.AnyConditionChanged(redisInstance, condition) && !meta.IsStatusConditionTrue(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeReplicationSyncing) {
+			return nil, nil
+		}
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(condition).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating RedisInstance status with missing replica network").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	out, err := state.replicaClient.CreateElastiCacheSubnetGroup(ctx, GetAwsElastiCacheReplicaSubnetGroupName(state.Obj().GetName()), replication.SubnetIds, state.elastiCacheTags())
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error creating replica subnet group", ctx)
+	}
+
+	logger = logger.WithValues("replicaSubnetGroupName", out.CacheSubnetGroup.CacheSubnetGroupName)
+	logger.Info("Replica subnet group created")
+
+	return composed.StopWithRequeue, nil
+}
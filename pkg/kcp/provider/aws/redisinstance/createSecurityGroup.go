@@ -3,11 +3,9 @@ package redisinstance
 import (
 	"context"
 
-	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
-	"k8s.io/utils/ptr"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 )
 
 func createSecurityGroup(ctx context.Context, st composed.State) (error, context.Context) {
@@ -17,31 +15,9 @@ func createSecurityGroup(ctx context.Context, st composed.State) (error, context
 	}
 
 	logger := composed.LoggerFromCtx(ctx)
-	redisInstance := state.ObjAsRedisInstance()
 
 	sgName := GetAwsElastiCacheSecurityGroupName(state.Obj().GetName())
-	sgId, err := state.awsClient.CreateElastiCacheSecurityGroup(ctx, state.IpRange().Status.VpcId, sgName, []ec2Types.Tag{
-		{
-			Key:   ptr.To("Name"),
-			Value: ptr.To(sgName),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(redisInstance.Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(redisInstance.Spec.Scope.Name),
-		},
-		{
-			Key:   ptr.To(common.TagShoot),
-			Value: ptr.To(state.Scope().Spec.ShootName),
-		},
-	})
+	sgId, err := state.awsClient.CreateElastiCacheSecurityGroup(ctx, state.IpRange().Status.VpcId, sgName, awsutil.Ec2TagsFromMap(state.tags, "Name", sgName))
 	if err != nil {
 		return awsmeta.LogErrorAndReturn(err, "Error creating security group", ctx)
 	}
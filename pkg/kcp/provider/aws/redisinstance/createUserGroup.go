@@ -3,11 +3,8 @@ package redisinstance
 import (
 	"context"
 
-	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
-	"k8s.io/utils/ptr"
 )
 
 func createUserGroup(ctx context.Context, st composed.State) (error, context.Context) {
@@ -17,26 +14,8 @@ func createUserGroup(ctx context.Context, st composed.State) (error, context.Con
 	}
 
 	logger := composed.LoggerFromCtx(ctx)
-	redisInstance := state.ObjAsRedisInstance()
 
-	out, err := state.awsClient.CreateUserGroup(ctx, GetAwsElastiCacheParameterGroupName(state.Obj().GetName()), []types.Tag{
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(redisInstance.Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(redisInstance.Spec.Scope.Name),
-		},
-		{
-			Key:   ptr.To(common.TagShoot),
-			Value: ptr.To(state.Scope().Spec.ShootName),
-		},
-	})
+	out, err := state.awsClient.CreateUserGroup(ctx, GetAwsElastiCacheParameterGroupName(state.Obj().GetName()), state.elastiCacheTags())
 	if err != nil {
 		return awsmeta.LogErrorAndReturn(err, "Error creating user group", ctx)
 	}
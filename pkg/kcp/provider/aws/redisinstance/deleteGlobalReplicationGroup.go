@@ -0,0 +1,33 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+func deleteGlobalReplicationGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.IsReplicationDesired() || state.globalReplicationGroup == nil || state.replicaReplicationGroup != nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	if ptr.Deref(state.globalReplicationGroup.Status, "") == awsmeta.ElastiCache_DELETING {
+		logger.Info("Waiting elasticache global replication group to get deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	logger.Info("Deleting elasticache global replication group")
+	err := state.awsClient.DeleteGlobalReplicationGroup(ctx, ptr.Deref(state.globalReplicationGroup.GlobalReplicationGroupId, ""))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting elasticache global replication group", ctx)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
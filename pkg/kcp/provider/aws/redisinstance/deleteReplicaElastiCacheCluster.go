@@ -0,0 +1,65 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+func deleteReplicaElastiCacheCluster(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.IsReplicationDesired() || state.replicaReplicationGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+	replicaId := ptr.Deref(state.replicaReplicationGroup.ReplicationGroupId, "")
+
+	cacheState := ptr.Deref(state.replicaReplicationGroup.Status, "")
+	if cacheState == awsmeta.ElastiCache_DELETING {
+		logger.Info("Waiting replica elasticache cluster to get deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	if state.globalReplicationGroup != nil {
+		for _, member := range state.globalReplicationGroup.Members {
+			if ptr.Deref(member.ReplicationGroupId, "") != replicaId {
+				continue
+			}
+			if ptr.Deref(member.Status, "") == awsmeta.ElastiCache_GlobalMember_DISASSOCIATING {
+				logger.Info("Waiting replica elasticache cluster to get disassociated")
+				return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+			}
+
+			region := ptr.Deref(member.ReplicationGroupRegion, "")
+			if len(region) == 0 && state.ObjAsRedisInstance().Status.Replica != nil {
+				region = state.ObjAsRedisInstance().Status.Replica.Region
+			}
+
+			logger.Info("Disassociating replica elasticache cluster from global replication group")
+			err := state.awsClient.DisassociateGlobalReplicationGroup(
+				ctx,
+				ptr.Deref(state.globalReplicationGroup.GlobalReplicationGroupId, ""),
+				replicaId,
+				region,
+			)
+			if err != nil {
+				return awsmeta.LogErrorAndReturn(err, "Error disassociating replica elasticache cluster", ctx)
+			}
+
+			return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+		}
+	}
+
+	logger.Info("Deleting replica elasticache cluster")
+	err := state.replicaClient.DeleteElastiCacheReplicationGroup(ctx, replicaId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting replica elasticache cluster", ctx)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
@@ -0,0 +1,35 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+func deleteReplicaSecurityGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.IsReplicationDesired() || state.replicaSecurityGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replicaReplicationGroup != nil {
+		logger.Info("Replica security group is still used by the replica, waiting for it to be deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	logger.
+		WithValues("replicaSecurityGroupId", ptr.Deref(state.replicaSecurityGroup.GroupId, "")).
+		Info("Deleting replica security group")
+
+	err := state.replicaClient.DeleteElastiCacheSecurityGroup(ctx, ptr.Deref(state.replicaSecurityGroup.GroupId, ""))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting replica security group", ctx)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
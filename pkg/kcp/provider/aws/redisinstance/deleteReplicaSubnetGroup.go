@@ -0,0 +1,35 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+func deleteReplicaSubnetGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.IsReplicationDesired() || state.replicaSubnetGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.replicaReplicationGroup != nil {
+		logger.Info("Replica subnet group is still used by the replica, waiting for it to be deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	logger.
+		WithValues("replicaSubnetGroupName", ptr.Deref(state.replicaSubnetGroup.CacheSubnetGroupName, "")).
+		Info("Deleting replica subnet group")
+
+	err := state.replicaClient.DeleteElastiCacheSubnetGroup(ctx, ptr.Deref(state.replicaSubnetGroup.CacheSubnetGroupName, ""))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error deleting replica subnet group", ctx)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
@@ -17,6 +17,11 @@ func deleteSubnetGroup(ctx context.Context, st composed.State) (error, context.C
 		return nil, nil
 	}
 
+	if state.elastiCacheReplicationGroup != nil {
+		logger.Info("Subnet group is still used by the replication group, waiting for it to be deleted")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
 	logger.
 		WithValues("subnetGroupName", ptr.Deref(state.subnetGroup.CacheSubnetGroupName, "")).
 		Info("Deleting subnet group")
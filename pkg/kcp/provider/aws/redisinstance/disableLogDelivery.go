@@ -0,0 +1,67 @@
+package redisinstance
+
+import (
+	"context"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+// disableLogDelivery is the first stage of the delete flow. It disables the log delivery of the
+// replication group, so the log destinations are released before the replication group is deleted,
+// and waits until the disabling completes.
+func disableLogDelivery(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if state.elastiCacheReplicationGroup == nil {
+		return nil, nil
+	}
+	cacheState := ptr.Deref(state.elastiCacheReplicationGroup.Status, "")
+	if cacheState == awsmeta.ElastiCache_DELETING {
+		return nil, nil
+	}
+
+	var requests []elasticacheTypes.LogDeliveryConfigurationRequest
+	disabling := false
+	for _, c := range state.elastiCacheReplicationGroup.LogDeliveryConfigurations {
+		if c.Status == elasticacheTypes.LogDeliveryConfigurationStatusDisabling {
+			disabling = true
+			continue
+		}
+		requests = append(requests, elasticacheTypes.LogDeliveryConfigurationRequest{
+			LogType: c.LogType,
+			Enabled: ptr.To(false),
+		})
+	}
+
+	if len(requests) == 0 {
+		if disabling {
+			logger.Info("Waiting for log delivery to be disabled")
+			return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+		}
+		return nil, nil
+	}
+
+	if cacheState != awsmeta.ElastiCache_AVAILABLE {
+		logger.Info("Waiting for elasti cache cluster to be available to disable log delivery")
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+	}
+
+	logger.
+		WithValues("logDeliveryConfigurations", len(requests)).
+		Info("Disabling log delivery")
+
+	_, err := state.awsClient.ModifyElastiCacheReplicationGroup(ctx, ptr.Deref(state.elastiCacheReplicationGroup.ReplicationGroupId, ""), client.ModifyElastiCacheClusterOptions{
+		LogDeliveryConfigurations: requests,
+	})
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error disabling log delivery", ctx)
+	}
+
+	return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+}
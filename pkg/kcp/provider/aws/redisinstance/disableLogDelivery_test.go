@@ -0,0 +1,69 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDisableLogDelivery(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateElastiCacheReplicationGroup(ctx, nil, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:          "cm-redis",
+		CacheNodeType: "cache.m5.large",
+		EngineVersion: "7.0.7",
+	})
+	assert.NoError(t, err)
+	_, err = awsMock.ModifyElastiCacheReplicationGroup(ctx, "cm-redis", redisinstanceclient.ModifyElastiCacheClusterOptions{
+		LogDeliveryConfigurations: []elasticacheTypes.LogDeliveryConfigurationRequest{
+			newLogDeliveryConfigurationRequest(elasticacheTypes.LogTypeSlowLog, &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{CloudWatchLogGroup: "redis-slow-log"}),
+		},
+	})
+	assert.NoError(t, err)
+	awsMock.SetAwsElastiCacheLifeCycleState("cm-redis", awsmeta.ElastiCache_AVAILABLE)
+
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+	state.elastiCacheReplicationGroup = ptr.To(*awsMock.GetAwsElastiCacheByName("cm-redis"))
+
+	err, _ = disableLogDelivery(ctx, state)
+	assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	assert.Empty(t, awsMock.GetAwsElastiCacheByName("cm-redis").LogDeliveryConfigurations)
+
+	state.elastiCacheReplicationGroup = ptr.To(*awsMock.GetAwsElastiCacheByName("cm-redis"))
+	err, _ = disableLogDelivery(ctx, state)
+	assert.Nil(t, err, "stage completed once log delivery is disabled")
+}
@@ -0,0 +1,70 @@
+package redisinstance
+
+import (
+	"context"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+
+	"k8s.io/utils/ptr"
+)
+
+// ensureCacheSubnetGroup creates the ElastiCache subnet group spanning the IpRange zone subnets, replaces
+// its subnets if they drifted from the IpRange ones, and reports its name in the status
+func ensureCacheSubnetGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	logger := composed.LoggerFromCtx(ctx)
+	subnetIds := pie.Map(state.IpRange().Status.Subnets, func(subnet cloudcontrolv1beta1.IpRangeSubnet) string {
+		return subnet.Id
+	})
+
+	if state.subnetGroup == nil {
+		out, err := state.awsClient.CreateElastiCacheSubnetGroup(ctx, GetAwsElastiCacheSubnetGroupName(state.Obj().GetName()), subnetIds, state.elastiCacheTags())
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error creating subnet group", ctx)
+		}
+
+		logger = logger.WithValues("subnetGroupName", out.CacheSubnetGroup.CacheSubnetGroupName)
+		logger.Info("Subnet group created")
+
+		return composed.StopWithRequeue, nil
+	}
+
+	subnetGroupName := ptr.Deref(state.subnetGroup.CacheSubnetGroupName, "")
+	currentSubnetIds := pie.Map(state.subnetGroup.Subnets, func(subnet elasticacheTypes.Subnet) string {
+		return ptr.Deref(subnet.SubnetIdentifier, "")
+	})
+
+	if added, removed := pie.Diff(pie.Sort(currentSubnetIds), pie.Sort(subnetIds)); len(subnetIds) > 0 && (len(added) > 0 || len(removed) > 0) {
+		logger.
+			WithValues(
+				"subnetGroupName", subnetGroupName,
+				"currentSubnetIds", currentSubnetIds,
+				"desiredSubnetIds", subnetIds,
+			).
+			Info("Updating subnet group subnets")
+
+		err := state.awsClient.ModifyElastiCacheSubnetGroup(ctx, subnetGroupName, subnetIds)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error updating subnet group subnets", ctx)
+		}
+
+		return composed.StopWithRequeue, nil
+	}
+
+	if redisInstance.Status.SubnetGroupName == subnetGroupName {
+		return nil, nil
+	}
+
+	redisInstance.Status.SubnetGroupName = subnetGroupName
+
+	return composed.UpdateStatus(redisInstance).
+		ErrorLogMessage("Error updating RedisInstance status with subnet group name").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
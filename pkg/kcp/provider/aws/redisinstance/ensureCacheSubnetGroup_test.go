@@ -0,0 +1,128 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/elliotchance/pie/v2"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ensureCacheSubnetGroupSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *ensureCacheSubnetGroupSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *ensureCacheSubnetGroupSuite) newState(subnetIds ...string) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{ShootName: "shoot"},
+	})
+
+	ipRange := &cloudcontrolv1beta1.IpRange{}
+	for _, id := range subnetIds {
+		ipRange.Status.Subnets = append(ipRange.Status.Subnets, cloudcontrolv1beta1.IpRangeSubnet{Id: id})
+	}
+
+	awsMock := awsmock.New()
+	state := newState(&testRedisInstanceState{State: focalState, ipRange: ipRange}, awsMock, nil)
+
+	return state, awsMock
+}
+
+func (suite *ensureCacheSubnetGroupSuite) loadSubnetGroup(state *State) []string {
+	list, err := state.awsClient.DescribeElastiCacheSubnetGroup(suite.ctx, GetAwsElastiCacheSubnetGroupName(state.Obj().GetName()))
+	assert.NoError(suite.T(), err)
+	if len(list) == 0 {
+		state.subnetGroup = nil
+		return nil
+	}
+	state.subnetGroup = &list[0]
+	return pie.Map(list[0].Subnets, func(s elasticacheTypes.Subnet) string {
+		return ptr.Deref(s.SubnetIdentifier, "")
+	})
+}
+
+func (suite *ensureCacheSubnetGroupSuite) TestCreatedFromIpRangeSubnets() {
+	state, _ := suite.newState("subnet-a", "subnet-b", "subnet-c")
+
+	err, _ := ensureCacheSubnetGroup(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	assert.Equal(suite.T(), []string{"subnet-a", "subnet-b", "subnet-c"}, suite.loadSubnetGroup(state))
+
+	err, _ = ensureCacheSubnetGroup(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	assert.Equal(suite.T(), GetAwsElastiCacheSubnetGroupName(state.Obj().GetName()), saved.Status.SubnetGroupName)
+}
+
+func (suite *ensureCacheSubnetGroupSuite) TestSubnetDriftIsReconciled() {
+	state, _ := suite.newState("subnet-a", "subnet-b")
+
+	err, _ := ensureCacheSubnetGroup(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	suite.loadSubnetGroup(state)
+
+	state.IpRange().Status.Subnets = append(state.IpRange().Status.Subnets, cloudcontrolv1beta1.IpRangeSubnet{Id: "subnet-c"})
+
+	err, _ = ensureCacheSubnetGroup(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	assert.Equal(suite.T(), []string{"subnet-a", "subnet-b", "subnet-c"}, suite.loadSubnetGroup(state))
+}
+
+func (suite *ensureCacheSubnetGroupSuite) TestNotDeletedWhileUsedByReplicationGroup() {
+	state, awsMock := suite.newState("subnet-a")
+
+	err, _ := ensureCacheSubnetGroup(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeue, err)
+	suite.loadSubnetGroup(state)
+	state.elastiCacheReplicationGroup = &elasticacheTypes.ReplicationGroup{ReplicationGroupId: ptr.To("cm-redis")}
+
+	_, _ = deleteSubnetGroup(suite.ctx, state)
+	assert.NotNil(suite.T(), suite.loadSubnetGroup(state))
+
+	state.elastiCacheReplicationGroup = nil
+	_, _ = deleteSubnetGroup(suite.ctx, state)
+	list, err := awsMock.DescribeElastiCacheSubnetGroup(suite.ctx, GetAwsElastiCacheSubnetGroupName(state.Obj().GetName()))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), list)
+}
+
+func TestEnsureCacheSubnetGroup(t *testing.T) {
+	suite.Run(t, new(ensureCacheSubnetGroupSuite))
+}
@@ -0,0 +1,46 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+// findReplicaSecurityGroup loads the security group of the replica in the replica region. It is found by
+// the tags only, since the spec may already request the replica in other VPC than the existing one is in.
+func findReplicaSecurityGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.replicaSecurityGroup != nil || state.replicaClient == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	list, err := state.replicaClient.DescribeElastiCacheSecurityGroups(ctx, []ec2Types.Filter{
+		{
+			Name:   ptr.To("tag:Name"),
+			Values: []string{GetAwsElastiCacheReplicaSecurityGroupName(state.Obj().GetName())},
+		},
+		{
+			Name:   ptr.To(fmt.Sprintf("tag:%s", common.TagCloudManagerName)),
+			Values: []string{state.Name().String()},
+		},
+	}, nil)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing replica security groups", ctx)
+	}
+
+	if len(list) > 0 {
+		state.replicaSecurityGroup = &list[0]
+		logger = logger.WithValues("replicaSecurityGroupId", ptr.Deref(state.replicaSecurityGroup.GroupId, ""))
+		logger.Info("Replica security group found")
+		return nil, composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, nil
+}
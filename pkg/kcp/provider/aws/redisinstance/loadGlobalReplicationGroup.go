@@ -0,0 +1,42 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+func loadGlobalReplicationGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.globalReplicationGroup != nil {
+		return nil, nil
+	}
+	if state.elastiCacheReplicationGroup == nil || state.elastiCacheReplicationGroup.GlobalReplicationGroupInfo == nil {
+		return nil, nil
+	}
+
+	globalReplicationGroupId := ptr.Deref(state.elastiCacheReplicationGroup.GlobalReplicationGroupInfo.GlobalReplicationGroupId, "")
+	if len(globalReplicationGroupId) == 0 {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	globalReplicationGroup, err := state.awsClient.DescribeGlobalReplicationGroup(ctx, globalReplicationGroupId)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading elasticache global replication group", ctx)
+	}
+
+	if globalReplicationGroup == nil {
+		logger.Info("ElastiCache global replication group not found")
+		return nil, nil
+	}
+
+	state.globalReplicationGroup = globalReplicationGroup
+	logger = logger.WithValues("elastiCacheGlobalReplicationGroupId", globalReplicationGroupId)
+	logger.Info("ElastiCache global replication group found and loaded")
+
+	return nil, composed.LoggerIntoCtx(ctx, logger)
+}
@@ -0,0 +1,32 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+func loadReplicaElastiCacheCluster(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.replicaReplicationGroup != nil || state.replicaClient == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	list, err := state.replicaClient.DescribeElastiCacheReplicationGroup(ctx, GetAwsElastiCacheReplicaClusterName(state.Obj().GetName()))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing replica elasticache clusters", ctx)
+	}
+
+	if len(list) > 0 {
+		state.replicaReplicationGroup = &list[0]
+		logger = logger.WithValues("replicaElastiCacheClusterId", ptr.Deref(state.replicaReplicationGroup.ReplicationGroupId, ""))
+		logger.Info("Replica ElastiCache cluster found and loaded")
+		return nil, composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+func loadReplicaSubnetGroup(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.replicaSubnetGroup != nil || state.replicaClient == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+
+	list, err := state.replicaClient.DescribeElastiCacheSubnetGroup(ctx, GetAwsElastiCacheReplicaSubnetGroupName(state.Obj().GetName()))
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error listing replica subnet groups", ctx)
+	}
+
+	if len(list) > 0 {
+		state.replicaSubnetGroup = &list[0]
+		logger = logger.WithValues("replicaSubnetGroupName", ptr.Deref(state.replicaSubnetGroup.CacheSubnetGroupName, ""))
+		logger.Info("Replica ElastiCache subnet group found and loaded")
+		return nil, composed.LoggerIntoCtx(ctx, logger)
+	}
+
+	return nil, nil
+}
@@ -2,13 +2,22 @@ package redisinstance
 
 import (
 	"context"
+	"fmt"
 
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+// modifyCacheNodeType scales the cache up or down to the desired node type with the Scaling condition.
+// A node type without the data tiering support can not be set while it is enabled, so such transition
+// is rejected. The data tiering change itself requires the cache to be recreated, see recreator.
 func modifyCacheNodeType(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
 
 	redisInstance := state.ObjAsRedisInstance()
 
@@ -18,12 +27,55 @@ func modifyCacheNodeType(ctx context.Context, st composed.State) (error, context
 
 	currentCacheNodeType := ptr.Deref(state.elastiCacheReplicationGroup.CacheNodeType, "")
 	desiredCacheNodeType := redisInstance.Spec.Instance.Aws.CacheNodeType
+	currentDataTiering := state.elastiCacheReplicationGroup.DataTiering == elasticacheTypes.DataTieringStatusEnabled
+
+	if currentDataTiering && !IsDataTieringNodeType(desiredCacheNodeType) {
+		return composed.UpdateStatus(redisInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonIncompatibleNodeType,
+				Message: fmt.Sprintf("Cache node type %s does not support the enabled data tiering", desiredCacheNodeType),
+			}).
+			ErrorLogMessage("Error updating KCP RedisInstance status with incompatible cache node type").
+			SuccessLogMsg("KCP RedisInstance incompatible cache node type requested").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, st)
+	}
 
 	if currentCacheNodeType == desiredCacheNodeType {
-		return nil, nil
+		if meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeScaling) == nil {
+			return nil, nil
+		}
+		logger.Info("Cache node type change completed")
+		return composed.UpdateStatus(redisInstance).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeScaling).
+			SuccessErrorNil().
+			ErrorLogMessage("Failed to remove scaling condition from redis instance").
+			Run(ctx, st)
 	}
 
+	logger.
+		WithValues(
+			"currentCacheNodeType", currentCacheNodeType,
+			"desiredCacheNodeType", desiredCacheNodeType,
+		).
+		Info("Changing cache node type")
 	state.UpdateCacheNodeType(desiredCacheNodeType)
 
-	return nil, nil
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeScaling,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonScaling,
+		Message: fmt.Sprintf("Cache node type is changing from %s to %s", currentCacheNodeType, desiredCacheNodeType),
+	}
+	if !composed.AnyConditionChanged(redisInstance, condition) {
+		return nil, nil
+	}
+
+	return composed.UpdateStatus(redisInstance).
+		SetCondition(condition).
+		SuccessErrorNil().
+		ErrorLogMessage("Failed to add scaling condition to redis instance").
+		Run(ctx, st)
 }
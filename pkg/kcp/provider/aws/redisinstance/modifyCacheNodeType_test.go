@@ -0,0 +1,143 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestIsDataTieringNodeType(t *testing.T) {
+	assert.True(t, IsDataTieringNodeType("cache.r6gd.xlarge"))
+	assert.False(t, IsDataTieringNodeType("cache.r6g.xlarge"))
+	assert.False(t, IsDataTieringNodeType("cache.m5.large"))
+}
+
+type modifyCacheNodeTypeSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *modifyCacheNodeTypeSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *modifyCacheNodeTypeSuite) newState(currentNodeType string, currentDataTiering bool, desired cloudcontrolv1beta1.RedisInstanceAws) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &desired,
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateElastiCacheReplicationGroup(suite.ctx, nil, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:               "cm-redis",
+		CacheNodeType:      currentNodeType,
+		EngineVersion:      "7.0",
+		DataTieringEnabled: currentDataTiering,
+	})
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	return state, awsMock
+}
+
+func (suite *modifyCacheNodeTypeSuite) TestSameNodeTypeDoesNothing() {
+	state, _ := suite.newState("cache.m5.large", false, cloudcontrolv1beta1.RedisInstanceAws{CacheNodeType: "cache.m5.large"})
+
+	err, _ := modifyCacheNodeType(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+}
+
+func (suite *modifyCacheNodeTypeSuite) TestScaling() {
+	state, awsMock := suite.newState("cache.r6gd.xlarge", true, cloudcontrolv1beta1.RedisInstanceAws{
+		CacheNodeType:      "cache.r6gd.2xlarge",
+		DataTieringEnabled: true,
+	})
+
+	err, _ := modifyCacheNodeType(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Equal(suite.T(), "cache.r6gd.2xlarge", ptr.Deref(state.GetModifyElastiCacheClusterOptions().CacheNodeType, ""))
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeScaling)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonScaling, cond.Reason)
+
+	// scaling condition is removed once the node type is changed
+	_, err = awsMock.ModifyElastiCacheReplicationGroup(suite.ctx, "cm-redis", state.GetModifyElastiCacheClusterOptions())
+	assert.NoError(suite.T(), err)
+	state.modifyElastiCacheClusterOptions = redisinstanceclient.ModifyElastiCacheClusterOptions{}
+	state.updateMask = nil
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	err, _ = modifyCacheNodeType(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Nil(suite.T(), meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeScaling))
+}
+
+func (suite *modifyCacheNodeTypeSuite) TestEnablingDataTieringRequiresRecreate() {
+	state, _ := suite.newState("cache.r6g.xlarge", false, cloudcontrolv1beta1.RedisInstanceAws{
+		CacheNodeType:      "cache.r6gd.xlarge",
+		DataTieringEnabled: true,
+	})
+
+	change := (&recreator{}).RequiresRecreate(suite.ctx, state)
+
+	assert.Equal(suite.T(), "Data tiering can not be changed to true on the existing instance", change)
+}
+
+func (suite *modifyCacheNodeTypeSuite) TestIncompatibleNodeTypeWithDataTiering() {
+	state, _ := suite.newState("cache.r6gd.xlarge", true, cloudcontrolv1beta1.RedisInstanceAws{
+		CacheNodeType:      "cache.r6g.xlarge",
+		DataTieringEnabled: true,
+	})
+
+	err, _ := modifyCacheNodeType(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonIncompatibleNodeType, cond.Reason)
+}
+
+func TestModifyCacheNodeType(t *testing.T) {
+	suite.Run(t, new(modifyCacheNodeTypeSuite))
+}
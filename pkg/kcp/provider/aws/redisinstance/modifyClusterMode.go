@@ -0,0 +1,130 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// validateClusterMode rejects the cluster mode on the cache node type without its support.
+// Must run before the parameter group and the cache are modified.
+func validateClusterMode(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	redisInstance := state.ObjAsRedisInstance()
+	desiredClusterMode := redisInstance.Spec.Instance.Aws.Shards != nil
+
+	cacheNodeType := redisInstance.Spec.Instance.Aws.CacheNodeType
+	if desiredClusterMode && !IsClusterModeNodeType(cacheNodeType) {
+		return composed.UpdateStatus(redisInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonIncompatibleNodeType,
+				Message: fmt.Sprintf("Cache node type %s does not support the cluster mode", cacheNodeType),
+			}).
+			ErrorLogMessage("Error updating KCP RedisInstance status with incompatible cache node type").
+			SuccessLogMsg("KCP RedisInstance incompatible cache node type requested").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, st)
+	}
+
+	return nil, nil
+}
+
+// modifyClusterMode reconciles the number of shards and the replicas per shard of the cluster mode
+// cache with the online resharding, one change at the time, with the Resharding condition. On scale
+// in the last shards are removed, with their slots migrated to the remaining ones.
+func modifyClusterMode(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	redisInstance := state.ObjAsRedisInstance()
+
+	if state.elastiCacheReplicationGroup == nil {
+		return composed.StopWithRequeue, nil
+	}
+
+	if redisInstance.Spec.Instance.Aws.Shards == nil {
+		return nil, nil
+	}
+
+	id := ptr.Deref(state.elastiCacheReplicationGroup.ReplicationGroupId, "")
+	nodeGroups := state.elastiCacheReplicationGroup.NodeGroups
+	currentShards := int32(len(nodeGroups))
+	desiredShards := ptr.Deref(redisInstance.Spec.Instance.Aws.Shards, 1)
+	currentReplicas := int32(0)
+	if len(nodeGroups) > 0 {
+		currentReplicas = int32(len(nodeGroups[0].NodeGroupMembers) - 1)
+	}
+	desiredReplicas := ptr.Deref(redisInstance.Spec.Instance.Aws.ReplicasPerShard, 0)
+
+	if currentShards == desiredShards && currentReplicas == desiredReplicas {
+		if meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeResharding) == nil {
+			return nil, nil
+		}
+		logger.Info("Resharding completed")
+		return composed.UpdateStatus(redisInstance).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeResharding).
+			SuccessErrorNil().
+			ErrorLogMessage("Failed to remove resharding condition from redis instance").
+			Run(ctx, st)
+	}
+
+	var message string
+	if currentShards != desiredShards {
+		var nodeGroupsToRemove []string
+		if desiredShards < currentShards {
+			var ids []string
+			for _, ng := range nodeGroups {
+				ids = append(ids, ptr.Deref(ng.NodeGroupId, ""))
+			}
+			sort.Strings(ids)
+			nodeGroupsToRemove = ids[desiredShards:]
+		}
+
+		logger.
+			WithValues(
+				"currentShards", currentShards,
+				"desiredShards", desiredShards,
+				"nodeGroupsToRemove", nodeGroupsToRemove,
+			).
+			Info("Changing number of shards")
+		err := state.awsClient.ModifyElastiCacheReplicationGroupShardConfiguration(ctx, id, desiredShards, nodeGroupsToRemove)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error modifying elasticache shard configuration", ctx)
+		}
+		message = fmt.Sprintf("Number of shards is changing from %d to %d", currentShards, desiredShards)
+	} else {
+		logger.
+			WithValues(
+				"currentReplicasPerShard", currentReplicas,
+				"desiredReplicasPerShard", desiredReplicas,
+			).
+			Info("Changing number of replicas per shard")
+		err := state.awsClient.ModifyElastiCacheReplicaCount(ctx, id, currentReplicas, desiredReplicas)
+		if err != nil {
+			return awsmeta.LogErrorAndReturn(err, "Error modifying elasticache replica count", ctx)
+		}
+		message = fmt.Sprintf("Number of replicas per shard is changing from %d to %d", currentReplicas, desiredReplicas)
+	}
+
+	return composed.UpdateStatus(redisInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeResharding,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonResharding,
+			Message: message,
+		}).
+		ErrorLogMessage("Failed to add resharding condition to redis instance").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+		Run(ctx, st)
+}
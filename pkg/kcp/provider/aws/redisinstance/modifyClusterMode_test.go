@@ -0,0 +1,157 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type modifyClusterModeSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *modifyClusterModeSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+// newState creates the cache with the current shards, or with the cluster mode disabled if nil,
+// and the RedisInstance with the desired shards and replicas per shard
+func (suite *modifyClusterModeSuite) newState(cacheNodeType string, currentShards *int32, currentReplicas int32, desiredShards, desiredReplicas *int32) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &cloudcontrolv1beta1.RedisInstanceAws{
+					CacheNodeType:    cacheNodeType,
+					EngineVersion:    "7.0",
+					Shards:           desiredShards,
+					ReplicasPerShard: desiredReplicas,
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateElastiCacheReplicationGroup(suite.ctx, nil, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:             "cm-redis",
+		CacheNodeType:    cacheNodeType,
+		EngineVersion:    "7.0.7",
+		Shards:           currentShards,
+		ReplicasPerShard: currentReplicas,
+	})
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	return state, awsMock
+}
+
+func (suite *modifyClusterModeSuite) TestScaleOut() {
+	state, awsMock := suite.newState("cache.m5.large", ptr.To(int32(2)), 1, ptr.To(int32(3)), ptr.To(int32(1)))
+	redisInstance := state.ObjAsRedisInstance()
+
+	err, _ := modifyClusterMode(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	assert.Len(suite.T(), awsMock.GetAwsElastiCacheByName("cm-redis").NodeGroups, 3)
+	cond := meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeResharding)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), "Number of shards is changing from 2 to 3", cond.Message)
+
+	// replicas are added in the next step, once the resharding is done
+	redisInstance.Spec.Instance.Aws.ReplicasPerShard = ptr.To(int32(2))
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Update(suite.ctx, redisInstance))
+	err, _ = modifyClusterMode(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	for _, ng := range awsMock.GetAwsElastiCacheByName("cm-redis").NodeGroups {
+		assert.Len(suite.T(), ng.NodeGroupMembers, 3)
+	}
+	cond = meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeResharding)
+	assert.Equal(suite.T(), "Number of replicas per shard is changing from 1 to 2", cond.Message)
+
+	err, _ = modifyClusterMode(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeResharding))
+}
+
+func (suite *modifyClusterModeSuite) TestScaleIn() {
+	state, awsMock := suite.newState("cache.m5.large", ptr.To(int32(4)), 2, ptr.To(int32(2)), ptr.To(int32(2)))
+	redisInstance := state.ObjAsRedisInstance()
+
+	err, _ := modifyClusterMode(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	nodeGroups := awsMock.GetAwsElastiCacheByName("cm-redis").NodeGroups
+	assert.Len(suite.T(), nodeGroups, 2)
+	assert.Equal(suite.T(), "0001", ptr.Deref(nodeGroups[0].NodeGroupId, ""), "the last shards are removed")
+	assert.Equal(suite.T(), "0002", ptr.Deref(nodeGroups[1].NodeGroupId, ""))
+
+	redisInstance.Spec.Instance.Aws.ReplicasPerShard = ptr.To(int32(0))
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Update(suite.ctx, redisInstance))
+	err, _ = modifyClusterMode(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T10000ms()), err)
+	for _, ng := range awsMock.GetAwsElastiCacheByName("cm-redis").NodeGroups {
+		assert.Len(suite.T(), ng.NodeGroupMembers, 1)
+	}
+	cond := meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeResharding)
+	assert.Equal(suite.T(), "Number of replicas per shard is changing from 2 to 0", cond.Message)
+}
+
+func (suite *modifyClusterModeSuite) TestClusterModeChangeRequiresRecreate() {
+	state, _ := suite.newState("cache.m5.large", nil, 0, ptr.To(int32(2)), nil)
+
+	change := (&recreator{}).RequiresRecreate(suite.ctx, state)
+
+	assert.Equal(suite.T(), "Cluster mode can not be changed to true on the existing instance", change)
+}
+
+func (suite *modifyClusterModeSuite) TestNodeTypeWithoutClusterMode() {
+	state, _ := suite.newState("cache.t1.micro", ptr.To(int32(2)), 0, ptr.To(int32(2)), nil)
+	redisInstance := state.ObjAsRedisInstance()
+
+	err, _ := validateClusterMode(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonIncompatibleNodeType, cond.Reason)
+}
+
+func TestModifyClusterMode(t *testing.T) {
+	suite.Run(t, new(modifyClusterModeSuite))
+}
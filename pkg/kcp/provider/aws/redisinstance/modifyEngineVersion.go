@@ -0,0 +1,142 @@
+package redisinstance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func modifyEngineVersion(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	redisInstance := state.ObjAsRedisInstance()
+
+	if state.elastiCacheReplicationGroup == nil {
+		return composed.StopWithRequeue, nil
+	}
+
+	if len(state.elastiCacheReplicationGroup.MemberClusters) < 1 {
+		return composed.LogErrorAndReturn(errors.New("no replication group clusters found"), "no replication group clusters found", composed.StopWithRequeueDelay(5*util.Timing.T10000ms()), ctx)
+	}
+
+	memberClusterId := state.elastiCacheReplicationGroup.MemberClusters[0]
+	clusters, err := state.awsClient.DescribeElastiCacheCluster(ctx, memberClusterId)
+	if err != nil {
+		logger := logger.WithValues("memberClusterId", memberClusterId)
+		return composed.LogErrorAndReturn(err, "failed to describe cluster", composed.StopWithRequeueDelay(5*util.Timing.T10000ms()), composed.LoggerIntoCtx(ctx, logger))
+	}
+	if len(clusters) < 1 {
+		return composed.LogErrorAndReturn(errors.New("no replication group clusters found"), "no replication group clusters found", composed.StopWithRequeueDelay(5*util.Timing.T10000ms()), ctx)
+	}
+
+	currentEngineVersion := ptr.Deref(clusters[0].EngineVersion, "")
+	pendingEngineVersion := ""
+	if clusters[0].PendingModifiedValues != nil {
+		pendingEngineVersion = ptr.Deref(clusters[0].PendingModifiedValues.EngineVersion, "")
+	}
+	desiredEngineVersion := redisInstance.Spec.Instance.Aws.EngineVersion
+
+	// saved with the status later in the flow
+	redisInstance.Status.EngineVersion = currentEngineVersion
+
+	if desiredEngineVersion == "" || currentEngineVersion == "" || CompareEngineVersions(desiredEngineVersion, currentEngineVersion) == 0 {
+		if meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading) == nil {
+			// the upgrade deferred by the maintenance freeze is not pending any more
+			return composed.RemoveFrozen(ctx, st)
+		}
+		logger.Info("Engine version upgrade completed")
+		return composed.UpdateStatus(redisInstance).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeUpgrading, composed.ConditionTypeMaintenanceFrozen).
+			SuccessErrorNil().
+			ErrorLogMessage("Failed to remove upgrading condition from redis instance").
+			Run(ctx, st)
+	}
+
+	if CompareEngineVersions(desiredEngineVersion, currentEngineVersion) < 0 {
+		return composed.UpdateStatus(redisInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonInvalidVersionDowngrade,
+				Message: fmt.Sprintf("Engine version can not be downgraded from %s to %s", currentEngineVersion, desiredEngineVersion),
+			}).
+			ErrorLogMessage("Error updating KCP RedisInstance status with invalid version downgrade").
+			SuccessLogMsg("KCP RedisInstance engine version downgrade requested").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, st)
+	}
+
+	if !IsSupportedEngineUpgrade(currentEngineVersion, desiredEngineVersion) {
+		return composed.UpdateStatus(redisInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonUnsupportedUpgradePath,
+				Message: fmt.Sprintf("Engine version upgrade from %s to %s is not supported", currentEngineVersion, desiredEngineVersion),
+			}).
+			ErrorLogMessage("Error updating KCP RedisInstance status with unsupported upgrade path").
+			SuccessLogMsg("KCP RedisInstance unsupported engine version upgrade requested").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, st)
+	}
+
+	applyImmediately := redisInstance.Spec.Instance.Aws.ApplyImmediately
+	scheduled := pendingEngineVersion != "" && CompareEngineVersions(desiredEngineVersion, pendingEngineVersion) == 0
+
+	if !scheduled {
+		if !applyImmediately && state.ShouldUpdateRedisInstance() {
+			// upgrade in the maintenance window would defer the other modifications as well,
+			// so it is scheduled in the next reconciliation once they are done
+			return nil, nil
+		}
+		if until, frozen := composed.MaintenanceFrozenUntil(ctx, st); frozen {
+			logger.
+				WithValues(
+					"currentEngineVersion", currentEngineVersion,
+					"desiredEngineVersion", desiredEngineVersion,
+				).
+				Info("Engine version upgrade deferred in maintenance freeze window")
+			return composed.DeferFrozen(ctx, st, until)
+		}
+		logger.
+			WithValues(
+				"currentEngineVersion", currentEngineVersion,
+				"desiredEngineVersion", desiredEngineVersion,
+				"applyImmediately", applyImmediately,
+			).
+			Info("Upgrading engine version")
+		state.UpdateEngineVersion(desiredEngineVersion, applyImmediately)
+	}
+
+	reason := cloudcontrolv1beta1.ReasonUpgradeScheduled
+	message := fmt.Sprintf("Engine version upgrade from %s to %s is scheduled for the next maintenance window", currentEngineVersion, desiredEngineVersion)
+	if applyImmediately {
+		reason = cloudcontrolv1beta1.ReasonUpgradeInProgress
+		message = fmt.Sprintf("Engine version is upgrading from %s to %s", currentEngineVersion, desiredEngineVersion)
+	}
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeUpgrading,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+	if !composed.AnyConditionChanged(redisInstance, condition) &&
+		meta.FindStatusCondition(redisInstance.Status.Conditions, composed.ConditionTypeMaintenanceFrozen) == nil {
+		return nil, nil
+	}
+
+	return composed.UpdateStatus(redisInstance).
+		SetCondition(condition).
+		RemoveConditions(composed.ConditionTypeMaintenanceFrozen).
+		SuccessErrorNil().
+		ErrorLogMessage("Failed to add upgrading condition to redis instance").
+		Run(ctx, st)
+}
@@ -0,0 +1,245 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCompareEngineVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareEngineVersions("7.0", "7.0.7"))
+	assert.Equal(t, 1, CompareEngineVersions("7.1", "7.0.7"))
+	assert.Equal(t, -1, CompareEngineVersions("6.2", "7.0"))
+	assert.Equal(t, 1, CompareEngineVersions("6.10", "6.2"))
+}
+
+func TestIsSupportedEngineUpgrade(t *testing.T) {
+	assert.True(t, IsSupportedEngineUpgrade("7.0.7", "7.1"))
+	assert.True(t, IsSupportedEngineUpgrade("6.0", "6.2"))
+	assert.False(t, IsSupportedEngineUpgrade("6.2", "7.0"), "parameter group family changes")
+	assert.False(t, IsSupportedEngineUpgrade("7.1", "7.0"), "downgrade")
+	assert.False(t, IsSupportedEngineUpgrade("7.0", "7.0.7"), "same version")
+}
+
+type testRedisInstanceState struct {
+	focal.State
+	ipRange *cloudcontrolv1beta1.IpRange
+}
+
+func (s *testRedisInstanceState) ObjAsRedisInstance() *cloudcontrolv1beta1.RedisInstance {
+	return s.Obj().(*cloudcontrolv1beta1.RedisInstance)
+}
+
+func (s *testRedisInstanceState) IpRange() *cloudcontrolv1beta1.IpRange {
+	return s.ipRange
+}
+
+func (s *testRedisInstanceState) SetIpRange(r *cloudcontrolv1beta1.IpRange) {
+	s.ipRange = r
+}
+
+type modifyEngineVersionSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *modifyEngineVersionSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *modifyEngineVersionSuite) newState(currentVersion, desiredVersion string, applyImmediately bool) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &cloudcontrolv1beta1.RedisInstanceAws{
+					CacheNodeType:    "cache.m5.large",
+					EngineVersion:    desiredVersion,
+					ApplyImmediately: applyImmediately,
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateElastiCacheReplicationGroup(suite.ctx, nil, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:          "cm-redis",
+		CacheNodeType: "cache.m5.large",
+		EngineVersion: currentVersion,
+	})
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	return state, awsMock
+}
+
+func (suite *modifyEngineVersionSuite) TestSameVersionDoesNothing() {
+	state, _ := suite.newState("7.0.7", "7.0", false)
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Equal(suite.T(), "7.0.7", state.ObjAsRedisInstance().Status.EngineVersion)
+}
+
+func (suite *modifyEngineVersionSuite) TestDowngradeIsRejected() {
+	state, _ := suite.newState("7.1.0", "7.0", false)
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonInvalidVersionDowngrade, cond.Reason)
+}
+
+func (suite *modifyEngineVersionSuite) TestUnsupportedUpgradePathIsRejected() {
+	state, _ := suite.newState("6.2.6", "7.0", false)
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonUnsupportedUpgradePath, cond.Reason)
+}
+
+func (suite *modifyEngineVersionSuite) TestUpgradeIsScheduledForMaintenanceWindow() {
+	state, awsMock := suite.newState("7.0.7", "7.1", false)
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), state.ShouldUpdateRedisInstance())
+	options := state.GetModifyElastiCacheClusterOptions()
+	assert.Equal(suite.T(), "7.1", ptr.Deref(options.EngineVersion, ""))
+	assert.False(suite.T(), ptr.Deref(options.ApplyImmediately, true))
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonUpgradeScheduled, cond.Reason)
+
+	// once scheduled it is not requested again
+	_, err = awsMock.ModifyElastiCacheReplicationGroup(suite.ctx, "cm-redis", options)
+	assert.NoError(suite.T(), err)
+	state.modifyElastiCacheClusterOptions = redisinstanceclient.ModifyElastiCacheClusterOptions{}
+	state.updateMask = nil
+
+	err, _ = modifyEngineVersion(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+}
+
+func (suite *modifyEngineVersionSuite) TestUpgradeIsNotCombinedWithOtherChangesInMaintenanceWindow() {
+	state, _ := suite.newState("7.0.7", "7.1", false)
+	state.UpdateCacheNodeType("cache.m5.xlarge")
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), state.GetModifyElastiCacheClusterOptions().EngineVersion)
+}
+
+func (suite *modifyEngineVersionSuite) TestUpgradeAppliedImmediately() {
+	state, _ := suite.newState("7.0.7", "7.1", true)
+	state.UpdateCacheNodeType("cache.m5.xlarge")
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	options := state.GetModifyElastiCacheClusterOptions()
+	assert.Equal(suite.T(), "7.1", ptr.Deref(options.EngineVersion, ""))
+	assert.Nil(suite.T(), options.ApplyImmediately)
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonUpgradeInProgress, cond.Reason)
+}
+
+func (suite *modifyEngineVersionSuite) TestUpgradeDeferredInMaintenanceFreeze() {
+	state, _ := suite.newState("7.0.7", "7.1", true)
+	redisInstance := state.ObjAsRedisInstance()
+	now := time.Now().UTC()
+	redisInstance.Spec.MaintenanceFreeze = []cloudcontrolv1beta1.MaintenanceFreezeWindow{{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}}
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Equal(suite.T(), "7.0.7", redisInstance.Status.EngineVersion)
+	assert.Nil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading))
+	assert.NotNil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, composed.ConditionTypeMaintenanceFrozen))
+
+	// once the window ends the upgrade runs and the freeze is cleared
+	redisInstance.Spec.MaintenanceFreeze = nil
+
+	err, _ = modifyEngineVersion(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "7.1", ptr.Deref(state.GetModifyElastiCacheClusterOptions().EngineVersion, ""))
+	assert.NotNil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading))
+	assert.Nil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, composed.ConditionTypeMaintenanceFrozen))
+}
+
+func (suite *modifyEngineVersionSuite) TestUpgradingConditionRemovedInMaintenanceFreeze() {
+	state, _ := suite.newState("7.1.0", "7.1", false)
+	redisInstance := state.ObjAsRedisInstance()
+	now := time.Now().UTC()
+	redisInstance.Spec.MaintenanceFreeze = []cloudcontrolv1beta1.MaintenanceFreezeWindow{{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}}
+	meta.SetStatusCondition(&redisInstance.Status.Conditions, metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeUpgrading,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonUpgradeScheduled,
+		Message: "scheduled",
+	})
+
+	err, _ := modifyEngineVersion(suite.ctx, state)
+
+	// no disruptive change is pending, so the freeze does not apply
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "7.1.0", redisInstance.Status.EngineVersion)
+	assert.Nil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, cloudcontrolv1beta1.ConditionTypeUpgrading))
+	assert.Nil(suite.T(), meta.FindStatusCondition(redisInstance.Status.Conditions, composed.ConditionTypeMaintenanceFrozen))
+}
+
+func TestModifyEngineVersion(t *testing.T) {
+	suite.Run(t, new(modifyEngineVersionSuite))
+}
@@ -0,0 +1,201 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awserrorhandling "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/errorhandling"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// modifyLogDelivery reconciles the slow log and engine log delivery of the replication group with
+// spec.logDelivery. The destinations are validated before they are enabled, and the delivery of the
+// log types removed from the spec is disabled.
+func modifyLogDelivery(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	redisInstance := state.ObjAsRedisInstance()
+
+	if state.elastiCacheReplicationGroup == nil {
+		return composed.StopWithRequeue, nil
+	}
+
+	var requests []elasticacheTypes.LogDeliveryConfigurationRequest
+	for _, logType := range []elasticacheTypes.LogType{elasticacheTypes.LogTypeSlowLog, elasticacheTypes.LogTypeEngineLog} {
+		desired := getDesiredLogDestination(redisInstance.Spec.Instance.Aws.LogDelivery, logType)
+		current := findLogDeliveryConfiguration(state.elastiCacheReplicationGroup.LogDeliveryConfigurations, logType)
+
+		if desired == nil {
+			if current != nil {
+				requests = append(requests, elasticacheTypes.LogDeliveryConfigurationRequest{
+					LogType: logType,
+					Enabled: ptr.To(false),
+				})
+			}
+			continue
+		}
+
+		request := newLogDeliveryConfigurationRequest(logType, desired)
+		if current != nil && isLogDeliveryConfigured(current, request) {
+			continue
+		}
+
+		if err, ctx := validateLogDestination(ctx, state, request); err != nil {
+			return err, ctx
+		}
+		requests = append(requests, request)
+	}
+
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	logger.
+		WithValues("logDeliveryConfigurations", len(requests)).
+		Info("Modifying log delivery")
+	state.UpdateLogDeliveryConfigurations(requests)
+
+	return nil, nil
+}
+
+func getDesiredLogDestination(logDelivery *cloudcontrolv1beta1.RedisInstanceAwsLogDelivery, logType elasticacheTypes.LogType) *cloudcontrolv1beta1.RedisInstanceAwsLogDestination {
+	if logDelivery == nil {
+		return nil
+	}
+	if logType == elasticacheTypes.LogTypeSlowLog {
+		return logDelivery.SlowLog
+	}
+	return logDelivery.EngineLog
+}
+
+// findLogDeliveryConfiguration returns the log delivery of the log type, unless it is being disabled
+func findLogDeliveryConfiguration(configurations []elasticacheTypes.LogDeliveryConfiguration, logType elasticacheTypes.LogType) *elasticacheTypes.LogDeliveryConfiguration {
+	for _, c := range configurations {
+		if c.LogType == logType && c.Status != elasticacheTypes.LogDeliveryConfigurationStatusDisabling {
+			return ptr.To(c)
+		}
+	}
+	return nil
+}
+
+func newLogDeliveryConfigurationRequest(logType elasticacheTypes.LogType, destination *cloudcontrolv1beta1.RedisInstanceAwsLogDestination) elasticacheTypes.LogDeliveryConfigurationRequest {
+	request := elasticacheTypes.LogDeliveryConfigurationRequest{
+		LogType:   logType,
+		LogFormat: elasticacheTypes.LogFormatJson,
+		Enabled:   ptr.To(true),
+	}
+	if destination.Format == string(elasticacheTypes.LogFormatText) {
+		request.LogFormat = elasticacheTypes.LogFormatText
+	}
+	if len(destination.CloudWatchLogGroup) > 0 {
+		request.DestinationType = elasticacheTypes.DestinationTypeCloudWatchLogs
+		request.DestinationDetails = &elasticacheTypes.DestinationDetails{
+			CloudWatchLogsDetails: &elasticacheTypes.CloudWatchLogsDestinationDetails{
+				LogGroup: ptr.To(destination.CloudWatchLogGroup),
+			},
+		}
+	} else {
+		request.DestinationType = elasticacheTypes.DestinationTypeKinesisFirehose
+		request.DestinationDetails = &elasticacheTypes.DestinationDetails{
+			KinesisFirehoseDetails: &elasticacheTypes.KinesisFirehoseDestinationDetails{
+				DeliveryStream: ptr.To(destination.KinesisFirehoseDeliveryStream),
+			},
+		}
+	}
+	return request
+}
+
+func isLogDeliveryConfigured(current *elasticacheTypes.LogDeliveryConfiguration, request elasticacheTypes.LogDeliveryConfigurationRequest) bool {
+	return current.DestinationType == request.DestinationType &&
+		current.LogFormat == request.LogFormat &&
+		getLogDestinationName(current.DestinationDetails) == getLogDestinationName(request.DestinationDetails)
+}
+
+// getLogDestinationName returns the name of the log group or the delivery stream
+func getLogDestinationName(details *elasticacheTypes.DestinationDetails) string {
+	if details == nil {
+		return ""
+	}
+	if details.CloudWatchLogsDetails != nil {
+		return ptr.Deref(details.CloudWatchLogsDetails.LogGroup, "")
+	}
+	if details.KinesisFirehoseDetails != nil {
+		return ptr.Deref(details.KinesisFirehoseDetails.DeliveryStream, "")
+	}
+	return ""
+}
+
+// logDestinationWriteActions are the actions the delivery of the logs calls on the destination of the type
+var logDestinationWriteActions = map[elasticacheTypes.DestinationType][]string{
+	elasticacheTypes.DestinationTypeCloudWatchLogs:  {"logs:CreateLogStream", "logs:PutLogEvents"},
+	elasticacheTypes.DestinationTypeKinesisFirehose: {"firehose:PutRecord", "firehose:PutRecordBatch"},
+}
+
+// validateLogDestination checks the log destination exists and can be written to by cloud-manager. The missing
+// or not writable destination is requeued with a delay, so the delivery is enabled once the user fixes it.
+func validateLogDestination(ctx context.Context, state *State, request elasticacheTypes.LogDeliveryConfigurationRequest) (error, context.Context) {
+	name := getLogDestinationName(request.DestinationDetails)
+
+	var arn string
+	var err error
+	if request.DestinationType == elasticacheTypes.DestinationTypeCloudWatchLogs {
+		logGroup, e := state.awsClient.DescribeLogGroup(ctx, name)
+		if logGroup != nil {
+			arn = ptr.Deref(logGroup.Arn, "")
+		}
+		err = e
+	} else {
+		deliveryStream, e := state.awsClient.DescribeDeliveryStream(ctx, name)
+		if deliveryStream != nil {
+			arn = ptr.Deref(deliveryStream.DeliveryStreamARN, "")
+		}
+		err = e
+	}
+
+	if err != nil {
+		return awserrorhandling.HandleError(ctx, err, state, "KCP RedisInstance on validate log destination",
+			cloudcontrolv1beta1.ReasonLogDestinationInvalid,
+			fmt.Sprintf("Failed validating %s destination %s: %s", request.LogType, name, awsmeta.GetErrorMessage(err))), nil
+	}
+
+	if len(arn) == 0 {
+		return logDestinationError(ctx, state, cloudcontrolv1beta1.ReasonLogDestinationNotFound,
+			fmt.Sprintf("The %s destination %s %s does not exist", request.LogType, request.DestinationType, name))
+	}
+
+	deniedActions, err := state.awsClient.GetDeniedActions(ctx, arn, logDestinationWriteActions[request.DestinationType])
+	if err != nil {
+		return awserrorhandling.HandleError(ctx, err, state, "KCP RedisInstance on validate log destination permissions",
+			cloudcontrolv1beta1.ReasonLogDestinationInvalid,
+			fmt.Sprintf("Failed validating permissions on %s destination %s: %s", request.LogType, name, awsmeta.GetErrorMessage(err))), nil
+	}
+
+	if len(deniedActions) > 0 {
+		return logDestinationError(ctx, state, cloudcontrolv1beta1.ReasonLogDestinationAccessDenied,
+			fmt.Sprintf("The %s destination %s %s does not allow %s", request.LogType, request.DestinationType, name, strings.Join(deniedActions, ", ")))
+	}
+
+	return nil, nil
+}
+
+func logDestinationError(ctx context.Context, state *State, reason, message string) (error, context.Context) {
+	return composed.UpdateStatus(state.ObjAsRedisInstance()).
+		SetExclusiveConditions(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeError,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		}).
+		ErrorLogMessage("Error updating KCP RedisInstance status with invalid log destination").
+		SuccessLogMsg("KCP RedisInstance log destination invalid").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+		Run(ctx, state)
+}
@@ -0,0 +1,201 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type modifyLogDeliverySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *modifyLogDeliverySuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *modifyLogDeliverySuite) newState(logDelivery *cloudcontrolv1beta1.RedisInstanceAwsLogDelivery) (*State, awsmock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &cloudcontrolv1beta1.RedisInstanceAws{
+					CacheNodeType: "cache.m5.large",
+					EngineVersion: "7.0",
+					LogDelivery:   logDelivery,
+				},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+
+	awsMock := awsmock.New()
+	_, err := awsMock.CreateElastiCacheReplicationGroup(suite.ctx, nil, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:          "cm-redis",
+		CacheNodeType: "cache.m5.large",
+		EngineVersion: "7.0.7",
+	})
+	assert.NoError(suite.T(), err)
+
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	return state, awsMock
+}
+
+func (suite *modifyLogDeliverySuite) TestNoLogDeliveryDoesNothing() {
+	state, _ := suite.newState(nil)
+
+	err, _ := modifyLogDelivery(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+}
+
+func (suite *modifyLogDeliverySuite) TestLogDeliveryIsEnabled() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.RedisInstanceAwsLogDelivery{
+		SlowLog:   &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{CloudWatchLogGroup: "redis-slow-log", Format: "json"},
+		EngineLog: &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{KinesisFirehoseDeliveryStream: "redis-engine-log", Format: "text"},
+	})
+	awsMock.AddAwsLogGroup("redis-slow-log")
+	awsMock.AddAwsFirehoseDeliveryStream("redis-engine-log")
+
+	err, _ := modifyLogDelivery(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Equal(suite.T(), []elasticacheTypes.LogDeliveryConfigurationRequest{
+		{
+			LogType:         elasticacheTypes.LogTypeSlowLog,
+			DestinationType: elasticacheTypes.DestinationTypeCloudWatchLogs,
+			DestinationDetails: &elasticacheTypes.DestinationDetails{
+				CloudWatchLogsDetails: &elasticacheTypes.CloudWatchLogsDestinationDetails{LogGroup: ptr.To("redis-slow-log")},
+			},
+			LogFormat: elasticacheTypes.LogFormatJson,
+			Enabled:   ptr.To(true),
+		},
+		{
+			LogType:         elasticacheTypes.LogTypeEngineLog,
+			DestinationType: elasticacheTypes.DestinationTypeKinesisFirehose,
+			DestinationDetails: &elasticacheTypes.DestinationDetails{
+				KinesisFirehoseDetails: &elasticacheTypes.KinesisFirehoseDestinationDetails{DeliveryStream: ptr.To("redis-engine-log")},
+			},
+			LogFormat: elasticacheTypes.LogFormatText,
+			Enabled:   ptr.To(true),
+		},
+	}, state.GetModifyElastiCacheClusterOptions().LogDeliveryConfigurations)
+
+	// once configured it is not requested again
+	_, err = awsMock.ModifyElastiCacheReplicationGroup(suite.ctx, "cm-redis", state.GetModifyElastiCacheClusterOptions())
+	assert.NoError(suite.T(), err)
+	state.modifyElastiCacheClusterOptions = redisinstanceclient.ModifyElastiCacheClusterOptions{}
+	state.updateMask = nil
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	err, _ = modifyLogDelivery(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+}
+
+func (suite *modifyLogDeliverySuite) TestLogDeliveryIsDisabled() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.RedisInstanceAwsLogDelivery{
+		SlowLog: &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{CloudWatchLogGroup: "redis-slow-log", Format: "json"},
+	})
+	awsMock.AddAwsLogGroup("redis-slow-log")
+
+	err, _ := modifyLogDelivery(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	_, err = awsMock.ModifyElastiCacheReplicationGroup(suite.ctx, "cm-redis", state.GetModifyElastiCacheClusterOptions())
+	assert.NoError(suite.T(), err)
+	state.modifyElastiCacheClusterOptions = redisinstanceclient.ModifyElastiCacheClusterOptions{}
+	state.updateMask = nil
+	state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+
+	state.ObjAsRedisInstance().Spec.Instance.Aws.LogDelivery = nil
+
+	err, _ = modifyLogDelivery(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []elasticacheTypes.LogDeliveryConfigurationRequest{
+		{
+			LogType: elasticacheTypes.LogTypeSlowLog,
+			Enabled: ptr.To(false),
+		},
+	}, state.GetModifyElastiCacheClusterOptions().LogDeliveryConfigurations)
+}
+
+func (suite *modifyLogDeliverySuite) TestMissingDestinationIsRequeuedUntilCreated() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.RedisInstanceAwsLogDelivery{
+		SlowLog: &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{CloudWatchLogGroup: "redis-slow-log", Format: "json"},
+	})
+
+	err, _ := modifyLogDelivery(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T60000ms()), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonLogDestinationNotFound, cond.Reason)
+
+	// the delivery is enabled once the user creates the destination
+	awsMock.AddAwsLogGroup("redis-slow-log")
+
+	err, _ = modifyLogDelivery(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), state.ShouldUpdateRedisInstance())
+	assert.Len(suite.T(), state.GetModifyElastiCacheClusterOptions().LogDeliveryConfigurations, 1)
+}
+
+func (suite *modifyLogDeliverySuite) TestNotWritableDestinationIsRequeued() {
+	state, awsMock := suite.newState(&cloudcontrolv1beta1.RedisInstanceAwsLogDelivery{
+		EngineLog: &cloudcontrolv1beta1.RedisInstanceAwsLogDestination{KinesisFirehoseDeliveryStream: "redis-engine-log", Format: "json"},
+	})
+	awsMock.AddAwsFirehoseDeliveryStream("redis-engine-log")
+	awsMock.DenyAwsLogDestinationWrite("redis-engine-log")
+
+	err, _ := modifyLogDelivery(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T60000ms()), err)
+	assert.False(suite.T(), state.ShouldUpdateRedisInstance())
+	cond := meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonLogDestinationAccessDenied, cond.Reason)
+	assert.Contains(suite.T(), cond.Message, "firehose:PutRecordBatch")
+}
+
+func TestModifyLogDelivery(t *testing.T) {
+	suite.Run(t, new(modifyLogDeliverySuite))
+}
@@ -32,6 +32,9 @@ func modifyParameterGroup(ctx context.Context, st composed.State) (error, contex
 	defaultParametersMap := MapParameters(defaultParameters)
 
 	desiredParametersMap := GetDesiredParameters(defaultParametersMap, redisInstance.Spec.Instance.Aws.Parameters)
+	if redisInstance.Spec.Instance.Aws.Shards != nil {
+		desiredParametersMap[ParameterClusterEnabled] = "yes"
+	}
 	forUpdateParameters := GetMissmatchedParameters(currentParametersMap, desiredParametersMap)
 
 	if len(forUpdateParameters) > 0 {
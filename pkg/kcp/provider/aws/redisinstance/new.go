@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
@@ -11,6 +12,15 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/kcp/redisinstance/types"
 )
 
+// The delete flow cleanup stages, in the order they run. The first remaining stage finalizer
+// of the object marked for deletion is the stage in progress.
+const (
+	stageLogDelivery      = "aws-redis-log-delivery"
+	stageReplicationGroup = "aws-redis-replication-group"
+	stageAccessControl    = "aws-redis-access-control"
+	stageCacheGroups      = "aws-redis-cache-groups"
+)
+
 func New(stateFactory StateFactory) composed.Action {
 	return func(ctx context.Context, st composed.State) (error, context.Context) {
 		logger := composed.LoggerFromCtx(ctx)
@@ -25,6 +35,7 @@ func New(stateFactory StateFactory) composed.Action {
 		return composed.ComposeActions(
 			"awsRedisInstance",
 			rediscommon.LoadIpRange,
+			actions.AddStagedFinalizers(stageLogDelivery, stageReplicationGroup, stageAccessControl, stageCacheGroups),
 			actions.AddFinalizer,
 			loadSubnetGroup,
 			loadParameterGroup,
@@ -33,12 +44,23 @@ func New(stateFactory StateFactory) composed.Action {
 			findSecurityGroup,
 			loadSecurityGroup,
 			loadElastiCacheCluster,
+			loadGlobalReplicationGroup,
+			loadReplicaElastiCacheCluster,
+			loadReplicaSubnetGroup,
+			findReplicaSecurityGroup,
 			composed.IfElse(composed.Not(composed.MarkedForDeletionPredicate),
 				composed.ComposeActions(
 					"redisInstance-create",
-					createSubnetGroup,
+					reconcileUpdateStrategy,
+					validateClusterMode,
+					actions.ValidateTags(
+						cloudcontrolv1beta1.ProviderAws,
+						func(st composed.State) map[string]string { return getTags(st.(*State)) },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
+					ensureCacheSubnetGroup,
 					createParameterGroup,
-					modifyParameterGroup,
+					composed.IfNotPaused(PausedParameters, modifyParameterGroup),
 					createAuthTokenSecret,
 					createUserGroup,
 					createSecurityGroup,
@@ -47,26 +69,55 @@ func New(stateFactory StateFactory) composed.Action {
 					updateStatusId,
 					addUpdatingCondition,
 					waitElastiCacheAvailable,
+					reconcilePropagatedTags,
+					reconcileDnsRecord,
 					waitUserGroupActive,
-					modifyCacheNodeType,
+					composed.IfNotPaused(PausedScaling, modifyCacheNodeType),
+					composed.IfNotPaused(PausedScaling, modifyClusterMode),
 					modifyAutoMinorVersionUpgrade,
 					modifyTransitEncryptionEnabled,
 					modifyPreferredMaintenanceWindow,
 					modifyAuthEnabled,
+					modifyLogDelivery,
+					modifyEngineVersion,
 					updateElastiCacheCluster,
+					deleteReplicaElastiCacheCluster,
+					deleteReplicaSecurityGroup,
+					deleteReplicaSubnetGroup,
+					deleteGlobalReplicationGroup,
+					removeReplicaStatus,
+					createGlobalReplicationGroup,
+					createReplicaSubnetGroup,
+					createReplicaSecurityGroup,
+					authorizeReplicaSecurityGroupIngress,
+					createReplicaElastiCacheCluster,
+					waitReplicaSynced,
+					composed.UpdatePartiallyPausedCondition,
+					reconcileCostEstimate,
 					updateStatus,
 				),
 				composed.ComposeActions(
 					"redisInstance-delete",
 					removeReadyCondition,
+					deleteDnsRecord,
+					disableLogDelivery,
+					actions.RemoveStagedFinalizer(stageLogDelivery),
+					deleteReplicaElastiCacheCluster,
+					deleteReplicaSecurityGroup,
+					deleteReplicaSubnetGroup,
+					deleteGlobalReplicationGroup,
 					deleteElastiCacheCluster,
 					waitElastiCacheDeleted,
+					actions.RemoveStagedFinalizer(stageReplicationGroup),
 					deleteSecurityGroup,
 					deleteUserGroup,
 					waitUserGroupDeleted,
 					deleteAuthTokenSecret,
+					actions.RemoveStagedFinalizer(stageAccessControl),
 					deleteParameterGroup,
 					deleteSubnetGroup,
+					actions.RemoveStagedFinalizer(stageCacheGroups),
+					composed.CleanupStatusOnDelete("", clearStatusOnDelete),
 					actions.RemoveFinalizer,
 				),
 			),
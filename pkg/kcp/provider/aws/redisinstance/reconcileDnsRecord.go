@@ -0,0 +1,49 @@
+package redisinstance
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	awsdns "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/dns"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileDnsRecord keeps the spec.dns record in the spec.dns.hostedZoneId Route53 hosted zone pointing at
+// the primary endpoint of the available ElastiCache replication group, or at its configuration endpoint in
+// the cluster mode.
+// The record is optional, so its failure does not keep the RedisInstance from getting Ready.
+var reconcileDnsRecord = composed.Optional(
+	cloudcontrolv1beta1.ConditionTypeDnsRecordFailed,
+	cloudcontrolv1beta1.ReasonDnsRecordFailed,
+	composed.ComposeActions(
+		"dnsRecord",
+		awsdns.ValidateHostedZone(dnsClient),
+		kcpdns.ReconcileRecord(dnsClient, elastiCacheEndpoint),
+	),
+)
+
+// deleteDnsRecord deletes the spec.dns record on the RedisInstance teardown
+var deleteDnsRecord = kcpdns.DeleteRecord(dnsClient)
+
+func dnsClient(st composed.State) kcpdns.Client {
+	return st.(*State).dnsClient
+}
+
+func elastiCacheEndpoint(st composed.State) string {
+	state := st.(*State)
+	rg := state.elastiCacheReplicationGroup
+	if rg == nil || ptr.Deref(rg.Status, "") != awsmeta.ElastiCache_AVAILABLE {
+		return ""
+	}
+	if ptr.Deref(rg.ClusterEnabled, false) {
+		if rg.ConfigurationEndpoint == nil {
+			return ""
+		}
+		return ptr.Deref(rg.ConfigurationEndpoint.Address, "")
+	}
+	if len(rg.NodeGroups) == 0 || rg.NodeGroups[0].PrimaryEndpoint == nil {
+		return ""
+	}
+	return ptr.Deref(rg.NodeGroups[0].PrimaryEndpoint.Address, "")
+}
@@ -0,0 +1,69 @@
+package redisinstance
+
+import (
+	"context"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/utils/ptr"
+)
+
+// loadReplicationGroupTags loads the tags of the existing replication group, since they are not
+// returned by the replication group describe call
+func loadReplicationGroupTags(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	if state.elastiCacheReplicationGroup == nil || state.elastiCacheReplicationGroup.ARN == nil {
+		return nil, nil
+	}
+
+	tags, err := state.awsClient.ListElastiCacheTags(ctx, ptr.Deref(state.elastiCacheReplicationGroup.ARN, ""))
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error loading AWS ElastiCache replication group tags", composed.StopWithRequeueDelay(util.Timing.T10000ms()), ctx)
+	}
+
+	state.replicationGroupTags = map[string]string{}
+	for _, t := range tags {
+		state.replicationGroupTags[ptr.Deref(t.Key, "")] = ptr.Deref(t.Value, "")
+	}
+
+	return nil, nil
+}
+
+// reconcilePropagatedTags reconciles the spec tags and the tags propagated from the Scope labels and default
+// tags on the existing replication group. The cloud-manager tags take precedence over the spec tags, and the
+// spec tags over the propagated ones.
+var reconcilePropagatedTags = composed.ComposeActions(
+	"reconcilePropagatedTags",
+	loadReplicationGroupTags,
+	actions.ReconcilePropagatedTags(
+		cloudcontrolv1beta1.ProviderAws,
+		func(st composed.State) []actions.TaggedResource {
+			state := st.(*State)
+			if state.replicationGroupTags == nil {
+				return nil
+			}
+			return []actions.TaggedResource{{
+				Id:   ptr.Deref(state.elastiCacheReplicationGroup.ARN, ""),
+				Tags: state.replicationGroupTags,
+			}}
+		},
+		func(st composed.State) map[string]string { return st.(*State).ObjAsRedisInstance().Spec.Tags },
+		func(st composed.State) map[string]string {
+			return getOwnTags(st.(*State))
+		},
+		func(ctx context.Context, st composed.State, id string, tags map[string]string) error {
+			var elastiCacheTags []elasticacheTypes.Tag
+			for _, t := range awsutil.Ec2TagsFromMap(tags) {
+				elastiCacheTags = append(elastiCacheTags, elasticacheTypes.Tag{Key: t.Key, Value: t.Value})
+			}
+			return st.(*State).awsClient.AddElastiCacheTags(ctx, id, elastiCacheTags)
+		},
+		func(ctx context.Context, st composed.State, id string, keys []string) error {
+			return st.(*State).awsClient.RemoveElastiCacheTags(ctx, id, keys)
+		},
+	),
+)
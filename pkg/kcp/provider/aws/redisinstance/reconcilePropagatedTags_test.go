@@ -0,0 +1,135 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	redisinstanceclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcilePropagatedTags(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	common.SetPropagateLabelPrefix("tags.example.com/")
+	defer common.SetPropagateLabelPrefix("")
+
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			RemoteRef: cloudcontrolv1beta1.RemoteRef{Namespace: "default", Name: "my-redis"},
+			Scope:     cloudcontrolv1beta1.ScopeRef{Name: "kyma"},
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Aws: &cloudcontrolv1beta1.RedisInstanceAws{CacheNodeType: "cache.m5.large"},
+			},
+		},
+	}
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kcp-system",
+			Name:      "kyma",
+			Labels: map[string]string{
+				"tags.example.com/cost-center": "cc-1234",
+			},
+		},
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			ShootName:   "shoot",
+			DefaultTags: map[string]string{"environment": "prod", common.TagScope: "other"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+	focalState.SetScope(scope)
+
+	awsMock := awsmock.New()
+	state := newState(&testRedisInstanceState{State: focalState}, awsMock, nil)
+
+	// the replication group is created with the propagated tags
+	var createTags []elasticacheTypes.Tag
+	for key, value := range getTags(state) {
+		createTags = append(createTags, elasticacheTypes.Tag{Key: ptr.To(key), Value: ptr.To(value)})
+	}
+	_, err := awsMock.CreateElastiCacheReplicationGroup(ctx, createTags, redisinstanceclient.CreateElastiCacheClusterOptions{
+		Name:          "cm-redis",
+		CacheNodeType: "cache.m5.large",
+	})
+	assert.NoError(t, err)
+	tags := awsMock.GetAwsElastiCacheTagsByName("cm-redis")
+	assert.Equal(t, "cc-1234", tags["cost-center"])
+	assert.Equal(t, "prod", tags["environment"])
+	assert.Equal(t, "kyma", tags[common.TagScope])
+
+	reconcile := func() {
+		state.elastiCacheReplicationGroup = awsMock.GetAwsElastiCacheByName("cm-redis")
+		err, _ := reconcilePropagatedTags(ctx, state)
+		assert.NoError(t, err)
+	}
+
+	reconcile()
+	assert.Equal(t, []string{"cost-center", "environment"}, redisInstance.Status.PropagatedTags)
+
+	// changed label value is updated and deleted default tag is removed from the replication group
+	scope.Labels["tags.example.com/cost-center"] = "cc-5678"
+	scope.Spec.DefaultTags = map[string]string{"owner": "platform"}
+
+	reconcile()
+
+	tags = awsMock.GetAwsElastiCacheTagsByName("cm-redis")
+	assert.Equal(t, "cc-5678", tags["cost-center"])
+	assert.Equal(t, "platform", tags["owner"])
+	assert.NotContains(t, tags, "environment")
+	assert.Equal(t, "kyma", tags[common.TagScope])
+	assert.Equal(t, []string{"cost-center", "owner"}, redisInstance.Status.PropagatedTags)
+
+	// spec tags take precedence over the propagated tags, and the cloud-manager tags over the spec tags
+	redisInstance.Spec.Tags = map[string]string{
+		"cost-center":   "cc-spec",
+		"app":           "orders",
+		common.TagScope: "spec",
+	}
+
+	reconcile()
+
+	tags = awsMock.GetAwsElastiCacheTagsByName("cm-redis")
+	assert.Equal(t, "cc-spec", tags["cost-center"])
+	assert.Equal(t, "orders", tags["app"])
+	assert.Equal(t, "platform", tags["owner"])
+	assert.Equal(t, "kyma", tags[common.TagScope])
+	assert.Equal(t, []string{"app", "cost-center", "owner"}, redisInstance.Status.PropagatedTags)
+
+	// removed spec tag is removed from the replication group, and the label tag applies again
+	redisInstance.Spec.Tags = nil
+
+	reconcile()
+
+	tags = awsMock.GetAwsElastiCacheTagsByName("cm-redis")
+	assert.Equal(t, "cc-5678", tags["cost-center"])
+	assert.NotContains(t, tags, "app")
+	assert.Equal(t, []string{"cost-center", "owner"}, redisInstance.Status.PropagatedTags)
+}
@@ -0,0 +1,54 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/utils/ptr"
+)
+
+var _ actions.Recreator = &recreator{}
+
+// recreator recreates the cache on the data tiering and the cluster mode changes, that can not be
+// applied to the existing cache
+type recreator struct{}
+
+func (r *recreator) RequiresRecreate(ctx context.Context, st composed.State) string {
+	state := st.(*State)
+
+	if state.elastiCacheReplicationGroup == nil {
+		return ""
+	}
+	redisInstance := state.ObjAsRedisInstance()
+
+	currentDataTiering := state.elastiCacheReplicationGroup.DataTiering == elasticacheTypes.DataTieringStatusEnabled
+	desiredDataTiering := redisInstance.Spec.Instance.Aws.DataTieringEnabled
+	if currentDataTiering != desiredDataTiering {
+		return fmt.Sprintf("Data tiering can not be changed to %t on the existing instance", desiredDataTiering)
+	}
+
+	currentClusterMode := ptr.Deref(state.elastiCacheReplicationGroup.ClusterEnabled, false)
+	desiredClusterMode := redisInstance.Spec.Instance.Aws.Shards != nil
+	if currentClusterMode != desiredClusterMode {
+		return fmt.Sprintf("Cluster mode can not be changed to %t on the existing instance", desiredClusterMode)
+	}
+
+	return ""
+}
+
+func (r *recreator) Stateful() bool {
+	return true
+}
+
+func (r *recreator) Delete(ctx context.Context, st composed.State) (error, context.Context) {
+	return composed.ComposeActions(
+		"redisInstance-recreate",
+		deleteElastiCacheCluster,
+		waitElastiCacheDeleted,
+	)(ctx, st)
+}
+
+var reconcileUpdateStrategy = actions.ReconcileUpdateStrategy(&recreator{})
@@ -0,0 +1,33 @@
+package redisinstance
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+func removeReplicaStatus(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	if state.IsReplicationDesired() || state.globalReplicationGroup != nil || state.replicaReplicationGroup != nil ||
+		state.replicaSubnetGroup != nil || state.replicaSecurityGroup != nil {
+		return nil, nil
+	}
+	if redisInstance.Status.Replica == nil {
+		return nil, nil
+	}
+
+	redisInstance.Status.Replica = nil
+
+	return composed.UpdateStatus(redisInstance).
+		RemoveConditions(
+			cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+			cloudcontrolv1beta1.ConditionTypeReplicationReady,
+		).
+		ErrorLogMessage("Error updating RedisInstance status after removing replica").
+		SuccessLogMsg("KCP RedisInstance replica removed").
+		SuccessError(composed.StopWithRequeue).
+		Run(ctx, state)
+}
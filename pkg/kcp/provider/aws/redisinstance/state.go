@@ -8,6 +8,7 @@ import (
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
 	client "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance/client"
@@ -17,32 +18,47 @@ import (
 
 type State struct {
 	types.State
-	awsClient client.ElastiCacheClient
+	awsClient     client.ElastiCacheClient
+	replicaClient client.ElastiCacheClient
+	dnsClient     kcpdns.Client
 
 	subnetGroup                 *elasticacheTypes.CacheSubnetGroup
 	parameterGroup              *elasticacheTypes.CacheParameterGroup
 	elastiCacheReplicationGroup *elasticacheTypes.ReplicationGroup
+	replicationGroupTags        map[string]string
 	authTokenValue              *secretsmanager.GetSecretValueOutput
 	userGroup                   *elasticacheTypes.UserGroup
 	securityGroup               *ec2Types.SecurityGroup
 	securityGroupId             string
+	globalReplicationGroup      *elasticacheTypes.GlobalReplicationGroup
+	replicaReplicationGroup     *elasticacheTypes.ReplicationGroup
+	replicaSubnetGroup          *elasticacheTypes.CacheSubnetGroup
+	replicaSecurityGroup        *ec2Types.SecurityGroup
 
 	modifyElastiCacheClusterOptions client.ModifyElastiCacheClusterOptions
 	updateMask                      []string
+
+	// tags are the validated tags put on the AWS resources created for the RedisInstance
+	tags map[string]string
 }
 
 type StateFactory interface {
 	NewState(ctx context.Context, redisInstace types.State) (*State, error)
 }
 
-func NewStateFactory(skrProvider awsclient.SkrClientProvider[client.ElastiCacheClient]) StateFactory {
+func NewStateFactory(
+	skrProvider awsclient.SkrClientProvider[client.ElastiCacheClient],
+	dnsProvider awsclient.SkrClientProvider[kcpdns.Client],
+) StateFactory {
 	return &stateFactory{
 		skrProvider: skrProvider,
+		dnsProvider: dnsProvider,
 	}
 }
 
 type stateFactory struct {
 	skrProvider awsclient.SkrClientProvider[client.ElastiCacheClient]
+	dnsProvider awsclient.SkrClientProvider[kcpdns.Client]
 }
 
 func (f *stateFactory) NewState(ctx context.Context, redisInstace types.State) (*State, error) {
@@ -67,18 +83,83 @@ func (f *stateFactory) NewState(ctx context.Context, redisInstace types.State) (
 		return nil, err
 	}
 
-	return newState(redisInstace, c), nil
+	var replicaClient client.ElastiCacheClient
+	if replicaRegion := getReplicaRegion(redisInstace); len(replicaRegion) > 0 {
+		logger.
+			WithValues(
+				"awsRegion", replicaRegion,
+				"awsRole", roleName,
+			).
+			Info("Assuming AWS role for replica region")
+
+		replicaClient, err = f.skrProvider(
+			ctx,
+			replicaRegion,
+			awsconfig.AwsConfig.Default.AccessKeyId,
+			awsconfig.AwsConfig.Default.SecretAccessKey,
+			roleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dnsClient, err := f.dnsProvider(
+		ctx,
+		redisInstace.Scope().Spec.Region,
+		awsconfig.AwsConfig.Default.AccessKeyId,
+		awsconfig.AwsConfig.Default.SecretAccessKey,
+		roleName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newState(redisInstace, c, replicaClient)
+	state.dnsClient = dnsClient
+	return state, nil
 }
 
-func newState(redisInstace types.State, elastiCacheClient client.ElastiCacheClient) *State {
+func newState(redisInstace types.State, elastiCacheClient client.ElastiCacheClient, replicaClient client.ElastiCacheClient) *State {
 	return &State{
 		State:                           redisInstace,
 		awsClient:                       elastiCacheClient,
+		replicaClient:                   replicaClient,
 		modifyElastiCacheClusterOptions: client.ModifyElastiCacheClusterOptions{},
 		updateMask:                      []string{},
 	}
 }
 
+// getReplicaRegion returns the region of the already provisioned replica, or if none exists
+// the region of the replica requested in the spec
+func getReplicaRegion(redisInstace types.State) string {
+	redisInstance := redisInstace.ObjAsRedisInstance()
+	if redisInstance.Status.Replica != nil && len(redisInstance.Status.Replica.Region) > 0 {
+		return redisInstance.Status.Replica.Region
+	}
+	if redisInstance.Spec.Instance.Aws != nil && redisInstance.Spec.Instance.Aws.Replication != nil {
+		return redisInstance.Spec.Instance.Aws.Replication.Region
+	}
+	return ""
+}
+
+// IsReplicationDesired returns true if the spec requests a replica in the same region
+// the existing replica, if any, is provisioned in, and the RedisInstance is not being deleted
+func (s *State) IsReplicationDesired() bool {
+	if composed.IsMarkedForDeletion(s.Obj()) {
+		return false
+	}
+	redisInstance := s.ObjAsRedisInstance()
+	if redisInstance.Spec.Instance.Aws == nil || redisInstance.Spec.Instance.Aws.Replication == nil {
+		return false
+	}
+	if redisInstance.Status.Replica != nil && len(redisInstance.Status.Replica.Region) > 0 &&
+		redisInstance.Status.Replica.Region != redisInstance.Spec.Instance.Aws.Replication.Region {
+		return false
+	}
+	return true
+}
+
 func (s *State) ShouldUpdateRedisInstance() bool {
 	return len(s.updateMask) > 0
 }
@@ -125,6 +206,22 @@ func (s *State) UpdatePreferredMaintenanceWindow(preferredMaintenanceWindow stri
 	s.updateMask = append(s.updateMask, "preferredMaintenanceWindow")
 }
 
+// UpdateEngineVersion upgrades the engine version, in the next maintenance window unless applyImmediately is set.
+// Since the maintenance window applies to the whole modification, it must not be combined with other changes
+// when not applied immediately.
+func (s *State) UpdateEngineVersion(engineVersion string, applyImmediately bool) {
+	s.modifyElastiCacheClusterOptions.EngineVersion = ptr.To(engineVersion)
+	if !applyImmediately {
+		s.modifyElastiCacheClusterOptions.ApplyImmediately = ptr.To(false)
+	}
+	s.updateMask = append(s.updateMask, "engineVersion")
+}
+
+func (s *State) UpdateLogDeliveryConfigurations(requests []elasticacheTypes.LogDeliveryConfigurationRequest) {
+	s.modifyElastiCacheClusterOptions.LogDeliveryConfigurations = requests
+	s.updateMask = append(s.updateMask, "logDeliveryConfigurations")
+}
+
 func (s *State) UpdateAuthEnabled(authEnabled bool) {
 	s.updateMask = append(s.updateMask, "authEnabled")
 	if authEnabled {
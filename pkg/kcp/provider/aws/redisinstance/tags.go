@@ -0,0 +1,44 @@
+package redisinstance
+
+import (
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	secretsmanagerTypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+)
+
+// getTags returns the tags put on the AWS resources created for the RedisInstance, the spec tags and the tags propagated
+// from the Scope included
+func getTags(state *State) map[string]string {
+	return common.TagsWithPropagated(cloudcontrolv1beta1.ProviderAws, state.Scope(), state.ObjAsRedisInstance().Spec.Tags, getOwnTags(state))
+}
+
+// getOwnTags returns the cloud-manager tags of the AWS resources created for the RedisInstance
+func getOwnTags(state *State) map[string]string {
+	redisInstance := state.ObjAsRedisInstance()
+	return map[string]string{
+		common.TagCloudManagerName:       state.Name().String(),
+		common.TagCloudManagerRemoteName: redisInstance.Spec.RemoteRef.String(),
+		common.TagScope:                  redisInstance.Spec.Scope.Name,
+		common.TagShoot:                  state.Scope().Spec.ShootName,
+	}
+}
+
+// elastiCacheTags returns the validated tags as the ElastiCache tags sorted by key
+func (s *State) elastiCacheTags() []elasticacheTypes.Tag {
+	var result []elasticacheTypes.Tag
+	for _, t := range awsutil.Ec2TagsFromMap(s.tags) {
+		result = append(result, elasticacheTypes.Tag{Key: t.Key, Value: t.Value})
+	}
+	return result
+}
+
+// secretsManagerTags returns the validated tags as the Secrets Manager tags sorted by key
+func (s *State) secretsManagerTags() []secretsmanagerTypes.Tag {
+	var result []secretsmanagerTypes.Tag
+	for _, t := range awsutil.Ec2TagsFromMap(s.tags) {
+		result = append(result, secretsmanagerTypes.Tag{Key: t.Key, Value: t.Value})
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,31 +16,62 @@ func updateStatus(ctx context.Context, st composed.State) (error, context.Contex
 
 	redisInstance := state.ObjAsRedisInstance()
 
-	primaryEndpoint := fmt.Sprintf("%s:%d",
-		ptr.Deref(state.elastiCacheReplicationGroup.NodeGroups[0].PrimaryEndpoint.Address, ""),
-		ptr.Deref(state.elastiCacheReplicationGroup.NodeGroups[0].PrimaryEndpoint.Port, 0),
-	)
-	redisInstance.Status.PrimaryEndpoint = primaryEndpoint
+	if ptr.Deref(state.elastiCacheReplicationGroup.ClusterEnabled, false) {
+		// the cluster mode has no primary and reader endpoint, the clients discover the shards from the configuration endpoint
+		redisInstance.Status.ConfigurationEndpoint = formatEndpoint(state.elastiCacheReplicationGroup.ConfigurationEndpoint)
+		redisInstance.Status.PrimaryEndpoint = ""
+		redisInstance.Status.ReadEndpoint = ""
+	} else {
+		redisInstance.Status.PrimaryEndpoint = formatEndpoint(state.elastiCacheReplicationGroup.NodeGroups[0].PrimaryEndpoint)
+		redisInstance.Status.ReadEndpoint = formatEndpoint(state.elastiCacheReplicationGroup.NodeGroups[0].ReaderEndpoint)
+		redisInstance.Status.ConfigurationEndpoint = ""
+	}
+
+	redisInstance.Status.Connection = newConnection(state.elastiCacheReplicationGroup)
 
-	readEndpoint := fmt.Sprintf("%s:%d",
-		ptr.Deref(state.elastiCacheReplicationGroup.NodeGroups[0].ReaderEndpoint.Address, ""),
-		ptr.Deref(state.elastiCacheReplicationGroup.NodeGroups[0].ReaderEndpoint.Port, 0),
-	)
-	redisInstance.Status.ReadEndpoint = readEndpoint
+	redisInstance.Status.CacheNodeType = ptr.Deref(state.elastiCacheReplicationGroup.CacheNodeType, "")
+	redisInstance.Status.DataTieringEnabled = state.elastiCacheReplicationGroup.DataTiering == elasticacheTypes.DataTieringStatusEnabled
+
+	var logDeliveries []cloudcontrolv1beta1.RedisInstanceLogDeliveryStatus
+	for _, c := range state.elastiCacheReplicationGroup.LogDeliveryConfigurations {
+		logDeliveries = append(logDeliveries, cloudcontrolv1beta1.RedisInstanceLogDeliveryStatus{
+			LogType:         string(c.LogType),
+			DestinationType: string(c.DestinationType),
+			Destination:     getLogDestinationName(c.DestinationDetails),
+			Status:          string(c.Status),
+		})
+	}
+	redisInstance.Status.LogDeliveries = logDeliveries
 
 	if state.authTokenValue != nil {
 		redisInstance.Status.AuthString = ptr.Deref(state.authTokenValue.SecretString, "")
 	}
 
 	return composed.UpdateStatus(redisInstance).
-		SetExclusiveConditions(metav1.Condition{
+		SetCondition(metav1.Condition{
 			Type:    cloudcontrolv1beta1.ConditionTypeReady,
 			Status:  metav1.ConditionTrue,
 			Reason:  cloudcontrolv1beta1.ReasonReady,
 			Message: "Redis instance is ready",
 		}).
+		KeepConditions(
+			cloudcontrolv1beta1.ConditionTypeReady,
+			cloudcontrolv1beta1.ConditionTypeReplicationReady,
+			cloudcontrolv1beta1.ConditionTypeUpgrading,
+			cloudcontrolv1beta1.ConditionTypeScaling,
+			cloudcontrolv1beta1.ConditionTypeResharding,
+			composed.ConditionTypePartiallyPaused,
+			composed.ConditionTypeMaintenanceFrozen,
+		).
 		ErrorLogMessage("Error updating KCP RedisInstance status after setting Ready condition").
 		SuccessLogMsg("KCP RedisInstance is ready").
 		SuccessError(composed.StopAndForget).
 		Run(ctx, state)
 }
+
+func formatEndpoint(endpoint *elasticacheTypes.Endpoint) string {
+	if endpoint == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", ptr.Deref(endpoint.Address, ""), ptr.Deref(endpoint.Port, 0))
+}
@@ -2,12 +2,23 @@ package redisinstance
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	elasticacheTypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 	"k8s.io/utils/ptr"
 )
 
+const (
+	// PausedScaling is the RedisInstance spec.paused name of the cache node type modification
+	PausedScaling = "scaling"
+	// PausedParameters is the RedisInstance spec.paused name of the parameter group modification
+	PausedParameters = "parameters"
+
+	// ParameterClusterEnabled is the parameter of the parameter group the cluster mode replication group requires set to yes
+	ParameterClusterEnabled = "cluster-enabled"
+)
+
 func GetAwsElastiCacheSubnetGroupName(name string) string {
 	return fmt.Sprintf("cm-%s", name)
 }
@@ -50,10 +61,73 @@ func GetAwsElastiCacheParameterGroupFamily(engineVersion string) string {
 	return ""
 }
 
+// CompareEngineVersions compares the dot separated engine versions by their numeric components, only as many
+// of them as the shorter version has, so the 7.0 equals 7.0.7. Returns -1, 0 or 1 if a is lower, equal or greater than b.
+func CompareEngineVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < min(len(aParts), len(bParts)); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if aNum < bNum {
+			return -1
+		}
+		if aNum > bNum {
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsSupportedEngineUpgrade returns true if the engine can be upgraded from one version to the other in place,
+// which is the case for the versions of the same parameter group family, since the parameter group is kept
+func IsSupportedEngineUpgrade(from, to string) bool {
+	family := GetAwsElastiCacheParameterGroupFamily(from)
+	return family != "" && family == GetAwsElastiCacheParameterGroupFamily(to) && CompareEngineVersions(from, to) < 0
+}
+
+// IsDataTieringNodeType returns true if the cache node type supports the data tiering, what is the r6gd node family
+func IsDataTieringNodeType(cacheNodeType string) bool {
+	return strings.HasPrefix(cacheNodeType, "cache.r6gd.")
+}
+
+// IsClusterModeNodeType returns true if the cache node type supports the cluster mode, what all but
+// the previous generation t1, m1, m2 and c1 node families do
+func IsClusterModeNodeType(cacheNodeType string) bool {
+	for _, prefix := range []string{"cache.t1.", "cache.m1.", "cache.m2.", "cache.c1."} {
+		if strings.HasPrefix(cacheNodeType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
 func GetAwsElastiCacheClusterName(name string) string {
 	return fmt.Sprintf("cm-%s", name)
 }
 
+func GetAwsElastiCacheReplicaClusterName(name string) string {
+	return fmt.Sprintf("cm-%s-replica", name)
+}
+
+func GetAwsElastiCacheReplicaSubnetGroupName(name string) string {
+	return fmt.Sprintf("cm-%s-replica", name)
+}
+
+func GetAwsElastiCacheReplicaSecurityGroupName(name string) string {
+	return fmt.Sprintf("cm-%s-replica", name)
+}
+
+func GetAwsElastiCacheGlobalReplicationGroupIdSuffix(name string) string {
+	return fmt.Sprintf("cm-%s", name)
+}
+
 func GetAwsAuthTokenSecretName(name string) string {
 	return fmt.Sprintf("cm-%s/authToken", name)
 }
@@ -11,6 +11,9 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// elastiCachePollResourceType is the resource type of the ElastiCache availability poll interval
+const elastiCachePollResourceType = "AwsRedisInstance"
+
 func waitElastiCacheAvailable(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
@@ -32,9 +35,13 @@ func waitElastiCacheAvailable(ctx context.Context, st composed.State) (error, co
 
 	cacheState := ptr.Deref(state.elastiCacheReplicationGroup.Status, "")
 	if cacheState == awsmeta.ElastiCache_AVAILABLE {
+		composed.ResetPoll(state, elastiCachePollResourceType)
 		return nil, nil
 	}
 
 	logger.Info("Redis instance is not ready yet, requeueing with delay")
-	return composed.StopWithRequeueDelay(util.Timing.T60000ms()), nil
+	return composed.StopWithPollRequeue(state, elastiCachePollResourceType, composed.PollInterval{
+		Initial: util.Timing.T10000ms(),
+		Max:     util.Timing.T60000ms(),
+	}), nil
 }
@@ -0,0 +1,102 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func waitReplicaSynced(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	if !state.IsReplicationDesired() || state.replicaReplicationGroup == nil {
+		return nil, nil
+	}
+
+	logger := composed.LoggerFromCtx(ctx)
+	region := redisInstance.Spec.Instance.Aws.Replication.Region
+
+	memberStatus := ""
+	if state.globalReplicationGroup != nil {
+		for _, member := range state.globalReplicationGroup.Members {
+			if ptr.Deref(member.ReplicationGroupId, "") == ptr.Deref(state.replicaReplicationGroup.ReplicationGroupId, "") {
+				memberStatus = ptr.Deref(member.Status, "")
+			}
+		}
+	}
+
+	endpoint := ""
+	if len(state.replicaReplicationGroup.NodeGroups) > 0 && state.replicaReplicationGroup.NodeGroups[0].ReaderEndpoint != nil {
+		endpoint = fmt.Sprintf("%s:%d",
+			ptr.Deref(state.replicaReplicationGroup.NodeGroups[0].ReaderEndpoint.Address, ""),
+			ptr.Deref(state.replicaReplicationGroup.NodeGroups[0].ReaderEndpoint.Port, 0),
+		)
+	}
+
+	cacheState := ptr.Deref(state.replicaReplicationGroup.Status, "")
+	replica := &cloudcontrolv1beta1.ReplicaStatus{
+		Region:   region,
+		Id:       ptr.Deref(state.replicaReplicationGroup.ReplicationGroupId, ""),
+		Endpoint: endpoint,
+		State:    cacheState,
+	}
+	replicaChanged := ptr.Deref(redisInstance.Status.Replica, cloudcontrolv1beta1.ReplicaStatus{}) != *replica
+	redisInstance.Status.Replica = replica
+
+	if cacheState == awsmeta.ElastiCache_CREATE_FAILED {
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationFailed,
+				Message: fmt.Sprintf("Replica in region %s failed to create", region),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+			ErrorLogMessage("Error updating RedisInstance status after replica creation failed").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+			Run(ctx, state)
+	}
+
+	if cacheState != awsmeta.ElastiCache_AVAILABLE || memberStatus != awsmeta.ElastiCache_GlobalMember_ASSOCIATED {
+		logger.Info("Replica is not in sync yet, requeueing with delay")
+		if !replicaChanged && meta.IsStatusConditionTrue(*redisInstance.Conditions(), cloudcontrolv1beta1.ConditionTypeReplicationSyncing) {
+			return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+		}
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeReplicationSyncing,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonReplicationSyncing,
+				Message: fmt.Sprintf("Replication to region %s is syncing", region),
+			}).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationReady).
+			ErrorLogMessage("Error updating RedisInstance status with syncing replica").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T10000ms())).
+			Run(ctx, state)
+	}
+
+	if !replicaChanged && meta.IsStatusConditionTrue(*redisInstance.Conditions(), cloudcontrolv1beta1.ConditionTypeReplicationReady) {
+		return nil, nil
+	}
+
+	return composed.UpdateStatus(redisInstance).
+		SetCondition(metav1.Condition{
+			Type:    cloudcontrolv1beta1.ConditionTypeReplicationReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReplicationReady,
+			Message: fmt.Sprintf("Replica in region %s is ready", region),
+		}).
+		RemoveConditions(cloudcontrolv1beta1.ConditionTypeReplicationSyncing).
+		ErrorLogMessage("Error updating RedisInstance status with ready replica").
+		SuccessLogMsg("KCP RedisInstance replica is ready").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -4,6 +4,7 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	efsTypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
 	"k8s.io/utils/ptr"
+	"sort"
 	"strings"
 )
 
@@ -25,6 +26,50 @@ func Ec2Tags(args ...string) (result []ec2types.Tag) {
 	return
 }
 
+// Ec2TagsFromMap returns the tags from the map sorted by key, followed by the tags given as key value pairs in args
+func Ec2TagsFromMap(tags map[string]string, args ...string) []ec2types.Tag {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]ec2types.Tag, 0, len(tags)+len(args)/2)
+	for _, k := range keys {
+		result = append(result, ec2types.Tag{
+			Key:   ptr.To(k),
+			Value: ptr.To(tags[k]),
+		})
+	}
+	return append(result, Ec2Tags(args...)...)
+}
+
+// EfsTagsFromMap returns the EFS tags from the map sorted by key, followed by the tags given as key value pairs in args
+func EfsTagsFromMap(tags map[string]string, args ...string) []efsTypes.Tag {
+	var result []efsTypes.Tag
+	for _, t := range Ec2TagsFromMap(tags, args...) {
+		result = append(result, efsTypes.Tag{Key: t.Key, Value: t.Value})
+	}
+	return result
+}
+
+// Ec2TagsToMap returns the tags as the map by key
+func Ec2TagsToMap(tags []ec2types.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, t := range tags {
+		result[ptr.Deref(t.Key, "")] = ptr.Deref(t.Value, "")
+	}
+	return result
+}
+
+// EfsTagsToMap returns the EFS tags as the map by key
+func EfsTagsToMap(tags []efsTypes.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, t := range tags {
+		result[ptr.Deref(t.Key, "")] = ptr.Deref(t.Value, "")
+	}
+	return result
+}
+
 func GetEfsTagValue(tags []efsTypes.Tag, key string) string {
 	for _, t := range tags {
 		if ptr.Deref(t.Key, "") == key {
@@ -3,11 +3,10 @@ package vpcpeering
 import (
 	"context"
 	"fmt"
-	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
-	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,29 +21,6 @@ func createVpcPeeringConnection(ctx context.Context, st composed.State) (error,
 		return nil, nil
 	}
 
-	tags := []ec2types.Tag{
-		{
-			Key:   ptr.To("Name"),
-			Value: ptr.To(state.Obj().GetName()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerName),
-			Value: ptr.To(state.Name().String()),
-		},
-		{
-			Key:   ptr.To(common.TagCloudManagerRemoteName),
-			Value: ptr.To(state.ObjAsVpcPeering().Spec.RemoteRef.String()),
-		},
-		{
-			Key:   ptr.To(common.TagScope),
-			Value: ptr.To(state.ObjAsVpcPeering().Spec.Scope.Name),
-		},
-		{
-			Key:   ptr.To(common.TagShoot),
-			Value: ptr.To(state.Scope().Spec.ShootName),
-		},
-	}
-
 	remoteRegion := state.remoteNetwork.Spec.Network.Reference.Aws.Region
 
 	vpcPeering, err := state.client.CreateVpcPeeringConnection(
@@ -53,7 +29,7 @@ func createVpcPeeringConnection(ctx context.Context, st composed.State) (error,
 		state.remoteVpc.VpcId,
 		ptr.To(remoteRegion),
 		state.remoteVpc.OwnerId,
-		tags)
+		awsutil.Ec2TagsFromMap(state.tags, "Name", state.Obj().GetName()))
 
 	if err != nil {
 		logger.Error(err, "Error creating AWS VPC Peering")
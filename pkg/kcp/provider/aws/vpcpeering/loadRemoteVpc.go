@@ -3,19 +3,27 @@ package vpcpeering
 import (
 	"context"
 	"fmt"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func loadRemoteVpc(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
-	obj := state.ObjAsVpcPeering()
 
 	remoteVpcId := state.remoteNetwork.Spec.Network.Reference.Aws.VpcId
+	remoteVpcNameTag := state.remoteNetwork.Spec.Network.Reference.Aws.VpcNameTag
+
+	// explicit vpc id takes precedence over the name tag
+	if remoteVpcId == "" && remoteVpcNameTag != "" {
+		return loadRemoteVpcByNameTag(ctx, state, remoteVpcNameTag)
+	}
 
 	vpc, err := state.remoteClient.DescribeVpc(ctx, remoteVpcId)
 
@@ -25,22 +33,7 @@ func loadRemoteVpc(ctx context.Context, st composed.State) (error, context.Conte
 	if err != nil {
 		logger.Error(err, "Error loading remote AWS VPC Networks")
 
-		condition := metav1.Condition{
-			Type:    cloudcontrolv1beta1.ConditionTypeError,
-			Status:  metav1.ConditionTrue,
-			Reason:  cloudcontrolv1beta1.ReasonVpcNotFound,
-			Message: err.Error(),
-		}
-
-		if !composed.AnyConditionChanged(obj, condition) {
-			return composed.StopAndForget, nil
-		}
-
-		return composed.UpdateStatus(obj).
-			SetExclusiveConditions(condition).
-			ErrorLogMessage("Error updating VpcPeering status when loading vpc").
-			SuccessError(composed.StopAndForget).
-			Run(ctx, st)
+		return updateRemoteVpcErrorStatus(ctx, state, cloudcontrolv1beta1.ReasonVpcNotFound, err.Error())
 	}
 
 	if vpc == nil {
@@ -50,22 +43,7 @@ func loadRemoteVpc(ctx context.Context, st composed.State) (error, context.Conte
 			).
 			Info("VPC not found")
 
-		condition := metav1.Condition{
-			Type:    cloudcontrolv1beta1.ConditionTypeError,
-			Status:  metav1.ConditionTrue,
-			Reason:  cloudcontrolv1beta1.ReasonVpcNotFound,
-			Message: fmt.Sprintf("AWS VPC ID %s not found", remoteVpcId),
-		}
-
-		if !composed.AnyConditionChanged(obj, condition) {
-			return composed.StopAndForget, nil
-		}
-
-		return composed.UpdateStatus(obj).
-			SetExclusiveConditions(condition).
-			ErrorLogMessage("Error updating VpcPeering status when loading vpc").
-			SuccessError(composed.StopAndForget).
-			Run(ctx, st)
+		return updateRemoteVpcErrorStatus(ctx, state, cloudcontrolv1beta1.ReasonVpcNotFound, fmt.Sprintf("AWS VPC ID %s not found", remoteVpcId))
 	}
 
 	state.remoteVpc = vpc
@@ -77,3 +55,66 @@ func loadRemoteVpc(ctx context.Context, st composed.State) (error, context.Conte
 
 	return nil, ctx
 }
+
+func loadRemoteVpcByNameTag(ctx context.Context, state *State, remoteVpcNameTag string) (error, context.Context) {
+	logger := composed.LoggerFromCtx(ctx).WithValues("remoteVpcNameTag", remoteVpcNameTag)
+
+	vpcList, err := state.remoteClient.DescribeVpcs(ctx, remoteVpcNameTag)
+	if err != nil {
+		return awsmeta.LogErrorAndReturn(err, "Error loading remote AWS VPC Networks by name tag", ctx)
+	}
+
+	matched := pie.Filter(vpcList, func(v ec2Types.Vpc) bool {
+		return util.NameEc2TagEquals(v.Tags, remoteVpcNameTag)
+	})
+
+	if len(matched) == 0 {
+		logger.Info("VPC not found by name tag")
+
+		return updateRemoteVpcErrorStatus(ctx, state, cloudcontrolv1beta1.ReasonVpcNotFoundByTag,
+			fmt.Sprintf("AWS VPC with Name tag %s not found", remoteVpcNameTag))
+	}
+
+	if len(matched) > 1 {
+		matchedIds := pie.Map(matched, func(v ec2Types.Vpc) string {
+			return ptr.Deref(v.VpcId, "")
+		})
+
+		logger.
+			WithValues("matchedVpcIds", fmt.Sprintf("%v", matchedIds)).
+			Info("Multiple VPCs matched by name tag")
+
+		return updateRemoteVpcErrorStatus(ctx, state, cloudcontrolv1beta1.ReasonMultipleVpcsMatched,
+			fmt.Sprintf("Multiple AWS VPCs with Name tag %s found: %v", remoteVpcNameTag, matchedIds))
+	}
+
+	vpc := matched[0]
+	state.remoteVpc = &vpc
+
+	ctx = composed.LoggerIntoCtx(ctx, logger.WithValues(
+		"remoteVpcId", ptr.Deref(vpc.VpcId, ""),
+	))
+
+	return nil, ctx
+}
+
+func updateRemoteVpcErrorStatus(ctx context.Context, state *State, reason, message string) (error, context.Context) {
+	obj := state.ObjAsVpcPeering()
+
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeError,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+
+	if !composed.AnyConditionChanged(obj, condition) {
+		return composed.StopAndForget, nil
+	}
+
+	return composed.UpdateStatus(obj).
+		SetExclusiveConditions(condition).
+		ErrorLogMessage("Error updating VpcPeering status when loading vpc").
+		SuccessError(composed.StopAndForget).
+		Run(ctx, state)
+}
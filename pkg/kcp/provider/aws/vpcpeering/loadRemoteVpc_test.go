@@ -0,0 +1,126 @@
+package vpcpeering
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type testVpcPeeringState struct {
+	focal.State
+}
+
+func (s *testVpcPeeringState) ObjAsVpcPeering() *cloudcontrolv1beta1.VpcPeering {
+	return s.Obj().(*cloudcontrolv1beta1.VpcPeering)
+}
+
+type loadRemoteVpcSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *loadRemoteVpcSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *loadRemoteVpcSuite) newState(reference *cloudcontrolv1beta1.AwsNetworkReference, vpcNames ...string) *State {
+	vpcPeering := &cloudcontrolv1beta1.VpcPeering{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "peering"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(vpcPeering).
+		WithStatusSubresource(vpcPeering).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: vpcPeering.Namespace, Name: vpcPeering.Name}, vpcPeering),
+	)
+
+	awsMock := awsmock.New()
+	for i, name := range vpcNames {
+		awsMock.AddVpc(string(rune('a'+i))+"-vpc", "10.200.0.0/16", []ec2Types.Tag{{Key: ptr.To("Name"), Value: ptr.To(name)}}, nil)
+	}
+
+	state := newState(&testVpcPeeringState{State: focalState}, awsMock, nil, "", "", "")
+	state.remoteClient = awsMock
+	state.remoteNetwork = &cloudcontrolv1beta1.Network{
+		Spec: cloudcontrolv1beta1.NetworkSpec{
+			Network: cloudcontrolv1beta1.NetworkInfo{
+				Reference: &cloudcontrolv1beta1.NetworkReference{Aws: reference},
+			},
+		},
+	}
+	return state
+}
+
+func (suite *loadRemoteVpcSuite) TestNoVpcMatchedByNameTag() {
+	state := suite.newState(&cloudcontrolv1beta1.AwsNetworkReference{VpcNameTag: "shared"}, "other")
+
+	err, _ := loadRemoteVpc(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.Nil(suite.T(), state.remoteVpc)
+	cond := meta.FindStatusCondition(state.ObjAsVpcPeering().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonVpcNotFoundByTag, cond.Reason)
+}
+
+func (suite *loadRemoteVpcSuite) TestOneVpcMatchedByNameTag() {
+	state := suite.newState(&cloudcontrolv1beta1.AwsNetworkReference{VpcNameTag: "shared"}, "other", "shared")
+
+	err, _ := loadRemoteVpc(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), state.remoteVpc)
+	assert.Equal(suite.T(), "b-vpc", ptr.Deref(state.remoteVpc.VpcId, ""))
+	assert.Empty(suite.T(), state.ObjAsVpcPeering().Status.Conditions)
+}
+
+func (suite *loadRemoteVpcSuite) TestManyVpcsMatchedByNameTag() {
+	state := suite.newState(&cloudcontrolv1beta1.AwsNetworkReference{VpcNameTag: "shared"}, "shared", "shared")
+
+	err, _ := loadRemoteVpc(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+	assert.Nil(suite.T(), state.remoteVpc)
+	cond := meta.FindStatusCondition(state.ObjAsVpcPeering().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonMultipleVpcsMatched, cond.Reason)
+	assert.Contains(suite.T(), cond.Message, "a-vpc")
+	assert.Contains(suite.T(), cond.Message, "b-vpc")
+}
+
+func (suite *loadRemoteVpcSuite) TestVpcIdTakesPrecedenceOverNameTag() {
+	state := suite.newState(&cloudcontrolv1beta1.AwsNetworkReference{VpcId: "a-vpc", VpcNameTag: "shared"}, "other", "shared")
+
+	err, _ := loadRemoteVpc(suite.ctx, state)
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), state.remoteVpc)
+	assert.Equal(suite.T(), "a-vpc", ptr.Deref(state.remoteVpc.VpcId, ""))
+}
+
+func TestLoadRemoteVpc(t *testing.T) {
+	suite.Run(t, new(loadRemoteVpcSuite))
+}
@@ -3,6 +3,7 @@ package vpcpeering
 import (
 	"context"
 	"fmt"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/vpcpeering/types"
@@ -38,6 +39,11 @@ func New(stateFactory StateFactory) composed.Action {
 				),
 				composed.ComposeActions(
 					"awsVpcPeering-non-delete",
+					actions.ValidateTags(
+						cloudcontrolv1beta1.ProviderAws,
+						func(st composed.State) map[string]string { return getTags(st.(*State)) },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
 					actions.PatchAddFinalizer,
 					createRemoteClient,
 					loadRemoteVpcPeeringConnection,
@@ -47,7 +53,7 @@ func New(stateFactory StateFactory) composed.Action {
 					createVpcPeeringConnection,
 					acceptVpcPeeringConnection,
 					waitVpcPeeringActive,
-					createRoutes,
+					reconcileRoutes,
 					createRemoteRoutes,
 					updateSuccessStatus,
 					composed.StopAndForgetAction,
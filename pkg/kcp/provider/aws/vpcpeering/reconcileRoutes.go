@@ -0,0 +1,167 @@
+package vpcpeering
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/elliotchance/pie/v2"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/meta"
+	awsutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/util"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// reconcileRoutes adds the routes to each remote VPC CIDR through the peering connection into the local
+// route tables, and deletes the routes through the peering connection to the CIDRs no longer associated
+// with the remote VPC. A route table already having a route to the remote CIDR with another target is
+// skipped and reported with the RouteConflict condition, since replacing it could break the existing traffic.
+func reconcileRoutes(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsVpcPeering()
+
+	connectionId := ptr.Deref(state.vpcPeering.VpcPeeringConnectionId, "")
+	remoteCidrs := getVpcCidrs(state.remoteVpc)
+
+	var routes []cloudcontrolv1beta1.VpcPeeringRoute
+	var conflicts []string
+
+	for _, t := range state.routeTables {
+		routeTableId := ptr.Deref(t.RouteTableId, "")
+
+		for _, r := range t.Routes {
+			destination := ptr.Deref(r.DestinationCidrBlock, "")
+			if ptr.Deref(r.VpcPeeringConnectionId, "") != connectionId || pie.Contains(remoteCidrs, destination) {
+				continue
+			}
+			logger.
+				WithValues(
+					"routeTableId", routeTableId,
+					"destinationCidrBlock", destination,
+				).
+				Info("Deleting route to the CIDR no longer associated with the remote VPC")
+			err := state.client.DeleteRoute(ctx, t.RouteTableId, r.DestinationCidrBlock)
+			if err != nil {
+				return awsmeta.LogErrorAndReturn(err, "Error deleting stale VpcPeering route", ctx)
+			}
+		}
+
+		for _, cidr := range remoteCidrs {
+			existing := pie.Filter(t.Routes, func(r types.Route) bool {
+				return ptr.Deref(r.DestinationCidrBlock, "") == cidr
+			})
+			if pie.Any(existing, func(r types.Route) bool {
+				return ptr.Deref(r.VpcPeeringConnectionId, "") == connectionId
+			}) {
+				routes = append(routes, cloudcontrolv1beta1.VpcPeeringRoute{RouteTableId: routeTableId, DestinationCidrBlock: cidr})
+				continue
+			}
+			if len(existing) > 0 {
+				conflicts = append(conflicts, fmt.Sprintf("%s in %s", cidr, routeTableId))
+				continue
+			}
+
+			err := state.client.CreateRoute(ctx, t.RouteTableId, ptr.To(cidr), state.vpcPeering.VpcPeeringConnectionId)
+
+			if err != nil {
+				routeTableName := awsutil.GetEc2TagValue(t.Tags, "Name")
+				vpcName := awsutil.GetEc2TagValue(state.vpc.Tags, "Name")
+
+				logger.
+					WithValues(
+						"vpcName", vpcName,
+						"routeTableName", routeTableName,
+						"id", connectionId,
+					).
+					Error(err, "Failed to create route")
+
+				if awsmeta.IsErrorRetryable(err) {
+					return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
+				}
+
+				changed := false
+				if meta.RemoveStatusCondition(obj.Conditions(), cloudcontrolv1beta1.ConditionTypeReady) {
+					changed = true
+				}
+
+				if meta.SetStatusCondition(obj.Conditions(), metav1.Condition{
+					Type:    cloudcontrolv1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  cloudcontrolv1beta1.ReasonFailedCreatingRoutes,
+					Message: fmt.Sprintf("AWS Failed to create route for route table %s", routeTableName),
+				}) {
+					changed = true
+				}
+
+				if obj.Status.State != string(cloudcontrolv1beta1.ErrorState) {
+					obj.Status.State = string(cloudcontrolv1beta1.ErrorState)
+				}
+
+				if changed {
+					// User can not recover from internal error
+					return composed.PatchStatus(obj).
+						ErrorLogMessage("Error updating VpcPeering status when creating routes").
+						SuccessError(composed.StopAndForget).
+						Run(ctx, state)
+				}
+				continue
+			}
+
+			routes = append(routes, cloudcontrolv1beta1.VpcPeeringRoute{RouteTableId: routeTableId, DestinationCidrBlock: cidr})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		logger.
+			WithValues("conflicts", conflicts).
+			Info("Existing routes block adding the VpcPeering routes")
+
+		obj.Status.Routes = routes
+		return composed.UpdateStatus(obj).
+			RemoveConditions(cloudcontrolv1beta1.ConditionTypeReady).
+			SetCondition(metav1.Condition{
+				Type:    cloudcontrolv1beta1.ConditionTypeRouteConflict,
+				Status:  metav1.ConditionTrue,
+				Reason:  cloudcontrolv1beta1.ReasonRouteConflict,
+				Message: fmt.Sprintf("Existing routes with another target block the routes to: %s", strings.Join(conflicts, ", ")),
+			}).
+			ErrorLogMessage("Error updating VpcPeering status with RouteConflict condition").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T300000ms())).
+			Run(ctx, state)
+	}
+
+	if reflect.DeepEqual(obj.Status.Routes, routes) {
+		return nil, nil
+	}
+
+	obj.Status.Routes = routes
+	return composed.UpdateStatus(obj).
+		ErrorLogMessage("Error updating VpcPeering status with routes").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+// getVpcCidrs returns the CIDRs associated with the VPC, or its primary CIDR if the associations are not known
+func getVpcCidrs(vpc *types.Vpc) []string {
+	var result []string
+	for _, a := range vpc.CidrBlockAssociationSet {
+		if a.CidrBlockState != nil && a.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		if cidr := ptr.Deref(a.CidrBlock, ""); len(cidr) > 0 {
+			result = append(result, cidr)
+		}
+	}
+	if len(result) == 0 && len(ptr.Deref(vpc.CidrBlock, "")) > 0 {
+		result = append(result, ptr.Deref(vpc.CidrBlock, ""))
+	}
+	return pie.Sort(pie.Unique(result))
+}
@@ -0,0 +1,156 @@
+package vpcpeering
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	awsmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type reconcileRoutesSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileRoutesSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileRoutesSuite) newState(remoteCidrs ...string) (*State, awsmock.Server) {
+	vpcPeering := &cloudcontrolv1beta1.VpcPeering{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "peering"},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(vpcPeering).
+		WithStatusSubresource(vpcPeering).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: vpcPeering.Namespace, Name: vpcPeering.Name}, vpcPeering),
+	)
+
+	awsMock := awsmock.New()
+	state := newState(&testVpcPeeringState{State: focalState}, awsMock, nil, "", "", "")
+	state.vpc = awsMock.AddVpc("kyma-vpc", "10.250.0.0/16", nil, nil)
+	state.vpcPeering = &ec2Types.VpcPeeringConnection{VpcPeeringConnectionId: ptr.To("pcx-1")}
+	state.remoteVpc = &ec2Types.Vpc{VpcId: ptr.To("remote-vpc"), CidrBlock: ptr.To(remoteCidrs[0])}
+	for _, cidr := range remoteCidrs {
+		state.remoteVpc.CidrBlockAssociationSet = append(state.remoteVpc.CidrBlockAssociationSet, ec2Types.VpcCidrBlockAssociation{
+			CidrBlock:      ptr.To(cidr),
+			CidrBlockState: &ec2Types.VpcCidrBlockState{State: ec2Types.VpcCidrBlockStateCodeAssociated},
+		})
+	}
+
+	awsMock.AddRouteTable(ptr.To("rtb-a"), state.vpc.VpcId, nil, nil)
+	awsMock.AddRouteTable(ptr.To("rtb-b"), state.vpc.VpcId, nil, nil)
+	suite.loadRouteTables(state)
+
+	return state, awsMock
+}
+
+func (suite *reconcileRoutesSuite) loadRouteTables(state *State) {
+	routeTables, err := state.client.DescribeRouteTables(suite.ctx, ptr.Deref(state.vpc.VpcId, ""))
+	assert.NoError(suite.T(), err)
+	state.routeTables = routeTables
+}
+
+func (suite *reconcileRoutesSuite) routes(state *State, routeTableId string) map[string]string {
+	result := map[string]string{}
+	for _, t := range state.routeTables {
+		if ptr.Deref(t.RouteTableId, "") != routeTableId {
+			continue
+		}
+		for _, r := range t.Routes {
+			result[ptr.Deref(r.DestinationCidrBlock, "")] = ptr.Deref(r.VpcPeeringConnectionId, "")
+		}
+	}
+	return result
+}
+
+func (suite *reconcileRoutesSuite) TestRoutesAddedForAllRemoteCidrs() {
+	state, _ := suite.newState("10.200.0.0/16", "10.201.0.0/16")
+
+	err, _ := reconcileRoutes(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	suite.loadRouteTables(state)
+	expected := map[string]string{"10.200.0.0/16": "pcx-1", "10.201.0.0/16": "pcx-1"}
+	assert.Equal(suite.T(), expected, suite.routes(state, "rtb-a"))
+	assert.Equal(suite.T(), expected, suite.routes(state, "rtb-b"))
+
+	saved := &cloudcontrolv1beta1.VpcPeering{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.VpcPeeringRoute{
+		{RouteTableId: "rtb-a", DestinationCidrBlock: "10.200.0.0/16"},
+		{RouteTableId: "rtb-a", DestinationCidrBlock: "10.201.0.0/16"},
+		{RouteTableId: "rtb-b", DestinationCidrBlock: "10.200.0.0/16"},
+		{RouteTableId: "rtb-b", DestinationCidrBlock: "10.201.0.0/16"},
+	}, saved.Status.Routes)
+
+	// nothing changes on the next run
+	err, _ = reconcileRoutes(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *reconcileRoutesSuite) TestStaleRouteIsDeleted() {
+	state, awsMock := suite.newState("10.200.0.0/16")
+	assert.NoError(suite.T(), awsMock.CreateRoute(suite.ctx, ptr.To("rtb-a"), ptr.To("10.201.0.0/16"), ptr.To("pcx-1")))
+	assert.NoError(suite.T(), awsMock.CreateRoute(suite.ctx, ptr.To("rtb-a"), ptr.To("10.202.0.0/16"), ptr.To("pcx-other")))
+	suite.loadRouteTables(state)
+
+	err, _ := reconcileRoutes(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	suite.loadRouteTables(state)
+	assert.Equal(suite.T(), map[string]string{
+		"10.200.0.0/16": "pcx-1",
+		"10.202.0.0/16": "pcx-other",
+	}, suite.routes(state, "rtb-a"))
+}
+
+func (suite *reconcileRoutesSuite) TestConflictingRouteIsReported() {
+	state, awsMock := suite.newState("10.200.0.0/16")
+	assert.NoError(suite.T(), awsMock.CreateRoute(suite.ctx, ptr.To("rtb-a"), ptr.To("10.200.0.0/16"), ptr.To("pcx-other")))
+	suite.loadRouteTables(state)
+
+	err, _ := reconcileRoutes(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T300000ms()), err)
+
+	suite.loadRouteTables(state)
+	assert.Equal(suite.T(), map[string]string{"10.200.0.0/16": "pcx-other"}, suite.routes(state, "rtb-a"))
+	assert.Equal(suite.T(), map[string]string{"10.200.0.0/16": "pcx-1"}, suite.routes(state, "rtb-b"))
+
+	saved := &cloudcontrolv1beta1.VpcPeering{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	cond := meta.FindStatusCondition(saved.Status.Conditions, cloudcontrolv1beta1.ConditionTypeRouteConflict)
+	assert.NotNil(suite.T(), cond)
+	assert.Contains(suite.T(), cond.Message, "10.200.0.0/16 in rtb-a")
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.VpcPeeringRoute{
+		{RouteTableId: "rtb-b", DestinationCidrBlock: "10.200.0.0/16"},
+	}, saved.Status.Routes)
+}
+
+func TestReconcileRoutes(t *testing.T) {
+	suite.Run(t, new(reconcileRoutesSuite))
+}
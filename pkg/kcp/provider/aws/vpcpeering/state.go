@@ -6,6 +6,7 @@ import (
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/go-logr/logr"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
 	awsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/client"
 	awsconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/config"
 	vpcpeeringclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/vpcpeering/client"
@@ -33,6 +34,9 @@ type State struct {
 
 	localNetwork  *cloudcontrolv1beta1.Network
 	remoteNetwork *cloudcontrolv1beta1.Network
+
+	// tags are the validated tags put on the AWS resources created for the VpcPeering
+	tags map[string]string
 }
 
 type StateFactory interface {
@@ -91,3 +95,14 @@ func newState(vpcPeeringState vpcpeeringtypes.State,
 		roleName:           roleName,
 	}
 }
+
+// getTags returns the tags put on the AWS resources created for the VpcPeering, the tags propagated
+// from the Scope included
+func getTags(state *State) map[string]string {
+	return common.TagsWithPropagated(cloudcontrolv1beta1.ProviderAws, state.Scope(), nil, map[string]string{
+		common.TagCloudManagerName:       state.Name().String(),
+		common.TagCloudManagerRemoteName: state.ObjAsVpcPeering().Spec.RemoteRef.String(),
+		common.TagScope:                  state.ObjAsVpcPeering().Spec.Scope.Name,
+		common.TagShoot:                  state.Scope().Spec.ShootName,
+	})
+}
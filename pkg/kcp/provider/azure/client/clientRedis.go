@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+	azuremeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/meta"
 	"k8s.io/utils/ptr"
 )
 
@@ -12,6 +13,8 @@ type RedisClient interface {
 	GetRedisInstance(ctx context.Context, resourceGroupName, redisInstanceName string) (*armredis.ResourceInfo, error)
 	DeleteRedisInstance(ctx context.Context, resourceGroupName, redisInstanceName string) error
 	GetRedisInstanceAccessKeys(ctx context.Context, resourceGroupName, redisInstanceName string) ([]string, error)
+	// IsRedisNameAvailable returns false if the globally unique cache name is already used in any subscription
+	IsRedisNameAvailable(ctx context.Context, redisInstanceName string) (bool, error)
 }
 
 func NewRedisClient(svc *armredis.Client) RedisClient {
@@ -77,3 +80,20 @@ func (c *redisClient) UpdateRedisInstance(ctx context.Context, resourceGroupName
 
 	return nil
 }
+
+func (c *redisClient) IsRedisNameAvailable(ctx context.Context, redisInstanceName string) (bool, error) {
+	_, err := c.svc.CheckNameAvailability(
+		ctx,
+		armredis.CheckNameAvailabilityParameters{
+			Name: ptr.To(redisInstanceName),
+			Type: ptr.To("Microsoft.Cache/redis"),
+		},
+		nil)
+	if azuremeta.IsNameNotAvailable(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -57,6 +57,14 @@ func IsNotFound(err error) bool {
 	return false
 }
 
+// IsNameNotAvailable returns true if the error is the response of the name availability check of the name
+// already used by another resource
+func IsNameNotAvailable(err error) bool {
+	var respErr *azcore.ResponseError
+
+	return errors.As(err, &respErr) && (respErr.StatusCode == http.StatusConflict || respErr.ErrorCode == "NameNotAvailable")
+}
+
 func ErrorToRequeueResponse(err error) error {
 	if IsTooManyRequests(err) {
 		return composed.StopWithRequeueDelay(util.Timing.T60000ms())
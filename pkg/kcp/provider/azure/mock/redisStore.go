@@ -20,6 +20,7 @@ func newRedisStore(subscription string) *redisStore {
 	return &redisStore{
 		subscription: subscription,
 		items:        map[string]map[string]*instanceInfo{},
+		foreignNames: map[string]struct{}{},
 	}
 }
 
@@ -35,6 +36,9 @@ type redisStore struct {
 
 	// items is a map of resourceGroup => redisName => *armredis.ResourceInfo
 	items map[string]map[string]*instanceInfo
+
+	// foreignNames are the redis names used in other subscriptions
+	foreignNames map[string]struct{}
 }
 
 // Config =================================================================================================
@@ -55,6 +59,13 @@ func (s *redisStore) AzureRemoveRedisInstance(ctx context.Context, resourceGroup
 	return nil
 }
 
+func (s *redisStore) AzureAddForeignRedisName(redisInstanceName string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.foreignNames[redisInstanceName] = struct{}{}
+}
+
 func (s *redisStore) AzureSetRedisInstanceState(ctx context.Context, resourceGroupName, redisInstanceName string, state armredis.ProvisioningState) error {
 	if isContextCanceled(ctx) {
 		return context.Canceled
@@ -150,7 +161,7 @@ func (s *redisStore) UpdateRedisInstance(ctx context.Context, resourceGroupName,
 		return err
 	}
 
-	if err = mergo.Merge(info.redis.Properties, props); err != nil {
+	if err = mergo.Merge(info.redis.Properties, props, mergo.WithOverride); err != nil {
 		return err
 	}
 
@@ -236,3 +247,21 @@ func (s *redisStore) GetRedisInstanceAccessKeys(ctx context.Context, resourceGro
 
 	return []string{ptr.Deref(info.accessKeys.PrimaryKey, ""), ptr.Deref(info.accessKeys.SecondaryKey, "")}, nil
 }
+
+func (s *redisStore) IsRedisNameAvailable(ctx context.Context, redisInstanceName string) (bool, error) {
+	if isContextCanceled(ctx) {
+		return false, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.foreignNames[redisInstanceName]; ok {
+		return false, nil
+	}
+	for _, group := range s.items {
+		if _, ok := group[redisInstanceName]; ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
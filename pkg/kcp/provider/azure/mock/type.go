@@ -57,6 +57,8 @@ type NetworkConfig interface {
 type RedisConfig interface {
 	AzureRemoveRedisInstance(ctx context.Context, resourceGroupName, redisInstanceName string) error
 	AzureSetRedisInstanceState(ctx context.Context, resourceGroupName, redisInstanceName string, state armredis.ProvisioningState) error
+	// AzureAddForeignRedisName makes the redis name used in another subscription
+	AzureAddForeignRedisName(redisInstanceName string)
 }
 
 type Configs interface {
@@ -2,6 +2,8 @@ package network
 
 import (
 	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	azurecommon "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/common"
@@ -20,8 +22,8 @@ func initState(ctx context.Context, st composed.State) (error, context.Context)
 		state.location = state.Scope().Spec.Region
 	}
 
-	// Tags, nil until we determine the use case justifying their presence and unify on tag names across providers
-	state.tags = nil
+	// Tags, only the ones propagated from the Scope labels and default tags
+	state.tags = common.PropagatedTags(cloudcontrolv1beta1.ProviderAzure, state.Scope())
 
 	// VNet name
 	state.vnetName = state.ObjAsNetwork().Name
@@ -2,6 +2,7 @@ package network
 
 import (
 	"context"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	networktypes "github.com/kyma-project/cloud-manager/pkg/kcp/network/types"
@@ -33,6 +34,11 @@ func New(stateFactory StateFactory) composed.Action {
 				),
 				composed.ComposeActions(
 					"azureNetworkCreate",
+					actions.ValidateTags(
+						cloudcontrolv1beta1.ProviderAzure,
+						func(st composed.State) map[string]string { return st.(*State).tags },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
 					actions.PatchAddFinalizer,
 					resourceGroupCreate,
 					vnetCreate,
@@ -0,0 +1,29 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// checkNameAvailability checks the Azure cache name, which is globally unique as the DNS name of the cache,
+// is not used in another subscription or resource group. The cache loaded from the cloud-manager resource
+// group is owned by the RedisInstance.
+var checkNameAvailability = actions.CheckNameAvailability(func(ctx context.Context, st composed.State) (string, actions.NameAvailability, error) {
+	state := st.(*State)
+	name := state.ObjAsRedisInstance().Name
+
+	if state.azureRedisInstance != nil {
+		return name, actions.NameOwned, nil
+	}
+
+	available, err := state.client.IsRedisNameAvailable(ctx, name)
+	if err != nil {
+		return name, "", err
+	}
+	if !available {
+		return name, actions.NameTaken, nil
+	}
+	return name, actions.NameAvailable, nil
+})
@@ -0,0 +1,25 @@
+package redisinstance
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+// newConnection returns the connection of the Azure cache, over the TLS port unless the non-TLS port is enabled
+func newConnection(properties *armredis.Properties) *cloudcontrolv1beta1.RedisConnection {
+	if properties == nil {
+		return nil
+	}
+	if ptr.Deref(properties.EnableNonSSLPort, false) {
+		return &cloudcontrolv1beta1.RedisConnection{
+			Host: ptr.Deref(properties.HostName, ""),
+			Port: ptr.Deref(properties.Port, 0),
+		}
+	}
+	return &cloudcontrolv1beta1.RedisConnection{
+		Host: ptr.Deref(properties.HostName, ""),
+		Port: ptr.Deref(properties.SSLPort, 0),
+		Tls:  true,
+	}
+}
@@ -0,0 +1,30 @@
+package redisinstance
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewConnection(t *testing.T) {
+	properties := &armredis.Properties{
+		HostName: ptr.To("cache.redis.cache.windows.net"),
+		Port:     ptr.To(int32(6379)),
+		SSLPort:  ptr.To(int32(6380)),
+	}
+
+	assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{
+		Host: "cache.redis.cache.windows.net",
+		Port: 6380,
+		Tls:  true,
+	}, newConnection(properties))
+
+	properties.EnableNonSSLPort = ptr.To(true)
+	assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{
+		Host: "cache.redis.cache.windows.net",
+		Port: 6379,
+	}, newConnection(properties))
+}
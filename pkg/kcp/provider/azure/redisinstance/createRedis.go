@@ -6,7 +6,9 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
 	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	azureutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/util"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +56,11 @@ func createRedis(ctx context.Context, st composed.State) (error, context.Context
 	return composed.StopWithRequeueDelay(util.Timing.T60000ms()), nil
 }
 
+// getTags returns the tags put on the Azure Redis instance, the spec tags and the tags propagated from the Scope
+func getTags(state *State) map[string]string {
+	return common.TagsWithPropagated(v1beta1.ProviderAzure, state.Scope(), state.ObjAsRedisInstance().Spec.Tags, nil)
+}
+
 func getCreateParams(state *State) armredis.CreateParameters {
 	createProperties := &armredis.CreateProperties{
 		EnableNonSSLPort: to.Ptr(state.ObjAsRedisInstance().Spec.Instance.Azure.EnableNonSslPort),
@@ -62,6 +69,7 @@ func getCreateParams(state *State) armredis.CreateParameters {
 			Capacity: to.Ptr[int32](int32(state.ObjAsRedisInstance().Spec.Instance.Azure.SKU.Capacity)),
 			Family:   to.Ptr(armredis.SKUFamilyP),
 		},
+		SubnetID:           to.Ptr(state.subnetId),
 		RedisConfiguration: state.ObjAsRedisInstance().Spec.Instance.Azure.RedisConfiguration.GetRedisConfig(),
 	}
 
@@ -78,6 +86,7 @@ func getCreateParams(state *State) armredis.CreateParameters {
 	createParameters := armredis.CreateParameters{
 		Location:   to.Ptr(state.Scope().Spec.Region),
 		Properties: createProperties,
+		Tags:       azureutil.AzureTags(state.tags),
 	}
 	return createParameters
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func modifyRedis(ctx context.Context, st composed.State) (error, context.Context) {
@@ -27,14 +28,14 @@ func modifyRedis(ctx context.Context, st composed.State) (error, context.Context
 		return nil, nil
 	}
 
-	updateParams, capacityChanged := getUpdateParams(state)
+	updateParams, skuChanged := getUpdateParams(state)
 
-	if !capacityChanged {
+	if !skuChanged {
 		return nil, nil
 	}
 
 	resourceGroupName := state.resourceGroupName
-	logger.Info("Detected modified Redis configuration")
+	logger.Info("Detected modified Redis SKU, scaling")
 	err := state.client.UpdateRedisInstance(
 		ctx,
 		resourceGroupName,
@@ -62,15 +63,21 @@ func modifyRedis(ctx context.Context, st composed.State) (error, context.Context
 		return composed.StopWithRequeueDelay(util.Timing.T10000ms()), nil
 	}
 
-	return composed.StopWithRequeueDelay(util.Timing.T1000ms()), nil
+	return composed.UpdateStatus(requestedAzureRedisInstance).
+		SetCondition(scalingCondition()).
+		ErrorLogMessage("Error updating KCP RedisInstance status with Scaling condition").
+		SuccessError(composed.StopWithRequeueDelay(util.Timing.T1000ms())).
+		Run(ctx, state)
 }
 
 func getUpdateParams(state *State) (armredis.UpdateParameters, bool) {
 
 	requestedAzureRedisInstance := state.ObjAsRedisInstance()
-	capacityChanged := int(*state.azureRedisInstance.Properties.SKU.Capacity) != requestedAzureRedisInstance.Spec.Instance.Azure.SKU.Capacity
+	currentSku := state.azureRedisInstance.Properties.SKU
+	capacityChanged := int(ptr.Deref(currentSku.Capacity, 0)) != requestedAzureRedisInstance.Spec.Instance.Azure.SKU.Capacity
 	updateParameters := armredis.UpdateParameters{}
 
+	// the tier is always Premium, validateSku rejects the others, so only the capacity can change
 	if !capacityChanged {
 		return updateParameters, false
 	}
@@ -78,12 +85,21 @@ func getUpdateParams(state *State) (armredis.UpdateParameters, bool) {
 	updateProperties := &armredis.UpdateProperties{
 		SKU: &armredis.SKU{
 			Name:     to.Ptr(armredis.SKUNamePremium),
-			Capacity: to.Ptr[int32](int32(state.ObjAsRedisInstance().Spec.Instance.Azure.SKU.Capacity)),
+			Capacity: to.Ptr[int32](int32(requestedAzureRedisInstance.Spec.Instance.Azure.SKU.Capacity)),
 			Family:   to.Ptr(armredis.SKUFamilyP),
 		},
 	}
 
 	updateParameters.Properties = updateProperties
 
-	return updateParameters, capacityChanged
+	return updateParameters, true
+}
+
+func scalingCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    v1beta1.ConditionTypeScaling,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.ReasonScaling,
+		Message: "Redis instance SKU is being changed",
+	}
 }
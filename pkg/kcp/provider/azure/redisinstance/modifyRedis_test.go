@@ -0,0 +1,181 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	azuremock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/mock"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	testSubscriptionId = "subscription"
+	testTenantId       = "tenant"
+)
+
+type testRedisInstanceState struct {
+	focal.State
+	ipRange *cloudcontrolv1beta1.IpRange
+}
+
+func (s *testRedisInstanceState) ObjAsRedisInstance() *cloudcontrolv1beta1.RedisInstance {
+	return s.Obj().(*cloudcontrolv1beta1.RedisInstance)
+}
+
+func (s *testRedisInstanceState) IpRange() *cloudcontrolv1beta1.IpRange {
+	return s.ipRange
+}
+
+func (s *testRedisInstanceState) SetIpRange(r *cloudcontrolv1beta1.IpRange) {
+	s.ipRange = r
+}
+
+type modifyRedisSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *modifyRedisSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *modifyRedisSuite) newState(sku cloudcontrolv1beta1.AzureRedisSKU) (*State, azuremock.Server) {
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "redis"},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Instance: cloudcontrolv1beta1.RedisInstanceInfo{
+				Azure: &cloudcontrolv1beta1.RedisInstanceAzure{SKU: sku},
+			},
+		},
+	}
+	meta.SetStatusCondition(&redisInstance.Status.Conditions, metav1.Condition{
+		Type:   cloudcontrolv1beta1.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: cloudcontrolv1beta1.ReasonReady,
+	})
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(redisInstance).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: redisInstance.Namespace, Name: redisInstance.Name}, redisInstance),
+	)
+	focalState.SetScope(&cloudcontrolv1beta1.Scope{
+		Spec: cloudcontrolv1beta1.ScopeSpec{
+			Region: "westeurope",
+			Scope: cloudcontrolv1beta1.ScopeInfo{
+				Azure: &cloudcontrolv1beta1.AzureScope{
+					TenantId:       testTenantId,
+					SubscriptionId: testSubscriptionId,
+					VpcNetwork:     "shoot--dev--kyma",
+				},
+			},
+		},
+	})
+
+	azureMock := azuremock.New()
+	c, err := azureMock.RedisClientProvider()(suite.ctx, "", "", testSubscriptionId, testTenantId)
+	assert.NoError(suite.T(), err)
+
+	return newState(&testRedisInstanceState{State: focalState}, c, azureMock.RedisClientProvider(), "", "", testSubscriptionId, testTenantId), azureMock
+}
+
+func (suite *modifyRedisSuite) createRedis(state *State, azureMock azuremock.Server) {
+	err := state.client.CreateRedisInstance(suite.ctx, state.resourceGroupName, state.Name().Name, getCreateParams(state))
+	assert.NoError(suite.T(), err)
+	err = azureMock.MockConfigs(testSubscriptionId, testTenantId).
+		AzureSetRedisInstanceState(suite.ctx, state.resourceGroupName, state.Name().Name, armredis.ProvisioningStateSucceeded)
+	assert.NoError(suite.T(), err)
+	suite.loadRedis(state)
+}
+
+func (suite *modifyRedisSuite) loadRedis(state *State) {
+	redis, err := state.client.GetRedisInstance(suite.ctx, state.resourceGroupName, state.Name().Name)
+	assert.NoError(suite.T(), err)
+	state.azureRedisInstance = redis
+}
+
+func (suite *modifyRedisSuite) TestCreateInjectsIntoCloudManagerSubnet() {
+	state, azureMock := suite.newState(cloudcontrolv1beta1.AzureRedisSKU{Capacity: 1})
+	suite.createRedis(state, azureMock)
+
+	props := state.azureRedisInstance.Properties
+	assert.Equal(suite.T(),
+		"/subscriptions/subscription/resourceGroups/shoot--dev--kyma--cm/providers/Microsoft.Network/virtualNetworks/shoot--dev--kyma--cm/subnets/shoot--dev--kyma--cm",
+		ptr.Deref(props.SubnetID, ""),
+	)
+	assert.Equal(suite.T(), armredis.SKUNamePremium, ptr.Deref(props.SKU.Name, ""))
+	assert.Equal(suite.T(), armredis.SKUFamilyP, ptr.Deref(props.SKU.Family, ""))
+}
+
+func (suite *modifyRedisSuite) TestCapacityChangeIsScaled() {
+	state, azureMock := suite.newState(cloudcontrolv1beta1.AzureRedisSKU{Capacity: 1})
+	suite.createRedis(state, azureMock)
+
+	// nothing to do while the SKU is unchanged
+	err, _ := modifyRedis(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	state.ObjAsRedisInstance().Spec.Instance.Azure.SKU.Capacity = 2
+	err, _ = modifyRedis(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+	assert.NotNil(suite.T(), meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeScaling))
+
+	suite.loadRedis(state)
+	assert.Equal(suite.T(), int32(2), ptr.Deref(state.azureRedisInstance.Properties.SKU.Capacity, 0))
+
+	// the Scaling condition is kept while Azure is scaling
+	err = azureMock.MockConfigs(testSubscriptionId, testTenantId).
+		AzureSetRedisInstanceState(suite.ctx, state.resourceGroupName, state.Name().Name, armredis.ProvisioningStateScaling)
+	assert.NoError(suite.T(), err)
+	suite.loadRedis(state)
+
+	err, _ = waitRedisAvailable(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(util.Timing.T60000ms()), err)
+	assert.NotNil(suite.T(), meta.FindStatusCondition(state.ObjAsRedisInstance().Status.Conditions, cloudcontrolv1beta1.ConditionTypeScaling))
+}
+
+func (suite *modifyRedisSuite) TestStandardTierRequiresPremium() {
+	state, _ := suite.newState(cloudcontrolv1beta1.AzureRedisSKU{Tier: cloudcontrolv1beta1.AzureRedisTierStandard, Capacity: 1})
+
+	err, _ := validateSku(suite.ctx, state)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	saved := &cloudcontrolv1beta1.RedisInstance{}
+	assert.NoError(suite.T(), state.Cluster().K8sClient().Get(suite.ctx, state.Name(), saved))
+	cond := meta.FindStatusCondition(saved.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonVnetInjectionRequiresPremium, cond.Reason)
+}
+
+func (suite *modifyRedisSuite) TestPremiumTierIsValid() {
+	state, _ := suite.newState(cloudcontrolv1beta1.AzureRedisSKU{Capacity: 1})
+
+	err, _ := validateSku(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+}
+
+func TestModifyRedis(t *testing.T) {
+	suite.Run(t, new(modifyRedisSuite))
+}
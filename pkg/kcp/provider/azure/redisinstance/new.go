@@ -39,6 +39,13 @@ func New(stateFactory StateFactory) composed.Action {
 			composed.IfElse(composed.Not(composed.MarkedForDeletionPredicate),
 				composed.ComposeActions(
 					"azure-redisInstance-create",
+					validateSku,
+					checkNameAvailability,
+					actions.ValidateTags(
+						v1beta1.ProviderAzure,
+						func(st composed.State) map[string]string { return getTags(st.(*State)) },
+						func(st composed.State, tags map[string]string) { st.(*State).tags = tags },
+					),
 					//createResourceGroup,
 					createRedis,
 					updateStatusId,
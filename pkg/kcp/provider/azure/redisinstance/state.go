@@ -6,8 +6,10 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
 	"github.com/go-logr/logr"
 	azureclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/client"
+	azurecommon "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/common"
 	azureconfig "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/config"
 	azureredisclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/redisinstance/client"
+	azureutil "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/util"
 	redisinstancetypes "github.com/kyma-project/cloud-manager/pkg/kcp/redisinstance/types"
 )
 
@@ -22,8 +24,13 @@ type State struct {
 	tenantId       string
 
 	resourceGroupName string
+	// subnetId is the cloud-manager subnet the Redis instance is injected into
+	subnetId string
 
 	azureRedisInstance *armredis.ResourceInfo
+
+	// tags are the validated tags put on the Azure Redis instance
+	tags map[string]string
 }
 
 type StateFactory interface {
@@ -73,5 +80,11 @@ func newState(state redisinstancetypes.State,
 		tenantId:       tenantId,
 
 		resourceGroupName: fmt.Sprintf("cm-redis-%s", state.Obj().GetName()), // TODO this should be specified somehow
+		subnetId:          getCloudManagerSubnetId(state, subscriptionId),
 	}
 }
+
+func getCloudManagerSubnetId(state redisinstancetypes.State, subscriptionId string) string {
+	name := azurecommon.AzureCloudManagerResourceGroupName(state.Scope().Spec.Scope.Azure.VpcNetwork)
+	return azureutil.NewSubnetResourceId(subscriptionId, name, name, name).String()
+}
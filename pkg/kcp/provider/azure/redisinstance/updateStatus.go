@@ -19,6 +19,7 @@ func updateStatus(ctx context.Context, st composed.State) (error, context.Contex
 		*state.azureRedisInstance.Properties.HostName,
 		*state.azureRedisInstance.Properties.Port,
 	)
+	redisInstance.Status.Connection = newConnection(state.azureRedisInstance.Properties)
 	resourceGroupName := state.resourceGroupName
 	keys, err := state.client.GetRedisInstanceAccessKeys(ctx, resourceGroupName, state.ObjAsRedisInstance().Name)
 
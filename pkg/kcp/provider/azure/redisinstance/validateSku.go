@@ -0,0 +1,49 @@
+package redisinstance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validateSku stops the reconciliation with the VnetInjectionRequiresPremium reason if the requested tier
+// can not be injected into the cloud-manager subnet
+func validateSku(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+
+	tier := getSkuTier(redisInstance)
+	if tier == armredis.SKUNamePremium {
+		return nil, nil
+	}
+
+	condition := metav1.Condition{
+		Type:    cloudcontrolv1beta1.ConditionTypeError,
+		Status:  metav1.ConditionTrue,
+		Reason:  cloudcontrolv1beta1.ReasonVnetInjectionRequiresPremium,
+		Message: fmt.Sprintf("VNet injection requires the Premium tier, but %s is specified", tier),
+	}
+	if !composed.AnyConditionChanged(redisInstance, condition) {
+		return composed.StopAndForget, nil
+	}
+
+	redisInstance.SetStatusStateToError()
+
+	return composed.UpdateStatus(redisInstance).
+		SetExclusiveConditions(condition).
+		ErrorLogMessage("Error updating KCP RedisInstance status with VnetInjectionRequiresPremium condition").
+		SuccessLogMsg("Azure RedisInstance tier does not support VNet injection").
+		SuccessError(composed.StopAndForget).
+		Run(ctx, state)
+}
+
+func getSkuTier(redisInstance *cloudcontrolv1beta1.RedisInstance) armredis.SKUName {
+	if redisInstance.Spec.Instance.Azure.SKU.Tier == "" {
+		return armredis.SKUNamePremium
+	}
+	return armredis.SKUName(redisInstance.Spec.Instance.Azure.SKU.Tier)
+}
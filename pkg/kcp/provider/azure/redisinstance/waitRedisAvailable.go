@@ -33,6 +33,19 @@ func waitRedisAvailable(ctx context.Context, st composed.State) (error, context.
 		return nil, nil
 	}
 
+	if ptr.Deref(state.azureRedisInstance.Properties.ProvisioningState, "") == armredis.ProvisioningStateScaling {
+		logger.Info("Azure Redis instance is scaling, requeuing with delay")
+		redisInstance := state.ObjAsRedisInstance()
+		if !composed.AnyConditionChanged(redisInstance, scalingCondition()) {
+			return composed.StopWithRequeueDelay(util.Timing.T60000ms()), nil
+		}
+		return composed.UpdateStatus(redisInstance).
+			SetCondition(scalingCondition()).
+			ErrorLogMessage("Error updating KCP RedisInstance status with Scaling condition").
+			SuccessError(composed.StopWithRequeueDelay(util.Timing.T60000ms())).
+			Run(ctx, state)
+	}
+
 	logger.Info("Azure Redis instance is not ready yet, requeuing with delay")
 	return composed.StopWithRequeueDelay(util.Timing.T60000ms()), nil
 }
@@ -33,7 +33,7 @@ func peeringRemoteRequireSpecifiedName(ctx context.Context, st composed.State) (
 	cond := meta.FindStatusCondition(state.ObjAsVpcPeering().Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
 	if cond == nil {
 		changed = true
-	} else if cond.Status != metav1.ConditionTrue || cond.Reason != cloudcontrolv1beta1.ConditionTypeError || cond.Message != conditionMessage {
+	} else if cond.Status != metav1.ConditionTrue || cond.Reason != cloudcontrolv1beta1.ConditionTypeError || !composed.ConditionMessageEquals(*cond, conditionMessage) {
 		changed = true
 	}
 
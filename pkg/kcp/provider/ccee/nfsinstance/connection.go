@@ -0,0 +1,20 @@
+package nfsinstance
+
+import (
+	"strings"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// manilaMountOptions are the mount options for the Manila NFS shares
+var manilaMountOptions = []string{"nfsvers=4.1", "hard", "timeo=600", "retrans=2"}
+
+// newConnection returns the connection of the share export location, which has no mount targets since
+// it is reachable from all zones
+func newConnection(host, path string) *cloudcontrolv1beta1.NfsConnection {
+	return &cloudcontrolv1beta1.NfsConnection{
+		Host:         host,
+		Path:         "/" + strings.TrimPrefix(path, "/"),
+		MountOptions: manilaMountOptions,
+	}
+}
@@ -20,12 +20,12 @@ func shareCreate(ctx context.Context, st composed.State) (error, context.Context
 
 	logger.Info("Creating CCEE share")
 
-	metadata := map[string]string{
+	metadata := common.TagsWithPropagated(cloudcontrolv1beta1.ProviderOpenStack, state.Scope(), state.ObjAsNfsInstance().Spec.Tags, map[string]string{
 		common.TagCloudManagerName:       state.Obj().GetName(),
 		common.TagCloudManagerRemoteName: state.ObjAsNfsInstance().Spec.RemoteRef.Name,
 		common.TagScope:                  state.ObjAsNfsInstance().Spec.Scope.Name,
 		common.TagShoot:                  state.Scope().Spec.ShootName,
-	}
+	})
 	share, err := state.cceeClient.CreateShare(
 		ctx,
 		state.shareNetwork.ID,
@@ -2,6 +2,8 @@ package nfsinstance
 
 import (
 	"context"
+	"reflect"
+
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -29,6 +31,12 @@ func statusReady(ctx context.Context, st composed.State) (error, context.Context
 		changed = true
 	}
 
+	connection := newConnection(state.ObjAsNfsInstance().Status.Host, state.ObjAsNfsInstance().Status.Path)
+	if !reflect.DeepEqual(state.ObjAsNfsInstance().Status.Connection, connection) {
+		state.ObjAsNfsInstance().Status.Connection = connection
+		changed = true
+	}
+
 	if !changed {
 		return nil, nil
 	}
@@ -121,6 +121,7 @@ type FilestoreState string
 
 const (
 	READY    FilestoreState = "READY"
+	UPDATING FilestoreState = "UPDATING"
 	DELETING FilestoreState = "DELETING"
 	ERROR    FilestoreState = "ERROR"
 )
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpmeta "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/meta"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+)
+
+type DnsClient interface {
+	GetManagedZone(ctx context.Context, projectId, zoneName string) (*dns.ManagedZone, error)
+	GetResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) (*dns.ResourceRecordSet, error)
+	CreateResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error
+	PatchResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error
+	DeleteResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) error
+}
+
+func NewDnsClientProvider() client.ClientProvider[DnsClient] {
+	return client.NewCachedClientProvider(
+		func(ctx context.Context, saJsonKeyPath string) (DnsClient, error) {
+			httpClient, err := client.GetCachedGcpClient(ctx, saJsonKeyPath)
+			if err != nil {
+				return nil, err
+			}
+
+			dnsSvc, err := dns.NewService(ctx, option.WithHTTPClient(httpClient))
+			if err != nil {
+				return nil, fmt.Errorf("error obtaining GCP DNS Client: [%w]", err)
+			}
+			return NewDnsClient(dnsSvc), nil
+		},
+	)
+}
+
+func NewDnsClient(svcDns *dns.Service) DnsClient {
+	return &dnsClient{svcDns: svcDns}
+}
+
+type dnsClient struct {
+	svcDns *dns.Service
+}
+
+func (c *dnsClient) GetManagedZone(ctx context.Context, projectId, zoneName string) (*dns.ManagedZone, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	out, err := c.svcDns.ManagedZones.Get(projectId, zoneName).Context(ctx).Do()
+	client.IncrementCallCounter("Dns", "ManagedZones.Get", "", err)
+	if err != nil {
+		logger.Error(err, "GetManagedZone", "projectId", projectId, "zoneName", zoneName)
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dnsClient) GetResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) (*dns.ResourceRecordSet, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	out, err := c.svcDns.ResourceRecordSets.Get(projectId, zoneName, name, recordType).Context(ctx).Do()
+	client.IncrementCallCounter("Dns", "ResourceRecordSets.Get", "", err)
+	if err != nil {
+		logger.V(4).Info("GetResourceRecordSet", "err", err)
+	}
+	return out, err
+}
+
+func (c *dnsClient) CreateResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error {
+	logger := composed.LoggerFromCtx(ctx)
+	_, err := c.svcDns.ResourceRecordSets.Create(projectId, zoneName, rrs).Context(ctx).Do()
+	client.IncrementCallCounter("Dns", "ResourceRecordSets.Create", "", err)
+	if err != nil {
+		logger.Error(err, "CreateResourceRecordSet", "projectId", projectId, "zoneName", zoneName, "name", rrs.Name)
+	}
+	return err
+}
+
+func (c *dnsClient) PatchResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error {
+	logger := composed.LoggerFromCtx(ctx)
+	_, err := c.svcDns.ResourceRecordSets.Patch(projectId, zoneName, rrs.Name, rrs.Type, rrs).Context(ctx).Do()
+	client.IncrementCallCounter("Dns", "ResourceRecordSets.Patch", "", err)
+	if err != nil {
+		logger.Error(err, "PatchResourceRecordSet", "projectId", projectId, "zoneName", zoneName, "name", rrs.Name)
+	}
+	return err
+}
+
+func (c *dnsClient) DeleteResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) error {
+	logger := composed.LoggerFromCtx(ctx)
+	_, err := c.svcDns.ResourceRecordSets.Delete(projectId, zoneName, name, recordType).Context(ctx).Do()
+	client.IncrementCallCounter("Dns", "ResourceRecordSets.Delete", "", err)
+	if err != nil {
+		logger.Error(err, "DeleteResourceRecordSet", "projectId", projectId, "zoneName", zoneName, "name", name)
+	}
+	return err
+}
+
+// NewRecordClient returns the provider-agnostic DNS client of the Cloud DNS managed zones in the project
+func NewRecordClient(c DnsClient, projectId string) kcpdns.Client {
+	return &recordClient{dnsClient: c, projectId: projectId}
+}
+
+type recordClient struct {
+	dnsClient DnsClient
+	projectId string
+}
+
+func (c *recordClient) GetZoneDnsName(ctx context.Context, zoneName string) (string, error) {
+	zone, err := c.dnsClient.GetManagedZone(ctx, c.projectId, zoneName)
+	if err != nil {
+		return "", err
+	}
+	return zone.DnsName, nil
+}
+
+func (c *recordClient) GetRecord(ctx context.Context, zoneName, name, recordType string) (*kcpdns.Record, error) {
+	rrs, err := c.dnsClient.GetResourceRecordSet(ctx, c.projectId, zoneName, name, recordType)
+	if gcpmeta.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &kcpdns.Record{
+		Name:   rrs.Name,
+		Type:   rrs.Type,
+		Ttl:    rrs.Ttl,
+		Values: rrs.Rrdatas,
+	}, nil
+}
+
+func (c *recordClient) UpsertRecord(ctx context.Context, zoneName string, record kcpdns.Record) error {
+	rrs := &dns.ResourceRecordSet{
+		Name:    record.Name,
+		Type:    record.Type,
+		Ttl:     record.Ttl,
+		Rrdatas: record.Values,
+	}
+	existing, err := c.GetRecord(ctx, zoneName, record.Name, record.Type)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return c.dnsClient.CreateResourceRecordSet(ctx, c.projectId, zoneName, rrs)
+	}
+	return c.dnsClient.PatchResourceRecordSet(ctx, c.projectId, zoneName, rrs)
+}
+
+func (c *recordClient) DeleteRecord(ctx context.Context, zoneName, name, recordType string) error {
+	err := c.dnsClient.DeleteResourceRecordSet(ctx, c.projectId, zoneName, name, recordType)
+	if gcpmeta.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -15,6 +15,10 @@ type ComputeClient interface {
 	DeleteIpRange(ctx context.Context, projectId, name string) (*compute.Operation, error)
 	GetIpRange(ctx context.Context, projectId, name string) (*compute.Address, error)
 	GetGlobalOperation(ctx context.Context, projectId, operationName string) (*compute.Operation, error)
+	// ListSubnetworks returns the subnetworks of the VPC in the region
+	ListSubnetworks(ctx context.Context, projectId, region, vpc string) ([]*compute.Subnetwork, error)
+	// PatchSubnetworkSecondaryRanges replaces all the secondary ranges of the subnetwork with the given ones
+	PatchSubnetworkSecondaryRanges(ctx context.Context, projectId, region string, subnetwork *compute.Subnetwork, ranges []*compute.SubnetworkSecondaryRange) (*compute.Operation, error)
 }
 
 func NewComputeClient() client.ClientProvider[ComputeClient] {
@@ -98,3 +102,28 @@ func (c *computeClient) GetGlobalOperation(ctx context.Context, projectId, opera
 	}
 	return out, nil
 }
+
+func (c *computeClient) ListSubnetworks(ctx context.Context, projectId, region, vpc string) ([]*compute.Subnetwork, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	filter := client.GetNetworkFilter(projectId, vpc)
+	out, err := c.svcCompute.Subnetworks.List(projectId, region).Filter(filter).Do()
+	client.IncrementCallCounter("Compute", "Subnetworks.List", region, err)
+	if err != nil {
+		logger.Error(err, "ListSubnetworks", "projectId", projectId, "region", region, "vpc", vpc)
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+func (c *computeClient) PatchSubnetworkSecondaryRanges(ctx context.Context, projectId, region string, subnetwork *compute.Subnetwork, ranges []*compute.SubnetworkSecondaryRange) (*compute.Operation, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	operation, err := c.svcCompute.Subnetworks.Patch(projectId, region, subnetwork.Name, &compute.Subnetwork{
+		Fingerprint:       subnetwork.Fingerprint,
+		SecondaryIpRanges: ranges,
+		// the empty list removes all the secondary ranges
+		ForceSendFields: []string{"SecondaryIpRanges"},
+	}).Do()
+	client.IncrementCallCounter("Compute", "Subnetworks.Patch", region, err)
+	logger.V(4).Info("PatchSubnetworkSecondaryRanges", "operation", operation, "err", err)
+	return operation, err
+}
@@ -12,7 +12,9 @@ func copyCidrToStatus(ctx context.Context, st composed.State) (error, context.Co
 		return nil, nil
 	}
 
-	state.ObjAsIpRange().Status.Cidr = state.ObjAsIpRange().Spec.Cidr
+	if cidrs := state.ObjAsIpRange().SpecCidrs(); len(cidrs) > 0 {
+		state.ObjAsIpRange().Status.Cidr = cidrs[0]
+	}
 
 	return composed.PatchStatus(state.ObjAsIpRange()).
 		SuccessErrorNil().
@@ -99,6 +99,14 @@ func (c *computeClientStub) GetGlobalOperation(ctx context.Context, projectId st
 	panic("unimplemented")
 }
 
+func (c *computeClientStub) ListSubnetworks(ctx context.Context, projectId, region, vpc string) ([]*compute.Subnetwork, error) {
+	panic("unimplemented")
+}
+
+func (c *computeClientStub) PatchSubnetworkSecondaryRanges(ctx context.Context, projectId, region string, subnetwork *compute.Subnetwork, ranges []*compute.SubnetworkSecondaryRange) (*compute.Operation, error) {
+	panic("unimplemented")
+}
+
 func (c *computeClientStub) GetIpRange(ctx context.Context, projectId string, name string) (*compute.Address, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -40,6 +40,7 @@ func New(stateFactory StateFactory) composed.Action {
 			copyCidrToStatus,
 			validateCidr,
 			actions.AddFinalizer,
+			syncSubnetSecondaryRanges,
 			checkGcpOperation,
 			loadAddress,
 			updateStatusId,
@@ -97,6 +97,45 @@ func (suite *preventCidrEditSuite) TestWhenCidrIsSetNotEqualNotReady() {
 	assert.Len(suite.T(), newIpRange.Status.Conditions, 0)
 }
 
+func (suite *preventCidrEditSuite) TestWhenCidrIsSetNotEqualReady() {
+	fakeHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+	}))
+	defer fakeHttpServer.Close()
+
+	factory, err := newTestStateFactory(fakeHttpServer)
+	assert.Nil(suite.T(), err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//Get state object with ipRange
+	ipRange := gcpIpRange.DeepCopy()
+	ipRange.Status.Cidr = "10.10.10.0/24"
+	ipRange.Status.Conditions = []metav1.Condition{
+		{
+			Type:    cloudcontrolv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudcontrolv1beta1.ReasonReady,
+			Message: "Ready",
+		},
+	}
+	state, err := factory.newStateWith(ctx, ipRange)
+	assert.Nil(suite.T(), err)
+
+	//Invoke the function under test
+	//The fake client does not support server side apply, so only the stopped flow
+	//and the conditions set on the object are checked
+	err, _ = preventCidrEdit(ctx, state)
+	assert.NotNil(suite.T(), err)
+	newIpRange := state.ObjAsIpRange()
+
+	// check error condition in status
+	assert.Len(suite.T(), newIpRange.Status.Conditions, 1)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ConditionTypeError, newIpRange.Status.Conditions[0].Type)
+	assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonCidrCanNotChange, newIpRange.Status.Conditions[0].Reason)
+}
+
 func (suite *preventCidrEditSuite) TestWhenSpecCidrIsNotSetReady() {
 	fakeHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
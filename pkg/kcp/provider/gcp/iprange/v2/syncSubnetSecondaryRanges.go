@@ -0,0 +1,252 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// privateAddressSpace is where the subnet secondary ranges can be placed
+var privateAddressSpace = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10"}
+
+// syncSubnetSecondaryRanges adds the spec.secondaryRanges to the subnet of the Scope nodes network and
+// removes the ones added before that are no longer in the spec, recorded in the status. On delete all
+// the added ranges are removed. The secondary ranges of the subnet not added by the IpRange are kept.
+func syncSubnetSecondaryRanges(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	ipRange := state.ObjAsIpRange()
+
+	desired := ipRange.Spec.SecondaryRanges
+	if composed.IsMarkedForDeletion(ipRange) {
+		desired = nil
+	}
+	if len(desired) == 0 && len(ipRange.Status.SecondaryRanges) == 0 {
+		return nil, nil
+	}
+
+	gcpScope := state.Scope().Spec.Scope.Gcp
+	region := state.Scope().Spec.Region
+
+	subnetworks, err := state.computeClient.ListSubnetworks(ctx, gcpScope.Project, region, gcpScope.VpcNetwork)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing GCP subnetworks", composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpRetryWaitTime), ctx)
+	}
+
+	var subnetwork *compute.Subnetwork
+	for _, s := range subnetworks {
+		if s.IpCidrRange == gcpScope.Network.Nodes {
+			subnetwork = s
+			break
+		}
+	}
+	if subnetwork == nil {
+		if len(desired) == 0 {
+			// the subnet is gone with its secondary ranges
+			ipRange.Status.SecondaryRanges = nil
+			return composed.PatchStatus(ipRange).
+				SuccessErrorNil().
+				Run(ctx, state)
+		}
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    v1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1beta1.ReasonSubnetNotFound,
+				Message: fmt.Sprintf("Subnet of the nodes network %s not found in the VPC %s", gcpScope.Network.Nodes, gcpScope.VpcNetwork),
+			}).
+			SuccessError(composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpRetryWaitTime)).
+			SuccessLogMsg("Subnet of the nodes network for the secondary ranges not found").
+			Run(ctx, state)
+	}
+
+	owned := map[string]struct{}{}
+	for _, r := range ipRange.Status.SecondaryRanges {
+		owned[r.Name] = struct{}{}
+	}
+
+	if err := validateSecondaryRanges(ipRange, desired, subnetwork, subnetworks, owned); err != nil {
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    v1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1beta1.ReasonInvalidSecondaryRange,
+				Message: err.Error(),
+			}).
+			SuccessError(composed.StopAndForget).
+			SuccessLogMsg("Error updating IpRange status due to invalid secondary range.").
+			Run(ctx, state)
+	}
+
+	desiredByName := map[string]string{}
+	for _, r := range desired {
+		desiredByName[r.Name] = r.Cidr
+	}
+
+	var ranges []*compute.SubnetworkSecondaryRange
+	inSync := true
+	for _, r := range subnetwork.SecondaryIpRanges {
+		_, isOwned := owned[r.RangeName]
+		cidr, isDesired := desiredByName[r.RangeName]
+		if isDesired && cidr == r.IpCidrRange {
+			delete(desiredByName, r.RangeName)
+		} else if isDesired || isOwned {
+			inSync = false
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	for _, r := range desired {
+		if _, missing := desiredByName[r.Name]; missing {
+			inSync = false
+			ranges = append(ranges, &compute.SubnetworkSecondaryRange{RangeName: r.Name, IpCidrRange: r.Cidr})
+		}
+	}
+
+	if inSync {
+		if secondaryRangesEqual(ipRange.Status.SecondaryRanges, desired) {
+			return nil, nil
+		}
+		ipRange.Status.SecondaryRanges = append([]v1beta1.IpRangeSecondaryRange(nil), desired...)
+		return composed.PatchStatus(ipRange).
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	logger.
+		WithValues(
+			"subnetwork", subnetwork.Name,
+			"secondaryRanges", fmt.Sprintf("%v", desired),
+		).
+		Info("Updating GCP subnetwork secondary ranges")
+
+	_, err = state.computeClient.PatchSubnetworkSecondaryRanges(ctx, gcpScope.Project, region, subnetwork, ranges)
+	if err != nil {
+		return composed.PatchStatus(ipRange).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    v1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1beta1.ReasonGcpError,
+				Message: err.Error(),
+			}).
+			SuccessError(composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpRetryWaitTime)).
+			SuccessLogMsg(fmt.Sprintf("Error updating GCP subnetwork secondary ranges :%s", err)).
+			Run(ctx, state)
+	}
+
+	// the ranges being removed are kept in the status until they are gone from the subnet
+	ipRange.Status.SecondaryRanges = mergeSecondaryRanges(ipRange.Status.SecondaryRanges, desired)
+	return composed.PatchStatus(ipRange).
+		SuccessError(composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpOperationWaitTime)).
+		Run(ctx, state)
+}
+
+// validateSecondaryRanges checks the desired ranges are within the private address space and do not overlap
+// each other, the IpRange cidr, or the primary and the secondary ranges of the VPC subnets in the region,
+// except the ones added by the IpRange
+func validateSecondaryRanges(ipRange *v1beta1.IpRange, desired []v1beta1.IpRangeSecondaryRange, subnetwork *compute.Subnetwork, subnetworks []*compute.Subnetwork, owned map[string]struct{}) error {
+	type usedRange struct {
+		name string
+		cidr *net.IPNet
+	}
+	var used []usedRange
+	addUsed := func(name, cidr string) {
+		if _, n, err := net.ParseCIDR(cidr); err == nil {
+			used = append(used, usedRange{name: name, cidr: n})
+		}
+	}
+	addUsed("IpRange cidr", ipRange.Status.Cidr)
+	for _, s := range subnetworks {
+		addUsed(fmt.Sprintf("subnet %s", s.Name), s.IpCidrRange)
+		for _, r := range s.SecondaryIpRanges {
+			if s.Name == subnetwork.Name {
+				if _, isOwned := owned[r.RangeName]; isOwned {
+					continue
+				}
+				if isDesiredRange(desired, r) {
+					// added before its ownership was recorded
+					continue
+				}
+			}
+			addUsed(fmt.Sprintf("secondary range %s of subnet %s", r.RangeName, s.Name), r.IpCidrRange)
+		}
+	}
+
+	for _, r := range desired {
+		_, n, err := net.ParseCIDR(r.Cidr)
+		if err != nil {
+			return fmt.Errorf("secondary range %s has invalid cidr %s: %w", r.Name, r.Cidr, err)
+		}
+		private := false
+		for _, p := range privateAddressSpace {
+			_, pn, _ := net.ParseCIDR(p)
+			if util.CidrContains(pn, n) {
+				private = true
+				break
+			}
+		}
+		if !private {
+			return fmt.Errorf("secondary range %s cidr %s is not within the private address space", r.Name, r.Cidr)
+		}
+		for _, u := range used {
+			if util.CidrOverlap(n, u.cidr) {
+				return fmt.Errorf("secondary range %s cidr %s overlaps with the %s", r.Name, r.Cidr, u.name)
+			}
+		}
+		for _, s := range subnetwork.SecondaryIpRanges {
+			if _, isOwned := owned[s.RangeName]; s.RangeName == r.Name && !isOwned && s.IpCidrRange != r.Cidr {
+				return fmt.Errorf("secondary range %s already exists in the subnet %s", r.Name, subnetwork.Name)
+			}
+		}
+		used = append(used, usedRange{name: fmt.Sprintf("secondary range %s", r.Name), cidr: n})
+	}
+
+	return nil
+}
+
+func isDesiredRange(desired []v1beta1.IpRangeSecondaryRange, r *compute.SubnetworkSecondaryRange) bool {
+	for _, d := range desired {
+		if d.Name == r.RangeName && d.Cidr == r.IpCidrRange {
+			return true
+		}
+	}
+	return false
+}
+
+func secondaryRangesEqual(a, b []v1beta1.IpRangeSecondaryRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSecondaryRanges returns the desired ranges followed by the current ones not desired anymore
+func mergeSecondaryRanges(current, desired []v1beta1.IpRangeSecondaryRange) []v1beta1.IpRangeSecondaryRange {
+	result := append([]v1beta1.IpRangeSecondaryRange(nil), desired...)
+	for _, c := range current {
+		found := false
+		for _, d := range desired {
+			if d.Name == c.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package v2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type syncSubnetSecondaryRangesSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *syncSubnetSecondaryRangesSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *syncSubnetSecondaryRangesSuite) newState(ipRange *cloudcontrolv1beta1.IpRange) (*State, gcpmock.Server) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ipRange).
+		WithStatusSubresource(ipRange).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support the server side apply used to patch the status
+			SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	focalState := focal.NewStateFactory().NewState(
+		composed.NewStateFactory(cluster).NewState(types.NamespacedName{Namespace: ipRange.Namespace, Name: ipRange.Name}, ipRange),
+	)
+	scope := gcpScope.DeepCopy()
+	scope.Spec.Scope.Gcp.Network.Nodes = "10.250.0.0/22"
+	focalState.SetScope(scope)
+
+	gcpMock := gcpmock.New()
+	nodes := gcpMock.AddSubnetwork("test-project", "us-west1", "test-vpc", "nodes", "10.250.0.0/22")
+	nodes.SecondaryIpRanges = []*compute.SubnetworkSecondaryRange{
+		{RangeName: "foreign", IpCidrRange: "10.96.0.0/16"},
+	}
+	gcpMock.AddSubnetwork("test-project", "us-west1", "test-vpc", "other", "10.251.0.0/22")
+
+	return newState(newTypesState(focalState), gcpMock, gcpMock), gcpMock
+}
+
+func newIpRangeWithSecondaryRanges(ranges ...cloudcontrolv1beta1.IpRangeSecondaryRange) *cloudcontrolv1beta1.IpRange {
+	ipRange := gcpIpRange.DeepCopy()
+	ipRange.Spec.SecondaryRanges = ranges
+	ipRange.Status.Cidr = ipRange.Spec.Cidr
+	return ipRange
+}
+
+func subnetworkRangeNames(subnetwork *compute.Subnetwork) []string {
+	var result []string
+	for _, r := range subnetwork.SecondaryIpRanges {
+		result = append(result, r.RangeName)
+	}
+	return result
+}
+
+func (suite *syncSubnetSecondaryRangesSuite) TestAddAndRemove() {
+	pods := cloudcontrolv1beta1.IpRangeSecondaryRange{Name: "pods", Cidr: "10.100.0.0/16"}
+	services := cloudcontrolv1beta1.IpRangeSecondaryRange{Name: "services", Cidr: "10.101.0.0/20"}
+	ipRange := newIpRangeWithSecondaryRanges(pods, services)
+	state, gcpMock := suite.newState(ipRange)
+
+	err, _ := syncSubnetSecondaryRanges(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpOperationWaitTime), err, "requeued until the subnet is updated")
+	assert.Equal(suite.T(), []string{"foreign", "pods", "services"}, subnetworkRangeNames(gcpMock.GetSubnetwork("nodes")))
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.IpRangeSecondaryRange{pods, services}, ipRange.Status.SecondaryRanges)
+
+	err, _ = syncSubnetSecondaryRanges(suite.ctx, state)
+	assert.Nil(suite.T(), err, "in sync")
+
+	// services removed from the spec
+	ipRange.Spec.SecondaryRanges = []cloudcontrolv1beta1.IpRangeSecondaryRange{pods}
+	err, _ = syncSubnetSecondaryRanges(suite.ctx, state)
+
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(gcpclient.GcpConfig.GcpOperationWaitTime), err)
+	assert.Equal(suite.T(), []string{"foreign", "pods"}, subnetworkRangeNames(gcpMock.GetSubnetwork("nodes")))
+	assert.Len(suite.T(), ipRange.Status.SecondaryRanges, 2, "kept until gone from the subnet")
+
+	err, _ = syncSubnetSecondaryRanges(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []cloudcontrolv1beta1.IpRangeSecondaryRange{pods}, ipRange.Status.SecondaryRanges)
+}
+
+func (suite *syncSubnetSecondaryRangesSuite) TestRemovedOnDelete() {
+	pods := cloudcontrolv1beta1.IpRangeSecondaryRange{Name: "pods", Cidr: "10.100.0.0/16"}
+	ipRange := newIpRangeWithSecondaryRanges(pods)
+	state, gcpMock := suite.newState(ipRange)
+
+	_, _ = syncSubnetSecondaryRanges(suite.ctx, state)
+	assert.Equal(suite.T(), []string{"foreign", "pods"}, subnetworkRangeNames(gcpMock.GetSubnetwork("nodes")))
+
+	ipRange.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	_, _ = syncSubnetSecondaryRanges(suite.ctx, state)
+	assert.Equal(suite.T(), []string{"foreign"}, subnetworkRangeNames(gcpMock.GetSubnetwork("nodes")), "the foreign range is kept")
+
+	err, _ := syncSubnetSecondaryRanges(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), ipRange.Status.SecondaryRanges)
+}
+
+func (suite *syncSubnetSecondaryRangesSuite) TestInvalid() {
+	for name, r := range map[string]cloudcontrolv1beta1.IpRangeSecondaryRange{
+		"overlaps foreign range": {Name: "pods", Cidr: "10.96.128.0/20"},
+		"overlaps other subnet":  {Name: "pods", Cidr: "10.251.0.0/16"},
+		"overlaps iprange cidr":  {Name: "pods", Cidr: "10.20.0.0/16"},
+		"not private":            {Name: "pods", Cidr: "8.8.0.0/16"},
+		"name taken":             {Name: "foreign", Cidr: "10.100.0.0/16"},
+	} {
+		suite.Run(name, func() {
+			ipRange := newIpRangeWithSecondaryRanges(r)
+			state, gcpMock := suite.newState(ipRange)
+
+			err, _ := syncSubnetSecondaryRanges(suite.ctx, state)
+
+			assert.Equal(suite.T(), composed.StopAndForget, err)
+			cond := meta.FindStatusCondition(ipRange.Status.Conditions, cloudcontrolv1beta1.ConditionTypeError)
+			assert.NotNil(suite.T(), cond)
+			assert.Equal(suite.T(), cloudcontrolv1beta1.ReasonInvalidSecondaryRange, cond.Reason)
+			assert.Equal(suite.T(), []string{"foreign"}, subnetworkRangeNames(gcpMock.GetSubnetwork("nodes")))
+		})
+	}
+}
+
+func TestSyncSubnetSecondaryRanges(t *testing.T) {
+	suite.Run(t, new(syncSubnetSecondaryRangesSuite))
+}
@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+)
+
+type DnsUtils interface {
+	AddDnsManagedZone(projectId, zoneName, dnsName string)
+	GetDnsRecord(projectId, zoneName, name, recordType string) *dns.ResourceRecordSet
+}
+
+type dnsStore struct {
+	m       sync.Mutex
+	zones   map[string]*dns.ManagedZone
+	records map[string]*dns.ResourceRecordSet
+}
+
+func dnsZoneKey(projectId, zoneName string) string {
+	return fmt.Sprintf("%s/%s", projectId, zoneName)
+}
+
+func dnsRecordKey(projectId, zoneName, name, recordType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", projectId, zoneName, name, recordType)
+}
+
+func (s *dnsStore) AddDnsManagedZone(projectId, zoneName, dnsName string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.zones == nil {
+		s.zones = map[string]*dns.ManagedZone{}
+	}
+	s.zones[dnsZoneKey(projectId, zoneName)] = &dns.ManagedZone{
+		Name:       zoneName,
+		DnsName:    dnsName,
+		Visibility: "private",
+	}
+}
+
+func (s *dnsStore) GetDnsRecord(projectId, zoneName, name, recordType string) *dns.ResourceRecordSet {
+	s.m.Lock()
+	defer s.m.Unlock()
+	rrs, ok := s.records[dnsRecordKey(projectId, zoneName, name, recordType)]
+	if !ok {
+		return nil
+	}
+	cpy := *rrs
+	return &cpy
+}
+
+func (s *dnsStore) GetManagedZone(ctx context.Context, projectId, zoneName string) (*dns.ManagedZone, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	zone, ok := s.zones[dnsZoneKey(projectId, zoneName)]
+	if !ok {
+		return nil, &googleapi.Error{Code: 404, Message: fmt.Sprintf("managed zone %s not found", zoneName)}
+	}
+	cpy := *zone
+	return &cpy, nil
+}
+
+func (s *dnsStore) GetResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) (*dns.ResourceRecordSet, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	rrs := s.GetDnsRecord(projectId, zoneName, name, recordType)
+	if rrs == nil {
+		return nil, &googleapi.Error{Code: 404, Message: fmt.Sprintf("record set %s %s not found", name, recordType)}
+	}
+	return rrs, nil
+}
+
+func (s *dnsStore) CreateResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, ok := s.zones[dnsZoneKey(projectId, zoneName)]; !ok {
+		return &googleapi.Error{Code: 404, Message: fmt.Sprintf("managed zone %s not found", zoneName)}
+	}
+	key := dnsRecordKey(projectId, zoneName, rrs.Name, rrs.Type)
+	if _, ok := s.records[key]; ok {
+		return &googleapi.Error{Code: 409, Message: fmt.Sprintf("record set %s %s already exists", rrs.Name, rrs.Type)}
+	}
+	if s.records == nil {
+		s.records = map[string]*dns.ResourceRecordSet{}
+	}
+	cpy := *rrs
+	s.records[key] = &cpy
+	return nil
+}
+
+func (s *dnsStore) PatchResourceRecordSet(ctx context.Context, projectId, zoneName string, rrs *dns.ResourceRecordSet) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	key := dnsRecordKey(projectId, zoneName, rrs.Name, rrs.Type)
+	if _, ok := s.records[key]; !ok {
+		return &googleapi.Error{Code: 404, Message: fmt.Sprintf("record set %s %s not found", rrs.Name, rrs.Type)}
+	}
+	cpy := *rrs
+	s.records[key] = &cpy
+	return nil
+}
+
+func (s *dnsStore) DeleteResourceRecordSet(ctx context.Context, projectId, zoneName, name, recordType string) error {
+	if isContextCanceled(ctx) {
+		return context.Canceled
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	key := dnsRecordKey(projectId, zoneName, name, recordType)
+	if _, ok := s.records[key]; !ok {
+		return &googleapi.Error{Code: 404, Message: fmt.Sprintf("record set %s %s not found", name, recordType)}
+	}
+	delete(s.records, key)
+	return nil
+}
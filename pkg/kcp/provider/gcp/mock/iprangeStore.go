@@ -13,6 +13,28 @@ import (
 type iprangeStore struct {
 	connections []*servicenetworking.Connection
 	addresses   []*compute.Address
+	subnetworks []*compute.Subnetwork
+}
+
+func (s *iprangeStore) AddSubnetwork(projectId, region, vpc, name, cidr string) *compute.Subnetwork {
+	subnetwork := &compute.Subnetwork{
+		Name:        name,
+		Region:      region,
+		Network:     client.GetVPCPath(projectId, vpc),
+		IpCidrRange: cidr,
+		Fingerprint: "1",
+	}
+	s.subnetworks = append(s.subnetworks, subnetwork)
+	return subnetwork
+}
+
+func (s *iprangeStore) GetSubnetwork(name string) *compute.Subnetwork {
+	for _, subnetwork := range s.subnetworks {
+		if subnetwork.Name == name {
+			return subnetwork
+		}
+	}
+	return nil
 }
 
 func (s *iprangeStore) ListServiceConnections(ctx context.Context, projectId, vpcId string) ([]*servicenetworking.Connection, error) {
@@ -177,3 +199,41 @@ func (s *iprangeStore) GetGlobalOperation(ctx context.Context, projectId, operat
 
 	return nil, nil
 }
+
+func (s *iprangeStore) ListSubnetworks(ctx context.Context, projectId, region, vpc string) ([]*compute.Subnetwork, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+
+	nw := client.GetVPCPath(projectId, vpc)
+	var result []*compute.Subnetwork
+	for _, subnetwork := range s.subnetworks {
+		if subnetwork.Region == region && subnetwork.Network == nw {
+			result = append(result, subnetwork)
+		}
+	}
+	return result, nil
+}
+
+func (s *iprangeStore) PatchSubnetworkSecondaryRanges(ctx context.Context, projectId, region string, subnetwork *compute.Subnetwork, ranges []*compute.SubnetworkSecondaryRange) (*compute.Operation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+
+	existing := s.GetSubnetwork(subnetwork.Name)
+	if existing == nil {
+		return nil, &googleapi.Error{
+			Code:    404,
+			Message: "Not able to find the subnetwork",
+		}
+	}
+	if existing.Fingerprint != subnetwork.Fingerprint {
+		return nil, &googleapi.Error{
+			Code:    412,
+			Message: "Fingerprint mismatch",
+		}
+	}
+	existing.SecondaryIpRanges = ranges
+	existing.Fingerprint = fmt.Sprintf("%s1", existing.Fingerprint)
+	return nil, nil
+}
@@ -67,6 +67,7 @@ func (memoryStoreClientFake *memoryStoreClientFake) UpdateRedisInstance(ctx cont
 		instance.RedisConfigs = redisInstance.RedisConfigs
 		instance.MaintenancePolicy = redisInstance.MaintenancePolicy
 		instance.AuthEnabled = redisInstance.AuthEnabled
+		instance.Labels = redisInstance.Labels
 	}
 
 	return nil
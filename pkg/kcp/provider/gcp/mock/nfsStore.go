@@ -8,10 +8,12 @@ import (
 	"google.golang.org/api/file/v1"
 	"google.golang.org/api/googleapi"
 	"strings"
+	"time"
 )
 
 type nfsStore struct {
 	instances      []*file.Instance
+	backups        []*file.Backup
 	createError    *googleapi.Error
 	patchError     *googleapi.Error
 	deleteError    *googleapi.Error
@@ -141,6 +143,67 @@ func (s *nfsStore) PatchFilestoreInstance(ctx context.Context, projectId, locati
 	}
 }
 
+func (s *nfsStore) ListFilestoreBackups(ctx context.Context, projectId, location string, labels map[string]string) ([]*file.Backup, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	prefix := fmt.Sprintf("projects/%s/locations/%s/backups/", projectId, location)
+	var result []*file.Backup
+	for _, backup := range s.backups {
+		if !strings.HasPrefix(backup.Name, prefix) {
+			continue
+		}
+		matches := true
+		for k, v := range labels {
+			if backup.Labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, backup)
+		}
+	}
+	return result, nil
+}
+
+func (s *nfsStore) CreateFilestoreBackup(ctx context.Context, projectId, location, backupId string, backup *file.Backup) (*file.Operation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	completeId := fmt.Sprintf("projects/%s/locations/%s/backups/%s", projectId, location, backupId)
+	for _, existing := range s.backups {
+		if existing.Name == completeId {
+			return nil, &googleapi.Error{
+				Code:    409,
+				Message: "Resource already exists",
+			}
+		}
+	}
+	backup.Name = completeId
+	backup.State = "READY"
+	backup.CreateTime = time.Now().UTC().Format(time.RFC3339)
+	s.backups = append(s.backups, backup)
+	return newOperation("", false), nil
+}
+
+func (s *nfsStore) DeleteFilestoreBackup(ctx context.Context, projectId, location, backupId string) (*file.Operation, error) {
+	if isContextCanceled(ctx) {
+		return nil, context.Canceled
+	}
+	completeId := fmt.Sprintf("projects/%s/locations/%s/backups/%s", projectId, location, backupId)
+	for i, backup := range s.backups {
+		if backup.Name == completeId {
+			s.backups = append(s.backups[:i], s.backups[i+1:]...)
+			return newOperation("", false), nil
+		}
+	}
+	return nil, &googleapi.Error{
+		Code:    404,
+		Message: "Resource not found",
+	}
+}
+
 func newOperation(msg string, done bool) *file.Operation {
 	name := uuid.New().String()
 	if msg != "" {
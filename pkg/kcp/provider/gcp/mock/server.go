@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/cloudclient"
+	dnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange/client"
 	backupclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsbackup/client"
 	nfsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
@@ -27,6 +28,7 @@ func New() Server {
 		nfsRestoreStore:   &nfsRestoreStore{},
 		nfsBackupStore:    &nfsBackupStore{},
 		vpcPeeringStore:   &vpcPeeringStore{},
+		dnsStore:          &dnsStore{},
 		memoryStoreClientFake: &memoryStoreClientFake{
 			mutex:          sync.Mutex{},
 			redisInstances: map[string]*redispb.Instance{},
@@ -42,6 +44,7 @@ type server struct {
 	*nfsBackupStore
 	*vpcPeeringStore
 	*memoryStoreClientFake
+	*dnsStore
 }
 
 func (s *server) SetCreateError(err *googleapi.Error) {
@@ -141,3 +144,9 @@ func (s *server) MemoryStoreProviderFake() client.ClientProvider[memoryStoreClie
 		return s, nil
 	}
 }
+
+func (s *server) DnsClientProvider() client.ClientProvider[dnsclient.DnsClient] {
+	return func(ctx context.Context, saJsonKeyPath string) (dnsclient.DnsClient, error) {
+		return s, nil
+	}
+}
@@ -3,12 +3,14 @@ package mock
 import (
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
 	cloudclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/cloudclient"
+	dnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	iprangeclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/iprange/client"
 	backupclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsbackup/client"
 	nfsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
 	restoreclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsrestore/client"
 	memoryStoreClient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/redisinstance/client"
 	gcpvpcpeeringclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/vpcpeering/client"
+	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 )
 
@@ -17,6 +19,11 @@ type IpRangeClient interface {
 	iprangeclient.ServiceNetworkingClient
 }
 
+type IpRangeUtils interface {
+	AddSubnetwork(projectId, region, vpc, name, cidr string) *compute.Subnetwork
+	GetSubnetwork(name string) *compute.Subnetwork
+}
+
 type NfsClient interface {
 	nfsclient.FilestoreClient
 }
@@ -24,6 +31,7 @@ type NfsClient interface {
 type Clients interface {
 	IpRangeClient
 	NfsClient
+	dnsclient.DnsClient
 }
 
 type Providers interface {
@@ -35,6 +43,7 @@ type Providers interface {
 	FileBackupClientProvider() client.ClientProvider[backupclient.FileBackupClient]
 	VpcPeeringProvider() cloudclient.ClientProvider[gcpvpcpeeringclient.VpcPeeringClient]
 	MemoryStoreProviderFake() client.ClientProvider[memoryStoreClient.MemorystoreClient]
+	DnsClientProvider() client.ClientProvider[dnsclient.DnsClient]
 }
 
 // ClientErrors is an interface for setting errors on the mock client to simulate Hyperscaler API errors
@@ -54,4 +63,8 @@ type Server interface {
 	ClientErrors
 
 	MemoryStoreClientFakeUtils
+
+	IpRangeUtils
+
+	DnsUtils
 }
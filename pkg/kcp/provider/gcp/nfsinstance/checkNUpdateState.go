@@ -60,6 +60,7 @@ func checkNUpdateState(ctx context.Context, st composed.State) (error, context.C
 		nfsInstance.Status.Host = pie.First(state.fsInstance.Networks[0].IpAddresses)
 		nfsInstance.Status.Path = state.ObjAsNfsInstance().Spec.Instance.Gcp.FileShareName
 		nfsInstance.Status.CapacityGb = int(state.fsInstance.FileShares[0].CapacityGb)
+		nfsInstance.Status.Connection = newConnection(nfsInstance.Status.Host, nfsInstance.Status.Path)
 		return composed.UpdateStatus(nfsInstance).
 			SetExclusiveConditions(metav1.Condition{
 				Type:    v1beta1.ConditionTypeReady,
@@ -67,7 +68,7 @@ func checkNUpdateState(ctx context.Context, st composed.State) (error, context.C
 				Reason:  v1beta1.ReasonReady,
 				Message: "Filestore instance provisioned in GCP.",
 			}).
-			SuccessError(composed.StopAndForget).
+			SuccessError(readyResult(state)).
 			Run(ctx, state)
 	} else if prevState != state.curState {
 		if state.curState == v1beta1.ErrorState {
@@ -89,3 +90,11 @@ func checkNUpdateState(ctx context.Context, st composed.State) (error, context.C
 
 	return nil, nil
 }
+
+// readyResult returns the result for the ready instance, requeued when the next scheduled backup is due
+func readyResult(state *State) error {
+	if state.nextBackupDelay > 0 {
+		return composed.StopWithRequeueDelay(state.nextBackupDelay)
+	}
+	return composed.StopAndForget
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"sort"
+	"strings"
 
 	"google.golang.org/api/file/v1"
 	"google.golang.org/api/option"
@@ -16,6 +18,10 @@ type FilestoreClient interface {
 	DeleteFilestoreInstance(ctx context.Context, projectId, location, instanceId string) (*file.Operation, error)
 	GetFilestoreOperation(ctx context.Context, projectId, operationName string) (*file.Operation, error)
 	PatchFilestoreInstance(ctx context.Context, projectId, location, instanceId, updateMask string, instance *file.Instance) (*file.Operation, error)
+	// ListFilestoreBackups returns the backups in the location having all the given labels
+	ListFilestoreBackups(ctx context.Context, projectId, location string, labels map[string]string) ([]*file.Backup, error)
+	CreateFilestoreBackup(ctx context.Context, projectId, location, backupId string, backup *file.Backup) (*file.Operation, error)
+	DeleteFilestoreBackup(ctx context.Context, projectId, location, backupId string) (*file.Operation, error)
 }
 
 func NewFilestoreClientProvider() client.ClientProvider[FilestoreClient] {
@@ -98,3 +104,51 @@ func (c *filestoreClient) PatchFilestoreInstance(ctx context.Context, projectId,
 	}
 	return operation, nil
 }
+
+func (c *filestoreClient) ListFilestoreBackups(ctx context.Context, projectId, location string, labels map[string]string) ([]*file.Backup, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	filters := make([]string, 0, len(keys))
+	for _, k := range keys {
+		filters = append(filters, fmt.Sprintf("labels.%s=%q", k, labels[k]))
+	}
+	var result []*file.Backup
+	err := c.svcFilestore.Projects.Locations.Backups.List(client.GetFilestoreParentPath(projectId, location)).
+		Filter(strings.Join(filters, " AND ")).
+		Pages(ctx, func(page *file.ListBackupsResponse) error {
+			result = append(result, page.Backups...)
+			return nil
+		})
+	client.IncrementCallCounter("File", "Backups.List", location, err)
+	if err != nil {
+		logger.Error(err, "ListFilestoreBackups", "projectId", projectId, "location", location)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *filestoreClient) CreateFilestoreBackup(ctx context.Context, projectId, location, backupId string, backup *file.Backup) (*file.Operation, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	operation, err := c.svcFilestore.Projects.Locations.Backups.Create(client.GetFilestoreParentPath(projectId, location), backup).BackupId(backupId).Do()
+	client.IncrementCallCounter("File", "Backups.Create", location, err)
+	if err != nil {
+		logger.Error(err, "CreateFilestoreBackup", "projectId", projectId, "location", location, "backupId", backupId)
+		return nil, err
+	}
+	return operation, nil
+}
+
+func (c *filestoreClient) DeleteFilestoreBackup(ctx context.Context, projectId, location, backupId string) (*file.Operation, error) {
+	logger := composed.LoggerFromCtx(ctx)
+	operation, err := c.svcFilestore.Projects.Locations.Backups.Delete(client.GetFileBackupPath(projectId, location, backupId)).Do()
+	client.IncrementCallCounter("File", "Backups.Delete", location, err)
+	if err != nil {
+		logger.Error(err, "DeleteFilestoreBackup", "projectId", projectId, "location", location, "backupId", backupId)
+		return nil, err
+	}
+	return operation, nil
+}
@@ -0,0 +1,18 @@
+package nfsinstance
+
+import (
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+// filestoreMountOptions are the mount options recommended by GCP for the Filestore instances
+var filestoreMountOptions = []string{"hard", "timeo=600", "retrans=3", "rsize=262144", "wsize=1048576", "resvport", "async"}
+
+// newConnection returns the connection of the Filestore instance, which has no mount targets since its
+// single IP address is reachable from all zones
+func newConnection(host, fileShareName string) *v1beta1.NfsConnection {
+	return &v1beta1.NfsConnection{
+		Host:         host,
+		Path:         "/" + fileShareName,
+		MountOptions: filestoreMountOptions,
+	}
+}
@@ -0,0 +1,16 @@
+package nfsinstance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnection(t *testing.T) {
+	connection := newConnection("10.20.30.2", "vol1")
+
+	assert.Equal(t, "10.20.30.2", connection.Host)
+	assert.Equal(t, "/vol1", connection.Path)
+	assert.Empty(t, connection.MountTargets)
+	assert.Contains(t, connection.MountOptions, "hard")
+}
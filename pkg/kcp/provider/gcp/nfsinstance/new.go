@@ -32,10 +32,27 @@ func New(stateFactory StateFactory) composed.Action {
 		return composed.ComposeActions(
 			"gcsNfsInstance",
 			validateAlways,
+			actions.ValidateTags(
+				v1beta1.ProviderGCP,
+				func(st composed.State) map[string]string { return getBackupLabels(st.(*State).ObjAsNfsInstance()) },
+				func(st composed.State, labels map[string]string) { st.(*State).backupLabels = labels },
+			),
+			actions.ValidateTags(
+				v1beta1.ProviderGCP,
+				func(st composed.State) map[string]string { return getLabels(st.(*State)) },
+				func(st composed.State, labels map[string]string) { st.(*State).labels = labels },
+			),
 			actions.AddFinalizer,
 			checkGcpOperation,
 			loadNfsInstance,
+			reconcileDnsRecord,
 			validatePostCreate,
+			reconcileResize,
+			composed.Optional(
+				v1beta1.ConditionTypeBackupConfigurationFailed,
+				v1beta1.ReasonBackupConfigurationFailed,
+				reconcileBackupSchedule,
+			),
 			checkNUpdateState,
 			checkUpdateMask,
 			syncNfsInstance,
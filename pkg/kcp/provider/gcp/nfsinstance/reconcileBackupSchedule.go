@@ -0,0 +1,242 @@
+package nfsinstance
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"google.golang.org/api/file/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	backupLabelManagedBy   = "managed-by"
+	backupLabelNfsInstance = "nfs-instance"
+
+	minBackupInterval = time.Hour
+)
+
+// backupScheduleFunc returns the time the next backup is due after the given time
+type backupScheduleFunc func(last time.Time) time.Time
+
+// parseBackupSchedule parses the schedule either as an interval like "12h" of at least an hour,
+// or as a cron expression
+func parseBackupSchedule(schedule string) (backupScheduleFunc, error) {
+	if interval, err := time.ParseDuration(schedule); err == nil {
+		if interval < minBackupInterval {
+			return nil, fmt.Errorf("backup interval %s is shorter than %s", schedule, minBackupInterval)
+		}
+		return func(last time.Time) time.Time {
+			return last.Add(interval)
+		}, nil
+	}
+	expr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup schedule %q: %w", schedule, err)
+	}
+	return func(last time.Time) time.Time {
+		return expr.Next(last)
+	}, nil
+}
+
+func getBackupLabels(nfsInstance *v1beta1.NfsInstance) map[string]string {
+	return map[string]string{
+		backupLabelManagedBy:   "cloud-manager",
+		backupLabelNfsInstance: nfsInstance.Name,
+	}
+}
+
+// backupCreateLabels returns the labels of the created backup, the labels of the instance overridden by
+// the backup labels used to list them
+func backupCreateLabels(state *State) map[string]string {
+	result := map[string]string{}
+	maps.Copy(result, state.labels)
+	maps.Copy(result, state.backupLabels)
+	return result
+}
+
+func getBackupCreateTime(backup *file.Backup) time.Time {
+	t, err := time.Parse(time.RFC3339, backup.CreateTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// reconcileBackupSchedule creates the scheduled Filestore backups of the ready instance when they are due
+// by the spec.backup schedule, and deletes the scheduled backups older than the retention, always keeping
+// the latest one. The latest completed backup and the time the next one is due are set in the status, and
+// the time until the next backup is kept in the state for the requeue of the ready instance.
+// An invalid schedule sets the InvalidSchedule error, and the copyToRegion, that is not supported, sets
+// the InvalidBackupCopyRegion error. When the backups are disabled the schedule is
+// removed from the status, and the already created backups are kept.
+func reconcileBackupSchedule(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+	nfsInstance := state.ObjAsNfsInstance()
+
+	if composed.MarkedForDeletionPredicate(ctx, st) {
+		return nil, nil
+	}
+
+	backupOptions := nfsInstance.Spec.Backup
+	if backupOptions == nil || !backupOptions.Enabled {
+		if nfsInstance.Status.Backup == nil {
+			return nil, nil
+		}
+		nfsInstance.Status.Backup = nil
+		return composed.UpdateStatus(nfsInstance).
+			ErrorLogMessage("Error updating KCP NfsInstance status after removing backup schedule").
+			SuccessLogMsg("Backup schedule removed").
+			SuccessErrorNil().
+			Run(ctx, state)
+	}
+
+	next, err := parseBackupSchedule(backupOptions.Schedule)
+	if err != nil {
+		nfsInstance.Status.State = v1beta1.ErrorState
+		return composed.UpdateStatus(nfsInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    v1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1beta1.ReasonInvalidSchedule,
+				Message: err.Error(),
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with invalid backup schedule").
+			SuccessLogMsg("Forgetting NfsInstance with invalid backup schedule").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	if len(backupOptions.CopyToRegion) > 0 {
+		nfsInstance.Status.State = v1beta1.ErrorState
+		return composed.UpdateStatus(nfsInstance).
+			SetExclusiveConditions(metav1.Condition{
+				Type:    v1beta1.ConditionTypeError,
+				Status:  metav1.ConditionTrue,
+				Reason:  v1beta1.ReasonInvalidBackupCopyRegion,
+				Message: "Backup copy to other region is not supported for GCP Filestore",
+			}).
+			ErrorLogMessage("Error updating KCP NfsInstance status with unsupported backup copy").
+			SuccessLogMsg("Forgetting NfsInstance with unsupported backup copy").
+			SuccessError(composed.StopAndForget).
+			Run(ctx, state)
+	}
+
+	if state.fsInstance == nil || state.fsInstance.State != string(client.READY) {
+		return nil, nil
+	}
+
+	project := state.Scope().Spec.Scope.Gcp.Project
+	location := backupOptions.Location
+	if location == "" {
+		location = state.Scope().Spec.Region
+	}
+	labels := state.backupLabels
+
+	backups, err := state.filestoreClient.ListFilestoreBackups(ctx, project, location, labels)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing GCP Filestore backups", composed.StopWithRequeueDelay(client.GcpConfig.GcpRetryWaitTime), ctx)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return getBackupCreateTime(backups[i]).After(getBackupCreateTime(backups[j]))
+	})
+
+	now := time.Now()
+	last := nfsInstance.CreationTimestamp.Time
+	inProgress := false
+	if len(backups) > 0 {
+		last = getBackupCreateTime(backups[0])
+		inProgress = backups[0].State == "CREATING" || backups[0].State == "FINALIZING"
+	}
+	due := next(last)
+
+	if !now.Before(due) && !inProgress {
+		backupId := fmt.Sprintf("cm-%.36s-%s", nfsInstance.Name, now.UTC().Format("20060102150405"))
+		logger.
+			WithValues(
+				"backupId", backupId,
+				"backupLocation", location,
+			).
+			Info("Creating scheduled GCP Filestore backup")
+
+		_, err = state.filestoreClient.CreateFilestoreBackup(ctx, project, location, backupId, &file.Backup{
+			Description:     fmt.Sprintf("Scheduled backup of %s", nfsInstance.Name),
+			SourceInstance:  client.GetFilestoreInstancePath(project, state.getGcpLocation(), fmt.Sprintf("cm-%.60s", nfsInstance.Name)),
+			SourceFileShare: nfsInstance.Spec.Instance.Gcp.FileShareName,
+			Labels:          backupCreateLabels(state),
+		})
+		if err != nil {
+			return composed.UpdateStatus(nfsInstance).
+				SetCondition(metav1.Condition{
+					Type:    v1beta1.ConditionTypeError,
+					Status:  metav1.ConditionTrue,
+					Reason:  v1beta1.ReasonScheduledBackupFailed,
+					Message: fmt.Sprintf("Failed creating scheduled backup: %s", err),
+				}).
+				ErrorLogMessage("Error updating KCP NfsInstance status after failed scheduled backup").
+				SuccessError(composed.StopWithRequeueDelay(client.GcpConfig.GcpRetryWaitTime)).
+				Run(ctx, state)
+		}
+		due = next(now)
+	}
+	if !now.Before(due) {
+		// the previous backup is still in progress
+		due = now.Add(client.GcpConfig.GcpOperationWaitTime)
+	}
+
+	retentionCutoff := now.Add(-time.Duration(backupOptions.RetentionDays) * 24 * time.Hour)
+	for _, backup := range backups[min(1, len(backups)):] {
+		if backup.State != string(client.READY) || !getBackupCreateTime(backup).Before(retentionCutoff) {
+			continue
+		}
+		logger.WithValues("backup", backup.Name).Info("Deleting GCP Filestore backup past retention")
+		if _, err := state.filestoreClient.DeleteFilestoreBackup(ctx, project, location, path.Base(backup.Name)); err != nil {
+			// retried on the next backup
+			logger.Error(err, "Error deleting GCP Filestore backup past retention")
+		}
+	}
+
+	state.nextBackupDelay = time.Until(due)
+
+	status := &v1beta1.BackupStatus{
+		NextBackupTime: &metav1.Time{Time: due.Truncate(time.Second)},
+	}
+	for _, backup := range backups {
+		if backup.State == string(client.READY) {
+			status.LatestBackupName = backup.Name
+			status.LatestBackupTime = &metav1.Time{Time: getBackupCreateTime(backup)}
+			break
+		}
+	}
+	if isBackupStatusEqual(nfsInstance.Status.Backup, status) {
+		return nil, nil
+	}
+	nfsInstance.Status.Backup = status
+	return composed.UpdateStatus(nfsInstance).
+		ErrorLogMessage("Error updating KCP NfsInstance status with backup schedule").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
+
+func isBackupStatusEqual(a, b *v1beta1.BackupStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	timeEqual := func(x, y *metav1.Time) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Equal(y)
+	}
+	return a.LatestBackupName == b.LatestBackupName &&
+		timeEqual(a.LatestBackupTime, b.LatestBackupTime) &&
+		timeEqual(a.NextBackupTime, b.NextBackupTime)
+}
@@ -0,0 +1,206 @@
+package nfsinstance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/api/file/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type reconcileBackupScheduleSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileBackupScheduleSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileBackupScheduleSuite) newTestState(nfsInstance *v1beta1.NfsInstance, handler http.HandlerFunc) (*testStateFactory, *TestState, func()) {
+	fakeHttpServer := httptest.NewServer(handler)
+	factory, err := newTestStateFactory(fakeHttpServer, nfsInstance)
+	assert.Nil(suite.T(), err)
+	testState, err := factory.newStateWith(suite.ctx, nfsInstance, "")
+	assert.Nil(suite.T(), err)
+	testState.fsInstance = &file.Instance{
+		State:      string(gcpclient.READY),
+		FileShares: []*file.FileShareConfig{{Name: "vol1", CapacityGb: 1024}},
+	}
+	testState.backupLabels = getBackupLabels(nfsInstance)
+	return factory, testState, fakeHttpServer.Close
+}
+
+func (suite *reconcileBackupScheduleSuite) TestInvalidSchedule() {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Backup = &v1beta1.BackupOptions{Enabled: true, Schedule: "every day", RetentionDays: 7}
+
+	factory, testState, closeServer := suite.newTestState(gcpNfsInstance, func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+	})
+	defer closeServer()
+
+	err, _ := reconcileBackupSchedule(suite.ctx, testState.State)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	assert.Equal(suite.T(), v1beta1.ErrorState, updatedObject.Status.State)
+	cond := meta.FindStatusCondition(updatedObject.Status.Conditions, v1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), v1beta1.ReasonInvalidSchedule, cond.Reason)
+}
+
+func (suite *reconcileBackupScheduleSuite) TestCopyToRegionIsRejected() {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Backup = &v1beta1.BackupOptions{Enabled: true, Schedule: "0 2 * * *", RetentionDays: 7, CopyToRegion: "europe-west3"}
+
+	factory, testState, closeServer := suite.newTestState(gcpNfsInstance, func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+	})
+	defer closeServer()
+
+	err, _ := reconcileBackupSchedule(suite.ctx, testState.State)
+	assert.Equal(suite.T(), composed.StopAndForget, err)
+
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	assert.Equal(suite.T(), v1beta1.ErrorState, updatedObject.Status.State)
+	cond := meta.FindStatusCondition(updatedObject.Status.Conditions, v1beta1.ConditionTypeError)
+	assert.NotNil(suite.T(), cond)
+	assert.Equal(suite.T(), v1beta1.ReasonInvalidBackupCopyRegion, cond.Reason)
+}
+
+func (suite *reconcileBackupScheduleSuite) TestDueBackupIsCreated() {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Backup = &v1beta1.BackupOptions{Enabled: true, Schedule: "24h", RetentionDays: 7, Location: "us-east1"}
+
+	created := false
+	factory, testState, closeServer := suite.newTestState(gcpNfsInstance, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/projects/test-project/locations/us-east1/backups"):
+			assert.Contains(suite.T(), r.URL.Query().Get("filter"), `labels.nfs-instance="test-gcp-nfs-instance"`)
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/projects/test-project/locations/us-east1/backups"):
+			assert.True(suite.T(), strings.HasPrefix(r.URL.Query().Get("backupId"), "cm-test-gcp-nfs-instance-"))
+			b, err := io.ReadAll(r.Body)
+			assert.Nil(suite.T(), err)
+			obj := &file.Backup{}
+			assert.Nil(suite.T(), json.Unmarshal(b, obj))
+			assert.Equal(suite.T(), "projects/test-project/locations/us-west1/instances/cm-test-gcp-nfs-instance", obj.SourceInstance)
+			assert.Equal(suite.T(), "vol1", obj.SourceFileShare)
+			assert.Equal(suite.T(), "cloud-manager", obj.Labels[backupLabelManagedBy])
+			created = true
+			_, _ = w.Write([]byte(`{"name":"backup-operation"}`))
+		default:
+			assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+		}
+	})
+	defer closeServer()
+
+	err, _ := reconcileBackupSchedule(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), created)
+	assert.InDelta(suite.T(), float64(24*time.Hour), float64(testState.nextBackupDelay), float64(time.Minute))
+	assert.Equal(suite.T(), composed.StopWithRequeueDelay(testState.nextBackupDelay), readyResult(testState.State))
+
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	assert.NotNil(suite.T(), updatedObject.Status.Backup)
+	assert.NotNil(suite.T(), updatedObject.Status.Backup.NextBackupTime)
+}
+
+func (suite *reconcileBackupScheduleSuite) TestBackupsPastRetentionAreDeleted() {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Backup = &v1beta1.BackupOptions{Enabled: true, Schedule: "24h", RetentionDays: 7}
+
+	now := time.Now().UTC()
+	latest := now.Add(-time.Hour).Format(time.RFC3339)
+	old := now.Add(-8 * 24 * time.Hour).Format(time.RFC3339)
+	recent := now.Add(-2 * 24 * time.Hour).Format(time.RFC3339)
+	backupPath := "projects/test-project/locations/us-west1/backups/"
+
+	var deleted []string
+	factory, testState, closeServer := suite.newTestState(gcpNfsInstance, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"backups":[
+				{"name":"%[1]sold","state":"READY","createTime":"%[2]s"},
+				{"name":"%[1]slatest","state":"READY","createTime":"%[3]s"},
+				{"name":"%[1]srecent","state":"READY","createTime":"%[4]s"}
+			]}`, backupPath, old, latest, recent)))
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			_, _ = w.Write([]byte(`{"name":"delete-operation"}`))
+		default:
+			assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+		}
+	})
+	defer closeServer()
+
+	// a backup was created within the last hour, so the next one is not due yet
+	err, _ := reconcileBackupSchedule(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), deleted, 1)
+	assert.True(suite.T(), strings.HasSuffix(deleted[0], backupPath+"old"))
+
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	assert.Equal(suite.T(), backupPath+"latest", updatedObject.Status.Backup.LatestBackupName)
+	assert.True(suite.T(), updatedObject.Status.Backup.NextBackupTime.After(now))
+}
+
+func (suite *reconcileBackupScheduleSuite) TestDisabledBackupsRemoveSchedule() {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Backup = &v1beta1.BackupOptions{Enabled: false, Schedule: "24h", RetentionDays: 7}
+	gcpNfsInstance.Status.Backup = &v1beta1.BackupStatus{LatestBackupName: "some-backup"}
+
+	factory, testState, closeServer := suite.newTestState(gcpNfsInstance, func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(suite.T(), "unexpected request: "+r.URL.String())
+	})
+	defer closeServer()
+
+	err, _ := reconcileBackupSchedule(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), composed.StopAndForget, readyResult(testState.State))
+
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	assert.Nil(suite.T(), updatedObject.Status.Backup)
+}
+
+func (suite *reconcileBackupScheduleSuite) TestParseBackupSchedule() {
+	last := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	next, err := parseBackupSchedule("12h")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), last.Add(12*time.Hour), next(last))
+
+	next, err = parseBackupSchedule("0 2 * * *")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC), next(last))
+
+	_, err = parseBackupSchedule("30m")
+	assert.NotNil(suite.T(), err)
+
+	_, err = parseBackupSchedule("every day")
+	assert.NotNil(suite.T(), err)
+}
+
+func TestReconcileBackupSchedule(t *testing.T) {
+	suite.Run(t, new(reconcileBackupScheduleSuite))
+}
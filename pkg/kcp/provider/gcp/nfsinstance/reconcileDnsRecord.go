@@ -0,0 +1,34 @@
+package nfsinstance
+
+import (
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+)
+
+// reconcileDnsRecord keeps the spec.dns record pointing at the IP of the ready Filestore instance,
+// and deletes it once the NfsInstance is marked for deletion. The record is optional, so its failure
+// does not keep the NfsInstance from getting Ready.
+var reconcileDnsRecord = composed.IfElse(
+	composed.MarkedForDeletionPredicate,
+	kcpdns.DeleteRecord(dnsClient),
+	composed.Optional(
+		v1beta1.ConditionTypeDnsRecordFailed,
+		v1beta1.ReasonDnsRecordFailed,
+		kcpdns.ReconcileRecord(dnsClient, filestoreEndpoint),
+	),
+)
+
+func dnsClient(st composed.State) kcpdns.Client {
+	return st.(*State).dnsClient
+}
+
+func filestoreEndpoint(st composed.State) string {
+	state := st.(*State)
+	if state.fsInstance == nil || state.fsInstance.State != string(client.READY) || len(state.fsInstance.Networks) == 0 {
+		return ""
+	}
+	return pie.First(state.fsInstance.Networks[0].IpAddresses)
+}
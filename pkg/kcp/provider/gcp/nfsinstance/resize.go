@@ -0,0 +1,30 @@
+package nfsinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+)
+
+// reconcileResize reconciles the Resizing condition while the Filestore file share capacity differs from
+// the spec. The resize itself is patched by the update flow of checkNUpdateState, checkUpdateMask and
+// syncNfsInstance. Filestore does not report the used capacity, so the shrink is limited only by the
+// tier in validatePostCreate.
+func reconcileResize(ctx context.Context, st composed.State) (error, context.Context) {
+	return composed.ReconcileResize(client.GcpConfig.GcpOperationWaitTime, composed.ResizeFuncs{
+		DesiredCapacityGb: func(st composed.State) int64 {
+			return int64(st.(*State).ObjAsNfsInstance().Spec.Instance.Gcp.CapacityGb)
+		},
+		CurrentCapacityGb: func(st composed.State) (int64, bool) {
+			state := st.(*State)
+			if state.fsInstance == nil || len(state.fsInstance.FileShares) == 0 {
+				return 0, false
+			}
+			return state.fsInstance.FileShares[0].CapacityGb, true
+		},
+		SetStatusCapacityGb: func(st composed.State, capacityGb int64) {
+			st.(*State).ObjAsNfsInstance().Status.CapacityGb = int(capacityGb)
+		},
+	})(ctx, st)
+}
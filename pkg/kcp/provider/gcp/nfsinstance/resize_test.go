@@ -0,0 +1,92 @@
+package nfsinstance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	gcpclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/api/file/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type reconcileResizeSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *reconcileResizeSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *reconcileResizeSuite) newState(capacityGb int, fsInstance *file.Instance) (*testStateFactory, *TestState) {
+	gcpNfsInstance := getGcpNfsInstance()
+	gcpNfsInstance.Spec.Instance.Gcp.CapacityGb = capacityGb
+
+	fakeHttpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(suite.T(), "the resize is patched by the update flow: "+r.URL.String())
+	}))
+	suite.T().Cleanup(fakeHttpServer.Close)
+	factory, err := newTestStateFactory(fakeHttpServer, gcpNfsInstance)
+	assert.Nil(suite.T(), err)
+
+	testState, err := factory.newStateWith(suite.ctx, gcpNfsInstance, "")
+	assert.Nil(suite.T(), err)
+	testState.fsInstance = fsInstance
+	return factory, testState
+}
+
+func (suite *reconcileResizeSuite) loadObj(factory *testStateFactory, testState *TestState) *v1beta1.NfsInstance {
+	updatedObject := &v1beta1.NfsInstance{}
+	assert.Nil(suite.T(), factory.kcpCluster.K8sClient().Get(suite.ctx, testState.Name(), updatedObject))
+	return updatedObject
+}
+
+func (suite *reconcileResizeSuite) TestCapacityChangeIsResizing() {
+	factory, testState := suite.newState(2048, &file.Instance{
+		State:      string(gcpclient.READY),
+		FileShares: []*file.FileShareConfig{{Name: "vol1", CapacityGb: 1024}},
+	})
+
+	err, _ := reconcileResize(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err, "continues to the update flow patching the capacity")
+	assert.NotNil(suite.T(), meta.FindStatusCondition(suite.loadObj(factory, testState).Status.Conditions, composed.ConditionTypeResizing))
+}
+
+func (suite *reconcileResizeSuite) TestUpdatingWithoutCapacityChangeIsNotResizing() {
+	factory, testState := suite.newState(1024, &file.Instance{
+		State:      string(gcpclient.UPDATING),
+		FileShares: []*file.FileShareConfig{{Name: "vol1", CapacityGb: 1024}},
+	})
+
+	err, _ := reconcileResize(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), meta.FindStatusCondition(suite.loadObj(factory, testState).Status.Conditions, composed.ConditionTypeResizing))
+}
+
+func (suite *reconcileResizeSuite) TestResizeCompleted() {
+	factory, testState := suite.newState(2048, &file.Instance{
+		State:      string(gcpclient.UPDATING),
+		FileShares: []*file.FileShareConfig{{Name: "vol1", CapacityGb: 1024}},
+	})
+	err, _ := reconcileResize(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+
+	testState.fsInstance.FileShares[0].CapacityGb = 2048
+	err, _ = reconcileResize(suite.ctx, testState.State)
+	assert.Nil(suite.T(), err)
+	updatedObject := suite.loadObj(factory, testState)
+	assert.Nil(suite.T(), meta.FindStatusCondition(updatedObject.Status.Conditions, composed.ConditionTypeResizing))
+	assert.Equal(suite.T(), 2048, updatedObject.Status.CapacityGb)
+}
+
+func TestReconcileResize(t *testing.T) {
+	suite.Run(t, new(reconcileResizeSuite))
+}
@@ -2,11 +2,15 @@ package nfsinstance
 
 import (
 	"context"
+	"time"
 
 	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance/types"
 	client2 "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
 	"google.golang.org/api/file/v1"
 )
@@ -19,6 +23,13 @@ type State struct {
 	validations     []string
 	fsInstance      *file.Instance
 	filestoreClient client.FilestoreClient
+	dnsClient       kcpdns.Client
+	// nextBackupDelay is the time until the next scheduled backup is due, zero if backups are not scheduled
+	nextBackupDelay time.Duration
+	// backupLabels are the validated labels put on the scheduled backups and used to list them
+	backupLabels map[string]string
+	// labels are the validated spec tags and the labels propagated from the Scope, put on the instance and the backups
+	labels map[string]string
 }
 
 type StateFactory interface {
@@ -27,12 +38,18 @@ type StateFactory interface {
 
 type stateFactory struct {
 	filestoreClientProvider client2.ClientProvider[client.FilestoreClient]
+	dnsClientProvider       client2.ClientProvider[gcpdnsclient.DnsClient]
 	env                     abstractions.Environment
 }
 
-func NewStateFactory(filestoreClientProvider client2.ClientProvider[client.FilestoreClient], env abstractions.Environment) StateFactory {
+func NewStateFactory(
+	filestoreClientProvider client2.ClientProvider[client.FilestoreClient],
+	dnsClientProvider client2.ClientProvider[gcpdnsclient.DnsClient],
+	env abstractions.Environment,
+) StateFactory {
 	return &stateFactory{
 		filestoreClientProvider: filestoreClientProvider,
+		dnsClientProvider:       dnsClientProvider,
 		env:                     env,
 	}
 }
@@ -46,13 +63,21 @@ func (f *stateFactory) NewState(ctx context.Context, nfsInstanceState types.Stat
 	if err != nil {
 		return nil, err
 	}
-	return newState(nfsInstanceState, fc), nil
+	dc, err := f.dnsClientProvider(
+		ctx,
+		f.env.Get("GCP_SA_JSON_KEY_PATH"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newState(nfsInstanceState, fc, gcpdnsclient.NewRecordClient(dc, nfsInstanceState.Scope().Spec.Scope.Gcp.Project)), nil
 }
 
-func newState(nfsInstanceState types.State, fc client.FilestoreClient) *State {
+func newState(nfsInstanceState types.State, fc client.FilestoreClient, dc kcpdns.Client) *State {
 	return &State{
 		State:           nfsInstanceState,
 		filestoreClient: fc,
+		dnsClient:       dc,
 	}
 }
 
@@ -72,6 +97,12 @@ func (s State) getGcpLocation() string {
 	return location
 }
 
+// getLabels returns the labels put on the Filestore instance and its backups, the spec tags and the labels
+// propagated from the Scope
+func getLabels(state *State) map[string]string {
+	return common.TagsWithPropagated(v1beta1.ProviderGCP, state.Scope(), state.ObjAsNfsInstance().Spec.Tags, nil)
+}
+
 func (s State) toInstance() *file.Instance {
 	nfsInstance := s.ObjAsNfsInstance()
 	gcpOptions := nfsInstance.Spec.Instance.Gcp
@@ -84,6 +115,7 @@ func (s State) toInstance() *file.Instance {
 	return &file.Instance{
 		Description: nfsInstance.Name,
 		Tier:        string(gcpOptions.Tier),
+		Labels:      s.labels,
 
 		FileShares: []*file.FileShareConfig{
 			{
@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	nfsTypes "github.com/kyma-project/cloud-manager/pkg/kcp/nfsinstance/types"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	gcpmock "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/mock"
 	client2 "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/nfsinstance/client"
 	"google.golang.org/api/file/v1"
 	"google.golang.org/api/option"
@@ -124,7 +125,7 @@ func newTestStateFactory(fakeHttpServer *httptest.Server, objects ...*cloudcontr
 		Build()
 	kcpCluster := composed.NewStateCluster(kcpClient, kcpClient, nil, kcpScheme)
 	fakeFileStoreClientProvider := NewFakeFilestoreClientProvider(fakeHttpServer)
-	factory := NewStateFactory(fakeFileStoreClientProvider, abstractions.NewMockedEnvironment(map[string]string{"GCP_SA_JSON_KEY_PATH": "test"}))
+	factory := NewStateFactory(fakeFileStoreClientProvider, gcpmock.New().DnsClientProvider(), abstractions.NewMockedEnvironment(map[string]string{"GCP_SA_JSON_KEY_PATH": "test"}))
 
 	return &testStateFactory{
 		factory:                factory,
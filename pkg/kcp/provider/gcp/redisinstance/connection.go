@@ -0,0 +1,19 @@
+package redisinstance
+
+import (
+	"cloud.google.com/go/redis/apiv1/redispb"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+)
+
+func newConnection(instance *redispb.Instance) *cloudcontrolv1beta1.RedisConnection {
+	connection := &cloudcontrolv1beta1.RedisConnection{
+		Host: instance.Host,
+		Port: instance.Port,
+		Tls:  instance.TransitEncryptionMode == redispb.Instance_SERVER_AUTHENTICATION,
+	}
+	if instance.ReadEndpoint != "" {
+		connection.ReadHost = instance.ReadEndpoint
+		connection.ReadPort = instance.ReadEndpointPort
+	}
+	return connection
+}
@@ -0,0 +1,39 @@
+package redisinstance
+
+import (
+	"testing"
+
+	"cloud.google.com/go/redis/apiv1/redispb"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnection(t *testing.T) {
+	t.Run("with read endpoint and TLS", func(t *testing.T) {
+		connection := newConnection(&redispb.Instance{
+			Host:                  "10.0.0.3",
+			Port:                  6378,
+			ReadEndpoint:          "10.0.0.4",
+			ReadEndpointPort:      6378,
+			TransitEncryptionMode: redispb.Instance_SERVER_AUTHENTICATION,
+		})
+
+		assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{
+			Host:     "10.0.0.3",
+			Port:     6378,
+			ReadHost: "10.0.0.4",
+			ReadPort: 6378,
+			Tls:      true,
+		}, connection)
+	})
+
+	t.Run("without read endpoint", func(t *testing.T) {
+		connection := newConnection(&redispb.Instance{
+			Host:                  "10.0.0.3",
+			Port:                  6379,
+			TransitEncryptionMode: redispb.Instance_DISABLED,
+		})
+
+		assert.Equal(t, &cloudcontrolv1beta1.RedisConnection{Host: "10.0.0.3", Port: 6379}, connection)
+	})
+}
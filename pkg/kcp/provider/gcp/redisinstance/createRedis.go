@@ -29,11 +29,6 @@ func createRedis(ctx context.Context, st composed.State) (error, context.Context
 
 	vpcNetworkFullName := fmt.Sprintf("projects/%s/global/networks/%s", gcpScope.Project, gcpScope.VpcNetwork)
 
-	labels := util.NewLabelBuilder().WithGcpLabels(
-		redisInstance.Spec.Scope.Name,
-		state.Scope().Spec.ShootName,
-	).Build()
-
 	redisInstanceOptions := client.CreateRedisInstanceOptions{
 		VPCNetworkFullName:    vpcNetworkFullName,
 		IPRangeName:           state.IpRange().Status.Id,
@@ -44,7 +39,7 @@ func createRedis(ctx context.Context, st composed.State) (error, context.Context
 		TransitEncryptionMode: redisInstance.Spec.Instance.Gcp.TransitEncryptionMode,
 		RedisConfigs:          redisInstance.Spec.Instance.Gcp.RedisConfigs,
 		MaintenancePolicy:     redisInstance.Spec.Instance.Gcp.MaintenancePolicy,
-		Labels:                labels,
+		Labels:                state.labels,
 	}
 
 	_, err := state.memorystoreClient.CreateRedisInstance(ctx, gcpScope.Project, region, state.GetRemoteRedisName(), redisInstanceOptions)
@@ -0,0 +1,38 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// modifyPropagatedLabels adds the spec tags and the labels propagated from the Scope labels and default tags
+// to the update of the existing GCP Redis instance, and removes the ones no longer propagated. The cloud-manager
+// labels take precedence over the spec tags, and the spec tags over the propagated ones.
+var modifyPropagatedLabels = actions.ReconcilePropagatedTags(
+	v1beta1.ProviderGCP,
+	func(st composed.State) []actions.TaggedResource {
+		state := st.(*State)
+		if state.gcpRedisInstance == nil {
+			return nil
+		}
+		return []actions.TaggedResource{{
+			Id:   state.gcpRedisInstance.Name,
+			Tags: state.gcpRedisInstance.Labels,
+		}}
+	},
+	func(st composed.State) map[string]string { return st.(*State).ObjAsRedisInstance().Spec.Tags },
+	func(st composed.State) map[string]string {
+		return getOwnLabels(st.(*State))
+	},
+	func(ctx context.Context, st composed.State, id string, labels map[string]string) error {
+		st.(*State).UpdateLabels(labels, nil)
+		return nil
+	},
+	func(ctx context.Context, st composed.State, id string, keys []string) error {
+		st.(*State).UpdateLabels(nil, keys)
+		return nil
+	},
+)
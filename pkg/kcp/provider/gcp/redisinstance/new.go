@@ -39,20 +39,29 @@ func New(stateFactory StateFactory) composed.Action {
 			composed.IfElse(composed.Not(composed.MarkedForDeletionPredicate),
 				composed.ComposeActions(
 					"redisInstance-create",
+					validateZoneCount,
+					actions.ValidateTags(
+						v1beta1.ProviderGCP,
+						func(st composed.State) map[string]string { return getLabels(st.(*State)) },
+						func(st composed.State, labels map[string]string) { st.(*State).labels = labels },
+					),
 					createRedis,
 					updateStatusId,
 					addUpdatingCondition,
 					waitRedisAvailable,
+					reconcileDnsRecord,
 					modifyMemorySizeGb,
 					modifyRedisConfigs,
 					modifyMaintenancePolicy,
 					modifyAuthEnabled,
+					modifyPropagatedLabels,
 					updateRedis,
 					updateStatus,
 				),
 				composed.ComposeActions(
 					"redisInstance-delete",
 					removeReadyCondition,
+					deleteDnsRecord,
 					deleteRedis,
 					waitRedisDeleted,
 					actions.RemoveFinalizer,
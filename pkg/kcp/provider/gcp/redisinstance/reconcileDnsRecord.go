@@ -0,0 +1,31 @@
+package redisinstance
+
+import (
+	"cloud.google.com/go/redis/apiv1/redispb"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+)
+
+// reconcileDnsRecord keeps the spec.dns record pointing at the host of the ready Memorystore instance.
+// The record is optional, so its failure does not keep the RedisInstance from getting Ready.
+var reconcileDnsRecord = composed.Optional(
+	cloudcontrolv1beta1.ConditionTypeDnsRecordFailed,
+	cloudcontrolv1beta1.ReasonDnsRecordFailed,
+	kcpdns.ReconcileRecord(dnsClient, memorystoreEndpoint),
+)
+
+// deleteDnsRecord deletes the spec.dns record on the RedisInstance teardown
+var deleteDnsRecord = kcpdns.DeleteRecord(dnsClient)
+
+func dnsClient(st composed.State) kcpdns.Client {
+	return st.(*State).dnsClient
+}
+
+func memorystoreEndpoint(st composed.State) string {
+	state := st.(*State)
+	if state.gcpRedisInstance == nil || state.gcpRedisInstance.State != redispb.Instance_READY {
+		return ""
+	}
+	return state.gcpRedisInstance.Host
+}
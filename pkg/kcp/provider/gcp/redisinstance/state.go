@@ -4,12 +4,17 @@ import (
 	"context"
 
 	"cloud.google.com/go/redis/apiv1/redispb"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/common/abstractions"
+	kcpdns "github.com/kyma-project/cloud-manager/pkg/kcp/dns"
+	gcpdnsclient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/dns/client"
 
 	"github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/redisinstance/client"
 	"github.com/kyma-project/cloud-manager/pkg/kcp/redisinstance/types"
 
 	gcpClient "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/client"
+	"github.com/kyma-project/cloud-manager/pkg/util"
 )
 
 type State struct {
@@ -18,8 +23,12 @@ type State struct {
 	gcpRedisInstance     *redispb.Instance
 	gcpRedisInstanceAuth *redispb.InstanceAuthString
 	memorystoreClient    client.MemorystoreClient
+	dnsClient            kcpdns.Client
 
 	updateMask []string
+
+	// labels are the validated labels put on the GCP resources created for the RedisInstance
+	labels map[string]string
 }
 
 type StateFactory interface {
@@ -28,12 +37,18 @@ type StateFactory interface {
 
 type stateFactory struct {
 	memorystoreClientProvider gcpClient.ClientProvider[client.MemorystoreClient]
+	dnsClientProvider         gcpClient.ClientProvider[gcpdnsclient.DnsClient]
 	env                       abstractions.Environment
 }
 
-func NewStateFactory(memorystoreClientProvider gcpClient.ClientProvider[client.MemorystoreClient], env abstractions.Environment) StateFactory {
+func NewStateFactory(
+	memorystoreClientProvider gcpClient.ClientProvider[client.MemorystoreClient],
+	dnsClientProvider gcpClient.ClientProvider[gcpdnsclient.DnsClient],
+	env abstractions.Environment,
+) StateFactory {
 	return &stateFactory{
 		memorystoreClientProvider: memorystoreClientProvider,
+		dnsClientProvider:         dnsClientProvider,
 		env:                       env,
 	}
 }
@@ -47,13 +62,21 @@ func (statefactory *stateFactory) NewState(ctx context.Context, redisInstanceSta
 	if err != nil {
 		return nil, err
 	}
-	return newState(redisInstanceState, memorystoreClient), nil
+	dnsClient, err := statefactory.dnsClientProvider(
+		ctx,
+		statefactory.env.Get("GCP_SA_JSON_KEY_PATH"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newState(redisInstanceState, memorystoreClient, gcpdnsclient.NewRecordClient(dnsClient, redisInstanceState.Scope().Spec.Scope.Gcp.Project)), nil
 }
 
-func newState(redisInstanceState types.State, memorystoreClient client.MemorystoreClient) *State {
+func newState(redisInstanceState types.State, memorystoreClient client.MemorystoreClient, dnsClient kcpdns.Client) *State {
 	return &State{
 		State:             redisInstanceState,
 		memorystoreClient: memorystoreClient,
+		dnsClient:         dnsClient,
 		updateMask:        []string{},
 	}
 }
@@ -85,3 +108,31 @@ func (s *State) UpdateAuthEnabled(authEnabled bool) {
 	s.updateMask = append(s.updateMask, "auth_enabled")
 	s.gcpRedisInstance.AuthEnabled = authEnabled
 }
+
+// UpdateLabels adds the given labels to the GCP Redis instance and removes the labels with the given keys
+func (s *State) UpdateLabels(labels map[string]string, removedKeys []string) {
+	s.updateMask = append(s.updateMask, "labels")
+	if s.gcpRedisInstance.Labels == nil {
+		s.gcpRedisInstance.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		s.gcpRedisInstance.Labels[k] = v
+	}
+	for _, k := range removedKeys {
+		delete(s.gcpRedisInstance.Labels, k)
+	}
+}
+
+// getLabels returns the labels put on the GCP resources created for the RedisInstance, the spec tags and the labels
+// propagated from the Scope included
+func getLabels(state *State) map[string]string {
+	return common.TagsWithPropagated(cloudcontrolv1beta1.ProviderGCP, state.Scope(), state.ObjAsRedisInstance().Spec.Tags, getOwnLabels(state))
+}
+
+// getOwnLabels returns the cloud-manager labels of the GCP resources created for the RedisInstance
+func getOwnLabels(state *State) map[string]string {
+	return util.NewLabelBuilder().WithGcpLabels(
+		state.ObjAsRedisInstance().Spec.Scope.Name,
+		state.Scope().Spec.ShootName,
+	).Build()
+}
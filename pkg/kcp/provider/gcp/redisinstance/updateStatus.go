@@ -32,6 +32,8 @@ func updateStatus(ctx context.Context, st composed.State) (error, context.Contex
 
 	}
 
+	redisInstance.Status.Connection = newConnection(state.gcpRedisInstance)
+
 	authString := ""
 	if state.gcpRedisInstanceAuth != nil {
 		authString = state.gcpRedisInstanceAuth.AuthString
@@ -0,0 +1,25 @@
+package redisinstance
+
+import (
+	"context"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// validateZoneCount checks the cluster of the STANDARD_HA tier instance spans enough
+// zones for the primary and the replica to be placed in different ones
+var validateZoneCount = actions.ValidateZoneCount("RedisInstance", func(ctx context.Context, st composed.State) ([]string, bool) {
+	state := st.(*State)
+	redisInstance := state.ObjAsRedisInstance()
+	highlyAvailable := redisInstance.Spec.Instance.Gcp != nil && redisInstance.Spec.Instance.Gcp.Tier == "STANDARD_HA"
+
+	var zones []string
+	if state.Scope().Spec.Scope.Gcp != nil {
+		for _, w := range state.Scope().Spec.Scope.Gcp.Workers {
+			zones = append(zones, w.Zones...)
+		}
+	}
+	return pie.Unique(zones), highlyAvailable
+})
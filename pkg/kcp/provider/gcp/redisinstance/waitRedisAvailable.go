@@ -10,6 +10,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// memorystorePollResourceType is the resource type of the Memorystore availability poll interval
+const memorystorePollResourceType = "GcpRedisInstance"
+
 func waitRedisAvailable(ctx context.Context, st composed.State) (error, context.Context) {
 	state := st.(*State)
 	logger := composed.LoggerFromCtx(ctx)
@@ -30,9 +33,13 @@ func waitRedisAvailable(ctx context.Context, st composed.State) (error, context.
 	}
 
 	if state.gcpRedisInstance.State == redispb.Instance_READY {
+		composed.ResetPoll(state, memorystorePollResourceType)
 		return nil, nil
 	}
 
 	logger.Info("Redis instance is not ready yet, requeueing with delay")
-	return composed.StopWithRequeueDelay(util.Timing.T60000ms()), nil
+	return composed.StopWithPollRequeue(state, memorystorePollResourceType, composed.PollInterval{
+		Initial: util.Timing.T10000ms(),
+		Max:     util.Timing.T60000ms(),
+	}), nil
 }
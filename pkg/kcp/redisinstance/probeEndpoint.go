@@ -0,0 +1,34 @@
+package redisinstance
+
+import (
+	"context"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// probeEndpoint checks the primary endpoint, or the configuration endpoint of the cluster mode instance
+// that has no primary endpoint, is reachable, and that the plaintext instance replies to the PING
+var probeEndpoint = actions.ProbeEndpoint(func(ctx context.Context, st composed.State) (string, bool) {
+	redisInstance := st.Obj().(*cloudcontrolv1beta1.RedisInstance)
+	endpoint := redisInstance.Status.PrimaryEndpoint
+	if len(endpoint) == 0 {
+		endpoint = redisInstance.Status.ConfigurationEndpoint
+	}
+	return endpoint, !transitEncryptionEnabled(redisInstance)
+})
+
+// transitEncryptionEnabled returns true if the instance endpoint requires TLS
+func transitEncryptionEnabled(redisInstance *cloudcontrolv1beta1.RedisInstance) bool {
+	switch {
+	case redisInstance.Spec.Instance.Gcp != nil:
+		mode := redisInstance.Spec.Instance.Gcp.TransitEncryptionMode
+		return len(mode) > 0 && mode != "DISABLED"
+	case redisInstance.Spec.Instance.Aws != nil:
+		return redisInstance.Spec.Instance.Aws.TransitEncryptionEnabled
+	case redisInstance.Spec.Instance.Azure != nil:
+		return !redisInstance.Spec.Instance.Azure.EnableNonSslPort
+	}
+	return false
+}
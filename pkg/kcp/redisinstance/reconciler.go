@@ -6,13 +6,18 @@ import (
 	awsRedisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/aws/redisinstance"
 	azureRedisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/azure/redisinstance"
 	gcpRedisinstance "github.com/kyma-project/cloud-manager/pkg/kcp/provider/gcp/redisinstance"
+	"github.com/kyma-project/cloud-manager/pkg/kcp/validation"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type RedisInstanceReconciler interface {
@@ -55,9 +60,22 @@ func (r *redisInstanceReconciler) newAction() composed.Action {
 	return composed.ComposeActions(
 		"main",
 		focal.New(),
-		func(ctx context.Context, st composed.State) (error, context.Context) {
+		composed.ReconcileHeartbeat(util.Timing.T300000ms()),
+		composed.PausedUntil,
+		composed.ApiCallLogging,
+		composed.DeletionGracePeriod(actions.FinalizerName()),
+		composed.NotifyTransitions(composed.RecordLastError(composed.ResourceLock(func(ctx context.Context, st composed.State) (error, context.Context) {
 			return composed.ComposeActions(
 				"redisInstanceCommon",
+				composed.PreventDeleteWhileInUseByWorkloads(
+					util.Timing.T10000ms(),
+					composed.LabelSelectorWorkloadReferences(
+						func() client.ObjectList { return &corev1.SecretList{} },
+						cloudcontrolv1beta1.LabelRedisInstanceBinding,
+					),
+				),
+				actions.ValidateSpec(validation.ValidateRedisInstance),
+				probeEndpoint,
 				composed.BuildSwitchAction(
 					"providerSwitch",
 					nil,
@@ -66,7 +84,7 @@ func (r *redisInstanceReconciler) newAction() composed.Action {
 					composed.NewCase(focal.AwsProviderPredicate, awsRedisinstance.New(r.awsStateFactory)),
 				),
 			)(ctx, newState(st.(focal.State)))
-		},
+		}))),
 	)
 }
 
@@ -0,0 +1,92 @@
+package redisinstance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions/focal"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcileDeletionWhileInUseByWorkloads(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cloudcontrolv1beta1.AddToScheme(scheme))
+
+	// the Scope has no provider, so once the deletion is allowed no provider flow runs
+	scope := &cloudcontrolv1beta1.Scope{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcp-system", Name: "scope"},
+	}
+	redisInstance := &cloudcontrolv1beta1.RedisInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "kcp-system",
+			Name:              "redis",
+			Finalizers:        []string{actions.FinalizerName()},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: cloudcontrolv1beta1.RedisInstanceSpec{
+			Scope: cloudcontrolv1beta1.ScopeRef{Name: scope.Name},
+		},
+	}
+	binding := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kcp-system",
+			Name:      "binding",
+			Labels:    map[string]string{cloudcontrolv1beta1.LabelRedisInstanceBinding: redisInstance.Name},
+		},
+	}
+
+	clnt := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(scope, redisInstance, binding).
+		WithStatusSubresource(redisInstance).
+		Build()
+	cluster := composed.NewStateCluster(clnt, clnt, nil, scheme)
+	reconciler := NewRedisInstanceReconciler(composed.NewStateFactory(cluster), focal.NewStateFactory(), nil, nil, nil)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(redisInstance)}
+
+	loadInUseCondition := func() (*cloudcontrolv1beta1.RedisInstance, *metav1.Condition) {
+		loaded := &cloudcontrolv1beta1.RedisInstance{}
+		assert.NoError(t, clnt.Get(ctx, request.NamespacedName, loaded))
+		return loaded, meta.FindStatusCondition(loaded.Status.Conditions, composed.ConditionTypeInUseByWorkloads)
+	}
+
+	t.Run("deletion is held back while bound", func(t *testing.T) {
+		res, err := reconciler.Reconcile(ctx, request)
+
+		assert.NoError(t, err)
+		assert.NotZero(t, res.RequeueAfter)
+		loaded, cond := loadInUseCondition()
+		assert.Contains(t, loaded.Finalizers, actions.FinalizerName())
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Contains(t, cond.Message, "kcp-system/binding")
+		}
+	})
+
+	t.Run("deletion continues once unbound", func(t *testing.T) {
+		assert.NoError(t, clnt.Delete(ctx, binding))
+
+		_, err := reconciler.Reconcile(ctx, request)
+
+		assert.NoError(t, err)
+		_, cond := loadInUseCondition()
+		assert.Nil(t, cond)
+	})
+}
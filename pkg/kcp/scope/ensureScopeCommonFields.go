@@ -3,6 +3,7 @@ package scope
 import (
 	"context"
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -16,7 +17,7 @@ func ensureScopeCommonFields(ctx context.Context, st composed.State) (error, con
 	state.ObjAsScope().Namespace = state.kyma.GetNamespace()
 
 	// set finalizer
-	controllerutil.AddFinalizer(state.ObjAsScope(), cloudcontrolv1beta1.FinalizerName)
+	controllerutil.AddFinalizer(state.ObjAsScope(), actions.FinalizerName())
 
 	// set kyma name in label
 	metav1.SetMetaDataLabel(&state.ObjAsScope().ObjectMeta, cloudcontrolv1beta1.LabelKymaName, state.Obj().GetName())
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/cloud-manager/pkg/common"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -25,7 +26,10 @@ func loadNetworks(ctx context.Context, st composed.State) (error, context.Contex
 
 	netList := &cloudcontrolv1beta1.NetworkList{}
 	listOps := &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(cloudcontrolv1beta1.NetworkFieldScope, kymaName),
+		FieldSelector: fields.OneTermEqualSelector(
+			cloudcontrolv1beta1.NetworkFieldScope,
+			types.NamespacedName{Namespace: state.Name().Namespace, Name: kymaName}.String(),
+		),
 	}
 	if err := state.Cluster().K8sClient().List(ctx, netList, listOps); err != nil {
 		return composed.LogErrorAndReturn(err, "Error listing scope networks", composed.StopWithRequeue, ctx)
@@ -2,7 +2,7 @@ package scope
 
 import (
 	"context"
-	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 )
 
@@ -14,7 +14,7 @@ func removeKymaFinalizer(ctx context.Context, st composed.State) (error, context
 		return nil, ctx
 	}
 
-	removed, err := composed.PatchObjRemoveFinalizer(ctx, cloudcontrolv1beta1.FinalizerName, state.kyma, state.Cluster().K8sClient())
+	removed, err := composed.PatchObjRemoveFinalizer(ctx, actions.FinalizerName(), state.kyma, state.Cluster().K8sClient())
 	if err != nil {
 		return composed.LogErrorAndReturn(err, "Error updating Kyma CR with removed finalizer", composed.StopWithRequeue, ctx)
 	}
@@ -2,7 +2,7 @@ package scope
 
 import (
 	"context"
-	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"github.com/kyma-project/cloud-manager/pkg/util"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -23,7 +23,7 @@ func skrDeactivate(ctx context.Context, st composed.State) (error, context.Conte
 
 	state.activeSkrCollection.RemoveKymaUnstructured(state.kyma)
 
-	finalizerRemoved := controllerutil.RemoveFinalizer(state.Obj(), cloudcontrolv1beta1.FinalizerName)
+	finalizerRemoved := controllerutil.RemoveFinalizer(state.Obj(), actions.FinalizerName())
 	if finalizerRemoved {
 		if err := state.UpdateObj(ctx); err != nil {
 			return composed.LogErrorAndReturn(err, "Error updating Scope after finalizer removed", composed.StopWithRequeue, ctx)
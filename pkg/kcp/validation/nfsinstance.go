@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// AwsEfsMaxProvisionedThroughputMibps is the highest throughput an EFS file system can be provisioned with
+	AwsEfsMaxProvisionedThroughputMibps = 3414
+)
+
+// ValidateNfsInstance checks the NfsInstance spec against the provider hard limits that can not be
+// expressed in the CRD schema, returning the errors of the offending fields
+func ValidateNfsInstance(nfsInstance *cloudcontrolv1beta1.NfsInstance) field.ErrorList {
+	var errs field.ErrorList
+	instancePath := field.NewPath("spec", "instance")
+
+	if aws := nfsInstance.Spec.Instance.Aws; aws != nil {
+		errs = append(errs, validateNfsInstanceAws(aws, instancePath.Child("aws"))...)
+	}
+
+	if gcp := nfsInstance.Spec.Instance.Gcp; gcp != nil {
+		if err := GcpFilestoreCapacity(gcp.Tier, gcp.CapacityGb); err != nil {
+			errs = append(errs, field.Invalid(instancePath.Child("gcp", "capacityGb"), gcp.CapacityGb, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func validateNfsInstanceAws(aws *cloudcontrolv1beta1.NfsInstanceAws, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	throughput := aws.Throughput
+	if len(throughput) == 0 {
+		throughput = cloudcontrolv1beta1.AwsThroughputModeBursting
+	}
+
+	if aws.ProvisionedThroughput != nil {
+		if throughput != cloudcontrolv1beta1.AwsThroughputModeProvisioned {
+			errs = append(errs, field.Forbidden(path.Child("provisionedThroughput"),
+				fmt.Sprintf("provisioned throughput is not supported with the %s throughput mode", throughput)))
+		} else if aws.ProvisionedThroughput.Max > AwsEfsMaxProvisionedThroughputMibps {
+			errs = append(errs, field.Invalid(path.Child("provisionedThroughput", "max"), aws.ProvisionedThroughput.Max,
+				fmt.Sprintf("must be at most %d MiB/s", AwsEfsMaxProvisionedThroughputMibps)))
+		}
+	}
+
+	if aws.PerformanceMode == cloudcontrolv1beta1.AwsPerformanceModeBursting {
+		if throughput == cloudcontrolv1beta1.AwsThroughputModeElastic {
+			errs = append(errs, field.Invalid(path.Child("throughput"), throughput,
+				fmt.Sprintf("elastic throughput mode is not supported with the %s performance mode", aws.PerformanceMode)))
+		}
+		if len(aws.OneZone) > 0 {
+			errs = append(errs, field.Invalid(path.Child("performanceMode"), aws.PerformanceMode,
+				"performance mode is not supported by the One Zone file system"))
+		}
+	}
+
+	return errs
+}
+
+// GcpFilestoreCapacity returns an error if the capacity is not within the limits of the Filestore tier
+func GcpFilestoreCapacity(tier cloudcontrolv1beta1.GcpFileTier, capacityGb int) error {
+	switch tier {
+	case cloudcontrolv1beta1.BASIC_HDD, cloudcontrolv1beta1.STANDARD:
+		if capacityGb < 1024 {
+			return errors.New("Capacity should be > 1 TB")
+		} else if capacityGb > 65433 {
+			return errors.New("Capacity should be < 63.9 TB")
+		}
+	case cloudcontrolv1beta1.BASIC_SSD, cloudcontrolv1beta1.PREMIUM:
+		if capacityGb < 2560 {
+			return errors.New("Capacity should be > 2.5 TB")
+		} else if capacityGb > 65433 {
+			return errors.New("Capacity should be < 63.9 TB")
+		}
+	case cloudcontrolv1beta1.ZONAL, cloudcontrolv1beta1.ENTERPRISE, cloudcontrolv1beta1.REGIONAL:
+		if capacityGb < 1024 {
+			return errors.New("Capacity should be > 1 TB")
+		} else if capacityGb > 10240 {
+			return errors.New("Capacity should be < 10 TB")
+		} else if capacityGb%256 != 0 {
+			return errors.New("Capacity should be in increments of 256 GBs")
+		}
+	case cloudcontrolv1beta1.HIGH_SCALE_SSD:
+		if capacityGb < 10240 {
+			return errors.New("Capacity should be > 10 TB")
+		} else if capacityGb > 102400 {
+			return errors.New("Capacity should be < 100 TB")
+		} else if capacityGb%2560 != 0 {
+			return errors.New("Capacity should be in increments of 2560 GBs")
+		}
+	default:
+		return errors.New("Unknown Tier")
+	}
+	return nil
+}
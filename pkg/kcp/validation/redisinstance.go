@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"fmt"
+
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// AwsElastiCacheMaxNodes is the highest number of nodes of an ElastiCache cluster mode replication group
+	AwsElastiCacheMaxNodes = 500
+
+	// AzureRedisMaxReplicasPerPrimary is the highest number of replicas per primary of the Premium tier
+	AzureRedisMaxReplicasPerPrimary = 3
+)
+
+// gcpRedisMemorySizeGb are the min and max memory size in GiB of the Memorystore tiers
+var gcpRedisMemorySizeGb = map[string][2]int32{
+	"BASIC":       {1, 300},
+	"STANDARD_HA": {5, 300},
+}
+
+// ValidateRedisInstance checks the RedisInstance spec against the provider hard limits that can not be
+// expressed in the CRD schema, returning the errors of the offending fields
+func ValidateRedisInstance(redisInstance *cloudcontrolv1beta1.RedisInstance) field.ErrorList {
+	var errs field.ErrorList
+	instancePath := field.NewPath("spec", "instance")
+
+	if aws := redisInstance.Spec.Instance.Aws; aws != nil && aws.Shards != nil {
+		shards := *aws.Shards
+		replicas := ptr.Deref(aws.ReplicasPerShard, 0)
+		if nodes := shards * (replicas + 1); nodes > AwsElastiCacheMaxNodes {
+			errs = append(errs, field.Invalid(instancePath.Child("aws", "shards"), shards,
+				fmt.Sprintf("%d shards with %d replicas each have %d nodes, the cluster can have at most %d",
+					shards, replicas, nodes, AwsElastiCacheMaxNodes)))
+		}
+	}
+
+	if gcp := redisInstance.Spec.Instance.Gcp; gcp != nil {
+		if limits, ok := gcpRedisMemorySizeGb[gcp.Tier]; ok && (gcp.MemorySizeGb < limits[0] || gcp.MemorySizeGb > limits[1]) {
+			errs = append(errs, field.Invalid(instancePath.Child("gcp", "memorySizeGb"), gcp.MemorySizeGb,
+				fmt.Sprintf("must be between %d and %d GiB for the %s tier", limits[0], limits[1], gcp.Tier)))
+		}
+	}
+
+	if azure := redisInstance.Spec.Instance.Azure; azure != nil {
+		azurePath := instancePath.Child("azure")
+		premium := len(azure.SKU.Tier) == 0 || azure.SKU.Tier == cloudcontrolv1beta1.AzureRedisTierPremium
+		if !premium && azure.ShardCount > 0 {
+			errs = append(errs, field.Forbidden(azurePath.Child("shardCount"),
+				fmt.Sprintf("shards are not supported by the %s tier", azure.SKU.Tier)))
+		}
+		if !premium && azure.ReplicasPerPrimary > 0 {
+			errs = append(errs, field.Forbidden(azurePath.Child("replicasPerPrimary"),
+				fmt.Sprintf("replicas per primary are not supported by the %s tier", azure.SKU.Tier)))
+		} else if azure.ReplicasPerPrimary > AzureRedisMaxReplicasPerPrimary {
+			errs = append(errs, field.Invalid(azurePath.Child("replicasPerPrimary"), azure.ReplicasPerPrimary,
+				fmt.Sprintf("must be at most %d", AzureRedisMaxReplicasPerPrimary)))
+		}
+	}
+
+	if redisInstance.Spec.Instance.Aws == nil && len(redisInstance.Spec.MaintenanceFreeze) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "maintenanceFreeze"),
+			"maintenance freeze is supported only for AWS"))
+	}
+
+	return errs
+}
@@ -65,7 +65,7 @@ func (r *vpcPeeringReconciler) newAction() composed.Action {
 		"main",
 		feature.LoadFeatureContextFromObj(&cloudcontrolb1beta1.VpcPeering{}),
 		focal.New(),
-		func(ctx context.Context, st composed.State) (error, context.Context) {
+		composed.ResourceLock(func(ctx context.Context, st composed.State) (error, context.Context) {
 			return composed.ComposeActions(
 				"vpcPeeringCommon",
 				composed.BuildSwitchAction(
@@ -76,6 +76,6 @@ func (r *vpcPeeringReconciler) newAction() composed.Action {
 					composed.NewCase(focal.GcpProviderPredicate, gcp.New(r.gcpStateFactory)),
 				),
 			)(ctx, newState(st.(focal.State)))
-		},
+		}),
 	)
 }
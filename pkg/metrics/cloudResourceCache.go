@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	CloudResourceCacheHit = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_manager_cloud_resource_cache_hit_total",
+		Help: "Total number of cloud resource reads served from the inventory cache per provider, resource and region",
+	}, []string{"provider", "resource", "region"})
+
+	CloudResourceCacheMiss = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_manager_cloud_resource_cache_miss_total",
+		Help: "Total number of cloud resource reads not found in the inventory cache per provider, resource and region",
+	}, []string{"provider", "resource", "region"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		CloudResourceCacheHit,
+		CloudResourceCacheMiss,
+	)
+}
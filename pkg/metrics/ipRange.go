@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	IpRangeSubnetAvailableIps = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_manager_iprange_subnet_available_ips",
+		Help: "Number of available IP addresses per IpRange subnet, as reported by the cloud provider",
+	}, []string{"namespace", "iprange", "zone", "subnet"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		IpRangeSubnetAvailableIps,
+	)
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	IpRangePoolAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_manager_iprange_pool_available",
+		Help: "Number of ready and unclaimed IpRanges per IpRangePool",
+	}, []string{"namespace", "pool"})
+
+	IpRangePoolAllocated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_manager_iprange_pool_allocated",
+		Help: "Number of claimed IpRanges per IpRangePool",
+	}, []string{"namespace", "pool"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		IpRangePoolAvailable,
+		IpRangePoolAllocated,
+	)
+}
@@ -0,0 +1,11 @@
+package metrics
+
+// ResetGauges removes all the label values of the gauges that are set by the reconcilers, so
+// the values reported during the previous leadership term do not go stale after a failover.
+// The collectors stay registered, and the reconcilers set the values again.
+func ResetGauges() {
+	IpRangePoolAvailable.Reset()
+	IpRangePoolAllocated.Reset()
+	IpRangeSubnetAvailableIps.Reset()
+	SkrRuntimeModuleActiveCount.Reset()
+}
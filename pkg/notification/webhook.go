@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	defaultQueueSize = 100
+	defaultTimeout   = 5 * time.Second
+	defaultRetries   = 3
+	defaultBackoff   = time.Second
+)
+
+// WebhookNotifier POSTs the notifications as JSON to the configured url. Notifications are queued
+// and delivered asynchronously by the runnable added to the manager, so Notify never blocks the
+// reconciliation. Each delivery is retried with a short timeout, and notifications that could not be
+// delivered, or that do not fit in the full queue, are dropped and logged.
+type WebhookNotifier interface {
+	composed.Notifier
+	manager.Runnable
+}
+
+func NewWebhookNotifier(url string, logger logr.Logger) WebhookNotifier {
+	return &webhookNotifier{
+		url:     url,
+		logger:  logger.WithName("webhook-notifier"),
+		client:  &http.Client{Timeout: defaultTimeout},
+		queue:   make(chan composed.Notification, defaultQueueSize),
+		retries: defaultRetries,
+		backoff: defaultBackoff,
+	}
+}
+
+type webhookNotifier struct {
+	url     string
+	logger  logr.Logger
+	client  *http.Client
+	queue   chan composed.Notification
+	retries int
+	backoff time.Duration
+}
+
+func (w *webhookNotifier) Notify(_ context.Context, n composed.Notification) {
+	select {
+	case w.queue <- n:
+	default:
+		w.logWithNotification(n).Info("Notification queue is full, dropping notification")
+	}
+}
+
+func (w *webhookNotifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-w.queue:
+			w.deliver(ctx, n)
+		}
+	}
+}
+
+func (w *webhookNotifier) deliver(ctx context.Context, n composed.Notification) {
+	body, err := json.Marshal(n)
+	if err != nil {
+		w.logWithNotification(n).Error(err, "Error marshaling notification")
+		return
+	}
+
+	for attempt := 1; attempt <= w.retries; attempt++ {
+		err = w.post(ctx, body)
+		if err == nil {
+			return
+		}
+		if attempt < w.retries {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.backoff * time.Duration(attempt)):
+			}
+		}
+	}
+
+	w.logWithNotification(n).Error(err, "Error delivering notification to webhook")
+}
+
+func (w *webhookNotifier) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *webhookNotifier) logWithNotification(n composed.Notification) logr.Logger {
+	return w.logger.WithValues(
+		"kind", n.Kind,
+		"name", n.Name,
+		"namespace", n.Namespace,
+		"transition", n.Transition,
+		"reconcileId", n.ReconcileId,
+	)
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("notification is delivered after failed attempt", func(t *testing.T) {
+		var attempts atomic.Int32
+		received := make(chan composed.Notification, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var n composed.Notification
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+			received <- n
+		}))
+		defer server.Close()
+
+		notifier := NewWebhookNotifier(server.URL, logr.Discard()).(*webhookNotifier)
+		notifier.backoff = time.Millisecond
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = notifier.Start(ctx)
+		}()
+
+		notifier.Notify(ctx, composed.Notification{
+			Kind:        "IpRange",
+			Name:        "some-name",
+			Namespace:   "kcp-system",
+			Transition:  composed.NotificationTransitionReady,
+			ReconcileId: "some-id",
+		})
+
+		select {
+		case n := <-received:
+			assert.Equal(t, "IpRange", n.Kind)
+			assert.Equal(t, "some-name", n.Name)
+			assert.Equal(t, "kcp-system", n.Namespace)
+			assert.Equal(t, composed.NotificationTransitionReady, n.Transition)
+			assert.Equal(t, "some-id", n.ReconcileId)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "notification not delivered")
+		}
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("notify does not block when queue is full", func(t *testing.T) {
+		notifier := NewWebhookNotifier("http://localhost", logr.Discard())
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < defaultQueueSize+10; i++ {
+				notifier.Notify(context.Background(), composed.Notification{})
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "notify blocked")
+		}
+	})
+}
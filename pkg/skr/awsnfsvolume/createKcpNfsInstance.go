@@ -47,6 +47,7 @@ func createKcpNfsInstance(ctx context.Context, st composed.State) (error, contex
 				Aws: &cloudcontrolv1beta1.NfsInstanceAws{
 					PerformanceMode: cloudcontrolv1beta1.AwsPerformanceMode(state.ObjAsAwsNfsVolume().Spec.PerformanceMode),
 					Throughput:      cloudcontrolv1beta1.AwsThroughputMode(state.ObjAsAwsNfsVolume().Spec.Throughput),
+					Regions:         state.ObjAsAwsNfsVolume().Spec.Regions,
 				},
 			},
 		},
@@ -0,0 +1,21 @@
+package awsnfsvolume
+
+import (
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mirrorKcpConditions surfaces the warnings of the optional features of the KCP NfsInstance, like the backups,
+// that do not affect its readiness
+var mirrorKcpConditions = actions.MirrorConditions(
+	func(st composed.State) ([]metav1.Condition, bool) {
+		state := st.(*State)
+		if state.KcpNfsInstance == nil {
+			return nil, false
+		}
+		return state.KcpNfsInstance.Status.Conditions, true
+	},
+	cloudresourcesv1beta1.ConditionTypeBackupConfigurationFailed,
+)
@@ -62,7 +62,10 @@ func (r *reconciler) newAction() composed.Action {
 		updateId,
 		loadKcpNfsInstance,
 		createKcpNfsInstance,
+		updateKcpNfsInstanceRegions,
+		updateConnection,
 		updateStatus,
+		mirrorKcpConditions,
 		createVolume,
 		createPersistentVolumeClaim,
 		requeueWaitKcpStatus,
@@ -81,6 +84,8 @@ func (r *reconciler) newAction() composed.Action {
 		deleteKcpNfsInstance,
 		waitKcpNfsInstanceDeleted,
 
+		defaultiprange.DeleteAutoProvisionedIpRange,
+
 		removeFinalizer,
 
 		composed.StopAndForgetAction,
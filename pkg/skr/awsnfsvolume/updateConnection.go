@@ -0,0 +1,31 @@
+package awsnfsvolume
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	skrconnection "github.com/kyma-project/cloud-manager/pkg/skr/common/connection"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateConnection mirrors the connection of the KCP NfsInstance into the SKR AwsNfsVolume status
+func updateConnection(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.KcpNfsInstance == nil {
+		return nil, nil
+	}
+
+	awsNfsVolume := state.ObjAsAwsNfsVolume()
+	connection := skrconnection.NfsFromKcp(state.KcpNfsInstance.Status.Connection)
+	if equality.Semantic.DeepEqual(awsNfsVolume.Status.Connection, connection) {
+		return nil, nil
+	}
+
+	awsNfsVolume.Status.Connection = connection
+	return composed.UpdateStatus(awsNfsVolume).
+		ErrorLogMessage("Error updating SKR AwsNfsVolume status with connection").
+		SuccessLogMsg("Updated SKR AwsNfsVolume status with connection").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
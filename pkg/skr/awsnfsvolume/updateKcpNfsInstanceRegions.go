@@ -0,0 +1,39 @@
+package awsnfsvolume
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+)
+
+// updateKcpNfsInstanceRegions updates the additional regions of the KCP NfsInstance when they are changed
+// in the SKR AwsNfsVolume, the KCP NfsInstance provisions the added and deletes the removed ones
+func updateKcpNfsInstanceRegions(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if composed.MarkedForDeletionPredicate(ctx, st) {
+		return nil, nil
+	}
+	if state.KcpNfsInstance == nil || state.KcpNfsInstance.Spec.Instance.Aws == nil {
+		return nil, nil
+	}
+
+	regions := state.ObjAsAwsNfsVolume().Spec.Regions
+	if slices.Equal(regions, state.KcpNfsInstance.Spec.Instance.Aws.Regions) {
+		return nil, nil
+	}
+
+	state.KcpNfsInstance.Spec.Instance.Aws.Regions = regions
+	err := state.KcpCluster.K8sClient().Update(ctx, state.KcpNfsInstance)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error updating KCP NfsInstance regions", composed.StopWithRequeue, ctx)
+	}
+
+	logger.
+		WithValues("regions", regions).
+		Info("Updated KCP NfsInstance regions")
+
+	return nil, nil
+}
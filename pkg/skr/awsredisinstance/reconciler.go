@@ -58,6 +58,7 @@ func (r *reconciler) newAction() composed.Action {
 				actions.AddFinalizer,
 				createKcpRedisInstance,
 				waitKcpStatusUpdate,
+				updateConnection,
 				updateStatus,
 				waitSkrStatusReady,
 				modifyKcpRedisInstance,
@@ -70,6 +71,7 @@ func (r *reconciler) newAction() composed.Action {
 				waitAuthSecretDeleted,
 				deleteKcpRedisInstance,
 				waitKcpRedisInstanceDeleted,
+				composed.CleanupStatusOnDelete(cloudresourcesv1beta1.StateDeleting, nil),
 				actions.RemoveFinalizer,
 			),
 		),
@@ -0,0 +1,31 @@
+package awsredisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	skrconnection "github.com/kyma-project/cloud-manager/pkg/skr/common/connection"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateConnection mirrors the connection of the KCP RedisInstance into the SKR AwsRedisInstance status
+func updateConnection(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.KcpRedisInstance == nil {
+		return nil, nil
+	}
+
+	awsRedisInstance := state.ObjAsAwsRedisInstance()
+	connection := skrconnection.RedisFromKcp(state.KcpRedisInstance.Status.Connection)
+	if equality.Semantic.DeepEqual(awsRedisInstance.Status.Connection, connection) {
+		return nil, nil
+	}
+
+	awsRedisInstance.Status.Connection = connection
+	return composed.UpdateStatus(awsRedisInstance).
+		ErrorLogMessage("Error updating SKR AwsRedisInstance status with connection").
+		SuccessLogMsg("Updated SKR AwsRedisInstance status with connection").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
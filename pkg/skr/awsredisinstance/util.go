@@ -48,10 +48,17 @@ func getAuthSecretAnnotations(awsRedis *cloudresourcesv1beta1.AwsRedisInstance)
 func getAuthSecretData(kcpRedis *cloudcontrolv1beta1.RedisInstance) map[string][]byte {
 	result := map[string][]byte{}
 
-	if len(kcpRedis.Status.PrimaryEndpoint) > 0 {
-		result["primaryEndpoint"] = []byte(kcpRedis.Status.PrimaryEndpoint)
+	// the cluster mode instance has only the configuration endpoint the clients discover the shards from
+	endpoint := kcpRedis.Status.PrimaryEndpoint
+	if len(endpoint) > 0 {
+		result["primaryEndpoint"] = []byte(endpoint)
+	} else if len(kcpRedis.Status.ConfigurationEndpoint) > 0 {
+		endpoint = kcpRedis.Status.ConfigurationEndpoint
+		result["configurationEndpoint"] = []byte(endpoint)
+	}
 
-		splitEndpoint := strings.Split(kcpRedis.Status.PrimaryEndpoint, ":")
+	if len(endpoint) > 0 {
+		splitEndpoint := strings.Split(endpoint, ":")
 		if len(splitEndpoint) >= 2 {
 			host := splitEndpoint[0]
 			port := splitEndpoint[1]
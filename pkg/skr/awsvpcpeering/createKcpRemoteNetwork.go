@@ -40,6 +40,7 @@ func createKcpRemoteNetwork(ctx context.Context, st composed.State) (error, cont
 						Region:       obj.Spec.RemoteRegion,
 						AwsAccountId: obj.Spec.RemoteAccountId,
 						VpcId:        obj.Spec.RemoteVpcId,
+						VpcNameTag:   obj.Spec.VpcNameTag,
 					},
 				},
 			},
@@ -56,6 +56,7 @@ func (r *reconciler) newAction() composed.Action {
 				createKcpRedisInstance,
 				modifyKcpRedisInstance,
 				waitKcpStatusUpdate,
+				updateConnection,
 				updateStatus,
 				waitSkrStatusReady,
 				createAuthSecret,
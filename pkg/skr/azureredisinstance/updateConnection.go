@@ -0,0 +1,31 @@
+package azureredisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	skrconnection "github.com/kyma-project/cloud-manager/pkg/skr/common/connection"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateConnection mirrors the connection of the KCP RedisInstance into the SKR AzureRedisInstance status
+func updateConnection(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.KcpRedisInstance == nil {
+		return nil, nil
+	}
+
+	azureRedisInstance := state.ObjAsAzureRedisInstance()
+	connection := skrconnection.RedisFromKcp(state.KcpRedisInstance.Status.Connection)
+	if equality.Semantic.DeepEqual(azureRedisInstance.Status.Connection, connection) {
+		return nil, nil
+	}
+
+	azureRedisInstance.Status.Connection = connection
+	return composed.UpdateStatus(azureRedisInstance).
+		ErrorLogMessage("Error updating SKR AzureRedisInstance status with connection").
+		SuccessLogMsg("Updated SKR AzureRedisInstance status with connection").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
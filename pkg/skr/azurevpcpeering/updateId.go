@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/common/actions"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
 	"time"
 )
@@ -23,13 +24,7 @@ func updateId(ctx context.Context, st composed.State) (error, context.Context) {
 
 	id := uuid.NewString()
 
-	if obj.Labels == nil {
-		obj.Labels = map[string]string{}
-	}
-
-	obj.Labels[cloudresourcesv1beta1.LabelId] = id
-
-	err := state.UpdateObj(ctx)
+	err := actions.EnsureLabels(ctx, state, map[string]string{cloudresourcesv1beta1.LabelId: id})
 	if err != nil {
 		return composed.LogErrorAndReturn(err, "Error updating SKR AzureVpcPeering with ID label", composed.StopWithRequeue, ctx)
 	}
@@ -0,0 +1,44 @@
+package connection
+
+import (
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+)
+
+// NfsFromKcp returns the SKR status connection mirroring the connection of the KCP NfsInstance,
+// or nil if the KCP NfsInstance has none yet
+func NfsFromKcp(kcpConnection *cloudcontrolv1beta1.NfsConnection) *cloudresourcesv1beta1.NfsConnection {
+	if kcpConnection == nil {
+		return nil
+	}
+	result := &cloudresourcesv1beta1.NfsConnection{
+		Host: kcpConnection.Host,
+		Path: kcpConnection.Path,
+	}
+	for _, mt := range kcpConnection.MountTargets {
+		result.MountTargets = append(result.MountTargets, cloudresourcesv1beta1.NfsMountTarget{
+			Zone:      mt.Zone,
+			IpAddress: mt.IpAddress,
+		})
+	}
+	if len(kcpConnection.MountOptions) > 0 {
+		result.MountOptions = append([]string{}, kcpConnection.MountOptions...)
+	}
+	return result
+}
+
+// RedisFromKcp returns the SKR status connection mirroring the connection of the KCP RedisInstance,
+// or nil if the KCP RedisInstance has none yet
+func RedisFromKcp(kcpConnection *cloudcontrolv1beta1.RedisConnection) *cloudresourcesv1beta1.RedisConnection {
+	if kcpConnection == nil {
+		return nil
+	}
+	return &cloudresourcesv1beta1.RedisConnection{
+		Host:        kcpConnection.Host,
+		Port:        kcpConnection.Port,
+		ReadHost:    kcpConnection.ReadHost,
+		ReadPort:    kcpConnection.ReadPort,
+		Tls:         kcpConnection.Tls,
+		ClusterMode: kcpConnection.ClusterMode,
+	}
+}
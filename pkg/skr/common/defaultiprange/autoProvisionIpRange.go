@@ -0,0 +1,85 @@
+package defaultiprange
+
+import (
+	"context"
+	"fmt"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func autoProvisionDependencies(state State) bool {
+	obj, ok := state.Obj().(ObjWithAutoProvisionDependencies)
+	return ok && obj.GetAutoProvisionDependencies()
+}
+
+// autoProvisionIpRange creates the specified IpRange that does not exist with the empty cidr, so it gets
+// the default cidr of the Scope. To guard against the runaway creation only one IpRange is auto-provisioned
+// per Scope, and the objects specifying another missing IpRange fail with the IpRangeNotFound error.
+func autoProvisionIpRange(ctx context.Context, state State) (error, context.Context) {
+	logger := composed.LoggerFromCtx(ctx)
+	obj := state.ObjAsObjWithIpRangeRef()
+	ipRangeRef := obj.GetIpRangeRef()
+
+	autoProvisioned := &cloudresourcesv1beta1.IpRangeList{}
+	err := state.Cluster().K8sClient().List(ctx, autoProvisioned, client.MatchingLabels{
+		cloudresourcesv1beta1.LabelAutoProvisioned: "true",
+	})
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing auto-provisioned SKR IpRanges", composed.StopWithRequeue, ctx)
+	}
+	if len(autoProvisioned.Items) > 0 {
+		existing := autoProvisioned.Items[0].Name
+		logger.
+			WithValues("autoProvisionedIpRange", existing).
+			Info("Specified SKR IpRange does not exist, and another one is already auto-provisioned")
+		obj.SetState(cloudresourcesv1beta1.StateError)
+		return composed.PatchStatus(obj).
+			SetExclusiveConditions(metav1.Condition{
+				Type:   cloudresourcesv1beta1.ConditionTypeError,
+				Status: metav1.ConditionTrue,
+				Reason: cloudresourcesv1beta1.ConditionReasonIpRangeNotFound,
+				Message: fmt.Sprintf("Specified IpRange %s does not exist, and it can not be auto-provisioned since "+
+					"IpRange %s already is. Only one IpRange is auto-provisioned per Scope", ipRangeRef.Name, existing),
+			}).
+			SuccessLogMsg(fmt.Sprintf("Forgetting SKR %T after specified IpRange can not be auto-provisioned", state.Obj())).
+			ErrorLogMessage(fmt.Sprintf("Error patching SKR %T after specified IpRange can not be auto-provisioned", state.Obj())).
+			Run(ctx, state)
+	}
+
+	skrIpRange := &cloudresourcesv1beta1.IpRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ipRangeRef.Name,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":                   "auto-provisioned-iprange",
+				"app.kubernetes.io/instance":               ipRangeRef.Name,
+				"app.kubernetes.io/component":              "cloud-manager",
+				"app.kubernetes.io/part-of":                "kyma",
+				"app.kubernetes.io/managed-by":             "cloud-manager",
+				cloudresourcesv1beta1.LabelAutoProvisioned: "true",
+				cloudresourcesv1beta1.LabelNfsVolName:      obj.GetName(),
+				cloudresourcesv1beta1.LabelNfsVolNS:        obj.GetNamespace(),
+			},
+		},
+	}
+
+	err = state.Cluster().K8sClient().Create(ctx, skrIpRange)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error creating auto-provisioned SKR IpRange", composed.StopWithRequeue, ctx)
+	}
+
+	logger.Info("Created auto-provisioned SKR IpRange")
+	state.SetSkrIpRange(skrIpRange)
+
+	return composed.PatchStatus(obj).
+		SetCondition(metav1.Condition{
+			Type:    cloudresourcesv1beta1.ConditionTypeDependencyAutoProvisioned,
+			Status:  metav1.ConditionTrue,
+			Reason:  cloudresourcesv1beta1.ConditionReasonIpRangeAutoProvisioned,
+			Message: fmt.Sprintf("Specified IpRange %s did not exist and is auto-provisioned", ipRangeRef.Name),
+		}).
+		ErrorLogMessage(fmt.Sprintf("Error patching SKR %T with DependencyAutoProvisioned condition", state.Obj())).
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,197 @@
+package defaultiprange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type testState struct {
+	composed.State
+	skrIpRange *cloudresourcesv1beta1.IpRange
+}
+
+func (s *testState) GetSkrIpRange() *cloudresourcesv1beta1.IpRange {
+	return s.skrIpRange
+}
+
+func (s *testState) SetSkrIpRange(skrIpRange *cloudresourcesv1beta1.IpRange) {
+	s.skrIpRange = skrIpRange
+}
+
+func (s *testState) ObjAsObjWithIpRangeRef() ObjWithIpRangeRef {
+	return s.Obj().(ObjWithIpRangeRef)
+}
+
+func newTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cloudresourcesv1beta1.AddToScheme(scheme))
+	indexer := func(ref func(client.Object) string) client.IndexerFunc {
+		return func(obj client.Object) []string {
+			return []string{ref(obj)}
+		}
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(objs...).
+		WithIndex(&cloudresourcesv1beta1.AwsNfsVolume{}, cloudresourcesv1beta1.IpRangeField, indexer(func(o client.Object) string {
+			return o.(*cloudresourcesv1beta1.AwsNfsVolume).Spec.IpRange.Name
+		})).
+		WithIndex(&cloudresourcesv1beta1.GcpNfsVolume{}, cloudresourcesv1beta1.IpRangeField, indexer(func(o client.Object) string {
+			return o.(*cloudresourcesv1beta1.GcpNfsVolume).Spec.IpRange.Name
+		})).
+		WithIndex(&cloudresourcesv1beta1.AwsRedisInstance{}, cloudresourcesv1beta1.IpRangeField, indexer(func(o client.Object) string {
+			return o.(*cloudresourcesv1beta1.AwsRedisInstance).Spec.IpRange.Name
+		})).
+		WithIndex(&cloudresourcesv1beta1.GcpRedisInstance{}, cloudresourcesv1beta1.IpRangeField, indexer(func(o client.Object) string {
+			return o.(*cloudresourcesv1beta1.GcpRedisInstance).Spec.IpRange.Name
+		})).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				return nil
+			},
+		}).
+		Build()
+}
+
+func newTestState(clnt client.Client, obj client.Object) *testState {
+	cluster := composed.NewStateCluster(clnt, clnt, nil, clnt.Scheme())
+	return &testState{
+		State: composed.NewStateFactory(cluster).NewState(client.ObjectKeyFromObject(obj), obj),
+	}
+}
+
+func newGcpNfsVolume(name, ipRangeName string, autoProvision bool) *cloudresourcesv1beta1.GcpNfsVolume {
+	return &cloudresourcesv1beta1.GcpNfsVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       name,
+			UID:        types.UID("uid-" + name),
+			Finalizers: []string{cloudresourcesv1beta1.Finalizer},
+		},
+		Spec: cloudresourcesv1beta1.GcpNfsVolumeSpec{
+			IpRange:                   cloudresourcesv1beta1.IpRangeRef{Name: ipRangeName},
+			AutoProvisionDependencies: autoProvision,
+		},
+	}
+}
+
+func TestAutoProvisionIpRange(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), logr.Discard())
+
+	loadIpRange := func(clnt client.Client, name string) (*cloudresourcesv1beta1.IpRange, error) {
+		ipRange := &cloudresourcesv1beta1.IpRange{}
+		err := clnt.Get(ctx, client.ObjectKey{Name: name}, ipRange)
+		return ipRange, err
+	}
+
+	t.Run("specified IpRange is auto-provisioned", func(t *testing.T) {
+		vol := newGcpNfsVolume("vol1", "shared", true)
+		clnt := newTestClient(vol)
+		state := newTestState(clnt, vol)
+
+		err, _ := New()(ctx, state)
+
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+		ipRange, err := loadIpRange(clnt, "shared")
+		assert.NoError(t, err)
+		assert.Equal(t, "true", ipRange.Labels[cloudresourcesv1beta1.LabelAutoProvisioned])
+		assert.Equal(t, "vol1", ipRange.Labels[cloudresourcesv1beta1.LabelNfsVolName])
+		assert.Empty(t, ipRange.Spec.Cidr)
+		assert.Equal(t, "shared", state.GetSkrIpRange().Name)
+		cond := meta.FindStatusCondition(vol.Status.Conditions, cloudresourcesv1beta1.ConditionTypeDependencyAutoProvisioned)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, cloudresourcesv1beta1.ConditionReasonIpRangeAutoProvisioned, cond.Reason)
+		}
+	})
+
+	t.Run("specified IpRange is not auto-provisioned if disabled", func(t *testing.T) {
+		vol := newGcpNfsVolume("vol1", "shared", false)
+		clnt := newTestClient(vol)
+		state := newTestState(clnt, vol)
+
+		err, _ := New()(ctx, state)
+
+		assert.Equal(t, composed.StopAndForget, err)
+		_, err = loadIpRange(clnt, "shared")
+		assert.True(t, apierrors.IsNotFound(err))
+		cond := meta.FindStatusCondition(vol.Status.Conditions, cloudresourcesv1beta1.ConditionTypeError)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, cloudresourcesv1beta1.ConditionReasonIpRangeNotFound, cond.Reason)
+		}
+	})
+
+	t.Run("only one IpRange is auto-provisioned per Scope", func(t *testing.T) {
+		vol1 := newGcpNfsVolume("vol1", "shared", true)
+		vol2 := newGcpNfsVolume("vol2", "other", true)
+		clnt := newTestClient(vol1, vol2)
+
+		err, _ := New()(ctx, newTestState(clnt, vol1))
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+
+		err, _ = New()(ctx, newTestState(clnt, vol2))
+
+		assert.Equal(t, composed.StopAndForget, err)
+		_, err = loadIpRange(clnt, "other")
+		assert.True(t, apierrors.IsNotFound(err))
+		cond := meta.FindStatusCondition(vol2.Status.Conditions, cloudresourcesv1beta1.ConditionTypeError)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, cloudresourcesv1beta1.ConditionReasonIpRangeNotFound, cond.Reason)
+			assert.Contains(t, cond.Message, "IpRange shared already is")
+		}
+	})
+
+	t.Run("auto-provisioned IpRange is deleted with the last object referencing it", func(t *testing.T) {
+		vol1 := newGcpNfsVolume("vol1", "shared", true)
+		vol2 := newGcpNfsVolume("vol2", "shared", true)
+		clnt := newTestClient(vol1, vol2)
+
+		err, _ := New()(ctx, newTestState(clnt, vol1))
+		assert.Equal(t, composed.StopWithRequeueDelay(util.Timing.T1000ms()), err)
+
+		deleteVolume := func(vol *cloudresourcesv1beta1.GcpNfsVolume) {
+			assert.NoError(t, clnt.Delete(ctx, vol))
+			assert.NoError(t, clnt.Get(ctx, client.ObjectKeyFromObject(vol), vol))
+			state := newTestState(clnt, vol)
+			err, _ := New()(ctx, state)
+			assert.NoError(t, err)
+			err, _ = DeleteAutoProvisionedIpRange(ctx, state)
+			assert.NoError(t, err)
+		}
+
+		// still referenced by vol2
+		deleteVolume(vol1)
+		_, err = loadIpRange(clnt, "shared")
+		assert.NoError(t, err)
+
+		// vol1 is gone once its finalizer is removed
+		vol1.Finalizers = nil
+		assert.NoError(t, clnt.Update(ctx, vol1))
+
+		deleteVolume(vol2)
+		_, err = loadIpRange(clnt, "shared")
+		assert.True(t, apierrors.IsNotFound(err))
+
+		// the already deleted auto-provisioned IpRange does not fail the deletion
+		state := newTestState(clnt, vol2)
+		err, _ = New()(ctx, state)
+		assert.NoError(t, err)
+		assert.Nil(t, state.GetSkrIpRange())
+	})
+}
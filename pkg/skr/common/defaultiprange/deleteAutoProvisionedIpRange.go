@@ -0,0 +1,82 @@
+package defaultiprange
+
+import (
+	"context"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeleteAutoProvisionedIpRange deletes the auto-provisioned SKR IpRange of the object marked for deletion,
+// if no other object references it. It must run once the KCP resources of the object are deleted, right
+// before its finalizer is removed.
+func DeleteAutoProvisionedIpRange(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(State)
+	logger := composed.LoggerFromCtx(ctx)
+
+	if !composed.MarkedForDeletionPredicate(ctx, state) {
+		return nil, nil
+	}
+
+	skrIpRange := state.GetSkrIpRange()
+	if skrIpRange == nil ||
+		skrIpRange.Labels[cloudresourcesv1beta1.LabelAutoProvisioned] != "true" ||
+		skrIpRange.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	usedBy, err := ipRangeUsers(ctx, state, skrIpRange.Name)
+	if err != nil {
+		return composed.LogErrorAndReturn(err, "Error listing auto-provisioned SKR IpRange users", composed.StopWithRequeue, ctx)
+	}
+	if len(usedBy) > 0 {
+		logger.
+			WithValues("usedBy", usedBy).
+			Info("Keeping auto-provisioned SKR IpRange used by other objects")
+		return nil, nil
+	}
+
+	err = state.Cluster().K8sClient().Delete(ctx, skrIpRange)
+	if client.IgnoreNotFound(err) != nil {
+		return composed.LogErrorAndReturn(err, "Error deleting auto-provisioned SKR IpRange", composed.StopWithRequeue, ctx)
+	}
+
+	logger.Info("Deleted auto-provisioned SKR IpRange no longer referenced")
+
+	return nil, nil
+}
+
+// ipRangeUsers returns the objects other than the state object referencing the IpRange
+func ipRangeUsers(ctx context.Context, state State, ipRangeName string) ([]string, error) {
+	var result []string
+	for _, list := range []client.ObjectList{
+		&cloudresourcesv1beta1.AwsNfsVolumeList{},
+		&cloudresourcesv1beta1.GcpNfsVolumeList{},
+		&cloudresourcesv1beta1.AwsRedisInstanceList{},
+		&cloudresourcesv1beta1.GcpRedisInstanceList{},
+	} {
+		err := state.Cluster().K8sClient().List(ctx, list, &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(cloudresourcesv1beta1.IpRangeField, ipRangeName),
+		})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = meta.EachListItem(list, func(o runtime.Object) error {
+			user := o.(client.Object)
+			if user.GetUID() != state.Obj().GetUID() {
+				result = append(result, client.ObjectKeyFromObject(user).String())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
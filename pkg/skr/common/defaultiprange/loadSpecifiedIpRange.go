@@ -20,6 +20,13 @@ func loadSpecifiedIpRange(ctx context.Context, st composed.State) (error, contex
 
 	skrIpRange := &cloudresourcesv1beta1.IpRange{}
 	err := state.Cluster().K8sClient().Get(ctx, ipRangeRef.ObjKey(), skrIpRange)
+	if apierrors.IsNotFound(err) && autoProvisionDependencies(state) {
+		if composed.MarkedForDeletionPredicate(ctx, state) {
+			// the auto-provisioned IpRange is already deleted
+			return nil, nil
+		}
+		return autoProvisionIpRange(ctx, state)
+	}
 	if apierrors.IsNotFound(err) {
 		logger.Info("Specified SKR IpRange does not exist")
 		state.ObjAsObjWithIpRangeRef().SetState(cloudresourcesv1beta1.StateError)
@@ -10,6 +10,12 @@ type ObjWithIpRangeRef interface {
 	GetIpRangeRef() cloudresourcesv1beta1.IpRangeRef
 }
 
+// ObjWithAutoProvisionDependencies is implemented by objects that can have their specified IpRange
+// auto-provisioned if it does not exist
+type ObjWithAutoProvisionDependencies interface {
+	GetAutoProvisionDependencies() bool
+}
+
 type State interface {
 	composed.State
 	GetSkrIpRange() *cloudresourcesv1beta1.IpRange
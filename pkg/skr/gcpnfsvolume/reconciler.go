@@ -72,12 +72,15 @@ func (r *Reconciler) newAction() composed.Action {
 		deletePVForNameChange,
 		deletePersistenceVolume,
 		deleteKcpNfsInstance,
+		composed.If(NoKcpNfsInstancePredicate(), defaultiprange.DeleteAutoProvisionedIpRange),
 		removeFinalizer,
 		createPersistenceVolume,
 		modifyPersistenceVolume,
 		createPersistentVolumeClaim,
 		modifyPersistentVolumeClaim,
+		updateConnection,
 		updateStatus,
+		mirrorKcpConditions,
 		composed.StopAndForgetAction,
 	)
 }
@@ -0,0 +1,31 @@
+package gcpnfsvolume
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	skrconnection "github.com/kyma-project/cloud-manager/pkg/skr/common/connection"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateConnection mirrors the connection of the KCP NfsInstance into the SKR GcpNfsVolume status
+func updateConnection(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.KcpNfsInstance == nil {
+		return nil, nil
+	}
+
+	gcpNfsVolume := state.ObjAsGcpNfsVolume()
+	connection := skrconnection.NfsFromKcp(state.KcpNfsInstance.Status.Connection)
+	if equality.Semantic.DeepEqual(gcpNfsVolume.Status.Connection, connection) {
+		return nil, nil
+	}
+
+	gcpNfsVolume.Status.Connection = connection
+	return composed.PatchStatus(gcpNfsVolume).
+		ErrorLogMessage("Error updating SKR GcpNfsVolume status with connection").
+		SuccessLogMsg("Updated SKR GcpNfsVolume status with connection").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
@@ -0,0 +1,66 @@
+package gcpnfsvolume
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
+	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type updateConnectionSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *updateConnectionSuite) SetupTest() {
+	suite.ctx = log.IntoContext(context.Background(), logr.Discard())
+}
+
+func (suite *updateConnectionSuite) TestConnectionIsMirrored() {
+	factory, err := newTestStateFactory()
+	assert.Nil(suite.T(), err)
+
+	state := factory.newState()
+	kcpNfsInstance := gcpNfsInstance.DeepCopy()
+	kcpNfsInstance.Status.Connection = &cloudcontrolv1beta1.NfsConnection{
+		Host:         "10.20.30.40",
+		Path:         "/vol1",
+		MountOptions: []string{"nfsvers=3"},
+	}
+	state.KcpNfsInstance = kcpNfsInstance
+
+	err, _ = updateConnection(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+
+	nfsVol := &cloudresourcesv1beta1.GcpNfsVolume{}
+	err = factory.skrCluster.K8sClient().Get(suite.ctx,
+		types.NamespacedName{Name: gcpNfsVolume.Name, Namespace: gcpNfsVolume.Namespace}, nfsVol)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &cloudresourcesv1beta1.NfsConnection{
+		Host:         "10.20.30.40",
+		Path:         "/vol1",
+		MountOptions: []string{"nfsvers=3"},
+	}, nfsVol.Status.Connection)
+}
+
+func (suite *updateConnectionSuite) TestNoKcpConnection() {
+	factory, err := newTestStateFactory()
+	assert.Nil(suite.T(), err)
+
+	state := factory.newState()
+	state.KcpNfsInstance = &gcpNfsInstance
+
+	err, _ = updateConnection(suite.ctx, state)
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), state.ObjAsGcpNfsVolume().Status.Connection)
+}
+
+func TestUpdateConnection(t *testing.T) {
+	suite.Run(t, new(updateConnectionSuite))
+}
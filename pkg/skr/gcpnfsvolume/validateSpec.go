@@ -103,7 +103,8 @@ func validateIpRange(ctx context.Context, st composed.State) (error, context.Con
 
 	state := st.(*State)
 	ipRangeName := state.ObjAsGcpNfsVolume().Spec.IpRange
-	if ipRangeName.Name == "" {
+	if ipRangeName.Name == "" || state.ObjAsGcpNfsVolume().Spec.AutoProvisionDependencies {
+		// the missing IpRange is auto-provisioned by the defaultiprange flow
 		return nil, nil
 	}
 	ipRange := &cloudresourcesv1beta1.IpRange{}
@@ -59,6 +59,7 @@ func (r *reconciler) newAction() composed.Action {
 				createKcpRedisInstance,
 				modifyKcpRedisInstance,
 				waitKcpStatusUpdate,
+				updateConnection,
 				updateStatus,
 				waitSkrStatusReady,
 				createAuthSecret,
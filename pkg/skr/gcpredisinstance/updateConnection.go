@@ -0,0 +1,31 @@
+package gcpredisinstance
+
+import (
+	"context"
+
+	"github.com/kyma-project/cloud-manager/pkg/composed"
+	skrconnection "github.com/kyma-project/cloud-manager/pkg/skr/common/connection"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateConnection mirrors the connection of the KCP RedisInstance into the SKR GcpRedisInstance status
+func updateConnection(ctx context.Context, st composed.State) (error, context.Context) {
+	state := st.(*State)
+
+	if state.KcpRedisInstance == nil {
+		return nil, nil
+	}
+
+	gcpRedisInstance := state.ObjAsGcpRedisInstance()
+	connection := skrconnection.RedisFromKcp(state.KcpRedisInstance.Status.Connection)
+	if equality.Semantic.DeepEqual(gcpRedisInstance.Status.Connection, connection) {
+		return nil, nil
+	}
+
+	gcpRedisInstance.Status.Connection = connection
+	return composed.UpdateStatus(gcpRedisInstance).
+		ErrorLogMessage("Error updating SKR GcpRedisInstance status with connection").
+		SuccessLogMsg("Updated SKR GcpRedisInstance status with connection").
+		SuccessErrorNil().
+		Run(ctx, state)
+}
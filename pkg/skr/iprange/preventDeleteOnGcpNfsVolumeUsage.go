@@ -60,7 +60,7 @@ func preventDeleteOnGcpNfsVolumeUsage(ctx context.Context, st composed.State) (e
 		}).
 		DeriveStateFromConditions(state.MapConditionToState()).
 		ErrorLogMessage("Error updating IpRange status with Warning condition for delete while in use").
-		SuccessLogMsg("Forgetting SKR IpRange marked for deleting that is in use").
-		SuccessError(composed.StopAndForget).
+		SuccessLogMsg("Stopping SKR IpRange marked for deleting that is in use").
+		SuccessError(state.DeleteWhileUsedError()).
 		Run(ctx, state)
 }
@@ -4,6 +4,7 @@ import (
 	cloudcontrolv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-control/v1beta1"
 	cloudresourcesv1beta1 "github.com/kyma-project/cloud-manager/api/cloud-resources/v1beta1"
 	"github.com/kyma-project/cloud-manager/pkg/composed"
+	"github.com/kyma-project/cloud-manager/pkg/util"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -48,6 +49,15 @@ func (s *State) ObjAsIpRange() *cloudresourcesv1beta1.IpRange {
 	return s.Obj().(*cloudresourcesv1beta1.IpRange)
 }
 
+// DeleteWhileUsedError returns the result of the IpRange marked for deletion while used. The auto-provisioned
+// IpRange is deleted by its last user just before that user is gone, so it is requeued instead of forgotten.
+func (s *State) DeleteWhileUsedError() error {
+	if s.ObjAsIpRange().Labels[cloudresourcesv1beta1.LabelAutoProvisioned] == "true" {
+		return composed.StopWithRequeueDelay(util.Timing.T10000ms())
+	}
+	return composed.StopAndForget
+}
+
 func (s *State) MapConditionToState() (f func(obj composed.ObjWithConditions) (string, bool)) {
 	return func(obj composed.ObjWithConditions) (string, bool) {
 		if obj == nil || len(*obj.Conditions()) == 0 {
@@ -45,6 +45,17 @@ func WithKcpIpRangeSpecCidr(cidr string) ObjAction {
 	}
 }
 
+func WithKcpIpRangeSpecCidrs(cidrs ...string) ObjAction {
+	return &objAction{
+		f: func(obj client.Object) {
+			x := obj.(*cloudcontrolv1beta1.IpRange)
+			if len(x.Spec.Cidrs) == 0 {
+				x.Spec.Cidrs = cidrs
+			}
+		},
+	}
+}
+
 func WithKcpIpRangeStatusCidr(cidr string) ObjStatusAction {
 	return &objStatusAction{
 		f: func(obj client.Object) {
@@ -38,3 +38,10 @@ func CidrParseIPnPrefix(cidr string) (string, int, error) {
 	prefix, _ := ipnet.Mask.Size()
 	return ip.String(), prefix, nil
 }
+
+// CidrContains returns true if the child network is entirely within the parent network
+func CidrContains(parent, child *net.IPNet) bool {
+	parentOnes, _ := parent.Mask.Size()
+	childOnes, _ := child.Mask.Size()
+	return parentOnes <= childOnes && parent.Contains(child.IP)
+}